@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var demoteCmd = &cobra.Command{
+	Use:   "demote [email]",
+	Short: "Remove the admin role from a user",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		email := args[0]
+
+		// Validate email format
+		if email == "" {
+			return fmt.Errorf("email cannot be empty")
+		}
+
+		// Initialize database connection
+		logger.Info("Connecting to database...")
+		if err := database.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer database.Close()
+
+		return demoteUser(cmd.Context(), email)
+	},
+}
+
+// demoteUser removes the admin role from the user with the given email,
+// refusing to remove it from the last admin
+func demoteUser(ctx context.Context, email string) error {
+	// Find user by email
+	var user models.User
+	result := database.DB.Preload("Roles").Where("email = ?", helpers.NormalizeEmail(email)).First(&user)
+	if result.Error != nil {
+		return fmt.Errorf("user with email '%s' not found", email)
+	}
+
+	if !user.HasRole("admin") {
+		return fmt.Errorf("user with email '%s' does not have the admin role", email)
+	}
+
+	rbacService := services.NewRBACService()
+
+	// Keep at least one admin who can still sign in and manage the others
+	otherAdmins, err := rbacService.CountOtherUsersWithRole(ctx, "admin", user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count admins: %w", err)
+	}
+	if otherAdmins == 0 {
+		return fmt.Errorf("cannot demote '%s': they are the last admin", email)
+	}
+
+	if err := rbacService.RemoveRoleFromUser(ctx, user.ID, "admin"); err != nil {
+		return fmt.Errorf("failed to remove admin role: %w", err)
+	}
+
+	logger.Info("Successfully demoted user from admin role", "name", user.Name, "email", user.Email)
+	return nil
+}
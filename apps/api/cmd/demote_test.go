@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"api/internal/services"
+
+	"github.com/google/uuid"
+)
+
+// withTestTransaction points the database handles at a transaction that is
+// rolled back when the test ends, so the test can change the set of admins
+func withTestTransaction(t *testing.T) {
+	t.Helper()
+	if os.Getenv("SKIP_DB_TESTS") == "true" {
+		t.Skip("Skipping database test")
+	}
+
+	if err := database.Connect(); err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	primary, replica := database.DB, database.ReadDB
+	tx := primary.Begin()
+
+	database.DB, database.ReadDB = tx, tx
+	t.Cleanup(func() {
+		tx.Rollback()
+		database.DB, database.ReadDB = primary, replica
+		database.Close()
+	})
+}
+
+func createAdmin(t *testing.T) models.User {
+	t.Helper()
+	user := models.User{
+		Email:    "demote-" + uuid.New().String()[:8] + "@example.com",
+		Password: "not-a-real-hash",
+		Name:     "Demote Test",
+	}
+	if err := database.DB.Create(&user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := services.NewRBACService().AssignRoleToUser(context.Background(), user.ID, "admin", nil, nil); err != nil {
+		t.Fatalf("failed to assign admin role: %v", err)
+	}
+	return user
+}
+
+func TestDemoteUser(t *testing.T) {
+	withTestTransaction(t)
+
+	// Start from exactly two admins
+	if err := database.DB.Exec("DELETE FROM user_roles WHERE role_id = (SELECT id FROM roles WHERE name = 'admin')").Error; err != nil {
+		t.Fatalf("failed to clear admins: %v", err)
+	}
+	first, second := createAdmin(t), createAdmin(t)
+
+	if err := demoteUser(context.Background(), strings.ToUpper(first.Email)); err != nil {
+		t.Fatalf("demoteUser() error = %v", err)
+	}
+	roles, err := services.NewRBACService().GetUserRoles(context.Background(), first.ID)
+	if err != nil {
+		t.Fatalf("GetUserRoles() error = %v", err)
+	}
+	if len(roles) != 0 {
+		t.Errorf("roles after demotion = %v, want none", roles)
+	}
+
+	if err := demoteUser(context.Background(), first.Email); err == nil || !strings.Contains(err.Error(), "does not have the admin role") {
+		t.Errorf("demoting a non-admin: error = %v", err)
+	}
+
+	if err := demoteUser(context.Background(), second.Email); err == nil || !strings.Contains(err.Error(), "last admin") {
+		t.Errorf("demoting the last admin: error = %v", err)
+	}
+
+	// Admins who are deactivated, deleted or whose role expired do not count
+	inactive, deleted, expired := createAdmin(t), createAdmin(t), createAdmin(t)
+	if err := database.DB.Model(&inactive).Update("is_active", false).Error; err != nil {
+		t.Fatalf("failed to deactivate admin: %v", err)
+	}
+	if err := database.DB.Delete(&deleted).Error; err != nil {
+		t.Fatalf("failed to delete admin: %v", err)
+	}
+	if err := database.DB.Exec("UPDATE user_roles SET expires_at = ? WHERE user_id = ?", time.Now().Add(-time.Hour), expired.ID).Error; err != nil {
+		t.Fatalf("failed to expire admin role: %v", err)
+	}
+	if err := demoteUser(context.Background(), second.Email); err == nil || !strings.Contains(err.Error(), "last admin") {
+		t.Errorf("demoting the last active admin: error = %v", err)
+	}
+
+	if err := demoteUser(context.Background(), "missing-"+uuid.New().String()+"@example.com"); err == nil {
+		t.Error("demoting an unknown user should fail")
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+
+	"api/internal/database"
+	"api/internal/logger"
+	"api/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var expireIdempotencyKeysCmd = &cobra.Command{
+	Use:   "expire-idempotency-keys",
+	Short: "Purge idempotency keys older than 24 hours",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize database connection
+		logger.Info("Connecting to database...")
+		if err := database.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer database.Close()
+
+		idempotencyService := services.NewIdempotencyService()
+		purged, err := idempotencyService.PurgeExpiredIdempotencyKeys()
+		if err != nil {
+			return fmt.Errorf("failed to purge expired idempotency keys: %w", err)
+		}
+
+		logger.Info("Expired idempotency keys purged", "count", purged)
+		return nil
+	},
+}
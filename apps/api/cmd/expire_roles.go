@@ -0,0 +1,32 @@
+package api
+
+import (
+	"fmt"
+
+	"api/internal/database"
+	"api/internal/logger"
+	"api/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var expireRolesCmd = &cobra.Command{
+	Use:   "expire-roles",
+	Short: "Purge expired role assignments",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Initialize database connection
+		logger.Info("Connecting to database...")
+		if err := database.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer database.Close()
+
+		rbacService := services.NewRBACService()
+		purged, err := rbacService.PurgeExpiredRoleAssignments(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("failed to purge expired role assignments: %w", err)
+		}
+
+		logger.Info("Expired role assignments purged", "count", purged)
+		return nil
+	},
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"api/internal/logger"
+	"api/internal/openapi"
+	"api/internal/server"
+	"github.com/spf13/cobra"
+)
+
+var (
+	openAPIOutput      string
+	openAPIFormat      string
+	openAPIHandlersDir string
+	openAPICheck       bool
+)
+
+var generateOpenAPICmd = &cobra.Command{
+	Use:   "generate-openapi",
+	Short: "Generate the OpenAPI specification from the registered routes",
+	Long:  "Generate the OpenAPI 3.0 specification from the registered routes, the dto types and @openapi handler annotations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format := openAPIFormat
+		if format == "" {
+			format = "json"
+			if ext := filepath.Ext(openAPIOutput); ext == ".yaml" || ext == ".yml" {
+				format = "yaml"
+			}
+		}
+
+		// Always include the documentation routes so the spec does not depend on ENV
+		config := server.DefaultRouterConfig()
+		config.EnableDocs = true
+		app := server.NewRouterWithConfig(config)
+
+		document, err := openapi.Generate(app, openapi.Options{
+			Title:       defaultService,
+			Version:     defaultVersion,
+			HandlersDir: openAPIHandlersDir,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+		}
+		spec, err := openapi.Marshal(document, format)
+		if err != nil {
+			return err
+		}
+
+		if openAPICheck {
+			current, err := os.ReadFile(openAPIOutput)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", openAPIOutput, err)
+			}
+			if !bytes.Equal(current, spec) {
+				logger.Error("OpenAPI spec is stale, run make openapi", "path", openAPIOutput)
+				return fmt.Errorf("%s is stale", openAPIOutput)
+			}
+			logger.Info("OpenAPI spec is up to date", "path", openAPIOutput)
+			return nil
+		}
+
+		if err := os.WriteFile(openAPIOutput, spec, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", openAPIOutput, err)
+		}
+		logger.Info("OpenAPI spec written", "path", openAPIOutput, "paths", len(document.Paths))
+		return nil
+	},
+}
+
+func init() {
+	generateOpenAPICmd.Flags().StringVarP(&openAPIOutput, "output", "o", "internal/openapi/openapi.json", "File to write the specification to")
+	generateOpenAPICmd.Flags().StringVarP(&openAPIFormat, "format", "f", "", "Output format: json or yaml (default from the output extension)")
+	generateOpenAPICmd.Flags().StringVar(&openAPIHandlersDir, "handlers", "internal/handlers", "Directory containing the annotated handlers")
+	generateOpenAPICmd.Flags().BoolVar(&openAPICheck, "check", false, "Fail if the output file differs from the generated specification")
+}
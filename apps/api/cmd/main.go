@@ -1,13 +1,19 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"api/internal/auth"
+	"api/internal/config"
 	"api/internal/database"
 	"api/internal/helpers"
 	"api/internal/logger"
 	"api/internal/server"
+	"api/internal/services"
+	"api/internal/session"
+	"api/internal/tracing"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cobra"
 )
@@ -19,8 +25,9 @@ const (
 )
 
 var (
-	port    int
-	version string
+	port       int
+	version    string
+	configPath string
 )
 
 var rootCmd = &cobra.Command{
@@ -36,6 +43,22 @@ var serverCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the API server",
 	Run: func(cmd *cobra.Command, args []string) {
+		if err := loadConfigFile(cmd); err != nil {
+			logger.Fatal("Failed to load config file", "error", err)
+		}
+		if err := config.Validate(); err != nil {
+			logger.Fatal("Failed to start server", "error", err)
+		}
+		bcryptCost, err := auth.ValidateBcryptCost()
+		if err != nil {
+			logger.Fatal("Failed to start server", "error", err)
+		}
+		logger.Info("Password hashing", "bcrypt_cost", bcryptCost)
+
+		if err := tracing.Setup(defaultService, version); err != nil {
+			logger.Fatal("Failed to set up tracing", "error", err)
+		}
+
 		// Initialize database connection
 		logger.Info("Connecting to database...")
 		if err := database.Connect(); err != nil {
@@ -43,9 +66,26 @@ var serverCmd = &cobra.Command{
 		}
 		defer database.Close()
 
+		if err := session.Init(); err != nil {
+			logger.Fatal("Failed to set up session store", "error", err)
+		}
+
+		defaultRole, err := services.NewSystemSettingService().ValidateDefaultUserRole(cmd.Context())
+		if err != nil {
+			logger.Fatal("Invalid default user role", "error", err)
+		}
+		logger.Info("Default user role", "role", defaultRole)
+
+		defer services.CloseEmailQueue()
+		defer services.WaitForWebhookDeliveries()
+
 		// Start server
 		config := server.Config{
-			Port: port,
+			Port:            port,
+			Service:         defaultService,
+			Version:         version,
+			ShutdownTimeout: helpers.GetEnvDuration("SHUTDOWN_TIMEOUT", server.DefaultShutdownTimeout),
+			CleanupInterval: helpers.GetEnvDuration("PASSWORD_RESET_CLEANUP_INTERVAL", services.DefaultCleanupInterval),
 		}
 
 		srv := server.New(config)
@@ -63,6 +103,32 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// loadConfigFile applies the config file given with --config. The default
+// config.yaml is optional; a path given explicitly must exist. Settings from
+// the file only fill in environment variables that are not set.
+func loadConfigFile(cmd *cobra.Command) error {
+	if !cmd.Flags().Changed("config") {
+		if _, err := os.Stat(configPath); errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+	}
+
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		return err
+	}
+	config.Use(cfg)
+	logger.Logger = logger.New()
+	logger.Info("Loaded config file", "path", configPath)
+
+	// Flag defaults were read from the environment before the file was loaded
+	if !cmd.Flags().Changed("port") {
+		port = helpers.GetEnvInt("PORT", defaultPort)
+	}
+	version = helpers.GetEnv("SERVICE_VERSION", defaultVersion)
+	return nil
+}
+
 func init() {
 	// Load .env file if it exists
 	if err := godotenv.Load(); err != nil {
@@ -78,9 +144,15 @@ func init() {
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.AddCommand(migrateCmd)
 	rootCmd.AddCommand(promoteCmd)
+	rootCmd.AddCommand(demoteCmd)
+	rootCmd.AddCommand(seedCmd)
+	rootCmd.AddCommand(expireRolesCmd)
+	rootCmd.AddCommand(expireIdempotencyKeysCmd)
+	rootCmd.AddCommand(generateOpenAPICmd)
 
 	// Add flags
 	serverCmd.Flags().IntVarP(&port, "port", "p", envPort, "Port to run the server on")
+	serverCmd.Flags().StringVarP(&configPath, "config", "c", "config.yaml", "Path to a YAML config file")
 	versionCmd.Flags().StringVarP(&version, "version", "v", envVersion, "Service version")
 
 	// Set version for use in version command
@@ -89,8 +161,8 @@ func init() {
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		// Show help for unknown commands
-		fmt.Fprintf(os.Stderr, "Error: unknown command\n\n")
+		// Show the error followed by help
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
 		rootCmd.Help()
 		os.Exit(1)
 	}
@@ -50,13 +50,13 @@ var promoteCmd = &cobra.Command{
 		rbacService := services.NewRBACService()
 
 		// Check if admin role exists
-		adminRole, err := rbacService.GetRoleByName("admin")
+		adminRole, err := rbacService.GetRoleByName(cmd.Context(), "admin")
 		if err != nil {
 			return fmt.Errorf("admin role not found: %w", err)
 		}
 
 		// Assign admin role to user
-		if err := rbacService.AssignRoleToUser(user.ID, adminRole.Name, nil); err != nil {
+		if err := rbacService.AssignRoleToUser(cmd.Context(), user.ID, adminRole.Name, nil, nil); err != nil {
 			return fmt.Errorf("failed to assign admin role: %w", err)
 		}
 
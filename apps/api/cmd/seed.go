@@ -0,0 +1,52 @@
+package api
+
+import (
+	"fmt"
+
+	"api/internal/database"
+	"api/internal/logger"
+	"api/internal/services"
+	"github.com/spf13/cobra"
+)
+
+var seedCmd = &cobra.Command{
+	Use:   "seed [file]",
+	Short: "Create roles and permissions from a YAML seed file",
+	Long:  "Create the roles, permissions and role permission assignments in a YAML seed file, skipping any that already exist. Defaults to " + services.DefaultSeedFile + ".",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := services.DefaultSeedFile
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		// Initialize database connection
+		logger.Info("Connecting to database...")
+		if err := database.Connect(); err != nil {
+			return fmt.Errorf("failed to connect to database: %w", err)
+		}
+		defer database.Close()
+
+		summary, err := seed(path)
+		if err != nil {
+			return err
+		}
+
+		logger.Info("Seed complete: "+summary.String(), "file", path)
+		return nil
+	},
+}
+
+// seed loads the seed file at path and applies it to the database
+func seed(path string) (*services.SeedSummary, error) {
+	data, err := services.LoadSeedFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := services.NewSeedService().Seed(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed database: %w", err)
+	}
+	return summary, nil
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"api/internal/services"
+
+	"github.com/google/uuid"
+)
+
+func TestSeedIsIdempotent(t *testing.T) {
+	withTestTransaction(t)
+
+	suffix := uuid.New().String()[:8]
+	content := strings.ReplaceAll(`permissions:
+  - name: reports-SUFFIX.read
+    resource: reports-SUFFIX
+    action: read
+  - name: reports-SUFFIX.write
+    resource: reports-SUFFIX
+    action: write
+  - name: profile.read
+    resource: profile
+    action: read
+roles:
+  - name: reporter-SUFFIX
+    description: Reads and writes reports
+    permissions:
+      - reports-SUFFIX.read
+      - reports-SUFFIX.write
+      - profile.read
+`, "SUFFIX", suffix)
+	path := filepath.Join(t.TempDir(), "seed.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// profile.read already exists from the initial migration
+	first, err := seed(path)
+	if err != nil {
+		t.Fatalf("first seed() error = %v", err)
+	}
+	if want := (services.SeedSummary{Roles: 1, Permissions: 2, Assignments: 3}); *first != want {
+		t.Errorf("first seed() = %s, want %s", first, want)
+	}
+
+	second, err := seed(path)
+	if err != nil {
+		t.Fatalf("second seed() error = %v", err)
+	}
+	if *second != (services.SeedSummary{}) {
+		t.Errorf("second seed() = %s, want nothing created", second)
+	}
+}
+
+func TestSeedRejectsUnknownPermission(t *testing.T) {
+	withTestTransaction(t)
+
+	content := "roles:\n  - name: broken-" + uuid.New().String()[:8] + "\n    permissions: [missing-" + uuid.New().String()[:8] + ".read]\n"
+	path := filepath.Join(t.TempDir(), "seed.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := seed(path); err == nil || !strings.Contains(err.Error(), "unknown permission") {
+		t.Errorf("seed() error = %v, want unknown permission", err)
+	}
+}
@@ -0,0 +1,21 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+// APIKeyPrefix marks API keys so they are recognisable in logs and secret scanners
+const APIKeyPrefix = "sk_"
+
+// GenerateAPIKey returns a new random API key and the hash to store for it
+func GenerateAPIKey() (string, string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", fmt.Errorf("failed to generate random key: %w", err)
+	}
+
+	key := APIKeyPrefix + hex.EncodeToString(bytes)
+	return key, HashToken(key), nil
+}
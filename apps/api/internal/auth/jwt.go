@@ -1,48 +1,172 @@
 package auth
 
 import (
+	"api/internal/config"
+	"api/internal/helpers"
+	"api/internal/session"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"math/big"
 	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
+// Supported JWT_ALGORITHM values
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// ImpersonationTokenExpiration is how long an impersonation token is valid
+const ImpersonationTokenExpiration = 15 * time.Minute
+
 type Claims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
+	// ImpersonatedBy is the ID of the admin acting as the user; empty for
+	// tokens issued to the user themselves
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID string, email string) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", errors.New("JWT_SECRET environment variable is not set")
+// rsaKeys caches the RSA key pair loaded from JWT_PRIVATE_KEY_PATH and
+// JWT_PUBLIC_KEY_PATH, reloading it when the paths change
+var rsaKeys struct {
+	sync.Mutex
+	privatePath string
+	publicPath  string
+	private     *rsa.PrivateKey
+	public      *rsa.PublicKey
+	kid         string
+}
+
+// Algorithm returns the configured JWT signing algorithm, HS256 by default
+func Algorithm() (string, error) {
+	algorithm := strings.ToUpper(config.Get("JWT_ALGORITHM"))
+	switch algorithm {
+	case "", AlgorithmHS256:
+		return AlgorithmHS256, nil
+	case AlgorithmRS256:
+		return AlgorithmRS256, nil
+	default:
+		return "", fmt.Errorf("unsupported JWT_ALGORITHM %q", algorithm)
+	}
+}
+
+// GenerateToken issues a token for the user, valid for overrideDuration when
+// it is non-nil and for JWT_EXPIRATION otherwise. The token's jti is recorded
+// as a session for device, revoking the user's oldest sessions beyond
+// MAX_CONCURRENT_SESSIONS.
+func GenerateToken(userID string, email string, overrideDuration *time.Duration, device session.Device) (string, error) {
+	expiration := DefaultTokenExpiration()
+	if overrideDuration != nil {
+		expiration = *overrideDuration
 	}
 
-	expirationStr := os.Getenv("JWT_EXPIRATION")
-	if expirationStr == "" {
-		expirationStr = "24h"
+	claims := Claims{
+		UserID:           userID,
+		Email:            email,
+		RegisteredClaims: registeredClaims(expiration),
 	}
+	claims.ID = uuid.New().String()
 
-	expiration, err := time.ParseDuration(expirationStr)
+	token, err := signToken(claims)
 	if err != nil {
-		expiration = 24 * time.Hour
+		return "", err
 	}
 
-	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
+	err = session.Sessions().Add(session.Session{
+		ID:        claims.ID,
+		UserID:    userID,
+		UserAgent: device.UserAgent,
+		IPAddress: device.IPAddress,
+		CreatedAt: time.Now(),
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, session.MaxConcurrent())
+	if err != nil {
+		return "", fmt.Errorf("failed to record session: %w", err)
+	}
+
+	return token, nil
+}
+
+// DefaultTokenExpiration returns the token lifetime configured with
+// JWT_EXPIRATION, 24 hours by default
+func DefaultTokenExpiration() time.Duration {
+	expiration, err := time.ParseDuration(config.Get("JWT_EXPIRATION"))
+	if err != nil {
+		return 24 * time.Hour
+	}
+	return expiration
+}
+
+// MaxTokenExpiry returns the longest per-user token lifetime an admin may set,
+// MAX_TOKEN_EXPIRY_SECONDS or 30 days by default
+func MaxTokenExpiry() time.Duration {
+	return time.Duration(helpers.GetEnvInt("MAX_TOKEN_EXPIRY_SECONDS", 30*24*60*60)) * time.Second
+}
+
+// GenerateImpersonationToken returns a short-lived token that authenticates
+// as the user while recording the admin who requested it
+func GenerateImpersonationToken(userID, email, impersonatedBy string) (string, error) {
+	return signToken(Claims{
+		UserID:           userID,
+		Email:            email,
+		ImpersonatedBy:   impersonatedBy,
+		RegisteredClaims: registeredClaims(ImpersonationTokenExpiration),
+	})
+}
+
+// registeredClaims returns the standard claims of a token issued now and
+// valid for expiration
+func registeredClaims(expiration time.Duration) jwt.RegisteredClaims {
+	now := time.Now()
+	return jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+	}
+}
+
+// signToken signs claims with the configured algorithm
+func signToken(claims Claims) (string, error) {
+	algorithm, err := Algorithm()
+	if err != nil {
+		return "", err
+	}
+
+	var token *jwt.Token
+	var key interface{}
+	switch algorithm {
+	case AlgorithmRS256:
+		privateKey, _, kid, err := loadRSAKeys()
+		if err != nil {
+			return "", err
+		}
+		if privateKey == nil {
+			return "", errors.New("JWT_PRIVATE_KEY_PATH environment variable is not set")
+		}
+		token = jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = kid
+		key = privateKey
+	default:
+		secret := config.Get("JWT_SECRET")
+		if secret == "" {
+			return "", errors.New("JWT_SECRET environment variable is not set")
+		}
+		token = jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		key = []byte(secret)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(secret))
+	tokenString, err := token.SignedString(key)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %w", err)
 	}
@@ -51,18 +175,38 @@ func GenerateToken(userID string, email string) (string, error) {
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return nil, errors.New("JWT_SECRET environment variable is not set")
+	algorithm, err := Algorithm()
+	if err != nil {
+		return nil, err
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	var keyFunc jwt.Keyfunc
+	switch algorithm {
+	case AlgorithmRS256:
+		_, publicKey, _, err := loadRSAKeys()
+		if err != nil {
+			return nil, err
 		}
-		return []byte(secret), nil
-	})
+		keyFunc = func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return publicKey, nil
+		}
+	default:
+		secret := config.Get("JWT_SECRET")
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET environment variable is not set")
+		}
+		keyFunc = func(token *jwt.Token) (interface{}, error) {
+			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			}
+			return []byte(secret), nil
+		}
+	}
 
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, keyFunc, jwt.WithValidMethods([]string{algorithm}))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
@@ -74,3 +218,84 @@ func ValidateToken(tokenString string) (*Claims, error) {
 	return nil, fmt.Errorf("invalid token")
 }
 
+// PublicKey returns the RSA public key that verifies tokens and its key ID.
+// It returns a nil key when tokens are signed with a shared secret.
+func PublicKey() (*rsa.PublicKey, string, error) {
+	algorithm, err := Algorithm()
+	if err != nil || algorithm != AlgorithmRS256 {
+		return nil, "", err
+	}
+
+	_, publicKey, kid, err := loadRSAKeys()
+	return publicKey, kid, err
+}
+
+// loadRSAKeys returns the configured RSA keys. The public key is derived from
+// the private key when JWT_PUBLIC_KEY_PATH is not set; the private key is nil
+// when only a public key is configured.
+func loadRSAKeys() (*rsa.PrivateKey, *rsa.PublicKey, string, error) {
+	privatePath := config.Get("JWT_PRIVATE_KEY_PATH")
+	publicPath := config.Get("JWT_PUBLIC_KEY_PATH")
+
+	rsaKeys.Lock()
+	defer rsaKeys.Unlock()
+
+	if rsaKeys.public != nil && rsaKeys.privatePath == privatePath && rsaKeys.publicPath == publicPath {
+		return rsaKeys.private, rsaKeys.public, rsaKeys.kid, nil
+	}
+
+	if privatePath == "" && publicPath == "" {
+		return nil, nil, "", errors.New("JWT_PRIVATE_KEY_PATH or JWT_PUBLIC_KEY_PATH must be set for RS256")
+	}
+
+	var privateKey *rsa.PrivateKey
+	var publicKey *rsa.PublicKey
+	if privatePath != "" {
+		data, err := os.ReadFile(privatePath)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to read JWT private key: %w", err)
+		}
+		privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to parse JWT private key: %w", err)
+		}
+		publicKey = &privateKey.PublicKey
+	}
+
+	if publicPath != "" {
+		data, err := os.ReadFile(publicPath)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to read JWT public key: %w", err)
+		}
+		publicKey, err = jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to parse JWT public key: %w", err)
+		}
+		if privateKey != nil && !privateKey.PublicKey.Equal(publicKey) {
+			return nil, nil, "", errors.New("JWT public key does not match the private key")
+		}
+	}
+
+	rsaKeys.privatePath = privatePath
+	rsaKeys.publicPath = publicPath
+	rsaKeys.private = privateKey
+	rsaKeys.public = publicKey
+	rsaKeys.kid = keyID(publicKey)
+
+	return privateKey, publicKey, rsaKeys.kid, nil
+}
+
+// keyID returns the RFC 7638 JWK thumbprint of an RSA public key
+func keyID(key *rsa.PublicKey) string {
+	n, e := EncodeRSAPublicKey(key)
+	sum := sha256.Sum256([]byte(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// EncodeRSAPublicKey returns the base64url-encoded modulus and exponent of key
+// as used in a JWK
+func EncodeRSAPublicKey(key *rsa.PublicKey) (n, e string) {
+	n = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	return n, e
+}
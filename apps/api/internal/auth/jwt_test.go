@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"api/internal/session"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeRSAKeyPair generates an RSA key pair and writes it as PEM files,
+// returning their paths
+func writeRSAKeyPair(t *testing.T) (privatePath, publicPath string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+
+	dir := t.TempDir()
+	privatePath = filepath.Join(dir, "private.pem")
+	publicPath = filepath.Join(dir, "public.pem")
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	if err := os.WriteFile(privatePath, privatePEM, 0o600); err != nil {
+		t.Fatalf("failed to write private key: %v", err)
+	}
+	if err := os.WriteFile(publicPath, publicPEM, 0o644); err != nil {
+		t.Fatalf("failed to write public key: %v", err)
+	}
+	return privatePath, publicPath
+}
+
+func useRS256(t *testing.T) {
+	t.Helper()
+
+	privatePath, publicPath := writeRSAKeyPair(t)
+	t.Setenv("JWT_ALGORITHM", "RS256")
+	t.Setenv("JWT_PRIVATE_KEY_PATH", privatePath)
+	t.Setenv("JWT_PUBLIC_KEY_PATH", publicPath)
+}
+
+func requireRoundTrip(t *testing.T, wantAlg string) string {
+	t.Helper()
+
+	token, err := GenerateToken("user-1", "user@example.com", nil, session.Device{})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	if err != nil {
+		t.Fatalf("failed to decode token: %v", err)
+	}
+	if parsed.Method.Alg() != wantAlg {
+		t.Errorf("alg = %s, want %s", parsed.Method.Alg(), wantAlg)
+	}
+
+	claims, err := ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != "user-1" || claims.Email != "user@example.com" {
+		t.Errorf("claims = %+v, want user-1 / user@example.com", claims)
+	}
+	return token
+}
+
+func TestTokenHS256(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "")
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token := requireRoundTrip(t, "HS256")
+
+	t.Setenv("JWT_SECRET", "other-secret")
+	if _, err := ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a token signed with another secret")
+	}
+}
+
+func TestGenerateTokenExpiry(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "")
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("JWT_EXPIRATION", "2h")
+
+	override := 90 * 24 * time.Hour
+	tests := []struct {
+		name     string
+		override *time.Duration
+		want     time.Duration
+	}{
+		{name: "default", override: nil, want: 2 * time.Hour},
+		{name: "override", override: &override, want: override},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := GenerateToken("user-1", "user@example.com", tt.override, session.Device{})
+			if err != nil {
+				t.Fatalf("GenerateToken() error = %v", err)
+			}
+			claims, err := ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != tt.want {
+				t.Errorf("lifetime = %v, want %v", lifetime, tt.want)
+			}
+		})
+	}
+}
+
+func TestImpersonationToken(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "")
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	token, err := GenerateImpersonationToken("user-1", "user@example.com", "admin-1")
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken() error = %v", err)
+	}
+
+	claims, err := ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != "user-1" || claims.ImpersonatedBy != "admin-1" {
+		t.Errorf("claims = %+v, want user-1 impersonated by admin-1", claims)
+	}
+	if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != ImpersonationTokenExpiration {
+		t.Errorf("lifetime = %v, want %v", lifetime, ImpersonationTokenExpiration)
+	}
+
+	token, err = GenerateToken("user-1", "user@example.com", nil, session.Device{})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if claims, err := ValidateToken(token); err != nil || claims.ImpersonatedBy != "" {
+		t.Errorf("regular token claims = %+v, %v; want no impersonator", claims, err)
+	}
+}
+
+func TestTokenRS256(t *testing.T) {
+	useRS256(t)
+
+	token := requireRoundTrip(t, "RS256")
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	if err != nil {
+		t.Fatalf("failed to decode token: %v", err)
+	}
+	_, kid, err := PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey() error = %v", err)
+	}
+	if parsed.Header["kid"] != kid {
+		t.Errorf("kid = %v, want %s", parsed.Header["kid"], kid)
+	}
+
+	// A different key pair must not verify the token
+	useRS256(t)
+	if _, err := ValidateToken(token); err == nil {
+		t.Error("ValidateToken() accepted a token signed with another key")
+	}
+}
+
+func TestTokenRS256VerifiesWithPublicKeyOnly(t *testing.T) {
+	useRS256(t)
+	token := requireRoundTrip(t, "RS256")
+
+	t.Setenv("JWT_PRIVATE_KEY_PATH", "")
+	if _, err := ValidateToken(token); err != nil {
+		t.Errorf("ValidateToken() with only a public key error = %v", err)
+	}
+	if _, err := GenerateToken("user-1", "user@example.com", nil, session.Device{}); err == nil {
+		t.Error("GenerateToken() without a private key succeeded")
+	}
+}
+
+func TestValidateTokenRejectsOtherAlgorithm(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("JWT_ALGORITHM", "HS256")
+	hsToken := requireRoundTrip(t, "HS256")
+
+	useRS256(t)
+	rsToken := requireRoundTrip(t, "RS256")
+
+	if _, err := ValidateToken(hsToken); err == nil {
+		t.Error("RS256 validation accepted an HS256 token")
+	}
+
+	t.Setenv("JWT_ALGORITHM", "HS256")
+	if _, err := ValidateToken(rsToken); err == nil {
+		t.Error("HS256 validation accepted an RS256 token")
+	}
+}
+
+func TestUnsupportedAlgorithm(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "none")
+
+	if _, err := GenerateToken("user-1", "user@example.com", nil, session.Device{}); err == nil {
+		t.Error("GenerateToken() with an unsupported algorithm succeeded")
+	}
+}
+
+func TestPublicKeyHS256(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "HS256")
+
+	key, kid, err := PublicKey()
+	if err != nil || key != nil || kid != "" {
+		t.Errorf("PublicKey() = %v, %q, %v, want nil key", key, kid, err)
+	}
+}
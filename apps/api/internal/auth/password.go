@@ -2,12 +2,41 @@ package auth
 
 import (
 	"fmt"
+	"strings"
 
+	"api/internal/helpers"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// DefaultBcryptCost is the cost used when BCRYPT_COST is unset or invalid
+const DefaultBcryptCost = 12
+
+// MinProductionBcryptCost is the lowest cost the server starts with when
+// ENV=production
+const MinProductionBcryptCost = 10
+
+// GetBcryptCost returns the bcrypt cost from BCRYPT_COST, falling back to
+// DefaultBcryptCost for unset values or values outside bcrypt's 4 to 31 range
+func GetBcryptCost() int {
+	cost := helpers.GetEnvInt("BCRYPT_COST", DefaultBcryptCost)
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return DefaultBcryptCost
+	}
+	return cost
+}
+
+// ValidateBcryptCost returns the effective bcrypt cost, or an error when it is
+// below MinProductionBcryptCost and ENV=production
+func ValidateBcryptCost() (int, error) {
+	cost := GetBcryptCost()
+	if strings.EqualFold(helpers.GetEnv("ENV", "development"), "production") && cost < MinProductionBcryptCost {
+		return cost, fmt.Errorf("BCRYPT_COST %d is below the production minimum of %d", cost, MinProductionBcryptCost)
+	}
+	return cost, nil
+}
+
 func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), GetBcryptCost())
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -19,9 +48,7 @@ func CheckPassword(password, hash string) bool {
 	return err == nil
 }
 
+// ValidatePassword checks password against the configured password policy
 func ValidatePassword(password string) error {
-	if len(password) < 6 {
-		return fmt.Errorf("password must be at least 6 characters long")
-	}
-	return nil
-}
\ No newline at end of file
+	return Validate(password, LoadPasswordPolicy())
+}
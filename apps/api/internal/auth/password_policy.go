@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"api/internal/helpers"
+)
+
+// PasswordPolicy describes the rules a new password must satisfy. Lengths are
+// counted in characters.
+type PasswordPolicy struct {
+	MinLength        int  `json:"min_length"`
+	MaxLength        int  `json:"max_length"`
+	RequireUppercase bool `json:"require_uppercase"`
+	RequireLowercase bool `json:"require_lowercase"`
+	RequireDigit     bool `json:"require_digit"`
+	RequireSpecial   bool `json:"require_special"`
+}
+
+// PasswordPolicyError lists every rule a password failed
+type PasswordPolicyError struct {
+	Failures []string
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password must " + strings.Join(e.Failures, ", ")
+}
+
+// DefaultPasswordPolicy returns the policy used when no PASSWORD_* variables
+// are set. The maximum matches bcrypt's 72 byte input limit.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength: 8,
+		MaxLength: 72,
+	}
+}
+
+// LoadPasswordPolicy reads the password policy from the environment, falling
+// back to DefaultPasswordPolicy for unset or invalid values
+func LoadPasswordPolicy() PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+	policy.MinLength = helpers.GetEnvInt("PASSWORD_MIN_LENGTH", policy.MinLength)
+	policy.MaxLength = helpers.GetEnvInt("PASSWORD_MAX_LENGTH", policy.MaxLength)
+	policy.RequireUppercase = helpers.GetEnvBool("PASSWORD_REQUIRE_UPPERCASE", policy.RequireUppercase)
+	policy.RequireLowercase = helpers.GetEnvBool("PASSWORD_REQUIRE_LOWERCASE", policy.RequireLowercase)
+	policy.RequireDigit = helpers.GetEnvBool("PASSWORD_REQUIRE_DIGIT", policy.RequireDigit)
+	policy.RequireSpecial = helpers.GetEnvBool("PASSWORD_REQUIRE_SPECIAL", policy.RequireSpecial)
+	return policy
+}
+
+// Validate checks password against policy and returns a *PasswordPolicyError
+// describing all failing rules
+func Validate(password string, policy PasswordPolicy) error {
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	var failures []string
+	length := utf8.RuneCountInString(password)
+	if policy.MinLength > 0 && length < policy.MinLength {
+		failures = append(failures, fmt.Sprintf("be at least %d characters long", policy.MinLength))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		failures = append(failures, fmt.Sprintf("be at most %d characters long", policy.MaxLength))
+	}
+	if policy.RequireUppercase && !hasUpper {
+		failures = append(failures, "contain an uppercase letter")
+	}
+	if policy.RequireLowercase && !hasLower {
+		failures = append(failures, "contain a lowercase letter")
+	}
+	if policy.RequireDigit && !hasDigit {
+		failures = append(failures, "contain a digit")
+	}
+	if policy.RequireSpecial && !hasSpecial {
+		failures = append(failures, "contain a special character")
+	}
+
+	if len(failures) > 0 {
+		return &PasswordPolicyError{Failures: failures}
+	}
+	return nil
+}
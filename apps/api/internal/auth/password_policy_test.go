@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	strict := PasswordPolicy{
+		MinLength:        8,
+		MaxLength:        16,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		policy   PasswordPolicy
+		want     []string
+	}{
+		{name: "meets every rule", password: "Secr3t!pass", policy: strict},
+		{name: "too short", password: "Ab1!", policy: strict, want: []string{"be at least 8 characters long"}},
+		{name: "too long", password: "Abcdefgh1!abcdefg", policy: strict, want: []string{"be at most 16 characters long"}},
+		{name: "missing uppercase", password: "secr3t!pass", policy: strict, want: []string{"contain an uppercase letter"}},
+		{name: "missing lowercase", password: "SECR3T!PASS", policy: strict, want: []string{"contain a lowercase letter"}},
+		{name: "missing digit", password: "Secret!pass", policy: strict, want: []string{"contain a digit"}},
+		{name: "missing special", password: "Secr3tpass", policy: strict, want: []string{"contain a special character"}},
+		{
+			name:     "lists every failing rule",
+			password: "abc",
+			policy:   strict,
+			want: []string{
+				"be at least 8 characters long",
+				"contain an uppercase letter",
+				"contain a digit",
+				"contain a special character",
+			},
+		},
+		{name: "length counts characters", password: "pässwörd", policy: PasswordPolicy{MinLength: 8, MaxLength: 8}},
+		{name: "zero limits are disabled", password: "", policy: PasswordPolicy{}},
+		{name: "default policy", password: "password123", policy: DefaultPasswordPolicy()},
+		{name: "default policy too short", password: "pass123", policy: DefaultPasswordPolicy(), want: []string{"be at least 8 characters long"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.password, tt.policy)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Validate(%q) error = %v, want nil", tt.password, err)
+				}
+				return
+			}
+
+			var policyErr *PasswordPolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("Validate(%q) error = %v, want *PasswordPolicyError", tt.password, err)
+			}
+			if !reflect.DeepEqual(policyErr.Failures, tt.want) {
+				t.Errorf("Validate(%q) failures = %v, want %v", tt.password, policyErr.Failures, tt.want)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicyErrorMessage(t *testing.T) {
+	err := &PasswordPolicyError{Failures: []string{"be at least 8 characters long", "contain a digit"}}
+
+	want := "password must be at least 8 characters long, contain a digit"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestLoadPasswordPolicy(t *testing.T) {
+	t.Setenv("PASSWORD_MIN_LENGTH", "12")
+	t.Setenv("PASSWORD_MAX_LENGTH", "not-a-number")
+	t.Setenv("PASSWORD_REQUIRE_UPPERCASE", "true")
+	t.Setenv("PASSWORD_REQUIRE_SPECIAL", "1")
+
+	want := PasswordPolicy{
+		MinLength:        12,
+		MaxLength:        72,
+		RequireUppercase: true,
+		RequireSpecial:   true,
+	}
+	if got := LoadPasswordPolicy(); got != want {
+		t.Errorf("LoadPasswordPolicy() = %+v, want %+v", got, want)
+	}
+}
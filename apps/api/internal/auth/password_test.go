@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGetBcryptCost(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "unset", value: "", want: DefaultBcryptCost},
+		{name: "minimum", value: "4", want: 4},
+		{name: "custom", value: "11", want: 11},
+		{name: "maximum", value: "31", want: 31},
+		{name: "below minimum", value: "3", want: DefaultBcryptCost},
+		{name: "above maximum", value: "32", want: DefaultBcryptCost},
+		{name: "not a number", value: "high", want: DefaultBcryptCost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("BCRYPT_COST", tt.value)
+			if got := GetBcryptCost(); got != tt.want {
+				t.Errorf("GetBcryptCost() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHashPasswordUsesBcryptCost(t *testing.T) {
+	t.Setenv("BCRYPT_COST", "5")
+
+	hash, err := HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword() error = %v", err)
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		t.Fatalf("bcrypt.Cost() error = %v", err)
+	}
+	if cost != 5 {
+		t.Errorf("hash cost = %d, want 5", cost)
+	}
+	if !CheckPassword("correct horse", hash) {
+		t.Error("CheckPassword() = false, want true")
+	}
+}
+
+func TestValidateBcryptCost(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     string
+		cost    string
+		wantErr bool
+	}{
+		{name: "low cost in development", env: "development", cost: "4"},
+		{name: "low cost in production", env: "production", cost: "9", wantErr: true},
+		{name: "minimum cost in production", env: "production", cost: "10"},
+		{name: "default cost in production", env: "production", cost: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ENV", tt.env)
+			t.Setenv("BCRYPT_COST", tt.cost)
+			_, err := ValidateBcryptCost()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBcryptCost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
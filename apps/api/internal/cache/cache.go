@@ -0,0 +1,94 @@
+package cache
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+)
+
+const defaultTTL = 5 * time.Minute
+
+// PermissionCache stores the role names resolved for a user so that
+// authenticated requests can skip the user_roles lookup
+type PermissionCache interface {
+	Get(userID string) ([]string, bool)
+	Set(userID string, roles []string, ttl time.Duration)
+	Delete(userID string)
+}
+
+var (
+	permissions PermissionCache
+	mu          sync.RWMutex
+)
+
+// Permissions returns the process-wide permission cache, creating it from
+// the CACHE_BACKEND environment variable on first use
+func Permissions() PermissionCache {
+	mu.RLock()
+	c := permissions
+	mu.RUnlock()
+	if c != nil {
+		return c
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if permissions == nil {
+		permissions = New(helpers.GetEnv("CACHE_BACKEND", "memory"))
+	}
+	return permissions
+}
+
+// SetPermissions replaces the process-wide permission cache
+func SetPermissions(c PermissionCache) {
+	mu.Lock()
+	permissions = c
+	mu.Unlock()
+}
+
+// New creates a permission cache for the given backend ("memory", "redis" or "none")
+func New(backend string) PermissionCache {
+	switch strings.ToLower(backend) {
+	case "redis":
+		if database.Redis == nil {
+			if err := database.ConnectRedis(); err != nil {
+				logger.Warn("Failed to connect to redis, falling back to memory cache", "error", err)
+				return NewMemoryCache()
+			}
+		}
+		logger.Info("Redis permission cache initialized")
+		return NewRedisCache(database.Redis)
+	case "none":
+		return NewNoopCache()
+	default:
+		return NewMemoryCache()
+	}
+}
+
+// TTL returns how long resolved roles stay cached (PERMISSION_CACHE_TTL)
+func TTL() time.Duration {
+	ttl := helpers.GetEnvDuration("PERMISSION_CACHE_TTL", defaultTTL)
+	if ttl <= 0 {
+		return defaultTTL
+	}
+	return ttl
+}
+
+// NoopCache never stores anything, forcing every lookup to hit the database
+type NoopCache struct{}
+
+func NewNoopCache() *NoopCache {
+	return &NoopCache{}
+}
+
+func (NoopCache) Get(userID string) ([]string, bool) {
+	return nil, false
+}
+
+func (NoopCache) Set(userID string, roles []string, ttl time.Duration) {}
+
+func (NoopCache) Delete(userID string) {}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	roles     []string
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process PermissionCache. Entries are evicted lazily
+// when they are read after expiring.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (m *MemoryCache) Get(userID string) ([]string, bool) {
+	m.mu.RLock()
+	entry, ok := m.entries[userID]
+	m.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(entry.expiresAt) {
+		m.Delete(userID)
+		return nil, false
+	}
+
+	roles := make([]string, len(entry.roles))
+	copy(roles, entry.roles)
+	return roles, true
+}
+
+func (m *MemoryCache) Set(userID string, roles []string, ttl time.Duration) {
+	stored := make([]string, len(roles))
+	copy(stored, roles)
+
+	m.mu.Lock()
+	m.entries[userID] = memoryEntry{
+		roles:     stored,
+		expiresAt: time.Now().Add(ttl),
+	}
+	m.mu.Unlock()
+}
+
+func (m *MemoryCache) Delete(userID string) {
+	m.mu.Lock()
+	delete(m.entries, userID)
+	m.mu.Unlock()
+}
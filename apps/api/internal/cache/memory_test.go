@@ -0,0 +1,45 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryCache(t *testing.T) {
+	c := NewMemoryCache()
+
+	if _, ok := c.Get("user-1"); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	c.Set("user-1", []string{"admin", "user"}, time.Minute)
+	roles, ok := c.Get("user-1")
+	if !ok {
+		t.Fatal("expected hit after Set")
+	}
+	if len(roles) != 2 || roles[0] != "admin" || roles[1] != "user" {
+		t.Errorf("unexpected roles %v", roles)
+	}
+
+	// Callers must not be able to mutate the cached entry
+	roles[0] = "tampered"
+	if again, _ := c.Get("user-1"); again[0] != "admin" {
+		t.Errorf("cached roles were mutated: %v", again)
+	}
+
+	c.Delete("user-1")
+	if _, ok := c.Get("user-1"); ok {
+		t.Error("expected miss after Delete")
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	c := NewMemoryCache()
+	c.Set("user-1", []string{"user"}, time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("user-1"); ok {
+		t.Error("expected expired entry to be evicted")
+	}
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"api/internal/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "permissions:"
+
+// RedisCache is a PermissionCache shared between API instances through Redis
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{
+		client: client,
+	}
+}
+
+func (r *RedisCache) Get(userID string) ([]string, bool) {
+	data, err := r.client.Get(context.Background(), redisKeyPrefix+userID).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			logger.Warn("Failed to read permission cache", "user_id", userID, "error", err)
+		}
+		return nil, false
+	}
+
+	var roles []string
+	if err := json.Unmarshal(data, &roles); err != nil {
+		logger.Warn("Failed to decode permission cache entry", "user_id", userID, "error", err)
+		return nil, false
+	}
+
+	return roles, true
+}
+
+func (r *RedisCache) Set(userID string, roles []string, ttl time.Duration) {
+	data, err := json.Marshal(roles)
+	if err != nil {
+		logger.Warn("Failed to encode permission cache entry", "user_id", userID, "error", err)
+		return
+	}
+
+	if err := r.client.Set(context.Background(), redisKeyPrefix+userID, data, ttl).Err(); err != nil {
+		logger.Warn("Failed to write permission cache", "user_id", userID, "error", err)
+	}
+}
+
+func (r *RedisCache) Delete(userID string) {
+	if err := r.client.Del(context.Background(), redisKeyPrefix+userID).Err(); err != nil {
+		logger.Warn("Failed to invalidate permission cache", "user_id", userID, "error", err)
+	}
+}
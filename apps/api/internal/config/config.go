@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// file holds the values loaded from the config file, if any
+var file struct {
+	sync.RWMutex
+	values map[string]string
+}
+
+// Use makes the values in cfg available through Get. Passing nil forgets a
+// previously loaded file.
+func Use(cfg *Config) {
+	var values map[string]string
+	if cfg != nil {
+		values = cfg.Values()
+	}
+
+	file.Lock()
+	file.values = values
+	file.Unlock()
+}
+
+// Get returns the setting named key. An environment variable takes precedence
+// over the config file; an empty string means the setting is not set.
+func Get(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	file.RLock()
+	defer file.RUnlock()
+	return file.values[key]
+}
+
+// Validate checks that the settings the server cannot start without are set:
+// DB_DSN, and JWT_SECRET unless tokens are signed with RS256
+func Validate() error {
+	var errs []error
+	if Get("DB_DSN") == "" {
+		errs = append(errs, errors.New("DB_DSN is required"))
+	}
+	if strings.ToUpper(Get("JWT_ALGORITHM")) != "RS256" && Get("JWT_SECRET") == "" {
+		errs = append(errs, errors.New("JWT_SECRET is required"))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
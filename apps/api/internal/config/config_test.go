@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// useFile loads content as the config file for the duration of the test
+func useFile(t *testing.T, content string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	Use(cfg)
+	t.Cleanup(func() { Use(nil) })
+}
+
+func TestLoad(t *testing.T) {
+	useFile(t, "db_dsn: postgresql://file/studio45\nport: 9090\nmaintenance_mode: true\nrate_limit_api_window: 30s\n")
+
+	tests := map[string]string{
+		"DB_DSN":                "postgresql://file/studio45",
+		"PORT":                  "9090",
+		"MAINTENANCE_MODE":      "true",
+		"RATE_LIMIT_API_WINDOW": "30s",
+		"JWT_ALGORITHM":         "",
+	}
+	for key, want := range tests {
+		t.Setenv(key, "")
+		if got := Get(key); got != want {
+			t.Errorf("Get(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestEnvironmentTakesPrecedence(t *testing.T) {
+	useFile(t, "db_dsn: postgresql://file/studio45\njwt_secret: file-secret\n")
+
+	t.Setenv("DB_DSN", "postgresql://env/studio45")
+	t.Setenv("JWT_SECRET", "")
+
+	if got := Get("DB_DSN"); got != "postgresql://env/studio45" {
+		t.Errorf("Get(DB_DSN) = %q, want the environment value", got)
+	}
+	// An empty environment variable counts as unset
+	if got := Get("JWT_SECRET"); got != "file-secret" {
+		t.Errorf("Get(JWT_SECRET) = %q, want the file value", got)
+	}
+}
+
+func TestGetWithoutFile(t *testing.T) {
+	Use(nil)
+	t.Setenv("DB_DSN", "postgresql://env/studio45")
+
+	if got := Get("DB_DSN"); got != "postgresql://env/studio45" {
+		t.Errorf("Get(DB_DSN) = %q, want the environment value", got)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("db_dns: postgresql://typo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "db_dns") {
+		t.Errorf("Load() error = %v, want unknown key error", err)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Load() error = nil, want error for a missing file")
+	}
+}
+
+func TestLoadEmptyFile(t *testing.T) {
+	useFile(t, "")
+	t.Setenv("DB_DSN", "")
+
+	if got := Get("DB_DSN"); got != "" {
+		t.Errorf("Get(DB_DSN) = %q, want empty", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		file    string
+		missing []string
+	}{
+		{
+			name: "set in the environment",
+			env:  map[string]string{"DB_DSN": "postgresql://env/studio45", "JWT_SECRET": "secret"},
+		},
+		{
+			name: "set in the file",
+			file: "db_dsn: postgresql://file/studio45\njwt_secret: secret\n",
+		},
+		{
+			name:    "missing database",
+			env:     map[string]string{"JWT_SECRET": "secret"},
+			missing: []string{"DB_DSN"},
+		},
+		{
+			name:    "missing everything",
+			missing: []string{"DB_DSN", "JWT_SECRET"},
+		},
+		{
+			name: "RS256 needs no secret",
+			env:  map[string]string{"DB_DSN": "postgresql://env/studio45", "JWT_ALGORITHM": "RS256"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"DB_DSN", "JWT_SECRET", "JWT_ALGORITHM"} {
+				t.Setenv(key, tt.env[key])
+			}
+			useFile(t, tt.file)
+
+			err := Validate()
+			if len(tt.missing) == 0 {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() error = nil, want %v missing", tt.missing)
+			}
+			for _, key := range tt.missing {
+				if !strings.Contains(err.Error(), key+" is required") {
+					t.Errorf("Validate() error = %v, want %s is required", err, key)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigKeysMatchEnvironmentVariables(t *testing.T) {
+	typ := reflect.TypeOf(Config{})
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if env, key := field.Tag.Get("env"), field.Tag.Get("yaml"); env == "" || key != strings.ToLower(env) {
+			t.Errorf("%s: yaml key %q should be the lower-case env name %q", field.Name, key, env)
+		}
+	}
+}
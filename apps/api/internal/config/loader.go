@@ -0,0 +1,176 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings a config file may set. Each field is named after
+// the environment variable in its env tag, and the YAML key is that name in
+// lower case. Values are kept as strings, as if they had been read from the
+// environment.
+type Config struct {
+	// Server
+	Env             string `yaml:"env" env:"ENV"`
+	Port            string `yaml:"port" env:"PORT"`
+	ServiceVersion  string `yaml:"service_version" env:"SERVICE_VERSION"`
+	ShutdownTimeout string `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	CleanupInterval string `yaml:"password_reset_cleanup_interval" env:"PASSWORD_RESET_CLEANUP_INTERVAL"`
+	LogLevel        string `yaml:"log_level" env:"LOG_LEVEL"`
+	LogFormat       string `yaml:"log_format" env:"LOG_FORMAT"`
+	LogOutput       string `yaml:"log_output" env:"LOG_OUTPUT"`
+	FrontendURL     string `yaml:"frontend_url" env:"FRONTEND_URL"`
+	UploadDir       string `yaml:"upload_dir" env:"UPLOAD_DIR"`
+
+	// Tracing
+	OTELExporter               string `yaml:"otel_exporter" env:"OTEL_EXPORTER"`
+	OTELExporterOTLPEndpoint   string `yaml:"otel_exporter_otlp_endpoint" env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTELExporterZipkinEndpoint string `yaml:"otel_exporter_zipkin_endpoint" env:"OTEL_EXPORTER_ZIPKIN_ENDPOINT"`
+
+	// Database
+	DBDSN              string `yaml:"db_dsn" env:"DB_DSN"`
+	DBReadDSN          string `yaml:"db_read_dsn" env:"DB_READ_DSN"`
+	DBMaxOpenConns     string `yaml:"db_max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns     string `yaml:"db_max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime  string `yaml:"db_conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	DBStatsInterval    string `yaml:"db_stats_interval" env:"DB_STATS_INTERVAL"`
+	QueryTimeoutMS     string `yaml:"query_timeout_ms" env:"QUERY_TIMEOUT_MS"`
+	QueryWarnThreshold string `yaml:"query_warn_threshold" env:"QUERY_WARN_THRESHOLD"`
+	MigrationPath      string `yaml:"migration_path" env:"MIGRATION_PATH"`
+
+	// Authentication
+	JWTSecret             string `yaml:"jwt_secret" env:"JWT_SECRET"`
+	JWTAlgorithm          string `yaml:"jwt_algorithm" env:"JWT_ALGORITHM"`
+	JWTExpiration         string `yaml:"jwt_expiration" env:"JWT_EXPIRATION"`
+	JWTPrivateKeyPath     string `yaml:"jwt_private_key_path" env:"JWT_PRIVATE_KEY_PATH"`
+	JWTPublicKeyPath      string `yaml:"jwt_public_key_path" env:"JWT_PUBLIC_KEY_PATH"`
+	MaxTokenExpirySeconds string `yaml:"max_token_expiry_seconds" env:"MAX_TOKEN_EXPIRY_SECONDS"`
+	SessionBackend        string `yaml:"session_backend" env:"SESSION_BACKEND"`
+	MaxConcurrentSessions string `yaml:"max_concurrent_sessions" env:"MAX_CONCURRENT_SESSIONS"`
+
+	// Password policy
+	PasswordMinLength        string `yaml:"password_min_length" env:"PASSWORD_MIN_LENGTH"`
+	PasswordMaxLength        string `yaml:"password_max_length" env:"PASSWORD_MAX_LENGTH"`
+	PasswordRequireUppercase string `yaml:"password_require_uppercase" env:"PASSWORD_REQUIRE_UPPERCASE"`
+	PasswordRequireLowercase string `yaml:"password_require_lowercase" env:"PASSWORD_REQUIRE_LOWERCASE"`
+	PasswordRequireDigit     string `yaml:"password_require_digit" env:"PASSWORD_REQUIRE_DIGIT"`
+	PasswordRequireSpecial   string `yaml:"password_require_special" env:"PASSWORD_REQUIRE_SPECIAL"`
+	PasswordHistoryDepth     string `yaml:"password_history_depth" env:"PASSWORD_HISTORY_DEPTH"`
+	BcryptCost               string `yaml:"bcrypt_cost" env:"BCRYPT_COST"`
+
+	// Registration
+	NormalizeEmailPlusAddressing string `yaml:"normalize_email_plus_addressing" env:"NORMALIZE_EMAIL_PLUS_ADDRESSING"`
+	RejectDisposableEmails       string `yaml:"reject_disposable_emails" env:"REJECT_DISPOSABLE_EMAILS"`
+
+	// RBAC and caching
+	PermissionCacheTTL     string `yaml:"permission_cache_ttl" env:"PERMISSION_CACHE_TTL"`
+	CacheBackend           string `yaml:"cache_backend" env:"CACHE_BACKEND"`
+	PreventSystemRoleClone string `yaml:"prevent_system_role_clone" env:"PREVENT_SYSTEM_ROLE_CLONE"`
+	DefaultUserRole        string `yaml:"default_user_role" env:"DEFAULT_USER_ROLE"`
+	AllowedPreferenceKeys  string `yaml:"allowed_preference_keys" env:"ALLOWED_PREFERENCE_KEYS"`
+
+	// Redis
+	RedisHost     string `yaml:"redis_host" env:"REDIS_HOST"`
+	RedisPort     string `yaml:"redis_port" env:"REDIS_PORT"`
+	RedisPassword string `yaml:"redis_password" env:"REDIS_PASSWORD"`
+	RedisDB       string `yaml:"redis_db" env:"REDIS_DB"`
+
+	// Rate limiting
+	RateLimitBackend      string `yaml:"rate_limit_backend" env:"RATE_LIMIT_BACKEND"`
+	RateLimitAPIRequests  string `yaml:"rate_limit_api_requests" env:"RATE_LIMIT_API_REQUESTS"`
+	RateLimitAPIWindow    string `yaml:"rate_limit_api_window" env:"RATE_LIMIT_API_WINDOW"`
+	RateLimitAuthRequests string `yaml:"rate_limit_auth_requests" env:"RATE_LIMIT_AUTH_REQUESTS"`
+	RateLimitAuthWindow   string `yaml:"rate_limit_auth_window" env:"RATE_LIMIT_AUTH_WINDOW"`
+
+	// CORS and access control
+	CORSAllowedOrigins string `yaml:"cors_allowed_origins" env:"CORS_ALLOWED_ORIGINS"`
+	CORSAllowedHeaders string `yaml:"cors_allowed_headers" env:"CORS_ALLOWED_HEADERS"`
+	CORSAllowedMethods string `yaml:"cors_allowed_methods" env:"CORS_ALLOWED_METHODS"`
+	CORSAPIOrigins     string `yaml:"cors_api_origins" env:"CORS_API_ORIGINS"`
+	CORSAuthOrigins    string `yaml:"cors_auth_origins" env:"CORS_AUTH_ORIGINS"`
+	CORSAdminOrigins   string `yaml:"cors_admin_origins" env:"CORS_ADMIN_ORIGINS"`
+	AdminIPAllowlist   string `yaml:"admin_ip_allowlist" env:"ADMIN_IP_ALLOWLIST"`
+
+	// Operations
+	MaintenanceMode        string `yaml:"maintenance_mode" env:"MAINTENANCE_MODE"`
+	MaintenanceBypassToken string `yaml:"maintenance_bypass_token" env:"MAINTENANCE_BYPASS_TOKEN"`
+	MetricsBearerToken     string `yaml:"metrics_bearer_token" env:"METRICS_BEARER_TOKEN"`
+	DocsUsername           string `yaml:"docs_username" env:"DOCS_USERNAME"`
+	DocsPassword           string `yaml:"docs_password" env:"DOCS_PASSWORD"`
+	SentryDSN              string `yaml:"sentry_dsn" env:"SENTRY_DSN"`
+
+	// Webhooks
+	WebhookDeliveryRetention string `yaml:"webhook_delivery_retention" env:"WEBHOOK_DELIVERY_RETENTION"`
+
+	// Email
+	EmailProvider              string `yaml:"email_provider" env:"EMAIL_PROVIDER"`
+	EmailWorkerCount           string `yaml:"email_worker_count" env:"EMAIL_WORKER_COUNT"`
+	EmailQueueSize             string `yaml:"email_queue_size" env:"EMAIL_QUEUE_SIZE"`
+	EmailMaxRetries            string `yaml:"email_max_retries" env:"EMAIL_MAX_RETRIES"`
+	EmailCBMaxRequests         string `yaml:"email_cb_max_requests" env:"EMAIL_CB_MAX_REQUESTS"`
+	EmailCBInterval            string `yaml:"email_cb_interval" env:"EMAIL_CB_INTERVAL"`
+	EmailCBTimeout             string `yaml:"email_cb_timeout" env:"EMAIL_CB_TIMEOUT"`
+	SendWelcomeEmail           string `yaml:"send_welcome_email" env:"SEND_WELCOME_EMAIL"`
+	EmailDeliveryWebhookFormat string `yaml:"email_delivery_webhook_format" env:"EMAIL_DELIVERY_WEBHOOK_FORMAT"`
+	EmailDeliveryWebhookSecret string `yaml:"email_delivery_webhook_secret" env:"EMAIL_DELIVERY_WEBHOOK_SECRET"`
+
+	// SMTP
+	SMTPHost      string `yaml:"smtp_host" env:"SMTP_HOST"`
+	SMTPPort      string `yaml:"smtp_port" env:"SMTP_PORT"`
+	SMTPUsername  string `yaml:"smtp_username" env:"SMTP_USERNAME"`
+	SMTPPassword  string `yaml:"smtp_password" env:"SMTP_PASSWORD"`
+	SMTPUseTLS    string `yaml:"smtp_use_tls" env:"SMTP_USE_TLS"`
+	SMTPFromEmail string `yaml:"smtp_from_email" env:"SMTP_FROM_EMAIL"`
+	SMTPFromName  string `yaml:"smtp_from_name" env:"SMTP_FROM_NAME"`
+
+	// SendGrid
+	SendGridAPIKey                string `yaml:"sendgrid_api_key" env:"SENDGRID_API_KEY"`
+	SendGridFromEmail             string `yaml:"sendgrid_from_email" env:"SENDGRID_FROM_EMAIL"`
+	SendGridFromName              string `yaml:"sendgrid_from_name" env:"SENDGRID_FROM_NAME"`
+	SendGridPasswordResetTemplate string `yaml:"sendgrid_password_reset_template" env:"SENDGRID_PASSWORD_RESET_TEMPLATE"`
+	UseSendGridTemplates          string `yaml:"use_sendgrid_templates" env:"USE_SENDGRID_TEMPLATES"`
+
+	// Amazon SES
+	AWSRegion          string `yaml:"aws_region" env:"AWS_REGION"`
+	AWSAccessKeyID     string `yaml:"aws_access_key_id" env:"AWS_ACCESS_KEY_ID"`
+	AWSSecretAccessKey string `yaml:"aws_secret_access_key" env:"AWS_SECRET_ACCESS_KEY"`
+	SESFromEmail       string `yaml:"ses_from_email" env:"SES_FROM_EMAIL"`
+	SESFromName        string `yaml:"ses_from_name" env:"SES_FROM_NAME"`
+}
+
+// Load reads the YAML config file at path. Unknown keys are rejected so that
+// typos do not go unnoticed.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var cfg Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Values returns the settings in c keyed by environment variable name,
+// leaving out the ones that are not set
+func (c *Config) Values() map[string]string {
+	values := make(map[string]string)
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if value := v.Field(i).String(); value != "" {
+			values[v.Type().Field(i).Tag.Get("env")] = value
+		}
+	}
+	return values
+}
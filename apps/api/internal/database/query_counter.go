@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// queryCounterKey is the context key of the counter set by WithQueryCounter
+type queryCounterKey struct{}
+
+// WithQueryCounter returns a context that counts the SQL statements run with
+// it, or with contexts derived from it, through a handle with
+// RegisterQueryCounter installed
+func WithQueryCounter(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryCounterKey{}, new(atomic.Int64))
+}
+
+// QueriesRun returns how many SQL statements have run with ctx since
+// WithQueryCounter created it, or zero when it was not
+func QueriesRun(ctx context.Context) int64 {
+	if counter, ok := ctx.Value(queryCounterKey{}).(*atomic.Int64); ok {
+		return counter.Load()
+	}
+	return 0
+}
+
+// RegisterQueryCounter adds callbacks to db counting each statement against
+// the counter in its context, if any
+func RegisterQueryCounter(db *gorm.DB) error {
+	count := func(tx *gorm.DB) {
+		if tx.Statement.Context == nil {
+			return
+		}
+		if counter, ok := tx.Statement.Context.Value(queryCounterKey{}).(*atomic.Int64); ok {
+			counter.Add(1)
+		}
+	}
+
+	callbacks := db.Callback()
+	registrations := []error{
+		callbacks.Create().After("gorm:create").Register("query_counter:create", count),
+		callbacks.Query().After("gorm:query").Register("query_counter:query", count),
+		callbacks.Update().After("gorm:update").Register("query_counter:update", count),
+		callbacks.Delete().After("gorm:delete").Register("query_counter:delete", count),
+		callbacks.Row().After("gorm:row").Register("query_counter:row", count),
+		callbacks.Raw().After("gorm:raw").Register("query_counter:raw", count),
+	}
+	for _, err := range registrations {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"api/internal/helpers"
+	applogger "api/internal/logger"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var Redis *redis.Client
+
+func ConnectRedis() error {
+	host := helpers.GetEnv("REDIS_HOST", "localhost")
+	port := helpers.GetEnv("REDIS_PORT", "6379")
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%s", host, port),
+		Password: helpers.GetEnv("REDIS_PASSWORD", ""),
+		DB:       helpers.GetEnvInt("REDIS_DB", 0),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	Redis = client
+	applogger.Info("Redis connected successfully", "address", client.Options().Addr)
+	return nil
+}
+
+func CloseRedis() error {
+	if Redis != nil {
+		return Redis.Close()
+	}
+	return nil
+}
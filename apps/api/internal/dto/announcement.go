@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// CreateAnnouncementRequest posts an announcement. It is visible from
+// VisibleFrom, now when omitted, until VisibleUntil, indefinitely when omitted.
+type CreateAnnouncementRequest struct {
+	Title        string     `json:"title" validate:"required,max=200"`
+	Body         string     `json:"body" validate:"required"`
+	Type         string     `json:"type" validate:"omitempty,oneof=info warning critical"`
+	VisibleFrom  *time.Time `json:"visible_from,omitempty"`
+	VisibleUntil *time.Time `json:"visible_until,omitempty"`
+}
+
+// UpdateAnnouncementRequest updates an announcement. Sending visible_until as
+// null makes it visible indefinitely.
+type UpdateAnnouncementRequest struct {
+	Title        *string      `json:"title,omitempty" validate:"omitempty,min=1,max=200"`
+	Body         *string      `json:"body,omitempty" validate:"omitempty,min=1"`
+	Type         *string      `json:"type,omitempty" validate:"omitempty,oneof=info warning critical"`
+	VisibleFrom  *time.Time   `json:"visible_from,omitempty"`
+	VisibleUntil NullableTime `json:"visible_until,omitzero"`
+}
+
+type AnnouncementResponse struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	Type         string     `json:"type"`
+	VisibleFrom  time.Time  `json:"visible_from"`
+	VisibleUntil *time.Time `json:"visible_until"`
+	CreatedBy    *string    `json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// ActiveAnnouncementResponse is an announcement as shown to users. Read is
+// only set for authenticated users.
+type ActiveAnnouncementResponse struct {
+	ID           string     `json:"id"`
+	Title        string     `json:"title"`
+	Body         string     `json:"body"`
+	Type         string     `json:"type"`
+	VisibleFrom  time.Time  `json:"visible_from"`
+	VisibleUntil *time.Time `json:"visible_until"`
+	Read         *bool      `json:"read,omitempty"`
+}
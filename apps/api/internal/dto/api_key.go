@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,oneof=user:read user:write"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// CreateAPIKeyResponse holds a newly created key. Key is only ever returned
+// here; the API stores a hash of it.
+type CreateAPIKeyResponse struct {
+	Key    string         `json:"key"`
+	APIKey APIKeyResponse `json:"api_key"`
+}
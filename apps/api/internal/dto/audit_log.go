@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"api/internal/models"
+	"time"
+)
+
+// AuditLogListRequest filters and paginates the audit log. From and To are
+// RFC3339 timestamps or dates such as 2024-01-31; a date in To includes the
+// whole day.
+type AuditLogListRequest struct {
+	Page         int    `json:"page" query:"page"`
+	Limit        int    `json:"limit" query:"limit"`
+	ActorID      string `json:"actor_id" query:"actor_id" validate:"omitempty,uuid"`
+	ResourceType string `json:"resource_type" query:"resource_type"`
+	ResourceID   string `json:"resource_id" query:"resource_id"`
+	Action       string `json:"action" query:"action"`
+	From         string `json:"from" query:"from"`
+	To           string `json:"to" query:"to"`
+}
+
+// AuditLogResponse is an audit entry. ActorEmail is the address of the user
+// who performed the action, including deactivated and deleted users.
+// ImpersonatedBy is the admin who acted as that user, if any.
+type AuditLogResponse struct {
+	ID             string       `json:"id"`
+	ActorID        *string      `json:"actor_id"`
+	ImpersonatedBy *string      `json:"impersonated_by"`
+	ActorEmail     *string      `json:"actor_email"`
+	Action         string       `json:"action"`
+	ResourceType   string       `json:"resource_type"`
+	ResourceID     string       `json:"resource_id"`
+	Changes        models.JSONB `json:"changes"`
+	IPAddress      string       `json:"ip_address"`
+	UserAgent      string       `json:"user_agent"`
+	CreatedAt      time.Time    `json:"created_at"`
+}
+
+type PaginatedAuditLogsResponse struct {
+	Logs       []AuditLogResponse `json:"logs"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	TotalPages int                `json:"total_pages"`
+}
@@ -1,10 +1,15 @@
 package dto
 
+import "time"
+
 type RegisterRequest struct {
 	Email    string  `json:"email" validate:"required,email"`
-	Password string  `json:"password" validate:"required,min=6"`
+	Password string  `json:"password" validate:"required"`
 	Name     string  `json:"name" validate:"required,min=2"`
 	Phone    *string `json:"phone,omitempty" validate:"omitempty,phone"`
+	// ToSVersion is the terms of service version the user accepts; required
+	// once a version has been published
+	ToSVersion string `json:"tos_version,omitempty" validate:"max=50"`
 }
 
 type LoginRequest struct {
@@ -13,8 +18,17 @@ type LoginRequest struct {
 }
 
 type AuthResponse struct {
-	Token string      `json:"token"`
+	Token string       `json:"token"`
 	User  UserResponse `json:"user"`
+	// Warnings are problems with the request that did not stop it succeeding
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ImpersonationResponse carries a short-lived token that acts as the user
+type ImpersonationResponse struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      UserResponse `json:"user"`
 }
 
 type UserResponse struct {
@@ -24,17 +38,40 @@ type UserResponse struct {
 	Roles []string `json:"roles"`
 }
 
-type UpdateProfileRequest map[string]interface{}
+// PatchProfileRequest partially updates the profile with JSON Merge Patch
+// semantics: absent fields are left unchanged and null clears the phone or
+// company
+type PatchProfileRequest struct {
+	Name      NullableString `json:"name,omitzero" validate:"omitempty,min=2"`
+	Phone     NullableString `json:"phone,omitzero"`
+	CompanyID NullableString `json:"company_id,omitzero" validate:"omitempty,uuid"`
+}
+
+// ReplaceProfileRequest replaces every editable profile field. Phone and
+// CompanyID must be sent, as null when the user has none.
+type ReplaceProfileRequest struct {
+	Name      string         `json:"name" validate:"required,min=2"`
+	Phone     NullableString `json:"phone"`
+	CompanyID NullableString `json:"company_id" validate:"omitempty,uuid"`
+}
 
 type ProfileResponse struct {
-	ID        string   `json:"id"`
-	Email     string   `json:"email"`
-	Name      string   `json:"name"`
-	Phone     *string  `json:"phone"`
-	Company   *string  `json:"company"`
-	Roles     []string `json:"roles"`
-	CreatedAt string   `json:"created_at"`
-	UpdatedAt string   `json:"updated_at"`
+	ID        string              `json:"id"`
+	Email     string              `json:"email"`
+	Emails    []UserEmailResponse `json:"emails"`
+	Name      string              `json:"name"`
+	Phone     *string             `json:"phone"`
+	CompanyID *string             `json:"company_id"`
+	Company   *CompanyResponse    `json:"company"`
+	AvatarURL *string             `json:"avatar_url"`
+	Roles     []string            `json:"roles"`
+	CreatedAt string              `json:"created_at"`
+	UpdatedAt string              `json:"updated_at"`
+}
+
+// MaskingModel names the model field masking rules apply to
+func (ProfileResponse) MaskingModel() string {
+	return "user"
 }
 
 type ForgotPasswordRequest struct {
@@ -43,56 +80,224 @@ type ForgotPasswordRequest struct {
 
 type ResetPasswordRequest struct {
 	Token    string `json:"token" validate:"required"`
-	Password string `json:"password" validate:"required,min=6"`
+	Password string `json:"password" validate:"required"`
+}
+
+// AdminResetPasswordRequest sets a user's password; NotifyUser emails them
+// that it was reset
+type AdminResetPasswordRequest struct {
+	NewPassword string `json:"new_password" validate:"required"`
+	NotifyUser  bool   `json:"notify_user"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
 }
 
 type MessageResponse struct {
 	Message string `json:"message"`
 }
 
+type LoginEventResponse struct {
+	ID        string    `json:"id"`
+	IPAddress string    `json:"ip_address"`
+	UserAgent string    `json:"user_agent"`
+	Success   bool      `json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type UserManagementResponse struct {
-	ID        string   `json:"id"`
-	Email     string   `json:"email"`
-	Name      string   `json:"name"`
-	Phone     *string  `json:"phone"`
-	Company   *string  `json:"company"`
-	Roles     []string `json:"roles"`
-	CreatedAt string   `json:"created_at"`
-	UpdatedAt string   `json:"updated_at"`
+	ID                  string           `json:"id"`
+	Email               string           `json:"email"`
+	Name                string           `json:"name"`
+	Phone               *string          `json:"phone"`
+	CompanyID           *string          `json:"company_id"`
+	Company             *CompanyResponse `json:"company"`
+	AvatarURL           *string          `json:"avatar_url"`
+	Roles               []string         `json:"roles"`
+	Tags                []string         `json:"tags"`
+	IsActive            bool             `json:"is_active"`
+	EmailDeliveryStatus *string          `json:"email_delivery_status"`
+	// TokenExpiryOverride is the user's token lifetime in seconds, or nil
+	// when JWT_EXPIRATION applies
+	TokenExpiryOverride *int64           `json:"token_expiry_override"`
+	LastLoginAt         *time.Time       `json:"last_login_at"`
+	CreatedAt           string           `json:"created_at"`
+	UpdatedAt           string           `json:"updated_at"`
+	DeletedAt           *time.Time       `json:"deleted_at,omitempty"`
+}
+
+// MaskingModel names the model field masking rules apply to
+func (UserManagementResponse) MaskingModel() string {
+	return "user"
+}
+
+// UserDetailResponse is a single user with the permissions granted through
+// their roles
+type UserDetailResponse struct {
+	UserManagementResponse
+	EffectivePermissions []PermissionResponse `json:"effective_permissions"`
 }
 
 type UpdateRolesRequest struct {
-	Roles []string `json:"roles" validate:"required,min=1"`
+	Roles     []string             `json:"roles" validate:"required,min=1"`
+	ExpiresAt map[string]time.Time `json:"expires_at,omitempty"`
 }
 
+type BulkRoleUpdateEntry struct {
+	UserID string   `json:"user_id" validate:"required,uuid"`
+	Roles  []string `json:"roles" validate:"required,min=1"`
+}
+
+type BulkUpdateRolesRequest struct {
+	Updates   []BulkRoleUpdateEntry `json:"updates" validate:"required,min=1,max=100,unique=UserID,dive"`
+	GrantedBy string                `json:"granted_by,omitempty" validate:"omitempty,uuid"`
+}
+
+type BulkRoleUpdateResult struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkUpdateRolesResponse struct {
+	Results   []BulkRoleUpdateResult `json:"results"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+}
+
+type RoleAssignmentResponse struct {
+	RoleID    string     `json:"role_id"`
+	RoleName  string     `json:"role_name"`
+	GrantedAt time.Time  `json:"granted_at"`
+	GrantedBy *string    `json:"granted_by"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	IsExpired bool       `json:"is_expired"`
+}
+
+// UpdateUserRequest updates a user's details. An empty CompanyID removes the
+// user from their company.
 type UpdateUserRequest struct {
-	Email   *string `json:"email,omitempty" validate:"omitempty,email"`
-	Name    *string `json:"name,omitempty" validate:"omitempty,min=2"`
-	Phone   *string `json:"phone,omitempty" validate:"omitempty,phone"`
-	Company *string `json:"company,omitempty"`
+	Email     *string `json:"email,omitempty" validate:"omitempty,email"`
+	Name      *string `json:"name,omitempty" validate:"omitempty,min=2"`
+	Phone     *string `json:"phone,omitempty" validate:"omitempty,phone"`
+	CompanyID *string `json:"company_id,omitempty" validate:"omitempty,len=0|uuid"`
+}
+
+// PatchUserRequest partially updates a user with JSON Merge Patch semantics:
+// absent fields are left unchanged and null clears the phone or company
+type PatchUserRequest struct {
+	Email     NullableString `json:"email,omitzero" validate:"omitempty,email"`
+	Name      NullableString `json:"name,omitzero" validate:"omitempty,min=2"`
+	Phone     NullableString `json:"phone,omitzero"`
+	CompanyID NullableString `json:"company_id,omitzero" validate:"omitempty,uuid"`
 }
 
 type AdminRegisterUserRequest struct {
-	Email    string   `json:"email" validate:"required,email"`
-	Password string   `json:"password" validate:"required,min=6"`
-	Name     string   `json:"name" validate:"required,min=2"`
-	Phone    *string  `json:"phone,omitempty" validate:"omitempty,phone"`
-	Company  *string  `json:"company,omitempty"`
-	Roles    []string `json:"roles,omitempty" validate:"omitempty,min=1"`
+	Email     string   `json:"email" validate:"required,email"`
+	Password  string   `json:"password" validate:"required"`
+	Name      string   `json:"name" validate:"required,min=2"`
+	Phone     *string  `json:"phone,omitempty" validate:"omitempty,phone"`
+	CompanyID *string  `json:"company_id,omitempty" validate:"omitempty,uuid"`
+	Roles     []string `json:"roles,omitempty" validate:"omitempty,min=1"`
+}
+
+// UpdateTokenSettingsRequest sets how long a user's tokens are valid. Zero
+// restores the JWT_EXPIRATION default.
+type UpdateTokenSettingsRequest struct {
+	TokenExpirySeconds *int64 `json:"token_expiry_seconds" validate:"required,min=0"`
+}
+
+// MergeUsersRequest names the duplicate account to fold into the user in the
+// path and what to copy from it
+type MergeUsersRequest struct {
+	SourceUserID        string `json:"source_user_id" validate:"required,uuid"`
+	TransferRoles       bool   `json:"transfer_roles"`
+	TransferPreferences bool   `json:"transfer_preferences"`
+}
+
+type MergeUsersResponse struct {
+	TargetUserID           string `json:"target_user_id"`
+	SourceUserID           string `json:"source_user_id"`
+	RolesTransferred       int64  `json:"roles_transferred"`
+	PreferencesTransferred int64  `json:"preferences_transferred"`
 }
 
 type PaginationRequest struct {
-	Page     int    `json:"page" form:"page" validate:"omitempty,min=1"`
-	Limit    int    `json:"limit" form:"limit" validate:"omitempty,min=1,max=100"`
-	Search   string `json:"search" form:"search"`
-	SortBy   string `json:"sort_by" form:"sort_by"`
-	SortDesc bool   `json:"sort_desc" form:"sort_desc"`
+	Page           int    `json:"page" query:"page" form:"page" validate:"omitempty,min=1"`
+	Limit          int    `json:"limit" query:"limit" form:"limit" validate:"omitempty,min=1,max=100"`
+	Search         string `json:"search" query:"search" form:"search"`
+	CompanyID      string `json:"company_id" query:"company_id" form:"company_id" validate:"omitempty,uuid"`
+	IncludeDeleted bool   `json:"include_deleted" query:"include_deleted" form:"include_deleted"`
+	SortBy         string `json:"sort_by" query:"sort_by" form:"sort_by"`
+	SortDesc       bool   `json:"sort_desc" query:"sort_desc" form:"sort_desc"`
+	After          string `json:"after" query:"after" form:"after"`
+	Pagination     string `json:"pagination" query:"pagination" form:"pagination"`
+	Tag            string `json:"tag" query:"tag" form:"tag"`
+}
+
+// RoleUsersRequest pages through the users holding a role
+type RoleUsersRequest struct {
+	Page   int    `json:"page" query:"page" form:"page" validate:"omitempty,min=1"`
+	Limit  int    `json:"limit" query:"limit" form:"limit" validate:"omitempty,min=1,max=100"`
+	Search string `json:"search" query:"search" form:"search"`
 }
 
+// UserExportRequest selects the users in an export like the user list, and
+// the file format
+type UserExportRequest struct {
+	Format         string `json:"format" query:"format" form:"format" validate:"omitempty,oneof=csv json"`
+	Search         string `json:"search" query:"search" form:"search"`
+	CompanyID      string `json:"company_id" query:"company_id" form:"company_id" validate:"omitempty,uuid"`
+	IncludeDeleted bool   `json:"include_deleted" query:"include_deleted" form:"include_deleted"`
+	SortBy         string `json:"sort_by" query:"sort_by" form:"sort_by"`
+	SortDesc       bool   `json:"sort_desc" query:"sort_desc" form:"sort_desc"`
+}
+
+// PaginatedUsersResponse is the deprecated offset-paginated user list
 type PaginatedUsersResponse struct {
-	Users      []UserManagementResponse `json:"users"`
-	Total      int64                    `json:"total"`
-	Page       int                      `json:"page"`
-	Limit      int                      `json:"limit"`
-	TotalPages int                      `json:"total_pages"`
-}
\ No newline at end of file
+	Users          []UserManagementResponse `json:"users"`
+	Total          int64                    `json:"total"`
+	Page           int                      `json:"page"`
+	Limit          int                      `json:"limit"`
+	TotalPages     int                      `json:"total_pages"`
+	PaginationType string                   `json:"pagination_type"`
+}
+
+// CursorPaginatedUsersResponse is a page of users; pass NextCursor as the
+// after parameter to fetch the next page
+type CursorPaginatedUsersResponse struct {
+	Users          []UserManagementResponse `json:"users"`
+	Limit          int                      `json:"limit"`
+	NextCursor     *string                  `json:"next_cursor"`
+	HasMore        bool                     `json:"has_more"`
+	PaginationType string                   `json:"pagination_type"`
+}
+type UserImportFailure struct {
+	Row   int    `json:"row"`
+	Email string `json:"email,omitempty"`
+	Error string `json:"error"`
+}
+
+type UserImportResponse struct {
+	Total    int                 `json:"total"`
+	Imported int                 `json:"imported"`
+	Skipped  int                 `json:"skipped"`
+	Failures []UserImportFailure `json:"failures"`
+}
+
+// JWK is an RSA public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
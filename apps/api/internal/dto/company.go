@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+type CreateCompanyRequest struct {
+	Name    string  `json:"name" validate:"required,min=1,max=255"`
+	Domain  *string `json:"domain,omitempty" validate:"omitempty,fqdn,max=255"`
+	Website *string `json:"website,omitempty" validate:"omitempty,url,max=255"`
+}
+
+// UpdateCompanyRequest updates a company. An empty Domain or Website clears it.
+type UpdateCompanyRequest struct {
+	Name    *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Domain  *string `json:"domain,omitempty" validate:"omitempty,max=255,len=0|fqdn"`
+	Website *string `json:"website,omitempty" validate:"omitempty,max=255,len=0|url"`
+}
+
+type CompanyResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Domain    *string   `json:"domain"`
+	Website   *string   `json:"website"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// UserDataExport is the downloadable copy of a user's personal data
+type UserDataExport struct {
+	ExportedAt          time.Time                  `json:"exported_at"`
+	Profile             UserManagementResponse     `json:"profile"`
+	RoleAssignments     []RoleAssignmentResponse   `json:"role_assignments"`
+	AuditLogs           []AuditLogResponse         `json:"audit_logs"`
+	PasswordResetTokens []PasswordResetTokenExport `json:"password_reset_tokens"`
+	LoginHistory        []LoginEventResponse       `json:"login_history"`
+}
+
+// PasswordResetTokenExport omits the token hash, which is a credential
+type PasswordResetTokenExport struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type EraseAccountRequest struct {
+	Confirm string `json:"confirm" validate:"required"`
+}
@@ -4,30 +4,41 @@ import "api/internal/models"
 
 type CreateEmailTemplateRequest struct {
 	Name         string                      `json:"name" validate:"required,min=1,max=100"`
+	Language     string                      `json:"language" validate:"omitempty,bcp47_language_tag"`
 	Subject      string                      `json:"subject" validate:"required,max=500"`
 	HTMLTemplate string                      `json:"html_template" validate:"required"`
 	TextTemplate string                      `json:"text_template" validate:"required"`
 	Variables    models.TemplateVariables    `json:"variables"`
 	IsActive     *bool                       `json:"is_active,omitempty"`
+	IsProtected  *bool                       `json:"is_protected,omitempty"`
 }
 
 type UpdateEmailTemplateRequest struct {
 	Name         *string                     `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Language     string                      `json:"language,omitempty" validate:"omitempty,bcp47_language_tag"`
 	Subject      *string                     `json:"subject,omitempty" validate:"omitempty,max=500"`
 	HTMLTemplate *string                     `json:"html_template,omitempty"`
 	TextTemplate *string                     `json:"text_template,omitempty"`
 	Variables    models.TemplateVariables    `json:"variables,omitempty"`
 	IsActive     *bool                       `json:"is_active,omitempty"`
+	IsProtected  *bool                       `json:"is_protected,omitempty"`
+}
+
+type CloneEmailTemplateRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
 }
 
 type EmailTemplateResponse struct {
 	ID           string                      `json:"id"`
 	Name         string                      `json:"name"`
+	Language     string                      `json:"language"`
 	Subject      string                      `json:"subject"`
 	HTMLTemplate string                      `json:"html_template"`
 	TextTemplate string                      `json:"text_template"`
 	Variables    models.TemplateVariables    `json:"variables"`
 	IsActive     bool                        `json:"is_active"`
+	IsProtected  bool                        `json:"is_protected"`
+	Version      int                         `json:"version"`
 	CreatedAt    string                      `json:"created_at"`
 	UpdatedAt    string                      `json:"updated_at"`
 }
@@ -35,9 +46,11 @@ type EmailTemplateResponse struct {
 type EmailTemplateListResponse struct {
 	ID        string                      `json:"id"`
 	Name      string                      `json:"name"`
+	Language  string                      `json:"language"`
 	Subject   string                      `json:"subject"`
 	Variables models.TemplateVariables    `json:"variables"`
 	IsActive  bool                        `json:"is_active"`
+	IsProtected bool                      `json:"is_protected"`
 	CreatedAt string                      `json:"created_at"`
 	UpdatedAt string                      `json:"updated_at"`
 }
@@ -59,4 +72,14 @@ type TestEmailTemplateRequest struct {
 
 type TemplateVariablesResponse struct {
 	Variables []models.TemplateVariable `json:"variables"`
-}
\ No newline at end of file
+}
+type EmailTemplateVersionResponse struct {
+	ID            string                   `json:"id"`
+	VersionNumber int                      `json:"version_number"`
+	Subject       string                   `json:"subject"`
+	HTMLTemplate  string                   `json:"html_template"`
+	TextTemplate  string                   `json:"text_template"`
+	Variables     models.TemplateVariables `json:"variables"`
+	CreatedBy     *string                  `json:"created_by"`
+	CreatedAt     string                   `json:"created_at"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+type CreateInvitationRequest struct {
+	Email string   `json:"email" validate:"required,email"`
+	Roles []string `json:"roles,omitempty"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Name     string `json:"name" validate:"required,min=2"`
+	Password string `json:"password" validate:"required"`
+}
+
+type InvitationResponse struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Roles     []string  `json:"roles"`
+	InvitedBy *string   `json:"invited_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+type MaintenanceModeRequest struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
+
+type MaintenanceModeResponse struct {
+	Enabled bool `json:"enabled"`
+}
+
+// DefaultRoleRequest sets the role assigned to newly registered users. An
+// empty role makes new users start without one.
+type DefaultRoleRequest struct {
+	Role *string `json:"role" validate:"required,max=50"`
+}
+
+type DefaultRoleResponse struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// NullableString is a JSON Merge Patch (RFC 7396) string field. Set is false
+// when the key is absent, and Value is nil when it was sent as null.
+type NullableString struct {
+	Set   bool
+	Value *string
+}
+
+func (n *NullableString) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		n.Value = nil
+		return nil
+	}
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// MarshalJSON encodes the value, or null when it is not set; tag fields with
+// omitzero to leave absent ones out
+func (n NullableString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Value)
+}
+
+// Clears reports whether the field was sent as null or as an empty string
+func (n NullableString) Clears() bool {
+	return n.Set && (n.Value == nil || *n.Value == "")
+}
+
+// ValidationValue returns the string validate tags apply to, or nil when the
+// field is absent or null
+func (n NullableString) ValidationValue() interface{} {
+	if n.Value == nil {
+		return nil
+	}
+	return *n.Value
+}
+
+// NewNullableString returns a NullableString set to value, or to null when
+// value is nil
+func NewNullableString(value *string) NullableString {
+	return NullableString{Set: true, Value: value}
+}
+
+// NullableTime is a JSON Merge Patch (RFC 7396) timestamp field. Set is false
+// when the key is absent, and Value is nil when it was sent as null.
+type NullableTime struct {
+	Set   bool
+	Value *time.Time
+}
+
+func (n *NullableTime) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		n.Value = nil
+		return nil
+	}
+	var value time.Time
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	n.Value = &value
+	return nil
+}
+
+// MarshalJSON encodes the value, or null when it is not set; tag fields with
+// omitzero to leave absent ones out
+func (n NullableTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.Value)
+}
+
+// Clears reports whether the field was sent as null
+func (n NullableTime) Clears() bool {
+	return n.Set && n.Value == nil
+}
+
+// NewNullableTime returns a NullableTime set to value, or to null when value
+// is nil
+func NewNullableTime(value *time.Time) NullableTime {
+	return NullableTime{Set: true, Value: value}
+}
@@ -0,0 +1,16 @@
+package dto
+
+import "encoding/json"
+
+type UpdatePreferenceRequest struct {
+	Value json.RawMessage `json:"value" validate:"required"`
+}
+
+type PreferencesResponse struct {
+	Preferences map[string]json.RawMessage `json:"preferences"`
+}
+
+type PreferenceResponse struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
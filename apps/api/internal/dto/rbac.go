@@ -3,39 +3,146 @@ package dto
 import "time"
 
 // Permission DTOs
+
+// CreatePermissionRequest creates a permission. Metadata holds UI hints such
+// as {"dangerous":true,"icon":"trash"}.
 type CreatePermissionRequest struct {
-	Name        string  `json:"name" validate:"required,min=3,max=100"`
-	Resource    string  `json:"resource" validate:"required,min=2,max=100"`
-	Action      string  `json:"action" validate:"required,min=2,max=50"`
-	Description *string `json:"description,omitempty"`
+	Name        string                 `json:"name" validate:"required,min=3,max=100"`
+	Resource    string                 `json:"resource" validate:"required,min=2,max=100"`
+	Action      string                 `json:"action" validate:"required,min=2,max=50"`
+	Description *string                `json:"description,omitempty"`
+	CategoryID  *string                `json:"category_id,omitempty" validate:"omitempty,uuid"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// UpdatePermissionRequest updates a permission. An empty CategoryID removes
+// the permission from its category; Metadata replaces the stored metadata,
+// and an empty object clears it.
 type UpdatePermissionRequest struct {
-	Name        *string `json:"name,omitempty" validate:"omitempty,min=3,max=100"`
-	Resource    *string `json:"resource,omitempty" validate:"omitempty,min=2,max=100"`
-	Action      *string `json:"action,omitempty" validate:"omitempty,min=2,max=50"`
-	Description *string `json:"description,omitempty"`
+	Name        *string                `json:"name,omitempty" validate:"omitempty,min=3,max=100"`
+	Resource    *string                `json:"resource,omitempty" validate:"omitempty,min=2,max=100"`
+	Action      *string                `json:"action,omitempty" validate:"omitempty,min=2,max=50"`
+	Description *string                `json:"description,omitempty"`
+	CategoryID  *string                `json:"category_id,omitempty" validate:"omitempty,len=0|uuid"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PermissionListRequest filters the permission list by category
+type PermissionListRequest struct {
+	CategoryID string `json:"category_id" query:"category_id" validate:"omitempty,uuid"`
+}
+
+// CheckPermissionsRequest lists the permissions to check, at most 50
+type CheckPermissionsRequest struct {
+	Permissions []string `json:"permissions" validate:"max=50,dive,required"`
+}
+
+// CheckPermissionsResponse reports whether the caller has each requested
+// permission
+type CheckPermissionsResponse struct {
+	Results map[string]bool `json:"results"`
+}
+
+type PermissionRolesRequest struct {
+	RoleIDs []string `json:"role_ids" validate:"required,min=1,dive,uuid"`
+}
+
+type AssignPermissionToRolesResponse struct {
+	Assigned int      `json:"assigned"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors"`
+}
+
+type RemovePermissionFromRolesResponse struct {
+	Removed int      `json:"removed"`
+	Skipped int      `json:"skipped"`
+	Errors  []string `json:"errors"`
 }
 
 type PermissionResponse struct {
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Resource       string                 `json:"resource"`
+	Action         string                 `json:"action"`
+	Description    *string                `json:"description"`
+	CategoryID     *string                `json:"category_id"`
+	CategoryName   *string                `json:"category_name"`
+	Metadata       map[string]interface{} `json:"metadata"`
+	CreatedByEmail *string                `json:"created_by_email"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// Permission category DTOs
+type CreatePermissionCategoryRequest struct {
+	Name        string  `json:"name" validate:"required,min=2,max=100"`
+	Description *string `json:"description,omitempty"`
+}
+
+type UpdatePermissionCategoryRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=100"`
+	Description *string `json:"description,omitempty"`
+}
+
+type PermissionCategoryResponse struct {
 	ID          string    `json:"id"`
 	Name        string    `json:"name"`
-	Resource    string    `json:"resource"`
-	Action      string    `json:"action"`
 	Description *string   `json:"description"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // Role DTOs
+// CreateRoleRequest creates a role. DataScope limits the users the role's
+// holders can list and update, such as {"company_id":"..."}.
 type CreateRoleRequest struct {
+	Name                   string                 `json:"name" validate:"required,min=2,max=50"`
+	Description            *string                `json:"description,omitempty"`
+	UseWildcardPermissions bool                   `json:"use_wildcard_permissions,omitempty"`
+	DataScope              map[string]interface{} `json:"data_scope,omitempty"`
+}
+
+type CloneRoleRequest struct {
 	Name        string  `json:"name" validate:"required,min=2,max=50"`
 	Description *string `json:"description,omitempty"`
 }
 
+// UpdateRoleRequest updates a role. AccessWindows replaces the role's access
+// windows; an empty list makes the role always active.
 type UpdateRoleRequest struct {
-	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=50"`
-	Description *string `json:"description,omitempty"`
+	Name                   *string         `json:"name,omitempty" validate:"omitempty,min=2,max=50"`
+	Description            *string         `json:"description,omitempty"`
+	UseWildcardPermissions *bool           `json:"use_wildcard_permissions,omitempty"`
+	AccessWindows          *[]AccessWindow `json:"access_windows,omitempty" validate:"omitempty,max=20,dive"`
+}
+
+// AccessWindow is a weekly period during which a role is active. Days are Mon
+// to Sun, every day when empty; Start and End are HH:MM times in the TZ time
+// zone, UTC when empty. A window whose end is not after its start runs past
+// midnight.
+type AccessWindow struct {
+	Days  []string `json:"days"`
+	Start string   `json:"start" validate:"required"`
+	End   string   `json:"end" validate:"required"`
+	TZ    string   `json:"tz"`
+}
+
+// RoleListRequest filters and paginates the role list. Search matches the role
+// name or description; HasPermission keeps roles granted that permission ID.
+type RoleListRequest struct {
+	Page          int    `json:"page" query:"page" validate:"omitempty,min=1"`
+	Limit         int    `json:"limit" query:"limit" validate:"omitempty,min=1,max=100"`
+	Search        string `json:"search" query:"search"`
+	HasPermission string `json:"has_permission" query:"has_permission" validate:"omitempty,uuid"`
+}
+
+type PaginatedRolesResponse struct {
+	Roles          []RoleResponse `json:"roles"`
+	Total          int64          `json:"total"`
+	Page           int            `json:"page"`
+	Limit          int            `json:"limit"`
+	TotalPages     int            `json:"total_pages"`
+	PaginationType string         `json:"pagination_type"`
 }
 
 type AssignPermissionsToRoleRequest struct {
@@ -43,10 +150,48 @@ type AssignPermissionsToRoleRequest struct {
 }
 
 type RoleResponse struct {
-	ID          string               `json:"id"`
-	Name        string               `json:"name"`
-	Description *string              `json:"description"`
-	Permissions []PermissionResponse `json:"permissions,omitempty"`
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
-}
\ No newline at end of file
+	ID                     string                 `json:"id"`
+	Name                   string                 `json:"name"`
+	Description            *string                `json:"description"`
+	UseWildcardPermissions bool                   `json:"use_wildcard_permissions"`
+	AccessWindows          []AccessWindow         `json:"access_windows"`
+	DataScope              map[string]interface{} `json:"data_scope"`
+	Permissions            []PermissionResponse   `json:"permissions,omitempty"`
+	CreatedByEmail         *string                `json:"created_by_email"`
+	CreatedAt              time.Time              `json:"created_at"`
+	UpdatedAt              time.Time              `json:"updated_at"`
+}
+
+// RBAC export DTOs
+
+// RBACExport is the complete role and permission configuration, used both as
+// the export response and the import request. Permissions lists every
+// permission; roles embed the permissions they have.
+type RBACExport struct {
+	Roles       []RBACExportRole       `json:"roles" validate:"dive"`
+	Permissions []RBACExportPermission `json:"permissions" validate:"dive"`
+}
+
+type RBACExportRole struct {
+	Name                   string                 `json:"name" validate:"required,min=2,max=50"`
+	Description            *string                `json:"description"`
+	UseWildcardPermissions bool                   `json:"use_wildcard_permissions"`
+	Permissions            []RBACExportPermission `json:"permissions" validate:"dive"`
+}
+
+// RBACExportPermission identifies its category by name
+type RBACExportPermission struct {
+	Name        string                 `json:"name" validate:"required,min=3,max=100"`
+	Resource    string                 `json:"resource" validate:"required,min=2,max=100"`
+	Action      string                 `json:"action" validate:"required,min=2,max=50"`
+	Description *string                `json:"description"`
+	Category    *string                `json:"category"`
+	Metadata    map[string]interface{} `json:"metadata"`
+}
+
+type RBACImportResponse struct {
+	RolesCreated       int `json:"roles_created"`
+	RolesUpdated       int `json:"roles_updated"`
+	PermissionsCreated int `json:"permissions_created"`
+	PermissionsUpdated int `json:"permissions_updated"`
+}
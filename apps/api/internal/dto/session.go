@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// SessionResponse is a token issued to the user at login. Current marks the
+// session of the token that made the request.
+type SessionResponse struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
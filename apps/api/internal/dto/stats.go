@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AdminStatsResponse struct {
+	TotalUsers          int64     `json:"total_users"`
+	ActiveUsers         int64     `json:"active_users"`
+	UsersToday          int64     `json:"users_today"`
+	TotalRoles          int64     `json:"total_roles"`
+	TotalPermissions    int64     `json:"total_permissions"`
+	TotalEmailTemplates int64     `json:"total_email_templates"`
+	ActiveAnnouncements int64     `json:"active_announcements"`
+	EmailQueueDepth     *int      `json:"email_queue_depth"`
+	DBConnectionsOpen   int       `json:"db_connections_open"`
+	DBConnectionsIdle   int       `json:"db_connections_idle"`
+	GeneratedAt         time.Time `json:"generated_at"`
+}
+
+type DBStatsResponse struct {
+	Open      int   `json:"open"`
+	InUse     int   `json:"in_use"`
+	Idle      int   `json:"idle"`
+	WaitCount int64 `json:"wait_count"`
+}
+
+type RequestQueryCountResponse struct {
+	Method  string    `json:"method"`
+	Path    string    `json:"path"`
+	Status  int       `json:"status"`
+	Queries int64     `json:"queries"`
+	At      time.Time `json:"at"`
+}
+
+type SlowRequestsResponse struct {
+	Enabled  bool                        `json:"enabled"`
+	Requests []RequestQueryCountResponse `json:"requests"`
+}
+
+type QueryPlanResponse struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+	// Plan is PostgreSQL's EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) output
+	Plan json.RawMessage `json:"plan"`
+}
+
+type TableAnalysisResponse struct {
+	Table   string              `json:"table"`
+	Queries []QueryPlanResponse `json:"queries"`
+}
+
+type TableScanStatsResponse struct {
+	Table        string  `json:"table"`
+	SeqScan      int64   `json:"seq_scan"`
+	SeqTupRead   int64   `json:"seq_tup_read"`
+	IdxScan      int64   `json:"idx_scan"`
+	LiveRows     int64   `json:"live_rows"`
+	Indexes      int64   `json:"indexes"`
+	SeqScanRatio float64 `json:"seq_scan_ratio"`
+}
+
+type MissingIndexesResponse struct {
+	MinRows int64                    `json:"min_rows"`
+	Tables  []TableScanStatsResponse `json:"tables"`
+}
+
+// DependencyHealthResponse is the health of one external dependency;
+// LatencyMs and Error are omitted for dependencies that are not configured
+type DependencyHealthResponse struct {
+	Status    string `json:"status"`
+	LatencyMs *int64 `json:"latency_ms,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
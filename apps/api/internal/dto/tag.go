@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=50"`
+}
+
+// UpdateUserTagsRequest replaces a user's tags; an empty list removes them all
+type UpdateUserTagsRequest struct {
+	Tags []string `json:"tags" validate:"required,unique,dive,required,max=50"`
+}
+
+type TagResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
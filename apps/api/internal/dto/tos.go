@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+type PublishToSVersionRequest struct {
+	Version       string     `json:"version" validate:"required,max=50"`
+	Content       string     `json:"content" validate:"required"`
+	EffectiveDate *time.Time `json:"effective_date,omitempty"`
+}
+
+type AcceptToSRequest struct {
+	ToSVersion string `json:"tos_version" validate:"required,max=50"`
+}
+
+type ToSVersionResponse struct {
+	ID            string    `json:"id"`
+	Version       string    `json:"version"`
+	Content       string    `json:"content"`
+	EffectiveDate time.Time `json:"effective_date"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,18 @@
+package dto
+
+type AddEmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// UserEmailResponse is one of a user's email addresses. The primary address
+// is the one the user logs in with.
+type UserEmailResponse struct {
+	ID       string `json:"id"`
+	Address  string `json:"address"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
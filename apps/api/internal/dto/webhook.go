@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateWebhookRequest struct {
+	URL      string   `json:"url" validate:"required,url,max=2048"`
+	Events   []string `json:"events" validate:"required,min=1,dive,oneof=user.created user.updated user.deleted user.roles_updated role.created role.updated role.deleted"`
+	Secret   string   `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+	IsActive *bool    `json:"is_active,omitempty"`
+}
+
+type UpdateWebhookRequest struct {
+	URL      *string  `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Events   []string `json:"events,omitempty" validate:"omitempty,min=1,dive,oneof=user.created user.updated user.deleted user.roles_updated role.created role.updated role.deleted"`
+	Secret   *string  `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`
+	IsActive *bool    `json:"is_active,omitempty"`
+}
+
+type WebhookResponse struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateWebhookResponse holds a newly created webhook. Secret is only ever
+// returned here; receivers use it to verify the X-Studio45-Signature header.
+type CreateWebhookResponse struct {
+	Secret  string          `json:"secret"`
+	Webhook WebhookResponse `json:"webhook"`
+}
+
+// WebhookDeliveryResponse is one attempt to deliver an event to a webhook.
+// ResponseStatus and ResponseBody are null when no response was received, in
+// which case Error says why.
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`
+	WebhookID      string          `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	ResponseStatus *int            `json:"response_status"`
+	ResponseBody   *string         `json:"response_body"`
+	Error          *string         `json:"error"`
+	Succeeded      bool            `json:"succeeded"`
+	ReplayOf       *string         `json:"replay_of"`
+	AttemptedAt    time.Time       `json:"attempted_at"`
+}
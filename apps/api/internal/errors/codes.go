@@ -0,0 +1,122 @@
+// Package errors defines the machine-readable codes returned in error
+// responses, so clients can tell failures apart without matching on messages.
+// Import it as apperrors to keep the standard library's errors package
+// available.
+package errors
+
+import "net/http"
+
+// Generic codes, used when a response does not name a more specific one
+const (
+	ErrValidation         = "VALIDATION_FAILED"
+	ErrUnauthorized       = "UNAUTHORIZED"
+	ErrForbidden          = "FORBIDDEN"
+	ErrNotFound           = "NOT_FOUND"
+	ErrMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	ErrConflict           = "CONFLICT"
+	ErrRateLimited        = "RATE_LIMITED"
+	ErrInternal           = "INTERNAL_ERROR"
+	ErrServiceUnavailable = "SERVICE_UNAVAILABLE"
+)
+
+// Request codes
+const (
+	ErrInvalidRequestBody    = "INVALID_REQUEST_BODY"
+	ErrRequestTooLarge       = "REQUEST_TOO_LARGE"
+	ErrUnsupportedMediaType  = "UNSUPPORTED_MEDIA_TYPE"
+	ErrIdempotencyKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	ErrIdempotencyInProgress = "IDEMPOTENCY_IN_PROGRESS"
+	ErrMaintenanceMode       = "MAINTENANCE_MODE"
+	ErrRequestTimeout        = "REQUEST_TIMEOUT"
+	ErrDisposableEmail       = "DISPOSABLE_EMAIL_NOT_ALLOWED"
+)
+
+// Authentication codes
+const (
+	ErrAuthRequired                 = "AUTH_REQUIRED"
+	ErrAuthInvalidToken             = "AUTH_INVALID_TOKEN"
+	ErrAuthInvalidCredentials       = "AUTH_INVALID_CREDENTIALS"
+	ErrAuthIncorrectPassword        = "AUTH_INCORRECT_PASSWORD"
+	ErrAuthAccountSuspended         = "AUTH_ACCOUNT_SUSPENDED"
+	ErrAuthTooManyAttempts          = "AUTH_TOO_MANY_ATTEMPTS"
+	ErrAuthInvalidResetToken        = "AUTH_INVALID_RESET_TOKEN"
+	ErrAuthInvalidVerificationToken = "AUTH_INVALID_VERIFICATION_TOKEN"
+	ErrAuthInvalidInvitation        = "AUTH_INVALID_INVITATION"
+	ErrAuthInvalidAPIKey            = "AUTH_INVALID_API_KEY"
+	ErrAuthInvalidSignature         = "AUTH_INVALID_SIGNATURE"
+	ErrAuthSessionRevoked           = "AUTH_SESSION_REVOKED"
+)
+
+// Authorization codes
+const (
+	ErrPermissionDenied        = "PERMISSION_DENIED"
+	ErrAPIKeyNotAllowed        = "API_KEY_NOT_ALLOWED"
+	ErrAPIKeyScopeMissing      = "API_KEY_SCOPE_MISSING"
+	ErrIPNotAllowed            = "IP_NOT_ALLOWED"
+	ErrOriginNotAllowed        = "ORIGIN_NOT_ALLOWED"
+	ErrToSAcceptanceRequired   = "TOS_ACCEPTANCE_REQUIRED"
+	ErrDataScopeViolation      = "DATA_SCOPE_VIOLATION"
+	ErrImpersonationNotAllowed = "IMPERSONATION_NOT_ALLOWED"
+)
+
+// Resource codes
+const (
+	ErrUserNotFound                 = "USER_NOT_FOUND"
+	ErrRoleNotFound                 = "ROLE_NOT_FOUND"
+	ErrPermissionNotFound           = "PERMISSION_NOT_FOUND"
+	ErrPermissionCategoryNotFound   = "PERMISSION_CATEGORY_NOT_FOUND"
+	ErrEmailTemplateNotFound        = "EMAIL_TEMPLATE_NOT_FOUND"
+	ErrEmailTemplateVersionNotFound = "EMAIL_TEMPLATE_VERSION_NOT_FOUND"
+	ErrCompanyNotFound              = "COMPANY_NOT_FOUND"
+	ErrWebhookNotFound              = "WEBHOOK_NOT_FOUND"
+	ErrWebhookDeliveryNotFound      = "WEBHOOK_DELIVERY_NOT_FOUND"
+	ErrEmailNotFound                = "EMAIL_NOT_FOUND"
+	ErrAPIKeyNotFound               = "API_KEY_NOT_FOUND"
+	ErrSessionNotFound              = "SESSION_NOT_FOUND"
+	ErrAvatarNotFound               = "AVATAR_NOT_FOUND"
+	ErrPreferenceNotFound           = "PREFERENCE_NOT_FOUND"
+	ErrToSNotFound                  = "TOS_NOT_FOUND"
+	ErrAnnouncementNotFound         = "ANNOUNCEMENT_NOT_FOUND"
+	ErrTagNotFound                  = "TAG_NOT_FOUND"
+	ErrEmailTaken                   = "EMAIL_TAKEN"
+	ErrRoleExists                   = "ROLE_EXISTS"
+	ErrPermissionExists             = "PERMISSION_EXISTS"
+	ErrPermissionCategoryExists     = "PERMISSION_CATEGORY_EXISTS"
+	ErrEmailTemplateExists          = "EMAIL_TEMPLATE_EXISTS"
+	ErrCompanyExists                = "COMPANY_EXISTS"
+	ErrToSVersionExists             = "TOS_VERSION_EXISTS"
+	ErrTagExists                    = "TAG_EXISTS"
+	ErrWebhookDeliverySucceeded     = "WEBHOOK_DELIVERY_SUCCEEDED"
+	ErrWebhookReplayLimit           = "WEBHOOK_REPLAY_LIMIT"
+	ErrWebhookReplayFailed          = "WEBHOOK_REPLAY_FAILED"
+)
+
+// CodeForStatus returns the generic code for an HTTP error status
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrValidation
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrMethodNotAllowed
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusRequestEntityTooLarge:
+		return ErrRequestTooLarge
+	case http.StatusUnsupportedMediaType:
+		return ErrUnsupportedMediaType
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusServiceUnavailable:
+		return ErrServiceUnavailable
+	}
+	if status >= 500 {
+		return ErrInternal
+	}
+	return ErrValidation
+}
@@ -4,18 +4,36 @@ import (
 	"api/internal/auth"
 	"api/internal/database"
 	"api/internal/dto"
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
+	"api/internal/logger"
 	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/pkg/phonenumbers"
 	"api/internal/services"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-// ListUsers returns all users with pagination (admin only)
+// Pagination types reported in the user list envelope
+const (
+	paginationTypeOffset = "offset"
+	paginationTypeCursor = "cursor"
+)
+
+// ListUsers returns all users with pagination (admin only). Pass after, or
+// pagination=cursor for the first page, to use cursor pagination; page-based
+// offset pagination is deprecated.
+// @openapi tag Users
+// @openapi query dto.PaginationRequest
+// @openapi response 200 dto.PaginatedUsersResponse|dto.CursorPaginatedUsersResponse
+// @openapi response 400
 func ListUsers(c *fiber.Ctx) error {
 	// Parse pagination parameters
 	var paginationReq dto.PaginationRequest
@@ -33,50 +51,168 @@ func ListUsers(c *fiber.Ctx) error {
 	if paginationReq.Limit > 100 {
 		paginationReq.Limit = 100
 	}
+	if paginationReq.CompanyID != "" {
+		if _, err := uuid.Parse(paginationReq.CompanyID); err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid company_id")
+		}
+	}
+
+	if paginationReq.After != "" || paginationReq.Pagination == paginationTypeCursor {
+		return listUsersByCursor(c, paginationReq)
+	}
+
+	filter, err := userListFilter(c, paginationReq)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
 
 	rbacService := services.NewRBACService()
 	
 	// Get users with pagination
-	users, total, err := rbacService.GetUsersWithRolesPaginated(
+	users, total, err := rbacService.GetUsersWithRolesPaginated(c.UserContext(),
 		paginationReq.Page,
 		paginationReq.Limit,
-		paginationReq.Search,
+		filter,
+		paginationReq.SortBy,
+		paginationReq.SortDesc,
+	)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
+
+	// Calculate total pages
+	totalPages := int((total + int64(paginationReq.Limit) - 1) / int64(paginationReq.Limit))
+
+	response := dto.PaginatedUsersResponse{
+		Users:          toUserListResponses(users),
+		Total:          total,
+		Page:           paginationReq.Page,
+		Limit:          paginationReq.Limit,
+		TotalPages:     totalPages,
+		PaginationType: paginationTypeOffset,
+	}
+
+	masked, err := maskResponse(c, response)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, masked)
+}
+
+func listUsersByCursor(c *fiber.Ctx, paginationReq dto.PaginationRequest) error {
+	var after *services.PaginationCursor
+	if paginationReq.After != "" {
+		cursor, err := services.DecodePaginationCursor(paginationReq.After)
+		if err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid cursor")
+		}
+		after = cursor
+	}
+
+	filter, err := userListFilter(c, paginationReq)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
+
+	rbacService := services.NewRBACService()
+	users, next, err := rbacService.GetUsersWithRolesCursor(c.UserContext(),
+		after,
+		paginationReq.Limit,
+		filter,
 		paginationReq.SortBy,
 		paginationReq.SortDesc,
 	)
 	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedCursorSort) {
+			return helpers.ValidationErrorResponse(c, "Cursor pagination only supports sort_by=created_at")
+		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
 	}
 
+	response := dto.CursorPaginatedUsersResponse{
+		Users:          toUserListResponses(users),
+		Limit:          paginationReq.Limit,
+		HasMore:        next != nil,
+		PaginationType: paginationTypeCursor,
+	}
+	if next != nil {
+		token := next.Encode()
+		response.NextCursor = &token
+	}
+
+	masked, err := maskResponse(c, response)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, masked)
+}
+
+// userListFilter returns the user list filter for paginationReq, limited to
+// the users the caller's data scopes allow
+func userListFilter(c *fiber.Ctx, paginationReq dto.PaginationRequest) (services.UserListFilter, error) {
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return services.UserListFilter{}, err
+	}
+
+	return services.UserListFilter{
+		Search:         paginationReq.Search,
+		CompanyID:      paginationReq.CompanyID,
+		IncludeDeleted: paginationReq.IncludeDeleted,
+		Tag:            paginationReq.Tag,
+		DataScopes:     scopes,
+	}, nil
+}
+
+func toUserListResponses(users []models.User) []dto.UserManagementResponse {
 	var userResponses []dto.UserManagementResponse
 	for _, user := range users {
 		userResponses = append(userResponses, dto.UserManagementResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			Phone:     user.Phone,
-			Company:   user.Company,
-			Roles:     user.GetRoleNames(),
-			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+			ID:                  user.ID,
+			Email:               user.Email,
+			Name:                user.Name,
+			Phone:               user.Phone,
+			CompanyID:           user.CompanyID,
+			Company:             toCompanyResponse(user.Company),
+			AvatarURL:           user.AvatarURL,
+			Roles:               user.GetRoleNames(),
+			Tags:                user.GetTagNames(),
+			IsActive:            user.IsActive,
+			EmailDeliveryStatus: user.EmailDeliveryStatus,
+			TokenExpiryOverride: tokenExpirySeconds(user.TokenExpiryOverride),
+			LastLoginAt:         user.LastLoginAt,
+			CreatedAt:           user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:           user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+			DeletedAt:           deletedAt(user.DeletedAt),
 		})
 	}
+	return userResponses
+}
 
-	// Calculate total pages
-	totalPages := int((total + int64(paginationReq.Limit) - 1) / int64(paginationReq.Limit))
-
-	response := dto.PaginatedUsersResponse{
-		Users:      userResponses,
-		Total:      total,
-		Page:       paginationReq.Page,
-		Limit:      paginationReq.Limit,
-		TotalPages: totalPages,
+// tokenExpirySeconds returns a token lifetime override in whole seconds
+func tokenExpirySeconds(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
 	}
+	seconds := int64(d.Seconds())
+	return &seconds
+}
 
-	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+func deletedAt(d gorm.DeletedAt) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	return &d.Time
 }
 
 // UpdateUserRoles updates a user's roles (admin only)
+// @openapi tag Users
+// @openapi request dto.UpdateRolesRequest
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 404
 func UpdateUserRoles(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	if userID == "" {
@@ -85,24 +221,34 @@ func UpdateUserRoles(c *fiber.Ctx) error {
 
 	var req dto.UpdateRolesRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
 
 	// Check if user exists
-	_, err := rbacService.GetUserWithRoles(userID)
+	existingUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "User not found")
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
 	}
 
+	// Expiry may only be set for requested roles and must be in the future
+	for roleName, expiry := range req.ExpiresAt {
+		if !slices.Contains(req.Roles, roleName) {
+			return helpers.ValidationErrorResponse(c, "Expiry given for role not in roles list: "+roleName)
+		}
+		if !expiry.After(time.Now()) {
+			return helpers.ValidationErrorResponse(c, "Expiry for role "+roleName+" must be in the future")
+		}
+	}
+
 	// Prevent admin from removing their own admin role
 	currentUserID := middleware.GetUserID(c)
 	currentUserRoles := middleware.GetUserRoles(c)
@@ -131,30 +277,199 @@ func UpdateUserRoles(c *fiber.Ctx) error {
 
 	// Update user roles
 	grantedBy := currentUserID
-	err = rbacService.SetUserRoles(userID, req.Roles, &grantedBy)
+	err = rbacService.SetUserRoles(c.UserContext(), userID, req.Roles, &grantedBy, req.ExpiresAt)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to update user roles: " + err.Error())
 	}
 
+	roleChanges := map[string]interface{}{"roles": req.Roles}
+	if len(req.ExpiresAt) > 0 {
+		roleChanges["expires_at"] = req.ExpiresAt
+	}
+	recordAudit(c, services.AuditActionUserRolesUpdate, services.AuditResourceUser, userID, services.AuditDiff(
+		map[string]interface{}{"roles": existingUser.GetRoleNames()},
+		roleChanges,
+	))
+
 	// Get updated user
-	updatedUser, err := rbacService.GetUserWithRoles(userID)
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
 	}
 
-	return helpers.SuccessResponse(c, fiber.StatusOK, dto.UserManagementResponse{
-		ID:        updatedUser.ID,
-		Email:     updatedUser.Email,
-		Name:      updatedUser.Name,
-		Phone:     updatedUser.Phone,
-		Company:   updatedUser.Company,
-		Roles:     updatedUser.GetRoleNames(),
-		CreatedAt: updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	response := dto.UserManagementResponse{
+		ID:                  updatedUser.ID,
+		Email:               updatedUser.Email,
+		Name:                updatedUser.Name,
+		Phone:               updatedUser.Phone,
+		CompanyID:           updatedUser.CompanyID,
+		Company:             toCompanyResponse(updatedUser.Company),
+		AvatarURL:           updatedUser.AvatarURL,
+		Roles:               updatedUser.GetRoleNames(),
+		Tags:                updatedUser.GetTagNames(),
+		IsActive:            updatedUser.IsActive,
+		EmailDeliveryStatus: updatedUser.EmailDeliveryStatus,
+		TokenExpiryOverride: tokenExpirySeconds(updatedUser.TokenExpiryOverride),
+		LastLoginAt:         updatedUser.LastLoginAt,
+		CreatedAt:           updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	dispatchWebhook(services.WebhookEventUserRolesUpdated, response)
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+// BulkUpdateUserRoles replaces the roles of up to 100 users in one request
+// (admin only). Each user is updated independently and reported as a success
+// or an error.
+// @openapi tag Users
+// @openapi request dto.BulkUpdateRolesRequest
+// @openapi response 200 dto.BulkUpdateRolesResponse
+// @openapi response 400
+// @openapi response 404
+func BulkUpdateUserRoles(c *fiber.Ctx) error {
+	var req dto.BulkUpdateRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if len(req.Updates) > services.MaxBulkRoleUpdates {
+		return helpers.ValidationErrorResponse(c, fmt.Sprintf("At most %d updates are allowed per request", services.MaxBulkRoleUpdates))
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	// Prevent admin from removing their own admin role
+	currentUserID := middleware.GetUserID(c)
+	if slices.Contains(middleware.GetUserRoles(c), "admin") {
+		for _, update := range req.Updates {
+			if update.UserID == currentUserID && !slices.Contains(update.Roles, "admin") {
+				return helpers.ValidationErrorResponse(c, "Cannot remove admin role from yourself")
+			}
+		}
+	}
+
+	grantedBy := req.GrantedBy
+	if grantedBy == "" {
+		grantedBy = currentUserID
+	}
+
+	// Scoped admins can only re-role users inside their data scopes
+	rbacService := services.NewRBACService().Primary()
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to update user roles")
+	}
+	for _, update := range req.Updates {
+		inScope, err := rbacService.UserInDataScope(c.UserContext(), update.UserID, scopes)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to update user roles")
+		}
+		if !inScope {
+			return helpers.NotFoundResponse(c, "User "+update.UserID+" not found", apperrors.ErrUserNotFound)
+		}
+	}
+
+	updates := make([]services.BulkRoleUpdate, len(req.Updates))
+	for i, update := range req.Updates {
+		updates[i] = services.BulkRoleUpdate{UserID: update.UserID, Roles: update.Roles}
+	}
+
+	results, err := rbacService.BulkSetUserRoles(c.UserContext(), updates, grantedBy)
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownRole) || errors.Is(err, services.ErrGranterNotFound) {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update user roles")
+	}
+
+	response := dto.BulkUpdateRolesResponse{
+		Results: make([]dto.BulkRoleUpdateResult, len(results)),
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			response.Results[i] = dto.BulkRoleUpdateResult{UserID: result.UserID, Status: "error", Error: result.Err.Error()}
+			response.Failed++
+			continue
+		}
+
+		response.Results[i] = dto.BulkRoleUpdateResult{UserID: result.UserID, Status: "success"}
+		response.Succeeded++
+		recordAudit(c, services.AuditActionUserRolesUpdate, services.AuditResourceUser, result.UserID, services.AuditDiff(
+			map[string]interface{}{"roles": result.PreviousRoles},
+			map[string]interface{}{"roles": req.Updates[i].Roles},
+		))
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+// GetUser returns a user with their roles and effective permissions (admin
+// only)
+// @openapi tag Users
+// @openapi response 200 dto.UserDetailResponse
+// @openapi response 404
+func GetUser(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+	}
+
+	rbacService := services.NewRBACService()
+
+	user, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	userPermissions, err := rbacService.GetUserPermissions(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user permissions")
+	}
+
+	permissions := []dto.PermissionResponse{}
+	for _, p := range userPermissions {
+		permissions = append(permissions, toPermissionResponse(&p))
+	}
+
+	masked, err := maskResponse(c, dto.UserDetailResponse{
+		UserManagementResponse: dto.UserManagementResponse{
+			ID:                  user.ID,
+			Email:               user.Email,
+			Name:                user.Name,
+			Phone:               user.Phone,
+			CompanyID:           user.CompanyID,
+			Company:             toCompanyResponse(user.Company),
+			AvatarURL:           user.AvatarURL,
+			Roles:               user.GetRoleNames(),
+			Tags:                user.GetTagNames(),
+			IsActive:            user.IsActive,
+			EmailDeliveryStatus: user.EmailDeliveryStatus,
+			TokenExpiryOverride: tokenExpirySeconds(user.TokenExpiryOverride),
+			LastLoginAt:         user.LastLoginAt,
+			CreatedAt:           user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:           user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		},
+		EffectivePermissions: permissions,
 	})
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, masked)
 }
 
 // DeleteUser deletes a user (admin only)
+// @openapi tag Users
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 404
 func DeleteUser(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	if userID == "" {
@@ -167,129 +482,572 @@ func DeleteUser(c *fiber.Ctx) error {
 		return helpers.ValidationErrorResponse(c, "Cannot delete yourself")
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
 
 	// Check if user exists
-	_, err := rbacService.GetUserWithRoles(userID)
+	existingUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "User not found")
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
 	}
 
 	// Soft delete the user (GORM will handle role relationships via ON DELETE CASCADE)
-	err = rbacService.DeleteUser(userID)
+	err = rbacService.DeleteUser(c.UserContext(), userID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to delete user")
 	}
 
+	recordAudit(c, services.AuditActionUserDelete, services.AuditResourceUser, userID, userAuditFields(existingUser))
+	dispatchWebhook(services.WebhookEventUserDeleted, fiber.Map{
+		"id":    existingUser.ID,
+		"email": existingUser.Email,
+		"name":  existingUser.Name,
+	})
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
 		Message: "User deleted successfully",
 	})
 }
 
-// UpdateUser updates user information (admin only)
-func UpdateUser(c *fiber.Ctx) error {
+// AdminResetPassword sets a new password for a user (admin only). Outstanding
+// reset tokens are invalidated, and the user is emailed when notify_user is
+// set.
+// @openapi tag Users
+// @openapi request dto.AdminResetPasswordRequest
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 404
+func AdminResetPassword(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+	}
+
+	var req dto.AdminResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	if err := auth.ValidatePassword(req.NewPassword); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
+	var user models.User
+	result := database.DB.WithContext(c.UserContext()).Select("id", "email", "name", "password").Where("id = ?", userID).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	if err := setPassword(&user, req.NewPassword); err != nil {
+		if errors.Is(err, services.ErrPasswordReused) {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update password")
+	}
+
+	recordAudit(c, services.AuditActionUserPasswordReset, services.AuditResourceUser, userID, map[string]interface{}{
+		"notify_user": req.NotifyUser,
+	})
+
+	if req.NotifyUser {
+		sendAdminPasswordResetEmail(user.Email, user.Name)
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Password has been reset successfully.",
+	})
+}
+
+// sendAdminPasswordResetEmail tells a user an admin reset their password. The
+// reset stands even when the email cannot be sent.
+func sendAdminPasswordResetEmail(to, name string) {
+	if err := newEmailService().SendAdminPasswordReset(to, name); err != nil {
+		logger.Error("Failed to send admin password reset email", "to", to, "error", err)
+	}
+}
+
+// RestoreUser restores a soft-deleted user (admin only)
+// @openapi tag Users
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
+func RestoreUser(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	if err := rbacService.RestoreUser(c.UserContext(), userID); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		case errors.Is(err, services.ErrUserNotDeleted):
+			return helpers.ValidationErrorResponse(c, "User is not deleted")
+		case errors.Is(err, services.ErrEmailTaken), helpers.IsDuplicateError(err):
+			return helpers.ConflictResponse(c, "Email is already used by another user", apperrors.ErrEmailTaken)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to restore user")
+	}
+
+	restoredUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch restored user")
+	}
+
+	recordAudit(c, services.AuditActionUserRestore, services.AuditResourceUser, userID, userAuditFields(restoredUser))
+
+	response := dto.UserManagementResponse{
+		ID:                  restoredUser.ID,
+		Email:               restoredUser.Email,
+		Name:                restoredUser.Name,
+		Phone:               restoredUser.Phone,
+		CompanyID:           restoredUser.CompanyID,
+		Company:             toCompanyResponse(restoredUser.Company),
+		AvatarURL:           restoredUser.AvatarURL,
+		Roles:               restoredUser.GetRoleNames(),
+		Tags:                restoredUser.GetTagNames(),
+		IsActive:            restoredUser.IsActive,
+		EmailDeliveryStatus: restoredUser.EmailDeliveryStatus,
+		TokenExpiryOverride: tokenExpirySeconds(restoredUser.TokenExpiryOverride),
+		LastLoginAt:         restoredUser.LastLoginAt,
+		CreatedAt:           restoredUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           restoredUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	dispatchWebhook(services.WebhookEventUserUpdated, response)
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+// MergeUsers folds a duplicate account into the user in the path (admin
+// only). The source user's roles and preferences are copied when requested,
+// and the source user is soft deleted.
+// @openapi tag Users
+// @openapi request dto.MergeUsersRequest
+// @openapi response 200 dto.MergeUsersResponse
+// @openapi response 400
+// @openapi response 404
+func MergeUsers(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	var req dto.MergeUsersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	// The merged account is deleted, so admins cannot merge away their own
+	if req.SourceUserID == middleware.GetUserID(c) {
+		return helpers.ValidationErrorResponse(c, "Cannot merge yourself into another user")
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	// The source user must be inside the caller's data scopes too
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to merge users")
+	}
+	inScope, err := rbacService.UserInDataScope(c.UserContext(), req.SourceUserID, scopes)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to merge users")
+	}
+	if !inScope {
+		return helpers.NotFoundResponse(c, "Source user not found", apperrors.ErrUserNotFound)
+	}
+
+	result, err := rbacService.MergeUsers(c.UserContext(), userID, req.SourceUserID, services.MergeOptions{
+		TransferRoles:       req.TransferRoles,
+		TransferPreferences: req.TransferPreferences,
+		Actor:               services.AuditActorFromCtx(c),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrSelfMerge):
+			return helpers.ValidationErrorResponse(c, "Cannot merge a user into themselves")
+		case errors.Is(err, services.ErrMergeSourceNotFound):
+			return helpers.NotFoundResponse(c, "Source user not found", apperrors.ErrUserNotFound)
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to merge users")
+	}
+
+	dispatchWebhook(services.WebhookEventUserDeleted, fiber.Map{"id": req.SourceUserID})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MergeUsersResponse{
+		TargetUserID:           userID,
+		SourceUserID:           req.SourceUserID,
+		RolesTransferred:       result.RolesTransferred,
+		PreferencesTransferred: result.PreferencesTransferred,
+	})
+}
+
+// UpdateUserTokenSettings sets how long a user's tokens are valid (admin
+// only). The override applies to tokens issued at the user's next login.
+// @openapi tag Users
+// @openapi request dto.UpdateTokenSettingsRequest
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 404
+func UpdateUserTokenSettings(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	var req dto.UpdateTokenSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	maxExpiry := auth.MaxTokenExpiry()
+	if time.Duration(*req.TokenExpirySeconds)*time.Second > maxExpiry {
+		return helpers.ValidationErrorResponse(c, fmt.Sprintf("token_expiry_seconds must be at most %d", int64(maxExpiry.Seconds())))
+	}
+
+	var expiry *time.Duration
+	if *req.TokenExpirySeconds > 0 {
+		duration := time.Duration(*req.TokenExpirySeconds) * time.Second
+		expiry = &duration
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	existingUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	if err := rbacService.SetTokenExpiryOverride(c.UserContext(), userID, expiry); err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to update token settings")
+	}
+
+	recordAudit(c, services.AuditActionUserUpdate, services.AuditResourceUser, userID, fiber.Map{
+		"token_expiry_override": services.AuditChange{
+			From: tokenExpirySeconds(existingUser.TokenExpiryOverride),
+			To:   tokenExpirySeconds(expiry),
+		},
+	})
+
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
+	}
+
+	response := dto.UserManagementResponse{
+		ID:                  updatedUser.ID,
+		Email:               updatedUser.Email,
+		Name:                updatedUser.Name,
+		Phone:               updatedUser.Phone,
+		CompanyID:           updatedUser.CompanyID,
+		Company:             toCompanyResponse(updatedUser.Company),
+		AvatarURL:           updatedUser.AvatarURL,
+		Roles:               updatedUser.GetRoleNames(),
+		Tags:                updatedUser.GetTagNames(),
+		IsActive:            updatedUser.IsActive,
+		EmailDeliveryStatus: updatedUser.EmailDeliveryStatus,
+		TokenExpiryOverride: tokenExpirySeconds(updatedUser.TokenExpiryOverride),
+		LastLoginAt:         updatedUser.LastLoginAt,
+		CreatedAt:           updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	dispatchWebhook(services.WebhookEventUserUpdated, response)
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+// ImpersonateUser issues a short-lived token acting as another user (admin
+// only). Impersonating an admin or a super admin requires the super_admin
+// role.
+// @openapi tag Users
+// @openapi response 200 dto.ImpersonationResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 404
+func ImpersonateUser(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	if userID == "" {
 		return helpers.ValidationErrorResponse(c, "User ID is required")
 	}
 
+	currentUserID := middleware.GetUserID(c)
+	if userID == currentUserID {
+		return helpers.ValidationErrorResponse(c, "Cannot impersonate yourself")
+	}
+	if middleware.GetImpersonatedBy(c) != "" {
+		return helpers.ForbiddenResponse(c, "Cannot impersonate while impersonating another user", apperrors.ErrPermissionDenied)
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	targetUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+	if !targetUser.IsActive {
+		return helpers.ValidationErrorResponse(c, "Cannot impersonate a suspended user")
+	}
+
+	roles := targetUser.GetRoleNames()
+	targetIsAdmin := slices.Contains(roles, "admin") || slices.Contains(roles, "super_admin")
+	if targetIsAdmin && !slices.Contains(middleware.GetUserRoles(c), "super_admin") {
+		return helpers.ForbiddenResponse(c, "Impersonating an admin requires the super_admin role", apperrors.ErrPermissionDenied)
+	}
+
+	token, err := auth.GenerateImpersonationToken(targetUser.ID, targetUser.Email, currentUserID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to generate token")
+	}
+
+	recordAudit(c, services.AuditActionUserImpersonate, services.AuditResourceUser, userID, fiber.Map{
+		"email": targetUser.Email,
+		"roles": roles,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.ImpersonationResponse{
+		Token:     token,
+		ExpiresAt: time.Now().Add(auth.ImpersonationTokenExpiration),
+		User: dto.UserResponse{
+			ID:    targetUser.ID,
+			Email: targetUser.Email,
+			Name:  targetUser.Name,
+			Roles: roles,
+		},
+	})
+}
+
+// UpdateUser updates user information (admin only). Omitted fields are left
+// unchanged and an empty phone or company_id clears it.
+// @openapi tag Users
+// @openapi request dto.UpdateUserRequest
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 404
+func UpdateUser(c *fiber.Ctx) error {
 	var req dto.UpdateUserRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
-	rbacService := services.NewRBACService()
+	patch := dto.PatchUserRequest{}
+	if req.Email != nil {
+		patch.Email = dto.NewNullableString(req.Email)
+	}
+	if req.Name != nil {
+		patch.Name = dto.NewNullableString(req.Name)
+	}
+	if req.Phone != nil {
+		patch.Phone = dto.NewNullableString(req.Phone)
+	}
+	if req.CompanyID != nil {
+		patch.CompanyID = dto.NewNullableString(req.CompanyID)
+	}
+
+	return updateUser(c, patch)
+}
+
+// PatchUser partially updates a user with JSON Merge Patch semantics (admin
+// only)
+// @openapi tag Users
+// @openapi request dto.PatchUserRequest
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 404
+func PatchUser(c *fiber.Ctx) error {
+	var req dto.PatchUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	return updateUser(c, req)
+}
+
+// updateUser applies req to the user in the path and responds with the
+// updated user
+func updateUser(c *fiber.Ctx, req dto.PatchUserRequest) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	rbacService := services.NewRBACService().Primary()
 
 	// Check if user exists
-	_, err := rbacService.GetUserWithRoles(userID)
+	existingUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "User not found")
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
 	}
 
+	// Users outside the caller's data scopes are already rejected by
+	// RequireUserInDataScope, but the update must not move them out either
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
 	// Build updates map for selective updates
 	updates := make(map[string]interface{})
 
-	if req.Email != nil {
-		updates["email"] = *req.Email
+	if req.Email.Set {
+		if req.Email.Clears() {
+			return helpers.ValidationErrorResponse(c, "email cannot be cleared")
+		}
+		updates["email"] = helpers.NormalizeEmail(*req.Email.Value)
 	}
 
-	if req.Name != nil {
-		updates["name"] = *req.Name
+	if req.Name.Set {
+		if req.Name.Clears() {
+			return helpers.ValidationErrorResponse(c, "name cannot be cleared")
+		}
+		updates["name"] = *req.Name.Value
 	}
 
-	if req.Phone != nil {
-		if *req.Phone == "" {
-			updates["phone"] = nil
-		} else {
-			if !phonenumbers.IsValidNumber(*req.Phone, phonenumbers.DefaultPhoneRegion) {
-				return helpers.ValidationErrorResponse(c, "Invalid phone number format")
-			}
-			normalizedPhone, err := phonenumbers.NormalizeNumber(*req.Phone, phonenumbers.DefaultPhoneRegion)
-			if err != nil {
-				return helpers.ValidationErrorResponse(c, "Invalid phone number format")
+	if req.Phone.Clears() {
+		updates["phone"] = nil
+	} else if req.Phone.Set {
+		if !phonenumbers.IsValidNumber(*req.Phone.Value, phonenumbers.DefaultPhoneRegion) {
+			return helpers.ValidationErrorResponse(c, "Invalid phone number format")
+		}
+		normalizedPhone, err := phonenumbers.NormalizeNumber(*req.Phone.Value, phonenumbers.DefaultPhoneRegion)
+		if err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid phone number format")
+		}
+		updates["phone"] = normalizedPhone
+	}
+
+	if req.CompanyID.Clears() {
+		updates["company_id"] = nil
+	} else if req.CompanyID.Set {
+		if _, err := services.NewCompanyService().GetCompany(c.UserContext(), *req.CompanyID.Value); err != nil {
+			if errors.Is(err, services.ErrCompanyNotFound) {
+				return helpers.ValidationErrorResponse(c, "Company not found")
 			}
-			updates["phone"] = normalizedPhone
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch company")
 		}
+		updates["company_id"] = *req.CompanyID.Value
 	}
 
-	if req.Company != nil {
-		if *req.Company == "" {
-			updates["company"] = nil
-		} else {
-			updates["company"] = *req.Company
+	for column, value := range updates {
+		if !helpers.DataScopeAllows(scopes, column, value) {
+			return helpers.ForbiddenResponse(c, "The update would move the user outside your data scope", apperrors.ErrDataScopeViolation)
 		}
 	}
 
 	// Update user if there are changes
 	if len(updates) > 0 {
-		err = rbacService.UpdateUser(userID, updates)
+		err = rbacService.UpdateUser(c.UserContext(), userID, updates)
 		if err != nil {
-			if helpers.IsDuplicateError(err) && req.Email != nil {
+			if message, ok := helpers.ModelValidationError(err); ok {
+				return helpers.ValidationErrorResponse(c, message)
+			}
+			if helpers.IsDuplicateError(err) && req.Email.Set {
 				return helpers.ValidationErrorResponse(c, "Email already exists")
 			}
 			return helpers.InternalServerErrorResponse(c, "Failed to update user")
 		}
+
+		recordAudit(c, services.AuditActionUserUpdate, services.AuditResourceUser, userID, services.AuditDiff(userAuditFields(existingUser), updates))
 	}
 
 	// Get updated user
-	updatedUser, err := rbacService.GetUserWithRoles(userID)
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
 	}
 
-	return helpers.SuccessResponse(c, fiber.StatusOK, dto.UserManagementResponse{
-		ID:        updatedUser.ID,
-		Email:     updatedUser.Email,
-		Name:      updatedUser.Name,
-		Phone:     updatedUser.Phone,
-		Company:   updatedUser.Company,
-		Roles:     updatedUser.GetRoleNames(),
-		CreatedAt: updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-	})
+	response := dto.UserManagementResponse{
+		ID:                  updatedUser.ID,
+		Email:               updatedUser.Email,
+		Name:                updatedUser.Name,
+		Phone:               updatedUser.Phone,
+		CompanyID:           updatedUser.CompanyID,
+		Company:             toCompanyResponse(updatedUser.Company),
+		AvatarURL:           updatedUser.AvatarURL,
+		Roles:               updatedUser.GetRoleNames(),
+		Tags:                updatedUser.GetTagNames(),
+		IsActive:            updatedUser.IsActive,
+		EmailDeliveryStatus: updatedUser.EmailDeliveryStatus,
+		TokenExpiryOverride: tokenExpirySeconds(updatedUser.TokenExpiryOverride),
+		LastLoginAt:         updatedUser.LastLoginAt,
+		CreatedAt:           updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if len(updates) > 0 {
+		dispatchWebhook(services.WebhookEventUserUpdated, response)
+	}
+
+	masked, err := maskResponse(c, response)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, masked)
 }
 
 // CreateUser creates a new user (admin only)
+// @openapi tag Users
+// @openapi request dto.AdminRegisterUserRequest
+// @openapi response 201 user:dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 409
 func CreateUser(c *fiber.Ctx) error {
 	var req dto.AdminRegisterUserRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to process password")
@@ -309,49 +1067,222 @@ func CreateUser(c *fiber.Ctx) error {
 		user.Phone = &normalizedPhone
 	}
 
-	if req.Company != nil && *req.Company != "" {
-		trimmedCompany := helpers.TrimString(*req.Company)
-		user.Company = &trimmedCompany
+	// Scoped admins can only create users inside their data scopes
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to create user")
+	}
+	var companyID interface{}
+	if req.CompanyID != nil {
+		companyID = *req.CompanyID
+	}
+	if !helpers.DataScopeAllows(scopes, "company_id", companyID) {
+		return helpers.ForbiddenResponse(c, "The user would be created outside your data scope", apperrors.ErrDataScopeViolation)
+	}
+
+	if req.CompanyID != nil {
+		if _, err := services.NewCompanyService().GetCompany(c.UserContext(), *req.CompanyID); err != nil {
+			if errors.Is(err, services.ErrCompanyNotFound) {
+				return helpers.ValidationErrorResponse(c, "Company not found")
+			}
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch company")
+		}
+		user.CompanyID = req.CompanyID
 	}
 
-	result := database.DB.Create(&user)
+	result := database.DB.WithContext(c.UserContext()).Create(&user)
 	if result.Error != nil {
+		if message, ok := helpers.ModelValidationError(result.Error); ok {
+			return helpers.ValidationErrorResponse(c, message)
+		}
 		if helpers.IsDuplicateError(result.Error) {
-			return helpers.ConflictResponse(c, "Email already exists")
+			return helpers.ConflictResponse(c, "Email already exists", apperrors.ErrEmailTaken)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to create user")
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
 	currentUserID := middleware.GetUserID(c)
 
-	// Assign roles (default to "user" if no roles specified)
+	// Assign roles, falling back to the default user role
 	rolesToAssign := req.Roles
 	if len(rolesToAssign) == 0 {
-		rolesToAssign = []string{"user"}
+		defaultRole, err := services.NewSystemSettingService().GetDefaultUserRole(c.UserContext())
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch default role")
+		}
+		if defaultRole != "" {
+			rolesToAssign = []string{defaultRole}
+		}
 	}
 
-	err = rbacService.SetUserRoles(user.ID, rolesToAssign, &currentUserID)
+	err = rbacService.SetUserRoles(c.UserContext(), user.ID, rolesToAssign, &currentUserID, nil)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to assign roles: "+err.Error())
 	}
 
+	recordAudit(c, services.AuditActionUserCreate, services.AuditResourceUser, user.ID, fiber.Map{
+		"email": user.Email,
+		"name":  user.Name,
+		"roles": rolesToAssign,
+	})
+
 	// Get created user with roles
-	createdUser, err := rbacService.GetUserWithRoles(user.ID)
+	createdUser, err := rbacService.GetUserWithRoles(c.UserContext(), user.ID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch created user")
 	}
 
 	userResponse := dto.UserManagementResponse{
-		ID:        createdUser.ID,
-		Email:     createdUser.Email,
-		Name:      createdUser.Name,
-		Phone:     createdUser.Phone,
-		Company:   createdUser.Company,
-		Roles:     createdUser.GetRoleNames(),
-		CreatedAt: createdUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: createdUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:                  createdUser.ID,
+		Email:               createdUser.Email,
+		Name:                createdUser.Name,
+		Phone:               createdUser.Phone,
+		CompanyID:           createdUser.CompanyID,
+		Company:             toCompanyResponse(createdUser.Company),
+		AvatarURL:           createdUser.AvatarURL,
+		Roles:               createdUser.GetRoleNames(),
+		Tags:                createdUser.GetTagNames(),
+		IsActive:            createdUser.IsActive,
+		EmailDeliveryStatus: createdUser.EmailDeliveryStatus,
+		TokenExpiryOverride: tokenExpirySeconds(createdUser.TokenExpiryOverride),
+		LastLoginAt:         createdUser.LastLoginAt,
+		CreatedAt:           createdUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           createdUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	dispatchWebhook(services.WebhookEventUserCreated, userResponse)
+
+	masked, err := maskResponse(c, userResponse)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch created user")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, fiber.Map{"user": masked})
+}
+
+// GetUserRoleAssignments returns a user's role assignment records including expiry (admin only)
+// @openapi tag Users
+// @openapi response 200 assignments:[]dto.RoleAssignmentResponse total:integer
+// @openapi response 404
+func GetUserRoleAssignments(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
 	}
 
-	return helpers.SuccessResponse(c, fiber.StatusCreated, fiber.Map{"user": userResponse})
+	rbacService := services.NewRBACService()
+
+	// Check if user exists
+	_, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	assignments, err := rbacService.GetUserRoleAssignments(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch role assignments")
+	}
+
+	assignmentResponses := make([]dto.RoleAssignmentResponse, 0, len(assignments))
+	for _, assignment := range assignments {
+		assignmentResponses = append(assignmentResponses, dto.RoleAssignmentResponse{
+			RoleID:    assignment.RoleID,
+			RoleName:  assignment.Role.Name,
+			GrantedAt: assignment.GrantedAt,
+			GrantedBy: assignment.GrantedBy,
+			ExpiresAt: assignment.ExpiresAt,
+			IsExpired: assignment.IsExpired(),
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"assignments": assignmentResponses,
+		"total":       len(assignmentResponses),
+	})
+}
+
+// ActivateUser restores access for a suspended user (admin only)
+// @openapi tag Users
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 404
+func ActivateUser(c *fiber.Ctx) error {
+	return setUserActive(c, true)
+}
+
+// DeactivateUser suspends a user without deleting their account (admin only)
+// @openapi tag Users
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 404
+func DeactivateUser(c *fiber.Ctx) error {
+	return setUserActive(c, false)
+}
+
+func setUserActive(c *fiber.Ctx, active bool) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	// Prevent admin from suspending themselves
+	if !active && userID == middleware.GetUserID(c) {
+		return helpers.ValidationErrorResponse(c, "Cannot deactivate yourself")
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	existingUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	if existingUser.IsActive != active {
+		if err := rbacService.SetUserActive(c.UserContext(), userID, active); err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to update user status")
+		}
+
+		action := services.AuditActionUserDeactivate
+		if active {
+			action = services.AuditActionUserActivate
+		}
+		recordAudit(c, action, services.AuditResourceUser, userID, fiber.Map{
+			"is_active": services.AuditChange{From: existingUser.IsActive, To: active},
+		})
+	}
+
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
+	}
+
+	response := dto.UserManagementResponse{
+		ID:                  updatedUser.ID,
+		Email:               updatedUser.Email,
+		Name:                updatedUser.Name,
+		Phone:               updatedUser.Phone,
+		CompanyID:           updatedUser.CompanyID,
+		Company:             toCompanyResponse(updatedUser.Company),
+		AvatarURL:           updatedUser.AvatarURL,
+		Roles:               updatedUser.GetRoleNames(),
+		Tags:                updatedUser.GetTagNames(),
+		IsActive:            updatedUser.IsActive,
+		EmailDeliveryStatus: updatedUser.EmailDeliveryStatus,
+		TokenExpiryOverride: tokenExpirySeconds(updatedUser.TokenExpiryOverride),
+		LastLoginAt:         updatedUser.LastLoginAt,
+		CreatedAt:           updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:           updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+
+	if existingUser.IsActive != active {
+		dispatchWebhook(services.WebhookEventUserUpdated, response)
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
 }
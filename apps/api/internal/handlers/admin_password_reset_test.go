@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSendAdminPasswordResetEmail(t *testing.T) {
+	fake := &fakeEmailService{}
+	useFakeEmailService(t, fake)
+
+	sendAdminPasswordResetEmail("user@example.com", "Reset User")
+
+	if len(fake.passwordResets) != 1 || fake.passwordResets[0] != "user@example.com" {
+		t.Fatalf("password reset emails = %v, want one to user@example.com", fake.passwordResets)
+	}
+}
+
+func TestSendAdminPasswordResetEmailIgnoresFailures(t *testing.T) {
+	fake := &fakeEmailService{err: errors.New("smtp unavailable")}
+	useFakeEmailService(t, fake)
+
+	// Must not panic; the password has already been changed
+	sendAdminPasswordResetEmail("user@example.com", "Reset User")
+
+	if len(fake.passwordResets) != 1 {
+		t.Fatalf("password reset emails sent = %d, want 1", len(fake.passwordResets))
+	}
+}
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListActiveAnnouncements returns the announcements currently shown to users,
+// newest first
+// @openapi tag Announcements
+// @openapi response 200 announcements:[]dto.ActiveAnnouncementResponse total:integer
+func ListActiveAnnouncements(c *fiber.Ctx) error {
+	announcements, err := services.NewAnnouncementService().ListActive(time.Now())
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcements")
+	}
+
+	responses := make([]dto.ActiveAnnouncementResponse, len(announcements))
+	for i := range announcements {
+		responses[i] = toActiveAnnouncementResponse(&announcements[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"announcements": responses,
+		"total":         len(responses),
+	})
+}
+
+// ListMyAnnouncements returns the active announcements with whether the
+// authenticated user has read each one
+// @openapi tag Announcements
+// @openapi response 200 announcements:[]dto.ActiveAnnouncementResponse total:integer unread:integer
+func ListMyAnnouncements(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	announcementService := services.NewAnnouncementService()
+	announcements, err := announcementService.ListActive(time.Now())
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcements")
+	}
+	read, err := announcementService.ReadAnnouncementIDs(userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcements")
+	}
+
+	unread := 0
+	responses := make([]dto.ActiveAnnouncementResponse, len(announcements))
+	for i := range announcements {
+		isRead := read[announcements[i].ID]
+		if !isRead {
+			unread++
+		}
+		responses[i] = toActiveAnnouncementResponse(&announcements[i])
+		responses[i].Read = &isRead
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"announcements": responses,
+		"total":         len(responses),
+		"unread":        unread,
+	})
+}
+
+// MarkAnnouncementRead records the authenticated user reading an active
+// announcement
+// @openapi tag Announcements
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func MarkAnnouncementRead(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	announcementID := c.Params("id")
+	if _, err := uuid.Parse(announcementID); err != nil {
+		return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+	}
+
+	if err := services.NewAnnouncementService().MarkRead(userID, announcementID, time.Now()); err != nil {
+		if errors.Is(err, services.ErrAnnouncementNotFound) {
+			return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to mark announcement read")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Announcement marked as read",
+	})
+}
+
+// ListAnnouncements returns every announcement, including scheduled and
+// expired ones (admin only)
+// @openapi tag Announcements
+// @openapi response 200 announcements:[]dto.AnnouncementResponse total:integer
+func ListAnnouncements(c *fiber.Ctx) error {
+	announcements, err := services.NewAnnouncementService().ListAnnouncements()
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcements")
+	}
+
+	responses := make([]dto.AnnouncementResponse, len(announcements))
+	for i := range announcements {
+		responses[i] = toAnnouncementResponse(&announcements[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"announcements": responses,
+		"total":         len(responses),
+	})
+}
+
+// GetAnnouncement returns an announcement by ID (admin only)
+// @openapi tag Announcements
+// @openapi response 200 dto.AnnouncementResponse
+// @openapi response 404
+func GetAnnouncement(c *fiber.Ctx) error {
+	announcementID := c.Params("id")
+	if _, err := uuid.Parse(announcementID); err != nil {
+		return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+	}
+
+	announcement, err := services.NewAnnouncementService().GetAnnouncement(announcementID)
+	if err != nil {
+		if errors.Is(err, services.ErrAnnouncementNotFound) {
+			return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcement")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toAnnouncementResponse(announcement))
+}
+
+// CreateAnnouncement posts an announcement to every user (admin only)
+// @openapi tag Announcements
+// @openapi request dto.CreateAnnouncementRequest
+// @openapi response 201 dto.AnnouncementResponse
+// @openapi response 400
+func CreateAnnouncement(c *fiber.Ctx) error {
+	var req dto.CreateAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	announcement := models.Announcement{
+		Title:        helpers.TrimString(req.Title),
+		Body:         req.Body,
+		Type:         req.Type,
+		VisibleFrom:  time.Now(),
+		VisibleUntil: req.VisibleUntil,
+	}
+	if announcement.Type == "" {
+		announcement.Type = models.AnnouncementTypeInfo
+	}
+	if req.VisibleFrom != nil {
+		announcement.VisibleFrom = *req.VisibleFrom
+	}
+	if announcement.VisibleUntil != nil && !announcement.VisibleUntil.After(announcement.VisibleFrom) {
+		return helpers.ValidationErrorResponse(c, "visible_until must be after visible_from")
+	}
+	if adminID := middleware.GetUserID(c); adminID != "" {
+		announcement.CreatedBy = &adminID
+	}
+
+	if err := services.NewAnnouncementService().CreateAnnouncement(&announcement); err != nil {
+		logger.Error("Failed to create announcement", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to create announcement")
+	}
+
+	recordAudit(c, services.AuditActionAnnouncementCreate, services.AuditResourceAnnouncement, announcement.ID, announcementAuditFields(&announcement))
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, toAnnouncementResponse(&announcement))
+}
+
+// UpdateAnnouncement updates an announcement's content, type or visibility
+// (admin only)
+// @openapi tag Announcements
+// @openapi request dto.UpdateAnnouncementRequest
+// @openapi response 200 dto.AnnouncementResponse
+// @openapi response 400
+// @openapi response 404
+func UpdateAnnouncement(c *fiber.Ctx) error {
+	announcementID := c.Params("id")
+	if _, err := uuid.Parse(announcementID); err != nil {
+		return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+	}
+
+	var req dto.UpdateAnnouncementRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	announcementService := services.NewAnnouncementService()
+
+	existing, err := announcementService.GetAnnouncement(announcementID)
+	if err != nil {
+		if errors.Is(err, services.ErrAnnouncementNotFound) {
+			return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcement")
+	}
+
+	// Build updates map for selective updates
+	updates := make(map[string]interface{})
+
+	if req.Title != nil {
+		updates["title"] = helpers.TrimString(*req.Title)
+	}
+	if req.Body != nil {
+		updates["body"] = *req.Body
+	}
+	if req.Type != nil {
+		updates["type"] = *req.Type
+	}
+
+	visibleFrom, visibleUntil := existing.VisibleFrom, existing.VisibleUntil
+	if req.VisibleFrom != nil {
+		visibleFrom = *req.VisibleFrom
+		updates["visible_from"] = visibleFrom
+	}
+	if req.VisibleUntil.Clears() {
+		visibleUntil = nil
+		updates["visible_until"] = nil
+	} else if req.VisibleUntil.Set {
+		visibleUntil = req.VisibleUntil.Value
+		updates["visible_until"] = *visibleUntil
+	}
+	if visibleUntil != nil && !visibleUntil.After(visibleFrom) {
+		return helpers.ValidationErrorResponse(c, "visible_until must be after visible_from")
+	}
+
+	if len(updates) == 0 {
+		return helpers.ValidationErrorResponse(c, "No fields to update")
+	}
+
+	if err := announcementService.UpdateAnnouncement(announcementID, updates); err != nil {
+		if errors.Is(err, services.ErrAnnouncementNotFound) {
+			return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update announcement")
+	}
+
+	recordAudit(c, services.AuditActionAnnouncementUpdate, services.AuditResourceAnnouncement, announcementID, services.AuditDiff(announcementAuditFields(existing), updates))
+
+	updated, err := announcementService.GetAnnouncement(announcementID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated announcement")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toAnnouncementResponse(updated))
+}
+
+// DeleteAnnouncement removes an announcement (admin only)
+// @openapi tag Announcements
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func DeleteAnnouncement(c *fiber.Ctx) error {
+	announcementID := c.Params("id")
+	if _, err := uuid.Parse(announcementID); err != nil {
+		return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+	}
+
+	announcementService := services.NewAnnouncementService()
+
+	existing, err := announcementService.GetAnnouncement(announcementID)
+	if err != nil {
+		if errors.Is(err, services.ErrAnnouncementNotFound) {
+			return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch announcement")
+	}
+
+	if err := announcementService.DeleteAnnouncement(announcementID); err != nil {
+		if errors.Is(err, services.ErrAnnouncementNotFound) {
+			return helpers.NotFoundResponse(c, "Announcement not found", apperrors.ErrAnnouncementNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to delete announcement")
+	}
+
+	recordAudit(c, services.AuditActionAnnouncementDelete, services.AuditResourceAnnouncement, announcementID, announcementAuditFields(existing))
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Announcement deleted successfully",
+	})
+}
+
+// announcementAuditFields snapshots the editable announcement fields for
+// audit diffs
+func announcementAuditFields(announcement *models.Announcement) map[string]interface{} {
+	return map[string]interface{}{
+		"title":         announcement.Title,
+		"body":          announcement.Body,
+		"type":          announcement.Type,
+		"visible_from":  announcement.VisibleFrom,
+		"visible_until": announcement.VisibleUntil,
+	}
+}
+
+func toAnnouncementResponse(announcement *models.Announcement) dto.AnnouncementResponse {
+	return dto.AnnouncementResponse{
+		ID:           announcement.ID,
+		Title:        announcement.Title,
+		Body:         announcement.Body,
+		Type:         announcement.Type,
+		VisibleFrom:  announcement.VisibleFrom,
+		VisibleUntil: announcement.VisibleUntil,
+		CreatedBy:    announcement.CreatedBy,
+		CreatedAt:    announcement.CreatedAt,
+		UpdatedAt:    announcement.UpdatedAt,
+	}
+}
+
+func toActiveAnnouncementResponse(announcement *models.Announcement) dto.ActiveAnnouncementResponse {
+	return dto.ActiveAnnouncementResponse{
+		ID:           announcement.ID,
+		Title:        announcement.Title,
+		Body:         announcement.Body,
+		Type:         announcement.Type,
+		VisibleFrom:  announcement.VisibleFrom,
+		VisibleUntil: announcement.VisibleUntil,
+	}
+}
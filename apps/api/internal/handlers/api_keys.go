@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// CreateAPIKey creates an API key for the authenticated user. The key is only
+// shown in this response.
+// @openapi tag API Keys
+// @openapi request dto.CreateAPIKeyRequest
+// @openapi response 201 dto.CreateAPIKeyResponse
+// @openapi response 400
+func CreateAPIKey(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return helpers.ValidationErrorResponse(c, "expires_at must be in the future")
+	}
+
+	apiKey, key, err := services.NewAPIKeyService().CreateAPIKey(userID, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		logger.Error("Failed to create API key", "user_id", userID, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to create API key")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, dto.CreateAPIKeyResponse{
+		Key:    key,
+		APIKey: toAPIKeyResponse(apiKey),
+	})
+}
+
+// ListAPIKeys returns the authenticated user's API keys, including revoked ones
+// @openapi tag API Keys
+// @openapi response 200 api_keys:[]dto.APIKeyResponse total:integer
+func ListAPIKeys(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	keys, err := services.NewAPIKeyService().ListAPIKeys(userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch API keys")
+	}
+
+	responses := make([]dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		responses[i] = toAPIKeyResponse(&keys[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"api_keys": responses,
+		"total":    len(responses),
+	})
+}
+
+// RevokeAPIKey revokes one of the authenticated user's API keys
+// @openapi tag API Keys
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func RevokeAPIKey(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	keyID := c.Params("id")
+	if _, err := uuid.Parse(keyID); err != nil {
+		return helpers.NotFoundResponse(c, "API key not found", apperrors.ErrAPIKeyNotFound)
+	}
+
+	if err := services.NewAPIKeyService().RevokeAPIKey(userID, keyID); err != nil {
+		if errors.Is(err, services.ErrAPIKeyNotFound) {
+			return helpers.NotFoundResponse(c, "API key not found", apperrors.ErrAPIKeyNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to revoke API key")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "API key revoked successfully",
+	})
+}
+
+func toAPIKeyResponse(apiKey *models.APIKey) dto.APIKeyResponse {
+	scopes := []string(apiKey.Scopes)
+	if scopes == nil {
+		scopes = []string{}
+	}
+
+	return dto.APIKeyResponse{
+		ID:         apiKey.ID,
+		Name:       apiKey.Name,
+		Scopes:     scopes,
+		LastUsedAt: apiKey.LastUsedAt,
+		ExpiresAt:  apiKey.ExpiresAt,
+		CreatedAt:  apiKey.CreatedAt,
+		RevokedAt:  apiKey.RevokedAt,
+	}
+}
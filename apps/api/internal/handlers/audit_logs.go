@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListAuditLogs returns audit logs with pagination and filtering (admin only)
+// @openapi tag Audit Logs
+// @openapi query dto.AuditLogListRequest
+// @openapi response 200 dto.PaginatedAuditLogsResponse
+// @openapi response 400
+func ListAuditLogs(c *fiber.Ctx) error {
+	var req dto.AuditLogListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	// Set default values
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	filter := services.AuditLogFilter{
+		ActorID:      req.ActorID,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		Action:       req.Action,
+	}
+
+	if req.From != "" {
+		from, err := parseAuditTime(req.From, false)
+		if err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid from date, expected RFC3339 or YYYY-MM-DD format")
+		}
+		filter.From = &from
+	}
+
+	if req.To != "" {
+		to, err := parseAuditTime(req.To, true)
+		if err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid to date, expected RFC3339 or YYYY-MM-DD format")
+		}
+		filter.To = &to
+	}
+
+	auditService := services.NewAuditService()
+
+	logs, total, err := auditService.GetLogs(filter, req.Page, req.Limit)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch audit logs")
+	}
+
+	logResponses := make([]dto.AuditLogResponse, 0, len(logs))
+	for _, log := range logs {
+		logResponses = append(logResponses, toAuditLogResponse(log))
+	}
+
+	// Calculate total pages
+	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.PaginatedAuditLogsResponse{
+		Logs:       logResponses,
+		Total:      total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+		TotalPages: totalPages,
+	})
+}
+
+// parseAuditTime parses an RFC3339 timestamp or a YYYY-MM-DD date in UTC. A
+// date that ends a range is read as the last instant of that day.
+func parseAuditTime(value string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		return date.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	return date, nil
+}
+
+func toAuditLogResponse(log models.AuditLog) dto.AuditLogResponse {
+	response := dto.AuditLogResponse{
+		ID:             log.ID,
+		ActorID:        log.ActorID,
+		ImpersonatedBy: log.ImpersonatedBy,
+		Action:         log.Action,
+		ResourceType:   log.ResourceType,
+		ResourceID:     log.ResourceID,
+		Changes:        log.Changes,
+		IPAddress:      log.IPAddress,
+		UserAgent:      log.UserAgent,
+		CreatedAt:      log.CreatedAt,
+	}
+	if log.Actor != nil {
+		response.ActorEmail = &log.Actor.Email
+	}
+	return response
+}
+
+// recordAudit writes an audit entry for a completed admin mutation. The
+// mutation has already succeeded, so failures are logged rather than returned.
+func recordAudit(c *fiber.Ctx, action, resourceType, resourceID string, changes interface{}) {
+	if err := services.NewAuditService().Log(c, action, resourceType, resourceID, changes); err != nil {
+		logger.Error("Failed to write audit log", "action", action, "resource_id", resourceID, "error", err)
+	}
+}
+
+// userAuditFields snapshots the editable user fields for audit diffs
+func userAuditFields(user *models.User) map[string]interface{} {
+	return map[string]interface{}{
+		"email":      user.Email,
+		"name":       user.Name,
+		"phone":      derefString(user.Phone),
+		"company_id": derefString(user.CompanyID),
+		"is_active":  user.IsActive,
+	}
+}
+
+// roleAuditFields snapshots the editable role fields for audit diffs
+func roleAuditFields(role *models.Role) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                     role.Name,
+		"description":              derefString(role.Description),
+		"use_wildcard_permissions": role.UseWildcardPermissions,
+		"access_windows":           role.AccessWindows,
+		"data_scope":               role.DataScope,
+	}
+}
+
+// permissionAuditFields snapshots the editable permission fields for audit diffs
+func permissionAuditFields(permission *models.Permission) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        permission.Name,
+		"resource":    permission.Resource,
+		"action":      permission.Action,
+		"description": derefString(permission.Description),
+		"category_id": derefString(permission.CategoryID),
+		"metadata":    permission.Metadata,
+	}
+}
+
+// emailTemplateAuditFields snapshots the editable template fields for audit diffs
+func emailTemplateAuditFields(template *models.EmailTemplate) map[string]interface{} {
+	return map[string]interface{}{
+		"name":          template.Name,
+		"language":      template.Language,
+		"subject":       template.Subject,
+		"html_template": template.HTMLTemplate,
+		"text_template": template.TextTemplate,
+		"variables":     template.Variables,
+		"is_active":     template.IsActive,
+		"protected":     template.Protected,
+	}
+}
+
+func derefString(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
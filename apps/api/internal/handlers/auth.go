@@ -4,12 +4,17 @@ import (
 	"api/internal/auth"
 	"api/internal/database"
 	"api/internal/dto"
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
+	"api/internal/logger"
 	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/pkg/phonenumbers"
 	"api/internal/services"
+	"api/internal/session"
+	"encoding/json"
 	"errors"
+	"reflect"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -22,18 +27,51 @@ func init() {
 	if err := helpers.RegisterCustomValidators(validate); err != nil {
 		panic("Failed to register custom validators: " + err.Error())
 	}
+	// Validate tags on merge patch fields apply to the string sent, if any
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		return field.Interface().(dto.NullableString).ValidationValue()
+	}, dto.NullableString{})
 }
 
+// LandlinePhoneWarning is returned on registration when the phone number is a
+// landline, which SMS-based features cannot reach
+const LandlinePhoneWarning = "Phone number is a landline and cannot receive SMS messages"
+
+// Register creates a user account and returns a JWT for it
+// @openapi tag Auth
+// @openapi request dto.RegisterRequest
+// @openapi response 201 dto.AuthResponse
+// @openapi response 400
+// @openapi response 409
 func Register(c *fiber.Ctx) error {
 	var req dto.RegisterRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
+	if helpers.GetEnvBool("REJECT_DISPOSABLE_EMAILS", false) && helpers.IsDisposableEmail(req.Email) {
+		return helpers.ValidationErrorResponse(c, "Disposable email addresses are not allowed", apperrors.ErrDisposableEmail)
+	}
+
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
+	// Once terms of service are published, registering means accepting the
+	// current version
+	tosService := services.NewToSService()
+	currentToS, err := tosService.CurrentVersion(c.UserContext())
+	if err != nil && !errors.Is(err, services.ErrNoToSVersion) {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch terms of service")
+	}
+	if currentToS != nil && req.ToSVersion != currentToS.Version {
+		return helpers.ValidationErrorResponse(c, "Terms of service version "+currentToS.Version+" must be accepted")
+	}
+
 	hashedPassword, err := auth.HashPassword(req.Password)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to process password")
@@ -45,55 +83,94 @@ func Register(c *fiber.Ctx) error {
 		Name:     helpers.TrimString(req.Name),
 	}
 
+	var warnings []string
 	if req.Phone != nil && *req.Phone != "" {
 		normalizedPhone, err := phonenumbers.NormalizeNumber(*req.Phone, phonenumbers.DefaultPhoneRegion)
 		if err != nil {
 			return helpers.ValidationErrorResponse(c, "Invalid phone number format")
 		}
 		user.Phone = &normalizedPhone
+
+		// Landlines are accepted, but cannot receive SMS
+		if numberType, _ := phonenumbers.GetNumberType(normalizedPhone, phonenumbers.DefaultPhoneRegion); numberType == phonenumbers.FixedLine {
+			warnings = append(warnings, LandlinePhoneWarning)
+		}
 	}
 
-	result := database.DB.Create(&user)
+	result := database.DB.WithContext(c.UserContext()).Create(&user)
 	if result.Error != nil {
+		if message, ok := helpers.ModelValidationError(result.Error); ok {
+			return helpers.ValidationErrorResponse(c, message)
+		}
 		if helpers.IsDuplicateError(result.Error) {
-			return helpers.ConflictResponse(c, "Email already exists")
+			return helpers.ConflictResponse(c, "Email already exists", apperrors.ErrEmailTaken)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to create user")
 	}
 
-	// Assign default user role
-	rbacService := services.NewRBACService()
-	err = rbacService.AssignRoleToUser(user.ID, "user", nil)
+	// Assign the default user role, unless new users start without one
+	rbacService := services.NewRBACService().Primary()
+	defaultRole, err := services.NewSystemSettingService().GetDefaultUserRole(c.UserContext())
 	if err != nil {
-		return helpers.InternalServerErrorResponse(c, "Failed to assign default role")
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch default role")
 	}
+	if defaultRole != "" {
+		if err := rbacService.AssignRoleToUser(c.UserContext(), user.ID, defaultRole, nil, nil); err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to assign default role")
+		}
+	}
+
+	if currentToS != nil {
+		if err := tosService.RecordAcceptance(c.UserContext(), user.ID, currentToS.ID, c.IP()); err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to record terms of service acceptance")
+		}
+	}
+
+	sendWelcomeEmail(user.Email, user.Name)
 
-	token, err := auth.GenerateToken(user.ID, user.Email)
+	// Default preferences are a convenience; registration succeeds without them
+	if err := services.NewPreferenceService().SetDefaultPreferences(c.UserContext(), user.ID); err != nil {
+		logger.Error("Failed to store default preferences", "user_id", user.ID, "error", err)
+	}
+
+	token, err := auth.GenerateToken(user.ID, user.Email, nil, requestDevice(c))
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to generate token")
 	}
 
-	// Get user roles (should include the default "user" role that was just assigned)
-	userWithRoles, err := rbacService.GetUserWithRoles(user.ID)
+	// Get user roles, including the default role that was just assigned
+	userWithRoles, err := rbacService.GetUserWithRoles(c.UserContext(), user.ID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user roles")
 	}
 
+	userResponse := dto.UserResponse{
+		ID:    user.ID,
+		Email: user.Email,
+		Name:  user.Name,
+		Roles: userWithRoles.GetRoleNames(),
+	}
+	dispatchWebhook(services.WebhookEventUserCreated, userResponse)
+
 	return helpers.SuccessResponse(c, fiber.StatusCreated, dto.AuthResponse{
-		Token: token,
-		User: dto.UserResponse{
-			ID:    user.ID,
-			Email: user.Email,
-			Name:  user.Name,
-			Roles: userWithRoles.GetRoleNames(),
-		},
+		Token:    token,
+		User:     userResponse,
+		Warnings: warnings,
 	})
 }
 
+// Login authenticates a user and returns a JWT
+// @openapi tag Auth
+// @openapi request dto.LoginRequest
+// @openapi response 200 dto.AuthResponse
+// @openapi response 400
+// @openapi response 401
+// @openapi response 403
+// @openapi response 429
 func Login(c *fiber.Ctx) error {
 	var req dto.LoginRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -101,26 +178,45 @@ func Login(c *fiber.Ctx) error {
 	}
 
 	var user models.User
-	result := database.DB.Where("email = ?", helpers.NormalizeEmail(req.Email)).First(&user)
+	result := helpers.WhereEmail(database.DB.WithContext(c.UserContext()), req.Email).First(&user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return helpers.UnauthorizedResponse(c, "Invalid email or password")
+			return helpers.UnauthorizedResponse(c, "Invalid email or password", apperrors.ErrAuthInvalidCredentials)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to process request")
 	}
 
+	loginHistoryService := services.NewLoginHistoryService()
+	lockedOut, err := loginHistoryService.IsLockedOut(user.ID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to process request")
+	}
+	// Attempts refused by the lockout are not recorded, so they don't keep
+	// extending it
+	if lockedOut {
+		return helpers.TooManyRequestsResponse(c, "Too many failed login attempts, please try again later", apperrors.ErrAuthTooManyAttempts)
+	}
+
 	if !auth.CheckPassword(req.Password, user.Password) {
-		return helpers.UnauthorizedResponse(c, "Invalid email or password")
+		recordLoginAttempt(c, loginHistoryService, user.ID, false)
+		return helpers.UnauthorizedResponse(c, "Invalid email or password", apperrors.ErrAuthInvalidCredentials)
+	}
+
+	if !user.IsActive {
+		recordLoginAttempt(c, loginHistoryService, user.ID, false)
+		return helpers.ForbiddenResponse(c, "Account suspended", apperrors.ErrAuthAccountSuspended)
 	}
 
-	token, err := auth.GenerateToken(user.ID, user.Email)
+	recordLoginAttempt(c, loginHistoryService, user.ID, true)
+
+	token, err := auth.GenerateToken(user.ID, user.Email, user.TokenExpiryOverride, requestDevice(c))
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to generate token")
 	}
 
 	// Get user roles
-	rbacService := services.NewRBACService()
-	userWithRoles, err := rbacService.GetUserWithRoles(user.ID)
+	rbacService := services.NewRBACService().Primary()
+	userWithRoles, err := rbacService.GetUserWithRoles(c.UserContext(), user.ID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user roles")
 	}
@@ -136,136 +232,214 @@ func Login(c *fiber.Ctx) error {
 	})
 }
 
+// GetProfile returns the authenticated user's profile. The response carries an
+// ETag and Last-Modified; a request whose If-None-Match or If-Modified-Since
+// still matches gets 304 without a body.
+// @openapi tag Profile
+// @openapi response 200 dto.ProfileResponse
+// @openapi response 304
+// @openapi response 404
 func GetProfile(c *fiber.Ctx) error {
 	userID := middleware.GetUserID(c)
 	if userID == "" {
-		return helpers.UnauthorizedResponse(c, "User not authenticated")
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
 	}
 
 	rbacService := services.NewRBACService()
-	user, err := rbacService.GetUserWithRoles(userID)
+	user, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "User not found")
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user profile")
 	}
 
-	return helpers.SuccessResponse(c, fiber.StatusOK, dto.ProfileResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Name:      user.Name,
-		Phone:     user.Phone,
-		Company:   user.Company,
-		Roles:     user.GetRoleNames(),
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-	})
+	emails, err := services.NewUserEmailService().ListEmails(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user profile")
+	}
+
+	masked, err := maskResponse(c, toProfileResponse(user, emails))
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user profile")
+	}
+
+	body, err := json.Marshal(masked)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to encode user profile")
+	}
+
+	// Clients must revalidate, and shared caches must not store the profile
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	etag := helpers.ETag(body)
+	helpers.SetCacheHeaders(c, etag, user.UpdatedAt)
+	if helpers.CheckNotModified(c, etag, user.UpdatedAt) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(body)
 }
 
-func UpdateProfile(c *fiber.Ctx) error {
-	userID := middleware.GetUserID(c)
-	if userID == "" {
-		return helpers.UnauthorizedResponse(c, "User not authenticated")
+// PatchProfile partially updates the authenticated user's profile with JSON
+// Merge Patch semantics
+// @openapi tag Profile
+// @openapi request dto.PatchProfileRequest
+// @openapi response 200 dto.ProfileResponse
+// @openapi response 400
+// @openapi response 404
+func PatchProfile(c *fiber.Ctx) error {
+	var req dto.PatchProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
-	// Parse flexible JSON payload
-	var req dto.UpdateProfileRequest
+	return updateProfile(c, req)
+}
+
+// ReplaceProfile replaces the authenticated user's profile fields, all of
+// which must be sent
+// @openapi tag Profile
+// @openapi request dto.ReplaceProfileRequest
+// @openapi response 200 dto.ProfileResponse
+// @openapi response 400
+// @openapi response 404
+func ReplaceProfile(c *fiber.Ctx) error {
+	var req dto.ReplaceProfileRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+	if !req.Phone.Set {
+		return helpers.ValidationErrorResponse(c, "phone is required; send null to clear it")
+	}
+	if !req.CompanyID.Set {
+		return helpers.ValidationErrorResponse(c, "company_id is required; send null to clear it")
+	}
+
+	return updateProfile(c, dto.PatchProfileRequest{
+		Name:      dto.NewNullableString(&req.Name),
+		Phone:     req.Phone,
+		CompanyID: req.CompanyID,
+	})
+}
+
+// updateProfile applies req to the authenticated user's profile and responds
+// with the updated profile
+func updateProfile(c *fiber.Ctx, req dto.PatchProfileRequest) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
 	}
 
 	// Fetch the existing user
 	var user models.User
-	result := database.DB.Where("id = ?", userID).First(&user)
+	result := database.DB.WithContext(c.UserContext()).Where("id = ?", userID).First(&user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "User not found")
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
 	}
 
 	// Build updates map for selective updates
 	updates := make(map[string]interface{})
-	
-	// Process each field in the request
-	for key, value := range req {
-		switch key {
-		case "phone":
-			if v, ok := value.(string); ok {
-				if v == "" {
-					updates["phone"] = nil
-				} else {
-					if !phonenumbers.IsValidNumber(v, phonenumbers.DefaultPhoneRegion) {
-						return helpers.ValidationErrorResponse(c, "Invalid phone number format")
-					}
-					normalizedPhone, err := phonenumbers.NormalizeNumber(v, phonenumbers.DefaultPhoneRegion)
-					if err != nil {
-						return helpers.ValidationErrorResponse(c, "Invalid phone number format")
-					}
-					updates["phone"] = normalizedPhone
-				}
-			}
-		case "company":
-			if v, ok := value.(string); ok {
-				if v == "" {
-					updates["company"] = nil
-				} else {
-					updates["company"] = v
-				}
-			}
-		case "name":
-			if v, ok := value.(string); ok && v != "" {
-				updates["name"] = v
+
+	if req.Name.Set {
+		if req.Name.Clears() {
+			return helpers.ValidationErrorResponse(c, "name cannot be cleared")
+		}
+		updates["name"] = *req.Name.Value
+	}
+	if req.Phone.Clears() {
+		updates["phone"] = nil
+	} else if req.Phone.Set {
+		if !phonenumbers.IsValidNumber(*req.Phone.Value, phonenumbers.DefaultPhoneRegion) {
+			return helpers.ValidationErrorResponse(c, "Invalid phone number format")
+		}
+		normalizedPhone, err := phonenumbers.NormalizeNumber(*req.Phone.Value, phonenumbers.DefaultPhoneRegion)
+		if err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid phone number format")
+		}
+		updates["phone"] = normalizedPhone
+	}
+	if req.CompanyID.Clears() {
+		updates["company_id"] = nil
+	} else if req.CompanyID.Set {
+		if _, err := services.NewCompanyService().GetCompany(c.UserContext(), *req.CompanyID.Value); err != nil {
+			if errors.Is(err, services.ErrCompanyNotFound) {
+				return helpers.ValidationErrorResponse(c, "Company not found")
 			}
-		// Skip protected fields (including roles - handled separately via admin endpoints)
-		case "id", "email", "password", "roles", "created_at", "updated_at", "deleted_at":
-			continue
-		// For any other fields, you can add more cases as needed
-		default:
-			continue
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch company")
 		}
+		updates["company_id"] = *req.CompanyID.Value
 	}
 
 	// Update fields
 	if len(updates) > 0 {
-		result = database.DB.Model(&user).Updates(updates)
+		result = database.DB.WithContext(c.UserContext()).Model(&user).Updates(updates)
 		if result.Error != nil {
+			if message, ok := helpers.ModelValidationError(result.Error); ok {
+				return helpers.ValidationErrorResponse(c, message)
+			}
 			return helpers.InternalServerErrorResponse(c, "Failed to update profile")
 		}
 	}
 	
 	// Reload the user with roles
-	rbacService := services.NewRBACService()
-	updatedUser, err := rbacService.GetUserWithRoles(userID)
+	rbacService := services.NewRBACService().Primary()
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated profile")
 	}
 
-	return helpers.SuccessResponse(c, fiber.StatusOK, dto.ProfileResponse{
-		ID:        updatedUser.ID,
-		Email:     updatedUser.Email,
-		Name:      updatedUser.Name,
-		Phone:     updatedUser.Phone,
-		Company:   updatedUser.Company,
-		Roles:     updatedUser.GetRoleNames(),
-		CreatedAt: updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-	})
+	emails, err := services.NewUserEmailService().ListEmails(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated profile")
+	}
+
+	response := toProfileResponse(updatedUser, emails)
+
+	if len(updates) > 0 {
+		dispatchWebhook(services.WebhookEventUserUpdated, response)
+	}
+
+	masked, err := maskResponse(c, response)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated profile")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, masked)
 }
 
+// ForgotPassword emails a password reset link if the account exists
+// @openapi tag Auth
+// @openapi request dto.ForgotPasswordRequest
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
 func ForgotPassword(c *fiber.Ctx) error {
 	var req dto.ForgotPasswordRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
+	// Run the queries and the email with the request's trace context
+	ctx := c.UserContext()
+	db := database.DB.WithContext(ctx)
+
 	var user models.User
-	result := database.DB.Where("email = ?", helpers.NormalizeEmail(req.Email)).First(&user)
+	result := helpers.WhereEmail(db, req.Email).First(&user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
@@ -286,13 +460,13 @@ func ForgotPassword(c *fiber.Ctx) error {
 		ExpiresAt: auth.GetResetTokenExpiration(),
 	}
 
-	result = database.DB.Create(&resetToken)
+	result = db.Create(&resetToken)
 	if result.Error != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to create reset token")
 	}
 
 	emailService := services.NewEmailService()
-	if err := emailService.SendPasswordReset(user.Email, token); err != nil {
+	if err := emailService.SendPasswordReset(ctx, user.Email, token); err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to send reset email")
 	}
 
@@ -301,45 +475,207 @@ func ForgotPassword(c *fiber.Ctx) error {
 	})
 }
 
+// ResetPassword sets a new password using a reset token
+// @openapi tag Auth
+// @openapi request dto.ResetPasswordRequest
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 401
 func ResetPassword(c *fiber.Ctx) error {
 	var req dto.ResetPasswordRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
 	hashedToken := auth.HashToken(req.Token)
 
 	var resetToken models.PasswordResetToken
-	result := database.DB.Where("token = ?", hashedToken).First(&resetToken)
+	result := database.DB.WithContext(c.UserContext()).Where("token = ?", hashedToken).First(&resetToken)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-			return helpers.UnauthorizedResponse(c, "Invalid or expired reset token")
+			return helpers.UnauthorizedResponse(c, "Invalid or expired reset token", apperrors.ErrAuthInvalidResetToken)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to process request")
 	}
 
 	if resetToken.IsExpired() {
-		database.DB.Delete(&resetToken)
-		return helpers.UnauthorizedResponse(c, "Invalid or expired reset token")
+		database.DB.WithContext(c.UserContext()).Delete(&resetToken)
+		return helpers.UnauthorizedResponse(c, "Invalid or expired reset token", apperrors.ErrAuthInvalidResetToken)
 	}
 
-	hashedPassword, err := auth.HashPassword(req.Password)
-	if err != nil {
-		return helpers.InternalServerErrorResponse(c, "Failed to process password")
+	var user models.User
+	result = database.DB.WithContext(c.UserContext()).Select("id", "password").Where("id = ?", resetToken.UserID).First(&user)
+	if result.Error != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to process request")
 	}
 
-	result = database.DB.Model(&models.User{}).Where("id = ?", resetToken.UserID).Update("password", hashedPassword)
-	if result.Error != nil {
+	if err := setPassword(&user, req.Password); err != nil {
+		if errors.Is(err, services.ErrPasswordReused) {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
 		return helpers.InternalServerErrorResponse(c, "Failed to update password")
 	}
 
-	database.DB.Where("user_id = ?", resetToken.UserID).Delete(&models.PasswordResetToken{})
-
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
 		Message: "Password has been reset successfully.",
 	})
-}
\ No newline at end of file
+}
+
+// ChangePassword sets a new password for the authenticated user after
+// checking their current one
+// @openapi tag Profile
+// @openapi request dto.ChangePasswordRequest
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 401
+func ChangePassword(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	var req dto.ChangePasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	var user models.User
+	result := database.DB.WithContext(c.UserContext()).Select("id", "password").Where("id = ?", userID).First(&user)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to process request")
+	}
+
+	if !auth.CheckPassword(req.CurrentPassword, user.Password) {
+		return helpers.UnauthorizedResponse(c, "Current password is incorrect", apperrors.ErrAuthIncorrectPassword)
+	}
+
+	if err := auth.ValidatePassword(req.NewPassword); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
+	if err := setPassword(&user, req.NewPassword); err != nil {
+		if errors.Is(err, services.ErrPasswordReused) {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update password")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Password has been changed successfully.",
+	})
+}
+
+// setPassword replaces the password of user, which must have its ID and
+// current password hash loaded, and invalidates outstanding reset tokens and
+// every session of the user. The current password counts as reused as well as
+// the ones in the history.
+func setPassword(user *models.User, password string) error {
+	passwordHistoryService := services.NewPasswordHistoryService()
+	historyDepth := helpers.GetEnvInt("PASSWORD_HISTORY_DEPTH", services.DefaultPasswordHistoryDepth)
+	if historyDepth > 0 && auth.CheckPassword(password, user.Password) {
+		return services.ErrPasswordReused
+	}
+	if err := passwordHistoryService.CheckHistory(user.ID, password, historyDepth); err != nil {
+		return err
+	}
+
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if err := database.DB.Model(&models.User{}).Where("id = ?", user.ID).Update("password", hashedPassword).Error; err != nil {
+		return err
+	}
+
+	if err := passwordHistoryService.RecordPassword(user.ID, user.Password); err != nil {
+		logger.Error("Failed to record password history", "user_id", user.ID, "error", err)
+	}
+
+	database.DB.Where("user_id = ?", user.ID).Delete(&models.PasswordResetToken{})
+	if err := session.Sessions().RevokeAll(user.ID); err != nil {
+		logger.Error("Failed to revoke sessions after password change", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+// GetPasswordPolicy returns the password requirements so clients can show them
+// @openapi tag Auth
+// @openapi response 200 min_length:integer max_length:integer require_uppercase:boolean require_lowercase:boolean require_digit:boolean require_special:boolean
+func GetPasswordPolicy(c *fiber.Ctx) error {
+	return helpers.SuccessResponse(c, fiber.StatusOK, auth.LoadPasswordPolicy())
+}
+
+// GetJWKS returns the public keys that verify access tokens as a JSON Web Key
+// Set. The set is empty when tokens are signed with a shared secret (HS256).
+// @openapi tag Auth
+// @openapi response 200 dto.JWKSResponse
+func GetJWKS(c *fiber.Ctx) error {
+	publicKey, kid, err := auth.PublicKey()
+	if err != nil {
+		logger.Error("Failed to load JWT public key", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to load signing keys")
+	}
+
+	keys := []dto.JWK{}
+	if publicKey != nil {
+		n, e := auth.EncodeRSAPublicKey(publicKey)
+		keys = append(keys, dto.JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: auth.AlgorithmRS256,
+			Kid: kid,
+			N:   n,
+			E:   e,
+		})
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=3600")
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.JWKSResponse{Keys: keys})
+}
+
+func toProfileResponse(user *models.User, emails []models.UserEmail) dto.ProfileResponse {
+	return dto.ProfileResponse{
+		ID:        user.ID,
+		Email:     user.Email,
+		Emails:    toUserEmailResponses(emails),
+		Name:      user.Name,
+		Phone:     user.Phone,
+		CompanyID: user.CompanyID,
+		Company:   toCompanyResponse(user.Company),
+		AvatarURL: user.AvatarURL,
+		Roles:     user.GetRoleNames(),
+		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// newEmailService is replaced in tests
+var newEmailService = services.NewEmailService
+
+// sendWelcomeEmail greets a newly registered user unless SEND_WELCOME_EMAIL is
+// false. Registration succeeds even when the email cannot be sent.
+func sendWelcomeEmail(to, name string) {
+	if !helpers.GetEnvBool("SEND_WELCOME_EMAIL", true) {
+		return
+	}
+
+	if err := newEmailService().SendWelcomeEmail(to, name); err != nil {
+		logger.Error("Failed to send welcome email", "to", to, "error", err)
+	}
+}
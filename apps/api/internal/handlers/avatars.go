@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/services"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// avatarFilenamePattern matches the <userID>.webp names avatars are stored
+// under, which also keeps requests inside the avatar directory
+var avatarFilenamePattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\.webp$`)
+
+// UploadAvatar sets the authenticated user's profile picture from a JPEG,
+// PNG or WebP image of up to 5 MB, stored as a 256×256 WebP
+// @openapi tag Profile
+// @openapi upload file
+// @openapi response 200 dto.ProfileResponse
+// @openapi response 400
+// @openapi response 404
+func UploadAvatar(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return helpers.ValidationErrorResponse(c, "Image file is required in the 'file' form field")
+	}
+	if fileHeader.Size > services.MaxAvatarSize {
+		return helpers.ValidationErrorResponse(c, services.ErrAvatarTooLarge.Error())
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return helpers.ValidationErrorResponse(c, "Failed to read uploaded file")
+	}
+	defer file.Close()
+
+	if _, err := services.NewAvatarService().Upload(userID, file); err != nil {
+		switch {
+		case errors.Is(err, services.ErrAvatarTooLarge), errors.Is(err, services.ErrAvatarType), errors.Is(err, services.ErrInvalidAvatar):
+			return helpers.ValidationErrorResponse(c, err.Error())
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		logger.Error("Failed to upload avatar", "user_id", userID, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to upload avatar")
+	}
+
+	rbacService := services.NewRBACService().Primary()
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated profile")
+	}
+
+	emails, err := services.NewUserEmailService().ListEmails(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated profile")
+	}
+
+	response := toProfileResponse(updatedUser, emails)
+	dispatchWebhook(services.WebhookEventUserUpdated, response)
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+// ServeAvatar serves an uploaded avatar image
+// @openapi tag Profile
+// @openapi response 200
+// @openapi response 404
+func ServeAvatar(c *fiber.Ctx) error {
+	filename := c.Params("filename")
+	if !avatarFilenamePattern.MatchString(filename) {
+		return helpers.NotFoundResponse(c, "Avatar not found", apperrors.ErrAvatarNotFound)
+	}
+
+	path := filepath.Join(services.AvatarDir(), filename)
+	if _, err := os.Stat(path); err != nil {
+		return helpers.NotFoundResponse(c, "Avatar not found", apperrors.ErrAvatarNotFound)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.SendFile(path)
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"api/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestServeAvatar(t *testing.T) {
+	uploadDir := t.TempDir()
+	t.Setenv("UPLOAD_DIR", uploadDir)
+
+	const filename = "0b6b5a4e-3c1f-4d8e-9a52-6f1e2d3c4b5a.webp"
+	if err := os.MkdirAll(services.AvatarDir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(services.AvatarDir(), filename), []byte("RIFF"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// A file beside the avatar directory must not be reachable
+	if err := os.WriteFile(filepath.Join(uploadDir, "secret.webp"), []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	app := fiber.New()
+	app.Get("/uploads/avatars/:filename", ServeAvatar)
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{"stored avatar", "/uploads/avatars/" + filename, fiber.StatusOK},
+		{"missing avatar", "/uploads/avatars/1b6b5a4e-3c1f-4d8e-9a52-6f1e2d3c4b5a.webp", fiber.StatusNotFound},
+		{"other extension", "/uploads/avatars/0b6b5a4e-3c1f-4d8e-9a52-6f1e2d3c4b5a.png", fiber.StatusNotFound},
+		{"parent directory", "/uploads/avatars/..%2Fsecret.webp", fiber.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if tt.want == fiber.StatusOK && resp.Header.Get("Content-Type") != "image/webp" {
+				t.Errorf("Content-Type = %q, want image/webp", resp.Header.Get("Content-Type"))
+			}
+		})
+	}
+}
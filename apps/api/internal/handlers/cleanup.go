@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"api/internal/helpers"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetCleanupStats returns how many expired records the last cleanup run
+// deleted from each table (admin only)
+// @openapi tag System
+// @openapi response 200 last_run_at:string deleted:object
+func GetCleanupStats(c *fiber.Ctx) error {
+	stats := services.GetCleanupStats()
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"last_run_at": stats.LastRunAt,
+		"deleted":     stats.Deleted,
+	})
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListCompanies returns all companies (admin only)
+// @openapi tag Companies
+// @openapi response 200 companies:[]dto.CompanyResponse total:integer
+func ListCompanies(c *fiber.Ctx) error {
+	companies, err := services.NewCompanyService().ListCompanies(c.UserContext())
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch companies")
+	}
+
+	responses := make([]dto.CompanyResponse, len(companies))
+	for i := range companies {
+		responses[i] = *toCompanyResponse(&companies[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"companies": responses,
+		"total":     len(responses),
+	})
+}
+
+// GetCompany returns a company by ID (admin only)
+// @openapi tag Companies
+// @openapi response 200 dto.CompanyResponse
+// @openapi response 404
+func GetCompany(c *fiber.Ctx) error {
+	companyID := c.Params("id")
+	if _, err := uuid.Parse(companyID); err != nil {
+		return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+	}
+
+	company, err := services.NewCompanyService().GetCompany(c.UserContext(), companyID)
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyNotFound) {
+			return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch company")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toCompanyResponse(company))
+}
+
+// CreateCompany creates a company (admin only)
+// @openapi tag Companies
+// @openapi request dto.CreateCompanyRequest
+// @openapi response 201 dto.CompanyResponse
+// @openapi response 400
+// @openapi response 409
+func CreateCompany(c *fiber.Ctx) error {
+	var req dto.CreateCompanyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	company := models.Company{
+		Name:    helpers.TrimString(req.Name),
+		Domain:  req.Domain,
+		Website: req.Website,
+	}
+
+	if err := services.NewCompanyService().CreateCompany(c.UserContext(), &company); err != nil {
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Company name or domain already exists", apperrors.ErrCompanyExists)
+		}
+		logger.Error("Failed to create company", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to create company")
+	}
+
+	recordAudit(c, services.AuditActionCompanyCreate, services.AuditResourceCompany, company.ID, companyAuditFields(&company))
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, toCompanyResponse(&company))
+}
+
+// UpdateCompany updates a company's name, domain or website (admin only)
+// @openapi tag Companies
+// @openapi request dto.UpdateCompanyRequest
+// @openapi response 200 dto.CompanyResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
+func UpdateCompany(c *fiber.Ctx) error {
+	companyID := c.Params("id")
+	if _, err := uuid.Parse(companyID); err != nil {
+		return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+	}
+
+	var req dto.UpdateCompanyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	companyService := services.NewCompanyService()
+
+	existingCompany, err := companyService.GetCompany(c.UserContext(), companyID)
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyNotFound) {
+			return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch company")
+	}
+
+	// Build updates map for selective updates
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		updates["name"] = helpers.TrimString(*req.Name)
+	}
+
+	if req.Domain != nil {
+		if *req.Domain == "" {
+			updates["domain"] = nil
+		} else {
+			updates["domain"] = *req.Domain
+		}
+	}
+
+	if req.Website != nil {
+		if *req.Website == "" {
+			updates["website"] = nil
+		} else {
+			updates["website"] = *req.Website
+		}
+	}
+
+	if len(updates) == 0 {
+		return helpers.ValidationErrorResponse(c, "No fields to update")
+	}
+
+	if err := companyService.UpdateCompany(c.UserContext(), companyID, updates); err != nil {
+		if errors.Is(err, services.ErrCompanyNotFound) {
+			return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+		}
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Company name or domain already exists", apperrors.ErrCompanyExists)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update company")
+	}
+
+	recordAudit(c, services.AuditActionCompanyUpdate, services.AuditResourceCompany, companyID, services.AuditDiff(companyAuditFields(existingCompany), updates))
+
+	updatedCompany, err := companyService.GetCompany(c.UserContext(), companyID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated company")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toCompanyResponse(updatedCompany))
+}
+
+// DeleteCompany removes a company; its members are kept without a company
+// (admin only)
+// @openapi tag Companies
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func DeleteCompany(c *fiber.Ctx) error {
+	companyID := c.Params("id")
+	if _, err := uuid.Parse(companyID); err != nil {
+		return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+	}
+
+	companyService := services.NewCompanyService()
+
+	existingCompany, err := companyService.GetCompany(c.UserContext(), companyID)
+	if err != nil {
+		if errors.Is(err, services.ErrCompanyNotFound) {
+			return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch company")
+	}
+
+	if err := companyService.DeleteCompany(c.UserContext(), companyID); err != nil {
+		if errors.Is(err, services.ErrCompanyNotFound) {
+			return helpers.NotFoundResponse(c, "Company not found", apperrors.ErrCompanyNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to delete company")
+	}
+
+	recordAudit(c, services.AuditActionCompanyDelete, services.AuditResourceCompany, companyID, companyAuditFields(existingCompany))
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Company deleted successfully",
+	})
+}
+
+// companyAuditFields snapshots the editable company fields for audit diffs
+func companyAuditFields(company *models.Company) map[string]interface{} {
+	return map[string]interface{}{
+		"name":    company.Name,
+		"domain":  derefString(company.Domain),
+		"website": derefString(company.Website),
+	}
+}
+
+// toCompanyResponse converts a company, returning nil for users without one
+func toCompanyResponse(company *models.Company) *dto.CompanyResponse {
+	if company == nil {
+		return nil
+	}
+	return &dto.CompanyResponse{
+		ID:        company.ID,
+		Name:      company.Name,
+		Domain:    company.Domain,
+		Website:   company.Website,
+		CreatedAt: company.CreatedAt,
+		UpdatedAt: company.UpdatedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/services"
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// ExportMyData downloads all personal data stored for the authenticated user
+// @openapi tag Privacy
+// @openapi response 200 dto.UserDataExport
+func ExportMyData(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	return sendUserDataExport(c, userID, "my_data.json")
+}
+
+// ExportUserData downloads all personal data stored for a user (admin only)
+// @openapi tag Privacy
+// @openapi response 200 dto.UserDataExport
+// @openapi response 404
+func ExportUserData(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	return sendUserDataExport(c, userID, "user_"+userID+"_data.json")
+}
+
+func sendUserDataExport(c *fiber.Ctx, userID, filename string) error {
+	gdprService := services.NewGDPRService()
+
+	export, err := gdprService.ExportUserData(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to export user data")
+	}
+
+	response := dto.UserDataExport{
+		ExportedAt: export.ExportedAt,
+		Profile: dto.UserManagementResponse{
+			ID:                  export.User.ID,
+			Email:               export.User.Email,
+			Name:                export.User.Name,
+			Phone:               export.User.Phone,
+			CompanyID:           export.User.CompanyID,
+			Company:             toCompanyResponse(export.User.Company),
+			AvatarURL:           export.User.AvatarURL,
+			Roles:               []string{},
+			Tags:                []string{},
+			IsActive:            export.User.IsActive,
+			EmailDeliveryStatus: export.User.EmailDeliveryStatus,
+			TokenExpiryOverride: tokenExpirySeconds(export.User.TokenExpiryOverride),
+			LastLoginAt:         export.User.LastLoginAt,
+			CreatedAt:           export.User.CreatedAt.Format("2006-01-02T15:04:05Z"),
+			UpdatedAt:           export.User.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		},
+		RoleAssignments:     make([]dto.RoleAssignmentResponse, 0, len(export.RoleAssignments)),
+		AuditLogs:           make([]dto.AuditLogResponse, 0, len(export.AuditLogs)),
+		PasswordResetTokens: make([]dto.PasswordResetTokenExport, 0, len(export.PasswordResetTokens)),
+		LoginHistory:        make([]dto.LoginEventResponse, 0, len(export.LoginEvents)),
+	}
+
+	for _, assignment := range export.RoleAssignments {
+		if !assignment.IsExpired() {
+			response.Profile.Roles = append(response.Profile.Roles, assignment.Role.Name)
+		}
+		response.RoleAssignments = append(response.RoleAssignments, dto.RoleAssignmentResponse{
+			RoleID:    assignment.RoleID,
+			RoleName:  assignment.Role.Name,
+			GrantedAt: assignment.GrantedAt,
+			GrantedBy: assignment.GrantedBy,
+			ExpiresAt: assignment.ExpiresAt,
+			IsExpired: assignment.IsExpired(),
+		})
+	}
+
+	for _, log := range export.AuditLogs {
+		response.AuditLogs = append(response.AuditLogs, toAuditLogResponse(log))
+	}
+
+	for _, token := range export.PasswordResetTokens {
+		response.PasswordResetTokens = append(response.PasswordResetTokens, dto.PasswordResetTokenExport{
+			ID:        token.ID,
+			CreatedAt: token.CreatedAt,
+			ExpiresAt: token.ExpiresAt,
+		})
+	}
+
+	for _, event := range export.LoginEvents {
+		response.LoginHistory = append(response.LoginHistory, toLoginEventResponse(event))
+	}
+
+	data, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to export user data")
+	}
+
+	c.Attachment(filename)
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(data)
+}
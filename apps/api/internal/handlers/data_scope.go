@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// dataScopes returns the data scopes of the authenticated user's roles, which
+// limit the users they can see and manage. Scopes already loaded by
+// middleware.RequireUserInDataScope are reused.
+func dataScopes(c *fiber.Ctx) ([]map[string]interface{}, error) {
+	if scopes, ok := middleware.GetDataScopes(c); ok {
+		return scopes, nil
+	}
+	return services.NewRBACService().GetDataScopes(c.UserContext(), middleware.GetUserRoles(c))
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// dbDebugger inspects how the database runs the API's queries
+type dbDebugger interface {
+	ExplainTable(ctx context.Context, table string) ([]services.QueryPlan, error)
+	MissingIndexes(ctx context.Context, minRows int64) ([]services.TableScanStats, error)
+}
+
+// newDBDebugger is replaced in tests
+var newDBDebugger = func() dbDebugger { return services.NewDBDebugService() }
+
+// dbDebugEnabled reports whether the database debug endpoints are served.
+// They run real queries and expose the schema, so they are off in production.
+func dbDebugEnabled() bool {
+	return helpers.GetEnv("ENV", "development") != "production"
+}
+
+// AnalyzeTable returns the executed query plans of the most common queries on
+// a table (admin only, not served when ENV=production)
+// @openapi tag System
+// @openapi param table string Table whose queries to explain, such as users
+// @openapi response 200 dto.TableAnalysisResponse
+// @openapi response 400
+// @openapi response 404
+func AnalyzeTable(c *fiber.Ctx) error {
+	if !dbDebugEnabled() {
+		return helpers.NotFoundResponse(c, "Not found", apperrors.ErrNotFound)
+	}
+
+	table := c.Query("table")
+	if table == "" {
+		return helpers.ValidationErrorResponse(c, "table is required")
+	}
+
+	plans, err := newDBDebugger().ExplainTable(c.UserContext(), table)
+	if err != nil {
+		if errors.Is(err, services.ErrTableNotAnalyzable) {
+			return helpers.ValidationErrorResponse(c, "table must be one of: "+strings.Join(services.AnalyzableTables(), ", "))
+		}
+		logger.Error("Failed to analyze table", "table", table, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to analyze table")
+	}
+
+	queries := make([]dto.QueryPlanResponse, len(plans))
+	for i, plan := range plans {
+		queries[i] = dto.QueryPlanResponse{
+			Name:  plan.Name,
+			Query: plan.Query,
+			Plan:  plan.Plan,
+		}
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.TableAnalysisResponse{
+		Table:   table,
+		Queries: queries,
+	})
+}
+
+// GetMissingIndexes lists tables read sequentially more often than through
+// an index, which may need one (admin only, not served when ENV=production)
+// @openapi tag System
+// @openapi param min_rows integer Smallest table to report, in rows (default 1000)
+// @openapi response 200 dto.MissingIndexesResponse
+// @openapi response 400
+// @openapi response 404
+func GetMissingIndexes(c *fiber.Ctx) error {
+	if !dbDebugEnabled() {
+		return helpers.NotFoundResponse(c, "Not found", apperrors.ErrNotFound)
+	}
+
+	minRows := c.QueryInt("min_rows", services.DefaultMissingIndexMinRows)
+	if minRows < 0 {
+		return helpers.ValidationErrorResponse(c, "min_rows must not be negative")
+	}
+
+	stats, err := newDBDebugger().MissingIndexes(c.UserContext(), int64(minRows))
+	if err != nil {
+		logger.Error("Failed to read table scan statistics", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to find missing indexes")
+	}
+
+	tables := make([]dto.TableScanStatsResponse, len(stats))
+	for i, s := range stats {
+		tables[i] = dto.TableScanStatsResponse{
+			Table:        s.Table,
+			SeqScan:      s.SeqScan,
+			SeqTupRead:   s.SeqTupRead,
+			IdxScan:      s.IdxScan,
+			LiveRows:     s.LiveRows,
+			Indexes:      s.Indexes,
+			SeqScanRatio: s.SeqScanRatio(),
+		}
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MissingIndexesResponse{
+		MinRows: int64(minRows),
+		Tables:  tables,
+	})
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"api/internal/dto"
+	"api/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+type fakeDBDebugger struct {
+	minRows int64
+}
+
+func (f *fakeDBDebugger) ExplainTable(ctx context.Context, table string) ([]services.QueryPlan, error) {
+	if table != "users" {
+		return nil, services.ErrTableNotAnalyzable
+	}
+	return []services.QueryPlan{
+		{Name: "login by email", Query: "SELECT * FROM users WHERE email = ?", Plan: json.RawMessage(`[{"Plan":{"Node Type":"Index Scan"}}]`)},
+	}, nil
+}
+
+func (f *fakeDBDebugger) MissingIndexes(ctx context.Context, minRows int64) ([]services.TableScanStats, error) {
+	f.minRows = minRows
+	return []services.TableScanStats{
+		{Table: "audit_logs", SeqScan: 30, SeqTupRead: 90000, IdxScan: 10, LiveRows: 3000, Indexes: 1},
+	}, nil
+}
+
+func useFakeDBDebugger(t *testing.T, fake *fakeDBDebugger) {
+	original := newDBDebugger
+	newDBDebugger = func() dbDebugger { return fake }
+	t.Cleanup(func() { newDBDebugger = original })
+}
+
+func newDBDebugApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/db/analyze", AnalyzeTable)
+	app.Get("/db/missing-indexes", GetMissingIndexes)
+	return app
+}
+
+func TestAnalyzeTable(t *testing.T) {
+	t.Setenv("ENV", "development")
+	useFakeDBDebugger(t, &fakeDBDebugger{})
+
+	resp, err := newDBDebugApp().Test(httptest.NewRequest("GET", "/db/analyze?table=users", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	var body dto.TableAnalysisResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if body.Table != "users" || len(body.Queries) != 1 {
+		t.Fatalf("body = %+v, want one users query", body)
+	}
+
+	var plan []map[string]map[string]string
+	if err := json.Unmarshal(body.Queries[0].Plan, &plan); err != nil {
+		t.Fatalf("plan is not JSON: %v", err)
+	}
+	if plan[0]["Plan"]["Node Type"] != "Index Scan" {
+		t.Errorf("plan = %s, want the explained plan", body.Queries[0].Plan)
+	}
+}
+
+func TestAnalyzeTableRejectsUnknownTable(t *testing.T) {
+	t.Setenv("ENV", "development")
+	useFakeDBDebugger(t, &fakeDBDebugger{})
+
+	for _, path := range []string{"/db/analyze", "/db/analyze?table=pg_authid"} {
+		resp, err := newDBDebugApp().Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want %d", path, resp.StatusCode, fiber.StatusBadRequest)
+		}
+	}
+}
+
+func TestGetMissingIndexes(t *testing.T) {
+	t.Setenv("ENV", "staging")
+	fake := &fakeDBDebugger{}
+	useFakeDBDebugger(t, fake)
+
+	resp, err := newDBDebugApp().Test(httptest.NewRequest("GET", "/db/missing-indexes?min_rows=500", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	var body dto.MissingIndexesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	if fake.minRows != 500 || body.MinRows != 500 {
+		t.Errorf("min_rows = %d (queried with %d), want 500", body.MinRows, fake.minRows)
+	}
+	if len(body.Tables) != 1 || body.Tables[0].Table != "audit_logs" {
+		t.Fatalf("tables = %+v, want audit_logs", body.Tables)
+	}
+	if body.Tables[0].SeqScanRatio != 0.75 {
+		t.Errorf("seq_scan_ratio = %v, want 0.75", body.Tables[0].SeqScanRatio)
+	}
+}
+
+func TestDBDebugDisabledInProduction(t *testing.T) {
+	t.Setenv("ENV", "production")
+	fake := &fakeDBDebugger{minRows: -1}
+	useFakeDBDebugger(t, fake)
+
+	for _, path := range []string{"/db/analyze?table=users", "/db/missing-indexes"} {
+		resp, err := newDBDebugApp().Test(httptest.NewRequest("GET", path, nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("GET %s status = %d, want %d", path, resp.StatusCode, fiber.StatusNotFound)
+		}
+	}
+	if fake.minRows != -1 {
+		t.Error("the database was queried in production")
+	}
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	"api/internal/helpers"
+	"api/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetSlowRequests returns the last 50 requests with the number of SQL
+// statements each ran, most first (admin only). Statements are only counted
+// when ENV is development; otherwise enabled is false and the list is empty.
+// @openapi tag System
+// @openapi response 200 dto.SlowRequestsResponse
+func GetSlowRequests(c *fiber.Ctx) error {
+	requests := []dto.RequestQueryCountResponse{}
+	for _, entry := range middleware.RecentQueryCounts() {
+		requests = append(requests, dto.RequestQueryCountResponse{
+			Method:  entry.Method,
+			Path:    entry.Path,
+			Status:  entry.Status,
+			Queries: entry.Queries,
+			At:      entry.At,
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.SlowRequestsResponse{
+		Enabled:  middleware.QueryCounterEnabled(),
+		Requests: requests,
+	})
+}
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"api/internal/openapi"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetDocs serves Swagger UI for the YAML specification at /docs/openapi.yaml
+// @openapi tag System
+// @openapi response 200
+// @openapi response 401
+func GetDocs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(fmt.Sprintf(swaggerUIPage, "/docs/openapi.yaml"))
+}
+
+// GetDocsSpec serves the OpenAPI specification embedded at build time as YAML
+// @openapi tag System
+// @openapi response 200
+// @openapi response 401
+func GetDocsSpec(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, "application/yaml")
+	return c.Send(openapi.SpecYAML)
+}
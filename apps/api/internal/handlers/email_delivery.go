@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// EmailDeliveryWebhook receives delivery reports from the email provider in
+// the EMAIL_DELIVERY_WEBHOOK_FORMAT format. Bounces and complaints deactivate
+// the recipient's account.
+// @openapi tag Webhooks
+// @openapi response 200 processed:integer
+// @openapi response 400
+// @openapi response 401
+// @openapi response 404
+func EmailDeliveryWebhook(c *fiber.Ctx) error {
+	deliveryService := services.NewEmailDeliveryService()
+
+	events, err := deliveryService.ParseWebhook(c.Body(), c.Get(services.SendGridSignatureHeader), c.Get(services.SendGridTimestampHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEmailWebhookDisabled):
+			return helpers.NotFoundResponse(c, "Email delivery webhook is not configured")
+		case errors.Is(err, services.ErrInvalidEmailWebhookSignature):
+			return helpers.UnauthorizedResponse(c, "Invalid webhook signature", apperrors.ErrAuthInvalidSignature)
+		case errors.Is(err, services.ErrInvalidEmailWebhookPayload):
+			return helpers.ValidationErrorResponse(c, "Invalid webhook payload")
+		}
+		logger.Error("Failed to parse email delivery webhook", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to process webhook")
+	}
+
+	for _, event := range events {
+		if err := deliveryService.HandleDeliveryEvent(event); err != nil {
+			// A 5xx makes the provider retry the whole payload later
+			logger.Error("Failed to handle email delivery event", "status", event.Status, "error", err)
+			return helpers.InternalServerErrorResponse(c, "Failed to process webhook")
+		}
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"processed": len(events),
+	})
+}
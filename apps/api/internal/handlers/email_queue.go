@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"api/internal/helpers"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetEmailQueueStats returns email queue depth and delivery counters (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 enabled:boolean depth:integer capacity:integer workers:integer processed:integer failed:integer
+func GetEmailQueueStats(c *fiber.Ctx) error {
+	stats, enabled := services.GetEmailQueueStats()
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"enabled":   enabled,
+		"depth":     stats.Depth,
+		"capacity":  stats.Capacity,
+		"workers":   stats.Workers,
+		"processed": stats.Processed,
+		"failed":    stats.Failed,
+	})
+}
+
+// GetEmailCircuitStatus returns the state of the SMTP circuit breaker and its
+// count of consecutive failures (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 state:string failures:integer
+func GetEmailCircuitStatus(c *fiber.Ctx) error {
+	status := services.GetEmailCircuitStatus()
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"state":    status.State,
+		"failures": status.Failures,
+	})
+}
@@ -2,20 +2,32 @@ package handlers
 
 import (
 	"api/internal/dto"
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
+	"api/internal/middleware"
 	"api/internal/models"
 	"api/internal/services"
 	"errors"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
 
+// canManageProtectedTemplates reports whether the caller may edit or delete
+// protected templates and change whether a template is protected
+func canManageProtectedTemplates(c *fiber.Ctx) (bool, error) {
+	rbacService := services.NewRBACService()
+	return rbacService.HasPermission(c.UserContext(), middleware.GetUserID(c), services.PermissionManageProtectedTemplates)
+}
+
 // ListEmailTemplates returns all email templates (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 templates:[]dto.EmailTemplateListResponse total:integer
 func ListEmailTemplates(c *fiber.Ctx) error {
 	templateService := services.NewEmailTemplateService()
 	
-	templates, err := templateService.GetAllTemplates()
+	templates, err := templateService.GetAllTemplates(c.UserContext())
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email templates")
 	}
@@ -25,9 +37,11 @@ func ListEmailTemplates(c *fiber.Ctx) error {
 		templateResponses = append(templateResponses, dto.EmailTemplateListResponse{
 			ID:        template.ID,
 			Name:      template.Name,
+			Language:  template.Language,
 			Subject:   template.Subject,
 			Variables: template.Variables,
 			IsActive:  template.IsActive,
+			IsProtected: template.Protected,
 			CreatedAt: template.CreatedAt.Format("2006-01-02T15:04:05Z"),
 			UpdatedAt: template.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 		})
@@ -40,6 +54,9 @@ func ListEmailTemplates(c *fiber.Ctx) error {
 }
 
 // GetEmailTemplate returns a specific email template (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 dto.EmailTemplateResponse
+// @openapi response 404
 func GetEmailTemplate(c *fiber.Ctx) error {
 	templateID := c.Params("id")
 	if templateID == "" {
@@ -47,10 +64,10 @@ func GetEmailTemplate(c *fiber.Ctx) error {
 	}
 
 	templateService := services.NewEmailTemplateService()
-	template, err := templateService.GetTemplateByID(templateID)
+	template, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Email template not found")
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
 	}
@@ -58,21 +75,30 @@ func GetEmailTemplate(c *fiber.Ctx) error {
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.EmailTemplateResponse{
 		ID:           template.ID,
 		Name:         template.Name,
+		Language:     template.Language,
 		Subject:      template.Subject,
 		HTMLTemplate: template.HTMLTemplate,
 		TextTemplate: template.TextTemplate,
 		Variables:    template.Variables,
 		IsActive:     template.IsActive,
+		IsProtected:  template.Protected,
+		Version:      template.Version,
 		CreatedAt:    template.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:    template.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	})
 }
 
 // CreateEmailTemplate creates a new email template (admin only)
+// @openapi tag Email Templates
+// @openapi request dto.CreateEmailTemplateRequest
+// @openapi response 201 dto.EmailTemplateResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 409
 func CreateEmailTemplate(c *fiber.Ctx) error {
 	var req dto.CreateEmailTemplateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -91,8 +117,13 @@ func CreateEmailTemplate(c *fiber.Ctx) error {
 		return helpers.ValidationErrorResponse(c, "Invalid template syntax: "+err.Error())
 	}
 
+	if err := templateService.ValidateVariableDeclarations(req.HTMLTemplate, req.TextTemplate, req.Variables); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
 	template := models.EmailTemplate{
 		Name:         req.Name,
+		Language:     req.Language,
 		Subject:      req.Subject,
 		HTMLTemplate: req.HTMLTemplate,
 		TextTemplate: req.TextTemplate,
@@ -100,32 +131,64 @@ func CreateEmailTemplate(c *fiber.Ctx) error {
 		IsActive:     true,
 	}
 
+	if template.Language == "" {
+		template.Language = services.DefaultTemplateLanguage
+	}
+
 	if req.IsActive != nil {
 		template.IsActive = *req.IsActive
 	}
 
-	err := templateService.CreateTemplate(&template)
+	if req.IsProtected != nil && *req.IsProtected {
+		allowed, err := canManageProtectedTemplates(c)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to check permission")
+		}
+		if !allowed {
+			return helpers.ForbiddenResponse(c, "Managing protected email templates requires the "+services.PermissionManageProtectedTemplates+" permission", apperrors.ErrPermissionDenied)
+		}
+		template.Protected = true
+	}
+
+	err := templateService.CreateTemplate(c.UserContext(), &template)
 	if err != nil {
 		if helpers.IsDuplicateError(err) {
-			return helpers.ConflictResponse(c, "Template with this name already exists")
+			return helpers.ConflictResponse(c, "Template with this name and language already exists", apperrors.ErrEmailTemplateExists)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to create email template")
 	}
 
+	recordAudit(c, services.AuditActionEmailTemplateCreate, services.AuditResourceEmailTemplate, template.ID, fiber.Map{
+		"name":      template.Name,
+		"language":  template.Language,
+		"subject":   template.Subject,
+		"is_active": template.IsActive,
+		"is_protected": template.Protected,
+	})
+
 	return helpers.SuccessResponse(c, fiber.StatusCreated, dto.EmailTemplateResponse{
 		ID:           template.ID,
 		Name:         template.Name,
+		Language:     template.Language,
 		Subject:      template.Subject,
 		HTMLTemplate: template.HTMLTemplate,
 		TextTemplate: template.TextTemplate,
 		Variables:    template.Variables,
 		IsActive:     template.IsActive,
+		IsProtected:  template.Protected,
+		Version:      template.Version,
 		CreatedAt:    template.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:    template.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	})
 }
 
 // UpdateEmailTemplate updates an existing email template (admin only)
+// @openapi tag Email Templates
+// @openapi request dto.UpdateEmailTemplateRequest
+// @openapi response 200 dto.EmailTemplateResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 404
 func UpdateEmailTemplate(c *fiber.Ctx) error {
 	templateID := c.Params("id")
 	if templateID == "" {
@@ -134,7 +197,7 @@ func UpdateEmailTemplate(c *fiber.Ctx) error {
 
 	var req dto.UpdateEmailTemplateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -144,14 +207,24 @@ func UpdateEmailTemplate(c *fiber.Ctx) error {
 	templateService := services.NewEmailTemplateService()
 
 	// Check if template exists
-	existingTemplate, err := templateService.GetTemplateByID(templateID)
+	existingTemplate, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Email template not found")
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
 	}
 
+	if existingTemplate.Protected || (req.IsProtected != nil && *req.IsProtected) {
+		allowed, err := canManageProtectedTemplates(c)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to check permission")
+		}
+		if !allowed {
+			return helpers.ForbiddenResponse(c, "Managing protected email templates requires the "+services.PermissionManageProtectedTemplates+" permission", apperrors.ErrPermissionDenied)
+		}
+	}
+
 	// Build updates map for selective updates
 	updates := make(map[string]interface{})
 
@@ -159,6 +232,10 @@ func UpdateEmailTemplate(c *fiber.Ctx) error {
 		updates["name"] = *req.Name
 	}
 
+	if req.Language != "" {
+		updates["language"] = req.Language
+	}
+
 	if req.Subject != nil {
 		updates["subject"] = *req.Subject
 	}
@@ -179,8 +256,12 @@ func UpdateEmailTemplate(c *fiber.Ctx) error {
 		updates["is_active"] = *req.IsActive
 	}
 
-	// Validate template syntax if templates are being updated
-	if req.HTMLTemplate != nil || req.TextTemplate != nil {
+	if req.IsProtected != nil {
+		updates["protected"] = *req.IsProtected
+	}
+
+	// Validate template syntax and variables if templates are being updated
+	if req.HTMLTemplate != nil || req.TextTemplate != nil || req.Variables != nil {
 		htmlTemplate := existingTemplate.HTMLTemplate
 		textTemplate := existingTemplate.TextTemplate
 		variables := existingTemplate.Variables
@@ -203,21 +284,28 @@ func UpdateEmailTemplate(c *fiber.Ctx) error {
 		if err := templateService.ValidateTemplate(htmlTemplate, textTemplate, testVariables); err != nil {
 			return helpers.ValidationErrorResponse(c, "Invalid template syntax: "+err.Error())
 		}
+
+		if err := templateService.ValidateVariableDeclarations(htmlTemplate, textTemplate, variables); err != nil {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
 	}
 
 	// Update template if there are changes
 	if len(updates) > 0 {
-		err = templateService.UpdateTemplate(templateID, updates)
+		updatedBy := middleware.GetUserID(c)
+		err = templateService.UpdateTemplate(c.UserContext(), templateID, updates, &updatedBy)
 		if err != nil {
-			if helpers.IsDuplicateError(err) && req.Name != nil {
-				return helpers.ValidationErrorResponse(c, "Template with this name already exists")
+			if helpers.IsDuplicateError(err) && (req.Name != nil || req.Language != "") {
+				return helpers.ValidationErrorResponse(c, "Template with this name and language already exists")
 			}
 			return helpers.InternalServerErrorResponse(c, "Failed to update email template")
 		}
+
+		recordAudit(c, services.AuditActionEmailTemplateUpdate, services.AuditResourceEmailTemplate, templateID, services.AuditDiff(emailTemplateAuditFields(existingTemplate), updates))
 	}
 
 	// Get updated template
-	updatedTemplate, err := templateService.GetTemplateByID(templateID)
+	updatedTemplate, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated email template")
 	}
@@ -225,17 +313,84 @@ func UpdateEmailTemplate(c *fiber.Ctx) error {
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.EmailTemplateResponse{
 		ID:           updatedTemplate.ID,
 		Name:         updatedTemplate.Name,
+		Language:     updatedTemplate.Language,
 		Subject:      updatedTemplate.Subject,
 		HTMLTemplate: updatedTemplate.HTMLTemplate,
 		TextTemplate: updatedTemplate.TextTemplate,
 		Variables:    updatedTemplate.Variables,
 		IsActive:     updatedTemplate.IsActive,
+		IsProtected:  updatedTemplate.Protected,
+		Version:      updatedTemplate.Version,
 		CreatedAt:    updatedTemplate.CreatedAt.Format("2006-01-02T15:04:05Z"),
 		UpdatedAt:    updatedTemplate.UpdatedAt.Format("2006-01-02T15:04:05Z"),
 	})
 }
 
+// ToggleEmailTemplate activates an inactive template or deactivates an active
+// one (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 dto.EmailTemplateResponse
+// @openapi response 403
+// @openapi response 404
+func ToggleEmailTemplate(c *fiber.Ctx) error {
+	templateID := c.Params("id")
+	if templateID == "" {
+		return helpers.ValidationErrorResponse(c, "Template ID is required")
+	}
+
+	templateService := services.NewEmailTemplateService()
+
+	existingTemplate, err := templateService.GetTemplateByID(c.UserContext(), templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
+	}
+
+	if existingTemplate.Protected {
+		allowed, err := canManageProtectedTemplates(c)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to check permission")
+		}
+		if !allowed {
+			return helpers.ForbiddenResponse(c, "Managing protected email templates requires the "+services.PermissionManageProtectedTemplates+" permission", apperrors.ErrPermissionDenied)
+		}
+	}
+
+	template, err := templateService.ToggleTemplate(c.UserContext(), templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to toggle email template")
+	}
+
+	recordAudit(c, services.AuditActionEmailTemplateUpdate, services.AuditResourceEmailTemplate, templateID, services.AuditDiff(emailTemplateAuditFields(existingTemplate), map[string]interface{}{
+		"is_active": template.IsActive,
+	}))
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.EmailTemplateResponse{
+		ID:           template.ID,
+		Name:         template.Name,
+		Language:     template.Language,
+		Subject:      template.Subject,
+		HTMLTemplate: template.HTMLTemplate,
+		TextTemplate: template.TextTemplate,
+		Variables:    template.Variables,
+		IsActive:     template.IsActive,
+		IsProtected:  template.Protected,
+		Version:      template.Version,
+		CreatedAt:    template.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:    template.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	})
+}
+
 // DeleteEmailTemplate deletes an email template (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 dto.MessageResponse
+// @openapi response 403
+// @openapi response 404
 func DeleteEmailTemplate(c *fiber.Ctx) error {
 	templateID := c.Params("id")
 	if templateID == "" {
@@ -245,26 +400,45 @@ func DeleteEmailTemplate(c *fiber.Ctx) error {
 	templateService := services.NewEmailTemplateService()
 
 	// Check if template exists
-	_, err := templateService.GetTemplateByID(templateID)
+	existingTemplate, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Email template not found")
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
 	}
 
+	if existingTemplate.Protected {
+		allowed, err := canManageProtectedTemplates(c)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to check permission")
+		}
+		if !allowed {
+			return helpers.ForbiddenResponse(c, "Managing protected email templates requires the "+services.PermissionManageProtectedTemplates+" permission", apperrors.ErrPermissionDenied)
+		}
+	}
+
 	// Soft delete the template
-	err = templateService.DeleteTemplate(templateID)
+	err = templateService.DeleteTemplate(c.UserContext(), templateID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to delete email template")
 	}
 
+	recordAudit(c, services.AuditActionEmailTemplateDelete, services.AuditResourceEmailTemplate, templateID, fiber.Map{
+		"name": existingTemplate.Name,
+	})
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
 		Message: "Email template deleted successfully",
 	})
 }
 
 // PreviewEmailTemplate renders a template with provided variables (admin only)
+// @openapi tag Email Templates
+// @openapi request dto.PreviewEmailTemplateRequest
+// @openapi response 200 dto.PreviewEmailTemplateResponse
+// @openapi response 400
+// @openapi response 404
 func PreviewEmailTemplate(c *fiber.Ctx) error {
 	templateID := c.Params("id")
 	if templateID == "" {
@@ -273,7 +447,7 @@ func PreviewEmailTemplate(c *fiber.Ctx) error {
 
 	var req dto.PreviewEmailTemplateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -283,10 +457,10 @@ func PreviewEmailTemplate(c *fiber.Ctx) error {
 	templateService := services.NewEmailTemplateService()
 
 	// Get template
-	template, err := templateService.GetTemplateByID(templateID)
+	template, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Email template not found")
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
 	}
@@ -305,6 +479,11 @@ func PreviewEmailTemplate(c *fiber.Ctx) error {
 }
 
 // TestEmailTemplate sends a test email using the template (admin only)
+// @openapi tag Email Templates
+// @openapi request dto.TestEmailTemplateRequest
+// @openapi response 200 message:string recipient:string subject:string language:string
+// @openapi response 400
+// @openapi response 404
 func TestEmailTemplate(c *fiber.Ctx) error {
 	templateID := c.Params("id")
 	if templateID == "" {
@@ -313,7 +492,7 @@ func TestEmailTemplate(c *fiber.Ctx) error {
 
 	var req dto.TestEmailTemplateRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -323,14 +502,29 @@ func TestEmailTemplate(c *fiber.Ctx) error {
 	templateService := services.NewEmailTemplateService()
 
 	// Get template
-	template, err := templateService.GetTemplateByID(templateID)
+	template, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Email template not found")
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
 	}
 
+	// Use the variant of this template matching the preferred language, if any
+	for _, language := range helpers.ParseAcceptLanguage(c.Get(fiber.HeaderAcceptLanguage)) {
+		if language == template.Language {
+			break
+		}
+		localized, err := templateService.GetTemplateByNameAndLanguage(c.UserContext(), template.Name, language)
+		if err == nil {
+			template = localized
+			break
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
+		}
+	}
+
 	// Render template
 	rendered, err := templateService.RenderEmailTemplate(template, req.Variables)
 	if err != nil {
@@ -349,10 +543,14 @@ func TestEmailTemplate(c *fiber.Ctx) error {
 		"message": "Test email sent successfully",
 		"recipient": req.Email,
 		"subject": rendered.Subject,
+		"language": template.Language,
 	})
 }
 
 // GetTemplateVariables returns the available variables for a template (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 dto.TemplateVariablesResponse
+// @openapi response 404
 func GetTemplateVariables(c *fiber.Ctx) error {
 	templateID := c.Params("id")
 	if templateID == "" {
@@ -362,10 +560,10 @@ func GetTemplateVariables(c *fiber.Ctx) error {
 	templateService := services.NewEmailTemplateService()
 
 	// Get template
-	template, err := templateService.GetTemplateByID(templateID)
+	template, err := templateService.GetTemplateByID(c.UserContext(), templateID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Email template not found")
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
 	}
@@ -373,4 +571,179 @@ func GetTemplateVariables(c *fiber.Ctx) error {
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.TemplateVariablesResponse{
 		Variables: template.Variables,
 	})
-}
\ No newline at end of file
+}
+// ListEmailTemplateVersions returns the saved versions of a template (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 current_version:integer versions:[]dto.EmailTemplateVersionResponse total:integer
+// @openapi response 404
+func ListEmailTemplateVersions(c *fiber.Ctx) error {
+	templateID := c.Params("id")
+	if templateID == "" {
+		return helpers.ValidationErrorResponse(c, "Template ID is required")
+	}
+
+	templateService := services.NewEmailTemplateService()
+
+	template, err := templateService.GetTemplateByID(c.UserContext(), templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
+	}
+
+	versions, err := templateService.GetTemplateVersions(c.UserContext(), templateID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template versions")
+	}
+
+	versionResponses := make([]dto.EmailTemplateVersionResponse, 0, len(versions))
+	for _, version := range versions {
+		versionResponses = append(versionResponses, dto.EmailTemplateVersionResponse{
+			ID:            version.ID,
+			VersionNumber: version.VersionNumber,
+			Subject:       version.Subject,
+			HTMLTemplate:  version.HTMLTemplate,
+			TextTemplate:  version.TextTemplate,
+			Variables:     version.Variables,
+			CreatedBy:     version.CreatedBy,
+			CreatedAt:     version.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"current_version": template.Version,
+		"versions":        versionResponses,
+		"total":           len(versionResponses),
+	})
+}
+
+// RestoreEmailTemplateVersion reverts a template to a saved version (admin only)
+// @openapi tag Email Templates
+// @openapi response 200 dto.EmailTemplateResponse
+// @openapi response 400
+// @openapi response 403
+// @openapi response 404
+func RestoreEmailTemplateVersion(c *fiber.Ctx) error {
+	templateID := c.Params("id")
+	if templateID == "" {
+		return helpers.ValidationErrorResponse(c, "Template ID is required")
+	}
+
+	versionNumber, err := strconv.Atoi(c.Params("version"))
+	if err != nil || versionNumber < 1 {
+		return helpers.ValidationErrorResponse(c, "Invalid version number")
+	}
+
+	templateService := services.NewEmailTemplateService()
+
+	existingTemplate, err := templateService.GetTemplateByID(c.UserContext(), templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch email template")
+	}
+
+	if existingTemplate.Protected {
+		allowed, err := canManageProtectedTemplates(c)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to check permission")
+		}
+		if !allowed {
+			return helpers.ForbiddenResponse(c, "Managing protected email templates requires the "+services.PermissionManageProtectedTemplates+" permission", apperrors.ErrPermissionDenied)
+		}
+	}
+
+	restoredBy := middleware.GetUserID(c)
+	if _, err := templateService.RestoreTemplateVersion(c.UserContext(), templateID, versionNumber, &restoredBy); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Email template version not found", apperrors.ErrEmailTemplateVersionNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to restore email template version")
+	}
+
+	restoredTemplate, err := templateService.GetTemplateByID(c.UserContext(), templateID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch restored email template")
+	}
+
+	changes := services.AuditDiff(emailTemplateAuditFields(existingTemplate), emailTemplateAuditFields(restoredTemplate))
+	recordAudit(c, services.AuditActionEmailTemplateRestore, services.AuditResourceEmailTemplate, templateID, fiber.Map{
+		"restored_version": versionNumber,
+		"changes":          changes,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.EmailTemplateResponse{
+		ID:           restoredTemplate.ID,
+		Name:         restoredTemplate.Name,
+		Language:     restoredTemplate.Language,
+		Subject:      restoredTemplate.Subject,
+		HTMLTemplate: restoredTemplate.HTMLTemplate,
+		TextTemplate: restoredTemplate.TextTemplate,
+		Variables:    restoredTemplate.Variables,
+		IsActive:     restoredTemplate.IsActive,
+		IsProtected:  restoredTemplate.Protected,
+		Version:      restoredTemplate.Version,
+		CreatedAt:    restoredTemplate.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:    restoredTemplate.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	})
+}
+
+// CloneEmailTemplate copies a template under a new name. Clones start inactive (admin only)
+// @openapi tag Email Templates
+// @openapi request dto.CloneEmailTemplateRequest
+// @openapi response 201 dto.EmailTemplateResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
+func CloneEmailTemplate(c *fiber.Ctx) error {
+	templateID := c.Params("id")
+	if templateID == "" {
+		return helpers.ValidationErrorResponse(c, "Template ID is required")
+	}
+
+	var req dto.CloneEmailTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	req.Name = helpers.TrimString(req.Name)
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	templateService := services.NewEmailTemplateService()
+
+	clone, err := templateService.CloneTemplate(c.UserContext(), templateID, req.Name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Email template not found", apperrors.ErrEmailTemplateNotFound)
+		}
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Template with this name and language already exists", apperrors.ErrEmailTemplateExists)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to clone email template")
+	}
+
+	recordAudit(c, services.AuditActionEmailTemplateClone, services.AuditResourceEmailTemplate, clone.ID, fiber.Map{
+		"name":               clone.Name,
+		"language":           clone.Language,
+		"source_template_id": templateID,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, dto.EmailTemplateResponse{
+		ID:           clone.ID,
+		Name:         clone.Name,
+		Language:     clone.Language,
+		Subject:      clone.Subject,
+		HTMLTemplate: clone.HTMLTemplate,
+		TextTemplate: clone.TextTemplate,
+		Variables:    clone.Variables,
+		IsActive:     clone.IsActive,
+		IsProtected:  clone.Protected,
+		Version:      clone.Version,
+		CreatedAt:    clone.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:    clone.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	})
+}
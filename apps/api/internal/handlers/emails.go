@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// AddEmail adds an email address to the authenticated user's account and
+// sends a verification link to it
+// @openapi tag Profile
+// @openapi request dto.AddEmailRequest
+// @openapi response 201 dto.UserEmailResponse
+// @openapi response 400
+// @openapi response 409
+func AddEmail(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	var req dto.AddEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	userEmail, err := services.NewUserEmailService().AddEmail(c.UserContext(), userID, req.Email, newEmailService().SendEmailVerification)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserEmailTaken):
+			return helpers.ConflictResponse(c, "Email already in use", apperrors.ErrEmailTaken)
+		case errors.Is(err, services.ErrEmailVerificationNotSent):
+			logger.Error("Failed to send email verification", "to", req.Email, "error", err)
+			return helpers.InternalServerErrorResponse(c, "Failed to send verification email")
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to add email")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, toUserEmailResponse(userEmail))
+}
+
+// VerifyEmail verifies an added email address with the token sent to it
+// @openapi tag Auth
+// @openapi request dto.VerifyEmailRequest
+// @openapi response 200 dto.UserEmailResponse
+// @openapi response 400
+// @openapi response 401
+func VerifyEmail(c *fiber.Ctx) error {
+	var req dto.VerifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	userEmail, err := services.NewUserEmailService().VerifyEmail(c.UserContext(), req.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEmailVerification) {
+			return helpers.UnauthorizedResponse(c, "Invalid or expired verification token", apperrors.ErrAuthInvalidVerificationToken)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to verify email")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toUserEmailResponse(userEmail))
+}
+
+// RemoveEmail removes one of the authenticated user's email addresses other
+// than the primary one
+// @openapi tag Profile
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 404
+func RemoveEmail(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	emailID := c.Params("id")
+	if _, err := uuid.Parse(emailID); err != nil {
+		return helpers.NotFoundResponse(c, "Email not found", apperrors.ErrEmailNotFound)
+	}
+
+	if err := services.NewUserEmailService().RemoveEmail(c.UserContext(), userID, emailID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserEmailNotFound):
+			return helpers.NotFoundResponse(c, "Email not found", apperrors.ErrEmailNotFound)
+		case errors.Is(err, services.ErrPrimaryEmailRemoval):
+			return helpers.ValidationErrorResponse(c, "The primary email cannot be removed")
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to remove email")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Email removed successfully",
+	})
+}
+
+// SetPrimaryEmail makes one of the authenticated user's verified email
+// addresses primary. The user logs in with it from then on.
+// @openapi tag Profile
+// @openapi response 200 dto.UserEmailResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
+func SetPrimaryEmail(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	emailID := c.Params("id")
+	if _, err := uuid.Parse(emailID); err != nil {
+		return helpers.NotFoundResponse(c, "Email not found", apperrors.ErrEmailNotFound)
+	}
+
+	userEmail, err := services.NewUserEmailService().SetPrimaryEmail(c.UserContext(), userID, emailID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrUserEmailNotFound):
+			return helpers.NotFoundResponse(c, "Email not found", apperrors.ErrEmailNotFound)
+		case errors.Is(err, services.ErrUserEmailNotVerified):
+			return helpers.ValidationErrorResponse(c, "Email must be verified before it can become primary")
+		case errors.Is(err, services.ErrUserEmailTaken):
+			return helpers.ConflictResponse(c, "Email already in use", apperrors.ErrEmailTaken)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to set primary email")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toUserEmailResponse(userEmail))
+}
+
+func toUserEmailResponse(userEmail *models.UserEmail) dto.UserEmailResponse {
+	return dto.UserEmailResponse{
+		ID:       userEmail.ID,
+		Address:  userEmail.Email,
+		Primary:  userEmail.IsPrimary,
+		Verified: userEmail.IsVerified(),
+	}
+}
+
+func toUserEmailResponses(emails []models.UserEmail) []dto.UserEmailResponse {
+	responses := make([]dto.UserEmailResponse, 0, len(emails))
+	for i := range emails {
+		responses = append(responses, toUserEmailResponse(&emails[i]))
+	}
+	return responses
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// eraseConfirmation must be sent as the confirm field to erase an account
+const eraseConfirmation = "DELETE MY ACCOUNT"
+
+// EraseMyAccount permanently deletes the authenticated user and their personal data
+// @openapi tag Privacy
+// @openapi request dto.EraseAccountRequest
+// @openapi response 204
+// @openapi response 400
+func EraseMyAccount(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	return eraseUser(c, userID)
+}
+
+// PurgeUser permanently deletes a user and their personal data (admin only)
+// @openapi tag Privacy
+// @openapi request dto.EraseAccountRequest
+// @openapi response 204
+// @openapi response 400
+// @openapi response 404
+func PurgeUser(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if userID == "" {
+		return helpers.ValidationErrorResponse(c, "User ID is required")
+	}
+
+	// Admins erase their own account through the self-service endpoint
+	if userID == middleware.GetUserID(c) {
+		return helpers.ValidationErrorResponse(c, "Cannot purge yourself")
+	}
+
+	return eraseUser(c, userID)
+}
+
+func eraseUser(c *fiber.Ctx, userID string) error {
+	var req dto.EraseAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	if req.Confirm != eraseConfirmation {
+		return helpers.ValidationErrorResponse(c, `Confirm must be "`+eraseConfirmation+`"`)
+	}
+
+	gdprService := services.NewGDPRService()
+	if err := gdprService.EraseUser(userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to erase user")
+	}
+
+	// A user erasing themselves no longer exists to be recorded as the actor
+	if userID == middleware.GetUserID(c) {
+		c.Locals("userID", "")
+	}
+	recordAudit(c, services.AuditActionUserPurge, services.AuditResourceUser, userID, nil)
+	dispatchWebhook(services.WebhookEventUserDeleted, fiber.Map{"id": userID})
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maskResponse masks data with the field masking rules of the authenticated
+// user's roles. Fields the user has the <model>.read.<field> permission for
+// are not masked.
+func maskResponse(c *fiber.Ctx, data interface{}) (interface{}, error) {
+	rules, err := maskingRules(c)
+	if err != nil || len(rules) == 0 {
+		return data, err
+	}
+	return helpers.MaskResponse(data, middleware.GetUserRoles(c), rules), nil
+}
+
+// maskingRules returns the field masking rules that apply to the
+// authenticated user: those of their roles, less the ones for fields they
+// have the <model>.read.<field> permission for
+func maskingRules(c *fiber.Ctx) ([]models.FieldMaskingRule, error) {
+	rules, err := services.NewFieldMaskingService().RulesForRoles(middleware.GetUserRoles(c))
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Permission())
+	}
+	granted, err := services.NewRBACService().HasPermissions(c.UserContext(), middleware.GetUserID(c), names)
+	if err != nil {
+		return nil, err
+	}
+
+	applicable := rules[:0]
+	for _, rule := range rules {
+		if !granted[rule.Permission()] {
+			applicable = append(applicable, rule)
+		}
+	}
+	return applicable, nil
+}
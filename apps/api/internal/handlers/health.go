@@ -1,25 +1,156 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"runtime"
 	"time"
 
+	"api/internal/database"
+	"api/internal/dto"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/migration"
+	"api/internal/services"
 	"github.com/gofiber/fiber/v2"
 )
 
 var startTime = time.Now()
 
-func HealthCheck() fiber.Handler {
+// databasePingTimeout bounds how long the health check waits for the database
+const databasePingTimeout = 2 * time.Second
+
+// migrationState is the applied schema version and the newest migration file
+type migrationState struct {
+	version uint
+	dirty   bool
+	latest  uint
+}
+
+// migrationStatus reads the schema version with a short-lived migration
+// manager; tests replace it to simulate a schema
+var migrationStatus = func() (migrationState, error) {
+	manager := migration.NewManagerFromEnv()
+	if err := manager.Initialize(); err != nil {
+		return migrationState{}, err
+	}
+	defer manager.Close()
+
+	version, dirty, err := manager.Version()
+	if err != nil {
+		return migrationState{}, err
+	}
+	latest, err := manager.LatestVersion()
+	if err != nil {
+		return migrationState{}, err
+	}
+	return migrationState{version: version, dirty: dirty, latest: latest}, nil
+}
+
+// HealthCheck reports service status, database reachability and the schema
+// version. It responds with 503 when the database cannot be pinged so load
+// balancers stop routing traffic to the instance. A schema that is behind or
+// ahead of the migration files still gets 200, with db_migration_pending and
+// migration_warning set for monitoring to alert on.
+// @openapi tag System
+// @openapi response 200 status:string service:string version:string database:string database_latency_ms:number uptime:string timestamp:string memory_mb:integer db_migration_version:integer db_migration_dirty:boolean db_migration_pending:boolean migration_warning:string
+// @openapi response 503 status:string service:string version:string database:string database_latency_ms:number uptime:string timestamp:string memory_mb:integer
+func HealthCheck(service, version string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		var m runtime.MemStats
 		runtime.ReadMemStats(&m)
 
-		return c.JSON(fiber.Map{
-			"status":    "ok",
-			"uptime":    time.Since(startTime).Round(time.Second).String(),
-			"timestamp": time.Now().Format(time.RFC3339),
-			"memory_mb": m.Alloc / 1024 / 1024,
-		})
+		start := time.Now()
+		dbErr := pingDatabase(c.UserContext())
+		latency := time.Since(start)
+
+		status := "ok"
+		databaseStatus := "healthy"
+		httpStatus := fiber.StatusOK
+		if dbErr != nil {
+			status = "unavailable"
+			databaseStatus = "unhealthy"
+			httpStatus = fiber.StatusServiceUnavailable
+		}
+
+		body := fiber.Map{
+			"status":              status,
+			"service":             service,
+			"version":             version,
+			"database":            databaseStatus,
+			"database_latency_ms": float64(latency.Microseconds()) / 1000,
+			"uptime":              time.Since(startTime).Round(time.Second).String(),
+			"timestamp":           time.Now().Format(time.RFC3339),
+			"memory_mb":           m.Alloc / 1024 / 1024,
+		}
+
+		if dbErr == nil {
+			state, err := migrationStatus()
+			if err != nil {
+				logger.Warn("Failed to read migration version for health check", "error", err)
+			} else {
+				pending := state.version != state.latest
+				body["db_migration_version"] = state.version
+				body["db_migration_dirty"] = state.dirty
+				body["db_migration_pending"] = pending
+				if pending {
+					body["migration_warning"] = fmt.Sprintf("database schema is at version %d but the latest migration is %d", state.version, state.latest)
+				}
+			}
+		}
+
+		return c.Status(httpStatus).JSON(body)
+	}
+}
+
+func pingDatabase(ctx context.Context) error {
+	if database.DB == nil {
+		return errors.New("database not connected")
+	}
+
+	sqlDB, err := database.DB.DB()
+	if err != nil {
+		return err
 	}
+
+	ctx, cancel := context.WithTimeout(ctx, databasePingTimeout)
+	defer cancel()
+
+	return sqlDB.PingContext(ctx)
+}
+
+// dependencyChecker checks the external dependencies of the API
+type dependencyChecker interface {
+	Check(ctx context.Context) map[string]services.DependencyHealth
 }
 
+// newDependencyChecker is replaced in tests
+var newDependencyChecker = func() dependencyChecker { return services.NewHealthCheckService() }
+
+// GetDependencyHealth reports the status and latency of the database, Redis
+// and SMTP separately (admin only). It responds with 200 when every
+// configured dependency is up and 207 when any is down; dependencies that are
+// not configured are reported as unconfigured.
+// @openapi tag System
+// @openapi response 200 database:object redis:object smtp:object
+// @openapi response 207 database:object redis:object smtp:object
+func GetDependencyHealth(c *fiber.Ctx) error {
+	results := newDependencyChecker().Check(c.UserContext())
+
+	body := make(map[string]dto.DependencyHealthResponse, len(results))
+	for name, health := range results {
+		response := dto.DependencyHealthResponse{Status: health.Status, Error: health.Error}
+		if health.Status != services.DependencyStatusUnconfigured {
+			latency := health.LatencyMs
+			response.LatencyMs = &latency
+		}
+		body[name] = response
+	}
+
+	status := fiber.StatusOK
+	if !services.DependenciesHealthy(results) {
+		status = fiber.StatusMultiStatus
+	}
+	return helpers.SuccessResponse(c, status, body)
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"api/internal/database"
+	"api/internal/services"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestHealthCheckWithoutDatabase(t *testing.T) {
+	previous := database.DB
+	database.DB = nil
+	t.Cleanup(func() { database.DB = previous })
+	stubMigrationStatus(t, migrationState{version: 42, latest: 42})
+
+	app := fiber.New()
+	app.Get("/health", HealthCheck("Test Service", "1.2.3"))
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/health", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusServiceUnavailable)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+
+	expected := map[string]interface{}{
+		"status":   "unavailable",
+		"database": "unhealthy",
+		"service":  "Test Service",
+		"version":  "1.2.3",
+	}
+	for key, want := range expected {
+		if body[key] != want {
+			t.Errorf("%s = %v, want %v", key, body[key], want)
+		}
+	}
+	if _, ok := body["database_latency_ms"]; !ok {
+		t.Error("database_latency_ms missing from response")
+	}
+	if _, ok := body["db_migration_version"]; ok {
+		t.Error("db_migration_version reported without a database")
+	}
+}
+
+// stubMigrationStatus makes the health check report state as the schema
+func stubMigrationStatus(t *testing.T, state migrationState) {
+	t.Helper()
+	previous := migrationStatus
+	migrationStatus = func() (migrationState, error) { return state, nil }
+	t.Cleanup(func() { migrationStatus = previous })
+}
+
+func TestHealthCheckMigrationVersion(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	previous := database.DB
+	database.DB = db
+	t.Cleanup(func() { database.DB = previous })
+
+	tests := []struct {
+		name        string
+		state       migrationState
+		wantPending bool
+	}{
+		{"current schema", migrationState{version: 42, latest: 42}, false},
+		{"pending migrations", migrationState{version: 40, latest: 42}, true},
+		{"dirty schema", migrationState{version: 42, dirty: true, latest: 42}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stubMigrationStatus(t, tt.state)
+
+			app := fiber.New()
+			app.Get("/health", HealthCheck("Test Service", "1.2.3"))
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/health", nil))
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != fiber.StatusOK {
+				t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+
+			if body["db_migration_version"] != float64(tt.state.version) {
+				t.Errorf("db_migration_version = %v, want %d", body["db_migration_version"], tt.state.version)
+			}
+			if body["db_migration_dirty"] != tt.state.dirty {
+				t.Errorf("db_migration_dirty = %v, want %v", body["db_migration_dirty"], tt.state.dirty)
+			}
+			if body["db_migration_pending"] != tt.wantPending {
+				t.Errorf("db_migration_pending = %v, want %v", body["db_migration_pending"], tt.wantPending)
+			}
+			if _, ok := body["migration_warning"]; ok != tt.wantPending {
+				t.Errorf("migration_warning present = %v, want %v", ok, tt.wantPending)
+			}
+		})
+	}
+}
+
+type fakeDependencyChecker map[string]services.DependencyHealth
+
+func (f fakeDependencyChecker) Check(ctx context.Context) map[string]services.DependencyHealth {
+	return f
+}
+
+func TestGetDependencyHealth(t *testing.T) {
+	tests := []struct {
+		name       string
+		results    fakeDependencyChecker
+		wantStatus int
+	}{
+		{
+			name: "all configured dependencies up",
+			results: fakeDependencyChecker{
+				"database": {Status: services.DependencyStatusOK, LatencyMs: 2},
+				"smtp":     {Status: services.DependencyStatusOK, LatencyMs: 45},
+				"redis":    {Status: services.DependencyStatusUnconfigured},
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name: "a dependency down",
+			results: fakeDependencyChecker{
+				"database": {Status: services.DependencyStatusOK, LatencyMs: 2},
+				"smtp":     {Status: services.DependencyStatusDown, LatencyMs: 2000, Error: "i/o timeout"},
+				"redis":    {Status: services.DependencyStatusUnconfigured},
+			},
+			wantStatus: fiber.StatusMultiStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := newDependencyChecker
+			newDependencyChecker = func() dependencyChecker { return tt.results }
+			t.Cleanup(func() { newDependencyChecker = original })
+
+			app := fiber.New()
+			app.Get("/health/dependencies", GetDependencyHealth)
+			resp, err := app.Test(httptest.NewRequest("GET", "/health/dependencies", nil))
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			var body map[string]map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			for name, health := range tt.results {
+				got := body[name]
+				if got["status"] != health.Status {
+					t.Errorf("%s status = %v, want %s", name, got["status"], health.Status)
+				}
+				latency, hasLatency := got["latency_ms"]
+				if health.Status == services.DependencyStatusUnconfigured {
+					if hasLatency {
+						t.Errorf("%s latency_ms = %v, want it omitted", name, latency)
+					}
+				} else if latency != float64(health.LatencyMs) {
+					t.Errorf("%s latency_ms = %v, want %d", name, latency, health.LatencyMs)
+				}
+			}
+			if tt.wantStatus == fiber.StatusMultiStatus && body["smtp"]["error"] != "i/o timeout" {
+				t.Errorf("smtp error = %v, want i/o timeout", body["smtp"]["error"])
+			}
+		})
+	}
+}
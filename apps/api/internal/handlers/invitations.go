@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"api/internal/auth"
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateInvitation invites a new user by email with the given roles (admin only)
+// @openapi tag Invitations
+// @openapi request dto.CreateInvitationRequest
+// @openapi response 201 invitation:dto.InvitationResponse
+// @openapi response 400
+// @openapi response 409
+func CreateInvitation(c *fiber.Ctx) error {
+	var req dto.CreateInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	invitationService := services.NewInvitationService()
+	currentUserID := middleware.GetUserID(c)
+
+	invitation, err := invitationService.CreateInvitation(req.Email, req.Roles, &currentUserID, newEmailService().SendInvitation)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvitationEmailTaken):
+			return helpers.ConflictResponse(c, "Email already exists", apperrors.ErrEmailTaken)
+		case errors.Is(err, services.ErrInvitationRoleNotFound):
+			return helpers.ValidationErrorResponse(c, err.Error())
+		case errors.Is(err, services.ErrInvitationNotSent):
+			logger.Error("Failed to send invitation email", "to", req.Email, "error", err)
+			return helpers.InternalServerErrorResponse(c, "Failed to send invitation email")
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to create invitation")
+	}
+
+	recordAudit(c, services.AuditActionInvitationCreate, services.AuditResourceInvitation, invitation.ID, fiber.Map{
+		"email": invitation.Email,
+		"roles": invitation.Roles,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, fiber.Map{
+		"invitation": toInvitationResponse(invitation),
+	})
+}
+
+// ListInvitations returns invitations that are still pending (admin only)
+// @openapi tag Invitations
+// @openapi response 200 invitations:[]dto.InvitationResponse total:integer
+func ListInvitations(c *fiber.Ctx) error {
+	invitationService := services.NewInvitationService()
+
+	invitations, err := invitationService.ListPendingInvitations()
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch invitations")
+	}
+
+	invitationResponses := make([]dto.InvitationResponse, 0, len(invitations))
+	for i := range invitations {
+		invitationResponses = append(invitationResponses, toInvitationResponse(&invitations[i]))
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"invitations": invitationResponses,
+		"total":       len(invitationResponses),
+	})
+}
+
+// AcceptInvitation creates the invited user's account and logs them in
+// @openapi tag Auth
+// @openapi request dto.AcceptInvitationRequest
+// @openapi response 201 dto.AuthResponse
+// @openapi response 400
+// @openapi response 401
+// @openapi response 409
+func AcceptInvitation(c *fiber.Ctx) error {
+	var req dto.AcceptInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	if err := auth.ValidatePassword(req.Password); err != nil {
+		return helpers.ValidationErrorResponse(c, err.Error())
+	}
+
+	invitationService := services.NewInvitationService()
+	user, err := invitationService.AcceptInvitation(req.Token, req.Name, req.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidInvitation):
+			return helpers.UnauthorizedResponse(c, "Invalid or expired invitation", apperrors.ErrAuthInvalidInvitation)
+		case errors.Is(err, services.ErrInvitationEmailTaken):
+			return helpers.ConflictResponse(c, "Email already exists", apperrors.ErrEmailTaken)
+		}
+		logger.Error("Failed to accept invitation", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to accept invitation")
+	}
+
+	token, err := auth.GenerateToken(user.ID, user.Email, nil, requestDevice(c))
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to generate token")
+	}
+
+	userWithRoles, err := services.NewRBACService().Primary().GetUserWithRoles(c.UserContext(), user.ID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user roles")
+	}
+
+	userResponse := dto.UserResponse{
+		ID:    user.ID,
+		Email: user.Email,
+		Name:  user.Name,
+		Roles: userWithRoles.GetRoleNames(),
+	}
+	dispatchWebhook(services.WebhookEventUserCreated, userResponse)
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, dto.AuthResponse{
+		Token: token,
+		User:  userResponse,
+	})
+}
+
+func toInvitationResponse(invitation *models.UserInvitation) dto.InvitationResponse {
+	return dto.InvitationResponse{
+		ID:        invitation.ID,
+		Email:     invitation.Email,
+		Roles:     invitation.Roles,
+		InvitedBy: invitation.InvitedBy,
+		ExpiresAt: invitation.ExpiresAt,
+		CreatedAt: invitation.CreatedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"api/internal/helpers"
+	"api/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetIPAllowlist returns the CIDR ranges admin routes are restricted to (admin
+// only). An empty allowlist means admin routes accept requests from any IP.
+// @openapi tag System
+// @openapi response 200 allowlist:[]string enabled:boolean
+func GetIPAllowlist(allowedCIDRs []string) fiber.Handler {
+	prefixes, err := middleware.ParseIPAllowlist(allowedCIDRs)
+	allowlist := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		allowlist = append(allowlist, prefix.String())
+	}
+
+	return func(c *fiber.Ctx) error {
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to load IP allowlist")
+		}
+
+		return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+			"allowlist": allowlist,
+			"enabled":   len(allowlist) > 0,
+		})
+	}
+}
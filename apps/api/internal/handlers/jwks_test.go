@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"api/internal/auth"
+	"api/internal/dto"
+	"api/internal/session"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func getJWKS(t *testing.T) dto.JWKSResponse {
+	t.Helper()
+
+	app := fiber.New()
+	app.Get("/jwks.json", GetJWKS)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/jwks.json", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	var set dto.JWKSResponse
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		t.Fatalf("failed to decode body: %v", err)
+	}
+	return set
+}
+
+func TestGetJWKSRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	privatePath := filepath.Join(t.TempDir(), "private.pem")
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(privatePath, privatePEM, 0o600); err != nil {
+		t.Fatalf("failed to write private key: %v", err)
+	}
+	t.Setenv("JWT_ALGORITHM", "RS256")
+	t.Setenv("JWT_PRIVATE_KEY_PATH", privatePath)
+	t.Setenv("JWT_PUBLIC_KEY_PATH", "")
+
+	set := getJWKS(t)
+	if len(set.Keys) != 1 {
+		t.Fatalf("keys = %d, want 1", len(set.Keys))
+	}
+	jwk := set.Keys[0]
+	if jwk.Kty != "RSA" || jwk.Alg != "RS256" || jwk.Use != "sig" || jwk.Kid == "" {
+		t.Errorf("jwk = %+v", jwk)
+	}
+
+	// A third party must be able to verify tokens with the published key
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		t.Fatalf("invalid modulus: %v", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		t.Fatalf("invalid exponent: %v", err)
+	}
+	publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+
+	tokenString, err := auth.GenerateToken("user-1", "user@example.com", nil, session.Device{})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	token, err := jwt.ParseWithClaims(tokenString, &auth.Claims{}, func(token *jwt.Token) (interface{}, error) {
+		if token.Header["kid"] != jwk.Kid {
+			t.Errorf("token kid = %v, want %s", token.Header["kid"], jwk.Kid)
+		}
+		return publicKey, nil
+	}, jwt.WithValidMethods([]string{"RS256"}))
+	if err != nil || !token.Valid {
+		t.Errorf("token did not verify with the published key: %v", err)
+	}
+}
+
+func TestGetJWKSHS256(t *testing.T) {
+	t.Setenv("JWT_ALGORITHM", "HS256")
+
+	if set := getJWKS(t); set.Keys == nil || len(set.Keys) != 0 {
+		t.Errorf("keys = %v, want an empty set", set.Keys)
+	}
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"api/internal/database"
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// recordLoginAttempt stores a login event; failures are logged so they never
+// block the login itself
+func recordLoginAttempt(c *fiber.Ctx, loginHistoryService *services.LoginHistoryService, userID string, success bool) {
+	if err := loginHistoryService.RecordLogin(userID, c.IP(), c.Get(fiber.HeaderUserAgent), success); err != nil {
+		logger.Error("Failed to record login event", "user_id", userID, "success", success, "error", err)
+	}
+}
+
+// GetMyLoginHistory returns the authenticated user's recent login attempts
+// @openapi tag Profile
+// @openapi param limit integer Maximum number of attempts to return
+// @openapi response 200 events:[]dto.LoginEventResponse total:integer
+func GetMyLoginHistory(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	return sendLoginHistory(c, userID)
+}
+
+// GetUserLoginHistory returns a user's recent login attempts (admin only)
+// @openapi tag Users
+// @openapi param limit integer Maximum number of attempts to return
+// @openapi response 200 events:[]dto.LoginEventResponse total:integer
+// @openapi response 404
+func GetUserLoginHistory(c *fiber.Ctx) error {
+	userID := c.Params("id")
+
+	if err := database.DB.WithContext(c.UserContext()).Select("id").Where("id = ?", userID).First(&models.User{}).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	return sendLoginHistory(c, userID)
+}
+
+func sendLoginHistory(c *fiber.Ctx, userID string) error {
+	limit := c.QueryInt("limit", services.DefaultLoginHistoryLimit)
+	if limit <= 0 {
+		limit = services.DefaultLoginHistoryLimit
+	}
+	if limit > services.MaxLoginHistoryLimit {
+		limit = services.MaxLoginHistoryLimit
+	}
+
+	events, err := services.NewLoginHistoryService().GetLoginHistory(userID, limit)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch login history")
+	}
+
+	eventResponses := make([]dto.LoginEventResponse, 0, len(events))
+	for _, event := range events {
+		eventResponses = append(eventResponses, toLoginEventResponse(event))
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"events": eventResponses,
+		"total":  len(eventResponses),
+	})
+}
+
+func toLoginEventResponse(event models.LoginEvent) dto.LoginEventResponse {
+	return dto.LoginEventResponse{
+		ID:        event.ID,
+		IPAddress: event.IPAddress,
+		UserAgent: event.UserAgent,
+		Success:   event.Success,
+		CreatedAt: event.CreatedAt,
+	}
+}
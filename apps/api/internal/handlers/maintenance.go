@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// UpdateMaintenanceMode turns maintenance mode on or off without a restart (admin only).
+// While it is on, requests without the bypass token get 503, including this
+// endpoint.
+// @openapi tag System
+// @openapi request dto.MaintenanceModeRequest
+// @openapi response 200 dto.MaintenanceModeResponse
+// @openapi response 400
+func UpdateMaintenanceMode(c *fiber.Ctx) error {
+	var req dto.MaintenanceModeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	previous := middleware.MaintenanceModeEnabled()
+	middleware.SetMaintenanceMode(*req.Enabled)
+
+	if previous != *req.Enabled {
+		recordAudit(c, services.AuditActionMaintenanceUpdate, services.AuditResourceSystem, "maintenance_mode", fiber.Map{
+			"enabled": services.AuditChange{From: previous, To: *req.Enabled},
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MaintenanceModeResponse{
+		Enabled: *req.Enabled,
+	})
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"api/internal/middleware"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics serves the collected metrics in the Prometheus text format
+func Metrics() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(middleware.MetricsRegistry, promhttp.HandlerOpts{}))
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"api/internal/openapi"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// swaggerUIPage loads Swagger UI from a CDN and points it at the spec URL
+// substituted for %q
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Studio45 API Documentation</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: %q, dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// GetOpenAPISpec serves the generated OpenAPI specification
+// @openapi tag System
+// @openapi response 200 object
+func GetOpenAPISpec(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(openapi.Spec)
+}
+
+// GetAPIDocs serves Swagger UI for the OpenAPI specification
+// @openapi tag System
+// @openapi response 200
+func GetAPIDocs(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.SendString(fmt.Sprintf(swaggerUIPage, "openapi.json"))
+}
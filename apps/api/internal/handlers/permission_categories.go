@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// ListPermissionCategories returns all permission categories (admin only)
+// @openapi tag Permissions
+// @openapi response 200 categories:[]dto.PermissionCategoryResponse total:integer
+func ListPermissionCategories(c *fiber.Ctx) error {
+	categories, err := services.NewPermissionCategoryService().ListCategories()
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission categories")
+	}
+
+	responses := make([]dto.PermissionCategoryResponse, len(categories))
+	for i := range categories {
+		responses[i] = toPermissionCategoryResponse(&categories[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"categories": responses,
+		"total":      len(responses),
+	})
+}
+
+// GetPermissionCategory returns a permission category by ID (admin only)
+// @openapi tag Permissions
+// @openapi response 200 dto.PermissionCategoryResponse
+// @openapi response 404
+func GetPermissionCategory(c *fiber.Ctx) error {
+	categoryID := c.Params("id")
+	if _, err := uuid.Parse(categoryID); err != nil {
+		return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+	}
+
+	category, err := services.NewPermissionCategoryService().GetCategory(categoryID)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+			return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission category")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toPermissionCategoryResponse(category))
+}
+
+// CreatePermissionCategory creates a permission category (admin only)
+// @openapi tag Permissions
+// @openapi request dto.CreatePermissionCategoryRequest
+// @openapi response 201 dto.PermissionCategoryResponse
+// @openapi response 400
+// @openapi response 409
+func CreatePermissionCategory(c *fiber.Ctx) error {
+	var req dto.CreatePermissionCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	category := models.PermissionCategory{
+		Name:        helpers.TrimString(req.Name),
+		Description: req.Description,
+	}
+
+	if err := services.NewPermissionCategoryService().CreateCategory(&category); err != nil {
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Permission category name already exists", apperrors.ErrPermissionCategoryExists)
+		}
+		logger.Error("Failed to create permission category", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to create permission category")
+	}
+
+	recordAudit(c, services.AuditActionPermissionCategoryCreate, services.AuditResourcePermissionCategory, category.ID, permissionCategoryAuditFields(&category))
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, toPermissionCategoryResponse(&category))
+}
+
+// UpdatePermissionCategory updates a permission category's name or
+// description (admin only)
+// @openapi tag Permissions
+// @openapi request dto.UpdatePermissionCategoryRequest
+// @openapi response 200 dto.PermissionCategoryResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
+func UpdatePermissionCategory(c *fiber.Ctx) error {
+	categoryID := c.Params("id")
+	if _, err := uuid.Parse(categoryID); err != nil {
+		return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+	}
+
+	var req dto.UpdatePermissionCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	categoryService := services.NewPermissionCategoryService()
+
+	existingCategory, err := categoryService.GetCategory(categoryID)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+			return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission category")
+	}
+
+	// Build updates map for selective updates
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		updates["name"] = helpers.TrimString(*req.Name)
+	}
+
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	if len(updates) == 0 {
+		return helpers.ValidationErrorResponse(c, "No fields to update")
+	}
+
+	if err := categoryService.UpdateCategory(categoryID, updates); err != nil {
+		if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+			return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+		}
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Permission category name already exists", apperrors.ErrPermissionCategoryExists)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update permission category")
+	}
+
+	recordAudit(c, services.AuditActionPermissionCategoryUpdate, services.AuditResourcePermissionCategory, categoryID, services.AuditDiff(permissionCategoryAuditFields(existingCategory), updates))
+
+	updatedCategory, err := categoryService.GetCategory(categoryID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated permission category")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toPermissionCategoryResponse(updatedCategory))
+}
+
+// DeletePermissionCategory removes a permission category; its permissions are
+// kept without a category (admin only)
+// @openapi tag Permissions
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func DeletePermissionCategory(c *fiber.Ctx) error {
+	categoryID := c.Params("id")
+	if _, err := uuid.Parse(categoryID); err != nil {
+		return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+	}
+
+	categoryService := services.NewPermissionCategoryService()
+
+	existingCategory, err := categoryService.GetCategory(categoryID)
+	if err != nil {
+		if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+			return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission category")
+	}
+
+	if err := categoryService.DeleteCategory(categoryID); err != nil {
+		if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+			return helpers.NotFoundResponse(c, "Permission category not found", apperrors.ErrPermissionCategoryNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to delete permission category")
+	}
+
+	recordAudit(c, services.AuditActionPermissionCategoryDelete, services.AuditResourcePermissionCategory, categoryID, permissionCategoryAuditFields(existingCategory))
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Permission category deleted successfully",
+	})
+}
+
+// permissionCategoryAuditFields snapshots the editable category fields for
+// audit diffs
+func permissionCategoryAuditFields(category *models.PermissionCategory) map[string]interface{} {
+	return map[string]interface{}{
+		"name":        category.Name,
+		"description": derefString(category.Description),
+	}
+}
+
+func toPermissionCategoryResponse(category *models.PermissionCategory) dto.PermissionCategoryResponse {
+	return dto.PermissionCategoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
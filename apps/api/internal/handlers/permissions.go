@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"api/internal/dto"
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/models"
 	"api/internal/services"
 	"errors"
 
@@ -11,6 +14,9 @@ import (
 )
 
 // GetPermission returns a single permission by ID (admin only)
+// @openapi tag Permissions
+// @openapi response 200 dto.PermissionResponse
+// @openapi response 404
 func GetPermission(c *fiber.Ctx) error {
 	permissionID := c.Params("id")
 	if permissionID == "" {
@@ -19,62 +25,72 @@ func GetPermission(c *fiber.Ctx) error {
 
 	rbacService := services.NewRBACService()
 	
-	permission, err := rbacService.GetPermissionByID(permissionID)
+	permission, err := rbacService.GetPermissionByID(c.UserContext(), permissionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Permission not found")
+			return helpers.NotFoundResponse(c, "Permission not found", apperrors.ErrPermissionNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission")
 	}
 
-	response := dto.PermissionResponse{
-		ID:          permission.ID,
-		Name:        permission.Name,
-		Resource:    permission.Resource,
-		Action:      permission.Action,
-		Description: permission.Description,
-		CreatedAt:   permission.CreatedAt,
-		UpdatedAt:   permission.UpdatedAt,
-	}
+	response := toPermissionResponse(permission)
 
 	return helpers.SuccessResponse(c, fiber.StatusOK, response)
 }
 
 // CreatePermission creates a new permission (admin only)
+// @openapi tag Permissions
+// @openapi request dto.CreatePermissionRequest
+// @openapi response 201 dto.PermissionResponse
+// @openapi response 400
+// @openapi response 409
 func CreatePermission(c *fiber.Ctx) error {
 	var req dto.CreatePermissionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
-	rbacService := services.NewRBACService()
-	
-	permission, err := rbacService.CreatePermission(req.Name, req.Resource, req.Action, req.Description)
+	if req.CategoryID != nil {
+		if _, err := services.NewPermissionCategoryService().GetCategory(*req.CategoryID); err != nil {
+			if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+				return helpers.ValidationErrorResponse(c, "Permission category not found")
+			}
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch permission category")
+		}
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	createdBy := middleware.GetUserID(c)
+	permission, err := rbacService.CreatePermission(c.UserContext(), req.Name, req.Resource, req.Action, req.Description, req.CategoryID, req.Metadata, &createdBy)
 	if err != nil {
+		if message, ok := helpers.ModelValidationError(err); ok {
+			return helpers.ValidationErrorResponse(c, message)
+		}
 		if helpers.IsDuplicateError(err) {
-			return helpers.ConflictResponse(c, "Permission name already exists")
+			return helpers.ConflictResponse(c, "Permission name already exists", apperrors.ErrPermissionExists)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to create permission")
 	}
 
-	response := dto.PermissionResponse{
-		ID:          permission.ID,
-		Name:        permission.Name,
-		Resource:    permission.Resource,
-		Action:      permission.Action,
-		Description: permission.Description,
-		CreatedAt:   permission.CreatedAt,
-		UpdatedAt:   permission.UpdatedAt,
-	}
+	recordAudit(c, services.AuditActionPermissionCreate, services.AuditResourcePermission, permission.ID, permissionAuditFields(permission))
+
+	response := toPermissionResponse(permission)
 
 	return helpers.SuccessResponse(c, fiber.StatusCreated, response)
 }
 
 // UpdatePermission updates an existing permission (admin only)
+// @openapi tag Permissions
+// @openapi request dto.UpdatePermissionRequest
+// @openapi response 200 dto.PermissionResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
 func UpdatePermission(c *fiber.Ctx) error {
 	permissionID := c.Params("id")
 	if permissionID == "" {
@@ -83,7 +99,7 @@ func UpdatePermission(c *fiber.Ctx) error {
 
 	var req dto.UpdatePermissionRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -109,61 +125,219 @@ func UpdatePermission(c *fiber.Ctx) error {
 		updates["description"] = *req.Description
 	}
 
+	if req.CategoryID != nil {
+		if *req.CategoryID == "" {
+			updates["category_id"] = nil
+		} else {
+			if _, err := services.NewPermissionCategoryService().GetCategory(*req.CategoryID); err != nil {
+				if errors.Is(err, services.ErrPermissionCategoryNotFound) {
+					return helpers.ValidationErrorResponse(c, "Permission category not found")
+				}
+				return helpers.InternalServerErrorResponse(c, "Failed to fetch permission category")
+			}
+			updates["category_id"] = *req.CategoryID
+		}
+	}
+
+	if req.Metadata != nil {
+		updates["metadata"] = models.PermissionMetadata(req.Metadata)
+	}
+
 	if len(updates) == 0 {
 		return helpers.ValidationErrorResponse(c, "No fields to update")
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
+
+	existingPermission, err := rbacService.GetPermissionByID(c.UserContext(), permissionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Permission not found", apperrors.ErrPermissionNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission")
+	}
 	
-	permission, err := rbacService.UpdatePermission(permissionID, updates)
+	updatedBy := middleware.GetUserID(c)
+	permission, err := rbacService.UpdatePermission(c.UserContext(), permissionID, updates, &updatedBy)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Permission not found")
+			return helpers.NotFoundResponse(c, "Permission not found", apperrors.ErrPermissionNotFound)
+		}
+		if message, ok := helpers.ModelValidationError(err); ok {
+			return helpers.ValidationErrorResponse(c, message)
 		}
 		if helpers.IsDuplicateError(err) {
-			return helpers.ConflictResponse(c, "Permission name already exists")
+			return helpers.ConflictResponse(c, "Permission name already exists", apperrors.ErrPermissionExists)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to update permission")
 	}
 
-	response := dto.PermissionResponse{
-		ID:          permission.ID,
-		Name:        permission.Name,
-		Resource:    permission.Resource,
-		Action:      permission.Action,
-		Description: permission.Description,
-		CreatedAt:   permission.CreatedAt,
-		UpdatedAt:   permission.UpdatedAt,
-	}
+	recordAudit(c, services.AuditActionPermissionUpdate, services.AuditResourcePermission, permissionID, services.AuditDiff(permissionAuditFields(existingPermission), updates))
+
+	response := toPermissionResponse(permission)
 
 	return helpers.SuccessResponse(c, fiber.StatusOK, response)
 }
 
 // DeletePermission deletes a permission (admin only)
+// @openapi tag Permissions
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
 func DeletePermission(c *fiber.Ctx) error {
 	permissionID := c.Params("id")
 	if permissionID == "" {
 		return helpers.ValidationErrorResponse(c, "Permission ID is required")
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
 	
 	// Check if permission exists first
-	_, err := rbacService.GetPermissionByID(permissionID)
+	existingPermission, err := rbacService.GetPermissionByID(c.UserContext(), permissionID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Permission not found")
+			return helpers.NotFoundResponse(c, "Permission not found", apperrors.ErrPermissionNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch permission")
 	}
 
 	// Delete the permission
-	err = rbacService.DeletePermission(permissionID)
+	err = rbacService.DeletePermission(c.UserContext(), permissionID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to delete permission")
 	}
 
+	recordAudit(c, services.AuditActionPermissionDelete, services.AuditResourcePermission, permissionID, permissionAuditFields(existingPermission))
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
 		Message: "Permission deleted successfully",
 	})
-}
\ No newline at end of file
+}
+
+// AssignPermissionToRoles grants a permission to several roles at once (admin only)
+// @openapi tag Permissions
+// @openapi request dto.PermissionRolesRequest
+// @openapi response 200 dto.AssignPermissionToRolesResponse
+// @openapi response 400
+// @openapi response 404
+func AssignPermissionToRoles(c *fiber.Ctx) error {
+	permissionID := c.Params("id")
+	if permissionID == "" {
+		return helpers.ValidationErrorResponse(c, "Permission ID is required")
+	}
+
+	var req dto.PermissionRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	assigned, errs := services.NewRBACService().Primary().AssignPermissionToRoles(c.UserContext(), permissionID, req.RoleIDs)
+	messages, err := rolePermissionErrorMessages(errs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Permission not found", apperrors.ErrPermissionNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to assign permission")
+	}
+
+	recordAudit(c, services.AuditActionPermissionAssign, services.AuditResourcePermission, permissionID, map[string]interface{}{
+		"role_ids": req.RoleIDs,
+		"assigned": assigned,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.AssignPermissionToRolesResponse{
+		Assigned: assigned,
+		Skipped:  len(req.RoleIDs) - assigned - len(messages),
+		Errors:   messages,
+	})
+}
+
+// RemovePermissionFromRoles revokes a permission from several roles at once (admin only)
+// @openapi tag Permissions
+// @openapi request dto.PermissionRolesRequest
+// @openapi response 200 dto.RemovePermissionFromRolesResponse
+// @openapi response 400
+// @openapi response 404
+func RemovePermissionFromRoles(c *fiber.Ctx) error {
+	permissionID := c.Params("id")
+	if permissionID == "" {
+		return helpers.ValidationErrorResponse(c, "Permission ID is required")
+	}
+
+	var req dto.PermissionRolesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	removed, errs := services.NewRBACService().Primary().RemovePermissionFromRoles(c.UserContext(), permissionID, req.RoleIDs)
+	messages, err := rolePermissionErrorMessages(errs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Permission not found", apperrors.ErrPermissionNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to remove permission")
+	}
+
+	recordAudit(c, services.AuditActionPermissionUnassign, services.AuditResourcePermission, permissionID, map[string]interface{}{
+		"role_ids": req.RoleIDs,
+		"removed":  removed,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.RemovePermissionFromRolesResponse{
+		Removed: removed,
+		Skipped: len(req.RoleIDs) - removed - len(messages),
+		Errors:  messages,
+	})
+}
+
+// rolePermissionErrorMessages converts the per-role errors of a bulk permission
+// change to response messages. Any other error failed the whole change and is
+// returned instead.
+func rolePermissionErrorMessages(errs []error) ([]string, error) {
+	messages := []string{}
+	for _, err := range errs {
+		var roleErr *services.RolePermissionError
+		if !errors.As(err, &roleErr) {
+			return nil, err
+		}
+		messages = append(messages, roleErr.Error())
+	}
+	return messages, nil
+}
+
+// toPermissionResponse converts a permission, naming its category and creator
+// when they are loaded
+func toPermissionResponse(permission *models.Permission) dto.PermissionResponse {
+	response := dto.PermissionResponse{
+		ID:          permission.ID,
+		Name:        permission.Name,
+		Resource:    permission.Resource,
+		Action:      permission.Action,
+		Description: permission.Description,
+		CategoryID:  permission.CategoryID,
+		Metadata:    permission.Metadata,
+		CreatedAt:   permission.CreatedAt,
+		UpdatedAt:   permission.UpdatedAt,
+	}
+	if permission.Category != nil {
+		response.CategoryName = &permission.Category.Name
+	}
+	response.CreatedByEmail = creatorEmail(permission.Creator)
+	return response
+}
+
+// creatorEmail returns the email of the user who created a role or
+// permission, when it is known and loaded
+func creatorEmail(creator *models.User) *string {
+	if creator == nil {
+		return nil
+	}
+	return &creator.Email
+}
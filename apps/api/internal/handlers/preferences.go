@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/services"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetPreferences returns all of the authenticated user's preferences
+// @openapi tag Profile
+// @openapi response 200 dto.PreferencesResponse
+func GetPreferences(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	preferences, err := services.NewPreferenceService().ListPreferences(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch preferences")
+	}
+
+	values := make(map[string]json.RawMessage, len(preferences))
+	for _, preference := range preferences {
+		values[preference.Key] = json.RawMessage(preference.Value)
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.PreferencesResponse{
+		Preferences: values,
+	})
+}
+
+// UpdatePreference creates or replaces one of the authenticated user's
+// preferences. The value may be any JSON value except null.
+// @openapi tag Profile
+// @openapi request dto.UpdatePreferenceRequest
+// @openapi response 200 dto.PreferenceResponse
+// @openapi response 400
+func UpdatePreference(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	var req dto.UpdatePreferenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	if bytes.Equal(bytes.TrimSpace(req.Value), []byte("null")) {
+		return helpers.ValidationErrorResponse(c, "Value must not be null")
+	}
+
+	key := c.Params("key")
+	preference, err := services.NewPreferenceService().SetPreference(c.UserContext(), userID, key, req.Value)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrPreferenceKeyNotAllowed):
+			return helpers.ValidationErrorResponse(c, "Preference key not allowed: "+key)
+		case errors.Is(err, services.ErrPreferenceValueTooLarge):
+			return helpers.ValidationErrorResponse(c, "Preference value must be at most "+strconv.Itoa(services.MaxPreferenceValueSize)+" bytes")
+		}
+		logger.Error("Failed to save preference", "user_id", userID, "key", key, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to save preference")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.PreferenceResponse{
+		Key:   preference.Key,
+		Value: json.RawMessage(preference.Value),
+	})
+}
+
+// DeletePreference removes one of the authenticated user's preferences
+// @openapi tag Profile
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 404
+func DeletePreference(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	key := c.Params("key")
+	if err := services.NewPreferenceService().DeletePreference(c.UserContext(), userID, key); err != nil {
+		switch {
+		case errors.Is(err, services.ErrPreferenceKeyNotAllowed):
+			return helpers.ValidationErrorResponse(c, "Preference key not allowed: "+key)
+		case errors.Is(err, services.ErrPreferenceNotFound):
+			return helpers.NotFoundResponse(c, "Preference not found", apperrors.ErrPreferenceNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to delete preference")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Preference deleted successfully",
+	})
+}
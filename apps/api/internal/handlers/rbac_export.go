@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ExportRBAC returns every role, with its permissions embedded, and every
+// permission, for replicating the configuration elsewhere (admin only)
+// @openapi tag Roles
+// @openapi response 200 dto.RBACExport
+func ExportRBAC(c *fiber.Ctx) error {
+	export, err := services.NewRBACExportService().Export()
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to export RBAC configuration")
+	}
+
+	response := dto.RBACExport{
+		Roles:       make([]dto.RBACExportRole, 0, len(export.Roles)),
+		Permissions: toRBACExportPermissionDTOs(export.Permissions),
+	}
+	for _, role := range export.Roles {
+		response.Roles = append(response.Roles, dto.RBACExportRole{
+			Name:                   role.Name,
+			Description:            role.Description,
+			UseWildcardPermissions: role.UseWildcardPermissions,
+			Permissions:            toRBACExportPermissionDTOs(role.Permissions),
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+// ImportRBAC creates or updates the roles and permissions of an export,
+// matched by name, in one transaction (admin only)
+// @openapi tag Roles
+// @openapi request dto.RBACExport
+// @openapi response 200 dto.RBACImportResponse
+// @openapi response 400
+func ImportRBAC(c *fiber.Ctx) error {
+	var req dto.RBACExport
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	export := services.RBACExport{
+		Roles:       make([]services.RBACExportRole, 0, len(req.Roles)),
+		Permissions: fromRBACExportPermissionDTOs(req.Permissions),
+	}
+	for _, role := range req.Roles {
+		export.Roles = append(export.Roles, services.RBACExportRole{
+			Name:                   role.Name,
+			Description:            role.Description,
+			UseWildcardPermissions: role.UseWildcardPermissions,
+			Permissions:            fromRBACExportPermissionDTOs(role.Permissions),
+		})
+	}
+
+	summary, err := services.NewRBACExportService().Import(&export)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRBACImport) {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
+		if message, ok := helpers.ModelValidationError(err); ok {
+			return helpers.ValidationErrorResponse(c, message)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to import RBAC configuration")
+	}
+
+	response := dto.RBACImportResponse{
+		RolesCreated:       summary.RolesCreated,
+		RolesUpdated:       summary.RolesUpdated,
+		PermissionsCreated: summary.PermissionsCreated,
+		PermissionsUpdated: summary.PermissionsUpdated,
+	}
+
+	recordAudit(c, services.AuditActionRBACImport, services.AuditResourceSystem, "rbac", fiber.Map{
+		"roles_created":       response.RolesCreated,
+		"roles_updated":       response.RolesUpdated,
+		"permissions_created": response.PermissionsCreated,
+		"permissions_updated": response.PermissionsUpdated,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, response)
+}
+
+func toRBACExportPermissionDTOs(permissions []services.RBACExportPermission) []dto.RBACExportPermission {
+	result := make([]dto.RBACExportPermission, 0, len(permissions))
+	for _, p := range permissions {
+		metadata := map[string]interface{}(p.Metadata)
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+		}
+		result = append(result, dto.RBACExportPermission{
+			Name:        p.Name,
+			Resource:    p.Resource,
+			Action:      p.Action,
+			Description: p.Description,
+			Category:    p.Category,
+			Metadata:    metadata,
+		})
+	}
+	return result
+}
+
+func fromRBACExportPermissionDTOs(permissions []dto.RBACExportPermission) []services.RBACExportPermission {
+	result := make([]services.RBACExportPermission, 0, len(permissions))
+	for _, p := range permissions {
+		result = append(result, services.RBACExportPermission{
+			Name:        p.Name,
+			Resource:    p.Resource,
+			Action:      p.Action,
+			Description: p.Description,
+			Category:    p.Category,
+			Metadata:    models.PermissionMetadata(p.Metadata),
+		})
+	}
+	return result
+}
@@ -2,30 +2,84 @@ package handlers
 
 import (
 	"api/internal/dto"
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/rbac"
 	"api/internal/services"
 	"errors"
+	"sort"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
-// GetAllRoles returns all available roles (admin only)
+// GetAllRoles returns a page of roles, optionally filtered by a search term
+// and a granted permission (admin only)
+// @openapi tag Roles
+// @openapi query dto.RoleListRequest
+// @openapi response 200 dto.PaginatedRolesResponse
+// @openapi response 400
 func GetAllRoles(c *fiber.Ctx) error {
+	var req dto.RoleListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+
+	// Set default values
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	if req.HasPermission != "" {
+		if _, err := uuid.Parse(req.HasPermission); err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid has_permission")
+		}
+	}
+
 	rbacService := services.NewRBACService()
-	
-	roles, err := rbacService.GetAllRoles()
+
+	roles, total, err := rbacService.GetRolesPaginated(c.UserContext(), req.Page, req.Limit, req.Search, req.HasPermission)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch roles")
 	}
 
-	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
-		"roles": roles,
-		"total": len(roles),
+	roleResponses := make([]dto.RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		roleResponses = append(roleResponses, dto.RoleResponse{
+			ID:                     role.ID,
+			Name:                   role.Name,
+			Description:            role.Description,
+			UseWildcardPermissions: role.UseWildcardPermissions,
+			AccessWindows:          toAccessWindowResponses(role.AccessWindows),
+			DataScope:              role.DataScope,
+			CreatedByEmail:         creatorEmail(role.Creator),
+			CreatedAt:              role.CreatedAt,
+			UpdatedAt:              role.UpdatedAt,
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.PaginatedRolesResponse{
+		Roles:          roleResponses,
+		Total:          total,
+		Page:           req.Page,
+		Limit:          req.Limit,
+		TotalPages:     int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		PaginationType: paginationTypeOffset,
 	})
 }
 
 // GetUserPermissions returns all permissions for a specific user (admin only)
+// @openapi tag Permissions
+// @openapi response 200 permissions:[]object total:integer
+// @openapi response 404
 func GetUserPermissions(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	if userID == "" {
@@ -35,12 +89,12 @@ func GetUserPermissions(c *fiber.Ctx) error {
 	rbacService := services.NewRBACService()
 	
 	// Check if user exists
-	_, err := rbacService.GetUserWithRoles(userID)
+	_, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
 	if err != nil {
-		return helpers.NotFoundResponse(c, "User not found")
+		return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
 	}
 
-	permissions, err := rbacService.GetUserPermissions(userID)
+	permissions, err := rbacService.GetUserPermissions(c.UserContext(), userID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch user permissions")
 	}
@@ -52,6 +106,8 @@ func GetUserPermissions(c *fiber.Ctx) error {
 }
 
 // CheckUserPermission checks if a user has a specific permission (admin only)
+// @openapi tag Permissions
+// @openapi response 200 user_id:string permission:string has_permission:boolean
 func CheckUserPermission(c *fiber.Ctx) error {
 	userID := c.Params("id")
 	permission := c.Params("permission")
@@ -62,7 +118,7 @@ func CheckUserPermission(c *fiber.Ctx) error {
 
 	rbacService := services.NewRBACService()
 	
-	hasPermission, err := rbacService.HasPermission(userID, permission)
+	hasPermission, err := rbacService.HasPermission(c.UserContext(), userID, permission)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to check permission")
 	}
@@ -74,15 +130,118 @@ func CheckUserPermission(c *fiber.Ctx) error {
 	})
 }
 
-// GetAllPermissions returns all available permissions (admin only)
+// GetMyPermissions returns the authenticated user's permissions
+// @openapi tag Profile
+// @openapi response 200 permissions:[]dto.PermissionResponse total:integer
+// @openapi response 401
+func GetMyPermissions(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	rbacService := services.NewRBACService()
+
+	userPermissions, err := rbacService.GetUserPermissions(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch permissions")
+	}
+
+	permissions := []dto.PermissionResponse{}
+	for _, p := range userPermissions {
+		permissions = append(permissions, toPermissionResponse(&p))
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"permissions": permissions,
+		"total":       len(permissions),
+	})
+}
+
+// CheckMyPermission checks whether the authenticated user has a permission,
+// including through wildcard roles
+// @openapi tag Profile
+// @openapi response 200 has_permission:boolean
+// @openapi response 401
+func CheckMyPermission(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	rbacService := services.NewRBACService()
+
+	hasPermission, err := rbacService.HasPermission(c.UserContext(), userID, c.Params("name"))
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to check permission")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"has_permission": hasPermission,
+	})
+}
+
+// CheckMyPermissions checks several permissions for the authenticated user at
+// once, including through wildcard roles
+// @openapi tag Profile
+// @openapi request dto.CheckPermissionsRequest
+// @openapi response 200 dto.CheckPermissionsResponse
+// @openapi response 400
+// @openapi response 401
+func CheckMyPermissions(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	var req dto.CheckPermissionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	rbacService := services.NewRBACService()
+
+	results, err := rbacService.HasPermissions(c.UserContext(), userID, req.Permissions)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to check permissions")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.CheckPermissionsResponse{Results: results})
+}
+
+// GetAllPermissions returns all available permissions, optionally only those
+// in a category (admin only)
+// @openapi tag Permissions
+// @openapi query dto.PermissionListRequest
+// @openapi response 200 permissions:[]dto.PermissionResponse total:integer
+// @openapi response 400
 func GetAllPermissions(c *fiber.Ctx) error {
+	var req dto.PermissionListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid query parameters")
+	}
+	if req.CategoryID != "" {
+		if _, err := uuid.Parse(req.CategoryID); err != nil {
+			return helpers.ValidationErrorResponse(c, "Invalid category_id")
+		}
+	}
+
 	rbacService := services.NewRBACService()
 	
-	permissions, err := rbacService.GetAllPermissions()
+	allPermissions, err := rbacService.GetAllPermissions(c.UserContext(), req.CategoryID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch permissions")
 	}
 
+	permissions := make([]dto.PermissionResponse, 0, len(allPermissions))
+	for _, p := range allPermissions {
+		permissions = append(permissions, toPermissionResponse(&p))
+	}
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
 		"permissions": permissions,
 		"total":       len(permissions),
@@ -90,6 +249,9 @@ func GetAllPermissions(c *fiber.Ctx) error {
 }
 
 // GetRole returns a single role with permissions by ID (admin only)
+// @openapi tag Roles
+// @openapi response 200 dto.RoleResponse
+// @openapi response 404
 func GetRole(c *fiber.Ctx) error {
 	roleID := c.Params("id")
 	if roleID == "" {
@@ -98,10 +260,10 @@ func GetRole(c *fiber.Ctx) error {
 
 	rbacService := services.NewRBACService()
 	
-	role, err := rbacService.GetRoleByIDWithPermissions(roleID)
+	role, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Role not found")
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch role")
 	}
@@ -109,30 +271,104 @@ func GetRole(c *fiber.Ctx) error {
 	// Convert permissions to response format
 	var permissions []dto.PermissionResponse
 	for _, p := range role.Permissions {
-		permissions = append(permissions, dto.PermissionResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Resource:    p.Resource,
-			Action:      p.Action,
-			Description: p.Description,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
-		})
+		permissions = append(permissions, toPermissionResponse(&p))
 	}
 
 	response := dto.RoleResponse{
-		ID:          role.ID,
-		Name:        role.Name,
-		Description: role.Description,
-		Permissions: permissions,
-		CreatedAt:   role.CreatedAt,
-		UpdatedAt:   role.UpdatedAt,
+		ID:                     role.ID,
+		Name:                   role.Name,
+		Description:            role.Description,
+		UseWildcardPermissions: role.UseWildcardPermissions,
+		AccessWindows:          toAccessWindowResponses(role.AccessWindows),
+		DataScope:              role.DataScope,
+		CreatedByEmail:         creatorEmail(role.Creator),
+		Permissions:            permissions,
+		CreatedAt:              role.CreatedAt,
+		UpdatedAt:              role.UpdatedAt,
 	}
 
 	return helpers.SuccessResponse(c, fiber.StatusOK, response)
 }
 
+// GetRoleUsers returns a page of the users holding a role, optionally
+// narrowed by search (admin only)
+// @openapi tag Roles
+// @openapi query dto.RoleUsersRequest
+// @openapi response 200 dto.PaginatedUsersResponse
+// @openapi response 404
+func GetRoleUsers(c *fiber.Ctx) error {
+	roleID := c.Params("id")
+	if _, err := uuid.Parse(roleID); err != nil {
+		return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+	}
+
+	var req dto.RoleUsersRequest
+	if err := c.QueryParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid pagination parameters")
+	}
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
+
+	users, total, err := services.NewRBACService().GetUsersByRole(c.UserContext(), roleID, req.Page, req.Limit, req.Search, scopes)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.PaginatedUsersResponse{
+		Users:          toUserListResponses(users),
+		Total:          total,
+		Page:           req.Page,
+		Limit:          req.Limit,
+		TotalPages:     int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		PaginationType: paginationTypeOffset,
+	})
+}
+
+// CountRoleUsers returns the number of users holding a role (admin only)
+// @openapi tag Roles
+// @openapi response 200 count:integer
+// @openapi response 404
+func CountRoleUsers(c *fiber.Ctx) error {
+	roleID := c.Params("id")
+	if _, err := uuid.Parse(roleID); err != nil {
+		return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+	}
+
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to count users")
+	}
+
+	count, err := services.NewRBACService().CountUsersByRole(c.UserContext(), roleID, scopes)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to count users")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{"count": count})
+}
+
 // GetRolePermissions returns permissions for a specific role (admin only)
+// @openapi tag Roles
+// @openapi response 200 permissions:[]dto.PermissionResponse total:integer
+// @openapi response 404
 func GetRolePermissions(c *fiber.Ctx) error {
 	roleID := c.Params("id")
 	if roleID == "" {
@@ -141,10 +377,10 @@ func GetRolePermissions(c *fiber.Ctx) error {
 
 	rbacService := services.NewRBACService()
 	
-	role, err := rbacService.GetRoleByIDWithPermissions(roleID)
+	role, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Role not found")
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch role")
 	}
@@ -152,15 +388,7 @@ func GetRolePermissions(c *fiber.Ctx) error {
 	// Convert permissions to response format
 	var permissions []dto.PermissionResponse
 	for _, p := range role.Permissions {
-		permissions = append(permissions, dto.PermissionResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Resource:    p.Resource,
-			Action:      p.Action,
-			Description: p.Description,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
-		})
+		permissions = append(permissions, toPermissionResponse(&p))
 	}
 
 	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
@@ -170,39 +398,134 @@ func GetRolePermissions(c *fiber.Ctx) error {
 }
 
 // CreateRole creates a new role (admin only)
+// @openapi tag Roles
+// @openapi request dto.CreateRoleRequest
+// @openapi response 201 dto.RoleResponse
+// @openapi response 400
+// @openapi response 409
 func CreateRole(c *fiber.Ctx) error {
 	var req dto.CreateRoleRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
-	rbacService := services.NewRBACService()
-	
-	role, err := rbacService.CreateRole(req.Name, req.Description)
+	rbacService := services.NewRBACService().Primary()
+
+	createdBy := middleware.GetUserID(c)
+	role, err := rbacService.CreateRole(c.UserContext(), req.Name, req.Description, req.UseWildcardPermissions, req.DataScope, &createdBy)
 	if err != nil {
+		if message, ok := helpers.ModelValidationError(err); ok {
+			return helpers.ValidationErrorResponse(c, message)
+		}
 		if helpers.IsDuplicateError(err) {
-			return helpers.ConflictResponse(c, "Role name already exists")
+			return helpers.ConflictResponse(c, "Role name already exists", apperrors.ErrRoleExists)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to create role")
 	}
 
+	recordAudit(c, services.AuditActionRoleCreate, services.AuditResourceRole, role.ID, roleAuditFields(role))
+
+	response := dto.RoleResponse{
+		ID:                     role.ID,
+		Name:                   role.Name,
+		Description:            role.Description,
+		UseWildcardPermissions: role.UseWildcardPermissions,
+		AccessWindows:          toAccessWindowResponses(role.AccessWindows),
+		DataScope:              role.DataScope,
+		CreatedByEmail:         creatorEmail(role.Creator),
+		Permissions:            []dto.PermissionResponse{}, // New roles have no permissions initially
+		CreatedAt:              role.CreatedAt,
+		UpdatedAt:              role.UpdatedAt,
+	}
+
+	dispatchWebhook(services.WebhookEventRoleCreated, response)
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, response)
+}
+
+// CloneRole creates a new role with the same permissions as an existing one (admin only)
+// @openapi tag Roles
+// @openapi request dto.CloneRoleRequest
+// @openapi response 201 dto.RoleResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
+func CloneRole(c *fiber.Ctx) error {
+	sourceID := c.Params("id")
+	if sourceID == "" {
+		return helpers.ValidationErrorResponse(c, "Role ID is required")
+	}
+
+	var req dto.CloneRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	createdBy := middleware.GetUserID(c)
+	role, err := rbacService.CloneRole(c.UserContext(), sourceID, req.Name, req.Description, &createdBy)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+		}
+		if errors.Is(err, services.ErrSystemRoleClone) {
+			return helpers.ValidationErrorResponse(c, "System roles cannot be cloned")
+		}
+		if message, ok := helpers.ModelValidationError(err); ok {
+			return helpers.ValidationErrorResponse(c, message)
+		}
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Role name already exists", apperrors.ErrRoleExists)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to clone role")
+	}
+
+	permissions := []dto.PermissionResponse{}
+	permissionNames := make([]string, 0, len(role.Permissions))
+	for _, p := range role.Permissions {
+		permissions = append(permissions, toPermissionResponse(&p))
+		permissionNames = append(permissionNames, p.Name)
+	}
+
+	fields := roleAuditFields(role)
+	fields["source_role_id"] = sourceID
+	fields["permissions"] = permissionNames
+	recordAudit(c, services.AuditActionRoleClone, services.AuditResourceRole, role.ID, fields)
+
 	response := dto.RoleResponse{
-		ID:          role.ID,
-		Name:        role.Name,
-		Description: role.Description,
-		Permissions: []dto.PermissionResponse{}, // New roles have no permissions initially
-		CreatedAt:   role.CreatedAt,
-		UpdatedAt:   role.UpdatedAt,
+		ID:                     role.ID,
+		Name:                   role.Name,
+		Description:            role.Description,
+		UseWildcardPermissions: role.UseWildcardPermissions,
+		AccessWindows:          toAccessWindowResponses(role.AccessWindows),
+		DataScope:              role.DataScope,
+		CreatedByEmail:         creatorEmail(role.Creator),
+		Permissions:            permissions,
+		CreatedAt:              role.CreatedAt,
+		UpdatedAt:              role.UpdatedAt,
 	}
 
+	dispatchWebhook(services.WebhookEventRoleCreated, response)
+
 	return helpers.SuccessResponse(c, fiber.StatusCreated, response)
 }
 
 // UpdateRole updates an existing role (admin only)
+// @openapi tag Roles
+// @openapi request dto.UpdateRoleRequest
+// @openapi response 200 dto.RoleResponse
+// @openapi response 400
+// @openapi response 404
+// @openapi response 409
 func UpdateRole(c *fiber.Ctx) error {
 	roleID := c.Params("id")
 	if roleID == "" {
@@ -211,7 +534,7 @@ func UpdateRole(c *fiber.Ctx) error {
 
 	var req dto.UpdateRoleRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
@@ -229,25 +552,51 @@ func UpdateRole(c *fiber.Ctx) error {
 		updates["description"] = *req.Description
 	}
 
+	if req.UseWildcardPermissions != nil {
+		updates["use_wildcard_permissions"] = *req.UseWildcardPermissions
+	}
+
+	if req.AccessWindows != nil {
+		windows := toAccessWindows(*req.AccessWindows)
+		if err := windows.Validate(); err != nil {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
+		updates["access_windows"] = windows
+	}
+
 	if len(updates) == 0 {
 		return helpers.ValidationErrorResponse(c, "No fields to update")
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
+
+	existingRole, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch role")
+	}
 	
-	_, err := rbacService.UpdateRole(roleID, updates)
+	updatedBy := middleware.GetUserID(c)
+	_, err = rbacService.UpdateRole(c.UserContext(), roleID, updates, &updatedBy)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Role not found")
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
+		}
+		if message, ok := helpers.ModelValidationError(err); ok {
+			return helpers.ValidationErrorResponse(c, message)
 		}
 		if helpers.IsDuplicateError(err) {
-			return helpers.ConflictResponse(c, "Role name already exists")
+			return helpers.ConflictResponse(c, "Role name already exists", apperrors.ErrRoleExists)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to update role")
 	}
 
+	recordAudit(c, services.AuditActionRoleUpdate, services.AuditResourceRole, roleID, services.AuditDiff(roleAuditFields(existingRole), updates))
+
 	// Get updated role with permissions
-	updatedRole, err := rbacService.GetRoleByIDWithPermissions(roleID)
+	updatedRole, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated role")
 	}
@@ -255,49 +604,51 @@ func UpdateRole(c *fiber.Ctx) error {
 	// Convert permissions to response format
 	var permissions []dto.PermissionResponse
 	for _, p := range updatedRole.Permissions {
-		permissions = append(permissions, dto.PermissionResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Resource:    p.Resource,
-			Action:      p.Action,
-			Description: p.Description,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
-		})
+		permissions = append(permissions, toPermissionResponse(&p))
 	}
 
 	response := dto.RoleResponse{
-		ID:          updatedRole.ID,
-		Name:        updatedRole.Name,
-		Description: updatedRole.Description,
-		Permissions: permissions,
-		CreatedAt:   updatedRole.CreatedAt,
-		UpdatedAt:   updatedRole.UpdatedAt,
+		ID:                     updatedRole.ID,
+		Name:                   updatedRole.Name,
+		Description:            updatedRole.Description,
+		UseWildcardPermissions: updatedRole.UseWildcardPermissions,
+		AccessWindows:          toAccessWindowResponses(updatedRole.AccessWindows),
+		DataScope:              updatedRole.DataScope,
+		CreatedByEmail:         creatorEmail(updatedRole.Creator),
+		Permissions:            permissions,
+		CreatedAt:              updatedRole.CreatedAt,
+		UpdatedAt:              updatedRole.UpdatedAt,
 	}
 
+	dispatchWebhook(services.WebhookEventRoleUpdated, response)
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, response)
 }
 
 // DeleteRole deletes a role (admin only)
+// @openapi tag Roles
+// @openapi response 200 dto.MessageResponse
+// @openapi response 400
+// @openapi response 404
 func DeleteRole(c *fiber.Ctx) error {
 	roleID := c.Params("id")
 	if roleID == "" {
 		return helpers.ValidationErrorResponse(c, "Role ID is required")
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
 	
 	// Check if role exists first
-	_, err := rbacService.GetRoleByIDWithPermissions(roleID)
+	existingRole, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Role not found")
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch role")
 	}
 
 	// Delete the role
-	err = rbacService.DeleteRole(roleID)
+	err = rbacService.DeleteRole(c.UserContext(), roleID)
 	if err != nil {
 		if err.Error() == "cannot delete system role: admin" || err.Error() == "cannot delete system role: user" {
 			return helpers.ValidationErrorResponse(c, err.Error())
@@ -305,12 +656,23 @@ func DeleteRole(c *fiber.Ctx) error {
 		return helpers.InternalServerErrorResponse(c, "Failed to delete role")
 	}
 
+	recordAudit(c, services.AuditActionRoleDelete, services.AuditResourceRole, roleID, roleAuditFields(existingRole))
+	dispatchWebhook(services.WebhookEventRoleDeleted, fiber.Map{
+		"id":   existingRole.ID,
+		"name": existingRole.Name,
+	})
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
 		Message: "Role deleted successfully",
 	})
 }
 
 // UpdateRolePermissions updates permissions for a role (admin only)
+// @openapi tag Roles
+// @openapi request dto.AssignPermissionsToRoleRequest
+// @openapi response 200 dto.RoleResponse
+// @openapi response 400
+// @openapi response 404
 func UpdateRolePermissions(c *fiber.Ctx) error {
 	roleID := c.Params("id")
 	if roleID == "" {
@@ -319,26 +681,26 @@ func UpdateRolePermissions(c *fiber.Ctx) error {
 
 	var req dto.AssignPermissionsToRoleRequest
 	if err := c.BodyParser(&req); err != nil {
-		return helpers.ValidationErrorResponse(c, "Invalid request body")
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
 	}
 
 	if err := validate.Struct(req); err != nil {
 		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
 	}
 
-	rbacService := services.NewRBACService()
+	rbacService := services.NewRBACService().Primary()
 	
 	// Check if role exists
-	_, err := rbacService.GetRoleByIDWithPermissions(roleID)
+	existingRole, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return helpers.NotFoundResponse(c, "Role not found")
+			return helpers.NotFoundResponse(c, "Role not found", apperrors.ErrRoleNotFound)
 		}
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch role")
 	}
 
 	// Update role permissions
-	err = rbacService.SetRolePermissions(roleID, req.PermissionIDs)
+	err = rbacService.SetRolePermissions(c.UserContext(), roleID, req.PermissionIDs)
 	if err != nil {
 		if err.Error() == "cannot remove admin.access permission from admin role" {
 			return helpers.ValidationErrorResponse(c, err.Error())
@@ -347,33 +709,65 @@ func UpdateRolePermissions(c *fiber.Ctx) error {
 	}
 
 	// Get updated role with permissions
-	updatedRole, err := rbacService.GetRoleByIDWithPermissions(roleID)
+	updatedRole, err := rbacService.GetRoleByIDWithPermissions(c.UserContext(), roleID)
 	if err != nil {
 		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated role")
 	}
 
+	recordAudit(c, services.AuditActionRolePermissionsUpdate, services.AuditResourceRole, roleID, services.AuditDiff(
+		map[string]interface{}{"permissions": permissionNames(existingRole.Permissions)},
+		map[string]interface{}{"permissions": permissionNames(updatedRole.Permissions)},
+	))
+
 	// Convert permissions to response format
 	var permissions []dto.PermissionResponse
 	for _, p := range updatedRole.Permissions {
-		permissions = append(permissions, dto.PermissionResponse{
-			ID:          p.ID,
-			Name:        p.Name,
-			Resource:    p.Resource,
-			Action:      p.Action,
-			Description: p.Description,
-			CreatedAt:   p.CreatedAt,
-			UpdatedAt:   p.UpdatedAt,
-		})
+		permissions = append(permissions, toPermissionResponse(&p))
 	}
 
 	response := dto.RoleResponse{
-		ID:          updatedRole.ID,
-		Name:        updatedRole.Name,
-		Description: updatedRole.Description,
-		Permissions: permissions,
-		CreatedAt:   updatedRole.CreatedAt,
-		UpdatedAt:   updatedRole.UpdatedAt,
+		ID:                     updatedRole.ID,
+		Name:                   updatedRole.Name,
+		Description:            updatedRole.Description,
+		UseWildcardPermissions: updatedRole.UseWildcardPermissions,
+		AccessWindows:          toAccessWindowResponses(updatedRole.AccessWindows),
+		DataScope:              updatedRole.DataScope,
+		CreatedByEmail:         creatorEmail(updatedRole.Creator),
+		Permissions:            permissions,
+		CreatedAt:              updatedRole.CreatedAt,
+		UpdatedAt:              updatedRole.UpdatedAt,
 	}
 
+	dispatchWebhook(services.WebhookEventRoleUpdated, response)
+
 	return helpers.SuccessResponse(c, fiber.StatusOK, response)
-}
\ No newline at end of file
+}
+
+// permissionNames returns the sorted names of the given permissions
+func permissionNames(permissions []models.Permission) []string {
+	names := make([]string, len(permissions))
+	for i, p := range permissions {
+		names[i] = p.Name
+	}
+	sort.Strings(names)
+	return names
+}
+
+// toAccessWindows converts requested access windows for storage
+func toAccessWindows(windows []dto.AccessWindow) rbac.AccessWindows {
+	converted := make(rbac.AccessWindows, len(windows))
+	for i, w := range windows {
+		converted[i] = rbac.AccessWindow{Days: w.Days, Start: w.Start, End: w.End, TZ: w.TZ}
+	}
+	return converted
+}
+
+// toAccessWindowResponses converts a role's access windows, returning an empty
+// list for roles that are always active
+func toAccessWindowResponses(windows rbac.AccessWindows) []dto.AccessWindow {
+	responses := make([]dto.AccessWindow, len(windows))
+	for i, w := range windows {
+		responses[i] = dto.AccessWindow{Days: w.Days, Start: w.Start, End: w.End, TZ: w.TZ}
+	}
+	return responses
+}
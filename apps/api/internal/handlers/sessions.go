@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/session"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ListSessions returns the authenticated user's active sessions, newest first
+// @openapi tag Sessions
+// @openapi response 200 sessions:[]dto.SessionResponse total:integer
+func ListSessions(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	sessions, err := session.Sessions().List(userID)
+	if err != nil {
+		logger.Error("Failed to list sessions", "user_id", userID, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch sessions")
+	}
+
+	currentID := middleware.GetSessionID(c)
+	responses := make([]dto.SessionResponse, len(sessions))
+	for i, s := range sessions {
+		responses[i] = dto.SessionResponse{
+			ID:        s.ID,
+			UserAgent: s.UserAgent,
+			IPAddress: s.IPAddress,
+			Current:   s.ID == currentID,
+			CreatedAt: s.CreatedAt,
+			ExpiresAt: s.ExpiresAt,
+		}
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"sessions": responses,
+		"total":    len(responses),
+	})
+}
+
+// RevokeSession signs out one of the authenticated user's sessions. Its token
+// is rejected from then on.
+// @openapi tag Sessions
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func RevokeSession(c *fiber.Ctx) error {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		return helpers.UnauthorizedResponse(c, "User not authenticated", apperrors.ErrAuthRequired)
+	}
+
+	revoked, err := session.Sessions().Revoke(userID, c.Params("sessionID"))
+	if err != nil {
+		logger.Error("Failed to revoke session", "user_id", userID, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to revoke session")
+	}
+	if !revoked {
+		return helpers.NotFoundResponse(c, "Session not found", apperrors.ErrSessionNotFound)
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Session revoked successfully",
+	})
+}
+
+// requestDevice describes the client making the request, for the session of
+// a token issued to it
+func requestDevice(c *fiber.Ctx) session.Device {
+	return session.Device{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IPAddress: c.IP(),
+	}
+}
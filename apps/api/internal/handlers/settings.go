@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetDefaultRole returns the role assigned to newly registered users (admin only)
+// @openapi tag System
+// @openapi response 200 dto.DefaultRoleResponse
+func GetDefaultRole(c *fiber.Ctx) error {
+	role, err := services.NewSystemSettingService().GetDefaultUserRole(c.UserContext())
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch default role")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.DefaultRoleResponse{
+		Role: role,
+	})
+}
+
+// UpdateDefaultRole changes the role assigned to newly registered users
+// without a restart (admin only). An empty role makes new users start without
+// one.
+// @openapi tag System
+// @openapi request dto.DefaultRoleRequest
+// @openapi response 200 dto.DefaultRoleResponse
+// @openapi response 400
+func UpdateDefaultRole(c *fiber.Ctx) error {
+	var req dto.DefaultRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	settingService := services.NewSystemSettingService()
+
+	previous, err := settingService.GetDefaultUserRole(c.UserContext())
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch default role")
+	}
+
+	role := helpers.TrimString(*req.Role)
+	if err := settingService.SetDefaultUserRole(c.UserContext(), role); err != nil {
+		if errors.Is(err, services.ErrDefaultRoleNotFound) {
+			return helpers.ValidationErrorResponse(c, "Role not found: "+role)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update default role")
+	}
+
+	if previous != role {
+		recordAudit(c, services.AuditActionSettingUpdate, services.AuditResourceSystem, services.SettingDefaultUserRole, fiber.Map{
+			"role": services.AuditChange{From: previous, To: role},
+		})
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.DefaultRoleResponse{
+		Role: role,
+	})
+}
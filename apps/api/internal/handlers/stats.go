@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"api/internal/database"
+	"api/internal/dto"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAdminStats returns a summary of users, RBAC, email and database state (admin only).
+// Values are cached for up to a minute; active users are those who logged in
+// within the last 30 days.
+// @openapi tag System
+// @openapi response 200 dto.AdminStatsResponse
+func GetAdminStats(c *fiber.Ctx) error {
+	stats, err := services.NewStatsService().GetStats(c.UserContext())
+	if err != nil {
+		logger.Error("Failed to compute admin stats", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch statistics")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.AdminStatsResponse{
+		TotalUsers:          stats.TotalUsers,
+		ActiveUsers:         stats.ActiveUsers,
+		UsersToday:          stats.UsersToday,
+		TotalRoles:          stats.TotalRoles,
+		TotalPermissions:    stats.TotalPermissions,
+		TotalEmailTemplates: stats.TotalEmailTemplates,
+		ActiveAnnouncements: stats.ActiveAnnouncements,
+		EmailQueueDepth:     stats.EmailQueueDepth,
+		DBConnectionsOpen:   stats.DBConnectionsOpen,
+		DBConnectionsIdle:   stats.DBConnectionsIdle,
+		GeneratedAt:         stats.GeneratedAt,
+	})
+}
+
+// GetDBStats returns the primary database connection pool stats (admin only).
+// wait_count is the total number of times a query waited for a free connection.
+// @openapi tag System
+// @openapi response 200 dto.DBStatsResponse
+func GetDBStats(c *fiber.Ctx) error {
+	stats, err := database.Stats()
+	if err != nil {
+		logger.Error("Failed to read database pool stats", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch database stats")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.DBStatsResponse{
+		Open:      stats.Open,
+		InUse:     stats.InUse,
+		Idle:      stats.Idle,
+		WaitCount: stats.WaitCount,
+	})
+}
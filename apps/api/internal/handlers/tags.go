@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListTags returns all user tags (admin only)
+// @openapi tag Tags
+// @openapi response 200 tags:[]dto.TagResponse total:integer
+func ListTags(c *fiber.Ctx) error {
+	tags, err := services.NewTagService().ListTags()
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch tags")
+	}
+
+	responses := make([]dto.TagResponse, len(tags))
+	for i := range tags {
+		responses[i] = toTagResponse(&tags[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"tags":  responses,
+		"total": len(responses),
+	})
+}
+
+// CreateTag creates a user tag (admin only)
+// @openapi tag Tags
+// @openapi request dto.CreateTagRequest
+// @openapi response 201 dto.TagResponse
+// @openapi response 400
+// @openapi response 409
+func CreateTag(c *fiber.Ctx) error {
+	var req dto.CreateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	req.Name = helpers.TrimString(req.Name)
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	tag := models.Tag{Name: req.Name}
+	if err := services.NewTagService().CreateTag(&tag); err != nil {
+		if helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Tag already exists", apperrors.ErrTagExists)
+		}
+		logger.Error("Failed to create tag", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to create tag")
+	}
+
+	recordAudit(c, services.AuditActionTagCreate, services.AuditResourceTag, tag.ID, map[string]interface{}{"name": tag.Name})
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, toTagResponse(&tag))
+}
+
+// DeleteTag removes a tag from every user and deletes it (admin only)
+// @openapi tag Tags
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func DeleteTag(c *fiber.Ctx) error {
+	tagID := c.Params("id")
+	if _, err := uuid.Parse(tagID); err != nil {
+		return helpers.NotFoundResponse(c, "Tag not found", apperrors.ErrTagNotFound)
+	}
+
+	tagService := services.NewTagService()
+
+	existingTag, err := tagService.GetTag(tagID)
+	if err != nil {
+		if errors.Is(err, services.ErrTagNotFound) {
+			return helpers.NotFoundResponse(c, "Tag not found", apperrors.ErrTagNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch tag")
+	}
+
+	if err := tagService.DeleteTag(tagID); err != nil {
+		if errors.Is(err, services.ErrTagNotFound) {
+			return helpers.NotFoundResponse(c, "Tag not found", apperrors.ErrTagNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to delete tag")
+	}
+
+	recordAudit(c, services.AuditActionTagDelete, services.AuditResourceTag, tagID, map[string]interface{}{"name": existingTag.Name})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Tag deleted successfully",
+	})
+}
+
+// UpdateUserTags replaces a user's tags; every tag must already exist (admin
+// only)
+// @openapi tag Users
+// @openapi request dto.UpdateUserTagsRequest
+// @openapi response 200 dto.UserManagementResponse
+// @openapi response 400
+// @openapi response 404
+func UpdateUserTags(c *fiber.Ctx) error {
+	userID := c.Params("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+	}
+
+	var req dto.UpdateUserTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	rbacService := services.NewRBACService().Primary()
+
+	existingUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	}
+
+	if err := services.NewTagService().SetUserTags(userID, req.Tags); err != nil {
+		if errors.Is(err, services.ErrTagNotFound) {
+			return helpers.ValidationErrorResponse(c, "Invalid tags: "+err.Error(), apperrors.ErrTagNotFound)
+		}
+		logger.Error("Failed to update user tags", "user_id", userID, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to update user tags")
+	}
+
+	recordAudit(c, services.AuditActionUserTagsUpdate, services.AuditResourceUser, userID, services.AuditDiff(
+		map[string]interface{}{"tags": existingUser.GetTagNames()},
+		map[string]interface{}{"tags": req.Tags},
+	))
+
+	updatedUser, err := rbacService.GetUserWithRoles(c.UserContext(), userID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
+	}
+
+	masked, err := maskResponse(c, toUserListResponses([]models.User{*updatedUser})[0])
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated user")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, masked)
+}
+
+func toTagResponse(tag *models.Tag) dto.TagResponse {
+	return dto.TagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt,
+	}
+}
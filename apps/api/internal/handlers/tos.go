@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/middleware"
+	"api/internal/models"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetCurrentToS returns the terms of service version currently in effect
+// @openapi tag Terms of Service
+// @openapi response 200 dto.ToSVersionResponse
+// @openapi response 404
+func GetCurrentToS(c *fiber.Ctx) error {
+	current, err := services.NewToSService().CurrentVersion(c.UserContext())
+	if err != nil {
+		if errors.Is(err, services.ErrNoToSVersion) {
+			return helpers.NotFoundResponse(c, "No terms of service published", apperrors.ErrToSNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch terms of service")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toToSVersionResponse(current))
+}
+
+// AcceptToS records the authenticated user accepting the current terms of service
+// @openapi tag Terms of Service
+// @openapi request dto.AcceptToSRequest
+// @openapi response 200 dto.ToSVersionResponse
+// @openapi response 400
+// @openapi response 404
+func AcceptToS(c *fiber.Ctx) error {
+	var req dto.AcceptToSRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	current, err := services.NewToSService().AcceptCurrentVersion(c.UserContext(), middleware.GetUserID(c), req.ToSVersion, c.IP())
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoToSVersion):
+			return helpers.NotFoundResponse(c, "No terms of service published", apperrors.ErrToSNotFound)
+		case errors.Is(err, services.ErrToSVersionMismatch):
+			return helpers.ValidationErrorResponse(c, "Terms of service version "+current.Version+" must be accepted")
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to record terms of service acceptance")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toToSVersionResponse(current))
+}
+
+// ListToSVersions returns every published terms of service version (admin only)
+// @openapi tag Terms of Service
+// @openapi response 200 versions:[]dto.ToSVersionResponse total:integer
+func ListToSVersions(c *fiber.Ctx) error {
+	versions, err := services.NewToSService().ListVersions(c.UserContext())
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch terms of service versions")
+	}
+
+	responses := make([]dto.ToSVersionResponse, 0, len(versions))
+	for i := range versions {
+		responses = append(responses, toToSVersionResponse(&versions[i]))
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"versions": responses,
+		"total":    len(responses),
+	})
+}
+
+// PublishToSVersion publishes a new terms of service version (admin only).
+// Once it takes effect, users must accept it before using protected routes.
+// @openapi tag Terms of Service
+// @openapi request dto.PublishToSVersionRequest
+// @openapi response 201 dto.ToSVersionResponse
+// @openapi response 400
+// @openapi response 409
+func PublishToSVersion(c *fiber.Ctx) error {
+	var req dto.PublishToSVersionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	version, err := services.NewToSService().PublishVersion(c.UserContext(), helpers.TrimString(req.Version), req.Content, req.EffectiveDate)
+	if err != nil {
+		if errors.Is(err, services.ErrToSVersionExists) || helpers.IsDuplicateError(err) {
+			return helpers.ConflictResponse(c, "Terms of service version already exists", apperrors.ErrToSVersionExists)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to publish terms of service")
+	}
+
+	recordAudit(c, services.AuditActionToSPublish, services.AuditResourceToS, version.ID, fiber.Map{
+		"version":        version.Version,
+		"effective_date": version.EffectiveDate,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, toToSVersionResponse(version))
+}
+
+func toToSVersionResponse(version *models.ToSVersion) dto.ToSVersionResponse {
+	return dto.ToSVersionResponse{
+		ID:            version.ID,
+		Version:       version.Version,
+		Content:       version.Content,
+		EffectiveDate: version.EffectiveDate,
+		CreatedAt:     version.CreatedAt,
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/middleware"
+	"api/internal/services"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ImportUsers creates users from an uploaded CSV file (admin only)
+// @openapi tag Users
+// @openapi upload file
+// @openapi response 200 dto.UserImportResponse
+// @openapi response 400
+// @openapi response 403
+func ImportUsers(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return helpers.ValidationErrorResponse(c, "CSV file is required in the 'file' form field")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return helpers.ValidationErrorResponse(c, "Failed to read uploaded file")
+	}
+	defer file.Close()
+
+	// Imported rows name companies that may not exist yet, so they can't be
+	// checked against the caller's data scopes
+	scopes, err := dataScopes(c)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to import users")
+	}
+	if len(scopes) > 0 {
+		return helpers.ForbiddenResponse(c, "Importing users is not allowed for admins limited by data scopes", apperrors.ErrDataScopeViolation)
+	}
+
+	importService := services.NewUserImportService()
+	currentUserID := middleware.GetUserID(c)
+
+	result, err := importService.Import(file, &currentUserID)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidUserImport) {
+			return helpers.ValidationErrorResponse(c, err.Error())
+		}
+		logger.Error("User import failed", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to import users")
+	}
+
+	failures := make([]dto.UserImportFailure, 0, len(result.Failures))
+	for _, failure := range result.Failures {
+		failures = append(failures, dto.UserImportFailure{
+			Row:   failure.Row,
+			Email: failure.Email,
+			Error: failure.Error,
+		})
+	}
+
+	if result.Imported > 0 {
+		recordAudit(c, services.AuditActionUserImport, services.AuditResourceUser, "", fiber.Map{
+			"file":     fileHeader.Filename,
+			"total":    result.Total,
+			"imported": result.Imported,
+			"skipped":  result.Skipped,
+			"failed":   len(result.Failures),
+		})
+		dispatchImportedUserWebhooks(c, result.UserIDs)
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.UserImportResponse{
+		Total:    result.Total,
+		Imported: result.Imported,
+		Skipped:  result.Skipped,
+		Failures: failures,
+	})
+}
+
+// dispatchImportedUserWebhooks sends a user.created webhook for each imported
+// user, as creating the user one at a time would
+func dispatchImportedUserWebhooks(c *fiber.Ctx, userIDs []string) {
+	users, err := services.NewRBACService().Primary().GetUsersWithRoles(c.UserContext(), userIDs)
+	if err != nil {
+		logger.Error("Failed to load imported users for webhooks", "error", err)
+		return
+	}
+	for _, userResponse := range toUserListResponses(users) {
+		dispatchWebhook(services.WebhookEventUserCreated, userResponse)
+	}
+}
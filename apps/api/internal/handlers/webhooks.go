@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"encoding/json"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ListWebhooks returns all webhooks (admin only)
+// @openapi tag Webhooks
+// @openapi response 200 webhooks:[]dto.WebhookResponse total:integer
+func ListWebhooks(c *fiber.Ctx) error {
+	webhooks, err := services.NewWebhookService().ListWebhooks()
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch webhooks")
+	}
+
+	responses := make([]dto.WebhookResponse, len(webhooks))
+	for i := range webhooks {
+		responses[i] = toWebhookResponse(&webhooks[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"webhooks": responses,
+		"total":    len(responses),
+	})
+}
+
+// GetWebhook returns a webhook by ID (admin only)
+// @openapi tag Webhooks
+// @openapi response 200 dto.WebhookResponse
+// @openapi response 404
+func GetWebhook(c *fiber.Ctx) error {
+	webhookID := c.Params("id")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+	}
+
+	webhook, err := services.NewWebhookService().GetWebhook(webhookID)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch webhook")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toWebhookResponse(webhook))
+}
+
+// CreateWebhook registers a webhook for user and role events (admin only).
+// The signing secret is only shown in this response.
+// @openapi tag Webhooks
+// @openapi request dto.CreateWebhookRequest
+// @openapi response 201 dto.CreateWebhookResponse
+// @openapi response 400
+func CreateWebhook(c *fiber.Ctx) error {
+	var req dto.CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	webhook := models.Webhook{
+		URL:      req.URL,
+		Secret:   req.Secret,
+		Events:   pq.StringArray(req.Events),
+		IsActive: req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := services.NewWebhookService().CreateWebhook(&webhook); err != nil {
+		logger.Error("Failed to create webhook", "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to create webhook")
+	}
+
+	recordAudit(c, services.AuditActionWebhookCreate, services.AuditResourceWebhook, webhook.ID, fiber.Map{
+		"url":       webhook.URL,
+		"events":    req.Events,
+		"is_active": webhook.IsActive,
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusCreated, dto.CreateWebhookResponse{
+		Secret:  webhook.Secret,
+		Webhook: toWebhookResponse(&webhook),
+	})
+}
+
+// UpdateWebhook updates a webhook's URL, events, secret or status (admin only)
+// @openapi tag Webhooks
+// @openapi request dto.UpdateWebhookRequest
+// @openapi response 200 dto.WebhookResponse
+// @openapi response 400
+// @openapi response 404
+func UpdateWebhook(c *fiber.Ctx) error {
+	webhookID := c.Params("id")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helpers.ValidationErrorResponse(c, "Invalid request body", apperrors.ErrInvalidRequestBody)
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return helpers.ValidationErrorResponse(c, helpers.FormatValidationError(err))
+	}
+
+	webhookService := services.NewWebhookService()
+
+	existingWebhook, err := webhookService.GetWebhook(webhookID)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch webhook")
+	}
+
+	// Build updates map for selective updates
+	updates := make(map[string]interface{})
+	changes := make(map[string]interface{})
+
+	if req.URL != nil {
+		updates["url"] = *req.URL
+		changes["url"] = *req.URL
+	}
+
+	if req.Events != nil {
+		updates["events"] = pq.StringArray(req.Events)
+		changes["events"] = req.Events
+	}
+
+	if req.Secret != nil {
+		updates["secret"] = *req.Secret
+	}
+
+	if req.IsActive != nil {
+		updates["is_active"] = *req.IsActive
+		changes["is_active"] = *req.IsActive
+	}
+
+	if len(updates) == 0 {
+		return helpers.ValidationErrorResponse(c, "No fields to update")
+	}
+
+	if err := webhookService.UpdateWebhook(webhookID, updates); err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to update webhook")
+	}
+
+	auditChanges := services.AuditDiff(map[string]interface{}{
+		"url":       existingWebhook.URL,
+		"events":    []string(existingWebhook.Events),
+		"is_active": existingWebhook.IsActive,
+	}, changes)
+	// The secret itself is never written to the audit log
+	if req.Secret != nil {
+		auditChanges["secret"] = services.AuditChange{To: "rotated"}
+	}
+	recordAudit(c, services.AuditActionWebhookUpdate, services.AuditResourceWebhook, webhookID, auditChanges)
+
+	updatedWebhook, err := webhookService.GetWebhook(webhookID)
+	if err != nil {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch updated webhook")
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, toWebhookResponse(updatedWebhook))
+}
+
+// DeleteWebhook removes a webhook (admin only)
+// @openapi tag Webhooks
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+func DeleteWebhook(c *fiber.Ctx) error {
+	webhookID := c.Params("id")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+	}
+
+	webhookService := services.NewWebhookService()
+
+	existingWebhook, err := webhookService.GetWebhook(webhookID)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch webhook")
+	}
+
+	if err := webhookService.DeleteWebhook(webhookID); err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to delete webhook")
+	}
+
+	recordAudit(c, services.AuditActionWebhookDelete, services.AuditResourceWebhook, webhookID, fiber.Map{
+		"url":    existingWebhook.URL,
+		"events": []string(existingWebhook.Events),
+	})
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Webhook deleted successfully",
+	})
+}
+
+// ListWebhookDeliveries returns a webhook's most recent delivery attempts,
+// newest first (admin only)
+// @openapi tag Webhooks
+// @openapi param failed boolean Only list failed attempts
+// @openapi param limit integer Maximum number of attempts to return
+// @openapi response 200 deliveries:[]dto.WebhookDeliveryResponse total:integer
+// @openapi response 404
+func ListWebhookDeliveries(c *fiber.Ctx) error {
+	webhookID := c.Params("id")
+	if _, err := uuid.Parse(webhookID); err != nil {
+		return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+	}
+
+	limit := c.QueryInt("limit", services.DefaultWebhookDeliveryLimit)
+	if limit <= 0 {
+		limit = services.DefaultWebhookDeliveryLimit
+	}
+	if limit > services.MaxWebhookDeliveryLimit {
+		limit = services.MaxWebhookDeliveryLimit
+	}
+
+	deliveries, err := services.NewWebhookService().ListDeliveries(webhookID, c.QueryBool("failed"), limit)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch webhook deliveries")
+	}
+
+	responses := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i := range deliveries {
+		responses[i] = toWebhookDeliveryResponse(&deliveries[i])
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, fiber.Map{
+		"deliveries": responses,
+		"total":      len(responses),
+	})
+}
+
+// ReplayWebhookDelivery re-sends a failed delivery's payload to the webhook's
+// current URL (admin only). A webhook that rejects the replay or cannot be
+// reached gives 502.
+// @openapi tag Webhooks
+// @openapi response 200 dto.MessageResponse
+// @openapi response 404
+// @openapi response 409
+// @openapi response 502
+func ReplayWebhookDelivery(c *fiber.Ctx) error {
+	deliveryID := c.Params("id")
+	if _, err := uuid.Parse(deliveryID); err != nil {
+		return helpers.NotFoundResponse(c, "Webhook delivery not found", apperrors.ErrWebhookDeliveryNotFound)
+	}
+
+	webhookService := services.NewWebhookService()
+
+	delivery, err := webhookService.GetDelivery(deliveryID)
+	if err != nil {
+		if errors.Is(err, services.ErrWebhookDeliveryNotFound) {
+			return helpers.NotFoundResponse(c, "Webhook delivery not found", apperrors.ErrWebhookDeliveryNotFound)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch webhook delivery")
+	}
+
+	err = webhookService.Replay(deliveryID)
+	switch {
+	case errors.Is(err, services.ErrWebhookDeliveryNotFound):
+		return helpers.NotFoundResponse(c, "Webhook delivery not found", apperrors.ErrWebhookDeliveryNotFound)
+	case errors.Is(err, services.ErrWebhookNotFound):
+		return helpers.NotFoundResponse(c, "Webhook not found", apperrors.ErrWebhookNotFound)
+	case errors.Is(err, services.ErrWebhookDeliverySucceeded):
+		return helpers.ConflictResponse(c, "Webhook delivery did not fail", apperrors.ErrWebhookDeliverySucceeded)
+	case errors.Is(err, services.ErrWebhookReplayLimit):
+		return helpers.ConflictResponse(c, "Webhook delivery has been replayed too many times", apperrors.ErrWebhookReplayLimit)
+	case err != nil && !errors.Is(err, services.ErrWebhookReplayFailed):
+		logger.Error("Failed to replay webhook delivery", "delivery_id", deliveryID, "error", err)
+		return helpers.InternalServerErrorResponse(c, "Failed to replay webhook delivery")
+	}
+
+	recordAudit(c, services.AuditActionWebhookReplay, services.AuditResourceWebhook, delivery.WebhookID, fiber.Map{
+		"delivery_id": deliveryID,
+		"event":       delivery.Event,
+		"succeeded":   err == nil,
+	})
+
+	if err != nil {
+		return helpers.ErrorResponse(c, fiber.StatusBadGateway, "Webhook replay failed", apperrors.ErrWebhookReplayFailed)
+	}
+
+	return helpers.SuccessResponse(c, fiber.StatusOK, dto.MessageResponse{
+		Message: "Webhook delivery replayed successfully",
+	})
+}
+
+// dispatchWebhook notifies webhooks subscribed to event. Delivery failures
+// never fail the request that triggered them.
+func dispatchWebhook(event string, data interface{}) {
+	if err := services.NewWebhookService().Dispatch(event, data); err != nil {
+		logger.Error("Failed to dispatch webhook", "event", event, "error", err)
+	}
+}
+
+func toWebhookResponse(webhook *models.Webhook) dto.WebhookResponse {
+	events := []string(webhook.Events)
+	if events == nil {
+		events = []string{}
+	}
+
+	return dto.WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *models.WebhookDeliveryAttempt) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        json.RawMessage(delivery.Payload),
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		Succeeded:      delivery.Succeeded,
+		ReplayOf:       delivery.ReplayOf,
+		AttemptedAt:    delivery.AttemptedAt,
+	}
+}
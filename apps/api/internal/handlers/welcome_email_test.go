@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"api/internal/services"
+)
+
+type fakeEmailService struct {
+	services.EmailService
+	welcomed       []string
+	passwordResets []string
+	err            error
+}
+
+func (f *fakeEmailService) SendWelcomeEmail(to, name string) error {
+	f.welcomed = append(f.welcomed, to)
+	return f.err
+}
+
+func (f *fakeEmailService) SendAdminPasswordReset(to, name string) error {
+	f.passwordResets = append(f.passwordResets, to)
+	return f.err
+}
+
+func useFakeEmailService(t *testing.T, fake *fakeEmailService) {
+	original := newEmailService
+	newEmailService = func() services.EmailService { return fake }
+	t.Cleanup(func() { newEmailService = original })
+}
+
+func TestSendWelcomeEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting string
+		want    int
+	}{
+		{"enabled by default", "", 1},
+		{"explicitly enabled", "true", 1},
+		{"disabled", "false", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SEND_WELCOME_EMAIL", tt.setting)
+			fake := &fakeEmailService{}
+			useFakeEmailService(t, fake)
+
+			sendWelcomeEmail("new@example.com", "New User")
+
+			if len(fake.welcomed) != tt.want {
+				t.Fatalf("welcome emails sent = %d, want %d", len(fake.welcomed), tt.want)
+			}
+			if tt.want > 0 && fake.welcomed[0] != "new@example.com" {
+				t.Errorf("welcome email sent to %q", fake.welcomed[0])
+			}
+		})
+	}
+}
+
+func TestSendWelcomeEmailIgnoresFailures(t *testing.T) {
+	fake := &fakeEmailService{err: errors.New("smtp unavailable")}
+	useFakeEmailService(t, fake)
+
+	// Must not panic or block registration
+	sendWelcomeEmail("new@example.com", "New User")
+
+	if len(fake.welcomed) != 1 {
+		t.Fatalf("welcome emails sent = %d, want 1", len(fake.welcomed))
+	}
+}
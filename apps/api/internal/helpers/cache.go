@@ -0,0 +1,49 @@
+package helpers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// ETag returns a strong entity tag for body: the quoted hex SHA-256 of it
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// SetCacheHeaders sets the ETag and Last-Modified response headers
+func SetCacheHeaders(c *fiber.Ctx, etag string, lastModified time.Time) {
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderLastModified, lastModified.UTC().Format(http.TimeFormat))
+}
+
+// CheckNotModified reports whether the client's cached copy is current, so
+// the handler can answer with 304. If-None-Match is compared with etag,
+// ignoring weak prefixes; only when it is absent is If-Modified-Since
+// compared with lastModified, to the second.
+func CheckNotModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := c.Get(fiber.HeaderIfNoneMatch); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ifModifiedSince := c.Get(fiber.HeaderIfModifiedSince); ifModifiedSince != "" {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package helpers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestETag(t *testing.T) {
+	etag := ETag([]byte(`{"id":"1"}`))
+	if len(etag) != 66 || etag[0] != '"' || etag[65] != '"' {
+		t.Fatalf("ETag() = %s, want a quoted SHA-256 hex digest", etag)
+	}
+	if ETag([]byte(`{"id":"1"}`)) != etag {
+		t.Error("ETag() differs for the same body")
+	}
+	if ETag([]byte(`{"id":"2"}`)) == etag {
+		t.Error("ETag() is the same for different bodies")
+	}
+}
+
+func TestCheckNotModified(t *testing.T) {
+	etag := ETag([]byte("profile"))
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{"no conditional headers", nil, false},
+		{"matching etag", map[string]string{"If-None-Match": etag}, true},
+		{"weak matching etag", map[string]string{"If-None-Match": "W/" + etag}, true},
+		{"etag in list", map[string]string{"If-None-Match": `"other", ` + etag}, true},
+		{"wildcard", map[string]string{"If-None-Match": "*"}, true},
+		{"stale etag", map[string]string{"If-None-Match": `"other"`}, false},
+		{"not modified since", map[string]string{"If-Modified-Since": lastModified.Format(http.TimeFormat)}, true},
+		{"modified since", map[string]string{"If-Modified-Since": lastModified.Add(-time.Second).Format(http.TimeFormat)}, false},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, false},
+		{
+			"stale etag wins over date",
+			map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": lastModified.Format(http.TimeFormat)},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				SetCacheHeaders(c, etag, lastModified)
+				if CheckNotModified(c, etag, lastModified) {
+					return c.SendStatus(fiber.StatusNotModified)
+				}
+				return c.SendString("profile")
+			})
+
+			req := httptest.NewRequest("GET", "/", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+
+			if got := resp.StatusCode == fiber.StatusNotModified; got != tt.want {
+				t.Errorf("not modified = %v, want %v", got, tt.want)
+			}
+			if resp.Header.Get("ETag") != etag {
+				t.Errorf("ETag = %q, want %q", resp.Header.Get("ETag"), etag)
+			}
+			if resp.Header.Get("Last-Modified") != "Thu, 02 Jan 2025 03:04:05 GMT" {
+				t.Errorf("Last-Modified = %q", resp.Header.Get("Last-Modified"))
+			}
+		})
+	}
+}
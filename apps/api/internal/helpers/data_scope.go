@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"api/internal/models"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApplyDataScope narrows a query on users, or joined with users, to the users
+// every scope allows. Each key names a user column that must equal the value,
+// or be one of the values when given a list. A scope naming a column outside
+// models.DataScopeColumns matches nothing, so a bad scope never widens access.
+func ApplyDataScope(query *gorm.DB, scopes []map[string]interface{}) *gorm.DB {
+	for _, scope := range scopes {
+		columns := make([]string, 0, len(scope))
+		for column := range scope {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		for _, column := range columns {
+			if !models.DataScopeColumns[column] {
+				return query.Where("1 = 0")
+			}
+			target := clause.Column{Table: "users", Name: column}
+			if values, ok := scope[column].([]interface{}); ok {
+				query = query.Where(clause.IN{Column: target, Values: values})
+			} else {
+				query = query.Where(clause.Eq{Column: target, Value: scope[column]})
+			}
+		}
+	}
+	return query
+}
+
+// DataScopeAllows reports whether every scope that names column allows
+// value. Only string values can match, so clearing a scoped column is never
+// allowed.
+func DataScopeAllows(scopes []map[string]interface{}, column string, value interface{}) bool {
+	for _, scope := range scopes {
+		allowed, ok := scope[column]
+		if !ok {
+			continue
+		}
+		if !scopeValueMatches(allowed, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// scopeValueMatches reports whether value is the string allowed or one of the
+// strings it lists
+func scopeValueMatches(allowed, value interface{}) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	switch a := allowed.(type) {
+	case string:
+		return a == str
+	case []interface{}:
+		for _, item := range a {
+			if item == str {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EmailLookupForms returns the stored forms an account's email may take for
+// an address a user typed: trimmed and lowercased, the way emails were stored
+// before plus-addressing and punycode normalization, and NormalizeEmail's
+// form when it differs
+func EmailLookupForms(email string) []string {
+	typed := strings.ToLower(strings.TrimSpace(email))
+	normalized := NormalizeEmail(email)
+	if normalized == typed {
+		return []string{typed}
+	}
+	return []string{typed, normalized}
+}
+
+// WhereEmail narrows query to the users whose email is one of the
+// EmailLookupForms of email. An exact match of the typed form is preferred,
+// so an account stored before normalization changed is still found first.
+func WhereEmail(query *gorm.DB, email string) *gorm.DB {
+	forms := EmailLookupForms(email)
+	return query.Where("email IN ?", forms).Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "email = ? DESC",
+		Vars:               []interface{}{forms[0]},
+		WithoutParentheses: true,
+	}})
+}
@@ -0,0 +1,29 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEmailLookupForms(t *testing.T) {
+	tests := []struct {
+		name          string
+		email         string
+		plusAddresses string
+		want          []string
+	}{
+		{name: "already normalized", email: " User@Example.com ", want: []string{"user@example.com"}},
+		{name: "plus tag kept", email: "user+news@example.com", want: []string{"user+news@example.com"}},
+		{name: "plus tag stripped", email: "User+News@example.com", plusAddresses: "true", want: []string{"user+news@example.com", "user@example.com"}},
+		{name: "punycode domain", email: "user@xn--bcher-kva.example", want: []string{"user@xn--bcher-kva.example", "user@bücher.example"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NORMALIZE_EMAIL_PLUS_ADDRESSING", tt.plusAddresses)
+			if got := EmailLookupForms(tt.email); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EmailLookupForms(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
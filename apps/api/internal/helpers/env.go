@@ -1,19 +1,20 @@
 package helpers
 
 import (
-	"os"
+	"api/internal/config"
 	"strconv"
+	"time"
 )
 
 func GetEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := config.Get(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func GetEnvInt(key string, defaultValue int) int {
-	value := os.Getenv(key)
+	value := config.Get(key)
 	if value == "" {
 		return defaultValue
 	}
@@ -27,7 +28,7 @@ func GetEnvInt(key string, defaultValue int) int {
 }
 
 func GetEnvBool(key string, defaultValue bool) bool {
-	value := os.Getenv(key)
+	value := config.Get(key)
 	if value == "" {
 		return defaultValue
 	}
@@ -38,4 +39,17 @@ func GetEnvBool(key string, defaultValue bool) bool {
 	}
 
 	return boolValue
-}
\ No newline at end of file
+}
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := config.Get(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	durationValue, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+
+	return durationValue
+}
@@ -1,9 +1,14 @@
 package helpers
 
 import (
+	apperrors "api/internal/errors"
+
 	"github.com/gofiber/fiber/v2"
 )
 
+// ErrorHandler is the Fiber error handler. Errors returned by handlers and
+// Fiber's own errors, such as unknown routes, are sent in the standard error
+// envelope.
 func ErrorHandler(c *fiber.Ctx, err error) error {
 	code := fiber.StatusInternalServerError
 	message := "Internal Server Error"
@@ -13,7 +18,5 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 		message = e.Message
 	}
 
-	return c.Status(code).JSON(fiber.Map{
-		"error": message,
-	})
-}
\ No newline at end of file
+	return c.Status(code).JSON(NewErrorEnvelope(apperrors.CodeForStatus(code), message, nil))
+}
@@ -0,0 +1,36 @@
+package helpers
+
+import (
+	"golang.org/x/text/language"
+)
+
+// ParseAcceptLanguage returns the languages in an Accept-Language header in
+// order of preference. A regional tag such as "id-ID" is followed by its base
+// language ("id") so either can match.
+func ParseAcceptLanguage(header string) []string {
+	if header == "" {
+		return nil
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var languages []string
+	add := func(code string) {
+		if code != "und" && code != "mul" && !seen[code] {
+			seen[code] = true
+			languages = append(languages, code)
+		}
+	}
+
+	for _, tag := range tags {
+		add(tag.String())
+		base, _ := tag.Base()
+		add(base.String())
+	}
+
+	return languages
+}
@@ -0,0 +1,28 @@
+package helpers
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{header: "", want: nil},
+		{header: "id", want: []string{"id"}},
+		{header: "id-ID,id;q=0.9,en;q=0.8", want: []string{"id-ID", "id", "en"}},
+		{header: "en;q=0.5, id;q=0.9", want: []string{"id", "en"}},
+		{header: "*", want: nil},
+		{header: "not a language!!", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
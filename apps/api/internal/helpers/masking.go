@@ -0,0 +1,229 @@
+package helpers
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"api/internal/models"
+)
+
+// maskPrefix replaces the masked part of a value
+const maskPrefix = "****"
+
+// partialMaskVisible is how many trailing characters a partial mask shows
+const partialMaskVisible = 4
+
+// MaskedModel is implemented by responses whose fields field masking rules
+// apply to; MaskingModel is matched against the rules' model
+type MaskedModel interface {
+	MaskingModel() string
+}
+
+var (
+	maskedModelType   = reflect.TypeOf((*MaskedModel)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	maskTimeType      = reflect.TypeOf(time.Time{})
+)
+
+// maskStrictness orders the mask types from least to most restrictive
+var maskStrictness = map[string]int{
+	models.MaskTypePartial: 1,
+	models.MaskTypeFull:    2,
+	models.MaskTypeHidden:  3,
+}
+
+// MaskResponse returns data with the fields of its MaskedModel values masked
+// for a viewer holding userRoles. A field is masked only when every one of the
+// viewer's roles has a rule for it, with the least restrictive of those rules;
+// a role without a rule lets the viewer see the field. Structs are returned as
+// maps keyed by their JSON names so the result encodes like data apart from
+// the masked fields. Data is returned unchanged when no rule applies.
+func MaskResponse(data interface{}, userRoles []string, rules []models.FieldMaskingRule) interface{} {
+	masks := effectiveMasks(userRoles, rules)
+	if len(masks) == 0 {
+		return data
+	}
+	return maskValue(reflect.ValueOf(data), masks)
+}
+
+// effectiveMasks returns the mask type of each masked field by model and
+// JSON field name
+func effectiveMasks(userRoles []string, rules []models.FieldMaskingRule) map[string]map[string]string {
+	held := make(map[string]bool, len(userRoles))
+	for _, role := range userRoles {
+		held[role] = true
+	}
+
+	type modelField struct{ model, field string }
+	byField := make(map[modelField]map[string]string)
+	for _, rule := range rules {
+		if !held[rule.RoleName] || maskStrictness[rule.MaskType] == 0 {
+			continue
+		}
+		key := modelField{rule.Model, rule.Field}
+		if byField[key] == nil {
+			byField[key] = make(map[string]string)
+		}
+		byField[key][rule.RoleName] = rule.MaskType
+	}
+
+	masks := make(map[string]map[string]string)
+	for key, roleMasks := range byField {
+		if len(roleMasks) < len(held) {
+			continue
+		}
+		least := ""
+		for _, maskType := range roleMasks {
+			if least == "" || maskStrictness[maskType] < maskStrictness[least] {
+				least = maskType
+			}
+		}
+		if masks[key.model] == nil {
+			masks[key.model] = make(map[string]string)
+		}
+		masks[key.model][key.field] = least
+	}
+	return masks
+}
+
+func maskValue(v reflect.Value, masks map[string]map[string]string) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		return maskValue(v.Elem(), masks)
+	}
+
+	t := v.Type()
+	if t == maskTimeType || t.Implements(jsonMarshalerType) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if (v.Kind() == reflect.Slice && v.IsNil()) || t.Elem().Kind() == reflect.Uint8 {
+			return v.Interface()
+		}
+		items := make([]interface{}, v.Len())
+		for i := range items {
+			items[i] = maskValue(v.Index(i), masks)
+		}
+		return items
+	case reflect.Map:
+		if v.IsNil() || t.Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		entries := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			entries[iter.Key().String()] = maskValue(iter.Value(), masks)
+		}
+		return entries
+	case reflect.Struct:
+		var fieldMasks map[string]string
+		if t.Implements(maskedModelType) {
+			fieldMasks = masks[v.Interface().(MaskedModel).MaskingModel()]
+		}
+		fields := make(map[string]interface{})
+		addMaskedFields(fields, v, fieldMasks, masks)
+		return fields
+	default:
+		return v.Interface()
+	}
+}
+
+// addMaskedFields adds the fields of struct v to fields as encoding/json
+// would encode them, masking those named in fieldMasks
+func addMaskedFields(fields map[string]interface{}, v reflect.Value, fieldMasks map[string]string, masks map[string]map[string]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		value := v.Field(i)
+
+		// Embedded structs without a JSON name are flattened
+		if field.Anonymous && name == "" {
+			if value.Kind() == reflect.Pointer {
+				if value.IsNil() {
+					continue
+				}
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				addMaskedFields(fields, value, fieldMasks, masks)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if hasJSONOption(options, "omitempty") && isEmptyJSONValue(value) {
+			continue
+		}
+		if hasJSONOption(options, "omitzero") && value.IsZero() {
+			continue
+		}
+
+		maskType, masked := fieldMasks[name]
+		if masked && maskType == models.MaskTypeHidden {
+			continue
+		}
+		fields[name] = maskValue(value, masks)
+		if masked {
+			fields[name] = maskField(fields[name], maskType)
+		}
+	}
+}
+
+// maskField masks a field's value; values that are not strings cannot be
+// partly shown and become null
+func maskField(value interface{}, maskType string) interface{} {
+	if value == nil {
+		return nil
+	}
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	runes := []rune(s)
+	if maskType != models.MaskTypePartial || len(runes) <= partialMaskVisible {
+		return maskPrefix
+	}
+	return maskPrefix + string(runes[len(runes)-partialMaskVisible:])
+}
+
+func hasJSONOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+// isEmptyJSONValue reports whether omitempty leaves v out, as encoding/json does
+func isEmptyJSONValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"api/internal/dto"
+	"api/internal/models"
+)
+
+func maskedJSON(t *testing.T, data interface{}, roles []string, rules []models.FieldMaskingRule) map[string]interface{} {
+	t.Helper()
+	body, err := json.Marshal(MaskResponse(data, roles, rules))
+	if err != nil {
+		t.Fatalf("failed to encode masked response: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("failed to decode masked response: %v", err)
+	}
+	return decoded
+}
+
+func TestMaskResponse(t *testing.T) {
+	phone := "+6281234561234"
+	profile := dto.ProfileResponse{ID: "user-1", Email: "jane@example.com", Name: "Jane", Phone: &phone}
+	rules := []models.FieldMaskingRule{
+		{RoleName: "user", Model: "user", Field: "phone", MaskType: models.MaskTypePartial},
+		{RoleName: "user", Model: "user", Field: "email", MaskType: models.MaskTypeFull},
+		{RoleName: "user", Model: "user", Field: "name", MaskType: models.MaskTypeHidden},
+	}
+
+	t.Run("masks fields for a role with rules", func(t *testing.T) {
+		got := maskedJSON(t, profile, []string{"user"}, rules)
+		if got["phone"] != "****1234" {
+			t.Errorf("phone = %v, want ****1234", got["phone"])
+		}
+		if got["email"] != "****" {
+			t.Errorf("email = %v, want ****", got["email"])
+		}
+		if _, ok := got["name"]; ok {
+			t.Errorf("name = %v, want it hidden", got["name"])
+		}
+		if got["id"] != "user-1" {
+			t.Errorf("id = %v, want user-1", got["id"])
+		}
+	})
+
+	t.Run("a role without rules sees the field", func(t *testing.T) {
+		got := maskedJSON(t, profile, []string{"user", "admin"}, rules)
+		if got["phone"] != phone {
+			t.Errorf("phone = %v, want %s", got["phone"], phone)
+		}
+	})
+
+	t.Run("uses the least restrictive rule of the viewer's roles", func(t *testing.T) {
+		withAuditor := append(rules, models.FieldMaskingRule{
+			RoleName: "auditor", Model: "user", Field: "name", MaskType: models.MaskTypeFull,
+		})
+		got := maskedJSON(t, profile, []string{"user", "auditor"}, withAuditor)
+		if got["name"] != "****" {
+			t.Errorf("name = %v, want ****", got["name"])
+		}
+	})
+
+	t.Run("masks nested models", func(t *testing.T) {
+		page := dto.PaginatedUsersResponse{Users: []dto.UserManagementResponse{
+			{ID: "user-1", Email: "jane@example.com", Name: "Jane", Phone: &phone},
+		}}
+		got := maskedJSON(t, page, []string{"user"}, rules)
+		users, ok := got["users"].([]interface{})
+		if !ok || len(users) != 1 {
+			t.Fatalf("users = %v, want one user", got["users"])
+		}
+		if user := users[0].(map[string]interface{}); user["phone"] != "****1234" {
+			t.Errorf("phone = %v, want ****1234", user["phone"])
+		}
+	})
+
+	t.Run("returns data unchanged without rules", func(t *testing.T) {
+		if got := MaskResponse(profile, []string{"user"}, nil); !reflect.DeepEqual(got, profile) {
+			t.Errorf("MaskResponse() = %v, want data unchanged", got)
+		}
+	})
+}
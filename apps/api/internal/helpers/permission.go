@@ -0,0 +1,18 @@
+package helpers
+
+import (
+	"path"
+)
+
+// MatchesPermission reports whether a permission check such as "user.read" is
+// granted by pattern. Patterns use path.Match syntax, so "user.*" matches any
+// user permission and "*" matches everything. A malformed pattern matches
+// nothing but its exact name.
+func MatchesPermission(pattern, check string) bool {
+	if pattern == check {
+		return true
+	}
+
+	matched, err := path.Match(pattern, check)
+	return err == nil && matched
+}
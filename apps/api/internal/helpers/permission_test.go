@@ -0,0 +1,36 @@
+package helpers
+
+import "testing"
+
+func TestMatchesPermission(t *testing.T) {
+	tests := []struct {
+		pattern string
+		check   string
+		want    bool
+	}{
+		{pattern: "user.read", check: "user.read", want: true},
+		{pattern: "user.read", check: "user.write", want: false},
+		{pattern: "*", check: "user.read", want: true},
+		{pattern: "*", check: "admin.access", want: true},
+		{pattern: "user.*", check: "user.read", want: true},
+		{pattern: "user.*", check: "user.write", want: true},
+		{pattern: "user.*", check: "role.read", want: false},
+		{pattern: "user.*", check: "user", want: false},
+		{pattern: "user.*", check: "username.read", want: false},
+		{pattern: "*.read", check: "role.read", want: true},
+		{pattern: "user.?ead", check: "user.read", want: true},
+		{pattern: "**", check: "user.read", want: true},
+		{pattern: "user.**", check: "user.read", want: true},
+		{pattern: "user.[", check: "user.read", want: false},
+		{pattern: "user.[", check: "user.[", want: true},
+		{pattern: "", check: "user.read", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.check, func(t *testing.T) {
+			if got := MatchesPermission(tt.pattern, tt.check); got != tt.want {
+				t.Errorf("MatchesPermission(%q, %q) = %v, want %v", tt.pattern, tt.check, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,39 +1,74 @@
 package helpers
 
 import (
+	apperrors "api/internal/errors"
+
 	"github.com/gofiber/fiber/v2"
 )
 
-func ErrorResponse(c *fiber.Ctx, status int, message string) error {
-	return c.Status(status).JSON(fiber.Map{
-		"error": message,
-	})
+// ErrorEnvelope is the body of every error response. Error keeps the
+// message as a string, as before codes were added, and Code sits beside it.
+type ErrorEnvelope struct {
+	Error   string      `json:"error"`
+	Code    string      `json:"code"`
+	Details interface{} `json:"details"`
+}
+
+// NewErrorEnvelope returns the error response body for code and message
+func NewErrorEnvelope(code, message string, details interface{}) ErrorEnvelope {
+	return ErrorEnvelope{
+		Error:   message,
+		Code:    code,
+		Details: details,
+	}
+}
+
+// ErrorResponse sends an error with the given status. The code defaults to
+// the generic code for the status.
+func ErrorResponse(c *fiber.Ctx, status int, message string, code ...string) error {
+	return ErrorDetailsResponse(c, status, errorCode(status, code), message, nil)
+}
+
+// ErrorDetailsResponse sends an error carrying extra machine-readable details
+func ErrorDetailsResponse(c *fiber.Ctx, status int, code, message string, details interface{}) error {
+	return c.Status(status).JSON(NewErrorEnvelope(code, message, details))
+}
+
+func errorCode(status int, code []string) string {
+	if len(code) > 0 && code[0] != "" {
+		return code[0]
+	}
+	return apperrors.CodeForStatus(status)
 }
 
 func SuccessResponse(c *fiber.Ctx, status int, data interface{}) error {
 	return c.Status(status).JSON(data)
 }
 
-func ValidationErrorResponse(c *fiber.Ctx, message string) error {
-	return ErrorResponse(c, fiber.StatusBadRequest, message)
+func ValidationErrorResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusBadRequest, message, code...)
 }
 
-func UnauthorizedResponse(c *fiber.Ctx, message string) error {
-	return ErrorResponse(c, fiber.StatusUnauthorized, message)
+func UnauthorizedResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusUnauthorized, message, code...)
 }
 
-func InternalServerErrorResponse(c *fiber.Ctx, message string) error {
-	return ErrorResponse(c, fiber.StatusInternalServerError, message)
+func InternalServerErrorResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusInternalServerError, message, code...)
 }
 
-func NotFoundResponse(c *fiber.Ctx, message string) error {
-	return ErrorResponse(c, fiber.StatusNotFound, message)
+func NotFoundResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusNotFound, message, code...)
 }
 
-func ConflictResponse(c *fiber.Ctx, message string) error {
-	return ErrorResponse(c, fiber.StatusConflict, message)
+func ConflictResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusConflict, message, code...)
 }
 
-func ForbiddenResponse(c *fiber.Ctx, message string) error {
-	return ErrorResponse(c, fiber.StatusForbidden, message)
-}
\ No newline at end of file
+func ForbiddenResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusForbidden, message, code...)
+}
+
+func TooManyRequestsResponse(c *fiber.Ctx, message string, code ...string) error {
+	return ErrorResponse(c, fiber.StatusTooManyRequests, message, code...)
+}
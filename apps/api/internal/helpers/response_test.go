@@ -0,0 +1,93 @@
+package helpers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "api/internal/errors"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func decodeEnvelope(t *testing.T, app *fiber.App, method, path string) (int, ErrorEnvelope) {
+	t.Helper()
+	resp, err := app.Test(httptest.NewRequest(method, path, nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	var envelope ErrorEnvelope
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return resp.StatusCode, envelope
+}
+
+func TestErrorResponseCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    fiber.Handler
+		wantStatus int
+		wantCode   string
+	}{
+		{"validation default", func(c *fiber.Ctx) error { return ValidationErrorResponse(c, "bad") }, 400, apperrors.ErrValidation},
+		{"unauthorized default", func(c *fiber.Ctx) error { return UnauthorizedResponse(c, "bad") }, 401, apperrors.ErrUnauthorized},
+		{"forbidden default", func(c *fiber.Ctx) error { return ForbiddenResponse(c, "bad") }, 403, apperrors.ErrForbidden},
+		{"not found default", func(c *fiber.Ctx) error { return NotFoundResponse(c, "bad") }, 404, apperrors.ErrNotFound},
+		{"conflict default", func(c *fiber.Ctx) error { return ConflictResponse(c, "bad") }, 409, apperrors.ErrConflict},
+		{"too many requests default", func(c *fiber.Ctx) error { return TooManyRequestsResponse(c, "bad") }, 429, apperrors.ErrRateLimited},
+		{"internal default", func(c *fiber.Ctx) error { return InternalServerErrorResponse(c, "bad") }, 500, apperrors.ErrInternal},
+		{"explicit code", func(c *fiber.Ctx) error {
+			return NotFoundResponse(c, "bad", apperrors.ErrUserNotFound)
+		}, 404, apperrors.ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Get("/", tt.handler)
+
+			status, envelope := decodeEnvelope(t, app, "GET", "/")
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if envelope.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", envelope.Code, tt.wantCode)
+			}
+			if envelope.Error != "bad" {
+				t.Errorf("error = %q, want %q", envelope.Error, "bad")
+			}
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Get("/boom", func(c *fiber.Ctx) error { return errors.New("boom") })
+	app.Get("/only-get", func(c *fiber.Ctx) error { return nil })
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"unknown route", "GET", "/missing", 404, apperrors.ErrNotFound},
+		{"wrong method", "POST", "/only-get", 405, apperrors.ErrMethodNotAllowed},
+		{"generic error", "GET", "/boom", 500, apperrors.ErrInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, envelope := decodeEnvelope(t, app, tt.method, tt.path)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+			if envelope.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", envelope.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -1,13 +1,89 @@
 package helpers
 
 import (
+	_ "embed"
 	"strings"
+
+	"golang.org/x/net/idna"
 )
 
+//go:embed disposable_domains.txt
+var disposableDomainList string
+
+// disposableDomains holds the bundled blocklist of disposable email domains
+var disposableDomains = parseDomainList(disposableDomainList)
+
+// NormalizeEmail trims and lowercases email and decodes a punycode domain to
+// Unicode. With NORMALIZE_EMAIL_PLUS_ADDRESSING set, a plus-addressing tag is
+// also dropped, so user+tag@example.com becomes user@example.com.
 func NormalizeEmail(email string) string {
-	return strings.ToLower(strings.TrimSpace(email))
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domain := email[:at], email[at+1:]
+
+	if GetEnvBool("NORMALIZE_EMAIL_PLUS_ADDRESSING", false) {
+		if plus := strings.Index(local, "+"); plus > 0 {
+			local = local[:plus]
+		}
+	}
+
+	return local + "@" + decodeDomain(domain)
+}
+
+// IsDisposableEmail reports whether email belongs to a disposable email
+// provider on the bundled blocklist, or to a subdomain of one
+func IsDisposableEmail(email string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := decodeDomain(email[at+1:])
+	for {
+		if _, ok := disposableDomains[domain]; ok {
+			return true
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			return false
+		}
+		domain = domain[dot+1:]
+	}
+}
+
+// decodeDomain converts a punycode domain such as xn--bcher-kva.example to
+// its Unicode form. Domains that are not valid IDNA, or whose labels do not
+// encode back to the same punycode, are returned unchanged.
+func decodeDomain(domain string) string {
+	decoded, err := idna.Lookup.ToUnicode(domain)
+	if err != nil {
+		return domain
+	}
+	if encoded, err := idna.Lookup.ToASCII(decoded); err != nil || encoded != domain {
+		return domain
+	}
+	return decoded
+}
+
+// parseDomainList reads one domain per line, skipping blank lines and
+// # comments
+func parseDomainList(list string) map[string]struct{} {
+	domains := make(map[string]struct{})
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.ToLower(strings.TrimSpace(line))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains[line] = struct{}{}
+	}
+	return domains
 }
 
 func TrimString(s string) string {
 	return strings.TrimSpace(s)
-}
\ No newline at end of file
+}
@@ -0,0 +1,69 @@
+package helpers
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		email         string
+		plusAddresses string
+		want          string
+	}{
+		{name: "trims and lowercases", email: "  User@Example.COM ", want: "user@example.com"},
+		{name: "keeps plus tag by default", email: "user+news@example.com", want: "user+news@example.com"},
+		{name: "strips plus tag", email: "User+News@example.com", plusAddresses: "true", want: "user@example.com"},
+		{name: "strips from the first plus", email: "user+a+b@example.com", plusAddresses: "true", want: "user@example.com"},
+		{name: "keeps leading plus", email: "+tag@example.com", plusAddresses: "true", want: "+tag@example.com"},
+		{name: "decodes punycode domain", email: "user@xn--bcher-kva.example", want: "user@bücher.example"},
+		{name: "decodes punycode subdomain", email: "user@mail.XN--BCHER-KVA.example", want: "user@mail.bücher.example"},
+		{name: "keeps empty punycode label", email: "user@xn--.example", want: "user@xn--.example"},
+		{name: "keeps invalid punycode", email: "user@xn--0.example", want: "user@xn--0.example"},
+		{name: "keeps non-canonical punycode", email: "user@xn--abc-.example", want: "user@xn--abc-.example"},
+		{name: "keeps value without domain", email: "User", want: "user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NORMALIZE_EMAIL_PLUS_ADDRESSING", tt.plusAddresses)
+			if got := NormalizeEmail(tt.email); got != tt.want {
+				t.Errorf("NormalizeEmail(%q) = %q, want %q", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDisposableEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  bool
+	}{
+		{email: "user@mailinator.com", want: true},
+		{email: " User@MAILINATOR.com ", want: true},
+		{email: "user+tag@yopmail.com", want: true},
+		{email: "user@eu.mailinator.com", want: true},
+		{email: "user@example.com", want: false},
+		{email: "user@notmailinator.com", want: false},
+		{email: "user@xn--bcher-kva.example", want: false},
+		{email: "mailinator.com", want: false},
+		{email: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			if got := IsDisposableEmail(tt.email); got != tt.want {
+				t.Errorf("IsDisposableEmail(%q) = %v, want %v", tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisposableDomainListSkipsComments(t *testing.T) {
+	if len(disposableDomains) == 0 {
+		t.Fatal("bundled disposable domain list is empty")
+	}
+	for domain := range disposableDomains {
+		if domain == "" || domain[0] == '#' {
+			t.Errorf("disposable domain list contains %q", domain)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package helpers
 
 import (
+	"errors"
 	"strings"
 
+	"api/internal/models"
 	"api/internal/pkg/phonenumbers"
 	"github.com/go-playground/validator/v10"
 )
@@ -19,6 +21,8 @@ func FormatValidationError(err error) string {
 			messages = append(messages, err.Field()+" is too short")
 		case "phone":
 			messages = append(messages, err.Field()+" must be a valid phone number")
+		case "eqfield":
+			messages = append(messages, err.Field()+" must match "+err.Param())
 		default:
 			messages = append(messages, err.Field()+" is invalid")
 		}
@@ -30,6 +34,16 @@ func IsDuplicateError(err error) bool {
 	return strings.Contains(err.Error(), "duplicate key value") || strings.Contains(err.Error(), "UNIQUE constraint")
 }
 
+// ModelValidationError returns the message of the model save hook error
+// wrapped in err, if there is one
+func ModelValidationError(err error) (string, bool) {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Error(), true
+	}
+	return "", false
+}
+
 func ValidatePhone(fl validator.FieldLevel) bool {
 	phone := fl.Field().String()
 	if phone == "" {
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"api/internal/config"
 	"log/slog"
 	"os"
 	"strings"
@@ -12,28 +13,18 @@ func init() {
 	Logger = New()
 }
 
+// New creates a logger writing to stdout with the level and format set with
+// LOG_LEVEL and LOG_FORMAT. LOG_OUTPUT is applied by the server, so other
+// commands do not open log files or syslog connections.
 func New() *slog.Logger {
-	level := getLogLevel()
-	format := getLogFormat()
-
-	var handler slog.Handler
-
-	opts := &slog.HandlerOptions{
-		Level: level,
-	}
-
-	switch format {
-	case "json":
-		handler = slog.NewJSONHandler(os.Stdout, opts)
-	default:
-		handler = slog.NewTextHandler(os.Stdout, opts)
-	}
-
-	return slog.New(handler)
+	return NewWithOptions(LogOptions{
+		Format: getLogFormat(),
+		Level:  getLogLevel(),
+	})
 }
 
 func getLogLevel() slog.Level {
-	level := strings.ToLower(os.Getenv("LOG_LEVEL"))
+	level := strings.ToLower(config.Get("LOG_LEVEL"))
 	switch level {
 	case "debug":
 		return slog.LevelDebug
@@ -44,7 +35,7 @@ func getLogLevel() slog.Level {
 	case "error":
 		return slog.LevelError
 	default:
-		env := os.Getenv("ENV")
+		env := config.Get("ENV")
 		if env == "production" {
 			return slog.LevelInfo
 		}
@@ -53,12 +44,12 @@ func getLogLevel() slog.Level {
 }
 
 func getLogFormat() string {
-	format := strings.ToLower(os.Getenv("LOG_FORMAT"))
+	format := strings.ToLower(config.Get("LOG_FORMAT"))
 	if format == "json" {
 		return "json"
 	}
 	
-	env := os.Getenv("ENV")
+	env := config.Get("ENV")
 	if env == "production" {
 		return "json"
 	}
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"api/internal/config"
+)
+
+// LogOptions configures a logger created with NewWithOptions
+type LogOptions struct {
+	// Output is where log lines are written: "stdout" (the default),
+	// "stderr", a file such as "file:///var/log/api.log", or a syslog server
+	// such as "syslog://localhost:514"
+	Output string
+	// Format is "text" (the default) or "json"
+	Format string
+	Level  slog.Level
+	// Writer receives the log lines instead of Output when set
+	Writer io.Writer
+}
+
+// OptionsFromEnv returns the options set with LOG_OUTPUT, LOG_FORMAT and
+// LOG_LEVEL
+func OptionsFromEnv() LogOptions {
+	return LogOptions{
+		Output: config.Get("LOG_OUTPUT"),
+		Format: getLogFormat(),
+		Level:  getLogLevel(),
+	}
+}
+
+// NewWithOptions creates a logger writing to the configured output. When
+// the output cannot be opened, the logger writes to stdout instead and
+// reports why.
+func NewWithOptions(opts LogOptions) *slog.Logger {
+	writer := opts.Writer
+	var outputErr error
+	if writer == nil {
+		writer, outputErr = openOutput(opts.Output)
+		if outputErr != nil {
+			writer = os.Stdout
+		}
+	}
+
+	handlerOptions := &slog.HandlerOptions{Level: opts.Level}
+
+	var handler slog.Handler
+	switch strings.ToLower(opts.Format) {
+	case "json":
+		handler = slog.NewJSONHandler(writer, handlerOptions)
+	default:
+		handler = slog.NewTextHandler(writer, handlerOptions)
+	}
+
+	logger := slog.New(handler)
+	if outputErr != nil {
+		logger.Error("Failed to open log output, logging to stdout", "output", opts.Output, "error", outputErr)
+	}
+	return logger
+}
+
+// openOutput returns the writer for a LOG_OUTPUT value
+func openOutput(output string) (io.Writer, error) {
+	switch output {
+	case "", "stdout":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	}
+
+	u, err := url.Parse(output)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log output %q: %w", output, err)
+	}
+
+	switch u.Scheme {
+	case "file":
+		// file://logs/api.log is read as a relative path
+		path := u.Host + u.Path
+		if path == "" {
+			return nil, fmt.Errorf("log output %q has no file path", output)
+		}
+		file, err := openLogFile(path)
+		if err != nil {
+			return nil, err
+		}
+		activeFile.Lock()
+		activeFile.output = file
+		activeFile.Unlock()
+		return file, nil
+	case "syslog":
+		return dialSyslog(u.Host)
+	default:
+		return nil, fmt.Errorf("unsupported log output %q", output)
+	}
+}
+
+// activeFile is the file written by the most recently created file logger,
+// which Rotate reopens
+var activeFile struct {
+	sync.Mutex
+	output *fileOutput
+}
+
+// fileOutput appends log lines to a file that can be reopened after it has
+// been moved away by log rotation
+type fileOutput struct {
+	mu   sync.Mutex
+	path string
+	file *os.File
+}
+
+func openLogFile(path string) (*fileOutput, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return &fileOutput{path: path, file: file}, nil
+}
+
+func (f *fileOutput) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Write(p)
+}
+
+// Rotate reopens the file by its path, so lines written afterwards go to a
+// new file once the old one has been renamed
+func (f *fileOutput) Rotate() error {
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen log file: %w", err)
+	}
+
+	f.mu.Lock()
+	previous := f.file
+	f.file = file
+	f.mu.Unlock()
+
+	return previous.Close()
+}
+
+// Rotate reopens the log file of the most recently created file logger. It
+// does nothing when logs are not written to a file.
+func Rotate() error {
+	activeFile.Lock()
+	output := activeFile.output
+	activeFile.Unlock()
+
+	if output == nil {
+		return nil
+	}
+	return output.Rotate()
+}
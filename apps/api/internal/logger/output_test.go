@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewWithOptionsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	log := NewWithOptions(LogOptions{Format: "json", Level: slog.LevelInfo, Writer: &buf})
+
+	log.Debug("hidden")
+	log.Info("User logged in", "user_id", "user-1", "attempts", 2)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("wrote %d lines, want 1: %q", len(lines), buf.String())
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("log line is not JSON: %v", err)
+	}
+	for key, want := range map[string]interface{}{"level": "INFO", "msg": "User logged in", "user_id": "user-1", "attempts": float64(2)} {
+		if entry[key] != want {
+			t.Errorf("%s = %v, want %v", key, entry[key], want)
+		}
+	}
+	if _, ok := entry["time"]; !ok {
+		t.Error("log line has no time")
+	}
+}
+
+func TestNewWithOptionsText(t *testing.T) {
+	var buf bytes.Buffer
+	NewWithOptions(LogOptions{Writer: &buf}).Info("Server starting", "port", 8080)
+
+	if got := buf.String(); !strings.Contains(got, `msg="Server starting" port=8080`) {
+		t.Errorf("text log line = %q", got)
+	}
+}
+
+func TestNewWithOptionsFileRotate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.log")
+	log := NewWithOptions(LogOptions{Output: "file://" + path, Format: "json"})
+	t.Cleanup(func() {
+		activeFile.Lock()
+		activeFile.output.file.Close()
+		activeFile.output = nil
+		activeFile.Unlock()
+	})
+
+	log.Info("before rotation")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("failed to move log file: %v", err)
+	}
+	if err := Rotate(); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	log.Info("after rotation")
+
+	rotated, _ := os.ReadFile(path + ".1")
+	current, _ := os.ReadFile(path)
+	if !strings.Contains(string(rotated), "before rotation") || strings.Contains(string(rotated), "after rotation") {
+		t.Errorf("rotated file = %q", rotated)
+	}
+	if !strings.Contains(string(current), "after rotation") || strings.Contains(string(current), "before rotation") {
+		t.Errorf("current file = %q", current)
+	}
+}
+
+func TestOpenOutput(t *testing.T) {
+	for _, output := range []string{"", "stdout", "stderr"} {
+		if _, err := openOutput(output); err != nil {
+			t.Errorf("openOutput(%q) error = %v", output, err)
+		}
+	}
+	for _, output := range []string{"kafka://localhost:9092", "file://", "file:///nonexistent-dir/api.log"} {
+		if _, err := openOutput(output); err == nil {
+			t.Errorf("openOutput(%q) succeeded, want an error", output)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/syslog"
+)
+
+// dialSyslog connects to the syslog server at address over UDP, or to the
+// local syslog daemon when address is empty
+func dialSyslog(address string) (io.Writer, error) {
+	network := "udp"
+	if address == "" {
+		network = ""
+	}
+
+	writer, err := syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_DAEMON, "api")
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return writer, nil
+}
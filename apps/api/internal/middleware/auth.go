@@ -2,43 +2,169 @@ package middleware
 
 import (
 	"api/internal/auth"
+	"api/internal/cache"
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
 	"api/internal/services"
+	"api/internal/session"
+	"context"
+	"errors"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 )
 
+// APIKeyHeader carries an API key as an alternative to a bearer token
+const APIKeyHeader = "X-API-Key"
+
+// ImpersonatedByHeader names the impersonating admin on responses to
+// requests made with an impersonation token
+const ImpersonatedByHeader = "X-Impersonated-By"
+
+// RequireAuth authenticates the request with a JWT bearer token or, when the
+// X-API-Key header is set, an API key
 func RequireAuth() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if key := c.Get(APIKeyHeader); key != "" {
+			return authenticateAPIKey(c, key)
+		}
+
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return helpers.UnauthorizedResponse(c, "Authorization header is required")
+			return helpers.UnauthorizedResponse(c, "Authorization header is required", apperrors.ErrAuthRequired)
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			return helpers.UnauthorizedResponse(c, "Invalid authorization header format")
+			return helpers.UnauthorizedResponse(c, "Invalid authorization header format", apperrors.ErrAuthInvalidToken)
 		}
 
 		token := parts[1]
 		claims, err := auth.ValidateToken(token)
 		if err != nil {
-			return helpers.UnauthorizedResponse(c, "Invalid or expired token")
+			return helpers.UnauthorizedResponse(c, "Invalid or expired token", apperrors.ErrAuthInvalidToken)
 		}
 
-		// Fetch user roles from database
-		rbacService := services.NewRBACService()
-		userRoles, err := rbacService.GetUserRoles(claims.UserID)
-		if err != nil {
-			// If we can't fetch roles, still allow but with empty roles
-			userRoles = []string{}
+		// Tokens issued at login must still be an active session; short-lived
+		// impersonation tokens are not tracked
+		if claims.ImpersonatedBy == "" {
+			active, err := session.Sessions().Exists(claims.UserID, claims.ID)
+			if err != nil {
+				return helpers.InternalServerErrorResponse(c, "Failed to verify session")
+			}
+			if !active {
+				return helpers.UnauthorizedResponse(c, "Session has been revoked", apperrors.ErrAuthSessionRevoked)
+			}
+		}
+
+		// Fetch user roles, falling back to the database on a cache miss.
+		// Suspending a user invalidates their cache entry, so the account
+		// status only needs checking when the roles are reloaded.
+		permissionCache := cache.Permissions()
+		userRoles, ok := permissionCache.Get(claims.UserID)
+		if !ok {
+			rbacService := services.NewRBACService()
+			active, err := rbacService.IsUserActive(c.UserContext(), claims.UserID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return helpers.UnauthorizedResponse(c, "Invalid or expired token", apperrors.ErrAuthInvalidToken)
+				}
+				return helpers.InternalServerErrorResponse(c, "Failed to verify account")
+			}
+			if !active {
+				return helpers.ForbiddenResponse(c, "Account suspended", apperrors.ErrAuthAccountSuspended)
+			}
+
+			userRoles = loadUserRoles(c.UserContext(), rbacService, claims.UserID)
 		}
 
 		c.Locals("userID", claims.UserID)
 		c.Locals("email", claims.Email)
 		c.Locals("userRoles", userRoles)
+		c.Locals("sessionID", claims.ID)
+		if claims.ImpersonatedBy != "" {
+			c.Locals("impersonatedBy", claims.ImpersonatedBy)
+			c.Set(ImpersonatedByHeader, claims.ImpersonatedBy)
+		}
+
+		return c.Next()
+	}
+}
+
+// authenticateAPIKey authenticates the request as the owner of key, limited
+// to the key's scopes
+func authenticateAPIKey(c *fiber.Ctx, key string) error {
+	apiKey, err := services.NewAPIKeyService().AuthenticateAPIKey(key)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidAPIKey) {
+			return helpers.UnauthorizedResponse(c, "Invalid or expired API key", apperrors.ErrAuthInvalidAPIKey)
+		}
+		return helpers.InternalServerErrorResponse(c, "Failed to verify API key")
+	}
+	if !apiKey.User.IsActive {
+		return helpers.ForbiddenResponse(c, "Account suspended", apperrors.ErrAuthAccountSuspended)
+	}
+
+	permissionCache := cache.Permissions()
+	userRoles, ok := permissionCache.Get(apiKey.UserID)
+	if !ok {
+		userRoles = loadUserRoles(c.UserContext(), services.NewRBACService(), apiKey.UserID)
+	}
+
+	c.Locals("userID", apiKey.UserID)
+	c.Locals("email", apiKey.User.Email)
+	c.Locals("userRoles", userRoles)
+	c.Locals("apiKeyScopes", []string(apiKey.Scopes))
+
+	return c.Next()
+}
+
+// loadUserRoles fetches the user's active role names and caches them
+func loadUserRoles(ctx context.Context, rbacService *services.RBACService, userID string) []string {
+	assignments, err := rbacService.GetUserRoleAssignments(ctx, userID)
+	if err != nil {
+		// If we can't fetch roles, still allow but with empty roles
+		return []string{}
+	}
+
+	userRoles, ttl := services.ActiveRoles(assignments, time.Now(), cache.TTL())
+	cache.Permissions().Set(userID, userRoles, ttl)
+	return userRoles
+}
 
+// RequireAPIKeyScope rejects requests authenticated with an API key that does
+// not grant scope. Requests authenticated with a JWT are not restricted.
+func RequireAPIKeyScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scopes, ok := GetAPIKeyScopes(c)
+		if !ok || slices.Contains(scopes, scope) {
+			return c.Next()
+		}
+		return helpers.ForbiddenResponse(c, "API key is missing the "+scope+" scope", apperrors.ErrAPIKeyScopeMissing)
+	}
+}
+
+// RequireJWT rejects requests authenticated with an API key, for routes that
+// API key scopes do not cover
+func RequireJWT() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := GetAPIKeyScopes(c); ok {
+			return helpers.ForbiddenResponse(c, "API keys cannot access this endpoint", apperrors.ErrAPIKeyNotAllowed)
+		}
+		return c.Next()
+	}
+}
+
+// RejectImpersonation rejects requests made with an impersonation token, for
+// routes that change how the user authenticates or remove their account
+func RejectImpersonation() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if GetImpersonatedBy(c) != "" {
+			return helpers.ForbiddenResponse(c, "Not allowed while impersonating a user", apperrors.ErrImpersonationNotAllowed)
+		}
 		return c.Next()
 	}
 }
@@ -55,4 +181,29 @@ func GetUserEmail(c *fiber.Ctx) string {
 		return email
 	}
 	return ""
-}
\ No newline at end of file
+}
+
+// GetSessionID returns the session of the token that authenticated the
+// request, or an empty string for API keys and impersonation tokens
+func GetSessionID(c *fiber.Ctx) string {
+	if sessionID, ok := c.Locals("sessionID").(string); ok {
+		return sessionID
+	}
+	return ""
+}
+
+// GetImpersonatedBy returns the ID of the admin impersonating the user, or an
+// empty string when the user authenticated themselves
+func GetImpersonatedBy(c *fiber.Ctx) string {
+	if adminID, ok := c.Locals("impersonatedBy").(string); ok {
+		return adminID
+	}
+	return ""
+}
+
+// GetAPIKeyScopes returns the scopes of the API key that authenticated the
+// request; ok is false for requests authenticated with a JWT
+func GetAPIKeyScopes(c *fiber.Ctx) ([]string, bool) {
+	scopes, ok := c.Locals("apiKeyScopes").([]string)
+	return scopes, ok
+}
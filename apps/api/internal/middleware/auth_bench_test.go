@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"api/internal/auth"
+	"api/internal/cache"
+	"api/internal/database"
+	"api/internal/session"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+const benchUserID = "00000000-0000-0000-0000-000000000001"
+
+func benchmarkRequireAuth(b *testing.B, permissionCache cache.PermissionCache) {
+	b.Helper()
+
+	os.Setenv("JWT_SECRET", "benchmark-secret")
+	token, err := auth.GenerateToken(benchUserID, "bench@example.com", nil, session.Device{})
+	if err != nil {
+		b.Fatalf("failed to generate token: %v", err)
+	}
+
+	previous := cache.Permissions()
+	cache.SetPermissions(permissionCache)
+	b.Cleanup(func() { cache.SetPermissions(previous) })
+
+	app := fiber.New()
+	app.Get("/", RequireAuth(), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	handler := app.Handler()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var ctx fasthttp.RequestCtx
+		ctx.Request.SetRequestURI("/")
+		ctx.Request.Header.Set("Authorization", "Bearer "+token)
+		handler(&ctx)
+		if ctx.Response.StatusCode() != fiber.StatusOK {
+			b.Fatalf("unexpected status %d", ctx.Response.StatusCode())
+		}
+	}
+}
+
+func BenchmarkRequireAuthCached(b *testing.B) {
+	permissionCache := cache.NewMemoryCache()
+	permissionCache.Set(benchUserID, []string{"user"}, time.Hour)
+
+	benchmarkRequireAuth(b, permissionCache)
+}
+
+func BenchmarkRequireAuthUncached(b *testing.B) {
+	if database.DB == nil {
+		if err := database.Connect(); err != nil {
+			b.Skipf("database not available: %v", err)
+		}
+	}
+
+	benchmarkRequireAuth(b, cache.NewNoopCache())
+}
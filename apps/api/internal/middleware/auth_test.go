@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"api/internal/auth"
+	"api/internal/cache"
+	"api/internal/services"
+	"api/internal/session"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// newAPIKeyScopedApp serves "/" behind handler as if the request had been
+// authenticated with an API key granting scopes, or with a JWT when scopes is nil
+func newAPIKeyScopedApp(scopes []string, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		c.Locals("userID", "user-1")
+		if scopes != nil {
+			c.Locals("apiKeyScopes", scopes)
+		}
+		return c.Next()
+	}, handler, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestRequireAPIKeyScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		want   int
+	}{
+		{name: "JWT is not restricted", scopes: nil, want: fiber.StatusOK},
+		{name: "key with scope", scopes: []string{"user:read", "user:write"}, want: fiber.StatusOK},
+		{name: "key without scope", scopes: []string{"user:read"}, want: fiber.StatusForbidden},
+		{name: "key without scopes", scopes: []string{}, want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newAPIKeyScopedApp(tt.scopes, RequireAPIKeyScope("user:write"))
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireJWT(t *testing.T) {
+	resp, err := newAPIKeyScopedApp(nil, RequireJWT()).Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("JWT request status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	resp, err = newAPIKeyScopedApp([]string{"user:read", "user:write"}, RequireJWT()).Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusForbidden {
+		t.Errorf("API key request status = %d, want %d", resp.StatusCode, fiber.StatusForbidden)
+	}
+}
+
+func TestRequireAuthRevokedSession(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	t.Setenv("MAX_CONCURRENT_SESSIONS", "1")
+
+	previousSessions := session.Sessions()
+	session.SetSessions(session.NewMemoryStore())
+	t.Cleanup(func() { session.SetSessions(previousSessions) })
+
+	permissionCache := cache.NewMemoryCache()
+	permissionCache.Set("user-1", []string{"user"}, time.Hour)
+	previousCache := cache.Permissions()
+	cache.SetPermissions(permissionCache)
+	t.Cleanup(func() { cache.SetPermissions(previousCache) })
+
+	app := fiber.New()
+	app.Get("/", RequireAuth(), func(c *fiber.Ctx) error {
+		return c.SendString(GetSessionID(c))
+	})
+	request := func(token string) int {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	first, err := auth.GenerateToken("user-1", "user@example.com", nil, session.Device{})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if status := request(first); status != fiber.StatusOK {
+		t.Fatalf("first token status = %d, want %d", status, fiber.StatusOK)
+	}
+
+	// A second login beyond the limit of one revokes the first session
+	second, err := auth.GenerateToken("user-1", "user@example.com", nil, session.Device{})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if status := request(first); status != fiber.StatusUnauthorized {
+		t.Errorf("revoked token status = %d, want %d", status, fiber.StatusUnauthorized)
+	}
+	if status := request(second); status != fiber.StatusOK {
+		t.Errorf("second token status = %d, want %d", status, fiber.StatusOK)
+	}
+}
+
+// newRoleTestDB opens an in-memory SQLite database holding the roles and
+// user_roles tables, with userID holding roleName when granted
+func newRoleTestDB(t *testing.T, userID, roleName string, granted bool) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: gormlogger.Default.LogMode(gormlogger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+
+	statements := []string{
+		`CREATE TABLE roles (
+			id TEXT PRIMARY KEY, name TEXT NOT NULL UNIQUE, description TEXT,
+			use_wildcard_permissions BOOLEAN NOT NULL DEFAULT false, access_windows TEXT NOT NULL DEFAULT '[]',
+			data_scope TEXT, created_by TEXT, updated_by TEXT, created_at DATETIME, updated_at DATETIME
+		)`,
+		`CREATE TABLE user_roles (
+			user_id TEXT NOT NULL, role_id TEXT NOT NULL, granted_at DATETIME, granted_by TEXT, expires_at DATETIME,
+			PRIMARY KEY (user_id, role_id)
+		)`,
+		`INSERT INTO roles (id, name) VALUES ('role-1', '` + roleName + `')`,
+	}
+	if granted {
+		statements = append(statements, `INSERT INTO user_roles (user_id, role_id, granted_at) VALUES ('`+userID+`', 'role-1', CURRENT_TIMESTAMP)`)
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("failed to prepare database: %v", err)
+		}
+	}
+	return db
+}
+
+func TestLoadUserRolesIgnoresStaleReplica(t *testing.T) {
+	previousCache := cache.Permissions()
+	cache.SetPermissions(cache.NewMemoryCache())
+	t.Cleanup(func() { cache.SetPermissions(previousCache) })
+
+	// The admin role was revoked on the primary, but the replica still has it
+	primary := newRoleTestDB(t, "user-1", "admin", false)
+	replica := newRoleTestDB(t, "user-1", "admin", true)
+
+	roles := loadUserRoles(context.Background(), services.NewRBACServiceWithDB(primary, replica), "user-1")
+	if len(roles) != 0 {
+		t.Errorf("roles = %v, want none", roles)
+	}
+	if cached, ok := cache.Permissions().Get("user-1"); ok && len(cached) != 0 {
+		t.Errorf("cached roles = %v, want none", cached)
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"io"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodySizeLimit rejects requests whose body is larger than maxBytes with 413.
+// The declared Content-Length is checked first; streamed bodies are wrapped so
+// no more than maxBytes are read from them.
+func BodySizeLimit(maxBytes int64) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		req := c.Request()
+		if int64(req.Header.ContentLength()) > maxBytes {
+			return bodyTooLarge(c)
+		}
+
+		if req.IsBodyStream() {
+			req.SetBodyStream(&io.LimitedReader{R: req.BodyStream(), N: maxBytes}, -1)
+		} else if int64(len(req.Body())) > maxBytes {
+			// Chunked bodies have no Content-Length to check up front
+			return bodyTooLarge(c)
+		}
+
+		return c.Next()
+	}
+}
+
+func bodyTooLarge(c *fiber.Ctx) error {
+	return helpers.ErrorResponse(c, fiber.StatusRequestEntityTooLarge, "request body too large", apperrors.ErrRequestTooLarge)
+}
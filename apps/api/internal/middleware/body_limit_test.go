@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newBodyLimitedApp(maxBytes int64) *fiber.App {
+	app := fiber.New()
+	app.Post("/", BodySizeLimit(maxBytes), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestBodySizeLimit(t *testing.T) {
+	app := newBodyLimitedApp(1024)
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/", bytes.NewReader(make([]byte, 1024))))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("body at limit: status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/", bytes.NewReader(make([]byte, 1025))))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Fatalf("body over limit: status = %d, want %d", resp.StatusCode, fiber.StatusRequestEntityTooLarge)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if want := `{"error":"request body too large","code":"REQUEST_TOO_LARGE","details":null}`; string(body) != want {
+		t.Errorf("body = %s, want %s", body, want)
+	}
+}
+
+func TestBodySizeLimitWithoutContentLength(t *testing.T) {
+	app := newBodyLimitedApp(1024)
+
+	req := httptest.NewRequest("POST", "/", bytes.NewReader(make([]byte, 2048)))
+	req.ContentLength = -1
+	req.TransferEncoding = []string{"chunked"}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusRequestEntityTooLarge {
+		t.Errorf("chunked body over limit: status = %d, want %d", resp.StatusCode, fiber.StatusRequestEntityTooLarge)
+	}
+}
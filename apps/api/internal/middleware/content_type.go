@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"strings"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EnforceJSONContentType rejects POST, PUT and PATCH requests whose body is
+// not sent as application/json with 415. Requests without a body, such as
+// action endpoints like POST /users/:id/restore, are let through.
+func EnforceJSONContentType() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch:
+		default:
+			return c.Next()
+		}
+
+		if len(c.Body()) == 0 {
+			return c.Next()
+		}
+
+		if !strings.Contains(strings.ToLower(c.Get(fiber.HeaderContentType)), fiber.MIMEApplicationJSON) {
+			return helpers.ErrorResponse(c, fiber.StatusUnsupportedMediaType, "Content-Type must be application/json", apperrors.ErrUnsupportedMediaType)
+		}
+
+		return c.Next()
+	}
+}
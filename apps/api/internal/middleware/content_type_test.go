@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestEnforceJSONContentType(t *testing.T) {
+	app := fiber.New()
+	app.Use(EnforceJSONContentType())
+	app.All("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		name        string
+		method      string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"json post", "POST", "application/json", `{"a":1}`, fiber.StatusOK},
+		{"json with charset", "PUT", "application/json; charset=utf-8", `{"a":1}`, fiber.StatusOK},
+		{"xml post", "POST", "text/xml", "<a>1</a>", fiber.StatusUnsupportedMediaType},
+		{"form patch", "PATCH", "application/x-www-form-urlencoded", "a=1", fiber.StatusUnsupportedMediaType},
+		{"missing content type", "POST", "", `{"a":1}`, fiber.StatusUnsupportedMediaType},
+		{"post without body", "POST", "", "", fiber.StatusOK},
+		{"delete with body", "DELETE", "text/plain", "a", fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+			if tt.want == fiber.StatusUnsupportedMediaType {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != `{"error":"Content-Type must be application/json","code":"UNSUPPORTED_MEDIA_TYPE","details":null}` {
+					t.Errorf("body = %s", body)
+				}
+			}
+		})
+	}
+}
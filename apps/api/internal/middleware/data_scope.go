@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// RequireUserInDataScope answers 404 for the user in the :id parameter when
+// they fall outside the data scopes of the caller's roles, so scoped admins
+// cannot read or act on users outside their scope
+func RequireUserInDataScope() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rbacService := services.NewRBACService()
+		scopes, err := rbacService.GetDataScopes(c.UserContext(), GetUserRoles(c))
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+		}
+		c.Locals("dataScopes", scopes)
+		if len(scopes) == 0 {
+			return c.Next()
+		}
+
+		userID := c.Params("id")
+		if _, err := uuid.Parse(userID); err != nil {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		inScope, err := rbacService.UserInDataScope(c.UserContext(), userID, scopes)
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+		}
+		if !inScope {
+			return helpers.NotFoundResponse(c, "User not found", apperrors.ErrUserNotFound)
+		}
+		return c.Next()
+	}
+}
+
+// GetDataScopes returns the data scopes RequireUserInDataScope loaded for the
+// caller; ok is false when it did not run
+func GetDataScopes(c *fiber.Ctx) ([]map[string]interface{}, bool) {
+	scopes, ok := c.Locals("dataScopes").([]map[string]interface{})
+	return scopes, ok
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/basicauth"
+)
+
+// DocsAuth requires HTTP Basic credentials matching username and password
+// when username is set, and allows every request otherwise
+func DocsAuth(username, password string) fiber.Handler {
+	if username == "" {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return basicauth.New(basicauth.Config{
+		Realm: "API Documentation",
+		Authorizer: func(user, pass string) bool {
+			userMatch := subtle.ConstantTimeCompare([]byte(user), []byte(username)) == 1
+			passMatch := subtle.ConstantTimeCompare([]byte(pass), []byte(password)) == 1
+			return userMatch && passMatch
+		},
+	})
+}
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"api/internal/services"
+	"github.com/gofiber/fiber/v2"
+)
+
+// IdempotencyKeyHeader carries the client-chosen key identifying a request
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyReplayedHeader is set on responses replayed from a stored key
+const IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotencyKeyLength matches the key column size
+const maxIdempotencyKeyLength = 255
+
+// skipIdempotencyKey marks a response that must not be stored for replay
+const skipIdempotencyKey = "skip_idempotency"
+
+// idempotencyStore persists the response for each key and user
+type idempotencyStore interface {
+	Reserve(key, userID, requestHash string) (*models.IdempotencyKey, bool, error)
+	Complete(key, userID string, status int, body []byte) error
+	Release(key, userID string) error
+}
+
+// newIdempotencyStore returns the store used by Idempotency; tests replace it
+var newIdempotencyStore = func() idempotencyStore {
+	return services.NewIdempotencyService()
+}
+
+// Idempotency replays the stored response when a POST request repeats an
+// Idempotency-Key already used by the same user within 24 hours, so retried
+// requests do not create duplicates. Requests without the header, and
+// methods other than POST, pass through. Reusing a key for a different
+// request is rejected with 422, and a key whose first request is still
+// running with 409. Server errors, and responses of routes using
+// SkipIdempotency, are not stored so they can be retried. It must run after
+// authentication for the user to be known.
+func Idempotency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return helpers.ValidationErrorResponse(c, "Idempotency-Key must be at most 255 characters")
+		}
+
+		store := newIdempotencyStore()
+		userID := GetUserID(c)
+		requestHash := hashIdempotentRequest(c)
+
+		entry, reserved, err := store.Reserve(key, userID, requestHash)
+		if err != nil {
+			// Fail open so a storage outage does not block writes
+			logger.Warn("Idempotency key lookup failed", "error", err)
+			return c.Next()
+		}
+
+		if !reserved {
+			if entry.RequestHash != requestHash {
+				return helpers.ErrorResponse(c, fiber.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request", apperrors.ErrIdempotencyKeyReused)
+			}
+			if !entry.IsCompleted() {
+				return helpers.ConflictResponse(c, "A request with this Idempotency-Key is still being processed", apperrors.ErrIdempotencyInProgress)
+			}
+
+			c.Set(IdempotencyReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			return c.Status(entry.ResponseStatus).Send(entry.ResponseBody)
+		}
+
+		err = c.Next()
+		status := c.Response().StatusCode()
+		if err != nil || status >= fiber.StatusInternalServerError || c.Locals(skipIdempotencyKey) != nil {
+			if releaseErr := store.Release(key, userID); releaseErr != nil {
+				logger.Warn("Failed to release idempotency key", "error", releaseErr)
+			}
+			return err
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		if err := store.Complete(key, userID, status, body); err != nil {
+			logger.Warn("Failed to store idempotent response", "error", err)
+		}
+		return nil
+	}
+}
+
+// SkipIdempotency keeps Idempotency from storing the response of a route
+// below an idempotent group, for responses carrying credentials such as
+// tokens that must not be replayed
+func SkipIdempotency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(skipIdempotencyKey, true)
+		return c.Next()
+	}
+}
+
+// hashIdempotentRequest fingerprints the method, path and body so a key
+// cannot be replayed for a different request
+func hashIdempotentRequest(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte{0})
+	hash.Write([]byte(c.OriginalURL()))
+	hash.Write([]byte{0})
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
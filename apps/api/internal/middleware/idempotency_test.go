@@ -0,0 +1,269 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"api/internal/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// memoryIdempotencyStore is an in-memory idempotencyStore for tests
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	entries map[string]*models.IdempotencyKey
+	fail    bool
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{entries: make(map[string]*models.IdempotencyKey)}
+}
+
+func (s *memoryIdempotencyStore) Reserve(key, userID, requestHash string) (*models.IdempotencyKey, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		return nil, false, errors.New("store unavailable")
+	}
+
+	if entry, ok := s.entries[userID+"|"+key]; ok {
+		copied := *entry
+		return &copied, false, nil
+	}
+	entry := &models.IdempotencyKey{Key: key, RequestHash: requestHash}
+	s.entries[userID+"|"+key] = entry
+	return entry, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(key, userID string, status int, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := s.entries[userID+"|"+key]
+	entry.ResponseStatus = status
+	entry.ResponseBody = body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(key, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, userID+"|"+key)
+	return nil
+}
+
+// newIdempotencyApp serves POST /items, creating a new item on every call that
+// reaches the handler. The X-User header stands in for authentication.
+func newIdempotencyApp(t *testing.T, store idempotencyStore, status *int) (*fiber.App, *int) {
+	t.Helper()
+	original := newIdempotencyStore
+	newIdempotencyStore = func() idempotencyStore { return store }
+	t.Cleanup(func() { newIdempotencyStore = original })
+
+	created := 0
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", c.Get("X-User"))
+		return c.Next()
+	})
+	app.Use(Idempotency())
+	app.Post("/items", func(c *fiber.Ctx) error {
+		if *status >= fiber.StatusInternalServerError {
+			return c.Status(*status).JSON(fiber.Map{"error": "failed"})
+		}
+		created++
+		return c.Status(*status).JSON(fiber.Map{"id": created})
+	})
+	return app, &created
+}
+
+func postItem(t *testing.T, app *fiber.App, key, user, body string) (int, string, string) {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	req.Header.Set("X-User", user)
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(data), resp.Header.Get(IdempotencyReplayedHeader)
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	status := fiber.StatusCreated
+	app, created := newIdempotencyApp(t, newMemoryIdempotencyStore(), &status)
+
+	firstStatus, firstBody, firstReplayed := postItem(t, app, "key-1", "user-1", `{"name":"a"}`)
+	secondStatus, secondBody, secondReplayed := postItem(t, app, "key-1", "user-1", `{"name":"a"}`)
+
+	if firstStatus != fiber.StatusCreated || secondStatus != fiber.StatusCreated {
+		t.Fatalf("statuses = %d, %d; want 201, 201", firstStatus, secondStatus)
+	}
+	if firstBody != secondBody {
+		t.Errorf("replayed body = %s, want %s", secondBody, firstBody)
+	}
+	if firstReplayed != "" || secondReplayed != "true" {
+		t.Errorf("%s headers = %q, %q; want \"\", \"true\"", IdempotencyReplayedHeader, firstReplayed, secondReplayed)
+	}
+	if *created != 1 {
+		t.Errorf("handler created %d items, want 1", *created)
+	}
+}
+
+func TestIdempotencyKeysAreScopedPerUser(t *testing.T) {
+	status := fiber.StatusCreated
+	app, created := newIdempotencyApp(t, newMemoryIdempotencyStore(), &status)
+
+	_, first, _ := postItem(t, app, "key-1", "user-1", `{}`)
+	_, second, replayed := postItem(t, app, "key-1", "user-2", `{}`)
+
+	if first == second || replayed != "" {
+		t.Errorf("second user got replayed response %s", second)
+	}
+	if *created != 2 {
+		t.Errorf("handler created %d items, want 2", *created)
+	}
+}
+
+func TestIdempotencyRejectsDifferentRequest(t *testing.T) {
+	status := fiber.StatusCreated
+	app, created := newIdempotencyApp(t, newMemoryIdempotencyStore(), &status)
+
+	postItem(t, app, "key-1", "user-1", `{"name":"a"}`)
+	got, _, _ := postItem(t, app, "key-1", "user-1", `{"name":"b"}`)
+
+	if got != fiber.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want %d", got, fiber.StatusUnprocessableEntity)
+	}
+	if *created != 1 {
+		t.Errorf("handler created %d items, want 1", *created)
+	}
+}
+
+func TestIdempotencyRejectsInProgressKey(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	store.Reserve("key-1", "user-1", hashForTest(t, `{}`))
+
+	status := fiber.StatusCreated
+	app, created := newIdempotencyApp(t, store, &status)
+
+	if got, _, _ := postItem(t, app, "key-1", "user-1", `{}`); got != fiber.StatusConflict {
+		t.Errorf("status = %d, want %d", got, fiber.StatusConflict)
+	}
+	if *created != 0 {
+		t.Errorf("handler created %d items, want 0", *created)
+	}
+}
+
+func TestIdempotencyDoesNotStoreServerErrors(t *testing.T) {
+	status := fiber.StatusInternalServerError
+	app, created := newIdempotencyApp(t, newMemoryIdempotencyStore(), &status)
+
+	if got, _, _ := postItem(t, app, "key-1", "user-1", `{}`); got != fiber.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", got, fiber.StatusInternalServerError)
+	}
+
+	status = fiber.StatusCreated
+	got, _, replayed := postItem(t, app, "key-1", "user-1", `{}`)
+	if got != fiber.StatusCreated || replayed != "" {
+		t.Errorf("retry status = %d, replayed = %q; want 201 from the handler", got, replayed)
+	}
+	if *created != 1 {
+		t.Errorf("handler created %d items, want 1", *created)
+	}
+}
+
+func TestIdempotencyDoesNotStoreSkippedRoutes(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	status := fiber.StatusCreated
+	app, _ := newIdempotencyApp(t, store, &status)
+
+	issued := 0
+	app.Post("/tokens", SkipIdempotency(), func(c *fiber.Ctx) error {
+		issued++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"token": issued})
+	})
+
+	for range 2 {
+		req := httptest.NewRequest("POST", "/tokens", strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		req.Header.Set("X-User", "user-1")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if replayed := resp.Header.Get(IdempotencyReplayedHeader); replayed != "" {
+			t.Errorf("%s = %q, want the response from the handler", IdempotencyReplayedHeader, replayed)
+		}
+	}
+	if issued != 2 {
+		t.Errorf("handler issued %d tokens, want 2", issued)
+	}
+	if len(store.entries) != 0 {
+		t.Errorf("store kept %d entries, want 0", len(store.entries))
+	}
+}
+
+func TestIdempotencyPassesThroughWithoutKey(t *testing.T) {
+	status := fiber.StatusCreated
+	app, created := newIdempotencyApp(t, newMemoryIdempotencyStore(), &status)
+
+	postItem(t, app, "", "user-1", `{}`)
+	postItem(t, app, "", "user-1", `{}`)
+
+	if *created != 2 {
+		t.Errorf("handler created %d items, want 2", *created)
+	}
+}
+
+func TestIdempotencyRejectsLongKey(t *testing.T) {
+	status := fiber.StatusCreated
+	app, _ := newIdempotencyApp(t, newMemoryIdempotencyStore(), &status)
+
+	key := strings.Repeat("k", maxIdempotencyKeyLength+1)
+	if got, _, _ := postItem(t, app, key, "user-1", `{}`); got != fiber.StatusBadRequest {
+		t.Errorf("status = %d, want %d", got, fiber.StatusBadRequest)
+	}
+}
+
+func TestIdempotencyFailsOpen(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	store.fail = true
+
+	status := fiber.StatusCreated
+	app, created := newIdempotencyApp(t, store, &status)
+
+	if got, _, _ := postItem(t, app, "key-1", "user-1", `{}`); got != fiber.StatusCreated {
+		t.Errorf("status = %d, want %d", got, fiber.StatusCreated)
+	}
+	if *created != 1 {
+		t.Errorf("handler created %d items, want 1", *created)
+	}
+}
+
+// hashForTest returns the request hash of a POST /items with body
+func hashForTest(t *testing.T, body string) string {
+	t.Helper()
+	var hash string
+	app := fiber.New()
+	app.Post("/items", func(c *fiber.Ctx) error {
+		hash = hashIdempotentRequest(c)
+		return nil
+	})
+	if _, err := app.Test(httptest.NewRequest("POST", "/items", strings.NewReader(body))); err != nil {
+		t.Fatal(err)
+	}
+	if hash == "" {
+		t.Fatal("request hash was not computed")
+	}
+	return hash
+}
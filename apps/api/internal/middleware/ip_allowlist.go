@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LoadIPAllowlist returns the CIDR ranges configured in ADMIN_IP_ALLOWLIST, a
+// comma-separated list such as "10.0.0.0/8,192.168.1.0/24"
+func LoadIPAllowlist() []string {
+	var cidrs []string
+	for _, entry := range strings.Split(helpers.GetEnv("ADMIN_IP_ALLOWLIST", ""), ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			cidrs = append(cidrs, entry)
+		}
+	}
+	return cidrs
+}
+
+// ParseIPAllowlist parses CIDR ranges. A bare address is treated as a range
+// containing only that address.
+func ParseIPAllowlist(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if !strings.Contains(cidr, "/") {
+			addr, err := netip.ParseAddr(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid IP allowlist entry %q: %w", cidr, err)
+			}
+			addr = addr.Unmap()
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP allowlist entry %q: %w", cidr, err)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// RequireIPAllowlist rejects requests whose client IP is outside every range
+// in allowedCIDRs. An empty list allows all requests. It panics on an invalid
+// range so a misconfigured allowlist fails at startup instead of silently
+// leaving routes open.
+func RequireIPAllowlist(allowedCIDRs []string) fiber.Handler {
+	prefixes, err := ParseIPAllowlist(allowedCIDRs)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(prefixes) == 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	return func(c *fiber.Ctx) error {
+		addr, err := netip.ParseAddr(c.IP())
+		if err == nil {
+			addr = addr.Unmap()
+			for _, prefix := range prefixes {
+				if prefix.Contains(addr) {
+					return c.Next()
+				}
+			}
+		}
+
+		return helpers.ForbiddenResponse(c, "Access denied from this IP address", apperrors.ErrIPNotAllowed)
+	}
+}
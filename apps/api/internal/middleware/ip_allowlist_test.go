@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"net"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// requestFrom serves a GET request to handler as if it came from ip
+func requestFrom(handler fasthttp.RequestHandler, ip string) int {
+	var req fasthttp.Request
+	req.SetRequestURI("/")
+
+	var ctx fasthttp.RequestCtx
+	ctx.Init(&req, &net.TCPAddr{IP: net.ParseIP(ip), Port: 40000}, nil)
+	handler(&ctx)
+	return ctx.Response.StatusCode()
+}
+
+func newIPAllowlistApp(cidrs []string) fasthttp.RequestHandler {
+	app := fiber.New()
+	app.Get("/", RequireIPAllowlist(cidrs), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app.Handler()
+}
+
+func TestRequireIPAllowlist(t *testing.T) {
+	handler := newIPAllowlistApp([]string{"127.0.0.0/8", "10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32"})
+
+	tests := []struct {
+		ip   string
+		want int
+	}{
+		{"127.0.0.1", fiber.StatusOK},
+		{"10.20.30.40", fiber.StatusOK},
+		{"192.168.1.200", fiber.StatusOK},
+		{"2001:db8::1", fiber.StatusOK},
+		{"192.168.2.1", fiber.StatusForbidden},
+		{"203.0.113.7", fiber.StatusForbidden},
+		{"::1", fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		if got := requestFrom(handler, tt.ip); got != tt.want {
+			t.Errorf("request from %s: status = %d, want %d", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestRequireIPAllowlistBareAddress(t *testing.T) {
+	handler := newIPAllowlistApp([]string{"127.0.0.1", "::1"})
+
+	if got := requestFrom(handler, "127.0.0.1"); got != fiber.StatusOK {
+		t.Errorf("IPv4 loopback: status = %d, want %d", got, fiber.StatusOK)
+	}
+	if got := requestFrom(handler, "::1"); got != fiber.StatusOK {
+		t.Errorf("IPv6 loopback: status = %d, want %d", got, fiber.StatusOK)
+	}
+	if got := requestFrom(handler, "127.0.0.2"); got != fiber.StatusForbidden {
+		t.Errorf("127.0.0.2: status = %d, want %d", got, fiber.StatusForbidden)
+	}
+}
+
+func TestRequireIPAllowlistEmptyIsPassthrough(t *testing.T) {
+	handler := newIPAllowlistApp(nil)
+
+	for _, ip := range []string{"127.0.0.1", "203.0.113.7"} {
+		if got := requestFrom(handler, ip); got != fiber.StatusOK {
+			t.Errorf("request from %s: status = %d, want %d", ip, got, fiber.StatusOK)
+		}
+	}
+}
+
+func TestRequireIPAllowlistInvalidRangePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for invalid CIDR")
+		}
+	}()
+	RequireIPAllowlist([]string{"10.0.0.0/33"})
+}
+
+func TestLoadIPAllowlist(t *testing.T) {
+	t.Setenv("ADMIN_IP_ALLOWLIST", " 10.0.0.0/8, ,192.168.1.0/24 ")
+
+	got := LoadIPAllowlist()
+	if len(got) != 2 || got[0] != "10.0.0.0/8" || got[1] != "192.168.1.0/24" {
+		t.Errorf("LoadIPAllowlist() = %v, want [10.0.0.0/8 192.168.1.0/24]", got)
+	}
+
+	t.Setenv("ADMIN_IP_ALLOWLIST", "")
+	if got := LoadIPAllowlist(); len(got) != 0 {
+		t.Errorf("LoadIPAllowlist() = %v, want empty", got)
+	}
+}
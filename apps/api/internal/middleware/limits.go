@@ -0,0 +1,21 @@
+package middleware
+
+// Maximum request body sizes in bytes, enforced per route group or endpoint
+// with BodySizeLimit
+const (
+	// DefaultBodyLimit applies to the protected and admin routes without a
+	// limit of their own
+	DefaultBodyLimit = 1 << 20
+	// AuthBodyLimit applies to the credential endpoints under /auth
+	AuthBodyLimit = 1 << 20
+	// AvatarBodyLimit applies to avatar uploads, leaving room for the
+	// multipart encoding around a 5 MB image
+	AvatarBodyLimit = 6 << 20
+	// EmailWebhookBodyLimit applies to delivery reports from the email provider
+	EmailWebhookBodyLimit = 1 << 20
+	// EmailTemplateBodyLimit applies to creating and updating email templates
+	EmailTemplateBodyLimit = 10 << 20
+	// ImportBodyLimit applies to bulk user imports and is the largest limit;
+	// the server-wide body limit must be at least this large
+	ImportBodyLimit = 50 << 20
+)
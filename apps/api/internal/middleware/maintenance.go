@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaintenanceBypassHeader carries the token that lets ops tools through while
+// maintenance mode is active
+const MaintenanceBypassHeader = "X-Maintenance-Bypass"
+
+// maintenanceRetryAfter is how long clients are told to wait, in seconds
+const maintenanceRetryAfter = 300
+
+// maintenanceTogglePath is the admin route that turns maintenance mode off,
+// relative to the API prefix. It stays reachable during maintenance; the
+// route itself still requires an admin.
+const maintenanceTogglePath = "/admin/maintenance"
+
+var maintenanceEnabled atomic.Bool
+
+// SetMaintenanceMode turns maintenance mode on or off for the running process
+func SetMaintenanceMode(enabled bool) {
+	maintenanceEnabled.Store(enabled)
+}
+
+// MaintenanceModeEnabled reports whether maintenance mode is active
+func MaintenanceModeEnabled() bool {
+	return maintenanceEnabled.Load()
+}
+
+// MaintenanceMode responds with 503 to every request while maintenance mode is
+// active, unless the request carries MAINTENANCE_BYPASS_TOKEN in the
+// X-Maintenance-Bypass header or toggles maintenance mode with
+// PUT /admin/maintenance. Creating the middleware sets the mode from
+// MAINTENANCE_MODE; afterwards it is toggled at runtime with SetMaintenanceMode.
+func MaintenanceMode() fiber.Handler {
+	maintenanceEnabled.Store(helpers.GetEnvBool("MAINTENANCE_MODE", false))
+	bypassToken := []byte(helpers.GetEnv("MAINTENANCE_BYPASS_TOKEN", ""))
+
+	return func(c *fiber.Ctx) error {
+		if !maintenanceEnabled.Load() || isMaintenanceToggle(c) {
+			return c.Next()
+		}
+
+		if len(bypassToken) > 0 {
+			provided := []byte(c.Get(MaintenanceBypassHeader))
+			if subtle.ConstantTimeCompare(provided, bypassToken) == 1 {
+				return c.Next()
+			}
+		}
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(maintenanceRetryAfter))
+		return helpers.ErrorDetailsResponse(c, fiber.StatusServiceUnavailable, apperrors.ErrMaintenanceMode, "service under maintenance", fiber.Map{
+			"retry_after": maintenanceRetryAfter,
+		})
+	}
+}
+
+// isMaintenanceToggle reports whether the request turns maintenance mode on
+// or off
+func isMaintenanceToggle(c *fiber.Ctx) bool {
+	return c.Method() == fiber.MethodPut && strings.HasSuffix(strings.TrimSuffix(c.Path(), "/"), maintenanceTogglePath)
+}
@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "false")
+	t.Setenv("MAINTENANCE_BYPASS_TOKEN", "ops-token")
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+
+	app := fiber.New()
+	app.Use(MaintenanceMode())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	get := func(token string) (int, string, string) {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/", nil)
+		if token != "" {
+			req.Header.Set(MaintenanceBypassHeader, token)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), resp.Header.Get(fiber.HeaderRetryAfter)
+	}
+
+	if status, _, _ := get(""); status != fiber.StatusOK {
+		t.Fatalf("maintenance off: status = %d, want %d", status, fiber.StatusOK)
+	}
+
+	SetMaintenanceMode(true)
+	status, body, retryAfter := get("")
+	if status != fiber.StatusServiceUnavailable {
+		t.Fatalf("maintenance on: status = %d, want %d", status, fiber.StatusServiceUnavailable)
+	}
+	if body != `{"error":"service under maintenance","code":"MAINTENANCE_MODE","details":{"retry_after":300}}` {
+		t.Errorf("maintenance on: body = %s", body)
+	}
+	if retryAfter != "300" {
+		t.Errorf("Retry-After = %q, want 300", retryAfter)
+	}
+
+	if status, _, _ := get("wrong-token"); status != fiber.StatusServiceUnavailable {
+		t.Errorf("wrong bypass token: status = %d, want %d", status, fiber.StatusServiceUnavailable)
+	}
+	if status, _, _ := get("ops-token"); status != fiber.StatusOK {
+		t.Errorf("bypass token: status = %d, want %d", status, fiber.StatusOK)
+	}
+
+	SetMaintenanceMode(false)
+	if status, _, _ := get(""); status != fiber.StatusOK {
+		t.Errorf("maintenance off again: status = %d, want %d", status, fiber.StatusOK)
+	}
+}
+
+func TestMaintenanceModeWithoutBypassToken(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "true")
+	t.Setenv("MAINTENANCE_BYPASS_TOKEN", "")
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+
+	app := fiber.New()
+	app.Use(MaintenanceMode())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// An empty token must not let requests without the header through
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(MaintenanceBypassHeader, "")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", resp.StatusCode, fiber.StatusServiceUnavailable)
+	}
+}
+
+func TestMaintenanceModeAllowsToggle(t *testing.T) {
+	t.Setenv("MAINTENANCE_MODE", "true")
+	t.Setenv("MAINTENANCE_BYPASS_TOKEN", "")
+	t.Cleanup(func() { SetMaintenanceMode(false) })
+
+	app := fiber.New()
+	app.Use(MaintenanceMode())
+	app.All("/api/v1/admin/maintenance", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	tests := []struct {
+		method string
+		want   int
+	}{
+		{fiber.MethodPut, fiber.StatusOK},
+		{fiber.MethodGet, fiber.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest(tt.method, "/api/v1/admin/maintenance", nil))
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if resp.StatusCode != tt.want {
+			t.Errorf("%s status = %d, want %d", tt.method, resp.StatusCode, tt.want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// QueryCountHeader reports how many SQL statements a request ran
+const QueryCountHeader = "X-Query-Count"
+
+// recentQueryCountsSize is how many requests RecentQueryCounts keeps
+const recentQueryCountsSize = 50
+
+// RequestQueryCount is the number of SQL statements one request ran
+type RequestQueryCount struct {
+	Method  string
+	Path    string
+	Status  int
+	Queries int64
+	At      time.Time
+}
+
+var recentQueryCounts struct {
+	sync.Mutex
+	entries []RequestQueryCount
+	next    int
+}
+
+// QueryCounterEnabled reports whether QueryCounter counts statements, which it
+// only does when ENV is development
+func QueryCounterEnabled() bool {
+	return helpers.GetEnv("ENV", "development") == "development"
+}
+
+// QueryCounter counts the SQL statements each request runs, to catch N+1
+// queries during development. The count is returned in the X-Query-Count
+// header and a warning is logged when it exceeds QUERY_WARN_THRESHOLD.
+// Statements are counted through a counter in c.UserContext(), so only
+// queries run with the request's context are counted; outside development
+// the middleware does nothing.
+func QueryCounter() fiber.Handler {
+	if !QueryCounterEnabled() {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	threshold := int64(helpers.GetEnvInt("QUERY_WARN_THRESHOLD", 10))
+
+	return func(c *fiber.Ctx) error {
+		parent := c.UserContext()
+		ctx := database.WithQueryCounter(parent)
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		c.SetUserContext(parent)
+		queries := database.QueriesRun(ctx)
+
+		c.Set(QueryCountHeader, strconv.FormatInt(queries, 10))
+		recordQueryCount(RequestQueryCount{
+			Method:  c.Method(),
+			Path:    c.Path(),
+			Status:  responseStatus(c, err),
+			Queries: queries,
+			At:      time.Now(),
+		})
+
+		if queries > threshold {
+			logger.Warn("Request exceeded query threshold",
+				"method", c.Method(),
+				"path", c.Path(),
+				"queries", queries,
+				"threshold", threshold,
+			)
+		}
+		return err
+	}
+}
+
+func recordQueryCount(entry RequestQueryCount) {
+	recentQueryCounts.Lock()
+	defer recentQueryCounts.Unlock()
+
+	if len(recentQueryCounts.entries) < recentQueryCountsSize {
+		recentQueryCounts.entries = append(recentQueryCounts.entries, entry)
+		return
+	}
+	recentQueryCounts.entries[recentQueryCounts.next] = entry
+	recentQueryCounts.next = (recentQueryCounts.next + 1) % recentQueryCountsSize
+}
+
+// RecentQueryCounts returns the last 50 requests counted by QueryCounter,
+// most statements first
+func RecentQueryCounts() []RequestQueryCount {
+	recentQueryCounts.Lock()
+	entries := slices.Clone(recentQueryCounts.entries)
+	recentQueryCounts.Unlock()
+
+	slices.SortStableFunc(entries, func(a, b RequestQueryCount) int {
+		if n := cmp.Compare(b.Queries, a.Queries); n != 0 {
+			return n
+		}
+		return b.At.Compare(a.At)
+	})
+	return entries
+}
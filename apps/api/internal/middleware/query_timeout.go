@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultQueryTimeout is the request deadline when QUERY_TIMEOUT_MS is unset
+const DefaultQueryTimeout = 5 * time.Second
+
+// LoadQueryTimeout returns the request deadline from QUERY_TIMEOUT_MS
+func LoadQueryTimeout() time.Duration {
+	return time.Duration(helpers.GetEnvInt("QUERY_TIMEOUT_MS", int(DefaultQueryTimeout/time.Millisecond))) * time.Millisecond
+}
+
+// QueryTimeout gives each request a deadline so slow queries cannot hold on to
+// pooled connections. The deadline is set on c.UserContext(); queries run
+// with that context are cancelled when it passes. A request that fails after its deadline passed gets 503 with the
+// REQUEST_TIMEOUT code. A timeout of zero or less disables the deadline.
+func QueryTimeout(timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if timeout <= 0 {
+			return c.Next()
+		}
+
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		parent := c.UserContext()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+		c.SetUserContext(parent)
+
+		timedOut := errors.Is(err, context.DeadlineExceeded) ||
+			(errors.Is(ctx.Err(), context.DeadlineExceeded) && (err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError))
+		if timedOut {
+			return helpers.ErrorResponse(c, fiber.StatusServiceUnavailable, "request timed out", apperrors.ErrRequestTimeout)
+		}
+		return err
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newQueryTimeoutApp(timeout time.Duration, handler fiber.Handler) *fiber.App {
+	app := fiber.New()
+	app.Get("/", QueryTimeout(timeout), handler)
+	return app
+}
+
+func TestQueryTimeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		timeout    time.Duration
+		handler    fiber.Handler
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:    "fast request",
+			timeout: time.Second,
+			handler: func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:    "returned deadline error",
+			timeout: 10 * time.Millisecond,
+			handler: func(c *fiber.Ctx) error {
+				<-c.UserContext().Done()
+				return c.UserContext().Err()
+			},
+			wantStatus: fiber.StatusServiceUnavailable,
+			wantCode:   apperrors.ErrRequestTimeout,
+		},
+		{
+			name:    "error response after the deadline",
+			timeout: 10 * time.Millisecond,
+			handler: func(c *fiber.Ctx) error {
+				<-c.UserContext().Done()
+				return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+			},
+			wantStatus: fiber.StatusServiceUnavailable,
+			wantCode:   apperrors.ErrRequestTimeout,
+		},
+		{
+			name:    "error response before the deadline",
+			timeout: time.Second,
+			handler: func(c *fiber.Ctx) error {
+				return helpers.InternalServerErrorResponse(c, "Failed to fetch users")
+			},
+			wantStatus: fiber.StatusInternalServerError,
+			wantCode:   apperrors.ErrInternal,
+		},
+		{
+			name:    "disabled",
+			timeout: 0,
+			handler: func(c *fiber.Ctx) error {
+				if _, ok := c.UserContext().Deadline(); ok {
+					return c.SendStatus(fiber.StatusInternalServerError)
+				}
+				return c.SendStatus(fiber.StatusOK)
+			},
+			wantStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newQueryTimeoutApp(tt.timeout, tt.handler)
+
+			resp, err := app.Test(httptest.NewRequest("GET", "/", nil), -1)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantCode == "" {
+				return
+			}
+
+			var envelope helpers.ErrorEnvelope
+			if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if envelope.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", envelope.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestLoadQueryTimeout(t *testing.T) {
+	t.Setenv("QUERY_TIMEOUT_MS", "")
+	if got := LoadQueryTimeout(); got != DefaultQueryTimeout {
+		t.Errorf("default = %v, want %v", got, DefaultQueryTimeout)
+	}
+
+	t.Setenv("QUERY_TIMEOUT_MS", "250")
+	if got := LoadQueryTimeout(); got != 250*time.Millisecond {
+		t.Errorf("QUERY_TIMEOUT_MS=250: got %v, want 250ms", got)
+	}
+}
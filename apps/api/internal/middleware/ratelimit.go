@@ -0,0 +1,220 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// RateLimitConfig configures a rate limiter instance
+type RateLimitConfig struct {
+	// Requests is the number of requests allowed per Window; zero or less disables limiting
+	Requests int
+	Window   time.Duration
+	// KeyGenerator identifies the client being limited, defaulting to the client IP
+	KeyGenerator func(c *fiber.Ctx) string
+}
+
+// rateLimitStore records hits in a sliding window and reports whether the
+// current request is allowed, how many requests remain and when the window resets
+type rateLimitStore interface {
+	Hit(key string, limit int, window time.Duration) (allowed bool, remaining int, reset time.Time, err error)
+}
+
+var rateLimiterCount uint64
+
+// RateLimit limits each client IP to requests per sliding window
+func RateLimit(requests int, window time.Duration) fiber.Handler {
+	return RateLimitWithConfig(RateLimitConfig{
+		Requests: requests,
+		Window:   window,
+	})
+}
+
+// RateLimitWithConfig creates a sliding-window rate limiter. Hits are kept in
+// memory unless RATE_LIMIT_BACKEND=redis, in which case they are shared
+// between API instances.
+func RateLimitWithConfig(config RateLimitConfig) fiber.Handler {
+	if config.Requests <= 0 || config.Window <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	if config.KeyGenerator == nil {
+		config.KeyGenerator = func(c *fiber.Ctx) string {
+			return c.IP()
+		}
+	}
+
+	// Each limiter gets its own namespace. Routes are registered in the same
+	// order on every instance, so the IDs line up when Redis is shared.
+	prefix := fmt.Sprintf("ratelimit:%d:", atomic.AddUint64(&rateLimiterCount, 1))
+	store := newRateLimitStore()
+
+	return func(c *fiber.Ctx) error {
+		allowed, remaining, reset, err := store.Hit(prefix+config.KeyGenerator(c), config.Requests, config.Window)
+		if err != nil {
+			// Fail open so a cache outage does not take the API down with it
+			logger.Warn("Rate limit check failed", "error", err)
+			return c.Next()
+		}
+
+		resetSeconds := int(math.Ceil(time.Until(reset).Seconds()))
+		if resetSeconds < 0 {
+			resetSeconds = 0
+		}
+
+		c.Set("X-RateLimit-Limit", strconv.Itoa(config.Requests))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		c.Set("X-RateLimit-Reset", strconv.Itoa(resetSeconds))
+
+		if !allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(resetSeconds))
+			return helpers.TooManyRequestsResponse(c, "Too many requests, please try again later")
+		}
+
+		return c.Next()
+	}
+}
+
+func newRateLimitStore() rateLimitStore {
+	if strings.ToLower(helpers.GetEnv("RATE_LIMIT_BACKEND", "memory")) == "redis" {
+		if database.Redis == nil {
+			if err := database.ConnectRedis(); err != nil {
+				logger.Warn("Failed to connect to redis, falling back to in-memory rate limiting", "error", err)
+				return newMemoryRateLimitStore()
+			}
+		}
+		return &redisRateLimitStore{client: database.Redis}
+	}
+	return newMemoryRateLimitStore()
+}
+
+type rateLimitEntry struct {
+	mu   sync.Mutex
+	hits []time.Time
+}
+
+// memoryRateLimitStore keeps a log of hit timestamps per key
+type memoryRateLimitStore struct {
+	entries   sync.Map
+	lastSweep atomic.Int64
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	store := &memoryRateLimitStore{}
+	store.lastSweep.Store(time.Now().UnixNano())
+	return store
+}
+
+func (s *memoryRateLimitStore) Hit(key string, limit int, window time.Duration) (bool, int, time.Time, error) {
+	now := time.Now()
+	s.sweep(now, window)
+
+	value, _ := s.entries.LoadOrStore(key, &rateLimitEntry{})
+	entry := value.(*rateLimitEntry)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	entry.hits = pruneHits(entry.hits, now.Add(-window))
+
+	if len(entry.hits) >= limit {
+		return false, 0, entry.hits[0].Add(window), nil
+	}
+
+	entry.hits = append(entry.hits, now)
+	return true, limit - len(entry.hits), entry.hits[0].Add(window), nil
+}
+
+// sweep drops keys that have been idle for a whole window, at most once per window
+func (s *memoryRateLimitStore) sweep(now time.Time, window time.Duration) {
+	last := s.lastSweep.Load()
+	if now.UnixNano()-last < int64(window) || !s.lastSweep.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	cutoff := now.Add(-window)
+	s.entries.Range(func(key, value any) bool {
+		entry := value.(*rateLimitEntry)
+		entry.mu.Lock()
+		entry.hits = pruneHits(entry.hits, cutoff)
+		if len(entry.hits) == 0 {
+			s.entries.Delete(key)
+		}
+		entry.mu.Unlock()
+		return true
+	})
+}
+
+// pruneHits removes hits at or before cutoff from the sorted hit log
+func pruneHits(hits []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(hits) && !hits[i].After(cutoff) {
+		i++
+	}
+	return hits[i:]
+}
+
+// slidingWindowScript atomically trims the window, records the hit if the
+// limit has not been reached and returns {allowed, count, reset_ms}
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, window)
+
+local reset = now + window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window
+end
+
+return {allowed, count, reset}
+`)
+
+// redisRateLimitStore keeps the hit log in a sorted set per key
+type redisRateLimitStore struct {
+	client *redis.Client
+}
+
+func (s *redisRateLimitStore) Hit(key string, limit int, window time.Duration) (bool, int, time.Time, error) {
+	now := time.Now().UnixMilli()
+	result, err := slidingWindowScript.Run(context.Background(), s.client, []string{key},
+		now, window.Milliseconds(), limit, fmt.Sprintf("%d-%s", now, uuid.NewString()),
+	).Int64Slice()
+	if err != nil {
+		return false, 0, time.Time{}, err
+	}
+
+	allowed := result[0] == 1
+	remaining := limit - int(result[1])
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return allowed, remaining, time.UnixMilli(result[2]), nil
+}
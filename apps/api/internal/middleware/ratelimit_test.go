@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func newRateLimitedApp(requests int, window time.Duration) *fiber.App {
+	app := fiber.New()
+	app.Get("/", RateLimit(requests, window), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	return app
+}
+
+func TestRateLimit(t *testing.T) {
+	app := newRateLimitedApp(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i, resp.StatusCode, fiber.StatusOK)
+		}
+		if got := resp.Header.Get("X-RateLimit-Limit"); got != "3" {
+			t.Errorf("X-RateLimit-Limit = %q, want 3", got)
+		}
+		if got := resp.Header.Get("X-RateLimit-Remaining"); got != strconv.Itoa(2-i) {
+			t.Errorf("request %d: X-RateLimit-Remaining = %q, want %d", i, got, 2-i)
+		}
+		if resp.Header.Get("X-RateLimit-Reset") == "" {
+			t.Error("X-RateLimit-Reset header missing")
+		}
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusTooManyRequests)
+	}
+	retryAfter, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || retryAfter <= 0 || retryAfter > 60 {
+		t.Errorf("Retry-After = %q, want between 1 and 60 seconds", resp.Header.Get("Retry-After"))
+	}
+}
+
+func TestRateLimitWindowSlides(t *testing.T) {
+	app := newRateLimitedApp(1, 50*time.Millisecond)
+
+	resp, _ := app.Test(httptest.NewRequest("GET", "/", nil))
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("first request: status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/", nil))
+	if resp.StatusCode != fiber.StatusTooManyRequests {
+		t.Fatalf("second request: status = %d, want %d", resp.StatusCode, fiber.StatusTooManyRequests)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	resp, _ = app.Test(httptest.NewRequest("GET", "/", nil))
+	if resp.StatusCode != fiber.StatusOK {
+		t.Errorf("request after window: status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+}
+
+func TestRateLimitPerKey(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", RateLimitWithConfig(RateLimitConfig{
+		Requests: 1,
+		Window:   time.Minute,
+		KeyGenerator: func(c *fiber.Ctx) string {
+			return c.Get("X-Client")
+		},
+	}), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	for _, client := range []string{"a", "b"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("X-Client", client)
+		resp, _ := app.Test(req)
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("client %s: status = %d, want %d", client, resp.StatusCode, fiber.StatusOK)
+		}
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	app := newRateLimitedApp(0, time.Minute)
+
+	for i := 0; i < 10; i++ {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/", nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i, resp.StatusCode, fiber.StatusOK)
+		}
+		if resp.Header.Get("X-RateLimit-Limit") != "" {
+			t.Fatal("disabled limiter should not set rate limit headers")
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	apperrors "api/internal/errors"
 	"api/internal/helpers"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,7 +12,7 @@ func RequireRole(role string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		roles := GetUserRoles(c)
 		if roles == nil {
-			return helpers.ForbiddenResponse(c, "Access denied: no roles found")
+			return helpers.ForbiddenResponse(c, "Access denied: no roles found", apperrors.ErrPermissionDenied)
 		}
 
 		for _, userRole := range roles {
@@ -20,7 +21,7 @@ func RequireRole(role string) fiber.Handler {
 			}
 		}
 
-		return helpers.ForbiddenResponse(c, "Access denied: insufficient permissions")
+		return helpers.ForbiddenResponse(c, "Access denied: insufficient permissions", apperrors.ErrPermissionDenied)
 	}
 }
 
@@ -29,7 +30,7 @@ func RequireAnyRole(requiredRoles []string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		roles := GetUserRoles(c)
 		if roles == nil {
-			return helpers.ForbiddenResponse(c, "Access denied: no roles found")
+			return helpers.ForbiddenResponse(c, "Access denied: no roles found", apperrors.ErrPermissionDenied)
 		}
 
 		for _, userRole := range roles {
@@ -40,7 +41,7 @@ func RequireAnyRole(requiredRoles []string) fiber.Handler {
 			}
 		}
 
-		return helpers.ForbiddenResponse(c, "Access denied: insufficient permissions")
+		return helpers.ForbiddenResponse(c, "Access denied: insufficient permissions", apperrors.ErrPermissionDenied)
 	}
 }
 
@@ -49,7 +50,7 @@ func RequireAllRoles(requiredRoles []string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		roles := GetUserRoles(c)
 		if roles == nil {
-			return helpers.ForbiddenResponse(c, "Access denied: no roles found")
+			return helpers.ForbiddenResponse(c, "Access denied: no roles found", apperrors.ErrPermissionDenied)
 		}
 
 		roleMap := make(map[string]bool)
@@ -59,7 +60,7 @@ func RequireAllRoles(requiredRoles []string) fiber.Handler {
 
 		for _, requiredRole := range requiredRoles {
 			if !roleMap[requiredRole] {
-				return helpers.ForbiddenResponse(c, "Access denied: insufficient permissions")
+				return helpers.ForbiddenResponse(c, "Access denied: insufficient permissions", apperrors.ErrPermissionDenied)
 			}
 		}
 
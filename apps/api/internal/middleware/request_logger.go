@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"api/internal/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxLoggedBodySize caps the request and response bodies included in logs
+const maxLoggedBodySize = 2048
+
+// redactedBody replaces bodies of requests to sensitive paths
+const redactedBody = "[REDACTED]"
+
+// streamedBody replaces streamed response bodies, which are not logged
+const streamedBody = "[STREAMED]"
+
+// sensitivePaths carry credentials or tokens in their request or response
+// bodies. A ":id" segment matches any segment, and paths below a sensitive
+// path are sensitive too.
+var sensitivePaths = []string{
+	"/auth/login",
+	"/auth/register",
+	"/auth/reset-password",
+	"/auth/accept-invitation",
+	"/auth/verify-email",
+	"/protected/change-password",
+	"/protected/api-keys",
+	"/admin/users/:id/impersonate",
+	"/admin/users/:id/reset-password",
+}
+
+// RequestLogger writes one structured log entry per request. Request and
+// response bodies are only included at debug level, and are redacted entirely
+// for sensitive paths.
+func RequestLogger() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		latency := time.Since(start)
+
+		status := responseStatus(c, err)
+
+		attrs := []slog.Attr{
+			slog.String("request_id", requestID(c)),
+			slog.String("method", c.Method()),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Duration("latency", latency),
+			slog.Int("response_size", responseSize(c)),
+			slog.String("ip", c.IP()),
+			slog.String("user_agent", c.Get(fiber.HeaderUserAgent)),
+		}
+		if userID := GetUserID(c); userID != "" {
+			attrs = append(attrs, slog.String("user_id", userID))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		ctx := context.Background()
+		if logger.Logger.Enabled(ctx, slog.LevelDebug) {
+			if isSensitivePath(c.Path()) {
+				attrs = append(attrs,
+					slog.String("request_body", redactedBody),
+					slog.String("response_body", redactedBody))
+			} else {
+				attrs = append(attrs,
+					slog.String("request_body", truncateBody(c.Body())),
+					slog.String("response_body", responseBody(c)))
+			}
+		}
+
+		logger.Logger.LogAttrs(ctx, level, "HTTP request", attrs...)
+		return err
+	}
+}
+
+// responseSize returns the length of the response body. Streamed bodies are
+// not read, as that would buffer them; their size is the Content-Length
+// header, or -1 when they are sent chunked.
+func responseSize(c *fiber.Ctx) int {
+	if c.Response().IsBodyStream() {
+		return c.Response().Header.ContentLength()
+	}
+	return len(c.Response().Body())
+}
+
+// responseBody returns the truncated response body for logging, leaving
+// streamed bodies unread
+func responseBody(c *fiber.Ctx) string {
+	if c.Response().IsBodyStream() {
+		return streamedBody
+	}
+	return truncateBody(c.Response().Body())
+}
+
+// responseStatus returns the status code a request is answered with, including
+// when the handler's error has not been written by the error handler yet
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+func requestID(c *fiber.Ctx) string {
+	if id, ok := c.Locals("requestid").(string); ok {
+		return id
+	}
+	return c.GetRespHeader(fiber.HeaderXRequestID)
+}
+
+func isSensitivePath(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, sensitive := range sensitivePaths {
+		pattern := strings.Split(strings.Trim(sensitive, "/"), "/")
+		for start := 0; start+len(pattern) <= len(segments); start++ {
+			if matchesSegments(segments[start:start+len(pattern)], pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesSegments reports whether path segments match pattern segments, where
+// a pattern segment starting with ":" matches any segment
+func matchesSegments(segments, pattern []string) bool {
+	for i, p := range pattern {
+		if !strings.HasPrefix(p, ":") && segments[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func truncateBody(body []byte) string {
+	if len(body) > maxLoggedBodySize {
+		return string(body[:maxLoggedBodySize]) + "...(truncated)"
+	}
+	return string(body)
+}
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/logger"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	previous := logger.Logger
+	logger.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	t.Cleanup(func() { logger.Logger = previous })
+	return &buf
+}
+
+func lastLogEntry(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var entry map[string]any
+	scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+	for scanner.Scan() {
+		entry = nil
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("log line is not JSON: %v", err)
+		}
+	}
+	if entry == nil {
+		t.Fatal("no log entry written")
+	}
+	return entry
+}
+
+func newRequestLoggerApp() *fiber.App {
+	app := fiber.New()
+	app.Use(requestid.New())
+	app.Use(RequestLogger())
+	app.Post("/api/v1/auth/login", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"token": "secret-token"})
+	})
+	app.Post("/api/v1/echo", func(c *fiber.Ctx) error {
+		c.Locals("userID", "user-123")
+		return c.Status(fiber.StatusCreated).Send(c.Body())
+	})
+	app.Get("/api/v1/fail", func(c *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "missing")
+	})
+	app.Get("/api/v1/stream", func(c *fiber.Ctx) error {
+		c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+			w.WriteString("streamed-row\n")
+		})
+		return nil
+	})
+	return app
+}
+
+func TestRequestLoggerFields(t *testing.T) {
+	buf := captureLogs(t)
+	app := newRequestLoggerApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/echo", strings.NewReader(`{"name":"test"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "logger-test")
+	resp, err := app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	entry := lastLogEntry(t, buf)
+	if entry["level"] != "INFO" {
+		t.Errorf("level = %v, want INFO", entry["level"])
+	}
+	if entry["request_id"] != resp.Header.Get(fiber.HeaderXRequestID) || entry["request_id"] == "" {
+		t.Errorf("request_id = %v, want %s", entry["request_id"], resp.Header.Get(fiber.HeaderXRequestID))
+	}
+	expected := map[string]any{
+		"method":        "POST",
+		"path":          "/api/v1/echo",
+		"status":        float64(fiber.StatusCreated),
+		"response_size": float64(len(`{"name":"test"}`)),
+		"user_agent":    "logger-test",
+		"user_id":       "user-123",
+		"request_body":  `{"name":"test"}`,
+		"response_body": `{"name":"test"}`,
+	}
+	for key, want := range expected {
+		if entry[key] != want {
+			t.Errorf("%s = %v, want %v", key, entry[key], want)
+		}
+	}
+	for _, key := range []string{"latency", "ip"} {
+		if _, ok := entry[key]; !ok {
+			t.Errorf("missing %s field", key)
+		}
+	}
+}
+
+func TestRequestLoggerError(t *testing.T) {
+	buf := captureLogs(t)
+	app := newRequestLoggerApp()
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/api/v1/fail", nil), -1); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	entry := lastLogEntry(t, buf)
+	if entry["level"] != "WARN" {
+		t.Errorf("level = %v, want WARN", entry["level"])
+	}
+	if entry["status"] != float64(fiber.StatusNotFound) {
+		t.Errorf("status = %v, want %d", entry["status"], fiber.StatusNotFound)
+	}
+	if entry["error"] != "missing" {
+		t.Errorf("error = %v, want missing", entry["error"])
+	}
+	if _, ok := entry["user_id"]; ok {
+		t.Error("user_id logged for unauthenticated request")
+	}
+}
+
+func TestRequestLoggerRedactsSensitivePaths(t *testing.T) {
+	buf := captureLogs(t)
+	app := newRequestLoggerApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(`{"password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	if _, err := app.Test(req, -1); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	if strings.Contains(buf.String(), "hunter2") || strings.Contains(buf.String(), "secret-token") {
+		t.Fatalf("sensitive body leaked into logs: %s", buf.String())
+	}
+	entry := lastLogEntry(t, buf)
+	if entry["request_body"] != redactedBody || entry["response_body"] != redactedBody {
+		t.Errorf("bodies = %v / %v, want %s", entry["request_body"], entry["response_body"], redactedBody)
+	}
+}
+
+func TestRequestLoggerOmitsBodiesAboveDebug(t *testing.T) {
+	var buf bytes.Buffer
+	previous := logger.Logger
+	logger.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	t.Cleanup(func() { logger.Logger = previous })
+	app := newRequestLoggerApp()
+
+	req := httptest.NewRequest("POST", "/api/v1/echo", strings.NewReader(`{"name":"test"}`))
+	if _, err := app.Test(req, -1); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	entry := lastLogEntry(t, &buf)
+	if _, ok := entry["request_body"]; ok {
+		t.Error("request_body logged above debug level")
+	}
+}
+
+func TestRequestLoggerLeavesStreamedBodiesUnread(t *testing.T) {
+	buf := captureLogs(t)
+	app := newRequestLoggerApp()
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/stream", nil), -1)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "streamed-row\n" {
+		t.Errorf("body = %q, want the streamed row", body)
+	}
+
+	entry := lastLogEntry(t, buf)
+	if entry["response_body"] != streamedBody {
+		t.Errorf("response_body = %v, want %q", entry["response_body"], streamedBody)
+	}
+	if entry["response_size"] != float64(-1) {
+		t.Errorf("response_size = %v, want -1", entry["response_size"])
+	}
+}
+
+func TestIsSensitivePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/api/v1/auth/login", true},
+		{"/api/v1/auth/login/", true},
+		{"/api/v1/auth/verify-email", true},
+		{"/api/v1/protected/api-keys", true},
+		{"/api/v1/protected/api-keys/key-1", true},
+		{"/api/v1/admin/users/user-1/impersonate", true},
+		{"/api/v1/admin/users/user-1/reset-password", true},
+		{"/api/v1/admin/users/user-1", false},
+		{"/api/v1/admin/users/user-1/roles", false},
+		{"/api/v1/protected/profile", false},
+	}
+
+	for _, tt := range tests {
+		if got := isSensitivePath(tt.path); got != tt.want {
+			t.Errorf("isSensitivePath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SentryRecovery reports panics and 5xx responses to Sentry. A panic is
+// recovered and returned as an error so the error handler still answers
+// with the standard 500 response; other 5xx errors are reported as
+// returned. Events are tagged with the user ID, request ID and route.
+func SentryRecovery() fiber.Handler {
+	return func(c *fiber.Ctx) (err error) {
+		hub := sentry.CurrentHub().Clone()
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				configureSentryScope(hub, c)
+				hub.RecoverWithContext(c.UserContext(), recovered)
+				err = fmt.Errorf("panic: %v", recovered)
+			}
+		}()
+
+		err = c.Next()
+
+		status := responseStatus(c, err)
+		if status < fiber.StatusInternalServerError {
+			return err
+		}
+
+		configureSentryScope(hub, c)
+		if err != nil {
+			hub.CaptureException(err)
+		} else {
+			// The handler wrote the error response itself
+			hub.CaptureMessage(fmt.Sprintf("%s %s responded with %d", c.Method(), c.Route().Path, status))
+		}
+		return err
+	}
+}
+
+// configureSentryScope tags the hub's events with the request's user ID,
+// request ID and route
+func configureSentryScope(hub *sentry.Hub, c *fiber.Ctx) {
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		scope.SetTag("request_id", requestID(c))
+		scope.SetTag("route", c.Method()+" "+c.Route().Path)
+		if userID := GetUserID(c); userID != "" {
+			scope.SetTag("user_id", userID)
+			scope.SetUser(sentry.User{ID: userID})
+		}
+	})
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"api/internal/helpers"
+	"github.com/getsentry/sentry-go"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+)
+
+// mockTransport keeps the events the SDK sends instead of delivering them
+type mockTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *mockTransport) Configure(options sentry.ClientOptions) {}
+func (t *mockTransport) Flush(timeout time.Duration) bool       { return true }
+func (t *mockTransport) FlushWithContext(ctx context.Context) bool {
+	return true
+}
+func (t *mockTransport) Close() {}
+
+func (t *mockTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *mockTransport) Events() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]*sentry.Event(nil), t.events...)
+}
+
+// newSentryApp initializes the SDK with a mock transport and returns an app
+// reporting through SentryRecovery
+func newSentryApp(t *testing.T) (*fiber.App, *mockTransport) {
+	t.Helper()
+
+	transport := &mockTransport{}
+	if err := sentry.Init(sentry.ClientOptions{Dsn: "https://key@sentry.example.com/1", Transport: transport}); err != nil {
+		t.Fatalf("sentry.Init() error = %v", err)
+	}
+	t.Cleanup(func() { sentry.CurrentHub().BindClient(nil) })
+
+	app := fiber.New(fiber.Config{ErrorHandler: helpers.ErrorHandler})
+	app.Use(requestid.New())
+	app.Use(SentryRecovery())
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("userID", "user-1")
+		return c.Next()
+	})
+	app.Get("/panic/:id", func(c *fiber.Ctx) error {
+		panic("boom")
+	})
+	app.Get("/error", func(c *fiber.Ctx) error {
+		return errors.New("database unavailable")
+	})
+	app.Get("/internal", func(c *fiber.Ctx) error {
+		return helpers.InternalServerErrorResponse(c, "Failed to fetch user")
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+
+	return app, transport
+}
+
+func TestSentryRecoveryCapturesPanics(t *testing.T) {
+	app, transport := newSentryApp(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/panic/42", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", resp.StatusCode)
+	}
+
+	events := transport.Events()
+	if len(events) != 1 {
+		t.Fatalf("captured %d events, want 1", len(events))
+	}
+	event := events[0]
+	if event.Message != "boom" {
+		t.Errorf("event message = %q, want boom", event.Message)
+	}
+	if event.Tags["user_id"] != "user-1" {
+		t.Errorf("user_id tag = %q, want user-1", event.Tags["user_id"])
+	}
+	if event.Tags["route"] != "GET /panic/:id" {
+		t.Errorf("route tag = %q, want GET /panic/:id", event.Tags["route"])
+	}
+	if event.Tags["request_id"] == "" || event.Tags["request_id"] != resp.Header.Get(fiber.HeaderXRequestID) {
+		t.Errorf("request_id tag = %q, want %q", event.Tags["request_id"], resp.Header.Get(fiber.HeaderXRequestID))
+	}
+}
+
+func TestSentryRecoveryCapturesServerErrors(t *testing.T) {
+	app, transport := newSentryApp(t)
+
+	for _, path := range []string{"/error", "/internal", "/missing"} {
+		if _, err := app.Test(httptest.NewRequest("GET", path, nil)); err != nil {
+			t.Fatalf("request to %s failed: %v", path, err)
+		}
+	}
+
+	events := transport.Events()
+	if len(events) != 2 {
+		t.Fatalf("captured %d events, want 2", len(events))
+	}
+	if len(events[0].Exception) == 0 || events[0].Exception[0].Value != "database unavailable" {
+		t.Errorf("first event exception = %+v, want the handler error", events[0].Exception)
+	}
+	if events[1].Message != "GET /internal responded with 500" {
+		t.Errorf("second event message = %q", events[1].Message)
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/services"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireToSAcceptance rejects authenticated users who have not accepted the
+// terms of service version currently in effect. It must run after RequireAuth.
+func RequireToSAcceptance() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		pending, err := services.NewToSService().PendingVersion(c.UserContext(), GetUserID(c))
+		if err != nil {
+			return helpers.InternalServerErrorResponse(c, "Failed to verify terms of service acceptance")
+		}
+		if pending == nil {
+			return c.Next()
+		}
+
+		return helpers.ErrorDetailsResponse(c, fiber.StatusForbidden, apperrors.ErrToSAcceptanceRequired, "Terms of service version "+pending.Version+" must be accepted", fiber.Map{
+			"current_version": pending.Version,
+		})
+	}
+}
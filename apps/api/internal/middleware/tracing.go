@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier lets the propagator read request headers and write response
+// headers
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0)
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Tracing starts a server span for every request, continuing the trace from
+// an incoming traceparent header, and returns the trace context in the
+// response headers. Handlers reach the span through c.UserContext(). The span
+// is named after the matched route pattern and records the method, path,
+// status code and any error.
+func Tracing(tracer trace.Tracer) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		propagator := otel.GetTextMapPropagator()
+		ctx := propagator.Extract(c.UserContext(), headerCarrier{c})
+
+		ctx, span := tracer.Start(ctx, c.Method()+" "+c.Path(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+			),
+		)
+		defer span.End()
+
+		c.SetUserContext(ctx)
+		propagator.Inject(ctx, headerCarrier{c})
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			// The error handler has not written the response yet
+			var fiberErr *fiber.Error
+			if errors.As(err, &fiberErr) {
+				status = fiberErr.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+			span.RecordError(err)
+		}
+
+		route := c.Route().Path
+		span.SetName(c.Method() + " " + route)
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+
+		return err
+	}
+}
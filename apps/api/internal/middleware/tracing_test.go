@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// newTracingApp returns an app traced by Tracing and the recorder its spans
+// end up in
+func newTracingApp(t *testing.T) (*fiber.App, *tracetest.SpanRecorder) {
+	t.Helper()
+
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	app := fiber.New()
+	app.Use(Tracing(tracer))
+	app.Get("/api/v1/users/:id", func(c *fiber.Ctx) error {
+		// Work done with the user context joins the request's trace
+		_, span := tracer.Start(c.UserContext(), "lookup")
+		span.End()
+		return c.SendString(c.Params("id"))
+	})
+	app.Get("/api/v1/fail", func(c *fiber.Ctx) error {
+		return errors.New("boom")
+	})
+	return app, recorder
+}
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestTracingRecordsRequestSpan(t *testing.T) {
+	app, recorder := newTracingApp(t)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/users/42", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans, want 2", len(spans))
+	}
+	child, root := spans[0], spans[1]
+
+	if root.Name() != "GET /api/v1/users/:id" {
+		t.Errorf("span name = %q, want the route pattern", root.Name())
+	}
+	if root.SpanKind() != trace.SpanKindServer {
+		t.Errorf("span kind = %v, want server", root.SpanKind())
+	}
+	attrs := spanAttributes(root)
+	if got := attrs["http.request.method"].AsString(); got != "GET" {
+		t.Errorf("http.request.method = %q", got)
+	}
+	if got := attrs["url.path"].AsString(); got != "/api/v1/users/42" {
+		t.Errorf("url.path = %q", got)
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != 200 {
+		t.Errorf("http.response.status_code = %d, want 200", got)
+	}
+	if child.Parent().SpanID() != root.SpanContext().SpanID() {
+		t.Error("span started from c.UserContext() is not a child of the request span")
+	}
+	if resp.Header.Get("traceparent") == "" {
+		t.Error("response has no traceparent header")
+	}
+}
+
+func TestTracingContinuesIncomingTrace(t *testing.T) {
+	app, recorder := newTracingApp(t)
+
+	req := httptest.NewRequest("GET", "/api/v1/users/42", nil)
+	req.Header.Set("traceparent", testTraceParent)
+	if _, err := app.Test(req); err != nil {
+		t.Fatal(err)
+	}
+
+	root := recorder.Ended()[1]
+	if got := root.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s, want the incoming trace", got)
+	}
+	if got := root.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span ID = %s, want the incoming span", got)
+	}
+}
+
+func TestTracingRecordsErrors(t *testing.T) {
+	app, recorder := newTracingApp(t)
+
+	if _, err := app.Test(httptest.NewRequest("GET", "/api/v1/fail", nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("recorded %d spans, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", span.Status().Code)
+	}
+	if got := spanAttributes(span)["http.response.status_code"].AsInt64(); got != 500 {
+		t.Errorf("http.response.status_code = %d, want 500", got)
+	}
+	if len(span.Events()) == 0 || span.Events()[0].Name != "exception" {
+		t.Error("error was not recorded on the span")
+	}
+}
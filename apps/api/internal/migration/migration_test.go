@@ -0,0 +1,223 @@
+package migration
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeMigrations creates an empty file for each name in a temporary
+// migrations directory and returns its path
+func writeMigrations(t *testing.T, names ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func validate(dir string) error {
+	return NewManager(Config{MigrationPath: dir}).Validate()
+}
+
+func TestValidateAcceptsWellFormedMigrations(t *testing.T) {
+	dir := writeMigrations(t,
+		"000001_initial_schema.up.sql",
+		"000001_initial_schema.down.sql",
+		"000002_create_audit_logs.up.sql",
+		"000002_create_audit_logs.down.sql",
+		"README.md",
+	)
+	if err := os.Mkdir(filepath.Join(dir, "archived"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validate(dir); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidateRepositoryMigrations(t *testing.T) {
+	if err := validate(filepath.Join("..", "..", "migrations")); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestValidateRejectsMissingDownMigration(t *testing.T) {
+	dir := writeMigrations(t,
+		"000001_initial_schema.up.sql",
+		"000001_initial_schema.down.sql",
+		"000002_create_audit_logs.up.sql",
+	)
+
+	err := validate(dir)
+	if err == nil || !strings.Contains(err.Error(), "000002_create_audit_logs.up.sql: missing down migration") {
+		t.Errorf("Validate() error = %v, want missing down migration", err)
+	}
+}
+
+func TestValidateRejectsMissingUpMigration(t *testing.T) {
+	dir := writeMigrations(t, "000001_initial_schema.down.sql")
+
+	err := validate(dir)
+	if err == nil || !strings.Contains(err.Error(), "missing up migration") {
+		t.Errorf("Validate() error = %v, want missing up migration", err)
+	}
+}
+
+func TestValidateRejectsBadFileNames(t *testing.T) {
+	for _, name := range []string{
+		"1_initial_schema.up.sql",
+		"000001-initial-schema.up.sql",
+		"000001_initial_schema.sql",
+		"000001_initial_schema.forward.sql",
+		"1700000000_initial_schema.up.sql",
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := validate(writeMigrations(t, name))
+			if err == nil || !strings.Contains(err.Error(), name+": file name must match") {
+				t.Errorf("Validate() error = %v, want naming error", err)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsDuplicateVersions(t *testing.T) {
+	dir := writeMigrations(t,
+		"000003_add_phone.up.sql",
+		"000003_add_phone.down.sql",
+		"000003_add_avatar.up.sql",
+		"000003_add_avatar.down.sql",
+	)
+
+	err := validate(dir)
+	if err == nil || !strings.Contains(err.Error(), "version 000003 is already used") {
+		t.Errorf("Validate() error = %v, want duplicate version error", err)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	dir := writeMigrations(t,
+		"000001_initial_schema.up.sql",
+		"bad.sql",
+	)
+
+	err := validate(dir)
+	if err == nil {
+		t.Fatal("Validate() error = nil")
+	}
+	if lines := strings.Split(err.Error(), "\n"); len(lines) != 2 {
+		t.Errorf("Validate() reported %d problems, want 2: %v", len(lines), err)
+	}
+}
+
+func TestPendingMigrations(t *testing.T) {
+	files, err := readMigrationFiles(writeMigrations(t,
+		"000002_create_audit_logs.up.sql",
+		"000002_create_audit_logs.down.sql",
+		"000001_initial_schema.up.sql",
+		"000001_initial_schema.down.sql",
+		"000003_add_phone.up.sql",
+		"000003_add_phone.down.sql",
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		version uint
+		want    []string
+	}{
+		{version: 0, want: []string{"000001_initial_schema.up.sql", "000002_create_audit_logs.up.sql", "000003_add_phone.up.sql"}},
+		{version: 1, want: []string{"000002_create_audit_logs.up.sql", "000003_add_phone.up.sql"}},
+		{version: 3, want: []string{}},
+	}
+	for _, tt := range tests {
+		if got := pendingMigrations(files, tt.version); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("pendingMigrations(%d) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestCreateMigrationUsesNextVersion(t *testing.T) {
+	dir := writeMigrations(t,
+		"000001_initial_schema.up.sql",
+		"000001_initial_schema.down.sql",
+		"000007_create_login_events.up.sql",
+		"000007_create_login_events.down.sql",
+	)
+
+	if err := CreateMigration("add_nickname", dir); err != nil {
+		t.Fatalf("CreateMigration() error = %v", err)
+	}
+	for _, name := range []string{"000008_add_nickname.up.sql", "000008_add_nickname.down.sql"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+	if err := validate(dir); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	dir := writeMigrations(t,
+		"000001_initial_schema.up.sql",
+		"000001_initial_schema.down.sql",
+		"000012_add_companies.up.sql",
+		"000012_add_companies.down.sql",
+		"README.sql",
+	)
+
+	latest, err := NewManager(Config{MigrationPath: dir}).LatestVersion()
+	if err != nil {
+		t.Fatalf("LatestVersion() error = %v", err)
+	}
+	if latest != 12 {
+		t.Errorf("LatestVersion() = %d, want 12", latest)
+	}
+}
+
+func TestInitializeWithRetryRecoversFromTransientErrors(t *testing.T) {
+	manager := NewManager(Config{})
+	calls := 0
+	manager.connector = func() error {
+		calls++
+		if calls <= 2 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	if err := manager.InitializeWithRetry(5, time.Millisecond); err != nil {
+		t.Fatalf("InitializeWithRetry() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("connect called %d times, want 3", calls)
+	}
+}
+
+func TestInitializeWithRetryGivesUp(t *testing.T) {
+	manager := NewManager(Config{})
+	calls := 0
+	manager.connector = func() error {
+		calls++
+		return errors.New("connection refused")
+	}
+
+	err := manager.InitializeWithRetry(3, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "giving up after 3 attempts: connection refused") {
+		t.Errorf("InitializeWithRetry() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("connect called %d times, want 3", calls)
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Announcement types, from least to most urgent
+const (
+	AnnouncementTypeInfo     = "info"
+	AnnouncementTypeWarning  = "warning"
+	AnnouncementTypeCritical = "critical"
+)
+
+// Announcement is a message admins post to every user. It is active from
+// VisibleFrom until VisibleUntil, or indefinitely when VisibleUntil is nil.
+type Announcement struct {
+	ID           string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Title        string     `gorm:"type:varchar(200);not null" json:"title"`
+	Body         string     `gorm:"type:text;not null" json:"body"`
+	Type         string     `gorm:"type:varchar(20);not null;default:info" json:"type"`
+	VisibleFrom  time.Time  `gorm:"not null" json:"visible_from"`
+	VisibleUntil *time.Time `json:"visible_until"`
+	CreatedBy    *string    `gorm:"type:uuid" json:"created_by"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+func (a *Announcement) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (Announcement) TableName() string {
+	return "system_announcements"
+}
+
+// AnnouncementRead records a user marking an announcement read
+type AnnouncementRead struct {
+	UserID         string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	AnnouncementID string    `gorm:"type:uuid;primaryKey" json:"announcement_id"`
+	ReadAt         time.Time `gorm:"not null" json:"read_at"`
+}
+
+func (AnnouncementRead) TableName() string {
+	return "user_announcement_reads"
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Scopes lists the operations an API key may perform, stored as a JSON array
+type Scopes []string
+
+func (s Scopes) Value() (driver.Value, error) {
+	if s == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(s)
+	return string(data), err
+}
+
+func (s *Scopes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = Scopes{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+}
+
+// APIKey is a long-lived credential a user creates for an integrating
+// service. Only the SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	KeyHash    string     `gorm:"type:varchar(64);unique;not null" json:"-"`
+	UserID     string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name       string     `gorm:"type:varchar(100);not null" json:"name"`
+	Scopes     Scopes     `gorm:"type:jsonb;not null;default:'[]'" json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+
+	// Relationships
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+// IsExpired reports whether the key has passed its expiry time
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && !k.ExpiresAt.After(time.Now())
+}
+
+// HasScope reports whether the key grants scope
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// JSONB holds a raw JSON document stored in a jsonb column
+type JSONB json.RawMessage
+
+func (j JSONB) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return string(j), nil
+}
+
+func (j *JSONB) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = JSONB(v)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+	return nil
+}
+
+func (j JSONB) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+func (j *JSONB) UnmarshalJSON(data []byte) error {
+	*j = append((*j)[:0], data...)
+	return nil
+}
+
+type AuditLog struct {
+	ID      string  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ActorID *string `gorm:"type:uuid" json:"actor_id"`
+	// ImpersonatedBy is the admin who acted while impersonating the actor
+	ImpersonatedBy *string   `gorm:"type:uuid" json:"impersonated_by"`
+	Action         string    `gorm:"type:varchar(100);not null" json:"action"`
+	ResourceType   string    `gorm:"type:varchar(100);not null" json:"resource_type"`
+	ResourceID     string    `gorm:"type:varchar(255)" json:"resource_id"`
+	Changes        JSONB     `gorm:"type:jsonb" json:"changes"`
+	IPAddress      string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent      string    `gorm:"type:text" json:"user_agent"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	Actor *User `gorm:"foreignKey:ActorID" json:"-"`
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
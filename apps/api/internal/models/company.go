@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Company is an organisation users can belong to.
+type Company struct {
+	ID        string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(255);unique;not null" json:"name"`
+	Domain    *string   `gorm:"type:varchar(255);unique" json:"domain"`
+	Website   *string   `gorm:"type:varchar(255)" json:"website"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *Company) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (Company) TableName() string {
+	return "companies"
+}
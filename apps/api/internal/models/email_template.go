@@ -37,12 +37,15 @@ func (tv *TemplateVariables) Scan(value interface{}) error {
 
 type EmailTemplate struct {
 	ID           string            `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Name         string            `gorm:"unique;not null;size:100" json:"name"`
+	Name         string            `gorm:"uniqueIndex:email_templates_name_language_key;not null;size:100" json:"name"`
+	Language     string            `gorm:"uniqueIndex:email_templates_name_language_key;not null;size:35;default:en" json:"language"`
 	Subject      string            `gorm:"not null;size:500" json:"subject"`
 	HTMLTemplate string            `gorm:"not null;column:html_template" json:"html_template"`
 	TextTemplate string            `gorm:"not null;column:text_template" json:"text_template"`
 	Variables    TemplateVariables `gorm:"type:jsonb;default:'[]'" json:"variables"`
 	IsActive     bool              `gorm:"default:true" json:"is_active"`
+	Protected    bool              `gorm:"not null;default:false" json:"is_protected"`
+	Version      int               `gorm:"not null;default:1" json:"version"`
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt    `gorm:"index" json:"-"`
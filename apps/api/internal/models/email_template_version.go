@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EmailTemplateVersion is a snapshot of an email template's content taken
+// before it was changed
+type EmailTemplateVersion struct {
+	ID            string            `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	TemplateID    string            `gorm:"type:uuid;not null" json:"template_id"`
+	VersionNumber int               `gorm:"not null" json:"version_number"`
+	Subject       string            `gorm:"not null;size:500" json:"subject"`
+	HTMLTemplate  string            `gorm:"not null;column:html_template" json:"html_template"`
+	TextTemplate  string            `gorm:"not null;column:text_template" json:"text_template"`
+	Variables     TemplateVariables `gorm:"type:jsonb;default:'[]'" json:"variables"`
+	CreatedBy     *string           `gorm:"type:uuid" json:"created_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+func (v *EmailTemplateVersion) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == "" {
+		v.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (EmailTemplateVersion) TableName() string {
+	return "email_template_versions"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey stores the response to a POST request sent with an
+// Idempotency-Key header. ResponseStatus is zero while the request is still
+// being processed.
+type IdempotencyKey struct {
+	ID             string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Key            string    `gorm:"type:varchar(255);not null" json:"key"`
+	UserID         *string   `gorm:"type:uuid" json:"user_id"`
+	RequestHash    string    `gorm:"type:varchar(64);not null" json:"-"`
+	ResponseBody   []byte    `gorm:"type:bytea" json:"-"`
+	ResponseStatus int       `gorm:"not null;default:0" json:"response_status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (k *IdempotencyKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsCompleted reports whether the original request has finished
+func (k *IdempotencyKey) IsCompleted() bool {
+	return k.ResponseStatus != 0
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
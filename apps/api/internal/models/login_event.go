@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LoginEvent records a single login attempt for an existing user
+type LoginEvent struct {
+	ID        string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	IPAddress string    `gorm:"type:varchar(45)" json:"ip_address"`
+	UserAgent string    `gorm:"type:text" json:"user_agent"`
+	Success   bool      `gorm:"not null" json:"success"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (e *LoginEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (LoginEvent) TableName() string {
+	return "login_events"
+}
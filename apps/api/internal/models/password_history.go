@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PasswordHistory holds a password hash a user had before changing it
+type PasswordHistory struct {
+	ID           string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID       string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	PasswordHash string    `gorm:"not null" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (h *PasswordHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (PasswordHistory) TableName() string {
+	return "password_history"
+}
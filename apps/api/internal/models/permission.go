@@ -1,23 +1,58 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// PermissionMetadata holds free-form UI hints for a permission, such as
+// {"dangerous":true,"icon":"trash"}, stored as a JSON object
+type PermissionMetadata map[string]interface{}
+
+func (m PermissionMetadata) Value() (driver.Value, error) {
+	if m == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(m)
+	return string(data), err
+}
+
+func (m *PermissionMetadata) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = PermissionMetadata{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, m)
+	case string:
+		return json.Unmarshal([]byte(v), m)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+}
+
 type Permission struct {
-	ID          string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Name        string    `gorm:"type:varchar(100);unique;not null" json:"name"`
-	Resource    string    `gorm:"type:varchar(100);not null" json:"resource"`
-	Action      string    `gorm:"type:varchar(50);not null" json:"action"`
-	Description *string   `gorm:"type:text" json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string             `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Name        string             `gorm:"type:varchar(100);unique;not null" json:"name"`
+	Resource    string             `gorm:"type:varchar(100);not null" json:"resource"`
+	Action      string             `gorm:"type:varchar(50);not null" json:"action"`
+	Description *string            `gorm:"type:text" json:"description"`
+	CategoryID  *string            `gorm:"type:uuid" json:"category_id"`
+	Metadata    PermissionMetadata `gorm:"type:jsonb;not null;default:'{}'" json:"metadata"`
+	CreatedBy   *string            `gorm:"type:uuid" json:"created_by"`
+	UpdatedBy   *string            `gorm:"type:uuid" json:"updated_by"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
 	
 	// Relationships
-	Roles []Role `gorm:"many2many:role_permissions" json:"roles,omitempty"`
+	Category *PermissionCategory `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Roles    []Role              `gorm:"many2many:role_permissions" json:"roles,omitempty"`
+	Creator  *User               `gorm:"foreignKey:CreatedBy" json:"-"`
 }
 
 func (p *Permission) BeforeCreate(tx *gorm.DB) error {
@@ -27,6 +62,15 @@ func (p *Permission) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// BeforeSave checks that the permission name follows the resource.action
+// pattern
+func (p *Permission) BeforeSave(tx *gorm.DB) error {
+	if name, ok := savedString(tx, p, "Name"); ok {
+		return validatePermissionName(name)
+	}
+	return nil
+}
+
 func (Permission) TableName() string {
 	return "permissions"
 }
\ No newline at end of file
@@ -1,22 +1,98 @@
 package models
 
 import (
+	"api/internal/rbac"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// DataScopeColumns are the user columns a data scope may filter on
+var DataScopeColumns = map[string]bool{
+	"company_id": true,
+}
+
+// DataScope limits the users a role's holders can list and update, such as
+// {"company_id":"..."}. Each key names a user column that must hold the
+// value, or one of the values when given a list. An empty scope does not
+// limit access and is stored as NULL.
+type DataScope map[string]interface{}
+
+func (d DataScope) Value() (driver.Value, error) {
+	if len(d) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(d)
+	return string(data), err
+}
+
+func (d *DataScope) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+}
+
+// Validate checks that every key is one of DataScopeColumns and every value
+// is a string or a non-empty list of strings
+func (d DataScope) Validate() error {
+	for column, value := range d {
+		if !DataScopeColumns[column] {
+			return fmt.Errorf("unsupported column %q", column)
+		}
+		switch v := value.(type) {
+		case string:
+		case []interface{}:
+			if len(v) == 0 {
+				return fmt.Errorf("%s must list at least one value", column)
+			}
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return fmt.Errorf("%s values must be strings", column)
+				}
+			}
+		default:
+			return fmt.Errorf("%s must be a string or a list of strings", column)
+		}
+	}
+	return nil
+}
+
 type Role struct {
 	ID          string       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
 	Name        string       `gorm:"type:varchar(50);unique;not null" json:"name"`
 	Description *string      `gorm:"type:text" json:"description"`
+	// UseWildcardPermissions treats the role's permission names as patterns,
+	// so user.* grants user.read and user.write
+	UseWildcardPermissions bool `gorm:"not null;default:false" json:"use_wildcard_permissions"`
+	// AccessWindows limits when the role is active, such as business hours.
+	// A role without windows is always active.
+	AccessWindows rbac.AccessWindows `gorm:"type:jsonb;not null;default:'[]'" json:"access_windows"`
+	// DataScope limits the users the role's holders can list and update
+	DataScope DataScope `gorm:"type:jsonb" json:"data_scope"`
+	// CreatedBy and UpdatedBy are the IDs of the admins who created and last
+	// updated the role
+	CreatedBy   *string      `gorm:"type:uuid" json:"created_by"`
+	UpdatedBy   *string      `gorm:"type:uuid" json:"updated_by"`
 	CreatedAt   time.Time    `json:"created_at"`
 	UpdatedAt   time.Time    `json:"updated_at"`
 	
 	// Relationships
 	Permissions []Permission `gorm:"many2many:role_permissions" json:"permissions,omitempty"`
 	Users       []User       `gorm:"many2many:user_roles" json:"users,omitempty"`
+	Creator     *User        `gorm:"foreignKey:CreatedBy" json:"-"`
 }
 
 func (r *Role) BeforeCreate(tx *gorm.DB) error {
@@ -26,6 +102,24 @@ func (r *Role) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// BeforeSave checks that the role name is a lowercase slug and the data
+// scope only filters on supported columns
+func (r *Role) BeforeSave(tx *gorm.DB) error {
+	if name, ok := savedString(tx, r, "Name"); ok {
+		if err := validateRoleName(name); err != nil {
+			return err
+		}
+	}
+	if value, ok := savedValue(tx, r, "DataScope"); ok {
+		if scope, ok := value.(DataScope); ok {
+			if err := scope.Validate(); err != nil {
+				return &ValidationError{Model: "role", Field: "data_scope", Reason: err.Error()}
+			}
+		}
+	}
+	return nil
+}
+
 func (Role) TableName() string {
 	return "roles"
 }
\ No newline at end of file
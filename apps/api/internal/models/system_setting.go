@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// SystemSetting is a single key-value setting that admins can change while
+// the server is running
+type SystemSetting struct {
+	Key       string    `gorm:"type:varchar(100);primaryKey" json:"key"`
+	Value     string    `gorm:"type:text;not null" json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (SystemSetting) TableName() string {
+	return "system_settings"
+}
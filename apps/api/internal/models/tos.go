@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ToSVersion is a published version of the terms of service
+type ToSVersion struct {
+	ID            string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Version       string    `gorm:"type:varchar(50);unique;not null" json:"version"`
+	Content       string    `gorm:"type:text;not null" json:"content"`
+	EffectiveDate time.Time `gorm:"not null;index" json:"effective_date"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (v *ToSVersion) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == "" {
+		v.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (ToSVersion) TableName() string {
+	return "tos_versions"
+}
+
+// UserToSAcceptance records a user accepting a terms of service version
+type UserToSAcceptance struct {
+	UserID       string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	ToSVersionID string    `gorm:"column:tos_version_id;type:uuid;primaryKey" json:"tos_version_id"`
+	AcceptedAt   time.Time `gorm:"not null" json:"accepted_at"`
+	IPAddress    string    `gorm:"type:varchar(45)" json:"ip_address"`
+}
+
+func (UserToSAcceptance) TableName() string {
+	return "user_tos_acceptances"
+}
@@ -8,18 +8,25 @@ import (
 )
 
 type User struct {
-	ID        string         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
-	Email     string         `gorm:"unique;not null" json:"email"`
-	Password  string         `gorm:"not null" json:"-"`
-	Name      string         `gorm:"not null" json:"name"`
-	Phone     *string        `gorm:"type:varchar(50)" json:"phone"`
-	Company   *string        `gorm:"type:varchar(255)" json:"company"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID                  string         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Email               string         `gorm:"uniqueIndex:idx_users_email,where:deleted_at IS NULL;not null" json:"email"`
+	Password            string         `gorm:"not null" json:"-"`
+	Name                string         `gorm:"not null" json:"name"`
+	Phone               *string        `gorm:"type:varchar(50)" json:"phone"`
+	CompanyID           *string        `gorm:"type:uuid" json:"company_id"`
+	AvatarURL           *string        `gorm:"type:varchar(255)" json:"avatar_url"`
+	IsActive            bool           `gorm:"not null;default:true" json:"is_active"`
+	EmailDeliveryStatus *string        `gorm:"type:email_delivery_status" json:"email_delivery_status"`
+	TokenExpiryOverride *time.Duration `json:"token_expiry_override"`
+	LastLoginAt         *time.Time     `json:"last_login_at"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `gorm:"index" json:"-"`
 	
 	// Relationships
-	Roles []Role `gorm:"many2many:user_roles" json:"roles,omitempty"`
+	Roles   []Role   `gorm:"many2many:user_roles" json:"roles,omitempty"`
+	Tags    []Tag    `gorm:"many2many:user_tags" json:"tags,omitempty"`
+	Company *Company `json:"company,omitempty"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
@@ -29,6 +36,27 @@ func (u *User) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// BeforeSave validates the email, name and phone written by a create, save
+// or update, so invalid data is rejected whichever code path saves the user
+func (u *User) BeforeSave(tx *gorm.DB) error {
+	if email, ok := savedString(tx, u, "Email"); ok {
+		if err := validateEmail(email); err != nil {
+			return err
+		}
+	}
+	if name, ok := savedString(tx, u, "Name"); ok {
+		if err := validateUserName(name); err != nil {
+			return err
+		}
+	}
+	if phone, ok := savedString(tx, u, "Phone"); ok && phone != "" {
+		if err := validatePhone(phone); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (User) TableName() string {
 	return "users"
 }
@@ -72,3 +100,12 @@ func (u *User) GetRoleNames() []string {
 	return roleNames
 }
 
+// GetTagNames returns the names of the user's tags
+func (u *User) GetTagNames() []string {
+	tagNames := make([]string, len(u.Tags))
+	for i, tag := range u.Tags {
+		tagNames[i] = tag.Name
+	}
+	return tagNames
+}
+
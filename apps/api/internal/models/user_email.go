@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserEmail is one of a user's email addresses. The primary address is kept
+// in sync with User.Email by a database trigger. Only the hash of a pending
+// verification token is stored, along with when it expires.
+type UserEmail struct {
+	ID                    string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID                string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	Email                 string     `gorm:"not null" json:"email"`
+	IsPrimary             bool       `gorm:"not null;default:false" json:"is_primary"`
+	VerifiedAt            *time.Time `json:"verified_at"`
+	VerificationTokenHash *string    `gorm:"type:varchar(64);unique" json:"-"`
+	VerificationExpiresAt *time.Time `json:"-"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+func (e *UserEmail) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (UserEmail) TableName() string {
+	return "user_emails"
+}
+
+func (e *UserEmail) IsVerified() bool {
+	return e.VerifiedAt != nil
+}
+
+// IsVerificationExpired reports whether the pending verification token can no
+// longer be used
+func (e *UserEmail) IsVerificationExpired() bool {
+	return e.VerificationExpiresAt == nil || e.VerificationExpiresAt.Before(time.Now())
+}
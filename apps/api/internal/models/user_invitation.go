@@ -0,0 +1,67 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RoleNames is a list of role names stored as a JSON array
+type RoleNames []string
+
+func (r RoleNames) Value() (driver.Value, error) {
+	if r == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(r))
+}
+
+func (r *RoleNames) Scan(value interface{}) error {
+	if value == nil {
+		*r = RoleNames{}
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, r)
+}
+
+// UserInvitation is an admin-issued invitation to create an account. Only the
+// hash of the invitation token is stored.
+type UserInvitation struct {
+	ID         string     `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	Email      string     `gorm:"not null;index" json:"email"`
+	InvitedBy  *string    `gorm:"type:uuid" json:"invited_by"`
+	TokenHash  string     `gorm:"type:varchar(64);unique;not null" json:"-"`
+	Roles      RoleNames  `gorm:"type:jsonb;not null;default:'[]'" json:"roles"`
+	ExpiresAt  time.Time  `gorm:"not null" json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func (i *UserInvitation) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (UserInvitation) TableName() string {
+	return "user_invitations"
+}
+
+func (i *UserInvitation) IsExpired() bool {
+	return time.Now().After(i.ExpiresAt)
+}
+
+func (i *UserInvitation) IsAccepted() bool {
+	return i.AcceptedAt != nil
+}
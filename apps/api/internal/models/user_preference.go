@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// UserPreference is a single key-value setting belonging to a user
+type UserPreference struct {
+	UserID    string    `gorm:"type:uuid;primaryKey" json:"user_id"`
+	Key       string    `gorm:"type:varchar(100);primaryKey" json:"key"`
+	Value     JSONB     `gorm:"type:jsonb;not null" json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (UserPreference) TableName() string {
+	return "user_preferences"
+}
@@ -27,4 +27,13 @@ func (ur *UserRole) IsExpired() bool {
 		return false
 	}
 	return ur.ExpiresAt.Before(time.Now())
+}
+
+// IsActiveAt reports whether the assignment has not expired by now and now
+// falls inside the role's access windows
+func (ur *UserRole) IsActiveAt(now time.Time) bool {
+	if ur.ExpiresAt != nil && ur.ExpiresAt.Before(now) {
+		return false
+	}
+	return ur.Role.AccessWindows.ActiveAt(now)
 }
\ No newline at end of file
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"api/internal/pkg/phonenumbers"
+	"gorm.io/gorm"
+)
+
+var (
+	htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+	// Role names are lowercase slugs; underscores are accepted for the
+	// built-in super_admin role
+	roleNamePattern = regexp.MustCompile(`^[a-z0-9]+([_-][a-z0-9]+)*$`)
+	// Permission names are resource.action, optionally with more segments.
+	// Segments may be * so that wildcard permissions such as user.* and *
+	// can be stored.
+	permissionNamePattern = regexp.MustCompile(`^(\*|[a-z0-9_-]+(\.([a-z0-9_-]+|\*+))+)$`)
+)
+
+// ValidationError is returned by the save hooks when a model holds a value
+// that must not reach the database
+type ValidationError struct {
+	Model  string
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s %s: %s", e.Model, e.Field, e.Reason)
+}
+
+// savedValue returns the value tx writes to field of model and whether it
+// writes the field at all. Creates and saves write the whole model, while
+// Update and Updates only write the columns they name.
+func savedValue(tx *gorm.DB, model interface{}, field string) (interface{}, bool) {
+	stmt := tx.Statement
+	schemaField := stmt.Schema.LookUpField(field)
+	if schemaField == nil {
+		return nil, false
+	}
+
+	switch dest := stmt.Dest.(type) {
+	case map[string]interface{}:
+		if value, ok := dest[schemaField.DBName]; ok {
+			return value, true
+		}
+		value, ok := dest[schemaField.Name]
+		return value, ok
+	}
+
+	destValue := reflect.ValueOf(stmt.Dest)
+	modelValue := reflect.ValueOf(stmt.Model)
+	isWholeModel := destValue.Kind() == reflect.Ptr && modelValue.Kind() == reflect.Ptr &&
+		destValue.Pointer() == modelValue.Pointer()
+	if isWholeModel || destValue.Kind() == reflect.Slice || (destValue.Kind() == reflect.Ptr && destValue.Elem().Kind() == reflect.Slice) {
+		return reflect.Indirect(reflect.ValueOf(model)).FieldByName(schemaField.Name).Interface(), true
+	}
+
+	// Updates with a struct writes its non-zero fields
+	destStruct := reflect.Indirect(destValue)
+	if destStruct.Kind() != reflect.Struct {
+		return nil, false
+	}
+	value := destStruct.FieldByName(schemaField.Name)
+	if !value.IsValid() || value.IsZero() {
+		return nil, false
+	}
+	return value.Interface(), true
+}
+
+// savedString returns the string tx writes to field, unwrapping pointers.
+// ok is false when the field is not written or is set to NULL or an
+// expression.
+func savedString(tx *gorm.DB, model interface{}, field string) (value string, ok bool) {
+	raw, written := savedValue(tx, model, field)
+	if !written {
+		return "", false
+	}
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case *string:
+		if v != nil {
+			return *v, true
+		}
+	}
+	return "", false
+}
+
+// validateEmail checks that email is a bare RFC 5322 address
+func validateEmail(email string) error {
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return &ValidationError{Model: "user", Field: "email", Reason: fmt.Sprintf("%q is not a valid email address", email)}
+	}
+	return nil
+}
+
+// validateUserName checks that name is not blank and has no HTML tags
+func validateUserName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return &ValidationError{Model: "user", Field: "name", Reason: "must not be empty"}
+	}
+	if htmlTagPattern.MatchString(name) {
+		return &ValidationError{Model: "user", Field: "name", Reason: "must not contain HTML tags"}
+	}
+	return nil
+}
+
+// validatePhone checks that phone is a valid number
+func validatePhone(phone string) error {
+	if !phonenumbers.IsValidNumber(phone, phonenumbers.DefaultPhoneRegion) {
+		return &ValidationError{Model: "user", Field: "phone", Reason: fmt.Sprintf("%q is not a valid phone number", phone)}
+	}
+	return nil
+}
+
+// validateRoleName checks that name is a lowercase slug
+func validateRoleName(name string) error {
+	if !roleNamePattern.MatchString(name) {
+		return &ValidationError{Model: "role", Field: "name", Reason: fmt.Sprintf("%q must contain only lowercase letters, digits and hyphens", name)}
+	}
+	return nil
+}
+
+// validatePermissionName checks that name follows the resource.action pattern
+func validatePermissionName(name string) error {
+	if !permissionNamePattern.MatchString(name) {
+		return &ValidationError{Model: "permission", Field: "name", Reason: fmt.Sprintf("%q must match the resource.action pattern", name)}
+	}
+	return nil
+}
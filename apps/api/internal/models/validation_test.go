@@ -0,0 +1,249 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens an in-memory SQLite database with the tables the save
+// hooks are exercised against. The tables are created by hand because the
+// model tags use Postgres-only defaults.
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+
+	statements := []string{
+		`CREATE TABLE users (
+			id TEXT PRIMARY KEY, email TEXT NOT NULL, password TEXT NOT NULL, name TEXT NOT NULL,
+			phone TEXT, company_id TEXT, avatar_url TEXT, is_active BOOLEAN NOT NULL DEFAULT true,
+			email_delivery_status TEXT, token_expiry_override INTEGER, last_login_at DATETIME,
+			created_at DATETIME, updated_at DATETIME, deleted_at DATETIME
+		)`,
+		`CREATE TABLE roles (
+			id TEXT PRIMARY KEY, name TEXT NOT NULL UNIQUE, description TEXT,
+			use_wildcard_permissions BOOLEAN NOT NULL DEFAULT false, access_windows TEXT NOT NULL DEFAULT '[]',
+			data_scope TEXT, created_by TEXT, updated_by TEXT,
+			created_at DATETIME, updated_at DATETIME
+		)`,
+		`CREATE TABLE permissions (
+			id TEXT PRIMARY KEY, name TEXT NOT NULL UNIQUE, resource TEXT NOT NULL, action TEXT NOT NULL,
+			description TEXT, category_id TEXT, metadata TEXT NOT NULL DEFAULT '{}', created_by TEXT, updated_by TEXT,
+			created_at DATETIME, updated_at DATETIME
+		)`,
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			t.Fatalf("failed to create table: %v", err)
+		}
+	}
+
+	return db
+}
+
+// requireValidationError fails unless err is a ValidationError for field
+func requireValidationError(t *testing.T, err error, field string) {
+	t.Helper()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+	if validationErr.Field != field {
+		t.Errorf("ValidationError.Field = %q, want %q", validationErr.Field, field)
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestUserBeforeSave(t *testing.T) {
+	tests := []struct {
+		name  string
+		user  User
+		field string
+	}{
+		{"valid user", User{Email: "jane@example.com", Name: "Jane Doe", Phone: stringPtr("+6281234567890")}, ""},
+		{"valid user without phone", User{Email: "john@example.com", Name: "John"}, ""},
+		{"invalid email", User{Email: "not-an-email", Name: "Jane"}, "email"},
+		{"email with display name", User{Email: "Jane <jane@example.com>", Name: "Jane"}, "email"},
+		{"blank name", User{Email: "jane@example.com", Name: "   "}, "name"},
+		{"name with HTML", User{Email: "jane@example.com", Name: "<script>alert(1)</script>"}, "name"},
+		{"invalid phone", User{Email: "jane@example.com", Name: "Jane", Phone: stringPtr("12345")}, "phone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+			tt.user.Password = "hashed"
+
+			err := db.Create(&tt.user).Error
+			if tt.field == "" {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return
+			}
+			requireValidationError(t, err, tt.field)
+
+			var count int64
+			db.Model(&User{}).Count(&count)
+			if count != 0 {
+				t.Errorf("invalid user was saved")
+			}
+		})
+	}
+}
+
+func TestUserBeforeSaveOnUpdate(t *testing.T) {
+	db := newTestDB(t)
+	user := User{Email: "jane@example.com", Password: "hashed", Name: "Jane"}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	err := db.Model(&User{}).Where("id = ?", user.ID).Update("name", "<b>Jane</b>").Error
+	requireValidationError(t, err, "name")
+
+	err = db.Model(&user).Updates(map[string]interface{}{"phone": "12345"}).Error
+	requireValidationError(t, err, "phone")
+
+	user.Email = "jane"
+	requireValidationError(t, db.Save(&user).Error, "email")
+
+	// Updates that do not touch validated columns are not checked against the
+	// zero values of the model they are run on
+	if err := db.Model(&User{}).Where("id = ?", user.ID).Update("is_active", false).Error; err != nil {
+		t.Fatalf("Update(is_active) error = %v", err)
+	}
+	if err := db.Model(&User{}).Where("id = ?", user.ID).Update("phone", nil).Error; err != nil {
+		t.Fatalf("Update(phone, nil) error = %v", err)
+	}
+
+	var saved User
+	db.First(&saved, "id = ?", user.ID)
+	if saved.Name != "Jane" || saved.Email != "jane@example.com" {
+		t.Errorf("saved user = %q <%s>, want the original values", saved.Name, saved.Email)
+	}
+}
+
+func TestRoleBeforeSave(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"editor", true},
+		{"content-manager", true},
+		{"super_admin", true},
+		{"team2", true},
+		{"Editor", false},
+		{"content manager", false},
+		{"-editor", false},
+		{"editor-", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			err := db.Create(&Role{Name: tt.name}).Error
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return
+			}
+			requireValidationError(t, err, "name")
+		})
+	}
+
+	t.Run("rename", func(t *testing.T) {
+		db := newTestDB(t)
+		role := Role{Name: "editor"}
+		if err := db.Create(&role).Error; err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		err := db.Model(&role).Updates(map[string]interface{}{"name": "Chief Editor"}).Error
+		requireValidationError(t, err, "name")
+
+		if err := db.Model(&role).Updates(map[string]interface{}{"description": "Edits content"}).Error; err != nil {
+			t.Fatalf("Updates(description) error = %v", err)
+		}
+	})
+}
+
+func TestRoleBeforeSaveDataScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		scope DataScope
+		valid bool
+	}{
+		{"unscoped", nil, true},
+		{"company", DataScope{"company_id": "c1"}, true},
+		{"company list", DataScope{"company_id": []interface{}{"c1", "c2"}}, true},
+		{"unknown column", DataScope{"password": "secret"}, false},
+		{"empty list", DataScope{"company_id": []interface{}{}}, false},
+		{"number", DataScope{"company_id": 1.0}, false},
+		{"list of numbers", DataScope{"company_id": []interface{}{1.0}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			err := db.Create(&Role{Name: "scoped", DataScope: tt.scope}).Error
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return
+			}
+			requireValidationError(t, err, "data_scope")
+		})
+	}
+}
+
+func TestPermissionBeforeSave(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{"user.read", true},
+		{"user-profile.update", true},
+		{"reports.export.csv", true},
+		{"user.*", true},
+		{"user.**", true},
+		{"*", true},
+		{"user", false},
+		{"User.Read", false},
+		{"user.", false},
+		{".read", false},
+		{"user read", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t)
+
+			err := db.Create(&Permission{Name: tt.name, Resource: "user", Action: "read"}).Error
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("Create() error = %v", err)
+				}
+				return
+			}
+			requireValidationError(t, err, "name")
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// Webhook is an external URL notified of the events it subscribes to.
+// Deliveries are signed with Secret.
+type Webhook struct {
+	ID        string         `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	URL       string         `gorm:"type:text;not null" json:"url"`
+	Secret    string         `gorm:"type:varchar(255);not null" json:"-"`
+	Events    pq.StringArray `gorm:"type:text[];not null;default:'{}'" json:"events"`
+	IsActive  bool           `gorm:"not null;default:true" json:"is_active"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+func (w *Webhook) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (Webhook) TableName() string {
+	return "webhooks"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookDeliveryAttempt records one POST of an event to a webhook.
+// ResponseStatus and ResponseBody are nil when no response was received, in
+// which case Error says why. A replay points at the attempt it re-sent through
+// ReplayOf and is one ReplayDepth deeper.
+type WebhookDeliveryAttempt struct {
+	ID             string    `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	WebhookID      string    `gorm:"type:uuid;not null" json:"webhook_id"`
+	Event          string    `gorm:"type:varchar(50);not null" json:"event"`
+	Payload        JSONB     `gorm:"type:jsonb;not null" json:"payload"`
+	ResponseStatus *int      `json:"response_status"`
+	ResponseBody   *string   `gorm:"type:text" json:"response_body"`
+	Error          *string   `gorm:"type:text" json:"error"`
+	Succeeded      bool      `gorm:"not null;default:false" json:"succeeded"`
+	ReplayOf       *string   `gorm:"type:uuid" json:"replay_of"`
+	ReplayDepth    int       `gorm:"not null;default:0" json:"replay_depth"`
+	AttemptedAt    time.Time `gorm:"not null" json:"attempted_at"`
+}
+
+func (a *WebhookDeliveryAttempt) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuid.New().String()
+	}
+	return nil
+}
+
+func (WebhookDeliveryAttempt) TableName() string {
+	return "webhook_delivery_attempts"
+}
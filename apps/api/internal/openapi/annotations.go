@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// annotationPrefix marks doc-comment lines read by the generator
+const annotationPrefix = "@openapi"
+
+// handlerDoc is the parsed doc comment of a handler function
+type handlerDoc struct {
+	// Summary is the first sentence without the function name, Description the rest
+	Summary     string
+	Description string
+	Annotations []annotation
+}
+
+// annotation is a single "@openapi <key> <args...>" line
+type annotation struct {
+	Key  string
+	Args []string
+	Pos  token.Position
+}
+
+// parseHandlerDocs reads the doc comments of the exported functions declared
+// in the Go files of dir, keyed by function name
+func parseHandlerDocs(dir string) (map[string]handlerDoc, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]handlerDoc)
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, filepath.Base(path), src, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil || !fn.Name.IsExported() || fn.Doc == nil {
+				continue
+			}
+			docs[fn.Name.Name] = parseDoc(fset, fn.Name.Name, fn.Doc)
+		}
+	}
+	return docs, nil
+}
+
+func parseDoc(fset *token.FileSet, name string, group *ast.CommentGroup) handlerDoc {
+	var doc handlerDoc
+	var text []string
+	for _, comment := range group.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if rest, ok := strings.CutPrefix(line, annotationPrefix); ok {
+			fields := strings.Fields(rest)
+			if len(fields) > 0 {
+				doc.Annotations = append(doc.Annotations, annotation{
+					Key:  fields[0],
+					Args: fields[1:],
+					Pos:  fset.Position(comment.Pos()),
+				})
+			}
+			continue
+		}
+		if line != "" {
+			text = append(text, line)
+		}
+	}
+
+	if len(text) > 0 {
+		first, rest, _ := strings.Cut(strings.Join(text, " "), ". ")
+		doc.Summary = summarize(name, first)
+		doc.Description = rest
+	}
+	return doc
+}
+
+// summarize turns "Login authenticates a user" into "Authenticates a user"
+func summarize(name, line string) string {
+	line = strings.TrimPrefix(line, name+" ")
+	line = strings.TrimSuffix(line, " (admin only)")
+	line = strings.TrimSuffix(line, ".")
+	if line == "" {
+		return ""
+	}
+	return strings.ToUpper(line[:1]) + line[1:]
+}
@@ -0,0 +1,449 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// handlersPackage is where documented route handlers live
+	handlersPackage   = "api/internal/handlers."
+	middlewarePackage = "api/internal/middleware."
+
+	bearerSchemeName = "bearerAuth"
+	apiKeySchemeName = "apiKeyAuth"
+	jsonContentType  = "application/json"
+)
+
+// routeAccess is the strictest access check applied to a route
+type routeAccess int
+
+const (
+	accessPublic routeAccess = iota
+	accessAuthenticated
+	// accessRole routes also reject users without the required roles
+	accessRole
+)
+
+// jwtOnlyMiddleware rejects requests authenticated with an API key
+const jwtOnlyMiddleware = "RequireJWT"
+
+// idempotencyMiddleware replays responses for repeated Idempotency-Key headers
+const idempotencyMiddleware = "Idempotency"
+
+// skipIdempotencyMiddleware opts a route out of an idempotent group
+const skipIdempotencyMiddleware = "SkipIdempotency"
+
+// Options configures Generate
+type Options struct {
+	Title   string
+	Version string
+	// HandlersDir is the directory holding the annotated handler sources
+	HandlersDir string
+}
+
+// Generate builds the specification for every route of app served by a
+// handler in the handlers package
+func Generate(app *fiber.App, options Options) (*Document, error) {
+	docs, err := parseHandlerDocs(options.HandlersDir)
+	if err != nil {
+		return nil, err
+	}
+	registry := newSchemaRegistry(schemaTypes)
+
+	document := &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: options.Title, Version: options.Version},
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: registry.components(),
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerSchemeName: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				apiKeySchemeName: {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			},
+		},
+	}
+
+	for _, routes := range app.Stack() {
+		// Group middleware is registered as separate routes ahead of the
+		// endpoints, so remember which prefixes require authentication
+		var authPrefixes, rolePrefixes, jwtPrefixes, idempotentPrefixes []string
+
+		for _, route := range routes {
+			if len(route.Handlers) == 0 {
+				continue
+			}
+			access := accessPublic
+			jwtOnly, idempotent, skipIdempotency := false, false, false
+			for _, handler := range route.Handlers {
+				access = max(access, middlewareAccess(funcName(handler)))
+				jwtOnly = jwtOnly || isMiddleware(funcName(handler), jwtOnlyMiddleware)
+				idempotent = idempotent || isMiddleware(funcName(handler), idempotencyMiddleware)
+				skipIdempotency = skipIdempotency || isMiddleware(funcName(handler), skipIdempotencyMiddleware)
+			}
+
+			name, ok := strings.CutPrefix(funcName(route.Handlers[len(route.Handlers)-1]), handlersPackage)
+			if !ok {
+				if jwtOnly {
+					jwtPrefixes = append(jwtPrefixes, strings.TrimSuffix(route.Path, "/"))
+				}
+				if idempotent {
+					idempotentPrefixes = append(idempotentPrefixes, strings.TrimSuffix(route.Path, "/"))
+				}
+				switch access {
+				case accessAuthenticated:
+					authPrefixes = append(authPrefixes, strings.TrimSuffix(route.Path, "/"))
+				case accessRole:
+					rolePrefixes = append(rolePrefixes, strings.TrimSuffix(route.Path, "/"))
+				}
+				continue
+			}
+			if route.Method == fiber.MethodHead {
+				// Fiber registers a HEAD route for every GET route
+				continue
+			}
+			// Handlers built by constructors run as closures named "HealthCheck.func1"
+			name, _, _ = strings.Cut(name, ".")
+
+			if hasPathPrefix(route.Path, authPrefixes) {
+				access = max(access, accessAuthenticated)
+			}
+			if hasPathPrefix(route.Path, rolePrefixes) {
+				access = max(access, accessRole)
+			}
+			jwtOnly = jwtOnly || hasPathPrefix(route.Path, jwtPrefixes)
+
+			operation, err := buildOperation(registry, name, docs[name], route.Params, access, jwtOnly)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+			}
+			idempotent = idempotent || hasPathPrefix(route.Path, idempotentPrefixes)
+			if idempotent && !skipIdempotency && route.Method == fiber.MethodPost {
+				operation.Parameters = append(operation.Parameters, Parameter{
+					Name:        "Idempotency-Key",
+					In:          "header",
+					Description: "Replays the stored response when repeated within 24 hours",
+					Schema:      &Schema{Type: "string"},
+				})
+			}
+			if err := addOperation(document, route.Method, openAPIPath(route.Path), operation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return document, nil
+}
+
+// Marshal encodes the document as indented JSON or, for format "yaml", YAML
+func Marshal(document *Document, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(document); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case "yaml":
+		return yaml.Marshal(document)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected json or yaml", format)
+	}
+}
+
+func buildOperation(registry *schemaRegistry, name string, doc handlerDoc, params []string, access routeAccess, jwtOnly bool) (*Operation, error) {
+	operation := &Operation{
+		OperationID: name,
+		Summary:     doc.Summary,
+		Description: doc.Description,
+		Responses:   make(map[string]*Response),
+	}
+
+	for _, param := range params {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:     param,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	for _, a := range doc.Annotations {
+		if err := applyAnnotation(registry, operation, a); err != nil {
+			return nil, fmt.Errorf("%s: %w", a.Pos, err)
+		}
+	}
+	if len(operation.Responses) == 0 {
+		return nil, fmt.Errorf("handler %s has no @openapi response annotation", name)
+	}
+
+	if access >= accessAuthenticated {
+		operation.Security = []map[string][]string{{bearerSchemeName: {}}}
+		if !jwtOnly {
+			operation.Security = append(operation.Security, map[string][]string{apiKeySchemeName: {}})
+		}
+		if _, ok := operation.Responses["401"]; !ok {
+			operation.Responses["401"] = errorResponse(fiber.StatusUnauthorized)
+		}
+	}
+	if access == accessRole {
+		if _, ok := operation.Responses["403"]; !ok {
+			operation.Responses["403"] = errorResponse(fiber.StatusForbidden)
+		}
+	}
+	return operation, nil
+}
+
+func applyAnnotation(registry *schemaRegistry, operation *Operation, a annotation) error {
+	switch a.Key {
+	case "summary":
+		operation.Summary = strings.Join(a.Args, " ")
+	case "tag":
+		operation.Tags = append(operation.Tags, strings.Join(a.Args, " "))
+	case "request":
+		if len(a.Args) != 1 {
+			return fmt.Errorf("request expects one type, got %q", a.Args)
+		}
+		schema, err := parseTypeExpr(registry, a.Args[0])
+		if err != nil {
+			return err
+		}
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{jsonContentType: {Schema: schema}},
+		}
+	case "upload":
+		if len(a.Args) != 1 {
+			return fmt.Errorf("upload expects a form field name, got %q", a.Args)
+		}
+		operation.RequestBody = &RequestBody{
+			Required: true,
+			Content: map[string]*MediaType{"multipart/form-data": {Schema: &Schema{
+				Type:       "object",
+				Properties: map[string]*Schema{a.Args[0]: {Type: "string", Format: "binary"}},
+				Required:   []string{a.Args[0]},
+			}}},
+		}
+	case "query":
+		if len(a.Args) != 1 {
+			return fmt.Errorf("query expects one type, got %q", a.Args)
+		}
+		t, ok := registry.lookup(strings.TrimPrefix(a.Args[0], "dto."))
+		if !ok || t.Kind() != reflect.Struct {
+			return fmt.Errorf("unknown query type %s", a.Args[0])
+		}
+		operation.Parameters = append(operation.Parameters, registry.queryParameters(t)...)
+	case "param":
+		if len(a.Args) < 2 {
+			return fmt.Errorf("param expects a name and a type, got %q", a.Args)
+		}
+		schema, err := parseTypeExpr(registry, a.Args[1])
+		if err != nil {
+			return err
+		}
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        a.Args[0],
+			In:          "query",
+			Description: strings.Join(a.Args[2:], " "),
+			Schema:      schema,
+		})
+	case "response":
+		return applyResponse(registry, operation, a.Args)
+	default:
+		return fmt.Errorf("unknown @openapi key %q", a.Key)
+	}
+	return nil
+}
+
+func applyResponse(registry *schemaRegistry, operation *Operation, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("response expects a status code")
+	}
+	status, err := strconv.Atoi(args[0])
+	if err != nil || http.StatusText(status) == "" {
+		return fmt.Errorf("invalid response status %q", args[0])
+	}
+
+	var schema *Schema
+	switch {
+	case len(args) == 1:
+		if status >= fiber.StatusBadRequest {
+			operation.Responses[args[0]] = errorResponse(status)
+			return nil
+		}
+	case strings.Contains(args[1], ":"):
+		// Inline object such as "users:[]dto.UserResponse total:integer"
+		schema = &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, field := range args[1:] {
+			name, expr, ok := strings.Cut(field, ":")
+			if !ok {
+				return fmt.Errorf("invalid response field %q, expected name:type", field)
+			}
+			property, err := parseTypeExpr(registry, expr)
+			if err != nil {
+				return err
+			}
+			schema.Properties[name] = property
+			schema.Required = append(schema.Required, name)
+		}
+	case len(args) == 2:
+		if schema, err = parseTypeExpr(registry, args[1]); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("response expects one type or name:type fields, got %q", args[1:])
+	}
+
+	response := &Response{Description: http.StatusText(status)}
+	if schema != nil {
+		response.Content = map[string]*MediaType{jsonContentType: {Schema: schema}}
+	}
+	operation.Responses[args[0]] = response
+	return nil
+}
+
+// parseTypeExpr resolves annotation types: dto.Name, []T, a JSON primitive or
+// alternatives separated by "|"
+func parseTypeExpr(registry *schemaRegistry, expr string) (*Schema, error) {
+	if strings.Contains(expr, "|") {
+		schema := &Schema{}
+		for _, alternative := range strings.Split(expr, "|") {
+			option, err := parseTypeExpr(registry, alternative)
+			if err != nil {
+				return nil, err
+			}
+			schema.OneOf = append(schema.OneOf, option)
+		}
+		return schema, nil
+	}
+	if elem, ok := strings.CutPrefix(expr, "[]"); ok {
+		items, err := parseTypeExpr(registry, elem)
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	}
+	if name, ok := strings.CutPrefix(expr, "dto."); ok {
+		t, ok := registry.lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown schema %s", expr)
+		}
+		return registry.ref(t), nil
+	}
+	switch expr {
+	case "string", "integer", "number", "boolean":
+		return &Schema{Type: expr}, nil
+	case "object":
+		return &Schema{Type: "object", AdditionalProperties: &Schema{}}, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", expr)
+	}
+}
+
+func errorResponse(status int) *Response {
+	return &Response{
+		Description: http.StatusText(status),
+		Content: map[string]*MediaType{jsonContentType: {
+			Schema: &Schema{Ref: "#/components/schemas/" + errorSchemaName},
+		}},
+	}
+}
+
+func addOperation(document *Document, method, path string, operation *Operation) error {
+	item, ok := document.Paths[path]
+	if !ok {
+		item = &PathItem{}
+	}
+
+	var slot **Operation
+	switch method {
+	case fiber.MethodGet:
+		slot = &item.Get
+	case fiber.MethodPost:
+		slot = &item.Post
+	case fiber.MethodPut:
+		slot = &item.Put
+	case fiber.MethodPatch:
+		slot = &item.Patch
+	case fiber.MethodDelete:
+		slot = &item.Delete
+	default:
+		return fmt.Errorf("unsupported method %s for %s", method, path)
+	}
+
+	if *slot != nil {
+		return fmt.Errorf("%s %s is registered twice", method, path)
+	}
+	*slot = operation
+	document.Paths[path] = item
+	return nil
+}
+
+// middlewareAccess maps the auth and RBAC middleware to the access they enforce
+func middlewareAccess(name string) routeAccess {
+	name, ok := strings.CutPrefix(name, middlewarePackage)
+	if !ok {
+		return accessPublic
+	}
+	constructor, _, _ := strings.Cut(name, ".")
+	switch constructor {
+	case "RequireAuth":
+		return accessAuthenticated
+	case "RequireRole", "RequireAnyRole", "RequireAllRoles", "RequireAdmin":
+		return accessRole
+	default:
+		return accessPublic
+	}
+}
+
+// isMiddleware reports whether name is a handler built by the given
+// middleware constructor
+func isMiddleware(name, constructor string) bool {
+	name, ok := strings.CutPrefix(name, middlewarePackage)
+	if !ok {
+		return false
+	}
+	name, _, _ = strings.Cut(name, ".")
+	return name == constructor
+}
+
+// hasPathPrefix reports whether path is one of prefixes or nested below one
+func hasPathPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// openAPIPath converts Fiber parameters such as ":id" to "{id}"
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + strings.TrimSuffix(name, "?") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func funcName(handler fiber.Handler) string {
+	fn := runtime.FuncForPC(reflect.ValueOf(handler).Pointer())
+	if fn == nil {
+		return ""
+	}
+	return fn.Name()
+}
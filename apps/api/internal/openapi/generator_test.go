@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenAPIPath(t *testing.T) {
+	tests := map[string]string{
+		"/health":                 "/health",
+		"/api/v1/admin/users/:id": "/api/v1/admin/users/{id}",
+		"/api/v1/templates/:id/versions/:version/restore": "/api/v1/templates/{id}/versions/{version}/restore",
+		"/api/v1/items/:id?":                              "/api/v1/items/{id}",
+	}
+	for path, want := range tests {
+		if got := openAPIPath(path); got != want {
+			t.Errorf("openAPIPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestParseHandlerDocs(t *testing.T) {
+	dir := t.TempDir()
+	source := `package handlers
+
+// ListWidgets returns all widgets (admin only). Results are sorted by name.
+// @openapi tag Widget Admin
+// @openapi response 200 widgets:[]string total:integer
+func ListWidgets() {}
+
+// helper is not exported and is ignored
+// @openapi response 200
+func helper() {}
+`
+	if err := os.WriteFile(filepath.Join(dir, "widgets.go"), []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	docs, err := parseHandlerDocs(dir)
+	if err != nil {
+		t.Fatalf("parseHandlerDocs() error = %v", err)
+	}
+	if len(docs) != 1 {
+		t.Fatalf("got %d documented handlers, want 1", len(docs))
+	}
+
+	doc := docs["ListWidgets"]
+	if doc.Summary != "Returns all widgets" {
+		t.Errorf("Summary = %q", doc.Summary)
+	}
+	if doc.Description != "Results are sorted by name." {
+		t.Errorf("Description = %q", doc.Description)
+	}
+	if len(doc.Annotations) != 2 || doc.Annotations[0].Key != "tag" || doc.Annotations[1].Key != "response" {
+		t.Fatalf("Annotations = %+v", doc.Annotations)
+	}
+
+	operation := &Operation{Responses: make(map[string]*Response)}
+	registry := newSchemaRegistry(schemaTypes)
+	for _, a := range doc.Annotations {
+		if err := applyAnnotation(registry, operation, a); err != nil {
+			t.Fatalf("applyAnnotation() error = %v", err)
+		}
+	}
+	if len(operation.Tags) != 1 || operation.Tags[0] != "Widget Admin" {
+		t.Errorf("Tags = %v, want [Widget Admin]", operation.Tags)
+	}
+	schema := operation.Responses["200"].Content[jsonContentType].Schema
+	if schema.Properties["widgets"].Items.Type != "string" || schema.Properties["total"].Type != "integer" {
+		t.Errorf("response schema = %+v", schema)
+	}
+}
+
+func TestApplyAnnotationErrors(t *testing.T) {
+	registry := newSchemaRegistry(schemaTypes)
+	tests := []annotation{
+		{Key: "unknown"},
+		{Key: "request", Args: []string{"dto.DoesNotExist"}},
+		{Key: "response", Args: []string{"abc"}},
+		{Key: "response", Args: []string{"200", "list:[]widget"}},
+		{Key: "query", Args: []string{"dto.UpdateProfileRequest"}},
+	}
+	for _, a := range tests {
+		operation := &Operation{Responses: make(map[string]*Response)}
+		if err := applyAnnotation(registry, operation, a); err == nil {
+			t.Errorf("applyAnnotation(%s %v) expected an error", a.Key, a.Args)
+		}
+	}
+}
+
+func TestErrorResponseWithoutSchema(t *testing.T) {
+	operation := &Operation{Responses: make(map[string]*Response)}
+	registry := newSchemaRegistry(schemaTypes)
+	if err := applyResponse(registry, operation, []string{"404"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyResponse(registry, operation, []string{"204"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if ref := operation.Responses["404"].Content[jsonContentType].Schema.Ref; ref != "#/components/schemas/Error" {
+		t.Errorf("404 schema ref = %q, want the Error schema", ref)
+	}
+	if operation.Responses["204"].Content != nil {
+		t.Error("204 response should have no content")
+	}
+}
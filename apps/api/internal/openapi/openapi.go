@@ -0,0 +1,120 @@
+// Package openapi builds the OpenAPI 3.0 description of the API from the
+// registered Fiber routes, the dto types and "@openapi" annotations in handler
+// doc comments.
+//
+// Annotations are doc-comment lines of the form "// @openapi <key> <value>":
+//
+//	// @openapi tag Auth
+//	// @openapi summary Log in
+//	// @openapi request dto.LoginRequest
+//	// @openapi query dto.PaginationRequest
+//	// @openapi param limit integer Maximum number of events to return
+//	// @openapi upload file
+//	// @openapi response 200 dto.AuthResponse
+//	// @openapi response 200 users:[]dto.UserManagementResponse total:integer
+//	// @openapi response 200 dto.PaginatedUsersResponse|dto.CursorPaginatedUsersResponse
+//	// @openapi response 401
+//
+// Tags may contain spaces. Error responses without a schema use the shared
+// Error schema. Path parameters and bearer authentication are derived from
+// the route itself.
+package openapi
+
+import _ "embed"
+
+// Version is the OpenAPI specification version emitted by the generator
+const Version = "3.0.3"
+
+// Spec is the generated specification served by the API. Regenerate it with
+// "make openapi" after changing routes, handler annotations or dto types.
+//
+//go:embed openapi.json
+var Spec []byte
+
+// SpecYAML is the same specification as YAML, served with the standalone
+// documentation at /docs
+//
+//go:embed openapi.yaml
+var SpecYAML []byte
+
+// Document is the root of an OpenAPI 3.0 specification
+type Document struct {
+	OpenAPI    string               `json:"openapi" yaml:"openapi"`
+	Info       Info                 `json:"info" yaml:"info"`
+	Paths      map[string]*PathItem `json:"paths" yaml:"paths"`
+	Components Components           `json:"components" yaml:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title" yaml:"title"`
+	Version string `json:"version" yaml:"version"`
+}
+
+// PathItem holds the operations registered for a single path
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Put    *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Patch  *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Delete *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId" yaml:"operationId"`
+	Summary     string                `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses" yaml:"responses"`
+	Security    []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name" yaml:"name"`
+	In          string  `json:"in" yaml:"in"`
+	Description string  `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      *Schema `json:"schema" yaml:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required" yaml:"required"`
+	Content  map[string]*MediaType `json:"content" yaml:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description" yaml:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema" yaml:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas" yaml:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes" yaml:"securitySchemes"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type" yaml:"type"`
+	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	// In and Name locate the key of apiKey schemes
+	In   string `json:"in,omitempty" yaml:"in,omitempty"`
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+// Schema is the subset of the OpenAPI schema object used by the generator
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	Type                 string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format               string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty" yaml:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
+}
@@ -0,0 +1,334 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"api/internal/dto"
+)
+
+// errorSchemaName is the component used for error responses without a schema
+const errorSchemaName = "Error"
+
+// schemaTypes lists the dto types published as component schemas. Every
+// exported dto type must be listed here; annotations can only reference
+// registered types.
+var schemaTypes = []any{
+	dto.APIKeyResponse{},
+	dto.AcceptInvitationRequest{},
+	dto.AcceptToSRequest{},
+	dto.AccessWindow{},
+	dto.ActiveAnnouncementResponse{},
+	dto.AddEmailRequest{},
+	dto.AdminRegisterUserRequest{},
+	dto.AdminResetPasswordRequest{},
+	dto.AdminStatsResponse{},
+	dto.AnnouncementResponse{},
+	dto.AssignPermissionToRolesResponse{},
+	dto.AssignPermissionsToRoleRequest{},
+	dto.AuditLogListRequest{},
+	dto.AuditLogResponse{},
+	dto.AuthResponse{},
+	dto.BulkRoleUpdateEntry{},
+	dto.BulkRoleUpdateResult{},
+	dto.BulkUpdateRolesRequest{},
+	dto.BulkUpdateRolesResponse{},
+	dto.ChangePasswordRequest{},
+	dto.CheckPermissionsRequest{},
+	dto.CheckPermissionsResponse{},
+	dto.CloneEmailTemplateRequest{},
+	dto.CloneRoleRequest{},
+	dto.CompanyResponse{},
+	dto.CreateAPIKeyRequest{},
+	dto.CreateAPIKeyResponse{},
+	dto.CreateAnnouncementRequest{},
+	dto.CreateCompanyRequest{},
+	dto.CreateEmailTemplateRequest{},
+	dto.CreateInvitationRequest{},
+	dto.CreatePermissionCategoryRequest{},
+	dto.CreatePermissionRequest{},
+	dto.CreateRoleRequest{},
+	dto.CreateTagRequest{},
+	dto.CreateWebhookRequest{},
+	dto.CreateWebhookResponse{},
+	dto.CursorPaginatedUsersResponse{},
+	dto.DBStatsResponse{},
+	dto.DefaultRoleRequest{},
+	dto.DefaultRoleResponse{},
+	dto.DependencyHealthResponse{},
+	dto.EmailTemplateListResponse{},
+	dto.EmailTemplateResponse{},
+	dto.EmailTemplateVersionResponse{},
+	dto.EraseAccountRequest{},
+	dto.ForgotPasswordRequest{},
+	dto.ImpersonationResponse{},
+	dto.InvitationResponse{},
+	dto.JWK{},
+	dto.JWKSResponse{},
+	dto.LoginEventResponse{},
+	dto.LoginRequest{},
+	dto.MaintenanceModeRequest{},
+	dto.MaintenanceModeResponse{},
+	dto.MergeUsersRequest{},
+	dto.MergeUsersResponse{},
+	dto.MessageResponse{},
+	dto.MissingIndexesResponse{},
+	dto.NullableString{},
+	dto.NullableTime{},
+	dto.PaginatedAuditLogsResponse{},
+	dto.PatchProfileRequest{},
+	dto.PatchUserRequest{},
+	dto.PaginatedRolesResponse{},
+	dto.PaginatedUsersResponse{},
+	dto.PaginationRequest{},
+	dto.PasswordResetTokenExport{},
+	dto.PermissionCategoryResponse{},
+	dto.PermissionListRequest{},
+	dto.PermissionResponse{},
+	dto.PermissionRolesRequest{},
+	dto.PreferenceResponse{},
+	dto.PreferencesResponse{},
+	dto.PreviewEmailTemplateRequest{},
+	dto.PreviewEmailTemplateResponse{},
+	dto.ProfileResponse{},
+	dto.PublishToSVersionRequest{},
+	dto.QueryPlanResponse{},
+	dto.RBACExport{},
+	dto.RBACExportPermission{},
+	dto.RBACExportRole{},
+	dto.RBACImportResponse{},
+	dto.RegisterRequest{},
+	dto.RemovePermissionFromRolesResponse{},
+	dto.ReplaceProfileRequest{},
+	dto.RequestQueryCountResponse{},
+	dto.ResetPasswordRequest{},
+	dto.RoleAssignmentResponse{},
+	dto.RoleListRequest{},
+	dto.RoleResponse{},
+	dto.RoleUsersRequest{},
+	dto.SessionResponse{},
+	dto.SlowRequestsResponse{},
+	dto.TableAnalysisResponse{},
+	dto.TableScanStatsResponse{},
+	dto.TagResponse{},
+	dto.TemplateVariablesResponse{},
+	dto.TestEmailTemplateRequest{},
+	dto.ToSVersionResponse{},
+	dto.UpdateAnnouncementRequest{},
+	dto.UpdateCompanyRequest{},
+	dto.UpdateEmailTemplateRequest{},
+	dto.UpdatePermissionCategoryRequest{},
+	dto.UpdatePermissionRequest{},
+	dto.UpdatePreferenceRequest{},
+	dto.UpdateRoleRequest{},
+	dto.UpdateRolesRequest{},
+	dto.UpdateTokenSettingsRequest{},
+	dto.UpdateUserRequest{},
+	dto.UpdateUserTagsRequest{},
+	dto.UpdateWebhookRequest{},
+	dto.UserDataExport{},
+	dto.UserDetailResponse{},
+	dto.UserEmailResponse{},
+	dto.UserExportRequest{},
+	dto.UserImportFailure{},
+	dto.UserImportResponse{},
+	dto.UserManagementResponse{},
+	dto.UserResponse{},
+	dto.VerifyEmailRequest{},
+	dto.WebhookDeliveryResponse{},
+	dto.WebhookResponse{},
+}
+
+var (
+	timeType           = reflect.TypeOf(time.Time{})
+	nullableStringType = reflect.TypeOf(dto.NullableString{})
+	nullableTimeType   = reflect.TypeOf(dto.NullableTime{})
+	marshalerType      = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaRegistry converts Go types to schemas, referencing registered types
+// by name instead of inlining them
+type schemaRegistry struct {
+	names map[reflect.Type]string
+	types map[string]reflect.Type
+}
+
+func newSchemaRegistry(values []any) *schemaRegistry {
+	registry := &schemaRegistry{
+		names: make(map[reflect.Type]string),
+		types: make(map[string]reflect.Type),
+	}
+	for _, value := range values {
+		t := reflect.TypeOf(value)
+		registry.names[t] = t.Name()
+		registry.types[t.Name()] = t
+	}
+	return registry
+}
+
+// components returns the schema of every registered type plus the Error schema
+func (r *schemaRegistry) components() map[string]*Schema {
+	schemas := map[string]*Schema{
+		errorSchemaName: {
+			Type: "object",
+			Properties: map[string]*Schema{
+				"error":   {Type: "string"},
+				"code":    {Type: "string"},
+				"details": {Type: "object", Nullable: true},
+			},
+			Required: []string{"error", "code", "details"},
+		},
+	}
+	for name, t := range r.types {
+		schemas[name] = r.define(t)
+	}
+	return schemas
+}
+
+// lookup returns the registered type with the given name
+func (r *schemaRegistry) lookup(name string) (reflect.Type, bool) {
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// ref returns a schema for t, using a $ref when t is registered
+func (r *schemaRegistry) ref(t reflect.Type) *Schema {
+	if name, ok := r.names[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+	return r.define(t)
+}
+
+// define returns the inline schema for t
+func (r *schemaRegistry) define(t reflect.Type) *Schema {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	if t == nullableStringType {
+		return &Schema{Type: "string", Nullable: true}
+	}
+	if t == nullableTimeType {
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	}
+	if t.Implements(marshalerType) {
+		// Custom JSON encodings such as models.JSONB can hold any value
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := r.ref(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.ref(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.ref(t.Elem())}
+	case reflect.Struct:
+		return r.defineStruct(t)
+	default:
+		return &Schema{}
+	}
+}
+
+func (r *schemaRegistry) defineStruct(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	return schema
+}
+
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs without a JSON name are flattened like encoding/json does
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			r.addFields(schema, field.Type)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.ref(field.Type)
+		rules := strings.Split(field.Tag.Get("validate"), ",")
+		for _, rule := range rules {
+			if rule == "email" && property.Type == "string" {
+				property.Format = "email"
+			}
+		}
+		schema.Properties[name] = property
+
+		if rules[0] == "required" && !omitEmpty {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// jsonFieldName returns the encoded name of a struct field and whether it is
+// omitted when empty. ok is false for fields encoding/json skips.
+func jsonFieldName(field reflect.StructField) (name string, omitEmpty bool, ok bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, false
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, true
+}
+
+// queryParameters describes the fields of a query struct such as
+// dto.PaginationRequest, named by their query or form tags
+func (r *schemaRegistry) queryParameters(t reflect.Type) []Parameter {
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Tag.Get("query")
+		if name == "" {
+			name = field.Tag.Get("form")
+		}
+		if name == "" || name == "-" {
+			continue
+		}
+		parameters = append(parameters, Parameter{
+			Name:   strings.Split(name, ",")[0],
+			In:     "query",
+			Schema: r.define(field.Type),
+		})
+	}
+	return parameters
+}
@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSchemaTypesCoverDTOPackage(t *testing.T) {
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, "../dto", nil, 0)
+	if err != nil {
+		t.Fatalf("failed to parse dto package: %v", err)
+	}
+
+	registry := newSchemaRegistry(schemaTypes)
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					name := spec.(*ast.TypeSpec).Name.Name
+					if _, ok := registry.lookup(name); ast.IsExported(name) && !ok {
+						t.Errorf("dto.%s is not listed in schemaTypes", name)
+					}
+				}
+			}
+		}
+	}
+}
+
+type schemaTestNested struct {
+	Value string `json:"value"`
+}
+
+type schemaTestEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type schemaTestRequest struct {
+	schemaTestEmbedded
+	Email     string             `json:"email" validate:"required,email"`
+	Name      *string            `json:"name,omitempty" validate:"omitempty,min=2"`
+	Count     int64              `json:"count"`
+	Tags      []string           `json:"tags"`
+	Nested    schemaTestNested   `json:"nested"`
+	Items     []schemaTestNested `json:"items"`
+	CreatedAt time.Time          `json:"created_at"`
+	Extra     map[string]any     `json:"extra"`
+	Hidden    string             `json:"-"`
+	internal  string
+}
+
+func TestDefineStruct(t *testing.T) {
+	registry := newSchemaRegistry([]any{schemaTestNested{}})
+	schema := registry.define(reflect.TypeOf(schemaTestRequest{}))
+
+	if schema.Type != "object" {
+		t.Fatalf("Type = %s, want object", schema.Type)
+	}
+	if !reflect.DeepEqual(schema.Required, []string{"email"}) {
+		t.Errorf("Required = %v, want [email]", schema.Required)
+	}
+
+	tests := []struct {
+		property string
+		want     Schema
+	}{
+		{"embedded", Schema{Type: "string"}},
+		{"email", Schema{Type: "string", Format: "email"}},
+		{"name", Schema{Type: "string", Nullable: true}},
+		{"count", Schema{Type: "integer", Format: "int64"}},
+		{"tags", Schema{Type: "array", Items: &Schema{Type: "string"}}},
+		{"nested", Schema{Ref: "#/components/schemas/schemaTestNested"}},
+		{"items", Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/schemaTestNested"}}},
+		{"created_at", Schema{Type: "string", Format: "date-time"}},
+		{"extra", Schema{Type: "object", AdditionalProperties: &Schema{}}},
+	}
+	for _, tt := range tests {
+		got, ok := schema.Properties[tt.property]
+		if !ok {
+			t.Errorf("property %s missing", tt.property)
+			continue
+		}
+		if !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("property %s = %+v, want %+v", tt.property, *got, tt.want)
+		}
+	}
+
+	if len(schema.Properties) != len(tests) {
+		t.Errorf("got %d properties, want %d", len(schema.Properties), len(tests))
+	}
+}
+
+func TestQueryParameters(t *testing.T) {
+	type query struct {
+		Page   int    `json:"page" form:"page"`
+		Action string `json:"action" query:"action"`
+		Body   string `json:"body"`
+	}
+
+	parameters := newSchemaRegistry(nil).queryParameters(reflect.TypeOf(query{}))
+	if len(parameters) != 2 {
+		t.Fatalf("got %d parameters, want 2", len(parameters))
+	}
+	if parameters[0].Name != "page" || parameters[0].Schema.Type != "integer" {
+		t.Errorf("parameters[0] = %+v, want page integer", parameters[0])
+	}
+	if parameters[1].Name != "action" || parameters[1].In != "query" {
+		t.Errorf("parameters[1] = %+v, want action in query", parameters[1])
+	}
+}
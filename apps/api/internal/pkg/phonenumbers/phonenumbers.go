@@ -14,11 +14,23 @@ var (
 	ErrMissingCountryCode = errors.New("missing country code")
 )
 
+// NumberType is the kind of line a phone number belongs to
+type NumberType string
+
+const (
+	Mobile    NumberType = "mobile"
+	FixedLine NumberType = "fixed_line"
+	TollFree  NumberType = "toll_free"
+	VoIP      NumberType = "voip"
+	Unknown   NumberType = "unknown"
+)
+
 type PhoneNumber struct {
 	Number     string
 	Region     string
 	IsValid    bool
 	E164Format string
+	NumberType string
 }
 
 func ParseAndValidate(number, defaultRegion string) (*PhoneNumber, error) {
@@ -48,9 +60,53 @@ func ParseAndValidate(number, defaultRegion string) (*PhoneNumber, error) {
 		Region:     region,
 		IsValid:    isValid,
 		E164Format: e164,
+		NumberType: string(numberType(num)),
 	}, nil
 }
 
+// GetNumberType reports whether a valid number is a mobile, fixed-line,
+// toll-free or VoIP number
+func GetNumberType(number, region string) (NumberType, error) {
+	if region == "" {
+		region = DefaultPhoneRegion
+	}
+
+	num, err := phonenumbers.Parse(number, region)
+	if err != nil {
+		return Unknown, fmt.Errorf("%w: %v", ErrInvalidPhoneNumber, err)
+	}
+
+	if !phonenumbers.IsValidNumber(num) {
+		return Unknown, ErrInvalidPhoneNumber
+	}
+
+	return numberType(num), nil
+}
+
+// IsMobileNumber reports whether number is a valid mobile number
+func IsMobileNumber(number, region string) bool {
+	numberType, err := GetNumberType(number, region)
+	return err == nil && numberType == Mobile
+}
+
+// numberType maps the library's number types onto NumberType. Regions such as
+// the US do not tell mobile and fixed-line numbers apart; those numbers are
+// reported as Mobile, since they may be one.
+func numberType(num *phonenumbers.PhoneNumber) NumberType {
+	switch phonenumbers.GetNumberType(num) {
+	case phonenumbers.MOBILE, phonenumbers.FIXED_LINE_OR_MOBILE:
+		return Mobile
+	case phonenumbers.FIXED_LINE:
+		return FixedLine
+	case phonenumbers.TOLL_FREE:
+		return TollFree
+	case phonenumbers.VOIP:
+		return VoIP
+	default:
+		return Unknown
+	}
+}
+
 func FormatPhone(number, region string, format phonenumbers.PhoneNumberFormat) (string, error) {
 	num, err := phonenumbers.Parse(number, region)
 	if err != nil {
@@ -477,4 +477,174 @@ func TestGetRegion(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestGetNumberType(t *testing.T) {
+	tests := []struct {
+		name        string
+		number      string
+		region      string
+		expected    NumberType
+		shouldError bool
+	}{
+		{
+			name:     "US number is reported as mobile",
+			number:   "(202) 456-1414",
+			region:   "US",
+			expected: Mobile,
+		},
+		{
+			name:     "US toll-free number",
+			number:   "+1 800-555-0199",
+			region:   "US",
+			expected: TollFree,
+		},
+		{
+			name:     "Indonesian mobile number",
+			number:   "0821-1234-5678",
+			region:   "ID",
+			expected: Mobile,
+		},
+		{
+			name:     "Indonesian fixed-line number",
+			number:   "+62 21 1234 5678",
+			region:   "ID",
+			expected: FixedLine,
+		},
+		{
+			name:     "Indonesian toll-free number",
+			number:   "0800 1234 567",
+			region:   "ID",
+			expected: TollFree,
+		},
+		{
+			name:     "UK mobile number",
+			number:   "07400 123456",
+			region:   "GB",
+			expected: Mobile,
+		},
+		{
+			name:     "UK fixed-line number",
+			number:   "+44 20 7946 0958",
+			region:   "GB",
+			expected: FixedLine,
+		},
+		{
+			name:     "UK toll-free number",
+			number:   "+44 800 123 4567",
+			region:   "GB",
+			expected: TollFree,
+		},
+		{
+			name:     "UK VoIP number",
+			number:   "+44 56 1234 5678",
+			region:   "GB",
+			expected: VoIP,
+		},
+		{
+			name:     "US premium-rate number is unknown",
+			number:   "+1 900-555-0199",
+			region:   "US",
+			expected: Unknown,
+		},
+		{
+			name:        "Invalid number",
+			number:      "abc",
+			region:      "US",
+			expected:    Unknown,
+			shouldError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := GetNumberType(tt.number, tt.region)
+
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("Expected error for number %s, but got none", tt.number)
+				}
+			} else if err != nil {
+				t.Errorf("Unexpected error for number %s: %v", tt.number, err)
+				return
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+
+			if tt.shouldError {
+				return
+			}
+
+			phoneData, err := ParseAndValidate(tt.number, tt.region)
+			if err != nil {
+				t.Fatalf("Unexpected error parsing number %s: %v", tt.number, err)
+			}
+			if phoneData.NumberType != string(tt.expected) {
+				t.Errorf("Expected ParseAndValidate number type %s, got %s", tt.expected, phoneData.NumberType)
+			}
+		})
+	}
+}
+
+func TestIsMobileNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		number   string
+		region   string
+		expected bool
+	}{
+		{
+			name:     "US number",
+			number:   "(202) 456-1414",
+			region:   "US",
+			expected: true,
+		},
+		{
+			name:     "Indonesian mobile number",
+			number:   "+62 821-1234-5678",
+			region:   "ID",
+			expected: true,
+		},
+		{
+			name:     "Indonesian fixed-line number",
+			number:   "021 1234 5678",
+			region:   "ID",
+			expected: false,
+		},
+		{
+			name:     "UK mobile number",
+			number:   "+44 7400 123456",
+			region:   "GB",
+			expected: true,
+		},
+		{
+			name:     "UK toll-free number",
+			number:   "0800 123 4567",
+			region:   "GB",
+			expected: false,
+		},
+		{
+			name:     "Default region when empty",
+			number:   "0821-1234-5678",
+			region:   "",
+			expected: true,
+		},
+		{
+			name:     "Invalid number",
+			number:   "abc",
+			region:   "US",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := IsMobileNumber(tt.number, tt.region)
+
+			if result != tt.expected {
+				t.Errorf("Expected %v for number %s, got %v", tt.expected, tt.number, result)
+			}
+		})
+	}
+}
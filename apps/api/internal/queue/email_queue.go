@@ -0,0 +1,167 @@
+package queue
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"api/internal/logger"
+)
+
+var ErrQueueFull = errors.New("email queue is full")
+var ErrQueueClosed = errors.New("email queue is closed")
+
+// EmailJob is an email waiting to be delivered. It is either fully rendered
+// or, for providers with hosted templates, refers to a template by ID.
+type EmailJob struct {
+	To           string
+	Subject      string
+	HTMLContent  string
+	TextContent  string
+	TemplateID   string
+	TemplateData map[string]string
+}
+
+// Sender delivers a single email. Workers call it once per attempt.
+type Sender interface {
+	Deliver(job EmailJob) error
+}
+
+// Config holds configuration for an EmailQueue
+type Config struct {
+	Workers    int
+	BufferSize int
+	MaxRetries int
+	// BaseBackoff is the delay before the first retry; it doubles on each further retry
+	BaseBackoff time.Duration
+}
+
+// Stats is a snapshot of queue activity
+type Stats struct {
+	Depth     int    `json:"depth"`
+	Capacity  int    `json:"capacity"`
+	Workers   int    `json:"workers"`
+	Processed uint64 `json:"processed"`
+	Failed    uint64 `json:"failed"`
+}
+
+// EmailQueue delivers emails in the background using a fixed pool of workers
+type EmailQueue struct {
+	jobs      chan EmailJob
+	sender    Sender
+	config    Config
+	processed atomic.Uint64
+	failed    atomic.Uint64
+	wg        sync.WaitGroup
+	mu        sync.RWMutex
+	closed    bool
+}
+
+// NewEmailQueue creates a queue and starts its workers
+func NewEmailQueue(sender Sender, config Config) *EmailQueue {
+	if config.Workers <= 0 {
+		config.Workers = 1
+	}
+	if config.BufferSize <= 0 {
+		config.BufferSize = 100
+	}
+	if config.MaxRetries < 0 {
+		config.MaxRetries = 0
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = time.Second
+	}
+
+	q := &EmailQueue{
+		jobs:   make(chan EmailJob, config.BufferSize),
+		sender: sender,
+		config: config,
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	return q
+}
+
+// Enqueue schedules a job for delivery without waiting for it to be sent
+func (q *EmailQueue) Enqueue(job EmailJob) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// Stats returns the current queue depth and delivery counters
+func (q *EmailQueue) Stats() Stats {
+	return Stats{
+		Depth:     len(q.jobs),
+		Capacity:  cap(q.jobs),
+		Workers:   q.config.Workers,
+		Processed: q.processed.Load(),
+		Failed:    q.failed.Load(),
+	}
+}
+
+// Close stops accepting jobs and waits for queued jobs to be delivered
+func (q *EmailQueue) Close() {
+	q.mu.Lock()
+	if q.closed {
+		q.mu.Unlock()
+		return
+	}
+	q.closed = true
+	close(q.jobs)
+	q.mu.Unlock()
+
+	q.wg.Wait()
+}
+
+func (q *EmailQueue) work() {
+	defer q.wg.Done()
+	for job := range q.jobs {
+		q.process(job)
+	}
+}
+
+// process delivers a job, retrying with exponential backoff. Jobs that
+// exhaust their retries are written to the dead-letter log.
+func (q *EmailQueue) process(job EmailJob) {
+	var err error
+	for attempt := 0; attempt <= q.config.MaxRetries; attempt++ {
+		if err = q.sender.Deliver(job); err == nil {
+			q.processed.Add(1)
+			return
+		}
+
+		if attempt < q.config.MaxRetries {
+			waitTime := q.config.BaseBackoff << attempt
+			logger.Warn("Failed to send queued email, retrying",
+				"to", job.To,
+				"attempt", attempt+1,
+				"max_retries", q.config.MaxRetries,
+				"error", err,
+				"wait_time", waitTime)
+			time.Sleep(waitTime)
+		}
+	}
+
+	q.failed.Add(1)
+	logger.Error("Email moved to dead-letter log",
+		"to", job.To,
+		"subject", job.Subject,
+		"attempts", q.config.MaxRetries+1,
+		"error", err)
+}
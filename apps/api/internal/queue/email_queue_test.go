@@ -0,0 +1,93 @@
+package queue
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSender struct {
+	mu        sync.Mutex
+	failUntil int
+	attempts  map[string]int
+}
+
+func newFakeSender(failUntil int) *fakeSender {
+	return &fakeSender{failUntil: failUntil, attempts: make(map[string]int)}
+}
+
+func (s *fakeSender) Deliver(job EmailJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts[job.To]++
+	if s.attempts[job.To] <= s.failUntil {
+		return errors.New("smtp unavailable")
+	}
+	return nil
+}
+
+func (s *fakeSender) attemptsFor(to string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts[to]
+}
+
+func TestEmailQueueDelivers(t *testing.T) {
+	sender := newFakeSender(0)
+	q := NewEmailQueue(sender, Config{Workers: 2, BufferSize: 10, MaxRetries: 3, BaseBackoff: time.Millisecond})
+
+	for _, to := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		if err := q.Enqueue(EmailJob{To: to, Subject: "Hello"}); err != nil {
+			t.Fatalf("Enqueue(%s) error = %v", to, err)
+		}
+	}
+	q.Close()
+
+	stats := q.Stats()
+	if stats.Processed != 3 || stats.Failed != 0 {
+		t.Errorf("Stats() = %+v, want 3 processed and 0 failed", stats)
+	}
+	if stats.Depth != 0 {
+		t.Errorf("Stats().Depth = %d, want 0 after Close", stats.Depth)
+	}
+}
+
+func TestEmailQueueRetries(t *testing.T) {
+	sender := newFakeSender(2)
+	q := NewEmailQueue(sender, Config{Workers: 1, MaxRetries: 3, BaseBackoff: time.Millisecond})
+
+	q.Enqueue(EmailJob{To: "retry@example.com"})
+	q.Close()
+
+	if got := sender.attemptsFor("retry@example.com"); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+	if stats := q.Stats(); stats.Processed != 1 || stats.Failed != 0 {
+		t.Errorf("Stats() = %+v, want 1 processed and 0 failed", stats)
+	}
+}
+
+func TestEmailQueueDeadLetter(t *testing.T) {
+	sender := newFakeSender(100)
+	q := NewEmailQueue(sender, Config{Workers: 1, MaxRetries: 2, BaseBackoff: time.Millisecond})
+
+	q.Enqueue(EmailJob{To: "dead@example.com"})
+	q.Close()
+
+	if got := sender.attemptsFor("dead@example.com"); got != 3 {
+		t.Errorf("attempts = %d, want 3 (1 try + 2 retries)", got)
+	}
+	if stats := q.Stats(); stats.Processed != 0 || stats.Failed != 1 {
+		t.Errorf("Stats() = %+v, want 0 processed and 1 failed", stats)
+	}
+}
+
+func TestEmailQueueRejectsAfterClose(t *testing.T) {
+	q := NewEmailQueue(newFakeSender(0), Config{Workers: 1})
+	q.Close()
+
+	if err := q.Enqueue(EmailJob{To: "late@example.com"}); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Enqueue() after Close error = %v, want %v", err, ErrQueueClosed)
+	}
+}
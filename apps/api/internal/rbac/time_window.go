@@ -0,0 +1,221 @@
+// Package rbac holds role evaluation rules that do not need the database
+package rbac
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	// Embed the time zone database so windows validate on hosts without one
+	_ "time/tzdata"
+)
+
+// weekdays maps the day names accepted in an access window to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// AccessWindow is a weekly period during which a role is active, such as
+// {"days":["Mon","Fri"],"start":"09:00","end":"17:00","tz":"UTC"}. Start and
+// End are HH:MM times in the TZ time zone, UTC when empty. A window whose end
+// is not after its start runs past midnight, and Days names the day it starts
+// on; no days means every day.
+type AccessWindow struct {
+	Days  []string `json:"days"`
+	Start string   `json:"start"`
+	End   string   `json:"end"`
+	TZ    string   `json:"tz"`
+}
+
+// AccessWindows is a role's list of access windows, stored as a JSON array. A
+// role with no windows is always active.
+type AccessWindows []AccessWindow
+
+// Validate checks every window's days, times and time zone
+func (ws AccessWindows) Validate() error {
+	for i, w := range ws {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("access window %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the window's days, times and time zone
+func (w AccessWindow) Validate() error {
+	for _, day := range w.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("unknown day %q, use Mon to Sun", day)
+		}
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return fmt.Errorf("invalid start: %w", err)
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return fmt.Errorf("invalid end: %w", err)
+	}
+	if start == end {
+		return errors.New("start and end must differ")
+	}
+	if _, err := w.location(); err != nil {
+		return fmt.Errorf("unknown time zone %q", w.TZ)
+	}
+	return nil
+}
+
+// ActiveAt reports whether t falls inside any of the windows. It is true when
+// there are no windows.
+func (ws AccessWindows) ActiveAt(t time.Time) bool {
+	if len(ws) == 0 {
+		return true
+	}
+	for _, w := range ws {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextChange returns the first window start or end after t, when ActiveAt may
+// next change. ok is false when there are no windows.
+func (ws AccessWindows) NextChange(t time.Time) (next time.Time, ok bool) {
+	for _, w := range ws {
+		if boundary, found := w.nextBoundary(t); found && (!ok || boundary.Before(next)) {
+			next, ok = boundary, true
+		}
+	}
+	return next, ok
+}
+
+// Contains reports whether t falls inside the window. An invalid window
+// contains nothing.
+func (w AccessWindow) Contains(t time.Time) bool {
+	start, end, loc, err := w.parse()
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	if start < end {
+		return w.onDay(local.Weekday()) && minute >= start && minute < end
+	}
+	// Past midnight: the evening part belongs to today, the morning part to
+	// the window that started yesterday
+	if minute >= start {
+		return w.onDay(local.Weekday())
+	}
+	return minute < end && w.onDay(local.AddDate(0, 0, -1).Weekday())
+}
+
+// nextBoundary returns the first time after t at which the window opens or
+// closes
+func (w AccessWindow) nextBoundary(t time.Time) (time.Time, bool) {
+	start, end, loc, err := w.parse()
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	var next time.Time
+	// Windows starting yesterday may still close today, and every day of the
+	// week is covered within the following seven days
+	for offset := -1; offset <= 7; offset++ {
+		day := midnight.AddDate(0, 0, offset)
+		if !w.onDay(day.Weekday()) {
+			continue
+		}
+		opens := atMinute(day, start)
+		closes := atMinute(day, end)
+		if end <= start {
+			closes = atMinute(day.AddDate(0, 0, 1), end)
+		}
+		for _, boundary := range []time.Time{opens, closes} {
+			if boundary.After(t) && (next.IsZero() || boundary.Before(next)) {
+				next = boundary
+			}
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// parse returns the window's start and end as minutes past midnight along with
+// its location
+func (w AccessWindow) parse() (start, end int, loc *time.Location, err error) {
+	if start, err = parseClock(w.Start); err != nil {
+		return 0, 0, nil, err
+	}
+	if end, err = parseClock(w.End); err != nil {
+		return 0, 0, nil, err
+	}
+	loc, err = w.location()
+	return start, end, loc, err
+}
+
+func (w AccessWindow) location() (*time.Location, error) {
+	if w.TZ == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.TZ)
+}
+
+func (w AccessWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, name := range w.Days {
+		if weekdays[strings.ToLower(name)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock parses an HH:MM time into minutes past midnight
+func parseClock(value string) (int, error) {
+	clock, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an HH:MM time", value)
+	}
+	return clock.Hour()*60 + clock.Minute(), nil
+}
+
+// atMinute returns minute minutes into day, a local midnight
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minute/60, minute%60, 0, 0, day.Location())
+}
+
+func (ws AccessWindows) Value() (driver.Value, error) {
+	if ws == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(ws)
+	return string(data), err
+}
+
+func (ws *AccessWindows) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*ws = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, ws)
+	case string:
+		return json.Unmarshal([]byte(v), ws)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+}
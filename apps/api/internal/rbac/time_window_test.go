@@ -0,0 +1,106 @@
+package rbac
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAccessWindowsActiveAt(t *testing.T) {
+	businessHours := AccessWindows{{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00", TZ: "UTC"}}
+	nightShift := AccessWindows{{Days: []string{"Fri"}, Start: "22:00", End: "06:00"}}
+	jakarta := AccessWindows{{Start: "09:00", End: "17:00", TZ: "Asia/Jakarta"}}
+
+	tests := []struct {
+		name    string
+		windows AccessWindows
+		at      time.Time
+		want    bool
+	}{
+		{name: "no windows", windows: nil, at: time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC), want: true},
+		{name: "weekday inside", windows: businessHours, at: time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), want: true},
+		{name: "at start", windows: businessHours, at: time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC), want: true},
+		{name: "at end", windows: businessHours, at: time.Date(2026, 10, 14, 17, 0, 0, 0, time.UTC), want: false},
+		{name: "weekday before", windows: businessHours, at: time.Date(2026, 10, 14, 8, 59, 0, 0, time.UTC), want: false},
+		{name: "weekend", windows: businessHours, at: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), want: false},
+		{name: "past midnight evening", windows: nightShift, at: time.Date(2026, 10, 16, 23, 0, 0, 0, time.UTC), want: true},
+		{name: "past midnight morning", windows: nightShift, at: time.Date(2026, 10, 17, 5, 0, 0, 0, time.UTC), want: true},
+		{name: "past midnight wrong day", windows: nightShift, at: time.Date(2026, 10, 16, 5, 0, 0, 0, time.UTC), want: false},
+		{name: "time zone inside", windows: jakarta, at: time.Date(2026, 10, 14, 3, 0, 0, 0, time.UTC), want: true},
+		{name: "time zone outside", windows: jakarta, at: time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.windows.ActiveAt(tt.at); got != tt.want {
+				t.Errorf("ActiveAt(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessWindowsNextChange(t *testing.T) {
+	windows := AccessWindows{{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"}}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{name: "inside closes", at: time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC), want: time.Date(2026, 10, 14, 17, 0, 0, 0, time.UTC)},
+		{name: "before opens", at: time.Date(2026, 10, 14, 7, 0, 0, 0, time.UTC), want: time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)},
+		{name: "weekend opens monday", at: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), want: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := windows.NextChange(tt.at)
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("NextChange(%v) = %v, %v, want %v", tt.at, got, ok, tt.want)
+			}
+		})
+	}
+
+	if _, ok := AccessWindows(nil).NextChange(time.Now()); ok {
+		t.Error("NextChange() without windows should not report a change")
+	}
+}
+
+func TestAccessWindowValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		window  AccessWindow
+		wantErr bool
+	}{
+		{name: "valid", window: AccessWindow{Days: []string{"mon", "FRI"}, Start: "09:00", End: "17:00", TZ: "Europe/Berlin"}},
+		{name: "past midnight", window: AccessWindow{Start: "22:00", End: "06:00"}},
+		{name: "unknown day", window: AccessWindow{Days: []string{"Funday"}, Start: "09:00", End: "17:00"}, wantErr: true},
+		{name: "bad start", window: AccessWindow{Start: "9am", End: "17:00"}, wantErr: true},
+		{name: "bad end", window: AccessWindow{Start: "09:00", End: "25:00"}, wantErr: true},
+		{name: "empty window", window: AccessWindow{Start: "09:00", End: "09:00"}, wantErr: true},
+		{name: "unknown time zone", window: AccessWindow{Start: "09:00", End: "17:00", TZ: "Mars/Olympus"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.window.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccessWindowsScan(t *testing.T) {
+	var windows AccessWindows
+	if err := windows.Scan([]byte(`[{"days":["Mon"],"start":"09:00","end":"17:00","tz":"UTC"}]`)); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if len(windows) != 1 || windows[0].Start != "09:00" || windows[0].Days[0] != "Mon" {
+		t.Errorf("Scan() = %+v", windows)
+	}
+
+	value, err := AccessWindows(nil).Value()
+	if err != nil || value != "[]" {
+		t.Errorf("Value() = %v, %v, want []", value, err)
+	}
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"slices"
+	"strings"
+
+	apperrors "api/internal/errors"
+	"api/internal/helpers"
+	"api/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+)
+
+// CORSConfig maps route prefixes to the CORS policy applied below them. A
+// request uses the policy of the longest matching prefix; "/" covers routes
+// outside any other prefix.
+type CORSConfig map[string]cors.Config
+
+// LoadCORSConfig builds the per-group CORS policy for the API routes under
+// v1Prefix from environment variables
+func LoadCORSConfig(v1Prefix string) CORSConfig {
+	defaultOrigins := helpers.GetEnv("CORS_ALLOWED_ORIGINS", "*")
+	apiOrigins := helpers.GetEnv("CORS_API_ORIGINS", defaultOrigins)
+
+	return CORSConfig{
+		"/":      newCORSPolicy(defaultOrigins),
+		v1Prefix: newCORSPolicy(apiOrigins),
+		// Mobile clients log in from arbitrary origins
+		v1Prefix + "/auth":  newCORSPolicy(helpers.GetEnv("CORS_AUTH_ORIGINS", "*")),
+		v1Prefix + "/admin": newCORSPolicy(helpers.GetEnv("CORS_ADMIN_ORIGINS", apiOrigins)),
+	}
+}
+
+func newCORSPolicy(origins string) cors.Config {
+	allowHeaders := helpers.GetEnv("CORS_ALLOWED_HEADERS", "Origin, Content-Type, Accept, Authorization")
+	allowMethods := helpers.GetEnv("CORS_ALLOWED_METHODS", "GET, POST, PUT, DELETE, OPTIONS")
+
+	return cors.Config{
+		AllowOrigins: origins,
+		AllowHeaders: allowHeaders,
+		AllowMethods: strings.ReplaceAll(allowMethods, " ", ""),
+		// Lets browser clients show when a session is impersonated
+		ExposeHeaders: middleware.ImpersonatedByHeader,
+	}
+}
+
+// policyPrefix returns the prefix whose policy applies to path
+func (p CORSConfig) policyPrefix(path string) string {
+	match := ""
+	for prefix := range p {
+		if len(prefix) <= len(match) {
+			continue
+		}
+		if prefix == "/" || path == prefix || strings.HasPrefix(path, prefix+"/") {
+			match = prefix
+		}
+	}
+	return match
+}
+
+// handler returns the CORS middleware for prefix. It only acts on requests
+// governed by that prefix, so it can be attached to a group containing
+// subgroups with their own policy. Preflight requests from origins the
+// policy does not allow are rejected with 403.
+func (p CORSConfig) handler(prefix string) fiber.Handler {
+	config, ok := p[prefix]
+	if !ok {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	allowed := strings.Split(strings.ReplaceAll(config.AllowOrigins, " ", ""), ",")
+	corsHandler := cors.New(config)
+
+	return func(c *fiber.Ctx) error {
+		if p.policyPrefix(c.Path()) != prefix {
+			return c.Next()
+		}
+
+		origin := c.Get(fiber.HeaderOrigin)
+		preflight := c.Method() == fiber.MethodOptions && c.Get(fiber.HeaderAccessControlRequestMethod) != ""
+		if preflight && origin != "" && !slices.Contains(allowed, "*") && !slices.ContainsFunc(allowed, func(o string) bool {
+			return strings.EqualFold(o, origin)
+		}) {
+			return helpers.ForbiddenResponse(c, "Origin not allowed", apperrors.ErrOriginNotAllowed)
+		}
+
+		return corsHandler(c)
+	}
+}
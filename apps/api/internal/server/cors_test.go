@@ -0,0 +1,83 @@
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func corsTestConfig(t *testing.T) *RouterConfig {
+	t.Helper()
+
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com")
+	t.Setenv("CORS_ADMIN_ORIGINS", "https://admin.example.com")
+	t.Setenv("CORS_AUTH_ORIGINS", "*")
+
+	config := DefaultRouterConfig()
+	config.CORSPolicy = LoadCORSConfig("/api/v1")
+	return &config
+}
+
+func preflight(t *testing.T, config *RouterConfig, path, origin string) (int, string) {
+	t.Helper()
+
+	app := NewRouterWithConfig(*config)
+	req := httptest.NewRequest("OPTIONS", path, nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "GET")
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, resp.Header.Get("Access-Control-Allow-Origin")
+}
+
+func TestCORSPolicyPerRouteGroup(t *testing.T) {
+	config := corsTestConfig(t)
+
+	tests := []struct {
+		name        string
+		path        string
+		origin      string
+		wantStatus  int
+		allowOrigin string
+	}{
+		{"admin from unauthorized origin", "/api/v1/admin/users", "https://evil.example.com", 403, ""},
+		{"admin from general API origin", "/api/v1/admin/users", "https://app.example.com", 403, ""},
+		{"admin from dashboard origin", "/api/v1/admin/users", "https://admin.example.com", 204, "https://admin.example.com"},
+		{"auth from any origin", "/api/v1/auth/login", "https://mobile.example.net", 204, "*"},
+		{"protected from API origin", "/api/v1/protected/profile", "https://app.example.com", 204, "https://app.example.com"},
+		{"protected from dashboard origin", "/api/v1/protected/profile", "https://admin.example.com", 403, ""},
+		{"health from API origin", "/health", "https://app.example.com", 204, "https://app.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, allowOrigin := preflight(t, config, tt.path, tt.origin)
+			if status != tt.wantStatus {
+				t.Errorf("OPTIONS %s from %s status = %d, want %d", tt.path, tt.origin, status, tt.wantStatus)
+			}
+			if allowOrigin != tt.allowOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", allowOrigin, tt.allowOrigin)
+			}
+		})
+	}
+}
+
+func TestCORSPolicyPrefix(t *testing.T) {
+	policy := CORSConfig{"/": {}, "/api/v1": {}, "/api/v1/admin": {}}
+
+	tests := map[string]string{
+		"/health":              "/",
+		"/api/v1":              "/api/v1",
+		"/api/v1/auth/login":   "/api/v1",
+		"/api/v1/admin":        "/api/v1/admin",
+		"/api/v1/admin/users":  "/api/v1/admin",
+		"/api/v1/administrate": "/api/v1",
+	}
+	for path, want := range tests {
+		if got := policy.policyPrefix(path); got != want {
+			t.Errorf("policyPrefix(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -1,14 +1,16 @@
 package server
 
 import (
-	"strings"
+	"time"
 
 	"api/internal/handlers"
 	"api/internal/helpers"
+	"api/internal/logger"
 	"api/internal/middleware"
+	"api/internal/services"
+	"api/internal/tracing"
+	"github.com/getsentry/sentry-go"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 )
@@ -18,6 +20,23 @@ type RouterConfig struct {
 	// Add any router-specific configuration here
 	EnableHealthCheck bool
 	APIPrefix         string
+	// Service and Version are reported by the health check
+	Service string
+	Version string
+	// EnableDocs serves the OpenAPI spec and Swagger UI
+	EnableDocs bool
+	// DocsUsername and DocsPassword protect /docs with HTTP Basic
+	// authentication when DocsUsername is set
+	DocsUsername string
+	DocsPassword string
+	// CORSPolicy holds the CORS policy per route prefix; nil loads it from
+	// the environment
+	CORSPolicy CORSConfig
+	// AdminIPAllowlist restricts admin routes to these CIDR ranges; empty
+	// allows any IP
+	AdminIPAllowlist []string
+	// SentryDSN enables error reporting to Sentry when set
+	SentryDSN string
 }
 
 // DefaultRouterConfig returns default router configuration
@@ -25,6 +44,14 @@ func DefaultRouterConfig() RouterConfig {
 	return RouterConfig{
 		EnableHealthCheck: true,
 		APIPrefix:         "/api",
+		Service:           "Studio45 API",
+		Version:           "dev",
+		EnableDocs:        helpers.GetEnv("ENV", "development") != "production",
+		DocsUsername:      helpers.GetEnv("DOCS_USERNAME", ""),
+		DocsPassword:      helpers.GetEnv("DOCS_PASSWORD", ""),
+		CORSPolicy:        LoadCORSConfig("/api/v1"),
+		AdminIPAllowlist:  middleware.LoadIPAllowlist(),
+		SentryDSN:         helpers.GetEnv("SENTRY_DSN", ""),
 	}
 }
 
@@ -37,97 +64,328 @@ func NewRouter() *fiber.App {
 func NewRouterWithConfig(config RouterConfig) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ErrorHandler: helpers.ErrorHandler,
+		// Routes enforce tighter limits with middleware.BodySizeLimit
+		BodyLimit: middleware.ImportBodyLimit,
 	})
 
-	setupMiddleware(app)
+	if config.CORSPolicy == nil {
+		config.CORSPolicy = LoadCORSConfig(config.APIPrefix + "/v1")
+	}
+
+	setupMiddleware(app, config)
 	setupRoutes(app, config)
 
 	return app
 }
 
-func setupMiddleware(app *fiber.App) {
+func setupMiddleware(app *fiber.App, config RouterConfig) {
 	app.Use(recover.New())
 	app.Use(requestid.New())
+	if config.SentryDSN != "" {
+		if err := initSentry(config); err != nil {
+			logger.Error("Failed to initialize Sentry, error reporting is disabled", "error", err)
+		} else {
+			app.Use(middleware.SentryRecovery())
+		}
+	}
+	app.Use(middleware.Tracing(tracing.Tracer()))
+	app.Use(middleware.Metrics())
+	app.Use(middleware.RequestLogger())
+	app.Use(middleware.QueryCounter())
 	
-	// Logger configuration from environment
-	logFormat := helpers.GetEnv("LOG_FORMAT", "[${time}] ${status} - ${latency} ${method} ${path}\n")
-	app.Use(logger.New(logger.Config{
-		Format: logFormat,
-	}))
-	
-	// CORS configuration from environment
-	allowOrigins := helpers.GetEnv("CORS_ALLOWED_ORIGINS", "*")
-	allowHeaders := helpers.GetEnv("CORS_ALLOWED_HEADERS", "Origin, Content-Type, Accept, Authorization")
-	allowMethods := helpers.GetEnv("CORS_ALLOWED_METHODS", "GET, POST, PUT, DELETE, OPTIONS")
-	
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: allowOrigins,
-		AllowHeaders: allowHeaders,
-		AllowMethods: strings.ReplaceAll(allowMethods, " ", ""),
-	}))
+	// CORS for routes outside the API groups; groups attach their own policy
+	app.Use(config.CORSPolicy.handler("/"))
+}
+
+// initSentry sets up the Sentry SDK for SentryRecovery; buffered events are
+// flushed on graceful shutdown
+func initSentry(config RouterConfig) error {
+	return sentry.Init(sentry.ClientOptions{
+		Dsn:         config.SentryDSN,
+		Environment: helpers.GetEnv("ENV", "development"),
+		Release:     config.Version,
+	})
 }
 
 func setupRoutes(app *fiber.App, config RouterConfig) {
 	// Health check route (optional)
 	if config.EnableHealthCheck {
-		healthHandler := handlers.HealthCheck()
+		healthHandler := handlers.HealthCheck(config.Service, config.Version)
 		app.Get("/health", healthHandler)
 	}
 
+	// Prometheus metrics, optionally protected by a bearer token
+	app.Get("/metrics", middleware.MetricsAuth(helpers.GetEnv("METRICS_BEARER_TOKEN", "")), handlers.Metrics())
+
+	// Uploaded avatars, referenced by avatar_url
+	app.Get("/uploads/avatars/:filename", handlers.ServeAvatar)
+
+	// Standalone API documentation, hidden in production
+	if config.EnableDocs {
+		docsAuth := middleware.DocsAuth(config.DocsUsername, config.DocsPassword)
+		app.Get("/docs", docsAuth, handlers.GetDocs)
+		app.Get("/docs/openapi.yaml", docsAuth, handlers.GetDocsSpec)
+	}
+
 	// API routes
 	api := app.Group(config.APIPrefix)
+	v1Prefix := config.APIPrefix + "/v1"
 	v1 := api.Group("/v1")
+	auth := v1.Group("/auth")
+	protected := v1.Group("/protected")
+	admin := v1.Group("/admin")
+
+	// CORS per route group, attached before rate limiting and authentication
+	// so preflight requests are answered directly
+	v1.Use(config.CORSPolicy.handler(v1Prefix))
+	auth.Use(config.CORSPolicy.handler(v1Prefix + "/auth"))
+	admin.Use(config.CORSPolicy.handler(v1Prefix + "/admin"))
+
+	// Maintenance mode answers API requests with 503 unless bypassed; /health
+	// and /metrics stay available
+	v1.Use(middleware.MaintenanceMode())
+
+	// General rate limit for all API routes
+	v1.Use(middleware.RateLimit(
+		helpers.GetEnvInt("RATE_LIMIT_API_REQUESTS", 100),
+		helpers.GetEnvDuration("RATE_LIMIT_API_WINDOW", time.Minute),
+	))
+
+	// Bound how long a request's queries may run
+	v1.Use(middleware.QueryTimeout(middleware.LoadQueryTimeout()))
+
+	// API documentation, hidden in production
+	if config.EnableDocs {
+		v1.Get("/openapi.json", handlers.GetOpenAPISpec)
+		v1.Get("/docs", handlers.GetAPIDocs)
+	}
+
+	// Delivery reports from the email provider, authenticated by signature
+	v1.Post("/webhooks/email/delivery", middleware.BodySizeLimit(middleware.EmailWebhookBodyLimit), handlers.EmailDeliveryWebhook)
+
+	// Announcements currently shown to every user
+	v1.Get("/announcements", handlers.ListActiveAnnouncements)
+
+	// Strict rate limit for credential endpoints
+	authRequests := helpers.GetEnvInt("RATE_LIMIT_AUTH_REQUESTS", 5)
+	authWindow := helpers.GetEnvDuration("RATE_LIMIT_AUTH_WINDOW", time.Minute)
 
 	// Auth routes
-	auth := v1.Group("/auth")
+	auth.Use(middleware.BodySizeLimit(middleware.AuthBodyLimit))
+	auth.Use(middleware.EnforceJSONContentType())
+	// Registration takes no Idempotency-Key: its response carries the new
+	// user's token, which must not be stored for replay. A retried register
+	// gets 409 for the existing email instead of a second account.
 	auth.Post("/register", handlers.Register)
-	auth.Post("/login", handlers.Login)
-	auth.Post("/forgot-password", handlers.ForgotPassword)
+	auth.Post("/login", middleware.RateLimit(authRequests, authWindow), handlers.Login)
+	auth.Post("/forgot-password", middleware.RateLimit(authRequests, authWindow), handlers.ForgotPassword)
 	auth.Post("/reset-password", handlers.ResetPassword)
+	auth.Post("/accept-invitation", handlers.AcceptInvitation)
+	auth.Post("/verify-email", handlers.VerifyEmail)
+	auth.Get("/password-policy", handlers.GetPasswordPolicy)
+	auth.Get("/.well-known/jwks.json", handlers.GetJWKS)
+	auth.Get("/tos/current", handlers.GetCurrentToS)
 
 	// Protected routes
-	protected := v1.Group("/protected")
 	protected.Use(middleware.RequireAuth())
-	protected.Get("/profile", handlers.GetProfile)
-	protected.Put("/profile", handlers.UpdateProfile)
 
-	// Admin routes
-	admin := v1.Group("/admin")
+	// Avatars are uploaded as multipart form data, so the route is registered
+	// before JSON bodies and the default body limit are enforced for the rest
+	// of the group
+	protected.Post("/profile/avatar", middleware.RequireToSAcceptance(), middleware.BodySizeLimit(middleware.AvatarBodyLimit), middleware.RequireAPIKeyScope(services.APIKeyScopeUserWrite), handlers.UploadAvatar)
+	protected.Use(middleware.EnforceJSONContentType())
+	protected.Use(middleware.BodySizeLimit(middleware.DefaultBodyLimit))
+
+	// Users who have not accepted the current terms of service can still
+	// accept them, export their data or close their account. Only the user,
+	// not an API key acting for them, can accept the terms.
+	protected.Post("/tos/accept", middleware.RequireJWT(), handlers.AcceptToS)
+	protected.Get("/data-export", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.ExportMyData)
+	protected.Delete("/account", middleware.RequireJWT(), middleware.RejectImpersonation(), handlers.EraseMyAccount)
+	protected.Use(middleware.RequireToSAcceptance())
+
+	protected.Get("/profile", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.GetProfile)
+	protected.Put("/profile", middleware.RequireAPIKeyScope(services.APIKeyScopeUserWrite), handlers.ReplaceProfile)
+	protected.Patch("/profile", middleware.RequireAPIKeyScope(services.APIKeyScopeUserWrite), handlers.PatchProfile)
+	protected.Get("/login-history", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.GetMyLoginHistory)
+	protected.Get("/permissions", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.GetMyPermissions)
+	protected.Get("/permissions/:name", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.CheckMyPermission)
+	protected.Post("/permissions/check", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.CheckMyPermissions)
+	protected.Get("/preferences", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.GetPreferences)
+	protected.Put("/preferences/:key", middleware.RequireAPIKeyScope(services.APIKeyScopeUserWrite), handlers.UpdatePreference)
+	protected.Delete("/preferences/:key", middleware.RequireAPIKeyScope(services.APIKeyScopeUserWrite), handlers.DeletePreference)
+	protected.Get("/announcements", middleware.RequireAPIKeyScope(services.APIKeyScopeUserRead), handlers.ListMyAnnouncements)
+	protected.Post("/announcements/:id/read", middleware.RequireAPIKeyScope(services.APIKeyScopeUserWrite), handlers.MarkAnnouncementRead)
+
+	// Passwords are changed with a JWT, as an API key does not prove the
+	// user is present. Impersonating admins cannot take over the account, so
+	// they cannot change its password, login emails or API keys either.
+	protected.Patch("/change-password", middleware.RequireJWT(), middleware.RejectImpersonation(), handlers.ChangePassword)
+
+	// Email addresses are managed with a JWT, as the primary one is used to log in
+	protected.Post("/emails", middleware.RequireJWT(), middleware.RejectImpersonation(), handlers.AddEmail)
+	protected.Delete("/emails/:id", middleware.RequireJWT(), middleware.RejectImpersonation(), handlers.RemoveEmail)
+	protected.Post("/emails/:id/set-primary", middleware.RequireJWT(), middleware.RejectImpersonation(), handlers.SetPrimaryEmail)
+
+	// API keys are managed with a JWT so a key cannot mint broader keys
+	protected.Post("/api-keys", middleware.RequireJWT(), middleware.RejectImpersonation(), handlers.CreateAPIKey)
+	protected.Get("/api-keys", middleware.RequireJWT(), handlers.ListAPIKeys)
+	protected.Delete("/api-keys/:id", middleware.RequireJWT(), handlers.RevokeAPIKey)
+
+	// Sessions are managed with a JWT; API keys are not sessions
+	protected.Get("/sessions", middleware.RequireJWT(), handlers.ListSessions)
+	protected.Delete("/sessions/:sessionID", middleware.RequireJWT(), handlers.RevokeSession)
+
+	// Admin routes, rejected before authentication when the client IP is
+	// outside the allowlist
+	admin.Use(middleware.RequireIPAllowlist(config.AdminIPAllowlist))
 	admin.Use(middleware.RequireAuth())
+	admin.Use(middleware.RequireJWT())
 	admin.Use(middleware.RequireAdmin())
+
+	// Retried admin POSTs with the same Idempotency-Key replay the first response,
+	// except impersonation, whose token must not be stored for replay
+	admin.Use(middleware.Idempotency())
+
+	// User imports are multipart uploads, registered before JSON bodies and
+	// the default body limit are enforced for the rest of the group
+	admin.Post("/users/import", middleware.BodySizeLimit(middleware.ImportBodyLimit), handlers.ImportUsers)
+	admin.Use(middleware.EnforceJSONContentType())
+
+	// Email templates may embed large HTML, so they are registered before the
+	// default body limit applies to the rest of the group
+	admin.Post("/email-templates", middleware.BodySizeLimit(middleware.EmailTemplateBodyLimit), handlers.CreateEmailTemplate)
+	admin.Put("/email-templates/:id", middleware.BodySizeLimit(middleware.EmailTemplateBodyLimit), handlers.UpdateEmailTemplate)
+	admin.Use(middleware.BodySizeLimit(middleware.DefaultBodyLimit))
 	
-	// User management
+	// Dashboard statistics
+	admin.Get("/stats", handlers.GetAdminStats)
+	admin.Get("/db/stats", handlers.GetDBStats)
+	admin.Get("/db/analyze", handlers.AnalyzeTable)
+	admin.Get("/db/missing-indexes", handlers.GetMissingIndexes)
+	admin.Get("/health/dependencies", handlers.GetDependencyHealth)
+	admin.Get("/dev/slow-requests", handlers.GetSlowRequests)
+
+	// User management. Routes for a single user answer 404 for users outside
+	// the caller's data scopes.
+	userInScope := middleware.RequireUserInDataScope()
 	admin.Get("/users", handlers.ListUsers)
+	admin.Get("/users/export", handlers.ExportUsers)
 	admin.Post("/users", handlers.CreateUser)
-	admin.Put("/users/:id", handlers.UpdateUser)
-	admin.Put("/users/:id/roles", handlers.UpdateUserRoles)
-	admin.Delete("/users/:id", handlers.DeleteUser)
+	admin.Patch("/users/bulk-roles", handlers.BulkUpdateUserRoles)
+	admin.Get("/users/:id", userInScope, handlers.GetUser)
+	admin.Put("/users/:id", userInScope, handlers.UpdateUser)
+	admin.Patch("/users/:id", userInScope, handlers.PatchUser)
+	admin.Put("/users/:id/roles", userInScope, handlers.UpdateUserRoles)
+	admin.Patch("/users/:id/tags", userInScope, handlers.UpdateUserTags)
+	admin.Put("/users/:id/activate", userInScope, handlers.ActivateUser)
+	admin.Put("/users/:id/deactivate", userInScope, handlers.DeactivateUser)
+	admin.Get("/users/:id/role-assignments", userInScope, handlers.GetUserRoleAssignments)
+	admin.Get("/users/:id/login-history", userInScope, handlers.GetUserLoginHistory)
+	admin.Get("/users/:id/data-export", userInScope, handlers.ExportUserData)
+	admin.Delete("/users/:id", userInScope, handlers.DeleteUser)
+	admin.Delete("/users/:id/purge", userInScope, handlers.PurgeUser)
+	admin.Post("/users/:id/restore", userInScope, handlers.RestoreUser)
+	admin.Post("/users/:id/merge", userInScope, handlers.MergeUsers)
+	admin.Patch("/users/:id/reset-password", userInScope, handlers.AdminResetPassword)
+	admin.Post("/users/:id/impersonate", userInScope, middleware.SkipIdempotency(), handlers.ImpersonateUser)
+	admin.Put("/users/:id/token-settings", userInScope, handlers.UpdateUserTokenSettings)
+	
+	// Invitations
+	admin.Get("/invitations", handlers.ListInvitations)
+	admin.Post("/invitations", handlers.CreateInvitation)
 	
 	// Role and permission management
+	admin.Get("/rbac/export", handlers.ExportRBAC)
+	admin.Post("/rbac/import", handlers.ImportRBAC)
 	admin.Get("/roles", handlers.GetAllRoles)
 	admin.Post("/roles", handlers.CreateRole)
 	admin.Get("/roles/:id", handlers.GetRole)
 	admin.Put("/roles/:id", handlers.UpdateRole)
 	admin.Delete("/roles/:id", handlers.DeleteRole)
+	admin.Post("/roles/:id/clone", handlers.CloneRole)
+	admin.Get("/roles/:id/users", handlers.GetRoleUsers)
+	admin.Get("/roles/:id/users/count", handlers.CountRoleUsers)
 	admin.Get("/roles/:id/permissions", handlers.GetRolePermissions)
 	admin.Put("/roles/:id/permissions", handlers.UpdateRolePermissions)
 	
 	admin.Get("/permissions", handlers.GetAllPermissions)
+	admin.Get("/permissions/categories", handlers.ListPermissionCategories)
+	admin.Post("/permissions/categories", handlers.CreatePermissionCategory)
+	admin.Get("/permissions/categories/:id", handlers.GetPermissionCategory)
+	admin.Put("/permissions/categories/:id", handlers.UpdatePermissionCategory)
+	admin.Delete("/permissions/categories/:id", handlers.DeletePermissionCategory)
 	admin.Post("/permissions", handlers.CreatePermission)
 	admin.Get("/permissions/:id", handlers.GetPermission)
 	admin.Put("/permissions/:id", handlers.UpdatePermission)
 	admin.Delete("/permissions/:id", handlers.DeletePermission)
+	admin.Post("/permissions/:id/assign-to-roles", handlers.AssignPermissionToRoles)
+	admin.Delete("/permissions/:id/remove-from-roles", handlers.RemovePermissionFromRoles)
 	
-	admin.Get("/users/:id/permissions", handlers.GetUserPermissions)
-	admin.Get("/users/:id/permissions/:permission", handlers.CheckUserPermission)
+	admin.Get("/users/:id/permissions", userInScope, handlers.GetUserPermissions)
+	admin.Get("/users/:id/permissions/:permission", userInScope, handlers.CheckUserPermission)
 	
 	// Email template management
 	admin.Get("/email-templates", handlers.ListEmailTemplates)
-	admin.Post("/email-templates", handlers.CreateEmailTemplate)
 	admin.Get("/email-templates/:id", handlers.GetEmailTemplate)
-	admin.Put("/email-templates/:id", handlers.UpdateEmailTemplate)
 	admin.Delete("/email-templates/:id", handlers.DeleteEmailTemplate)
+	admin.Patch("/email-templates/:id/toggle", handlers.ToggleEmailTemplate)
 	admin.Get("/email-templates/:id/variables", handlers.GetTemplateVariables)
 	admin.Post("/email-templates/:id/preview", handlers.PreviewEmailTemplate)
 	admin.Post("/email-templates/:id/test", handlers.TestEmailTemplate)
+	admin.Get("/email-templates/:id/versions", handlers.ListEmailTemplateVersions)
+	admin.Post("/email-templates/:id/versions/:version/restore", handlers.RestoreEmailTemplateVersion)
+	admin.Post("/email-templates/:id/clone", handlers.CloneEmailTemplate)
+
+	// Email queue
+	admin.Get("/email-queue/stats", handlers.GetEmailQueueStats)
+	admin.Get("/email/circuit-status", handlers.GetEmailCircuitStatus)
+
+	// Audit logs
+	admin.Get("/audit-logs", handlers.ListAuditLogs)
+
+	// Maintenance mode
+	admin.Put("/maintenance", handlers.UpdateMaintenanceMode)
+
+	// Runtime settings
+	admin.Get("/settings/default-role", handlers.GetDefaultRole)
+	admin.Put("/settings/default-role", handlers.UpdateDefaultRole)
+
+	// Effective admin IP allowlist
+	admin.Get("/ip-allowlist", handlers.GetIPAllowlist(config.AdminIPAllowlist))
+
+	// Expired record cleanup
+	admin.Get("/cleanup/stats", handlers.GetCleanupStats)
+
+	// Webhooks
+	admin.Get("/webhooks", handlers.ListWebhooks)
+	admin.Post("/webhooks", handlers.CreateWebhook)
+	admin.Get("/webhooks/:id", handlers.GetWebhook)
+	admin.Put("/webhooks/:id", handlers.UpdateWebhook)
+	admin.Delete("/webhooks/:id", handlers.DeleteWebhook)
+	admin.Get("/webhooks/:id/deliveries", handlers.ListWebhookDeliveries)
+	admin.Post("/webhooks/deliveries/:id/replay", handlers.ReplayWebhookDelivery)
+
+	// Companies
+	admin.Get("/companies", handlers.ListCompanies)
+	admin.Post("/companies", handlers.CreateCompany)
+	admin.Get("/companies/:id", handlers.GetCompany)
+	admin.Put("/companies/:id", handlers.UpdateCompany)
+	admin.Delete("/companies/:id", handlers.DeleteCompany)
+
+	// Tags
+	admin.Get("/tags", handlers.ListTags)
+	admin.Post("/tags", handlers.CreateTag)
+	admin.Delete("/tags/:id", handlers.DeleteTag)
+
+	// Announcements
+	admin.Get("/announcements", handlers.ListAnnouncements)
+	admin.Post("/announcements", handlers.CreateAnnouncement)
+	admin.Get("/announcements/:id", handlers.GetAnnouncement)
+	admin.Put("/announcements/:id", handlers.UpdateAnnouncement)
+	admin.Delete("/announcements/:id", handlers.DeleteAnnouncement)
+
+	// Terms of service
+	admin.Get("/tos", handlers.ListToSVersions)
+	admin.Post("/tos", handlers.PublishToSVersion)
 }
\ No newline at end of file
@@ -0,0 +1,437 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/auth"
+	"api/internal/cache"
+	"api/internal/middleware"
+	"api/internal/openapi"
+	"api/internal/session"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// metricValue returns the value of the sample with exactly the given name and
+// labels from a Prometheus text exposition
+func metricValue(t *testing.T, exposition, sample string) float64 {
+	t.Helper()
+
+	scanner := bufio.NewScanner(strings.NewReader(exposition))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if value, ok := strings.CutPrefix(line, sample+" "); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				t.Fatalf("invalid value for %s: %v", sample, err)
+			}
+			return parsed
+		}
+	}
+
+	t.Fatalf("sample %s not found in metrics", sample)
+	return 0
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	app := NewRouter()
+
+	for i := 0; i < 3; i++ {
+		resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/auth/password-policy", nil))
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		t.Fatalf("GET /metrics status = %d, want 200", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read metrics: %v", err)
+	}
+	exposition := string(body)
+
+	requests := metricValue(t, exposition, `http_requests_total{method="GET",route="/api/v1/auth/password-policy",status="200"}`)
+	if requests < 3 {
+		t.Errorf("http_requests_total = %v, want at least 3", requests)
+	}
+
+	observations := metricValue(t, exposition, `http_request_duration_seconds_count{method="GET",route="/api/v1/auth/password-policy"}`)
+	if observations < 3 {
+		t.Errorf("http_request_duration_seconds_count = %v, want at least 3", observations)
+	}
+
+	if goroutines := metricValue(t, exposition, "go_goroutines"); goroutines <= 0 {
+		t.Errorf("go_goroutines = %v, want a positive value", goroutines)
+	}
+
+	for _, name := range []string{"go_gc_duration_seconds", "db_pool_open_connections"} {
+		if !strings.Contains(exposition, "# TYPE "+name+" ") {
+			t.Errorf("metric %s missing from exposition", name)
+		}
+	}
+}
+
+func TestMetricsBearerToken(t *testing.T) {
+	t.Setenv("METRICS_BEARER_TOKEN", "metrics-secret")
+	app := NewRouter()
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "missing token", wantStatus: 401},
+		{name: "wrong token", authorization: "Bearer wrong", wantStatus: 401},
+		{name: "valid token", authorization: "Bearer metrics-secret", wantStatus: 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestAPIDocsRoutes(t *testing.T) {
+	config := DefaultRouterConfig()
+	config.EnableDocs = true
+	app := NewRouterWithConfig(config)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/openapi.json", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		t.Fatalf("GET /api/v1/openapi.json status = %d, want 200", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read spec: %v", err)
+	}
+	if !bytes.Equal(body, openapi.Spec) {
+		t.Error("GET /api/v1/openapi.json did not serve the embedded spec")
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/docs", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("GET /api/v1/docs status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	config.EnableDocs = false
+	app = NewRouterWithConfig(config)
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/openapi.json", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 404 {
+		t.Errorf("GET /api/v1/openapi.json with docs disabled status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestStandaloneDocs(t *testing.T) {
+	get := func(app *fiber.App, path string, credentials ...string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest("GET", path, nil)
+		if len(credentials) == 2 {
+			req.SetBasicAuth(credentials[0], credentials[1])
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("app.Test() error = %v", err)
+		}
+		return resp
+	}
+
+	t.Run("development", func(t *testing.T) {
+		t.Setenv("ENV", "development")
+		app := NewRouterWithConfig(DefaultRouterConfig())
+
+		resp := get(app, "/docs")
+		resp.Body.Close()
+		if resp.StatusCode != 200 || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+			t.Errorf("GET /docs status = %d, content type = %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+		}
+
+		resp = get(app, "/docs/openapi.yaml")
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != 200 || !bytes.Equal(body, openapi.SpecYAML) {
+			t.Errorf("GET /docs/openapi.yaml status = %d, want 200 with the embedded spec", resp.StatusCode)
+		}
+	})
+
+	t.Run("production", func(t *testing.T) {
+		t.Setenv("ENV", "production")
+		app := NewRouterWithConfig(DefaultRouterConfig())
+
+		for _, path := range []string{"/docs", "/docs/openapi.yaml"} {
+			resp := get(app, path)
+			resp.Body.Close()
+			if resp.StatusCode != 404 {
+				t.Errorf("GET %s status = %d, want 404", path, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("basic auth", func(t *testing.T) {
+		config := DefaultRouterConfig()
+		config.EnableDocs = true
+		config.DocsUsername = "docs"
+		config.DocsPassword = "s3cret"
+		app := NewRouterWithConfig(config)
+
+		tests := []struct {
+			name        string
+			credentials []string
+			wantStatus  int
+		}{
+			{"missing credentials", nil, 401},
+			{"wrong password", []string{"docs", "wrong"}, 401},
+			{"valid credentials", []string{"docs", "s3cret"}, 200},
+		}
+		for _, tt := range tests {
+			resp := get(app, "/docs/openapi.yaml", tt.credentials...)
+			resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("%s: status = %d, want %d", tt.name, resp.StatusCode, tt.wantStatus)
+			}
+		}
+	})
+}
+
+func TestAdminIPAllowlist(t *testing.T) {
+	config := DefaultRouterConfig()
+	config.AdminIPAllowlist = []string{"10.0.0.0/8"}
+	app := NewRouterWithConfig(config)
+
+	// app.Test requests come from 0.0.0.0, outside the allowlist, and are
+	// rejected before authentication
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/admin/ip-allowlist", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 403 {
+		t.Errorf("admin request outside allowlist status = %d, want 403", resp.StatusCode)
+	}
+
+	// Routes outside the admin group are unaffected
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/auth/password-policy", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Errorf("auth request status = %d, want 200", resp.StatusCode)
+	}
+
+	config.AdminIPAllowlist = nil
+	app = NewRouterWithConfig(config)
+	resp, err = app.Test(httptest.NewRequest("GET", "/api/v1/admin/ip-allowlist", nil))
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 401 {
+		t.Errorf("admin request without allowlist status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestAuthBodySizeLimit(t *testing.T) {
+	app := NewRouterWithConfig(DefaultRouterConfig())
+
+	body := bytes.Repeat([]byte("a"), middleware.AuthBodyLimit+1)
+	req := httptest.NewRequest("POST", "/api/v1/auth/register", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 413 {
+		t.Fatalf("oversized register body status = %d, want 413", resp.StatusCode)
+	}
+	got, _ := io.ReadAll(resp.Body)
+	if want := `{"error":"request body too large","code":"REQUEST_TOO_LARGE","details":null}`; string(got) != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+// authenticatedAsAdmin returns a bearer token for a user whose admin role is
+// cached, so requests pass authentication without a database
+func authenticatedAsAdmin(t *testing.T) string {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	previousSessions := session.Sessions()
+	session.SetSessions(session.NewMemoryStore())
+	t.Cleanup(func() { session.SetSessions(previousSessions) })
+
+	previousCache := cache.Permissions()
+	permissionCache := cache.NewMemoryCache()
+	permissionCache.Set("user-1", []string{"admin"}, time.Hour)
+	cache.SetPermissions(permissionCache)
+	t.Cleanup(func() { cache.SetPermissions(previousCache) })
+
+	token, err := auth.GenerateToken("user-1", "admin@example.com", nil, session.Device{})
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	return token
+}
+
+func TestDefaultBodySizeLimit(t *testing.T) {
+	token := authenticatedAsAdmin(t)
+	app := NewRouterWithConfig(DefaultRouterConfig())
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		limited bool
+	}{
+		{name: "protected route", method: "PUT", path: "/api/v1/protected/profile", limited: true},
+		{name: "admin route", method: "POST", path: "/api/v1/admin/roles", limited: true},
+		{name: "email template", method: "POST", path: "/api/v1/admin/email-templates", limited: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := bytes.Repeat([]byte("a"), middleware.DefaultBodyLimit+1)
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+token)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("app.Test() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if limited := resp.StatusCode == 413; limited != tt.limited {
+				t.Errorf("status = %d, want 413: %v", resp.StatusCode, tt.limited)
+			}
+		})
+	}
+}
+
+func TestLoginRequiresJSONContentType(t *testing.T) {
+	app := NewRouterWithConfig(DefaultRouterConfig())
+
+	body := `<login><email>user@example.com</email><password>secret</password></login>`
+	req := httptest.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/xml")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("app.Test() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 415 {
+		t.Fatalf("XML login status = %d, want 415", resp.StatusCode)
+	}
+	got, _ := io.ReadAll(resp.Body)
+	if want := `{"error":"Content-Type must be application/json","code":"UNSUPPORTED_MEDIA_TYPE","details":null}`; string(got) != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestOpenAPIGenerateCoversRoutes(t *testing.T) {
+	document, err := openapi.Generate(NewRouter(), openapi.Options{
+		Title:       "Studio45 API",
+		Version:     "test",
+		HandlersDir: "../handlers",
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	item, ok := document.Paths["/api/v1/admin/users/{id}"]
+	if !ok || item.Put == nil {
+		t.Fatal("PUT /api/v1/admin/users/{id} missing from spec")
+	}
+	if item.Put.OperationID != "UpdateUser" {
+		t.Errorf("operationId = %s, want UpdateUser", item.Put.OperationID)
+	}
+	if len(item.Put.Security) != 1 {
+		t.Errorf("admin route security = %v, want only a bearer token", item.Put.Security)
+	}
+	if _, ok := item.Put.Responses["403"]; !ok {
+		t.Error("admin route is missing the 403 response")
+	}
+
+	profile := document.Paths["/api/v1/protected/profile"]
+	if profile == nil || profile.Get == nil || len(profile.Get.Security) != 2 {
+		t.Error("GET /api/v1/protected/profile should accept a bearer token or an API key")
+	}
+
+	login := document.Paths["/api/v1/auth/login"]
+	if login == nil || login.Post == nil || len(login.Post.Security) != 0 {
+		t.Error("POST /api/v1/auth/login should be documented without security")
+	}
+	if hasHeaderParameter(login.Post, "Idempotency-Key") {
+		t.Error("POST /api/v1/auth/login should not accept an Idempotency-Key")
+	}
+
+	register := document.Paths["/api/v1/auth/register"]
+	if register == nil || hasHeaderParameter(register.Post, "Idempotency-Key") {
+		t.Error("POST /api/v1/auth/register should not accept an Idempotency-Key")
+	}
+	impersonate := document.Paths["/api/v1/admin/users/{id}/impersonate"]
+	if impersonate == nil || hasHeaderParameter(impersonate.Post, "Idempotency-Key") {
+		t.Error("POST /api/v1/admin/users/{id}/impersonate should not accept an Idempotency-Key")
+	}
+	roles := document.Paths["/api/v1/admin/roles"]
+	if roles == nil || !hasHeaderParameter(roles.Post, "Idempotency-Key") || hasHeaderParameter(roles.Get, "Idempotency-Key") {
+		t.Error("only POST /api/v1/admin/roles should document the Idempotency-Key header")
+	}
+}
+
+func hasHeaderParameter(operation *openapi.Operation, name string) bool {
+	if operation == nil {
+		return false
+	}
+	for _, param := range operation.Parameters {
+		if param.In == "header" && param.Name == name {
+			return true
+		}
+	}
+	return false
+}
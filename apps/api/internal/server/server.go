@@ -1,36 +1,68 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
 
+	"api/internal/database"
 	"api/internal/logger"
+	"api/internal/services"
+	"api/internal/tracing"
+	"github.com/getsentry/sentry-go"
 	"github.com/gofiber/fiber/v2"
 )
 
+// DefaultShutdownTimeout bounds how long in-flight requests may take to finish
+// after a shutdown signal
+const DefaultShutdownTimeout = 30 * time.Second
+
 type Config struct {
-	Port int
+	Port    int
+	Service string
+	Version string
+	// ShutdownTimeout bounds request draining on SIGTERM or SIGINT, defaulting
+	// to DefaultShutdownTimeout
+	ShutdownTimeout time.Duration
+	// CleanupInterval is how often expired tokens and idempotency keys are
+	// deleted while the server runs. Zero disables the cleanup job.
+	CleanupInterval time.Duration
 }
 
 type Server struct {
 	app    *fiber.App
 	config Config
+	// done receives the result of a signal-triggered shutdown
+	done         chan error
+	shuttingDown atomic.Bool
 }
 
-// New creates a new server with the default router
+// New creates a new server with the default router, reporting the configured
+// service name and version from the health check
 func New(config Config) *Server {
-	app := NewRouter()
+	routerConfig := DefaultRouterConfig()
+	routerConfig.Service = config.Service
+	routerConfig.Version = config.Version
+	app := NewRouterWithConfig(routerConfig)
 
-	return &Server{
-		app:    app,
-		config: config,
-	}
+	return NewWithRouter(app, config)
 }
 
 // NewWithRouter creates a new server with a custom router
 func NewWithRouter(app *fiber.App, config Config) *Server {
+	if config.ShutdownTimeout <= 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+
 	return &Server{
 		app:    app,
 		config: config,
+		done:   make(chan error, 1),
 	}
 }
 
@@ -40,11 +72,100 @@ func (s *Server) GetApp() *fiber.App {
 	return s.app
 }
 
-// Start starts the HTTP server on the configured port
+// Start starts the HTTP server on the configured port, along with the cleanup
+// job, and blocks until it has shut down. Logs go to LOG_OUTPUT from here on,
+// and SIGHUP reopens the log file. On SIGTERM or SIGINT it stops accepting
+// connections, waits up to the shutdown timeout for in-flight requests and
+// closes the database.
 func (s *Server) Start() error {
 	addr := fmt.Sprintf(":%d", s.config.Port)
+	logger.Logger = logger.NewWithOptions(logger.OptionsFromEnv())
+
+	if s.config.CleanupInterval > 0 {
+		stopCleanup := services.StartCleanupJob(s.config.CleanupInterval)
+		defer stopCleanup()
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	stopped := make(chan struct{})
+	defer close(stopped)
+
+	go func() {
+		defer signal.Stop(signals)
+		select {
+		case sig := <-signals:
+			s.done <- s.shutdown(sig)
+		case <-stopped:
+		}
+	}()
+
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hangups)
+		for {
+			select {
+			case <-hangups:
+				if err := logger.Rotate(); err != nil {
+					logger.Error("Failed to reopen log file", "error", err)
+				}
+			case <-stopped:
+				return
+			}
+		}
+	}()
+
 	logger.Info("Server starting", "address", fmt.Sprintf("http://localhost%s", addr), "port", s.config.Port)
-	return s.app.Listen(addr)
+	if err := s.app.Listen(addr); err != nil {
+		return err
+	}
+
+	// Listen returns once the listener is closed, before requests are drained
+	if s.shuttingDown.Load() {
+		return s.WaitForShutdown()
+	}
+	return nil
+}
+
+// WaitForShutdown blocks until a signal-triggered shutdown has finished and
+// returns its error
+func (s *Server) WaitForShutdown() error {
+	err := <-s.done
+	// Keep the result for later callers
+	s.done <- err
+	return err
+}
+
+// shutdown drains in-flight requests, flushes buffered trace spans and error
+// reports and closes the database connection
+func (s *Server) shutdown(sig os.Signal) error {
+	s.shuttingDown.Store(true)
+	logger.Info("Shutting down server", "signal", sig.String(), "timeout", s.config.ShutdownTimeout)
+	start := time.Now()
+
+	shutdownErr := s.app.ShutdownWithTimeout(s.config.ShutdownTimeout)
+	if shutdownErr != nil {
+		logger.Error("Failed to drain in-flight requests", "error", shutdownErr)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.ShutdownTimeout)
+	defer cancel()
+	tracingErr := tracing.Shutdown(ctx)
+	if tracingErr != nil {
+		logger.Error("Failed to shut down tracer provider", "error", tracingErr)
+	}
+
+	// Deliver error reports still queued for Sentry, if it is enabled
+	sentry.Flush(2 * time.Second)
+
+	dbErr := database.Close()
+	if dbErr != nil {
+		logger.Error("Failed to close database connection", "error", dbErr)
+	}
+
+	logger.Info("Server shutdown complete", "duration", time.Since(start))
+	return errors.Join(shutdownErr, tracingErr, dbErr)
 }
 
 // Shutdown gracefully shuts down the server
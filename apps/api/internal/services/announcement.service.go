@@ -0,0 +1,131 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrAnnouncementNotFound is returned when an announcement does not exist, or
+// is not active when users ask for it
+var ErrAnnouncementNotFound = errors.New("announcement not found")
+
+type AnnouncementService struct {
+	db *gorm.DB
+}
+
+func NewAnnouncementService() *AnnouncementService {
+	return &AnnouncementService{
+		db: database.DB,
+	}
+}
+
+// activeAnnouncements limits query to announcements active at now
+func activeAnnouncements(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("visible_from <= ? AND (visible_until IS NULL OR visible_until > ?)", now, now)
+}
+
+// ListActive returns the announcements active at now, newest first
+func (s *AnnouncementService) ListActive(now time.Time) ([]models.Announcement, error) {
+	var announcements []models.Announcement
+	err := activeAnnouncements(s.db, now).
+		Order("visible_from DESC, created_at DESC").
+		Find(&announcements).Error
+	return announcements, err
+}
+
+// ListAnnouncements returns every announcement, including scheduled and
+// expired ones, newest first
+func (s *AnnouncementService) ListAnnouncements() ([]models.Announcement, error) {
+	var announcements []models.Announcement
+	err := s.db.Order("visible_from DESC, created_at DESC").Find(&announcements).Error
+	return announcements, err
+}
+
+// GetAnnouncement returns an announcement by ID
+func (s *AnnouncementService) GetAnnouncement(id string) (*models.Announcement, error) {
+	var announcement models.Announcement
+	if err := s.db.Where("id = ?", id).First(&announcement).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAnnouncementNotFound
+		}
+		return nil, err
+	}
+	return &announcement, nil
+}
+
+// CreateAnnouncement stores an announcement
+func (s *AnnouncementService) CreateAnnouncement(announcement *models.Announcement) error {
+	if err := s.db.Create(announcement).Error; err != nil {
+		return err
+	}
+	InvalidateStatsCache()
+	return nil
+}
+
+// UpdateAnnouncement applies updates to an announcement
+func (s *AnnouncementService) UpdateAnnouncement(id string, updates map[string]interface{}) error {
+	result := s.db.Model(&models.Announcement{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAnnouncementNotFound
+	}
+	InvalidateStatsCache()
+	return nil
+}
+
+// DeleteAnnouncement removes an announcement and the record of who read it
+func (s *AnnouncementService) DeleteAnnouncement(id string) error {
+	result := s.db.Where("id = ?", id).Delete(&models.Announcement{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAnnouncementNotFound
+	}
+	InvalidateStatsCache()
+	return nil
+}
+
+// ReadAnnouncementIDs returns the IDs of the announcements userID has read
+func (s *AnnouncementService) ReadAnnouncementIDs(userID string) (map[string]bool, error) {
+	var ids []string
+	err := s.db.Model(&models.AnnouncementRead{}).Where("user_id = ?", userID).Pluck("announcement_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	read := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		read[id] = true
+	}
+	return read, nil
+}
+
+// MarkRead records userID reading the announcement, which must be active at
+// now. Marking an announcement read twice keeps the first read.
+func (s *AnnouncementService) MarkRead(userID, announcementID string, now time.Time) error {
+	var active int64
+	err := activeAnnouncements(s.db.Model(&models.Announcement{}), now).
+		Where("id = ?", announcementID).
+		Count(&active).Error
+	if err != nil {
+		return err
+	}
+	if active == 0 {
+		return ErrAnnouncementNotFound
+	}
+
+	read := models.AnnouncementRead{
+		UserID:         userID,
+		AnnouncementID: announcementID,
+		ReadAt:         now,
+	}
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&read).Error
+}
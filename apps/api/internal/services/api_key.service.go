@@ -0,0 +1,118 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"api/internal/auth"
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// API key scopes
+const (
+	APIKeyScopeUserRead  = "user:read"
+	APIKeyScopeUserWrite = "user:write"
+)
+
+// APIKeyScopes lists the scopes that can be granted to an API key
+var APIKeyScopes = []string{APIKeyScopeUserRead, APIKeyScopeUserWrite}
+
+// apiKeyLastUsedInterval limits how often last_used_at is written for a key
+const apiKeyLastUsedInterval = time.Minute
+
+var (
+	// ErrAPIKeyNotFound is returned when a key does not exist or belongs to another user
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	// ErrInvalidAPIKey is returned for unknown, revoked or expired keys
+	ErrInvalidAPIKey = errors.New("invalid or expired API key")
+)
+
+type APIKeyService struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyService() *APIKeyService {
+	return &APIKeyService{
+		db: database.DB,
+	}
+}
+
+// CreateAPIKey stores a new key for the user and returns it together with the
+// plaintext key, which cannot be retrieved again
+func (s *APIKeyService) CreateAPIKey(userID, name string, scopes []string, expiresAt *time.Time) (*models.APIKey, string, error) {
+	key, hash, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiKey := &models.APIKey{
+		KeyHash:   hash,
+		UserID:    userID,
+		Name:      name,
+		Scopes:    scopes,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.db.Create(apiKey).Error; err != nil {
+		return nil, "", err
+	}
+
+	return apiKey, key, nil
+}
+
+// ListAPIKeys returns the user's keys, including revoked ones, newest first
+func (s *APIKeyService) ListAPIKeys(userID string) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// RevokeAPIKey revokes one of the user's keys. Revoking an already revoked
+// key leaves its revocation time unchanged.
+func (s *APIKeyService) RevokeAPIKey(userID, keyID string) error {
+	var apiKey models.APIKey
+	if err := s.db.Where("id = ? AND user_id = ?", keyID, userID).First(&apiKey).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAPIKeyNotFound
+		}
+		return err
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+	return s.db.Model(&apiKey).Update("revoked_at", time.Now()).Error
+}
+
+// AuthenticateAPIKey looks up an active key by its plaintext value and
+// records that it was used. The key's owner is loaded into User.
+func (s *APIKeyService) AuthenticateAPIKey(key string) (*models.APIKey, error) {
+	var apiKey models.APIKey
+	err := s.db.Joins("User").
+		Where("api_keys.key_hash = ? AND api_keys.revoked_at IS NULL", auth.HashToken(key)).
+		First(&apiKey).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	// Keys of deleted users are not joined
+	if apiKey.IsExpired() || apiKey.User.ID == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyLastUsedInterval {
+		if err := s.db.Model(&models.APIKey{}).Where("id = ?", apiKey.ID).Update("last_used_at", now).Error; err != nil {
+			return nil, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return &apiKey, nil
+}
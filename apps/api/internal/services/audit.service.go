@@ -0,0 +1,233 @@
+package services
+
+import (
+	"api/internal/database"
+	"api/internal/models"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// Audit actions recorded for admin mutations
+const (
+	AuditActionUserCreate               = "user.create"
+	AuditActionUserUpdate               = "user.update"
+	AuditActionUserDelete               = "user.delete"
+	AuditActionUserActivate             = "user.activate"
+	AuditActionUserDeactivate           = "user.deactivate"
+	AuditActionUserImport               = "user.import"
+	AuditActionUserPurge                = "user.purge"
+	AuditActionUserRestore              = "user.restore"
+	AuditActionUserImpersonate          = "user.impersonate"
+	AuditActionUserMerge                = "user.merge"
+	AuditActionUserPasswordReset        = "user.password_reset"
+	AuditActionUserRolesUpdate          = "user.roles.update"
+	AuditActionUserTagsUpdate           = "user.tags.update"
+	AuditActionRoleCreate               = "role.create"
+	AuditActionRoleUpdate               = "role.update"
+	AuditActionRoleDelete               = "role.delete"
+	AuditActionRoleClone                = "role.clone"
+	AuditActionRolePermissionsUpdate    = "role.permissions.update"
+	AuditActionPermissionCreate         = "permission.create"
+	AuditActionPermissionUpdate         = "permission.update"
+	AuditActionPermissionDelete         = "permission.delete"
+	AuditActionPermissionAssign         = "permission.assign"
+	AuditActionPermissionUnassign       = "permission.unassign"
+	AuditActionPermissionCategoryCreate = "permission_category.create"
+	AuditActionPermissionCategoryUpdate = "permission_category.update"
+	AuditActionPermissionCategoryDelete = "permission_category.delete"
+	AuditActionEmailTemplateCreate      = "email_template.create"
+	AuditActionEmailTemplateUpdate      = "email_template.update"
+	AuditActionEmailTemplateDelete      = "email_template.delete"
+	AuditActionEmailTemplateRestore     = "email_template.restore"
+	AuditActionEmailTemplateClone       = "email_template.clone"
+	AuditActionInvitationCreate         = "invitation.create"
+	AuditActionMaintenanceUpdate        = "maintenance.update"
+	AuditActionSettingUpdate            = "setting.update"
+	AuditActionRBACImport               = "rbac.import"
+	AuditActionWebhookCreate            = "webhook.create"
+	AuditActionWebhookUpdate            = "webhook.update"
+	AuditActionWebhookDelete            = "webhook.delete"
+	AuditActionWebhookReplay            = "webhook.replay"
+	AuditActionCompanyCreate            = "company.create"
+	AuditActionCompanyUpdate            = "company.update"
+	AuditActionCompanyDelete            = "company.delete"
+	AuditActionToSPublish               = "tos.publish"
+	AuditActionAnnouncementCreate       = "announcement.create"
+	AuditActionAnnouncementUpdate       = "announcement.update"
+	AuditActionAnnouncementDelete       = "announcement.delete"
+	AuditActionTagCreate                = "tag.create"
+	AuditActionTagDelete                = "tag.delete"
+)
+
+// Audit resource types
+const (
+	AuditResourceUser               = "user"
+	AuditResourceRole               = "role"
+	AuditResourcePermission         = "permission"
+	AuditResourcePermissionCategory = "permission_category"
+	AuditResourceEmailTemplate      = "email_template"
+	AuditResourceInvitation         = "invitation"
+	AuditResourceSystem             = "system"
+	AuditResourceWebhook            = "webhook"
+	AuditResourceCompany            = "company"
+	AuditResourceToS                = "tos"
+	AuditResourceAnnouncement       = "announcement"
+	AuditResourceTag                = "tag"
+)
+
+// AuditChange is a single field change in an audit diff
+type AuditChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// AuditLogFilter narrows down audit log listings. Empty fields match every
+// entry.
+type AuditLogFilter struct {
+	ActorID      string
+	ResourceType string
+	ResourceID   string
+	Action       string
+	From         *time.Time
+	To           *time.Time
+}
+
+// AuditActor is who performed an audited action, and from where.
+// ImpersonatedBy is set when an admin acted as the user.
+type AuditActor struct {
+	ID             *string
+	ImpersonatedBy *string
+	IPAddress      string
+	UserAgent      string
+}
+
+// AuditActorFromCtx returns the authenticated user of the request as an
+// AuditActor
+func AuditActorFromCtx(ctx *fiber.Ctx) AuditActor {
+	actor := AuditActor{
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get(fiber.HeaderUserAgent),
+	}
+	if actorID, ok := ctx.Locals("userID").(string); ok && actorID != "" {
+		actor.ID = &actorID
+	}
+	if adminID, ok := ctx.Locals("impersonatedBy").(string); ok && adminID != "" {
+		actor.ImpersonatedBy = &adminID
+	}
+	return actor
+}
+
+type AuditService struct {
+	db *gorm.DB
+}
+
+func NewAuditService() *AuditService {
+	return NewAuditServiceWithDB(database.DB)
+}
+
+// NewAuditServiceWithDB returns a service writing to db, so entries can be
+// recorded in the same transaction as the change they describe
+func NewAuditServiceWithDB(db *gorm.DB) *AuditService {
+	return &AuditService{
+		db: db,
+	}
+}
+
+// Log records an action performed by the authenticated user of the request
+func (s *AuditService) Log(ctx *fiber.Ctx, action, resourceType, resourceID string, changes interface{}) error {
+	return s.LogActor(AuditActorFromCtx(ctx), action, resourceType, resourceID, changes)
+}
+
+// LogActor records an action performed by actor
+func (s *AuditService) LogActor(actor AuditActor, action, resourceType, resourceID string, changes interface{}) error {
+	return s.create(models.AuditLog{
+		ActorID:        actor.ID,
+		ImpersonatedBy: actor.ImpersonatedBy,
+		Action:         action,
+		ResourceType:   resourceType,
+		ResourceID:     resourceID,
+		IPAddress:      actor.IPAddress,
+		UserAgent:      actor.UserAgent,
+	}, changes)
+}
+
+// LogSystem records an action the API performed on its own, without an actor
+func (s *AuditService) LogSystem(action, resourceType, resourceID string, changes interface{}) error {
+	return s.create(models.AuditLog{
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}, changes)
+}
+
+func (s *AuditService) create(entry models.AuditLog, changes interface{}) error {
+	if changes != nil {
+		data, err := json.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		entry.Changes = data
+	}
+
+	return s.db.Create(&entry).Error
+}
+
+// GetLogs returns a page of the audit logs matching the filter, newest
+// first, with the total count. Each entry's actor is loaded, even when the
+// actor has since been deleted.
+func (s *AuditService) GetLogs(filter AuditLogFilter, page, limit int) ([]models.AuditLog, int64, error) {
+	var logs []models.AuditLog
+	var total int64
+
+	query := s.db.Model(&models.AuditLog{})
+
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Preload("Actor", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Select("id", "email")
+	}).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs).Error
+
+	return logs, total, err
+}
+
+// AuditDiff returns the fields in after whose values differ from before
+func AuditDiff(before, after map[string]interface{}) map[string]AuditChange {
+	diff := make(map[string]AuditChange)
+	for key, to := range after {
+		from := before[key]
+		if !reflect.DeepEqual(from, to) {
+			diff[key] = AuditChange{From: from, To: to}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,33 @@
+package services
+
+import "testing"
+
+func TestAuditDiff(t *testing.T) {
+	before := map[string]interface{}{
+		"name":    "Jane",
+		"email":   "jane@example.com",
+		"company": nil,
+		"roles":   []string{"user"},
+	}
+	after := map[string]interface{}{
+		"name":    "Jane",
+		"email":   "jane.doe@example.com",
+		"company": "Studio45",
+		"roles":   []string{"user"},
+	}
+
+	diff := AuditDiff(before, after)
+
+	if len(diff) != 2 {
+		t.Fatalf("AuditDiff() returned %d changes, want 2: %v", len(diff), diff)
+	}
+	if change := diff["email"]; change.From != "jane@example.com" || change.To != "jane.doe@example.com" {
+		t.Errorf("AuditDiff() email change = %+v", change)
+	}
+	if change := diff["company"]; change.From != nil || change.To != "Studio45" {
+		t.Errorf("AuditDiff() company change = %+v", change)
+	}
+	if _, ok := diff["name"]; ok {
+		t.Error("AuditDiff() reported unchanged field name")
+	}
+}
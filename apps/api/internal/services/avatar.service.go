@@ -0,0 +1,180 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/models"
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxAvatarSize is the largest accepted avatar upload in bytes
+	MaxAvatarSize = 5 << 20
+	// AvatarDimension is the width and height avatars are resized to
+	AvatarDimension = 256
+	// AvatarURLPrefix is the path avatars are served from
+	AvatarURLPrefix = "/uploads/avatars/"
+
+	// maxAvatarSourceDimension bounds the decoded image so a small file cannot
+	// expand into a huge bitmap
+	maxAvatarSourceDimension = 8192
+)
+
+// avatarContentTypes lists the accepted types as reported by content sniffing
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+var (
+	// ErrAvatarTooLarge is returned when an upload exceeds MaxAvatarSize
+	ErrAvatarTooLarge = errors.New("avatar must be at most 5 MB")
+	// ErrAvatarType is returned when the upload is not a JPEG, PNG or WebP image
+	ErrAvatarType = errors.New("avatar must be a JPEG, PNG or WebP image")
+	// ErrInvalidAvatar is returned when the image cannot be decoded
+	ErrInvalidAvatar = errors.New("avatar image is invalid")
+)
+
+// AvatarDir returns the directory avatars are stored in, below UPLOAD_DIR
+func AvatarDir() string {
+	return filepath.Join(helpers.GetEnv("UPLOAD_DIR", "uploads"), "avatars")
+}
+
+// AvatarFilename returns the file name of a user's avatar
+func AvatarFilename(userID string) string {
+	return userID + ".webp"
+}
+
+type AvatarService struct {
+	db  *gorm.DB
+	dir string
+}
+
+func NewAvatarService() *AvatarService {
+	return &AvatarService{
+		db:  database.DB,
+		dir: AvatarDir(),
+	}
+}
+
+// Upload validates the image in r, stores it as the user's 256×256 WebP
+// avatar and returns its URL. The type is taken from the content rather than
+// the file name.
+func (s *AvatarService) Upload(userID string, r io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxAvatarSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > MaxAvatarSize {
+		return "", ErrAvatarTooLarge
+	}
+
+	avatar, err := processAvatar(data)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.write(AvatarFilename(userID), avatar); err != nil {
+		return "", fmt.Errorf("failed to store avatar: %w", err)
+	}
+
+	avatarURL := AvatarURLPrefix + AvatarFilename(userID)
+	result := s.db.Model(&models.User{}).Where("id = ?", userID).Update("avatar_url", avatarURL)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+	return avatarURL, nil
+}
+
+// RemoveAvatar deletes a user's stored avatar file, if any
+func RemoveAvatar(userID string) error {
+	err := os.Remove(filepath.Join(AvatarDir(), AvatarFilename(userID)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// write replaces name in the avatar directory, writing to a temporary file
+// first so readers never see a partial image
+func (s *AvatarService) write(name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(s.dir, name+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+}
+
+// processAvatar checks the sniffed content type of data, then crops the image
+// to a centred square and encodes it as an AvatarDimension-sized WebP
+func processAvatar(data []byte) ([]byte, error) {
+	if !avatarContentTypes[http.DetectContentType(data)] {
+		return nil, ErrAvatarType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatar
+	}
+	if config.Width > maxAvatarSourceDimension || config.Height > maxAvatarSourceDimension {
+		return nil, fmt.Errorf("%w: larger than %dx%d pixels", ErrInvalidAvatar, maxAvatarSourceDimension, maxAvatarSourceDimension)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidAvatar
+	}
+
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, resizeAvatar(src), nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resizeAvatar scales the largest centred square of src to AvatarDimension
+func resizeAvatar(src image.Image) image.Image {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	square := image.Rect(x, y, x+side, y+side)
+
+	dst := image.NewNRGBA(image.Rect(0, 0, AvatarDimension, AvatarDimension))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, square, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/webp"
+)
+
+func encodeTestImage(t *testing.T, encode func(*bytes.Buffer, image.Image) error, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, height/2, color.RGBA{R: 200, A: 255})
+	}
+
+	var buf bytes.Buffer
+	if err := encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProcessAvatarResizesToSquareWebP(t *testing.T) {
+	tests := map[string]func(*bytes.Buffer, image.Image) error{
+		"png": func(buf *bytes.Buffer, img image.Image) error { return png.Encode(buf, img) },
+		"jpeg": func(buf *bytes.Buffer, img image.Image) error {
+			return jpeg.Encode(buf, img, nil)
+		},
+		"webp": func(buf *bytes.Buffer, img image.Image) error { return nativewebp.Encode(buf, img, nil) },
+	}
+
+	for name, encode := range tests {
+		t.Run(name, func(t *testing.T) {
+			avatar, err := processAvatar(encodeTestImage(t, encode, 640, 480))
+			if err != nil {
+				t.Fatalf("processAvatar() error = %v", err)
+			}
+
+			config, err := webp.DecodeConfig(bytes.NewReader(avatar))
+			if err != nil {
+				t.Fatalf("result is not a WebP image: %v", err)
+			}
+			if config.Width != AvatarDimension || config.Height != AvatarDimension {
+				t.Errorf("avatar is %dx%d, want %dx%d", config.Width, config.Height, AvatarDimension, AvatarDimension)
+			}
+		})
+	}
+}
+
+func TestProcessAvatarRejectsOtherContentTypes(t *testing.T) {
+	gifData := encodeTestImage(t, func(buf *bytes.Buffer, img image.Image) error {
+		return gif.Encode(buf, img, nil)
+	}, 16, 16)
+
+	tests := map[string][]byte{
+		"gif":        gifData,
+		"text":       []byte("definitely not an image, even if it is called avatar.png"),
+		"html":       []byte("<html><body><img src=x onerror=alert(1)></body></html>"),
+		"svg":        []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`),
+		"empty file": {},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := processAvatar(data); !errors.Is(err, ErrAvatarType) {
+				t.Errorf("processAvatar() error = %v, want %v", err, ErrAvatarType)
+			}
+		})
+	}
+}
+
+func TestProcessAvatarRejectsCorruptImage(t *testing.T) {
+	data := encodeTestImage(t, func(buf *bytes.Buffer, img image.Image) error { return png.Encode(buf, img) }, 32, 32)
+
+	// Keep the PNG signature so sniffing passes, but cut off the image data
+	if _, err := processAvatar(data[:40]); !errors.Is(err, ErrInvalidAvatar) {
+		t.Errorf("processAvatar() error = %v, want %v", err, ErrInvalidAvatar)
+	}
+}
+
+func TestAvatarUploadRejectsOversizedFile(t *testing.T) {
+	service := &AvatarService{dir: t.TempDir()}
+
+	data := make([]byte, MaxAvatarSize+1)
+	if _, err := service.Upload("user-1", bytes.NewReader(data)); !errors.Is(err, ErrAvatarTooLarge) {
+		t.Errorf("Upload() error = %v, want %v", err, ErrAvatarTooLarge)
+	}
+}
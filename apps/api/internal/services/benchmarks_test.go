@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"api/internal/database"
+	"api/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// benchSearchUsers is the number of users seeded for the search benchmarks
+const benchSearchUsers = 10000
+
+// seedSearchBenchmark connects to the database and inserts users sharing a
+// unique company, returning a search term matching exactly one of them
+func seedSearchBenchmark(b *testing.B) string {
+	b.Helper()
+
+	if database.DB == nil {
+		if err := database.Connect(); err != nil {
+			b.Skipf("database not available: %v", err)
+		}
+	}
+	// gorm connects lazily, so check the database is actually reachable
+	if sqlDB, err := database.DB.DB(); err != nil || sqlDB.Ping() != nil {
+		b.Skip("database not available")
+	}
+
+	company := models.Company{Name: "bench-" + uuid.New().String()}
+	if err := database.DB.Create(&company).Error; err != nil {
+		b.Fatalf("failed to seed company: %v", err)
+	}
+	err := database.DB.Exec(`
+		INSERT INTO users (email, password, name, company_id)
+		SELECT 'bench-' || n || '-' || ? || '@example.com', 'x', 'Bench User ' || n, ?
+		FROM generate_series(1, ?) AS n
+	`, company.Name, company.ID, benchSearchUsers).Error
+	if err != nil {
+		b.Fatalf("failed to seed users: %v", err)
+	}
+	b.Cleanup(func() {
+		database.DB.Exec("DELETE FROM users WHERE company_id = ?", company.ID)
+		database.DB.Delete(&company)
+	})
+
+	return fmt.Sprintf("bench-%d-%s@example.com", benchSearchUsers/2, company.Name)
+}
+
+// BenchmarkUserSearchILIKE measures the previous substring search
+func BenchmarkUserSearchILIKE(b *testing.B) {
+	search := seedSearchBenchmark(b)
+	pattern := "%" + search + "%"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var users []models.User
+		var total int64
+		query := database.DB.Model(&models.User{}).
+			Where("email ILIKE ? OR name ILIKE ? OR company_id IN (SELECT id FROM companies WHERE name ILIKE ?)", pattern, pattern, pattern)
+		if err := query.Count(&total).Error; err != nil {
+			b.Fatal(err)
+		}
+		if err := query.Select(userListColumns).Order("created_at DESC").Limit(20).Find(&users).Error; err != nil {
+			b.Fatal(err)
+		}
+		if len(users) != 1 {
+			b.Fatalf("found %d users, want 1", len(users))
+		}
+	}
+}
+
+// BenchmarkUserSearchFullText measures the tsvector search used by the user list
+func BenchmarkUserSearchFullText(b *testing.B) {
+	search := seedSearchBenchmark(b)
+	service := NewRBACService()
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		users, _, err := service.GetUsersWithRolesPaginated(ctx, 1, 20, UserListFilter{Search: search}, "", false)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(users) != 1 {
+			b.Fatalf("found %d users, want 1", len(users))
+		}
+	}
+}
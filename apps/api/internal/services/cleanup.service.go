@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DefaultCleanupInterval is how often expired records are deleted when
+// PASSWORD_RESET_CLEANUP_INTERVAL is not set
+const DefaultCleanupInterval = time.Hour
+
+// Cleanup table names, as reported in CleanupStats
+const (
+	CleanupTablePasswordResetTokens = "password_reset_tokens"
+	CleanupTableIdempotencyKeys     = "idempotency_keys"
+	CleanupTableWebhookDeliveries   = "webhook_delivery_attempts"
+)
+
+// CleanupStats describes the most recent cleanup run
+type CleanupStats struct {
+	// LastRunAt is nil until the first run has finished
+	LastRunAt *time.Time
+	// Deleted holds the number of rows the run removed from each table
+	Deleted map[string]int64
+}
+
+var lastCleanup struct {
+	sync.RWMutex
+	stats CleanupStats
+}
+
+// expiredRecords selects the rows of one table that a cleanup run deletes
+type expiredRecords struct {
+	table string
+	query *gorm.DB
+	model interface{}
+}
+
+type CleanupService struct {
+	db *gorm.DB
+}
+
+func NewCleanupService() *CleanupService {
+	return &CleanupService{
+		db: database.DB,
+	}
+}
+
+// Run deletes expired password reset tokens and idempotency keys, and webhook
+// delivery attempts older than WEBHOOK_DELIVERY_RETENTION, and returns the
+// number of rows removed from each table. A failure on one table does not stop
+// the others from being cleaned.
+func (s *CleanupService) Run() (map[string]int64, error) {
+	now := time.Now()
+	cleanups := []expiredRecords{
+		{CleanupTablePasswordResetTokens, s.db.Where("expires_at < ?", now), &models.PasswordResetToken{}},
+		{CleanupTableIdempotencyKeys, s.db.Where("created_at < ?", now.Add(-IdempotencyKeyTTL)), &models.IdempotencyKey{}},
+	}
+	if retention := helpers.GetEnvDuration("WEBHOOK_DELIVERY_RETENTION", DefaultWebhookDeliveryRetention); retention > 0 {
+		cleanups = append(cleanups, expiredRecords{CleanupTableWebhookDeliveries, s.db.Where("attempted_at < ?", now.Add(-retention)), &models.WebhookDeliveryAttempt{}})
+	}
+
+	deleted := make(map[string]int64, len(cleanups))
+	var errs []error
+	for _, cleanup := range cleanups {
+		result := cleanup.query.Delete(cleanup.model)
+		if result.Error != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", cleanup.table, result.Error))
+			continue
+		}
+		deleted[cleanup.table] = result.RowsAffected
+	}
+
+	lastCleanup.Lock()
+	lastCleanup.stats = CleanupStats{LastRunAt: &now, Deleted: deleted}
+	lastCleanup.Unlock()
+
+	return deleted, errors.Join(errs...)
+}
+
+// GetCleanupStats returns the outcome of the most recent cleanup run in this
+// process
+func GetCleanupStats() CleanupStats {
+	lastCleanup.RLock()
+	defer lastCleanup.RUnlock()
+
+	deleted := make(map[string]int64, len(lastCleanup.stats.Deleted))
+	for table, count := range lastCleanup.stats.Deleted {
+		deleted[table] = count
+	}
+	return CleanupStats{LastRunAt: lastCleanup.stats.LastRunAt, Deleted: deleted}
+}
+
+// StartCleanupJob runs a cleanup every interval in the background until the
+// returned function is called
+func StartCleanupJob(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				deleted, err := NewCleanupService().Run()
+				if err != nil {
+					logger.Error("Failed to clean up expired records", "error", err)
+				}
+				logger.Info("Expired records cleaned up",
+					CleanupTablePasswordResetTokens, deleted[CleanupTablePasswordResetTokens],
+					CleanupTableIdempotencyKeys, deleted[CleanupTableIdempotencyKeys],
+					CleanupTableWebhookDeliveries, deleted[CleanupTableWebhookDeliveries],
+				)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
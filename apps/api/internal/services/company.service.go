@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCompanyNotFound is returned when a company does not exist
+var ErrCompanyNotFound = errors.New("company not found")
+
+type CompanyService struct {
+	db *gorm.DB
+}
+
+func NewCompanyService() *CompanyService {
+	return &CompanyService{
+		db: database.DB,
+	}
+}
+
+// NewCompanyServiceWithDB returns a CompanyService using db, e.g. a transaction
+func NewCompanyServiceWithDB(db *gorm.DB) *CompanyService {
+	return &CompanyService{
+		db: db,
+	}
+}
+
+// ListCompanies returns all companies ordered by name
+func (s *CompanyService) ListCompanies(ctx context.Context) ([]models.Company, error) {
+	var companies []models.Company
+	err := s.db.WithContext(ctx).Order("name ASC").Find(&companies).Error
+	return companies, err
+}
+
+// GetCompany returns a company by ID
+func (s *CompanyService) GetCompany(ctx context.Context, id string) (*models.Company, error) {
+	var company models.Company
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&company).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+	return &company, nil
+}
+
+// CreateCompany stores a company
+func (s *CompanyService) CreateCompany(ctx context.Context, company *models.Company) error {
+	return s.db.WithContext(ctx).Create(company).Error
+}
+
+// UpdateCompany applies updates to a company
+func (s *CompanyService) UpdateCompany(ctx context.Context, id string, updates map[string]interface{}) error {
+	result := s.db.WithContext(ctx).Model(&models.Company{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCompanyNotFound
+	}
+	return nil
+}
+
+// DeleteCompany removes a company. Its members are kept without a company.
+func (s *CompanyService) DeleteCompany(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Where("id = ?", id).Delete(&models.Company{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCompanyNotFound
+	}
+	return nil
+}
+
+// FindOrCreateCompany returns the company with the given name, creating it
+// if it does not exist yet
+func (s *CompanyService) FindOrCreateCompany(ctx context.Context, name string) (*models.Company, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("company name is required")
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Company{Name: name}).Error; err != nil {
+		return nil, err
+	}
+
+	var company models.Company
+	if err := s.db.WithContext(ctx).Where("name = ?", name).First(&company).Error; err != nil {
+		return nil, err
+	}
+	return &company, nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"api/internal/database"
+	"gorm.io/gorm"
+)
+
+// ErrTableNotAnalyzable is returned when no common queries are known for a table
+var ErrTableNotAnalyzable = errors.New("table cannot be analyzed")
+
+// DefaultMissingIndexMinRows is the smallest table reported for missing
+// indexes; smaller tables are read faster sequentially
+const DefaultMissingIndexMinRows = 1000
+
+// analyzedQuery is a query the API runs often, with sample arguments to plan it with
+type analyzedQuery struct {
+	name  string
+	query string
+	args  []interface{}
+}
+
+// sampleID stands in for user, role and resource IDs in analyzed queries
+const sampleID = "00000000-0000-0000-0000-000000000000"
+
+// analyzedQueries lists the most common queries on each table
+var analyzedQueries = map[string][]analyzedQuery{
+	"users": {
+		{name: "login by email", query: "SELECT * FROM users WHERE email = ? AND deleted_at IS NULL LIMIT 1", args: []interface{}{"analyze@example.com"}},
+		{name: "list newest", query: "SELECT * FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 20"},
+		{name: "search", query: "SELECT * FROM users WHERE deleted_at IS NULL AND search_vector @@ plainto_tsquery('simple', ?) LIMIT 20", args: []interface{}{"analyze"}},
+		{name: "filter by company", query: "SELECT * FROM users WHERE deleted_at IS NULL AND company_id = ? LIMIT 20", args: []interface{}{sampleID}},
+	},
+	"user_roles": {
+		{name: "roles of user", query: "SELECT roles.* FROM roles JOIN user_roles ON user_roles.role_id = roles.id WHERE user_roles.user_id = ?", args: []interface{}{sampleID}},
+		{name: "users in role", query: "SELECT * FROM user_roles WHERE role_id = ? LIMIT 20", args: []interface{}{sampleID}},
+		{name: "expired assignments", query: "SELECT * FROM user_roles WHERE expires_at IS NOT NULL AND expires_at <= now()"},
+	},
+	"roles": {
+		{name: "by name", query: "SELECT * FROM roles WHERE name = ? LIMIT 1", args: []interface{}{"admin"}},
+		{name: "list by name", query: "SELECT * FROM roles ORDER BY name ASC LIMIT 20"},
+	},
+	"audit_logs": {
+		{name: "list newest", query: "SELECT * FROM audit_logs ORDER BY created_at DESC LIMIT 20"},
+		{name: "filter by actor", query: "SELECT * FROM audit_logs WHERE actor_id = ? ORDER BY created_at DESC LIMIT 20", args: []interface{}{sampleID}},
+		{name: "filter by resource", query: "SELECT * FROM audit_logs WHERE resource_type = ? AND resource_id = ? ORDER BY created_at DESC LIMIT 20", args: []interface{}{"user", sampleID}},
+	},
+}
+
+// AnalyzableTables returns the tables ExplainTable accepts, sorted by name
+func AnalyzableTables() []string {
+	tables := make([]string, 0, len(analyzedQueries))
+	for table := range analyzedQueries {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	return tables
+}
+
+// QueryPlan is the executed plan of a common query
+type QueryPlan struct {
+	Name  string
+	Query string
+	// Plan is the EXPLAIN (FORMAT JSON) output
+	Plan json.RawMessage
+}
+
+// TableScanStats compares how often a table was read sequentially and
+// through its indexes since the statistics were last reset
+type TableScanStats struct {
+	Table      string
+	SeqScan    int64
+	SeqTupRead int64
+	IdxScan    int64
+	LiveRows   int64
+	Indexes    int64
+}
+
+// SeqScanRatio is the share of the table's scans that were sequential
+func (s TableScanStats) SeqScanRatio() float64 {
+	total := s.SeqScan + s.IdxScan
+	if total == 0 {
+		return 0
+	}
+	return float64(s.SeqScan) / float64(total)
+}
+
+type DBDebugService struct {
+	db *gorm.DB
+}
+
+func NewDBDebugService() *DBDebugService {
+	return &DBDebugService{
+		db: database.DB,
+	}
+}
+
+// ExplainTable runs EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) on the common
+// queries of table. The queries run in a read-only transaction that is rolled
+// back.
+func (s *DBDebugService) ExplainTable(ctx context.Context, table string) ([]QueryPlan, error) {
+	queries, ok := analyzedQueries[table]
+	if !ok {
+		return nil, ErrTableNotAnalyzable
+	}
+
+	tx := s.db.WithContext(ctx).Begin(&sql.TxOptions{ReadOnly: true})
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+
+	plans := make([]QueryPlan, 0, len(queries))
+	for _, q := range queries {
+		var plan string
+		if err := tx.Raw("EXPLAIN (ANALYZE, BUFFERS, FORMAT JSON) "+q.query, q.args...).Row().Scan(&plan); err != nil {
+			return nil, fmt.Errorf("failed to explain %q: %w", q.name, err)
+		}
+		plans = append(plans, QueryPlan{
+			Name:  q.name,
+			Query: q.query,
+			Plan:  json.RawMessage(plan),
+		})
+	}
+	return plans, nil
+}
+
+// MissingIndexes returns the tables of at least minRows rows that were read
+// sequentially more often than through an index, most rows read first
+func (s *DBDebugService) MissingIndexes(ctx context.Context, minRows int64) ([]TableScanStats, error) {
+	var stats []TableScanStats
+	err := s.db.WithContext(ctx).Raw(`
+		SELECT t.relname AS "table", t.seq_scan, t.seq_tup_read,
+			COALESCE(t.idx_scan, 0) AS idx_scan, t.n_live_tup AS live_rows,
+			COUNT(i.indexrelid) AS indexes
+		FROM pg_stat_user_tables t
+		LEFT JOIN pg_stat_user_indexes i ON i.relid = t.relid
+		WHERE t.schemaname = current_schema() AND t.n_live_tup >= ?
+		GROUP BY t.relid, t.relname, t.seq_scan, t.seq_tup_read, t.idx_scan, t.n_live_tup
+		HAVING t.seq_scan > COALESCE(t.idx_scan, 0)
+		ORDER BY t.seq_tup_read DESC
+	`, minRows).Scan(&stats).Error
+	return stats, err
+}
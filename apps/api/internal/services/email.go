@@ -1,20 +1,65 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"os"
 	"strconv"
+	"sync"
 	"time"
 
+	"api/internal/helpers"
 	"api/internal/logger"
+	"api/internal/queue"
+	"api/internal/tracing"
+	"github.com/sony/gobreaker"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/gomail.v2"
+	"gorm.io/gorm"
 )
 
 type EmailService interface {
-	SendPasswordReset(to, token string) error
+	// SendPasswordReset records its work as a span in the trace carried by ctx
+	SendPasswordReset(ctx context.Context, to, token string) error
+	SendInvitation(to, token string) error
+	SendEmailVerification(to, token string) error
+	SendWelcomeEmail(to, name string) error
+	// SendAdminPasswordReset tells a user an admin has set a new password
+	SendAdminPasswordReset(to, name string) error
 	SendTestEmail(to, subject, htmlContent, textContent string) error
 }
 
+// emailTransport is an EmailService that can also deliver pre-rendered jobs
+// from the email queue
+type emailTransport interface {
+	EmailService
+	queue.Sender
+	passwordResetJob(ctx context.Context, to, token string) queue.EmailJob
+	invitationJob(to, token string) queue.EmailJob
+	emailVerificationJob(to, token string) queue.EmailJob
+	welcomeJob(to, name string) (queue.EmailJob, bool)
+	adminPasswordResetJob(to, name string) queue.EmailJob
+}
+
+// QueuedEmailService hands emails to the background email queue so that
+// requests do not wait on the mail server
+type QueuedEmailService struct {
+	transport emailTransport
+	queue     *queue.EmailQueue
+}
+
+// defaultMaxRetries is the number of delivery attempts for synchronous sends
+const defaultMaxRetries = 3
+
+// retryDelay is the wait after the first failed attempt; it grows linearly
+var retryDelay = time.Second
+
+var (
+	emailQueue        *queue.EmailQueue
+	emailQueueService *QueuedEmailService
+	emailQueueOnce    sync.Once
+)
+
 type ConsoleEmailService struct{}
 
 type SMTPConfig struct {
@@ -28,12 +73,56 @@ type SMTPConfig struct {
 }
 
 type SMTPEmailService struct {
-	config SMTPConfig
-	dialer *gomail.Dialer
+	config  SMTPConfig
+	dialer  smtpDialer
+	breaker *gobreaker.CircuitBreaker
 }
 
+// NewEmailService returns the configured email service. When EMAIL_WORKER_COUNT
+// is greater than zero, emails are sent by a shared background worker pool.
 func NewEmailService() EmailService {
-	emailProvider := os.Getenv("EMAIL_PROVIDER")
+	workers := helpers.GetEnvInt("EMAIL_WORKER_COUNT", 4)
+	if workers <= 0 {
+		return newEmailTransport()
+	}
+
+	emailQueueOnce.Do(func() {
+		transport := newEmailTransport()
+		emailQueue = queue.NewEmailQueue(transport, queue.Config{
+			Workers:     workers,
+			BufferSize:  helpers.GetEnvInt("EMAIL_QUEUE_SIZE", 100),
+			MaxRetries:  helpers.GetEnvInt("EMAIL_MAX_RETRIES", 3),
+			BaseBackoff: time.Second,
+		})
+		emailQueueService = &QueuedEmailService{
+			transport: transport,
+			queue:     emailQueue,
+		}
+		logger.Info("Email queue started", "workers", workers)
+	})
+
+	return emailQueueService
+}
+
+// GetEmailQueueStats returns email queue statistics and whether the queue is
+// enabled. The queue starts with the first email, so stats are zero until then.
+func GetEmailQueueStats() (queue.Stats, bool) {
+	enabled := helpers.GetEnvInt("EMAIL_WORKER_COUNT", 4) > 0
+	if emailQueue == nil {
+		return queue.Stats{}, enabled
+	}
+	return emailQueue.Stats(), enabled
+}
+
+// CloseEmailQueue waits for queued emails to be delivered
+func CloseEmailQueue() {
+	if emailQueue != nil {
+		emailQueue.Close()
+	}
+}
+
+func newEmailTransport() emailTransport {
+	emailProvider := helpers.GetEnv("EMAIL_PROVIDER", "")
 
 	switch emailProvider {
 	case "smtp":
@@ -57,18 +146,99 @@ func NewEmailService() EmailService {
 		logger.Info("SMTP email service initialized successfully")
 		return service
 	case "sendgrid":
-		// Future SendGrid implementation
-		logger.Info("SendGrid email service not implemented yet, falling back to console")
-		return &ConsoleEmailService{}
+		config, err := loadSendGridConfig()
+		if err != nil {
+			logger.Warn("Failed to load SendGrid config, falling back to console", "error", err)
+			return &ConsoleEmailService{}
+		}
+
+		logger.Info("SendGrid email service initialized successfully")
+		return NewSendGridEmailService(config, nil)
+	case "ses":
+		config, err := loadSESConfig()
+		if err != nil {
+			logger.Warn("Failed to load SES config, falling back to console", "error", err)
+			return &ConsoleEmailService{}
+		}
+
+		service, err := NewSESEmailService(config)
+		if err != nil {
+			logger.Warn("Failed to create SES service, falling back to console", "error", err)
+			return &ConsoleEmailService{}
+		}
+
+		logger.Info("SES email service initialized successfully")
+		return service
 	default:
 		return &ConsoleEmailService{}
 	}
 }
 
-func (c *ConsoleEmailService) SendPasswordReset(to, token string) error {
-	resetURL := fmt.Sprintf("%s/reset-password?token=%s",
-		getBaseURL(), token)
-	companyName := "Studio45" // Default company name for console service
+func (q *QueuedEmailService) SendPasswordReset(ctx context.Context, to, token string) error {
+	ctx, span := startEmailSpan(ctx, "email.SendPasswordReset")
+	defer span.End()
+
+	job := q.transport.passwordResetJob(ctx, to, token)
+	if err := q.queue.Enqueue(job); err != nil {
+		logger.Warn("Failed to enqueue password reset email, sending directly", "error", err)
+		return q.transport.SendPasswordReset(ctx, to, token)
+	}
+	return nil
+}
+
+func (q *QueuedEmailService) SendInvitation(to, token string) error {
+	job := q.transport.invitationJob(to, token)
+	if err := q.queue.Enqueue(job); err != nil {
+		logger.Warn("Failed to enqueue invitation email, sending directly", "error", err)
+		return q.transport.SendInvitation(to, token)
+	}
+	return nil
+}
+
+func (q *QueuedEmailService) SendEmailVerification(to, token string) error {
+	job := q.transport.emailVerificationJob(to, token)
+	if err := q.queue.Enqueue(job); err != nil {
+		logger.Warn("Failed to enqueue email verification, sending directly", "error", err)
+		return q.transport.SendEmailVerification(to, token)
+	}
+	return nil
+}
+
+func (q *QueuedEmailService) SendWelcomeEmail(to, name string) error {
+	job, ok := q.transport.welcomeJob(to, name)
+	if !ok {
+		return nil
+	}
+	if err := q.queue.Enqueue(job); err != nil {
+		logger.Warn("Failed to enqueue welcome email, sending directly", "error", err)
+		return q.transport.SendWelcomeEmail(to, name)
+	}
+	return nil
+}
+
+func (q *QueuedEmailService) SendAdminPasswordReset(to, name string) error {
+	job := q.transport.adminPasswordResetJob(to, name)
+	if err := q.queue.Enqueue(job); err != nil {
+		logger.Warn("Failed to enqueue admin password reset email, sending directly", "error", err)
+		return q.transport.SendAdminPasswordReset(to, name)
+	}
+	return nil
+}
+
+// SendTestEmail is sent synchronously so admins see delivery errors immediately
+func (q *QueuedEmailService) SendTestEmail(to, subject, htmlContent, textContent string) error {
+	return q.transport.SendTestEmail(to, subject, htmlContent, textContent)
+}
+
+// startEmailSpan starts a span for sending an email as part of the trace in ctx
+func startEmailSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name)
+}
+
+// buildPasswordResetJob renders the password reset email, preferring the
+// database template over the built-in fallback
+func buildPasswordResetJob(ctx context.Context, to, token, companyName string) queue.EmailJob {
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", getBaseURL(), token)
 
 	// Try to get template from database first
 	templateService := NewEmailTemplateService()
@@ -77,23 +247,243 @@ func (c *ConsoleEmailService) SendPasswordReset(to, token string) error {
 		"CompanyName": companyName,
 	}
 
-	rendered, err := templateService.RenderTemplate("password_reset", variables)
-	var subject, textContent string
+	rendered, err := templateService.RenderTemplate(ctx, "password_reset", DefaultTemplateLanguage, variables)
+	if err != nil {
+		// Fallback to hardcoded templates if database template is not available
+		logger.Warn("Failed to load email template from database, using fallback", "error", err)
+		return queue.EmailJob{
+			To:          to,
+			Subject:     "Reset Your Password",
+			HTMLContent: getPasswordResetHTMLTemplate(resetURL, companyName),
+			TextContent: getPasswordResetTextTemplate(resetURL, companyName),
+		}
+	}
+
+	return queue.EmailJob{
+		To:          to,
+		Subject:     rendered.Subject,
+		HTMLContent: rendered.HTMLContent,
+		TextContent: rendered.TextContent,
+	}
+}
+
+// buildInvitationJob renders the user invitation email, preferring the
+// database template over the built-in fallback
+func buildInvitationJob(to, token, companyName string) queue.EmailJob {
+	invitationURL := fmt.Sprintf("%s/accept-invitation?token=%s", getBaseURL(), token)
+
+	templateService := NewEmailTemplateService()
+	variables := map[string]string{
+		"InvitationURL": invitationURL,
+		"CompanyName":   companyName,
+	}
+
+	rendered, err := templateService.RenderTemplate(context.Background(), "user_invitation", DefaultTemplateLanguage, variables)
+	if err != nil {
+		logger.Warn("Failed to load email template from database, using fallback", "error", err)
+		return queue.EmailJob{
+			To:          to,
+			Subject:     fmt.Sprintf("You're invited to join %s", companyName),
+			HTMLContent: getInvitationHTMLTemplate(invitationURL, companyName),
+			TextContent: getInvitationTextTemplate(invitationURL, companyName),
+		}
+	}
+
+	return queue.EmailJob{
+		To:          to,
+		Subject:     rendered.Subject,
+		HTMLContent: rendered.HTMLContent,
+		TextContent: rendered.TextContent,
+	}
+}
+
+// buildEmailVerificationJob renders the email asking a user to verify an
+// address they added, preferring the database template over the built-in
+// fallback
+func buildEmailVerificationJob(to, token, companyName string) queue.EmailJob {
+	verificationURL := fmt.Sprintf("%s/verify-email?token=%s", getBaseURL(), token)
+
+	templateService := NewEmailTemplateService()
+	variables := map[string]string{
+		"VerificationURL": verificationURL,
+		"CompanyName":     companyName,
+	}
 
+	rendered, err := templateService.RenderTemplate(context.Background(), "email_verification", DefaultTemplateLanguage, variables)
 	if err != nil {
-		// Fallback to hardcoded display if database template is not available
 		logger.Warn("Failed to load email template from database, using fallback", "error", err)
-		subject = "Reset Your Password"
-		textContent = fmt.Sprintf("Click the link below to reset your password:\n%s\n\nThis link expires in 15 minutes.", resetURL)
-	} else {
-		subject = rendered.Subject
-		textContent = rendered.TextContent
+		return queue.EmailJob{
+			To:          to,
+			Subject:     "Verify your email address",
+			HTMLContent: getEmailVerificationHTMLTemplate(verificationURL, companyName),
+			TextContent: getEmailVerificationTextTemplate(verificationURL, companyName),
+		}
+	}
+
+	return queue.EmailJob{
+		To:          to,
+		Subject:     rendered.Subject,
+		HTMLContent: rendered.HTMLContent,
+		TextContent: rendered.TextContent,
+	}
+}
+
+// buildWelcomeJob renders the welcome email sent after registration. The
+// welcome email is opt-in per deployment: without a "welcome" template in the
+// database it reports false and nothing is sent. A template that fails to
+// render falls back to the built-in one.
+func buildWelcomeJob(to, name, companyName string) (queue.EmailJob, bool) {
+	loginURL := fmt.Sprintf("%s/login", getBaseURL())
+
+	templateService := NewEmailTemplateService()
+	variables := map[string]string{
+		"Name":        name,
+		"LoginURL":    loginURL,
+		"CompanyName": companyName,
+	}
+
+	rendered, err := templateService.RenderTemplate(context.Background(), "welcome", DefaultTemplateLanguage, variables)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Welcome email template not found, skipping welcome email", "to", to)
+			return queue.EmailJob{}, false
+		}
+
+		logger.Warn("Failed to render welcome email template, using fallback", "error", err)
+		return queue.EmailJob{
+			To:          to,
+			Subject:     fmt.Sprintf("Welcome to %s", companyName),
+			HTMLContent: getWelcomeHTMLTemplate(name, loginURL, companyName),
+			TextContent: getWelcomeTextTemplate(name, loginURL, companyName),
+		}, true
+	}
+
+	return queue.EmailJob{
+		To:          to,
+		Subject:     rendered.Subject,
+		HTMLContent: rendered.HTMLContent,
+		TextContent: rendered.TextContent,
+	}, true
+}
+
+// buildAdminPasswordResetJob renders the email telling a user that an admin
+// has reset their password, preferring the database template over the
+// built-in fallback
+func buildAdminPasswordResetJob(to, name, companyName string) queue.EmailJob {
+	loginURL := fmt.Sprintf("%s/login", getBaseURL())
+
+	templateService := NewEmailTemplateService()
+	variables := map[string]string{
+		"Name":        name,
+		"LoginURL":    loginURL,
+		"CompanyName": companyName,
+	}
+
+	rendered, err := templateService.RenderTemplate(context.Background(), "admin_password_reset", DefaultTemplateLanguage, variables)
+	if err != nil {
+		logger.Warn("Failed to load email template from database, using fallback", "error", err)
+		return queue.EmailJob{
+			To:          to,
+			Subject:     "Your password was reset",
+			HTMLContent: getAdminPasswordResetHTMLTemplate(name, loginURL, companyName),
+			TextContent: getAdminPasswordResetTextTemplate(name, loginURL, companyName),
+		}
 	}
 
+	return queue.EmailJob{
+		To:          to,
+		Subject:     rendered.Subject,
+		HTMLContent: rendered.HTMLContent,
+		TextContent: rendered.TextContent,
+	}
+}
+
+func (c *ConsoleEmailService) passwordResetJob(ctx context.Context, to, token string) queue.EmailJob {
+	return buildPasswordResetJob(ctx, to, token, "Studio45") // Default company name for console service
+}
+
+func (c *ConsoleEmailService) invitationJob(to, token string) queue.EmailJob {
+	return buildInvitationJob(to, token, "Studio45")
+}
+
+func (c *ConsoleEmailService) emailVerificationJob(to, token string) queue.EmailJob {
+	return buildEmailVerificationJob(to, token, "Studio45")
+}
+
+func (c *ConsoleEmailService) welcomeJob(to, name string) (queue.EmailJob, bool) {
+	return buildWelcomeJob(to, name, "Studio45")
+}
+
+func (c *ConsoleEmailService) adminPasswordResetJob(to, name string) queue.EmailJob {
+	return buildAdminPasswordResetJob(to, name, "Studio45")
+}
+
+func (c *ConsoleEmailService) Deliver(job queue.EmailJob) error {
+	logger.Info("Email (console mode)",
+		"to", job.To,
+		"subject", job.Subject,
+		"content", job.TextContent)
+
+	return nil
+}
+
+func (c *ConsoleEmailService) SendPasswordReset(ctx context.Context, to, token string) error {
+	ctx, span := startEmailSpan(ctx, "email.SendPasswordReset")
+	defer span.End()
+
+	job := c.passwordResetJob(ctx, to, token)
+
 	logger.Info("Password reset email (console mode)",
 		"to", to,
-		"subject", subject,
-		"content", textContent)
+		"subject", job.Subject,
+		"content", job.TextContent)
+
+	return nil
+}
+
+func (c *ConsoleEmailService) SendInvitation(to, token string) error {
+	job := c.invitationJob(to, token)
+
+	logger.Info("Invitation email (console mode)",
+		"to", to,
+		"subject", job.Subject,
+		"content", job.TextContent)
+
+	return nil
+}
+
+func (c *ConsoleEmailService) SendEmailVerification(to, token string) error {
+	job := c.emailVerificationJob(to, token)
+
+	logger.Info("Email verification (console mode)",
+		"to", to,
+		"subject", job.Subject,
+		"content", job.TextContent)
+
+	return nil
+}
+
+func (c *ConsoleEmailService) SendWelcomeEmail(to, name string) error {
+	job, ok := c.welcomeJob(to, name)
+	if !ok {
+		return nil
+	}
+
+	logger.Info("Welcome email (console mode)",
+		"to", to,
+		"subject", job.Subject,
+		"content", job.TextContent)
+
+	return nil
+}
+
+func (c *ConsoleEmailService) SendAdminPasswordReset(to, name string) error {
+	job := c.adminPasswordResetJob(to, name)
+
+	logger.Info("Admin password reset email (console mode)",
+		"to", to,
+		"subject", job.Subject,
+		"content", job.TextContent)
 
 	return nil
 }
@@ -109,21 +499,17 @@ func (c *ConsoleEmailService) SendTestEmail(to, subject, htmlContent, textConten
 }
 
 func getBaseURL() string {
-	baseURL := os.Getenv("FRONTEND_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:5173"
-	}
-	return baseURL
+	return helpers.GetEnv("FRONTEND_URL", "http://localhost:5173")
 }
 
 func loadSMTPConfig() (SMTPConfig, error) {
-	host := os.Getenv("SMTP_HOST")
-	portStr := os.Getenv("SMTP_PORT")
-	username := os.Getenv("SMTP_USERNAME")
-	password := os.Getenv("SMTP_PASSWORD")
-	fromEmail := os.Getenv("SMTP_FROM_EMAIL")
-	fromName := os.Getenv("SMTP_FROM_NAME")
-	useTLSStr := os.Getenv("SMTP_USE_TLS")
+	host := helpers.GetEnv("SMTP_HOST", "")
+	portStr := helpers.GetEnv("SMTP_PORT", "")
+	username := helpers.GetEnv("SMTP_USERNAME", "")
+	password := helpers.GetEnv("SMTP_PASSWORD", "")
+	fromEmail := helpers.GetEnv("SMTP_FROM_EMAIL", "")
+	fromName := helpers.GetEnv("SMTP_FROM_NAME", "")
+	useTLSStr := helpers.GetEnv("SMTP_USE_TLS", "")
 
 	if host == "" || portStr == "" || username == "" || password == "" || fromEmail == "" {
 		return SMTPConfig{}, fmt.Errorf("missing required SMTP configuration")
@@ -187,100 +573,150 @@ func NewSMTPEmailService(config SMTPConfig) (*SMTPEmailService, error) {
 	closer.Close()
 
 	return &SMTPEmailService{
-		config: config,
-		dialer: dialer,
+		config:  config,
+		dialer:  dialer,
+		breaker: getSMTPBreaker(),
 	}, nil
 }
 
-func (s *SMTPEmailService) SendPasswordReset(to, token string) error {
-	resetURL := fmt.Sprintf("%s/reset-password?token=%s", getBaseURL(), token)
-	companyName := s.config.FromName
+func (s *SMTPEmailService) passwordResetJob(ctx context.Context, to, token string) queue.EmailJob {
+	return buildPasswordResetJob(ctx, to, token, s.config.FromName)
+}
 
-	// Try to get template from database first
-	templateService := NewEmailTemplateService()
-	variables := map[string]string{
-		"ResetURL":    resetURL,
-		"CompanyName": companyName,
-	}
+func (s *SMTPEmailService) invitationJob(to, token string) queue.EmailJob {
+	return buildInvitationJob(to, token, s.config.FromName)
+}
 
-	rendered, err := templateService.RenderTemplate("password_reset", variables)
-	var subject, htmlContent, textContent string
+func (s *SMTPEmailService) emailVerificationJob(to, token string) queue.EmailJob {
+	return buildEmailVerificationJob(to, token, s.config.FromName)
+}
 
-	if err != nil {
-		// Fallback to hardcoded templates if database template is not available
-		logger.Warn("Failed to load email template from database, using fallback", "error", err)
-		subject = "Reset Your Password"
-		htmlContent = getPasswordResetHTMLTemplate(resetURL, companyName)
-		textContent = getPasswordResetTextTemplate(resetURL, companyName)
-	} else {
-		subject = rendered.Subject
-		htmlContent = rendered.HTMLContent
-		textContent = rendered.TextContent
-	}
+func (s *SMTPEmailService) welcomeJob(to, name string) (queue.EmailJob, bool) {
+	return buildWelcomeJob(to, name, s.config.FromName)
+}
 
+func (s *SMTPEmailService) adminPasswordResetJob(to, name string) queue.EmailJob {
+	return buildAdminPasswordResetJob(to, name, s.config.FromName)
+}
+
+func (s *SMTPEmailService) newMessage(job queue.EmailJob) *gomail.Message {
 	m := gomail.NewMessage()
 	m.SetHeader("From", m.FormatAddress(s.config.FromEmail, s.config.FromName))
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", subject)
+	m.SetHeader("To", job.To)
+	m.SetHeader("Subject", job.Subject)
 
 	// Set plain text body
-	m.SetBody("text/plain", textContent)
+	m.SetBody("text/plain", job.TextContent)
 
 	// Set HTML body
-	m.AddAlternative("text/html", htmlContent)
+	m.AddAlternative("text/html", job.HTMLContent)
 
-	// Retry logic with exponential backoff
-	maxRetries := 3
-	var lastErr error
+	return m
+}
 
-	for i := 0; i < maxRetries; i++ {
-		if err := s.dialer.DialAndSend(m); err != nil {
-			lastErr = err
-			if i < maxRetries-1 {
-				waitTime := time.Duration(i+1) * time.Second
-				logger.Warn("Failed to send email, retrying", "attempt", i+1, "max_retries", maxRetries, "error", err, "wait_time", waitTime)
-				time.Sleep(waitTime)
-				continue
-			}
-		} else {
-			logger.Info("Password reset email sent successfully", "to", to)
-			return nil
-		}
+// Deliver makes a single delivery attempt; the email queue handles retries
+func (s *SMTPEmailService) Deliver(job queue.EmailJob) error {
+	return s.dialAndSend(s.newMessage(job))
+}
+
+func (s *SMTPEmailService) SendPasswordReset(ctx context.Context, to, token string) error {
+	ctx, span := startEmailSpan(ctx, "email.SendPasswordReset")
+	defer span.End()
+
+	m := s.newMessage(s.passwordResetJob(ctx, to, token))
+
+	if err := sendWithRetry(func() error { return s.dialAndSend(m) }, defaultMaxRetries); err != nil {
+		return err
 	}
 
-	return fmt.Errorf("failed to send email after %d attempts: %w", maxRetries, lastErr)
+	logger.Info("Password reset email sent successfully", "to", to)
+	return nil
 }
 
-func (s *SMTPEmailService) SendTestEmail(to, subject, htmlContent, textContent string) error {
-	m := gomail.NewMessage()
-	m.SetHeader("From", m.FormatAddress(s.config.FromEmail, s.config.FromName))
-	m.SetHeader("To", to)
-	m.SetHeader("Subject", subject)
+func (s *SMTPEmailService) SendInvitation(to, token string) error {
+	m := s.newMessage(s.invitationJob(to, token))
 
-	// Set plain text body
-	m.SetBody("text/plain", textContent)
+	if err := sendWithRetry(func() error { return s.dialAndSend(m) }, defaultMaxRetries); err != nil {
+		return err
+	}
 
-	// Set HTML body
-	m.AddAlternative("text/html", htmlContent)
+	logger.Info("Invitation email sent successfully", "to", to)
+	return nil
+}
 
-	// Retry logic with exponential backoff
-	maxRetries := 3
+func (s *SMTPEmailService) SendEmailVerification(to, token string) error {
+	m := s.newMessage(s.emailVerificationJob(to, token))
+
+	if err := sendWithRetry(func() error { return s.dialAndSend(m) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Email verification sent successfully", "to", to)
+	return nil
+}
+
+func (s *SMTPEmailService) SendWelcomeEmail(to, name string) error {
+	job, ok := s.welcomeJob(to, name)
+	if !ok {
+		return nil
+	}
+	m := s.newMessage(job)
+
+	if err := sendWithRetry(func() error { return s.dialAndSend(m) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Welcome email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SMTPEmailService) SendAdminPasswordReset(to, name string) error {
+	m := s.newMessage(s.adminPasswordResetJob(to, name))
+
+	if err := sendWithRetry(func() error { return s.dialAndSend(m) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Admin password reset email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SMTPEmailService) SendTestEmail(to, subject, htmlContent, textContent string) error {
+	m := s.newMessage(queue.EmailJob{
+		To:          to,
+		Subject:     subject,
+		HTMLContent: htmlContent,
+		TextContent: textContent,
+	})
+
+	if err := sendWithRetry(func() error { return s.dialAndSend(m) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Test email sent successfully", "to", to)
+	return nil
+}
+
+// sendWithRetry calls send up to maxRetries times, waiting one second longer
+// after each failed attempt. An open circuit breaker is not retried.
+func sendWithRetry(send func() error, maxRetries int) error {
 	var lastErr error
 
 	for i := 0; i < maxRetries; i++ {
-		if err := s.dialer.DialAndSend(m); err != nil {
+		if err := send(); err != nil {
+			if errors.Is(err, ErrCircuitOpen) {
+				return err
+			}
 			lastErr = err
 			if i < maxRetries-1 {
-				waitTime := time.Duration(i+1) * time.Second
-				logger.Warn("Failed to send test email, retrying", "attempt", i+1, "max_retries", maxRetries, "error", err, "wait_time", waitTime)
+				waitTime := time.Duration(i+1) * retryDelay
+				logger.Warn("Failed to send email, retrying", "attempt", i+1, "max_retries", maxRetries, "error", err, "wait_time", waitTime)
 				time.Sleep(waitTime)
-				continue
 			}
-		} else {
-			logger.Info("Test email sent successfully", "to", to)
-			return nil
+			continue
 		}
+		return nil
 	}
 
-	return fmt.Errorf("failed to send test email after %d attempts: %w", maxRetries, lastErr)
+	return fmt.Errorf("failed to send email after %d attempts: %w", maxRetries, lastErr)
 }
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"api/internal/helpers"
+	"api/internal/logger"
+	"github.com/sony/gobreaker"
+	"gopkg.in/gomail.v2"
+)
+
+// ErrCircuitOpen is returned without contacting the SMTP server while the
+// circuit breaker is open
+var ErrCircuitOpen = errors.New("email circuit breaker is open")
+
+// emailBreakerFailureThreshold is the number of consecutive SMTP failures
+// that opens the circuit
+const emailBreakerFailureThreshold = 5
+
+// smtpDialer is the part of gomail.Dialer the SMTP service uses
+type smtpDialer interface {
+	DialAndSend(m ...*gomail.Message) error
+}
+
+var (
+	smtpBreaker     *gobreaker.CircuitBreaker
+	smtpBreakerOnce sync.Once
+)
+
+// EmailCircuitStatus is the state of the SMTP circuit breaker and the number
+// of consecutive failures it has counted
+type EmailCircuitStatus struct {
+	State    string
+	Failures uint32
+}
+
+// newEmailBreaker returns a circuit breaker that opens after
+// emailBreakerFailureThreshold consecutive failures. EMAIL_CB_MAX_REQUESTS is
+// the number of trial requests allowed while half-open, EMAIL_CB_INTERVAL how
+// often failure counts are reset while closed, and EMAIL_CB_TIMEOUT how long
+// the circuit stays open before it is tried again.
+func newEmailBreaker() *gobreaker.CircuitBreaker {
+	return gobreaker.NewCircuitBreaker(gobreaker.Settings{
+		Name:        "smtp",
+		MaxRequests: uint32(helpers.GetEnvInt("EMAIL_CB_MAX_REQUESTS", 1)),
+		Interval:    helpers.GetEnvDuration("EMAIL_CB_INTERVAL", time.Minute),
+		Timeout:     helpers.GetEnvDuration("EMAIL_CB_TIMEOUT", 30*time.Second),
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= emailBreakerFailureThreshold
+		},
+		OnStateChange: func(name string, from, to gobreaker.State) {
+			logger.Warn("Email circuit breaker changed state", "from", from.String(), "to", to.String())
+		},
+	})
+}
+
+// getSMTPBreaker returns the circuit breaker shared by every SMTP email
+// service, so its state survives services being recreated
+func getSMTPBreaker() *gobreaker.CircuitBreaker {
+	smtpBreakerOnce.Do(func() {
+		smtpBreaker = newEmailBreaker()
+	})
+	return smtpBreaker
+}
+
+// GetEmailCircuitStatus returns the state of the SMTP circuit breaker
+func GetEmailCircuitStatus() EmailCircuitStatus {
+	breaker := getSMTPBreaker()
+	return EmailCircuitStatus{
+		State:    breaker.State().String(),
+		Failures: breaker.Counts().ConsecutiveFailures,
+	}
+}
+
+// dialAndSend sends m through the circuit breaker, failing fast with
+// ErrCircuitOpen while the SMTP server is considered down
+func (s *SMTPEmailService) dialAndSend(m *gomail.Message) error {
+	_, err := s.breaker.Execute(func() (interface{}, error) {
+		return nil, s.dialer.DialAndSend(m)
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		return ErrCircuitOpen
+	}
+	return err
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"api/internal/queue"
+	"github.com/sony/gobreaker"
+	"gopkg.in/gomail.v2"
+)
+
+// fakeSMTPDialer fails the first failures sends and succeeds afterwards
+type fakeSMTPDialer struct {
+	failures int
+	calls    int
+}
+
+func (f *fakeSMTPDialer) DialAndSend(m ...*gomail.Message) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func newTestSMTPService(failures int, timeout time.Duration) (*SMTPEmailService, *fakeSMTPDialer) {
+	dialer := &fakeSMTPDialer{failures: failures}
+	return &SMTPEmailService{
+		config: SMTPConfig{FromEmail: "noreply@example.com", FromName: "Studio45"},
+		dialer: dialer,
+		breaker: gobreaker.NewCircuitBreaker(gobreaker.Settings{
+			Timeout: timeout,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= emailBreakerFailureThreshold
+			},
+		}),
+	}, dialer
+}
+
+var smtpTestJob = queue.EmailJob{To: "user@example.com", Subject: "Hello", HTMLContent: "<p>Hi</p>", TextContent: "Hi"}
+
+func TestSMTPCircuitOpensAfterThreshold(t *testing.T) {
+	service, dialer := newTestSMTPService(emailBreakerFailureThreshold+10, time.Minute)
+
+	for i := 0; i < emailBreakerFailureThreshold; i++ {
+		err := service.Deliver(smtpTestJob)
+		if err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("Deliver() attempt %d error = %v, want the SMTP error", i+1, err)
+		}
+	}
+	if got := service.breaker.State(); got != gobreaker.StateOpen {
+		t.Fatalf("state = %s, want open", got)
+	}
+
+	if err := service.Deliver(smtpTestJob); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Deliver() error = %v, want ErrCircuitOpen", err)
+	}
+	if dialer.calls != emailBreakerFailureThreshold {
+		t.Errorf("SMTP server called %d times, want %d", dialer.calls, emailBreakerFailureThreshold)
+	}
+}
+
+func TestSMTPCircuitStaysClosedBelowThreshold(t *testing.T) {
+	service, _ := newTestSMTPService(emailBreakerFailureThreshold-1, time.Minute)
+
+	for i := 0; i < emailBreakerFailureThreshold-1; i++ {
+		service.Deliver(smtpTestJob)
+	}
+	if err := service.Deliver(smtpTestJob); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if got := service.breaker.State(); got != gobreaker.StateClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+	if got := service.breaker.Counts().ConsecutiveFailures; got != 0 {
+		t.Errorf("consecutive failures = %d, want 0 after a success", got)
+	}
+}
+
+func TestSMTPCircuitClosesAfterTimeout(t *testing.T) {
+	service, _ := newTestSMTPService(emailBreakerFailureThreshold, 10*time.Millisecond)
+
+	for i := 0; i < emailBreakerFailureThreshold; i++ {
+		service.Deliver(smtpTestJob)
+	}
+	if got := service.breaker.State(); got != gobreaker.StateOpen {
+		t.Fatalf("state = %s, want open", got)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if got := service.breaker.State(); got != gobreaker.StateHalfOpen {
+		t.Fatalf("state = %s, want half-open", got)
+	}
+	if err := service.Deliver(smtpTestJob); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if got := service.breaker.State(); got != gobreaker.StateClosed {
+		t.Errorf("state = %s, want closed", got)
+	}
+}
+
+func TestSMTPSendFailsFastWhileOpen(t *testing.T) {
+	original := retryDelay
+	retryDelay = time.Hour
+	defer func() { retryDelay = original }()
+
+	service, dialer := newTestSMTPService(emailBreakerFailureThreshold+10, time.Minute)
+	for i := 0; i < emailBreakerFailureThreshold; i++ {
+		service.Deliver(smtpTestJob)
+	}
+
+	err := service.SendTestEmail("user@example.com", "Hello", "<p>Hi</p>", "Hi")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("SendTestEmail() error = %v, want ErrCircuitOpen", err)
+	}
+	if dialer.calls != emailBreakerFailureThreshold {
+		t.Errorf("SMTP server called %d times, want %d", dialer.calls, emailBreakerFailureThreshold)
+	}
+}
@@ -0,0 +1,502 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// Email delivery statuses stored in users.email_delivery_status
+const (
+	EmailDeliveryDelivered  = "delivered"
+	EmailDeliveryBounced    = "bounced"
+	EmailDeliveryComplained = "complained"
+)
+
+// Payload formats accepted by the email delivery webhook, selected with
+// EMAIL_DELIVERY_WEBHOOK_FORMAT
+const (
+	EmailWebhookMailgun  = "MAILGUN_WEBHOOK"
+	EmailWebhookSendGrid = "SENDGRID_WEBHOOK"
+	EmailWebhookSESSNS   = "SES_SNS"
+)
+
+// mailgunSignatureMaxAge is how old a Mailgun signature timestamp may be,
+// and how long its token is remembered to reject replays
+const mailgunSignatureMaxAge = 5 * time.Minute
+
+// Headers of SendGrid's signed Event Webhook. The signature is a base64
+// ECDSA signature of the timestamp followed by the body.
+const (
+	SendGridSignatureHeader = "X-Twilio-Email-Event-Webhook-Signature"
+	SendGridTimestampHeader = "X-Twilio-Email-Event-Webhook-Timestamp"
+)
+
+// snsCertificateHost matches the hosts SNS serves its signing certificates from
+var snsCertificateHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+var (
+	// ErrEmailWebhookDisabled is returned when no webhook secret is configured
+	ErrEmailWebhookDisabled = errors.New("email delivery webhook is not configured")
+	// ErrInvalidEmailWebhookSignature is returned when a payload is not signed
+	// with the webhook secret
+	ErrInvalidEmailWebhookSignature = errors.New("invalid email delivery webhook signature")
+	// ErrInvalidEmailWebhookPayload is returned for payloads that cannot be parsed
+	ErrInvalidEmailWebhookPayload = errors.New("invalid email delivery webhook payload")
+)
+
+// DeliveryEvent is a delivery outcome for a single recipient
+type DeliveryEvent struct {
+	Email  string
+	Status string
+}
+
+type EmailDeliveryService struct {
+	db     *gorm.DB
+	format string
+	secret string
+}
+
+// NewEmailDeliveryService returns a service using the payload format from
+// EMAIL_DELIVERY_WEBHOOK_FORMAT and the secret from
+// EMAIL_DELIVERY_WEBHOOK_SECRET: the Mailgun signing key, the SendGrid
+// verification key or the ARN of the SNS topic SES publishes to
+func NewEmailDeliveryService() *EmailDeliveryService {
+	return &EmailDeliveryService{
+		db:     database.DB,
+		format: strings.ToUpper(helpers.GetEnv("EMAIL_DELIVERY_WEBHOOK_FORMAT", EmailWebhookSendGrid)),
+		secret: helpers.GetEnv("EMAIL_DELIVERY_WEBHOOK_SECRET", ""),
+	}
+}
+
+// ParseWebhook verifies a webhook request and returns the delivery events it
+// reports. signature and timestamp are the SendGrid signature headers; the
+// other formats carry their signature in the body. Events the API does not
+// track, such as opens or temporary failures, are left out.
+func (s *EmailDeliveryService) ParseWebhook(body []byte, signature, timestamp string) ([]DeliveryEvent, error) {
+	if s.secret == "" {
+		return nil, ErrEmailWebhookDisabled
+	}
+
+	switch s.format {
+	case EmailWebhookMailgun:
+		return parseMailgunWebhook(body, s.secret, time.Now())
+	case EmailWebhookSendGrid:
+		if !verifySendGridSignature(body, signature, timestamp, s.secret) {
+			return nil, ErrInvalidEmailWebhookSignature
+		}
+		return parseSendGridWebhook(body)
+	case EmailWebhookSESSNS:
+		return parseSESWebhook(body, s.secret)
+	default:
+		return nil, fmt.Errorf("unsupported email delivery webhook format %q", s.format)
+	}
+}
+
+// HandleDeliveryEvent stores the delivery status for the user with the
+// event's email address. Bounces and complaints also deactivate the user so
+// no further email is sent to the address. Addresses without a user are
+// ignored.
+func (s *EmailDeliveryService) HandleDeliveryEvent(event DeliveryEvent) error {
+	var user models.User
+	err := helpers.WhereEmail(s.db, event.Email).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Debug("Ignoring email delivery event for unknown address", "status", event.Status)
+			return nil
+		}
+		return err
+	}
+
+	err = s.db.Model(&user).Update("email_delivery_status", event.Status).Error
+	if err != nil {
+		return err
+	}
+
+	if event.Status == EmailDeliveryDelivered || !user.IsActive {
+		return nil
+	}
+
+	if err := NewRBACServiceWithDB(s.db, s.db).SetUserActive(context.Background(), user.ID, false); err != nil {
+		return err
+	}
+
+	return NewAuditService().LogSystem(AuditActionUserDeactivate, AuditResourceUser, user.ID, map[string]interface{}{
+		"is_active":             AuditChange{From: true, To: false},
+		"email_delivery_status": AuditChange{From: derefStatus(user.EmailDeliveryStatus), To: event.Status},
+	})
+}
+
+func derefStatus(status *string) string {
+	if status == nil {
+		return ""
+	}
+	return *status
+}
+
+// verifySendGridSignature checks the ECDSA signature of timestamp and body
+// against verificationKey, the base64 DER public key SendGrid shows when
+// signed event webhooks are enabled
+func verifySendGridSignature(body []byte, signature, timestamp, verificationKey string) bool {
+	der, err := base64.StdEncoding.DecodeString(verificationKey)
+	if err != nil {
+		return false
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return false
+	}
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return false
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil || timestamp == "" {
+		return false
+	}
+
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	return ecdsa.VerifyASN1(publicKey, digest[:], sig)
+}
+
+type mailgunWebhook struct {
+	Signature struct {
+		Timestamp string `json:"timestamp"`
+		Token     string `json:"token"`
+		Signature string `json:"signature"`
+	} `json:"signature"`
+	EventData struct {
+		Event     string `json:"event"`
+		Severity  string `json:"severity"`
+		Recipient string `json:"recipient"`
+	} `json:"event-data"`
+}
+
+// mailgunTokenCache remembers the tokens of accepted Mailgun signatures until
+// their timestamps are too old to be accepted again
+type mailgunTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]time.Time
+}
+
+var mailgunTokens = &mailgunTokenCache{tokens: make(map[string]time.Time)}
+
+// claim records token as used, signed at signedAt, and reports whether it
+// was unused. Tokens signed before the oldest acceptable timestamp are
+// dropped.
+func (m *mailgunTokenCache) claim(token string, signedAt, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for used, usedSignedAt := range m.tokens {
+		if now.Sub(usedSignedAt) > mailgunSignatureMaxAge {
+			delete(m.tokens, used)
+		}
+	}
+	if _, ok := m.tokens[token]; ok {
+		return false
+	}
+	m.tokens[token] = signedAt
+	return true
+}
+
+// parseMailgunWebhook parses a Mailgun webhook, which is signed with an
+// HMAC-SHA256 of its timestamp and token keyed with the webhook signing key.
+// Signatures older than mailgunSignatureMaxAge, or whose token was already
+// used, are rejected so captured requests cannot be replayed.
+func parseMailgunWebhook(body []byte, signingKey string, now time.Time) ([]DeliveryEvent, error) {
+	var payload mailgunWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidEmailWebhookPayload
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(payload.Signature.Timestamp + payload.Signature.Token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if payload.Signature.Token == "" || !hmac.Equal([]byte(payload.Signature.Signature), []byte(expected)) {
+		return nil, ErrInvalidEmailWebhookSignature
+	}
+
+	unix, err := strconv.ParseInt(payload.Signature.Timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidEmailWebhookSignature
+	}
+	signedAt := time.Unix(unix, 0)
+	if age := now.Sub(signedAt); age > mailgunSignatureMaxAge || age < -mailgunSignatureMaxAge {
+		return nil, ErrInvalidEmailWebhookSignature
+	}
+	if !mailgunTokens.claim(payload.Signature.Token, signedAt, now) {
+		return nil, ErrInvalidEmailWebhookSignature
+	}
+
+	var status string
+	switch payload.EventData.Event {
+	case "delivered":
+		status = EmailDeliveryDelivered
+	case "failed":
+		// Temporary failures are retried by Mailgun
+		if payload.EventData.Severity != "permanent" {
+			return nil, nil
+		}
+		status = EmailDeliveryBounced
+	case "complained":
+		status = EmailDeliveryComplained
+	default:
+		return nil, nil
+	}
+
+	return []DeliveryEvent{{Email: payload.EventData.Recipient, Status: status}}, nil
+}
+
+type sendGridEvent struct {
+	Email string `json:"email"`
+	Event string `json:"event"`
+}
+
+// parseSendGridWebhook parses a batch of SendGrid Event Webhook events
+func parseSendGridWebhook(body []byte) ([]DeliveryEvent, error) {
+	var payload []sendGridEvent
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, ErrInvalidEmailWebhookPayload
+	}
+
+	var events []DeliveryEvent
+	for _, event := range payload {
+		var status string
+		switch event.Event {
+		case "delivered":
+			status = EmailDeliveryDelivered
+		case "bounce":
+			status = EmailDeliveryBounced
+		case "spamreport":
+			status = EmailDeliveryComplained
+		default:
+			continue
+		}
+		events = append(events, DeliveryEvent{Email: event.Email, Status: status})
+	}
+	return events, nil
+}
+
+type snsEnvelope struct {
+	Type             string `json:"Type"`
+	MessageID        string `json:"MessageId"`
+	TopicArn         string `json:"TopicArn"`
+	Subject          string `json:"Subject,omitempty"`
+	Message          string `json:"Message"`
+	Timestamp        string `json:"Timestamp"`
+	Token            string `json:"Token,omitempty"`
+	SubscribeURL     string `json:"SubscribeURL,omitempty"`
+	SignatureVersion string `json:"SignatureVersion"`
+	Signature        string `json:"Signature"`
+	SigningCertURL   string `json:"SigningCertURL"`
+}
+
+// stringToSign returns the canonical form of the message SNS signs
+func (e snsEnvelope) stringToSign() string {
+	fields := [][2]string{{"Message", e.Message}, {"MessageId", e.MessageID}}
+	if e.Type == "Notification" {
+		if e.Subject != "" {
+			fields = append(fields, [2]string{"Subject", e.Subject})
+		}
+		fields = append(fields, [2]string{"Timestamp", e.Timestamp})
+	} else {
+		fields = append(fields, [2]string{"SubscribeURL", e.SubscribeURL}, [2]string{"Timestamp", e.Timestamp}, [2]string{"Token", e.Token})
+	}
+	fields = append(fields, [2]string{"TopicArn", e.TopicArn}, [2]string{"Type", e.Type})
+
+	var b strings.Builder
+	for _, field := range fields {
+		b.WriteString(field[0] + "\n" + field[1] + "\n")
+	}
+	return b.String()
+}
+
+// snsCertificateCache holds the SNS signing certificates fetched so far, by URL
+type snsCertificateCache struct {
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+var (
+	snsCertificates = &snsCertificateCache{certs: make(map[string]*x509.Certificate)}
+	snsClient       = &http.Client{Timeout: 10 * time.Second}
+)
+
+// get returns the certificate at certURL, which must be an SNS certificate
+// served over HTTPS, fetching it on first use
+func (s *snsCertificateCache) get(certURL string) (*x509.Certificate, error) {
+	parsed, err := url.Parse(certURL)
+	if err != nil || parsed.Scheme != "https" || !snsCertificateHost.MatchString(parsed.Host) || !strings.HasSuffix(parsed.Path, ".pem") {
+		return nil, fmt.Errorf("untrusted SNS signing certificate URL %q", certURL)
+	}
+
+	s.mu.Lock()
+	cert, ok := s.certs[certURL]
+	s.mu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	resp, err := snsClient.Get(certURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching SNS signing certificate: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("SNS signing certificate is not PEM encoded")
+	}
+	cert, err = x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.certs[certURL] = cert
+	s.mu.Unlock()
+	return cert, nil
+}
+
+// verifySNSSignature checks that envelope was signed by SNS for topicARN
+func verifySNSSignature(envelope snsEnvelope, topicARN string) error {
+	if envelope.TopicArn != topicARN {
+		return ErrInvalidEmailWebhookSignature
+	}
+
+	var hash crypto.Hash
+	var digest []byte
+	switch envelope.SignatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(envelope.stringToSign()))
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256([]byte(envelope.stringToSign()))
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return ErrInvalidEmailWebhookSignature
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(envelope.Signature)
+	if err != nil {
+		return ErrInvalidEmailWebhookSignature
+	}
+	cert, err := snsCertificates.get(envelope.SigningCertURL)
+	if err != nil {
+		logger.Warn("Rejected SNS signing certificate", "error", err)
+		return ErrInvalidEmailWebhookSignature
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidEmailWebhookSignature
+	}
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, sig); err != nil {
+		return ErrInvalidEmailWebhookSignature
+	}
+	return nil
+}
+
+type sesRecipient struct {
+	EmailAddress string `json:"emailAddress"`
+}
+
+type sesNotification struct {
+	NotificationType string `json:"notificationType"`
+	EventType        string `json:"eventType"`
+	Bounce           struct {
+		BounceType        string         `json:"bounceType"`
+		BouncedRecipients []sesRecipient `json:"bouncedRecipients"`
+	} `json:"bounce"`
+	Complaint struct {
+		ComplainedRecipients []sesRecipient `json:"complainedRecipients"`
+	} `json:"complaint"`
+	Delivery struct {
+		Recipients []string `json:"recipients"`
+	} `json:"delivery"`
+}
+
+// parseSESWebhook parses an Amazon SES notification delivered through an SNS
+// HTTP subscription to topicARN, verifying the SNS message signature
+func parseSESWebhook(body []byte, topicARN string) ([]DeliveryEvent, error) {
+	var envelope snsEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, ErrInvalidEmailWebhookPayload
+	}
+	if err := verifySNSSignature(envelope, topicARN); err != nil {
+		return nil, err
+	}
+
+	switch envelope.Type {
+	case "SubscriptionConfirmation":
+		// Confirming fetches a URL taken from the request, so it is left to
+		// an operator
+		logger.Info("SNS subscription confirmation received, visit SubscribeURL to confirm", "subscribe_url", envelope.SubscribeURL)
+		return nil, nil
+	case "Notification":
+	default:
+		return nil, nil
+	}
+
+	var notification sesNotification
+	if err := json.Unmarshal([]byte(envelope.Message), &notification); err != nil {
+		return nil, ErrInvalidEmailWebhookPayload
+	}
+
+	notificationType := notification.NotificationType
+	if notificationType == "" {
+		notificationType = notification.EventType
+	}
+
+	var events []DeliveryEvent
+	switch notificationType {
+	case "Delivery":
+		for _, recipient := range notification.Delivery.Recipients {
+			events = append(events, DeliveryEvent{Email: recipient, Status: EmailDeliveryDelivered})
+		}
+	case "Bounce":
+		// Transient bounces are retried by SES
+		if notification.Bounce.BounceType != "Permanent" {
+			return nil, nil
+		}
+		for _, recipient := range notification.Bounce.BouncedRecipients {
+			events = append(events, DeliveryEvent{Email: recipient.EmailAddress, Status: EmailDeliveryBounced})
+		}
+	case "Complaint":
+		for _, recipient := range notification.Complaint.ComplainedRecipients {
+			events = append(events, DeliveryEvent{Email: recipient.EmailAddress, Status: EmailDeliveryComplained})
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,318 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testEmailWebhookSecret = "email-webhook-secret"
+
+func mailgunSignature(timestamp, token string) string {
+	mac := hmac.New(sha256.New, []byte(testEmailWebhookSecret))
+	mac.Write([]byte(timestamp + token))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func mailgunPayload(timestamp, token, event, severity, signature string) []byte {
+	return []byte(`{
+		"signature": {
+			"timestamp": "` + timestamp + `",
+			"token": "` + token + `",
+			"signature": "` + signature + `"
+		},
+		"event-data": {
+			"event": "` + event + `",
+			"severity": "` + severity + `",
+			"recipient": "alice@example.com",
+			"log-level": "error",
+			"id": "CPgfbmQMTCKtHW6uIWtuVe"
+		}
+	}`)
+}
+
+const sendGridEventsPayload = `[
+	{"email": "alice@example.com", "timestamp": 1513299569, "event": "delivered", "sg_event_id": "sg_event_id", "sg_message_id": "sg_message_id"},
+	{"email": "bob@example.com", "timestamp": 1513299569, "event": "bounce", "type": "bounce", "reason": "500 unknown recipient", "status": "5.0.0"},
+	{"email": "carol@example.com", "timestamp": 1513299569, "event": "spamreport"},
+	{"email": "dave@example.com", "timestamp": 1513299569, "event": "open", "useragent": "Mozilla/4.0"}
+]`
+
+const (
+	testSNSTopicARN = "arn:aws:sns:us-east-1:123456789012:ses-notifications"
+	testSNSCertURL  = "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-test.pem"
+)
+
+// newSendGridKey returns an ECDSA key and its base64 DER public key, the
+// form of SendGrid's verification key
+func newSendGridKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, base64.StdEncoding.EncodeToString(der)
+}
+
+func signSendGrid(t *testing.T, key *ecdsa.PrivateKey, timestamp string, body []byte) string {
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+// newSNSSigner returns an RSA key whose self-signed certificate is cached as
+// the one served at testSNSCertURL
+func newSNSSigner(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snsCertificates.mu.Lock()
+	snsCertificates.certs[testSNSCertURL] = cert
+	snsCertificates.mu.Unlock()
+	t.Cleanup(func() {
+		snsCertificates.mu.Lock()
+		delete(snsCertificates.certs, testSNSCertURL)
+		snsCertificates.mu.Unlock()
+	})
+	return key
+}
+
+// signSNS signs envelope with key as SNS does with SignatureVersion 2
+func signSNS(t *testing.T, key *rsa.PrivateKey, envelope snsEnvelope) []byte {
+	envelope.SignatureVersion = "2"
+	envelope.SigningCertURL = testSNSCertURL
+	digest := sha256.Sum256([]byte(envelope.stringToSign()))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope.Signature = base64.StdEncoding.EncodeToString(sig)
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func snsNotification(message string) snsEnvelope {
+	var decoded string
+	if err := json.Unmarshal([]byte(message), &decoded); err != nil {
+		panic(err)
+	}
+	return snsEnvelope{
+		Type:      "Notification",
+		MessageID: "22b80b92-fdea-4c2c-8f9d-bdfb0c7bf324",
+		TopicArn:  testSNSTopicARN,
+		Message:   decoded,
+		Timestamp: "2024-01-01T00:00:00.000Z",
+	}
+}
+
+const sesBounceMessage = `"{\"notificationType\":\"Bounce\",\"bounce\":{\"bounceType\":\"Permanent\",\"bounceSubType\":\"General\",\"bouncedRecipients\":[{\"emailAddress\":\"bob@example.com\",\"action\":\"failed\",\"status\":\"5.1.1\"}]},\"mail\":{\"source\":\"noreply@example.com\"}}"`
+
+const sesTransientBounceMessage = `"{\"notificationType\":\"Bounce\",\"bounce\":{\"bounceType\":\"Transient\",\"bouncedRecipients\":[{\"emailAddress\":\"bob@example.com\"}]}}"`
+
+const sesComplaintMessage = `"{\"notificationType\":\"Complaint\",\"complaint\":{\"complainedRecipients\":[{\"emailAddress\":\"carol@example.com\"}],\"complaintFeedbackType\":\"abuse\"}}"`
+
+const sesDeliveryMessage = `"{\"eventType\":\"Delivery\",\"delivery\":{\"recipients\":[\"alice@example.com\"],\"smtpResponse\":\"250 ok\"}}"`
+
+func TestParseMailgunWebhook(t *testing.T) {
+	service := &EmailDeliveryService{format: EmailWebhookMailgun, secret: testEmailWebhookSecret}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	// Tokens are single use, so each run needs its own
+	run := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	tests := []struct {
+		name     string
+		event    string
+		severity string
+		want     []DeliveryEvent
+	}{
+		{"delivered", "delivered", "", []DeliveryEvent{{Email: "alice@example.com", Status: EmailDeliveryDelivered}}},
+		{"permanent failure", "failed", "permanent", []DeliveryEvent{{Email: "alice@example.com", Status: EmailDeliveryBounced}}},
+		{"temporary failure", "failed", "temporary", nil},
+		{"complaint", "complained", "", []DeliveryEvent{{Email: "alice@example.com", Status: EmailDeliveryComplained}}},
+		{"untracked event", "opened", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := run + "-" + tt.name
+			events, err := service.ParseWebhook(mailgunPayload(timestamp, token, tt.event, tt.severity, mailgunSignature(timestamp, token)), "", "")
+			if err != nil {
+				t.Fatalf("ParseWebhook() error = %v", err)
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("events = %+v, want %+v", events, tt.want)
+			}
+		})
+	}
+
+	_, err := service.ParseWebhook(mailgunPayload(timestamp, run+"-forged", "failed", "permanent", "forged"), "", "")
+	if !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("forged signature error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+
+	usedToken := run + "-delivered"
+	replayed := mailgunPayload(timestamp, usedToken, "delivered", "", mailgunSignature(timestamp, usedToken))
+	if _, err := service.ParseWebhook(replayed, "", ""); !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("replayed token error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	_, err = service.ParseWebhook(mailgunPayload(stale, "token-stale", "delivered", "", mailgunSignature(stale, "token-stale")), "", "")
+	if !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("stale timestamp error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+}
+
+func TestParseSendGridWebhook(t *testing.T) {
+	key, verificationKey := newSendGridKey(t)
+	service := &EmailDeliveryService{format: EmailWebhookSendGrid, secret: verificationKey}
+	body := []byte(sendGridEventsPayload)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	events, err := service.ParseWebhook(body, signSendGrid(t, key, timestamp, body), timestamp)
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+
+	want := []DeliveryEvent{
+		{Email: "alice@example.com", Status: EmailDeliveryDelivered},
+		{Email: "bob@example.com", Status: EmailDeliveryBounced},
+		{Email: "carol@example.com", Status: EmailDeliveryComplained},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+
+	otherKey, _ := newSendGridKey(t)
+	_, err = service.ParseWebhook(body, signSendGrid(t, otherKey, timestamp, body), timestamp)
+	if !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("wrong key error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+
+	_, err = service.ParseWebhook(body, signSendGrid(t, key, timestamp, body), "1")
+	if !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("wrong timestamp error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+}
+
+func TestParseSESWebhook(t *testing.T) {
+	key := newSNSSigner(t)
+	service := &EmailDeliveryService{format: EmailWebhookSESSNS, secret: testSNSTopicARN}
+	confirmation := snsEnvelope{
+		Type:         "SubscriptionConfirmation",
+		MessageID:    "165545c9-2a5c-472c-8df2-7ff2be2b3b1b",
+		TopicArn:     testSNSTopicARN,
+		Message:      "You have chosen to subscribe to the topic",
+		Timestamp:    "2024-01-01T00:00:00.000Z",
+		Token:        "2336412f37",
+		SubscribeURL: "https://sns.us-east-1.amazonaws.com/confirm",
+	}
+
+	tests := []struct {
+		name     string
+		envelope snsEnvelope
+		want     []DeliveryEvent
+	}{
+		{"permanent bounce", snsNotification(sesBounceMessage), []DeliveryEvent{{Email: "bob@example.com", Status: EmailDeliveryBounced}}},
+		{"transient bounce", snsNotification(sesTransientBounceMessage), nil},
+		{"complaint", snsNotification(sesComplaintMessage), []DeliveryEvent{{Email: "carol@example.com", Status: EmailDeliveryComplained}}},
+		{"delivery event", snsNotification(sesDeliveryMessage), []DeliveryEvent{{Email: "alice@example.com", Status: EmailDeliveryDelivered}}},
+		{"subscription confirmation", confirmation, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := service.ParseWebhook(signSNS(t, key, tt.envelope), "", "")
+			if err != nil {
+				t.Fatalf("ParseWebhook() error = %v", err)
+			}
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("events = %+v, want %+v", events, tt.want)
+			}
+		})
+	}
+
+	otherTopic := snsNotification(sesBounceMessage)
+	otherTopic.TopicArn = "arn:aws:sns:us-east-1:999999999999:attacker"
+	if _, err := service.ParseWebhook(signSNS(t, key, otherTopic), "", ""); !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("other topic error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+
+	var tampered snsEnvelope
+	if err := json.Unmarshal(signSNS(t, key, snsNotification(sesDeliveryMessage)), &tampered); err != nil {
+		t.Fatal(err)
+	}
+	tampered.Message = `{"notificationType":"Complaint","complaint":{"complainedRecipients":[{"emailAddress":"alice@example.com"}]}}`
+	body, _ := json.Marshal(tampered)
+	if _, err := service.ParseWebhook(body, "", ""); !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("tampered message error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+
+	untrusted := snsNotification(sesDeliveryMessage)
+	untrusted.SigningCertURL = "https://attacker.example.com/cert.pem"
+	untrusted.SignatureVersion = "2"
+	untrusted.Signature = base64.StdEncoding.EncodeToString([]byte("signature"))
+	body, _ = json.Marshal(untrusted)
+	if _, err := service.ParseWebhook(body, "", ""); !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("untrusted certificate error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+}
+
+func TestParseWebhookErrors(t *testing.T) {
+	body := []byte(`not json`)
+
+	disabled := &EmailDeliveryService{format: EmailWebhookSendGrid}
+	if _, err := disabled.ParseWebhook(body, "", ""); !errors.Is(err, ErrEmailWebhookDisabled) {
+		t.Errorf("without secret error = %v, want ErrEmailWebhookDisabled", err)
+	}
+
+	key, verificationKey := newSendGridKey(t)
+	service := &EmailDeliveryService{format: EmailWebhookSendGrid, secret: verificationKey}
+	if _, err := service.ParseWebhook(body, "", ""); !errors.Is(err, ErrInvalidEmailWebhookSignature) {
+		t.Errorf("unsigned error = %v, want ErrInvalidEmailWebhookSignature", err)
+	}
+	if _, err := service.ParseWebhook(body, signSendGrid(t, key, "1700000000", body), "1700000000"); !errors.Is(err, ErrInvalidEmailWebhookPayload) {
+		t.Errorf("malformed error = %v, want ErrInvalidEmailWebhookPayload", err)
+	}
+}
@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/queue"
+	"github.com/sendgrid/rest"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+const sendGridMailEndpoint = "/v3/mail/send"
+
+type SendGridConfig struct {
+	APIKey    string
+	FromEmail string
+	FromName  string
+	// UseTemplates sends password resets with a SendGrid dynamic template
+	// instead of rendering the local email template
+	UseTemplates bool
+	// PasswordResetTemplate is the email template name, used as the SendGrid
+	// dynamic template ID when UseTemplates is set
+	PasswordResetTemplate string
+	// Host overrides the SendGrid API host
+	Host string
+}
+
+type SendGridEmailService struct {
+	config SendGridConfig
+	client *rest.Client
+}
+
+func loadSendGridConfig() (SendGridConfig, error) {
+	apiKey := helpers.GetEnv("SENDGRID_API_KEY", "")
+	fromEmail := helpers.GetEnv("SENDGRID_FROM_EMAIL", "")
+	fromName := helpers.GetEnv("SENDGRID_FROM_NAME", "")
+	useTemplatesStr := helpers.GetEnv("USE_SENDGRID_TEMPLATES", "")
+	passwordResetTemplate := helpers.GetEnv("SENDGRID_PASSWORD_RESET_TEMPLATE", "")
+
+	if apiKey == "" || fromEmail == "" {
+		return SendGridConfig{}, fmt.Errorf("missing required SendGrid configuration")
+	}
+
+	useTemplates := false
+	if useTemplatesStr != "" {
+		var err error
+		useTemplates, err = strconv.ParseBool(useTemplatesStr)
+		if err != nil {
+			return SendGridConfig{}, fmt.Errorf("invalid USE_SENDGRID_TEMPLATES: %w", err)
+		}
+	}
+
+	if fromName == "" {
+		fromName = "Studio45"
+	}
+
+	if passwordResetTemplate == "" {
+		passwordResetTemplate = "password_reset"
+	}
+
+	return SendGridConfig{
+		APIKey:                apiKey,
+		FromEmail:             fromEmail,
+		FromName:              fromName,
+		UseTemplates:          useTemplates,
+		PasswordResetTemplate: passwordResetTemplate,
+	}, nil
+}
+
+// NewSendGridEmailService creates a SendGrid service. A nil httpClient uses
+// the default HTTP client.
+func NewSendGridEmailService(config SendGridConfig, httpClient *http.Client) *SendGridEmailService {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &SendGridEmailService{
+		config: config,
+		client: &rest.Client{HTTPClient: httpClient},
+	}
+}
+
+func (s *SendGridEmailService) passwordResetJob(ctx context.Context, to, token string) queue.EmailJob {
+	if !s.config.UseTemplates {
+		return buildPasswordResetJob(ctx, to, token, s.config.FromName)
+	}
+
+	return queue.EmailJob{
+		To:         to,
+		TemplateID: s.config.PasswordResetTemplate,
+		TemplateData: map[string]string{
+			"ResetURL":    fmt.Sprintf("%s/reset-password?token=%s", getBaseURL(), token),
+			"CompanyName": s.config.FromName,
+		},
+	}
+}
+
+func (s *SendGridEmailService) invitationJob(to, token string) queue.EmailJob {
+	return buildInvitationJob(to, token, s.config.FromName)
+}
+
+func (s *SendGridEmailService) emailVerificationJob(to, token string) queue.EmailJob {
+	return buildEmailVerificationJob(to, token, s.config.FromName)
+}
+
+func (s *SendGridEmailService) welcomeJob(to, name string) (queue.EmailJob, bool) {
+	return buildWelcomeJob(to, name, s.config.FromName)
+}
+
+func (s *SendGridEmailService) adminPasswordResetJob(to, name string) queue.EmailJob {
+	return buildAdminPasswordResetJob(to, name, s.config.FromName)
+}
+
+func (s *SendGridEmailService) newMessage(job queue.EmailJob) *mail.SGMailV3 {
+	m := mail.NewV3Mail()
+	m.SetFrom(mail.NewEmail(s.config.FromName, s.config.FromEmail))
+
+	p := mail.NewPersonalization()
+	p.AddTos(mail.NewEmail("", job.To))
+
+	if job.TemplateID != "" {
+		m.SetTemplateID(job.TemplateID)
+		for key, value := range job.TemplateData {
+			p.SetDynamicTemplateData(key, value)
+		}
+	} else {
+		m.Subject = job.Subject
+		// SendGrid requires the plain text part to come first
+		m.AddContent(
+			mail.NewContent("text/plain", job.TextContent),
+			mail.NewContent("text/html", job.HTMLContent),
+		)
+	}
+
+	m.AddPersonalizations(p)
+	return m
+}
+
+// Deliver makes a single delivery attempt; the email queue handles retries
+func (s *SendGridEmailService) Deliver(job queue.EmailJob) error {
+	request := sendgrid.GetRequest(s.config.APIKey, sendGridMailEndpoint, s.config.Host)
+	request.Method = rest.Post
+	request.Body = mail.GetRequestBody(s.newMessage(job))
+
+	response, err := s.client.Send(request)
+	if err != nil {
+		return fmt.Errorf("failed to reach SendGrid: %w", err)
+	}
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("SendGrid returned status %d: %s", response.StatusCode, response.Body)
+	}
+
+	return nil
+}
+
+func (s *SendGridEmailService) SendPasswordReset(ctx context.Context, to, token string) error {
+	ctx, span := startEmailSpan(ctx, "email.SendPasswordReset")
+	defer span.End()
+
+	job := s.passwordResetJob(ctx, to, token)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Password reset email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SendGridEmailService) SendInvitation(to, token string) error {
+	job := s.invitationJob(to, token)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Invitation email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SendGridEmailService) SendEmailVerification(to, token string) error {
+	job := s.emailVerificationJob(to, token)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Email verification sent successfully", "to", to)
+	return nil
+}
+
+func (s *SendGridEmailService) SendWelcomeEmail(to, name string) error {
+	job, ok := s.welcomeJob(to, name)
+	if !ok {
+		return nil
+	}
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Welcome email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SendGridEmailService) SendAdminPasswordReset(to, name string) error {
+	job := s.adminPasswordResetJob(to, name)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Admin password reset email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SendGridEmailService) SendTestEmail(to, subject, htmlContent, textContent string) error {
+	job := queue.EmailJob{
+		To:          to,
+		Subject:     subject,
+		HTMLContent: htmlContent,
+		TextContent: textContent,
+	}
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Test email sent successfully", "to", to)
+	return nil
+}
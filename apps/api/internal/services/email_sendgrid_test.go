@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"api/internal/queue"
+)
+
+// sendGridPayload is the subset of the v3 mail/send body checked by the tests
+type sendGridPayload struct {
+	From struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	} `json:"from"`
+	Subject          string `json:"subject"`
+	TemplateID       string `json:"template_id"`
+	Personalizations []struct {
+		To []struct {
+			Email string `json:"email"`
+		} `json:"to"`
+		DynamicTemplateData map[string]string `json:"dynamic_template_data"`
+	} `json:"personalizations"`
+	Content []struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	} `json:"content"`
+}
+
+func newTestSendGridService(t *testing.T, status int, config SendGridConfig) (*SendGridEmailService, *[]sendGridPayload) {
+	t.Helper()
+
+	var requests []sendGridPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != sendGridMailEndpoint {
+			t.Errorf("request path = %s, want %s", r.URL.Path, sendGridMailEndpoint)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization header = %q", got)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload sendGridPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		requests = append(requests, payload)
+
+		w.WriteHeader(status)
+		if status >= 300 {
+			w.Write([]byte(`{"errors":[{"message":"bad request"}]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	config.APIKey = "test-key"
+	config.FromEmail = "noreply@studio45.test"
+	config.FromName = "Studio45"
+	config.Host = server.URL
+
+	return NewSendGridEmailService(config, server.Client()), &requests
+}
+
+func TestSendGridSendTestEmail(t *testing.T) {
+	service, requests := newTestSendGridService(t, http.StatusAccepted, SendGridConfig{})
+
+	err := service.SendTestEmail("user@example.com", "Hello", "<p>Hi</p>", "Hi")
+	if err != nil {
+		t.Fatalf("SendTestEmail() error = %v", err)
+	}
+
+	if len(*requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*requests))
+	}
+	payload := (*requests)[0]
+
+	if payload.From.Email != "noreply@studio45.test" || payload.Subject != "Hello" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if payload.Personalizations[0].To[0].Email != "user@example.com" {
+		t.Errorf("recipient = %s", payload.Personalizations[0].To[0].Email)
+	}
+	if len(payload.Content) != 2 || payload.Content[0].Type != "text/plain" || payload.Content[1].Value != "<p>Hi</p>" {
+		t.Errorf("unexpected content %+v", payload.Content)
+	}
+}
+
+func TestSendGridPasswordResetWithTemplates(t *testing.T) {
+	t.Setenv("FRONTEND_URL", "https://app.studio45.test")
+	service, requests := newTestSendGridService(t, http.StatusAccepted, SendGridConfig{
+		UseTemplates:          true,
+		PasswordResetTemplate: "d-1234567890",
+	})
+
+	if err := service.SendPasswordReset(context.Background(), "user@example.com", "reset-token"); err != nil {
+		t.Fatalf("SendPasswordReset() error = %v", err)
+	}
+
+	payload := (*requests)[0]
+	if payload.TemplateID != "d-1234567890" {
+		t.Errorf("template_id = %q, want d-1234567890", payload.TemplateID)
+	}
+	if len(payload.Content) != 0 {
+		t.Errorf("dynamic template request should not include content, got %+v", payload.Content)
+	}
+
+	data := payload.Personalizations[0].DynamicTemplateData
+	if data["ResetURL"] != "https://app.studio45.test/reset-password?token=reset-token" {
+		t.Errorf("ResetURL = %q", data["ResetURL"])
+	}
+	if data["CompanyName"] != "Studio45" {
+		t.Errorf("CompanyName = %q", data["CompanyName"])
+	}
+}
+
+func TestSendGridDeliverError(t *testing.T) {
+	service, _ := newTestSendGridService(t, http.StatusBadRequest, SendGridConfig{})
+
+	err := service.Deliver(queue.EmailJob{To: "user@example.com", Subject: "Hello", TextContent: "Hi", HTMLContent: "<p>Hi</p>"})
+	if err == nil {
+		t.Fatal("Deliver() expected error for 400 response")
+	}
+	if !strings.Contains(err.Error(), "400") {
+		t.Errorf("Deliver() error = %v, want status code in message", err)
+	}
+}
+
+func TestLoadSendGridConfig(t *testing.T) {
+	t.Setenv("SENDGRID_API_KEY", "")
+	t.Setenv("SENDGRID_FROM_EMAIL", "")
+	if _, err := loadSendGridConfig(); err == nil {
+		t.Error("loadSendGridConfig() expected error without API key")
+	}
+
+	t.Setenv("SENDGRID_API_KEY", "key")
+	t.Setenv("SENDGRID_FROM_EMAIL", "noreply@studio45.test")
+	t.Setenv("USE_SENDGRID_TEMPLATES", "true")
+	config, err := loadSendGridConfig()
+	if err != nil {
+		t.Fatalf("loadSendGridConfig() error = %v", err)
+	}
+	if !config.UseTemplates || config.PasswordResetTemplate != "password_reset" || config.FromName != "Studio45" {
+		t.Errorf("loadSendGridConfig() = %+v", config)
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"api/internal/helpers"
+	"api/internal/logger"
+	"api/internal/queue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ses"
+	"github.com/aws/aws-sdk-go-v2/service/ses/types"
+	"github.com/aws/smithy-go"
+)
+
+type SESConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	FromEmail       string
+	FromName        string
+}
+
+// sesAPI is the part of the SES client used to send email
+type sesAPI interface {
+	SendEmail(ctx context.Context, params *ses.SendEmailInput, optFns ...func(*ses.Options)) (*ses.SendEmailOutput, error)
+}
+
+type SESEmailService struct {
+	config SESConfig
+	client sesAPI
+}
+
+func loadSESConfig() (SESConfig, error) {
+	region := helpers.GetEnv("AWS_REGION", "")
+	fromEmail := helpers.GetEnv("SES_FROM_EMAIL", "")
+	fromName := helpers.GetEnv("SES_FROM_NAME", "")
+
+	if region == "" || fromEmail == "" {
+		return SESConfig{}, fmt.Errorf("missing required SES configuration")
+	}
+
+	if fromName == "" {
+		fromName = "Studio45"
+	}
+
+	return SESConfig{
+		Region:          region,
+		AccessKeyID:     helpers.GetEnv("AWS_ACCESS_KEY_ID", ""),
+		SecretAccessKey: helpers.GetEnv("AWS_SECRET_ACCESS_KEY", ""),
+		FromEmail:       fromEmail,
+		FromName:        fromName,
+	}, nil
+}
+
+// NewSESEmailService creates an SES service. Static credentials are used when
+// provided; otherwise the default AWS credential chain applies.
+func NewSESEmailService(config SESConfig) (*SESEmailService, error) {
+	options := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithRegion(config.Region),
+	}
+
+	if config.AccessKeyID != "" && config.SecretAccessKey != "" {
+		options = append(options, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(config.AccessKeyID, config.SecretAccessKey, ""),
+		))
+	}
+
+	awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	return &SESEmailService{
+		config: config,
+		client: ses.NewFromConfig(awsConfig),
+	}, nil
+}
+
+func (s *SESEmailService) passwordResetJob(ctx context.Context, to, token string) queue.EmailJob {
+	return buildPasswordResetJob(ctx, to, token, s.config.FromName)
+}
+
+func (s *SESEmailService) invitationJob(to, token string) queue.EmailJob {
+	return buildInvitationJob(to, token, s.config.FromName)
+}
+
+func (s *SESEmailService) emailVerificationJob(to, token string) queue.EmailJob {
+	return buildEmailVerificationJob(to, token, s.config.FromName)
+}
+
+func (s *SESEmailService) welcomeJob(to, name string) (queue.EmailJob, bool) {
+	return buildWelcomeJob(to, name, s.config.FromName)
+}
+
+func (s *SESEmailService) adminPasswordResetJob(to, name string) queue.EmailJob {
+	return buildAdminPasswordResetJob(to, name, s.config.FromName)
+}
+
+// Deliver makes a single delivery attempt; the email queue handles retries
+func (s *SESEmailService) Deliver(job queue.EmailJob) error {
+	input := &ses.SendEmailInput{
+		Source: aws.String(fmt.Sprintf("%s <%s>", s.config.FromName, s.config.FromEmail)),
+		Destination: &types.Destination{
+			ToAddresses: []string{job.To},
+		},
+		Message: &types.Message{
+			Subject: &types.Content{Data: aws.String(job.Subject), Charset: aws.String("UTF-8")},
+			Body: &types.Body{
+				Html: &types.Content{Data: aws.String(job.HTMLContent), Charset: aws.String("UTF-8")},
+				Text: &types.Content{Data: aws.String(job.TextContent), Charset: aws.String("UTF-8")},
+			},
+		},
+	}
+
+	if _, err := s.client.SendEmail(context.Background(), input); err != nil {
+		return wrapSESError(err)
+	}
+	return nil
+}
+
+func (s *SESEmailService) SendPasswordReset(ctx context.Context, to, token string) error {
+	ctx, span := startEmailSpan(ctx, "email.SendPasswordReset")
+	defer span.End()
+
+	job := s.passwordResetJob(ctx, to, token)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Password reset email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SESEmailService) SendInvitation(to, token string) error {
+	job := s.invitationJob(to, token)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Invitation email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SESEmailService) SendEmailVerification(to, token string) error {
+	job := s.emailVerificationJob(to, token)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Email verification sent successfully", "to", to)
+	return nil
+}
+
+func (s *SESEmailService) SendWelcomeEmail(to, name string) error {
+	job, ok := s.welcomeJob(to, name)
+	if !ok {
+		return nil
+	}
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Welcome email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SESEmailService) SendAdminPasswordReset(to, name string) error {
+	job := s.adminPasswordResetJob(to, name)
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Admin password reset email sent successfully", "to", to)
+	return nil
+}
+
+func (s *SESEmailService) SendTestEmail(to, subject, htmlContent, textContent string) error {
+	job := queue.EmailJob{
+		To:          to,
+		Subject:     subject,
+		HTMLContent: htmlContent,
+		TextContent: textContent,
+	}
+
+	if err := sendWithRetry(func() error { return s.Deliver(job) }, defaultMaxRetries); err != nil {
+		return err
+	}
+
+	logger.Info("Test email sent successfully", "to", to)
+	return nil
+}
+
+// wrapSESError adds an explanation to SES errors that usually need operator action
+func wrapSESError(err error) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("failed to send email via SES: %w", err)
+	}
+
+	switch apiErr.ErrorCode() {
+	case "Throttling", "ThrottlingException":
+		return fmt.Errorf("SES sending rate exceeded, try again later: %w", err)
+	case "MessageRejected":
+		if strings.Contains(strings.ToLower(apiErr.ErrorMessage()), "not verified") {
+			return fmt.Errorf("SES rejected the message because an address is not verified; accounts in the SES sandbox can only send to verified addresses: %w", err)
+		}
+		return fmt.Errorf("SES rejected the message: %w", err)
+	case "MailFromDomainNotVerifiedException":
+		return fmt.Errorf("SES sender domain is not verified: %w", err)
+	case "AccountSendingPausedException":
+		return fmt.Errorf("SES sending is paused for this account: %w", err)
+	default:
+		return fmt.Errorf("failed to send email via SES: %w", err)
+	}
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"api/internal/queue"
+	"github.com/aws/aws-sdk-go-v2/service/ses"
+	"github.com/aws/smithy-go"
+)
+
+type fakeSESClient struct {
+	inputs []*ses.SendEmailInput
+	err    error
+}
+
+func (f *fakeSESClient) SendEmail(ctx context.Context, params *ses.SendEmailInput, optFns ...func(*ses.Options)) (*ses.SendEmailOutput, error) {
+	f.inputs = append(f.inputs, params)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &ses.SendEmailOutput{}, nil
+}
+
+func newTestSESService(err error) (*SESEmailService, *fakeSESClient) {
+	client := &fakeSESClient{err: err}
+	return &SESEmailService{
+		config: SESConfig{
+			Region:    "us-east-1",
+			FromEmail: "noreply@example.com",
+			FromName:  "Studio45",
+		},
+		client: client,
+	}, client
+}
+
+func TestSESDeliverBuildsMessage(t *testing.T) {
+	service, client := newTestSESService(nil)
+
+	err := service.Deliver(queue.EmailJob{
+		To:          "user@example.com",
+		Subject:     "Hello",
+		HTMLContent: "<p>Hi</p>",
+		TextContent: "Hi",
+	})
+	if err != nil {
+		t.Fatalf("Deliver returned error: %v", err)
+	}
+
+	if len(client.inputs) != 1 {
+		t.Fatalf("SendEmail called %d times, want 1", len(client.inputs))
+	}
+
+	input := client.inputs[0]
+	if got := *input.Source; got != "Studio45 <noreply@example.com>" {
+		t.Errorf("Source = %q", got)
+	}
+	if got := input.Destination.ToAddresses; len(got) != 1 || got[0] != "user@example.com" {
+		t.Errorf("ToAddresses = %v", got)
+	}
+	if got := *input.Message.Subject.Data; got != "Hello" {
+		t.Errorf("Subject = %q", got)
+	}
+	if got := *input.Message.Body.Html.Data; got != "<p>Hi</p>" {
+		t.Errorf("Html body = %q", got)
+	}
+	if got := *input.Message.Body.Text.Data; got != "Hi" {
+		t.Errorf("Text body = %q", got)
+	}
+}
+
+func TestWrapSESError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "throttling",
+			err:  &smithy.GenericAPIError{Code: "Throttling", Message: "Maximum sending rate exceeded."},
+			want: "sending rate exceeded",
+		},
+		{
+			name: "sandbox",
+			err:  &smithy.GenericAPIError{Code: "MessageRejected", Message: "Email address is not verified."},
+			want: "SES sandbox",
+		},
+		{
+			name: "other rejection",
+			err:  &smithy.GenericAPIError{Code: "MessageRejected", Message: "Illegal address"},
+			want: "SES rejected the message",
+		},
+		{
+			name: "non API error",
+			err:  errors.New("connection reset"),
+			want: "failed to send email via SES",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapped := wrapSESError(tt.err)
+			if !strings.Contains(wrapped.Error(), tt.want) {
+				t.Errorf("wrapSESError() = %q, want it to contain %q", wrapped.Error(), tt.want)
+			}
+			if !errors.Is(wrapped, tt.err) {
+				t.Error("wrapped error does not wrap the original")
+			}
+		})
+	}
+}
+
+func TestSESSendTestEmailRetries(t *testing.T) {
+	original := retryDelay
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = original }()
+
+	service, client := newTestSESService(&smithy.GenericAPIError{Code: "Throttling"})
+
+	err := service.SendTestEmail("user@example.com", "Hello", "<p>Hi</p>", "Hi")
+	if err == nil {
+		t.Fatal("SendTestEmail succeeded, want error")
+	}
+	if len(client.inputs) != defaultMaxRetries {
+		t.Errorf("SendEmail called %d times, want %d", len(client.inputs), defaultMaxRetries)
+	}
+}
+
+func TestSendWithRetryStopsOnSuccess(t *testing.T) {
+	original := retryDelay
+	retryDelay = time.Millisecond
+	defer func() { retryDelay = original }()
+
+	attempts := 0
+	err := sendWithRetry(func() error {
+		attempts++
+		if attempts < 2 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	}, 3)
+	if err != nil {
+		t.Fatalf("sendWithRetry returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
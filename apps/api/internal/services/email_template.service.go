@@ -2,16 +2,28 @@ package services
 
 import (
 	"api/internal/database"
+	"api/internal/logger"
 	"api/internal/models"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	htmltemplate "html/template"
 	"strings"
 	texttemplate "text/template"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// DefaultTemplateLanguage is used when a template has no entry for the
+// requested language
+const DefaultTemplateLanguage = "en"
+
+// PermissionManageProtectedTemplates is needed, on top of admin access, to
+// edit or delete a protected template and to change whether one is protected
+const PermissionManageProtectedTemplates = "template.manage.protected"
+
 type EmailTemplateService struct {
 	db *gorm.DB
 }
@@ -22,47 +34,168 @@ func NewEmailTemplateService() *EmailTemplateService {
 	}
 }
 
-func (s *EmailTemplateService) GetAllTemplates() ([]models.EmailTemplate, error) {
+func (s *EmailTemplateService) GetAllTemplates(ctx context.Context) ([]models.EmailTemplate, error) {
 	var templates []models.EmailTemplate
-	err := s.db.Where("deleted_at IS NULL").Order("name ASC").Find(&templates).Error
+	err := s.db.WithContext(ctx).Where("deleted_at IS NULL").Order("name ASC").Find(&templates).Error
 	return templates, err
 }
 
-func (s *EmailTemplateService) GetTemplateByID(id string) (*models.EmailTemplate, error) {
+func (s *EmailTemplateService) GetTemplateByID(ctx context.Context, id string) (*models.EmailTemplate, error) {
 	var template models.EmailTemplate
-	err := s.db.Where("id = ? AND deleted_at IS NULL", id).First(&template).Error
+	err := s.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&template).Error
 	if err != nil {
 		return nil, err
 	}
 	return &template, nil
 }
 
-func (s *EmailTemplateService) GetTemplateByName(name string) (*models.EmailTemplate, error) {
+// GetTemplateByName returns the active template with the given name in the
+// default language
+func (s *EmailTemplateService) GetTemplateByName(ctx context.Context, name string) (*models.EmailTemplate, error) {
+	return s.GetTemplateByNameAndLanguage(ctx, name, DefaultTemplateLanguage)
+}
+
+func (s *EmailTemplateService) GetTemplateByNameAndLanguage(ctx context.Context, name, language string) (*models.EmailTemplate, error) {
 	var template models.EmailTemplate
-	err := s.db.Where("name = ? AND deleted_at IS NULL AND is_active = true", name).First(&template).Error
+	err := s.db.WithContext(ctx).Where("name = ? AND language = ? AND deleted_at IS NULL AND is_active = true", name, language).First(&template).Error
 	if err != nil {
 		return nil, err
 	}
 	return &template, nil
 }
 
-func (s *EmailTemplateService) CreateTemplate(template *models.EmailTemplate) error {
-	return s.db.Create(template).Error
+// GetLocalizedTemplate returns the active template for language, falling back
+// to the default language when the template has no entry for it
+func (s *EmailTemplateService) GetLocalizedTemplate(ctx context.Context, name, language string) (*models.EmailTemplate, error) {
+	if language != "" && language != DefaultTemplateLanguage {
+		template, err := s.GetTemplateByNameAndLanguage(ctx, name, language)
+		if err == nil {
+			return template, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	return s.GetTemplateByNameAndLanguage(ctx, name, DefaultTemplateLanguage)
+}
+
+func (s *EmailTemplateService) CreateTemplate(ctx context.Context, template *models.EmailTemplate) error {
+	return s.db.WithContext(ctx).Create(template).Error
+}
+
+// CloneTemplate copies a template's content into a new, inactive template
+// named newName in the same language. Deleted templates cannot be cloned.
+func (s *EmailTemplateService) CloneTemplate(ctx context.Context, sourceID, newName string) (*models.EmailTemplate, error) {
+	source, err := s.GetTemplateByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := models.EmailTemplate{
+		Name:         newName,
+		Language:     source.Language,
+		Subject:      source.Subject,
+		HTMLTemplate: source.HTMLTemplate,
+		TextTemplate: source.TextTemplate,
+		Variables:    source.Variables,
+		IsActive:     false,
+		Version:      1,
+	}
+
+	// Select all columns so the false is_active is written instead of being
+	// replaced by the column default.
+	if err := s.db.WithContext(ctx).Select("*").Create(&clone).Error; err != nil {
+		return nil, err
+	}
+
+	return &clone, nil
+}
+
+// UpdateTemplate applies updates to a template. The current content is saved
+// as a version first so it can be restored later.
+func (s *EmailTemplateService) UpdateTemplate(ctx context.Context, id string, updates map[string]interface{}, updatedBy *string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var template models.EmailTemplate
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NULL", id).
+			First(&template).Error
+		if err != nil {
+			return err
+		}
+
+		version := models.EmailTemplateVersion{
+			TemplateID:    template.ID,
+			VersionNumber: template.Version,
+			Subject:       template.Subject,
+			HTMLTemplate:  template.HTMLTemplate,
+			TextTemplate:  template.TextTemplate,
+			Variables:     template.Variables,
+			CreatedBy:     updatedBy,
+		}
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+
+		// Copy so the caller's map is left untouched
+		versionedUpdates := make(map[string]interface{}, len(updates)+1)
+		for key, value := range updates {
+			versionedUpdates[key] = value
+		}
+		versionedUpdates["version"] = gorm.Expr("version + 1")
+
+		return tx.Model(&models.EmailTemplate{}).Where("id = ?", id).Updates(versionedUpdates).Error
+	})
 }
 
-func (s *EmailTemplateService) UpdateTemplate(id string, updates map[string]interface{}) error {
-	result := s.db.Model(&models.EmailTemplate{}).Where("id = ? AND deleted_at IS NULL", id).Updates(updates)
+// GetTemplateVersions returns the saved versions of a template, newest first
+func (s *EmailTemplateService) GetTemplateVersions(ctx context.Context, templateID string) ([]models.EmailTemplateVersion, error) {
+	var versions []models.EmailTemplateVersion
+	err := s.db.WithContext(ctx).Where("template_id = ?", templateID).Order("version_number DESC").Find(&versions).Error
+	return versions, err
+}
+
+// RestoreTemplateVersion reverts a template's content to a saved version. The
+// restore is itself an update, so the content it replaces is versioned too.
+func (s *EmailTemplateService) RestoreTemplateVersion(ctx context.Context, templateID string, versionNumber int, restoredBy *string) (*models.EmailTemplateVersion, error) {
+	var version models.EmailTemplateVersion
+	err := s.db.WithContext(ctx).Where("template_id = ? AND version_number = ?", templateID, versionNumber).First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.UpdateTemplate(ctx, templateID, map[string]interface{}{
+		"subject":       version.Subject,
+		"html_template": version.HTMLTemplate,
+		"text_template": version.TextTemplate,
+		"variables":     version.Variables,
+	}, restoredBy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// ToggleTemplate flips whether a template is active and returns it as updated.
+// Only is_active changes, so no version is saved.
+func (s *EmailTemplateService) ToggleTemplate(ctx context.Context, id string) (*models.EmailTemplate, error) {
+	var template models.EmailTemplate
+	result := s.db.WithContext(ctx).Model(&template).
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Update("is_active", gorm.Expr("NOT is_active"))
 	if result.Error != nil {
-		return result.Error
+		return nil, result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		return nil, gorm.ErrRecordNotFound
 	}
-	return nil
+	return &template, nil
 }
 
-func (s *EmailTemplateService) DeleteTemplate(id string) error {
-	result := s.db.Where("id = ?", id).Delete(&models.EmailTemplate{})
+func (s *EmailTemplateService) DeleteTemplate(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Where("id = ?", id).Delete(&models.EmailTemplate{})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -72,8 +205,10 @@ func (s *EmailTemplateService) DeleteTemplate(id string) error {
 	return nil
 }
 
-func (s *EmailTemplateService) RenderTemplate(templateName string, variables map[string]string) (*RenderedTemplate, error) {
-	emailTemplate, err := s.GetTemplateByName(templateName)
+// RenderTemplate renders the named template in language, falling back to
+// DefaultTemplateLanguage if there is no entry for it
+func (s *EmailTemplateService) RenderTemplate(ctx context.Context, templateName, language string, variables map[string]string) (*RenderedTemplate, error) {
+	emailTemplate, err := s.GetLocalizedTemplate(ctx, templateName, language)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template: %w", err)
 	}
@@ -153,8 +288,8 @@ func (s *EmailTemplateService) ValidateTemplate(htmlTemplate, textTemplate strin
 	return nil
 }
 
-func (s *EmailTemplateService) GetTemplateVariables(templateName string) ([]models.TemplateVariable, error) {
-	template, err := s.GetTemplateByName(templateName)
+func (s *EmailTemplateService) GetTemplateVariables(ctx context.Context, templateName string) ([]models.TemplateVariable, error) {
+	template, err := s.GetTemplateByName(ctx, templateName)
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +323,57 @@ func (s *EmailTemplateService) ExtractVariablesFromTemplate(templateStr string)
 	return variables
 }
 
+// UndeclaredVariablesError lists placeholders used in a template that are
+// missing from its declared variables
+type UndeclaredVariablesError struct {
+	Variables []string
+}
+
+func (e *UndeclaredVariablesError) Error() string {
+	return "template uses undeclared variables: " + strings.Join(e.Variables, ", ")
+}
+
+// ValidateVariableDeclarations checks the declared variables against the
+// placeholders in the HTML and text templates. Placeholders that are not
+// declared return an *UndeclaredVariablesError; declared variables that are
+// never used are only logged, since they may appear in the subject.
+func (s *EmailTemplateService) ValidateVariableDeclarations(htmlTemplate, textTemplate string, declared []models.TemplateVariable) error {
+	declaredNames := make(map[string]bool, len(declared))
+	for _, variable := range declared {
+		declaredNames[variable.Name] = true
+	}
+
+	used := make(map[string]bool)
+	var undeclared []string
+	for _, templateStr := range []string{htmlTemplate, textTemplate} {
+		for _, name := range s.ExtractVariablesFromTemplate(templateStr) {
+			if used[name] {
+				continue
+			}
+			used[name] = true
+			if !declaredNames[name] {
+				undeclared = append(undeclared, name)
+			}
+		}
+	}
+
+	var unused []string
+	for _, variable := range declared {
+		if !used[variable.Name] {
+			unused = append(unused, variable.Name)
+		}
+	}
+	if len(unused) > 0 {
+		logger.Warn("Email template declares unused variables", "variables", unused)
+	}
+
+	if len(undeclared) > 0 {
+		return &UndeclaredVariablesError{Variables: undeclared}
+	}
+
+	return nil
+}
+
 type RenderedTemplate struct {
 	Subject     string
 	HTMLContent string
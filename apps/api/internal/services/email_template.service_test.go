@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"api/internal/models"
+)
+
+func TestValidateVariableDeclarations(t *testing.T) {
+	service := &EmailTemplateService{}
+	html := `<a href="{{.ResetURL}}">Reset</a> {{.CompanyName}}`
+	text := `{{.CompanyName}}: {{.ResetURL}} (expires {{.ExpiresAt}})`
+
+	tests := []struct {
+		name     string
+		declared []models.TemplateVariable
+		want     []string
+	}{
+		{
+			name: "all declared",
+			declared: []models.TemplateVariable{
+				{Name: "ResetURL"}, {Name: "CompanyName"}, {Name: "ExpiresAt"},
+			},
+		},
+		{
+			name: "unused declarations are allowed",
+			declared: []models.TemplateVariable{
+				{Name: "ResetURL"}, {Name: "CompanyName"}, {Name: "ExpiresAt"}, {Name: "UserName"},
+			},
+		},
+		{
+			name:     "undeclared variables",
+			declared: []models.TemplateVariable{{Name: "CompanyName"}},
+			want:     []string{"ResetURL", "ExpiresAt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ValidateVariableDeclarations(html, text, tt.declared)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateVariableDeclarations() error = %v", err)
+				}
+				return
+			}
+
+			var undeclaredErr *UndeclaredVariablesError
+			if !errors.As(err, &undeclaredErr) {
+				t.Fatalf("ValidateVariableDeclarations() error = %v, want *UndeclaredVariablesError", err)
+			}
+			if !reflect.DeepEqual(undeclaredErr.Variables, tt.want) {
+				t.Errorf("undeclared variables = %v, want %v", undeclaredErr.Variables, tt.want)
+			}
+		})
+	}
+}
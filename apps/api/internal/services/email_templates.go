@@ -1,6 +1,9 @@
 package services
 
-import "fmt"
+import (
+	"fmt"
+	"html"
+)
 
 func getPasswordResetHTMLTemplate(resetURL, companyName string) string {
 	return fmt.Sprintf(`
@@ -139,4 +142,546 @@ If you have any questions, please contact our support team.
 ---
 %s
 `, companyName, resetURL, companyName)
-}
\ No newline at end of file
+}
+
+func getInvitationHTMLTemplate(invitationURL, companyName string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Invitation</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <h2>You're Invited</h2>
+            <p>An administrator has created an account for you. Click the button below to choose your name and password and finish setting up your account:</p>
+            
+            <a href="%s" class="button">Accept Invitation</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Security Notice:</strong> This invitation will expire in 72 hours. If you weren't expecting it, please ignore this email.
+            </div>
+            
+            <p>If the button doesn't work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">%s</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from %s. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>`, companyName, invitationURL, invitationURL, companyName)
+}
+
+func getInvitationTextTemplate(invitationURL, companyName string) string {
+	return fmt.Sprintf(`
+%s - Invitation
+
+An administrator has created an account for you.
+
+Please click or copy the following link to accept the invitation:
+%s
+
+This invitation will expire in 72 hours.
+If you weren't expecting it, please ignore this email.
+
+If you have any questions, please contact our support team.
+
+---
+%s
+`, companyName, invitationURL, companyName)
+}
+
+func getWelcomeHTMLTemplate(name, loginURL, companyName string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Welcome</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <h2>Welcome, %s!</h2>
+            <p>Thanks for signing up. Your account is ready, and you can sign in at any time with the email address and password you registered with:</p>
+            
+            <a href="%s" class="button">Sign In</a>
+            
+            <p>If the button doesn't work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">%s</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from %s. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>`, html.EscapeString(companyName), html.EscapeString(name), loginURL, loginURL, html.EscapeString(companyName))
+}
+
+func getWelcomeTextTemplate(name, loginURL, companyName string) string {
+	return fmt.Sprintf(`
+%s - Welcome
+
+Hi %s,
+
+Thanks for signing up. Your account is ready, and you can sign in at any time
+with the email address and password you registered with:
+%s
+
+If you have any questions, please contact our support team.
+
+---
+%s
+`, companyName, name, loginURL, companyName)
+}
+
+func getEmailVerificationHTMLTemplate(verificationURL, companyName string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Verify Your Email</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <h2>Verify Your Email Address</h2>
+            <p>This address was added to your account. Click the button below to confirm that it belongs to you:</p>
+            
+            <a href="%s" class="button">Verify Email</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Security Notice:</strong> If you didn't add this address to an account, please ignore this email. It will not be verified.
+            </div>
+            
+            <p>If the button doesn't work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">%s</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from %s. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>`, companyName, verificationURL, verificationURL, companyName)
+}
+
+func getEmailVerificationTextTemplate(verificationURL, companyName string) string {
+	return fmt.Sprintf(`
+%s - Verify Your Email
+
+This address was added to your account.
+
+Please click or copy the following link to confirm that it belongs to you:
+%s
+
+If you didn't add this address to an account, please ignore this email.
+It will not be verified.
+
+If you have any questions, please contact our support team.
+
+---
+%s
+`, companyName, verificationURL, companyName)
+}
+
+func getAdminPasswordResetHTMLTemplate(name, loginURL, companyName string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Password Reset by Administrator</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <h2>Hi %s,</h2>
+            <p>An administrator has reset the password for your account. Ask them for your new password, then sign in:</p>
+            
+            <a href="%s" class="button">Sign In</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Security Notice:</strong> If you didn't ask for your password to be reset, please contact our support team right away.
+            </div>
+            
+            <p>If the button doesn't work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">%s</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from %s. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>`, html.EscapeString(companyName), html.EscapeString(name), loginURL, loginURL, html.EscapeString(companyName))
+}
+
+func getAdminPasswordResetTextTemplate(name, loginURL, companyName string) string {
+	return fmt.Sprintf(`
+%s - Password Reset by Administrator
+
+Hi %s,
+
+An administrator has reset the password for your account. Ask them for your
+new password, then sign in:
+%s
+
+If you didn't ask for your password to be reset, please contact our support
+team right away.
+
+---
+%s
+`, companyName, name, loginURL, companyName)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"api/internal/cache"
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// fieldMaskingRulesEntry is the cached rules of one set of roles
+type fieldMaskingRulesEntry struct {
+	rules     []models.FieldMaskingRule
+	version   int64
+	expiresAt time.Time
+}
+
+// fieldMaskingRules caches RulesForRoles by role set. Entries are dropped
+// when a rule changes in this process, and expire after
+// PERMISSION_CACHE_TTL for changes made elsewhere.
+var fieldMaskingRules = struct {
+	sync.Mutex
+	entries map[string]fieldMaskingRulesEntry
+}{entries: make(map[string]fieldMaskingRulesEntry)}
+
+type FieldMaskingService struct {
+	db *gorm.DB
+}
+
+func NewFieldMaskingService() *FieldMaskingService {
+	return &FieldMaskingService{
+		db: database.DB,
+	}
+}
+
+// RulesForRoles returns the field masking rules of roleNames. The caller
+// owns the returned slice.
+func (s *FieldMaskingService) RulesForRoles(roleNames []string) ([]models.FieldMaskingRule, error) {
+	if len(roleNames) == 0 {
+		return nil, nil
+	}
+
+	sorted := slices.Clone(roleNames)
+	slices.Sort(sorted)
+	key := strings.Join(slices.Compact(sorted), "\x00")
+	version := models.FieldMaskingRulesVersion()
+
+	fieldMaskingRules.Lock()
+	entry, ok := fieldMaskingRules.entries[key]
+	fieldMaskingRules.Unlock()
+	if ok && entry.version == version && time.Now().Before(entry.expiresAt) {
+		return slices.Clone(entry.rules), nil
+	}
+
+	var rules []models.FieldMaskingRule
+	if err := s.db.Where("role_name IN ?", roleNames).Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	fieldMaskingRules.Lock()
+	fieldMaskingRules.entries[key] = fieldMaskingRulesEntry{
+		rules:     rules,
+		version:   version,
+		expiresAt: time.Now().Add(cache.TTL()),
+	}
+	fieldMaskingRules.Unlock()
+	return slices.Clone(rules), nil
+}
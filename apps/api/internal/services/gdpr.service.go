@@ -0,0 +1,218 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"api/internal/cache"
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErasedValue replaces personal data in records kept after a user is erased
+const ErasedValue = "[DELETED]"
+
+// UserDataExport holds all personal data stored for a user
+type UserDataExport struct {
+	ExportedAt          time.Time
+	User                models.User
+	RoleAssignments     []models.UserRole
+	AuditLogs           []models.AuditLog
+	PasswordResetTokens []models.PasswordResetToken
+	LoginEvents         []models.LoginEvent
+}
+
+type GDPRService struct {
+	db *gorm.DB
+}
+
+func NewGDPRService() *GDPRService {
+	return &GDPRService{
+		db: database.DB,
+	}
+}
+
+// ExportUserData collects the personal data stored for a user. Audit entries
+// include both actions the user performed and actions performed on them.
+func (s *GDPRService) ExportUserData(userID string) (*UserDataExport, error) {
+	export := &UserDataExport{
+		ExportedAt: time.Now().UTC(),
+	}
+
+	if err := s.db.Preload("Company").Where("id = ?", userID).First(&export.User).Error; err != nil {
+		return nil, err
+	}
+
+	err := s.db.Preload("Role").
+		Where("user_id = ?", userID).
+		Order("granted_at ASC").
+		Find(&export.RoleAssignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Where("actor_id = ? OR (resource_type = ? AND resource_id = ?)", userID, AuditResourceUser, userID).
+		Order("created_at ASC").
+		Find(&export.AuditLogs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&export.PasswordResetTokens).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&export.LoginEvents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+// EraseUser permanently deletes a user, their personal data and their avatar
+// file. Audit log entries are kept for accountability but stripped of the
+// user's details.
+func (s *GDPRService) EraseUser(userID string) error {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Unscoped().Where("id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+
+		if err := anonymizeAuditLogs(tx, &user); err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+
+		// Roles this user granted to others stay, without the reference
+		if err := tx.Model(&models.UserRole{}).Where("granted_by = ?", userID).Update("granted_by", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserEmail{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.LoginEvent{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.PasswordHistory{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.APIKey{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserPreference{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.EmailTemplateVersion{}).Where("created_by = ?", userID).Update("created_by", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Delete(&user).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	cache.Permissions().Delete(userID)
+	return RemoveAvatar(userID)
+}
+
+// anonymizeAuditLogs removes the user's personal details from audit entries
+// they performed or that concern them
+func anonymizeAuditLogs(tx *gorm.DB, user *models.User) error {
+	var logs []models.AuditLog
+	err := tx.Where("actor_id = ? OR (resource_type = ? AND resource_id = ?)", user.ID, AuditResourceUser, user.ID).
+		Find(&logs).Error
+	if err != nil {
+		return err
+	}
+
+	personalData := []string{user.Email, user.Name}
+	if user.Phone != nil {
+		personalData = append(personalData, *user.Phone)
+	}
+
+	for _, log := range logs {
+		updates := map[string]interface{}{
+			"changes": anonymizeAuditChanges(log.Changes, personalData),
+		}
+		if log.ActorID != nil && *log.ActorID == user.ID {
+			updates["ip_address"] = ""
+			updates["user_agent"] = ""
+		}
+
+		if err := tx.Model(&models.AuditLog{}).Where("id = ?", log.ID).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// anonymizeAuditChanges replaces every string in changes that matches one of
+// personalData with ErasedValue
+func anonymizeAuditChanges(changes models.JSONB, personalData []string) models.JSONB {
+	if len(changes) == 0 {
+		return changes
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(changes, &decoded); err != nil {
+		return changes
+	}
+
+	anonymized, err := json.Marshal(anonymizeValue(decoded, personalData))
+	if err != nil {
+		return changes
+	}
+	return models.JSONB(anonymized)
+}
+
+func anonymizeValue(value interface{}, personalData []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = anonymizeValue(item, personalData)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = anonymizeValue(item, personalData)
+		}
+		return v
+	case string:
+		for _, data := range personalData {
+			if data != "" && strings.EqualFold(v, data) {
+				return ErasedValue
+			}
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"api/internal/models"
+)
+
+func TestAnonymizeAuditChanges(t *testing.T) {
+	changes := models.JSONB(`{
+		"email": {"from": "Jane@Example.com", "to": "jane.doe@example.com"},
+		"name": "Jane Doe",
+		"roles": ["user", "admin"],
+		"count": 3
+	}`)
+
+	anonymized := anonymizeAuditChanges(changes, []string{"jane@example.com", "Jane Doe"})
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(anonymized, &got); err != nil {
+		t.Fatalf("anonymized changes are not valid JSON: %v", err)
+	}
+
+	want := map[string]interface{}{
+		"email": map[string]interface{}{"from": ErasedValue, "to": "jane.doe@example.com"},
+		"name":  ErasedValue,
+		"roles": []interface{}{"user", "admin"},
+		"count": float64(3),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("anonymizeAuditChanges() = %v, want %v", got, want)
+	}
+}
+
+func TestAnonymizeAuditChangesEmpty(t *testing.T) {
+	if got := anonymizeAuditChanges(nil, []string{"jane@example.com"}); got != nil {
+		t.Errorf("anonymizeAuditChanges(nil) = %s, want nil", got)
+	}
+}
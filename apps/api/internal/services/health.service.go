@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+)
+
+// Dependency health statuses
+const (
+	DependencyStatusOK           = "ok"
+	DependencyStatusDown         = "down"
+	DependencyStatusUnconfigured = "unconfigured"
+)
+
+// dependencyCheckTimeout bounds how long each dependency may take to answer
+const dependencyCheckTimeout = 2 * time.Second
+
+// DependencyHealth is the result of checking one external dependency
+type DependencyHealth struct {
+	Status    string
+	LatencyMs int64
+	Error     string
+}
+
+// dependencyCheck pings a dependency, returning an error when it is unreachable
+type dependencyCheck func(ctx context.Context) error
+
+// HealthCheckService checks the external dependencies the API relies on
+type HealthCheckService struct {
+	// checks holds the check of each dependency by name; a nil check marks
+	// the dependency as not configured
+	checks  map[string]dependencyCheck
+	timeout time.Duration
+}
+
+func NewHealthCheckService() *HealthCheckService {
+	checks := map[string]dependencyCheck{
+		"database": nil,
+		"redis":    nil,
+		"smtp":     nil,
+	}
+	if db := database.DB; db != nil {
+		checks["database"] = func(ctx context.Context) error {
+			sqlDB, err := db.DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}
+	}
+	if client := database.Redis; client != nil {
+		checks["redis"] = func(ctx context.Context) error {
+			return client.Ping(ctx).Err()
+		}
+	}
+	if helpers.GetEnv("EMAIL_PROVIDER", "") == "smtp" {
+		if config, err := loadSMTPConfig(); err == nil {
+			checks["smtp"] = dialCheck(net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+		}
+	}
+
+	return &HealthCheckService{
+		checks:  checks,
+		timeout: dependencyCheckTimeout,
+	}
+}
+
+// dialCheck checks that a TCP connection to address can be opened, closing
+// it straight away
+func dialCheck(address string) dependencyCheck {
+	return func(ctx context.Context) error {
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
+
+// Check pings every configured dependency concurrently and returns their
+// health by name
+func (s *HealthCheckService) Check(ctx context.Context) map[string]DependencyHealth {
+	results := make(map[string]DependencyHealth, len(s.checks))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, check := range s.checks {
+		if check == nil {
+			results[name] = DependencyHealth{Status: DependencyStatusUnconfigured}
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, check dependencyCheck) {
+			defer wg.Done()
+			health := s.checkOne(ctx, check)
+			mu.Lock()
+			results[name] = health
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (s *HealthCheckService) checkOne(ctx context.Context, check dependencyCheck) DependencyHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	health := DependencyHealth{Status: DependencyStatusOK, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		health.Status = DependencyStatusDown
+		health.Error = err.Error()
+	}
+	return health
+}
+
+// DependenciesHealthy reports whether every configured dependency is up
+func DependenciesHealthy(results map[string]DependencyHealth) bool {
+	for _, health := range results {
+		if health.Status == DependencyStatusDown {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"api/internal/database"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// useHealthDependencies points the health check at an in-memory database, a
+// miniredis server and a TCP listener standing in for the SMTP server
+func useHealthDependencies(t *testing.T) *miniredis.Miniredis {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	redisServer := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: redisServer.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+
+	previousDB, previousRedis := database.DB, database.Redis
+	database.DB, database.Redis = db, client
+	t.Cleanup(func() { database.DB, database.Redis = previousDB, previousRedis })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	t.Setenv("EMAIL_PROVIDER", "smtp")
+	t.Setenv("SMTP_HOST", host)
+	t.Setenv("SMTP_PORT", port)
+	t.Setenv("SMTP_USERNAME", "user")
+	t.Setenv("SMTP_PASSWORD", "secret")
+	t.Setenv("SMTP_FROM_EMAIL", "noreply@example.com")
+
+	return redisServer
+}
+
+func TestHealthCheckServiceCheck(t *testing.T) {
+	redisServer := useHealthDependencies(t)
+
+	results := NewHealthCheckService().Check(context.Background())
+	for _, name := range []string{"database", "redis", "smtp"} {
+		if results[name].Status != DependencyStatusOK {
+			t.Errorf("%s = %+v, want ok", name, results[name])
+		}
+	}
+	if !DependenciesHealthy(results) {
+		t.Error("DependenciesHealthy() = false, want true")
+	}
+
+	redisServer.Close()
+	results = NewHealthCheckService().Check(context.Background())
+	if got := results["redis"]; got.Status != DependencyStatusDown || got.Error == "" {
+		t.Errorf("redis = %+v, want down with an error", got)
+	}
+	if results["database"].Status != DependencyStatusOK {
+		t.Errorf("database = %+v, want ok", results["database"])
+	}
+	if DependenciesHealthy(results) {
+		t.Error("DependenciesHealthy() = true with redis down")
+	}
+}
+
+func TestHealthCheckServiceUnconfigured(t *testing.T) {
+	previousDB, previousRedis := database.DB, database.Redis
+	database.DB, database.Redis = nil, nil
+	t.Cleanup(func() { database.DB, database.Redis = previousDB, previousRedis })
+	t.Setenv("EMAIL_PROVIDER", "console")
+
+	results := NewHealthCheckService().Check(context.Background())
+	for _, name := range []string{"database", "redis", "smtp"} {
+		if results[name].Status != DependencyStatusUnconfigured {
+			t.Errorf("%s = %+v, want unconfigured", name, results[name])
+		}
+	}
+	if !DependenciesHealthy(results) {
+		t.Error("DependenciesHealthy() = false with nothing configured")
+	}
+}
+
+func TestHealthCheckServiceTimeout(t *testing.T) {
+	service := &HealthCheckService{
+		checks: map[string]dependencyCheck{
+			"slow": func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			"broken": func(ctx context.Context) error { return errors.New("connection refused") },
+		},
+		timeout: 10 * time.Millisecond,
+	}
+
+	results := service.Check(context.Background())
+	if got := results["slow"]; got.Status != DependencyStatusDown || got.Error != context.DeadlineExceeded.Error() {
+		t.Errorf("slow = %+v, want down after the timeout", got)
+	}
+	if got := results["broken"]; got.Status != DependencyStatusDown || got.Error != "connection refused" {
+		t.Errorf("broken = %+v, want down with its error", got)
+	}
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyTTL is how long a stored response is replayed for
+const IdempotencyKeyTTL = 24 * time.Hour
+
+type IdempotencyService struct {
+	db *gorm.DB
+}
+
+func NewIdempotencyService() *IdempotencyService {
+	return &IdempotencyService{
+		db: database.DB,
+	}
+}
+
+// Reserve claims key for userID, or for anonymous requests when userID is
+// empty. When the key is already in use it returns the existing entry and
+// false; otherwise it returns the new in-progress entry and true. Expired
+// entries are replaced.
+func (s *IdempotencyService) Reserve(key, userID, requestHash string) (*models.IdempotencyKey, bool, error) {
+	var entry models.IdempotencyKey
+	reserved := false
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		err := scopeIdempotencyKey(tx, key, userID).
+			Where("created_at < ?", time.Now().Add(-IdempotencyKeyTTL)).
+			Delete(&models.IdempotencyKey{}).Error
+		if err != nil {
+			return err
+		}
+
+		entry = models.IdempotencyKey{
+			Key:         key,
+			RequestHash: requestHash,
+		}
+		if userID != "" {
+			entry.UserID = &userID
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 1 {
+			reserved = true
+			return nil
+		}
+
+		entry = models.IdempotencyKey{}
+		return scopeIdempotencyKey(tx, key, userID).First(&entry).Error
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return &entry, reserved, nil
+}
+
+// Complete stores the response for a reserved key
+func (s *IdempotencyService) Complete(key, userID string, status int, body []byte) error {
+	return scopeIdempotencyKey(s.db.Model(&models.IdempotencyKey{}), key, userID).
+		Updates(map[string]interface{}{
+			"response_status": status,
+			"response_body":   body,
+		}).Error
+}
+
+// Release removes a reserved key so the request can be retried
+func (s *IdempotencyService) Release(key, userID string) error {
+	return scopeIdempotencyKey(s.db, key, userID).Delete(&models.IdempotencyKey{}).Error
+}
+
+// PurgeExpiredIdempotencyKeys deletes every expired key and returns how many
+// were removed
+func (s *IdempotencyService) PurgeExpiredIdempotencyKeys() (int64, error) {
+	result := s.db.Where("created_at < ?", time.Now().Add(-IdempotencyKeyTTL)).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+func scopeIdempotencyKey(db *gorm.DB, key, userID string) *gorm.DB {
+	if userID == "" {
+		return db.Where("key = ? AND user_id IS NULL", key)
+	}
+	return db.Where("key = ? AND user_id = ?", key, userID)
+}
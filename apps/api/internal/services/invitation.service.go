@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"api/internal/auth"
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// InvitationExpiration is how long an invitation can be accepted after it is sent
+const InvitationExpiration = 72 * time.Hour
+
+var (
+	// ErrInvitationEmailTaken is returned when the invited email already has an account
+	ErrInvitationEmailTaken = errors.New("a user with this email already exists")
+	// ErrInvitationRoleNotFound is returned when an invitation names an unknown role
+	ErrInvitationRoleNotFound = errors.New("role not found")
+	// ErrInvalidInvitation is returned for unknown, expired or already accepted tokens
+	ErrInvalidInvitation = errors.New("invalid or expired invitation")
+	// ErrInvitationNotSent is returned when the invitation email could not be
+	// sent; the invitation is not stored
+	ErrInvitationNotSent = errors.New("invitation email could not be sent")
+)
+
+type InvitationService struct {
+	db *gorm.DB
+}
+
+func NewInvitationService() *InvitationService {
+	return &InvitationService{
+		db: database.DB,
+	}
+}
+
+// CreateInvitation stores a new invitation and calls send with the invitee's
+// email and the plain token. Pending invitations for the same email are
+// replaced, so only the latest link works. When send fails nothing is stored
+// and ErrInvitationNotSent is returned. Users without roles are invited with
+// the default user role.
+func (s *InvitationService) CreateInvitation(email string, roles []string, invitedBy *string, send func(to, token string) error) (*models.UserInvitation, error) {
+	email = helpers.NormalizeEmail(email)
+	if len(roles) == 0 {
+		defaultRole, err := (&SystemSettingService{db: s.db}).GetDefaultUserRole(s.db.Statement.Context)
+		if err != nil {
+			return nil, err
+		}
+		if defaultRole != "" {
+			roles = []string{defaultRole}
+		}
+	}
+
+	token, hashedToken, err := auth.GenerateResetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := models.UserInvitation{
+		Email:     email,
+		InvitedBy: invitedBy,
+		TokenHash: hashedToken,
+		Roles:     models.RoleNames(roles),
+		ExpiresAt: time.Now().Add(InvitationExpiration),
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var existing int64
+		if err := tx.Model(&models.User{}).Unscoped().Where("email = ?", email).Count(&existing).Error; err != nil {
+			return err
+		}
+		if existing > 0 {
+			return ErrInvitationEmailTaken
+		}
+
+		for _, roleName := range roles {
+			var count int64
+			if err := tx.Model(&models.Role{}).Where("name = ?", roleName).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return fmt.Errorf("%w: %s", ErrInvitationRoleNotFound, roleName)
+			}
+		}
+
+		if err := tx.Where("email = ? AND accepted_at IS NULL", email).Delete(&models.UserInvitation{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Create(&invitation).Error; err != nil {
+			return err
+		}
+
+		// Sent inside the transaction so that a failed email leaves no
+		// invitation behind and keeps the previous one working
+		if err := send(invitation.Email, token); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvitationNotSent, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// ListPendingInvitations returns invitations that have not been accepted and
+// have not expired, newest first
+func (s *InvitationService) ListPendingInvitations() ([]models.UserInvitation, error) {
+	var invitations []models.UserInvitation
+	err := s.db.Where("accepted_at IS NULL AND expires_at > ?", time.Now()).
+		Order("created_at DESC").
+		Find(&invitations).Error
+	return invitations, err
+}
+
+// AcceptInvitation creates the invited user with the given name and password,
+// assigns the invited roles and marks the invitation accepted
+func (s *InvitationService) AcceptInvitation(token, name, password string) (*models.User, error) {
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var invitation models.UserInvitation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", auth.HashToken(token)).
+			First(&invitation).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidInvitation
+			}
+			return err
+		}
+
+		if invitation.IsAccepted() || invitation.IsExpired() {
+			return ErrInvalidInvitation
+		}
+
+		user = models.User{
+			Email:    invitation.Email,
+			Password: hashedPassword,
+			Name:     helpers.TrimString(name),
+		}
+		if err := tx.Create(&user).Error; err != nil {
+			if helpers.IsDuplicateError(err) {
+				return ErrInvitationEmailTaken
+			}
+			return err
+		}
+
+		rbacService := &RBACService{db: tx}
+		if err := rbacService.SetUserRoles(tx.Statement.Context, user.ID, invitation.Roles, invitation.InvitedBy, nil); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		return tx.Model(&invitation).Update("accepted_at", &now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// MaxFailedLogins is the number of failed attempts within LoginLockoutWindow
+	// that locks an account
+	MaxFailedLogins = 10
+	// LoginLockoutWindow is how far back failed attempts are counted; an account
+	// unlocks once its failures fall outside the window
+	LoginLockoutWindow = 15 * time.Minute
+
+	DefaultLoginHistoryLimit = 20
+	MaxLoginHistoryLimit     = 100
+)
+
+type LoginHistoryService struct {
+	db *gorm.DB
+}
+
+func NewLoginHistoryService() *LoginHistoryService {
+	return &LoginHistoryService{
+		db: database.DB,
+	}
+}
+
+// RecordLogin stores a login attempt and, when it succeeded, updates the
+// user's last login time
+func (s *LoginHistoryService) RecordLogin(userID, ipAddress, userAgent string, success bool) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		event := models.LoginEvent{
+			UserID:    userID,
+			IPAddress: ipAddress,
+			UserAgent: userAgent,
+			Success:   success,
+		}
+		if err := tx.Create(&event).Error; err != nil {
+			return err
+		}
+
+		if !success {
+			return nil
+		}
+
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("last_login_at", event.CreatedAt).Error
+	})
+}
+
+// IsLockedOut reports whether the user has MaxFailedLogins failed attempts
+// within LoginLockoutWindow. Failures before the last successful login are
+// not counted.
+func (s *LoginHistoryService) IsLockedOut(userID string) (bool, error) {
+	since := time.Now().Add(-LoginLockoutWindow)
+
+	var lastSuccess models.LoginEvent
+	err := s.db.Where("user_id = ? AND success = ? AND created_at > ?", userID, true, since).
+		Order("created_at DESC").
+		First(&lastSuccess).Error
+	if err == nil {
+		since = lastSuccess.CreatedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, err
+	}
+
+	var failures int64
+	err = s.db.Model(&models.LoginEvent{}).
+		Where("user_id = ? AND success = ? AND created_at > ?", userID, false, since).
+		Count(&failures).Error
+	if err != nil {
+		return false, err
+	}
+
+	return failures >= MaxFailedLogins, nil
+}
+
+// GetLoginHistory returns the user's most recent login attempts, newest first
+func (s *LoginHistoryService) GetLoginHistory(userID string, limit int) ([]models.LoginEvent, error) {
+	var events []models.LoginEvent
+	err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
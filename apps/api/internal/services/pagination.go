@@ -0,0 +1,43 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidCursor is returned when a pagination cursor cannot be decoded
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	// ErrUnsupportedCursorSort is returned for sort fields that cursors cannot page through
+	ErrUnsupportedCursorSort = errors.New("cursor pagination only supports sorting by created_at")
+)
+
+// PaginationCursor identifies the last row of a page. Rows are ordered by
+// created_at with the ID as a tie-breaker, so pages stay stable when rows are
+// inserted between requests.
+type PaginationCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        string    `json:"id"`
+}
+
+// Encode returns the opaque token handed to clients
+func (c PaginationCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodePaginationCursor parses a token produced by Encode
+func DecodePaginationCursor(token string) (*PaginationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor PaginationCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
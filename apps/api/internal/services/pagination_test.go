@@ -0,0 +1,37 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPaginationCursorRoundTrip(t *testing.T) {
+	cursor := PaginationCursor{
+		CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.UTC),
+		ID:        "7f1c2d3e-0000-4000-8000-000000000001",
+	}
+
+	decoded, err := DecodePaginationCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodePaginationCursor() error = %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID {
+		t.Errorf("DecodePaginationCursor() = %+v, want %+v", decoded, cursor)
+	}
+}
+
+func TestDecodePaginationCursorInvalid(t *testing.T) {
+	tokens := []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("not json")),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"id":"abc"}`)),
+		base64.RawURLEncoding.EncodeToString([]byte(`{"created_at":"2024-05-01T12:30:00Z"}`)),
+	}
+	for _, token := range tokens {
+		if _, err := DecodePaginationCursor(token); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodePaginationCursor(%q) error = %v, want ErrInvalidCursor", token, err)
+		}
+	}
+}
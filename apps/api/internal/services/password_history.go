@@ -0,0 +1,60 @@
+package services
+
+import (
+	"errors"
+
+	"api/internal/auth"
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DefaultPasswordHistoryDepth is the number of previous passwords checked when
+// PASSWORD_HISTORY_DEPTH is not set
+const DefaultPasswordHistoryDepth = 5
+
+// ErrPasswordReused is returned when a new password matches a recent one
+var ErrPasswordReused = errors.New("password was used recently, please choose a different one")
+
+type PasswordHistoryService struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryService() *PasswordHistoryService {
+	return &PasswordHistoryService{
+		db: database.DB,
+	}
+}
+
+// CheckHistory returns ErrPasswordReused if newPassword matches any of the
+// user's last depth previous passwords. A depth of zero disables the check.
+func (s *PasswordHistoryService) CheckHistory(userID, newPassword string, depth int) error {
+	if depth <= 0 {
+		return nil
+	}
+
+	var history []models.PasswordHistory
+	err := s.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(depth).
+		Find(&history).Error
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range history {
+		if auth.CheckPassword(newPassword, entry.PasswordHash) {
+			return ErrPasswordReused
+		}
+	}
+
+	return nil
+}
+
+// RecordPassword stores a password hash the user is moving away from
+func (s *PasswordHistoryService) RecordPassword(userID, hash string) error {
+	return s.db.Create(&models.PasswordHistory{
+		UserID:       userID,
+		PasswordHash: hash,
+	}).Error
+}
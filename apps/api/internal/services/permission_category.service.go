@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrPermissionCategoryNotFound is returned when a permission category does
+// not exist
+var ErrPermissionCategoryNotFound = errors.New("permission category not found")
+
+type PermissionCategoryService struct {
+	db *gorm.DB
+}
+
+func NewPermissionCategoryService() *PermissionCategoryService {
+	return &PermissionCategoryService{
+		db: database.DB,
+	}
+}
+
+// ListCategories returns all permission categories ordered by name
+func (s *PermissionCategoryService) ListCategories() ([]models.PermissionCategory, error) {
+	var categories []models.PermissionCategory
+	err := s.db.Order("name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// GetCategory returns a permission category by ID
+func (s *PermissionCategoryService) GetCategory(id string) (*models.PermissionCategory, error) {
+	var category models.PermissionCategory
+	if err := s.db.Where("id = ?", id).First(&category).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPermissionCategoryNotFound
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// CreateCategory stores a permission category
+func (s *PermissionCategoryService) CreateCategory(category *models.PermissionCategory) error {
+	return s.db.Create(category).Error
+}
+
+// UpdateCategory applies updates to a permission category
+func (s *PermissionCategoryService) UpdateCategory(id string, updates map[string]interface{}) error {
+	result := s.db.Model(&models.PermissionCategory{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPermissionCategoryNotFound
+	}
+	return nil
+}
+
+// DeleteCategory removes a permission category. Its permissions are kept
+// without a category.
+func (s *PermissionCategoryService) DeleteCategory(id string) error {
+	result := s.db.Where("id = ?", id).Delete(&models.PermissionCategory{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPermissionCategoryNotFound
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultAllowedPreferenceKeys are the preference keys accepted when
+// ALLOWED_PREFERENCE_KEYS is not set
+var DefaultAllowedPreferenceKeys = []string{"theme", "locale", "timezone", "email_notifications"}
+
+// DefaultPreferences are stored for every newly registered user. Keys missing
+// from the allowlist are skipped.
+var DefaultPreferences = map[string]interface{}{
+	"theme":               "system",
+	"locale":              "en",
+	"email_notifications": true,
+}
+
+// MaxPreferenceValueSize caps the encoded size of a single preference value
+const MaxPreferenceValueSize = 4 << 10
+
+var (
+	ErrPreferenceNotFound      = errors.New("preference not found")
+	ErrPreferenceKeyNotAllowed = errors.New("preference key not allowed")
+	ErrPreferenceValueTooLarge = errors.New("preference value too large")
+	ErrPreferenceTypeMismatch  = errors.New("preference value has the wrong type")
+)
+
+type PreferenceService struct {
+	db          *gorm.DB
+	allowedKeys []string
+}
+
+func NewPreferenceService() *PreferenceService {
+	return &PreferenceService{
+		db:          database.DB,
+		allowedKeys: LoadAllowedPreferenceKeys(),
+	}
+}
+
+// LoadAllowedPreferenceKeys returns the keys configured in
+// ALLOWED_PREFERENCE_KEYS, a comma-separated list such as "theme,locale"
+func LoadAllowedPreferenceKeys() []string {
+	var keys []string
+	for _, key := range strings.Split(helpers.GetEnv("ALLOWED_PREFERENCE_KEYS", ""), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return DefaultAllowedPreferenceKeys
+	}
+	return keys
+}
+
+// IsAllowedKey reports whether key may be stored
+func (s *PreferenceService) IsAllowedKey(key string) bool {
+	return slices.Contains(s.allowedKeys, key)
+}
+
+// ListPreferences returns all of a user's preferences ordered by key
+func (s *PreferenceService) ListPreferences(ctx context.Context, userID string) ([]models.UserPreference, error) {
+	var preferences []models.UserPreference
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).Order("key ASC").Find(&preferences).Error
+	return preferences, err
+}
+
+// GetPreference returns a single preference
+func (s *PreferenceService) GetPreference(ctx context.Context, userID, key string) (*models.UserPreference, error) {
+	var preference models.UserPreference
+	if err := s.db.WithContext(ctx).Where("user_id = ? AND key = ?", userID, key).First(&preference).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPreferenceNotFound
+		}
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// SetPreference creates or replaces a preference. value must be valid JSON.
+func (s *PreferenceService) SetPreference(ctx context.Context, userID, key string, value json.RawMessage) (*models.UserPreference, error) {
+	if !s.IsAllowedKey(key) {
+		return nil, ErrPreferenceKeyNotAllowed
+	}
+	if len(value) > MaxPreferenceValueSize {
+		return nil, ErrPreferenceValueTooLarge
+	}
+
+	preference := models.UserPreference{
+		UserID: userID,
+		Key:    key,
+		Value:  models.JSONB(value),
+	}
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&preference).Error
+	if err != nil {
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// DeletePreference removes a preference
+func (s *PreferenceService) DeletePreference(ctx context.Context, userID, key string) error {
+	if !s.IsAllowedKey(key) {
+		return ErrPreferenceKeyNotAllowed
+	}
+
+	result := s.db.WithContext(ctx).Where("user_id = ? AND key = ?", userID, key).Delete(&models.UserPreference{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPreferenceNotFound
+	}
+	return nil
+}
+
+// SetDefaultPreferences stores DefaultPreferences for a user without
+// overwriting values they already have
+func (s *PreferenceService) SetDefaultPreferences(ctx context.Context, userID string) error {
+	now := time.Now()
+	var preferences []models.UserPreference
+	for key, value := range DefaultPreferences {
+		if !s.IsAllowedKey(key) {
+			continue
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		preferences = append(preferences, models.UserPreference{
+			UserID:    userID,
+			Key:       key,
+			Value:     models.JSONB(data),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	if len(preferences) == 0 {
+		return nil
+	}
+
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&preferences).Error
+}
+
+// GetString returns a preference as a string, or fallback when it is not set.
+// Numbers and booleans are converted to their text form.
+func (s *PreferenceService) GetString(ctx context.Context, userID, key, fallback string) (string, error) {
+	raw, err := s.rawValue(ctx, userID, key)
+	if err != nil || raw == nil {
+		return fallback, err
+	}
+	return coerceString(raw)
+}
+
+// GetBool returns a preference as a boolean, or fallback when it is not set.
+// Strings such as "true" and "0" and the numbers 0 and 1 are accepted.
+func (s *PreferenceService) GetBool(ctx context.Context, userID, key string, fallback bool) (bool, error) {
+	raw, err := s.rawValue(ctx, userID, key)
+	if err != nil || raw == nil {
+		return fallback, err
+	}
+	return coerceBool(raw)
+}
+
+// GetInt returns a preference as an integer, or fallback when it is not set.
+// Numeric strings and whole floating point numbers are accepted.
+func (s *PreferenceService) GetInt(ctx context.Context, userID, key string, fallback int) (int, error) {
+	raw, err := s.rawValue(ctx, userID, key)
+	if err != nil || raw == nil {
+		return fallback, err
+	}
+	return coerceInt(raw)
+}
+
+// rawValue returns the stored JSON for key, or nil when it is not set
+func (s *PreferenceService) rawValue(ctx context.Context, userID, key string) (json.RawMessage, error) {
+	preference, err := s.GetPreference(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, ErrPreferenceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return json.RawMessage(preference.Value), nil
+}
+
+// decodePreferenceValue decodes raw, keeping numbers as json.Number
+func decodePreferenceValue(raw json.RawMessage) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, ErrPreferenceTypeMismatch
+	}
+	return value, nil
+}
+
+func coerceString(raw json.RawMessage) (string, error) {
+	value, err := decodePreferenceValue(raw)
+	if err != nil {
+		return "", err
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", ErrPreferenceTypeMismatch
+}
+
+func coerceBool(raw json.RawMessage) (bool, error) {
+	value, err := decodePreferenceValue(raw)
+	if err != nil {
+		return false, err
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return b, nil
+		}
+	case json.Number:
+		switch v.String() {
+		case "0":
+			return false, nil
+		case "1":
+			return true, nil
+		}
+	}
+	return false, ErrPreferenceTypeMismatch
+}
+
+func coerceInt(raw json.RawMessage) (int, error) {
+	value, err := decodePreferenceValue(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	var text string
+	switch v := value.(type) {
+	case json.Number:
+		text = v.String()
+	case string:
+		text = strings.TrimSpace(v)
+	default:
+		return 0, ErrPreferenceTypeMismatch
+	}
+
+	if i, err := strconv.Atoi(text); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(text, 64)
+	if err != nil || f != math.Trunc(f) || f > math.MaxInt || f < math.MinInt {
+		return 0, ErrPreferenceTypeMismatch
+	}
+	return int(f), nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLoadAllowedPreferenceKeys(t *testing.T) {
+	t.Setenv("ALLOWED_PREFERENCE_KEYS", " theme, ,locale ")
+	if got := LoadAllowedPreferenceKeys(); !slices.Equal(got, []string{"theme", "locale"}) {
+		t.Errorf("LoadAllowedPreferenceKeys() = %v, want [theme locale]", got)
+	}
+
+	t.Setenv("ALLOWED_PREFERENCE_KEYS", "")
+	if got := LoadAllowedPreferenceKeys(); !slices.Equal(got, DefaultAllowedPreferenceKeys) {
+		t.Errorf("LoadAllowedPreferenceKeys() = %v, want defaults %v", got, DefaultAllowedPreferenceKeys)
+	}
+}
+
+func TestPreferenceServiceIsAllowedKey(t *testing.T) {
+	t.Setenv("ALLOWED_PREFERENCE_KEYS", "theme,locale")
+	service := NewPreferenceService()
+
+	if !service.IsAllowedKey("theme") {
+		t.Error("IsAllowedKey(theme) = false, want true")
+	}
+	for _, key := range []string{"Theme", "timezone", "", "theme,locale"} {
+		if service.IsAllowedKey(key) {
+			t.Errorf("IsAllowedKey(%q) = true, want false", key)
+		}
+	}
+}
+
+func TestPreferenceServiceRejectsDisallowedKeys(t *testing.T) {
+	t.Setenv("ALLOWED_PREFERENCE_KEYS", "theme")
+	service := NewPreferenceService()
+
+	if _, err := service.SetPreference(context.Background(), "user-1", "is_admin", json.RawMessage(`true`)); !errors.Is(err, ErrPreferenceKeyNotAllowed) {
+		t.Errorf("SetPreference() error = %v, want %v", err, ErrPreferenceKeyNotAllowed)
+	}
+	if err := service.DeletePreference(context.Background(), "user-1", "is_admin"); !errors.Is(err, ErrPreferenceKeyNotAllowed) {
+		t.Errorf("DeletePreference() error = %v, want %v", err, ErrPreferenceKeyNotAllowed)
+	}
+}
+
+func TestPreferenceServiceRejectsLargeValues(t *testing.T) {
+	t.Setenv("ALLOWED_PREFERENCE_KEYS", "theme")
+	service := NewPreferenceService()
+
+	value, _ := json.Marshal(string(make([]byte, MaxPreferenceValueSize)))
+	if _, err := service.SetPreference(context.Background(), "user-1", "theme", value); !errors.Is(err, ErrPreferenceValueTooLarge) {
+		t.Errorf("SetPreference() error = %v, want %v", err, ErrPreferenceValueTooLarge)
+	}
+}
+
+func TestCoerceString(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want string
+		err  bool
+	}{
+		{`"dark"`, "dark", false},
+		{`42`, "42", false},
+		{`1.5`, "1.5", false},
+		{`true`, "true", false},
+		{`["a"]`, "", true},
+		{`{"a":1}`, "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := coerceString(json.RawMessage(tt.raw))
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("coerceString(%s) = %q, %v; want %q, error %v", tt.raw, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestCoerceBool(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+		err  bool
+	}{
+		{`true`, true, false},
+		{`false`, false, false},
+		{`"true"`, true, false},
+		{`"0"`, false, false},
+		{`1`, true, false},
+		{`0`, false, false},
+		{`2`, false, true},
+		{`"yes"`, false, true},
+		{`[]`, false, true},
+	}
+
+	for _, tt := range tests {
+		got, err := coerceBool(json.RawMessage(tt.raw))
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("coerceBool(%s) = %v, %v; want %v, error %v", tt.raw, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestCoerceInt(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want int
+		err  bool
+	}{
+		{`42`, 42, false},
+		{`-7`, -7, false},
+		{`3.0`, 3, false},
+		{`" 12 "`, 12, false},
+		{`3.5`, 0, true},
+		{`"twelve"`, 0, true},
+		{`true`, 0, true},
+		{`1e400`, 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := coerceInt(json.RawMessage(tt.raw))
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("coerceInt(%s) = %d, %v; want %d, error %v", tt.raw, got, err, tt.want, tt.err)
+		}
+		if err != nil && !errors.Is(err, ErrPreferenceTypeMismatch) {
+			t.Errorf("coerceInt(%s) error = %v, want %v", tt.raw, err, ErrPreferenceTypeMismatch)
+		}
+	}
+}
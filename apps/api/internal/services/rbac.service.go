@@ -1,37 +1,95 @@
 package services
 
 import (
+	"api/internal/cache"
 	"api/internal/database"
+	"api/internal/helpers"
 	"api/internal/models"
+	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrSystemRoleClone is returned when cloning the admin or user role while
+// PREVENT_SYSTEM_ROLE_CLONE is enabled
+var ErrSystemRoleClone = errors.New("cannot clone system role")
+
+var (
+	// ErrUserNotDeleted is returned when restoring a user that is not deleted
+	ErrUserNotDeleted = errors.New("user is not deleted")
+	// ErrEmailTaken is returned when restoring a user whose email now belongs
+	// to another active user
+	ErrEmailTaken = errors.New("email is used by another user")
+)
+
+// userListColumns are the user columns loaded for the admin user list
+const userListColumns = "id, email, name, phone, company_id, avatar_url, is_active, email_delivery_status, token_expiry_override, last_login_at, created_at, updated_at, deleted_at"
+
+// RBACService manages users, roles and permissions. Writes and the lookups
+// that authorize requests use db; listing and detail reads use readDB, which
+// may be a lagging read replica.
 type RBACService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	readDB *gorm.DB
 }
 
+// NewRBACService returns a service using database.DB and database.ReadDB
 func NewRBACService() *RBACService {
+	return NewRBACServiceWithDB(database.DB, database.ReadDB)
+}
+
+// NewRBACServiceWithDB returns a service writing to db and reading from
+// readDB. A nil readDB reads from db.
+func NewRBACServiceWithDB(db, readDB *gorm.DB) *RBACService {
+	if readDB == nil {
+		readDB = db
+	}
 	return &RBACService{
-		db: database.DB,
+		db:     db,
+		readDB: readDB,
 	}
 }
 
-// GetUserWithRoles fetches a user with their roles loaded
-func (s *RBACService) GetUserWithRoles(userID string) (*models.User, error) {
+// Primary returns a copy of the service that also reads from the primary,
+// for requests that read back their own writes
+func (s *RBACService) Primary() *RBACService {
+	return &RBACService{
+		db:     s.db,
+		readDB: s.db,
+	}
+}
+
+// GetUserWithRoles fetches a user with their roles, tags and company loaded
+func (s *RBACService) GetUserWithRoles(ctx context.Context, userID string) (*models.User, error) {
 	var user models.User
-	err := s.db.Preload("Roles").Where("id = ?", userID).First(&user).Error
+	err := s.readDB.WithContext(ctx).Preload("Roles").Preload("Tags").Preload("Company").Where("id = ?", userID).First(&user).Error
 	if err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
+// GetUsersWithRoles returns the users with the given IDs with their roles,
+// tags and company loaded
+func (s *RBACService) GetUsersWithRoles(ctx context.Context, userIDs []string) ([]models.User, error) {
+	var users []models.User
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+	err := s.readDB.WithContext(ctx).Preload("Roles").Preload("Tags").Preload("Company").Where("id IN ?", userIDs).Find(&users).Error
+	return users, err
+}
+
 // GetUserRoles returns role names for a user
-func (s *RBACService) GetUserRoles(userID string) ([]string, error) {
+func (s *RBACService) GetUserRoles(ctx context.Context, userID string) ([]string, error) {
 	var roles []models.Role
-	err := s.db.Table("roles").
+	err := s.db.WithContext(ctx).Table("roles").
 		Select("roles.name").
 		Joins("JOIN user_roles ON roles.id = user_roles.role_id").
 		Where("user_roles.user_id = ?", userID).
@@ -49,23 +107,91 @@ func (s *RBACService) GetUserRoles(userID string) ([]string, error) {
 	return roleNames, nil
 }
 
-// AssignRoleToUser assigns a role to a user
-func (s *RBACService) AssignRoleToUser(userID, roleName string, grantedBy *string) error {
+// GetUserRoleAssignments returns the user's role assignment records with roles
+// loaded. It reads from the primary because requests are authorized with it.
+func (s *RBACService) GetUserRoleAssignments(ctx context.Context, userID string) ([]models.UserRole, error) {
+	var assignments []models.UserRole
+	err := s.db.WithContext(ctx).Preload("Role").
+		Where("user_id = ?", userID).
+		Order("granted_at ASC").
+		Find(&assignments).Error
+	return assignments, err
+}
+
+// GetActiveRolesForUser returns the names of the user's roles that are active
+// at now: the assignment has not expired and now falls inside the role's
+// access windows
+func (s *RBACService) GetActiveRolesForUser(ctx context.Context, userID string, now time.Time) ([]string, error) {
+	assignments, err := s.GetUserRoleAssignments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, _ := ActiveRoles(assignments, now, 0)
+	return roles, nil
+}
+
+// ActiveRoles returns the names of the role assignments active at now, unexpired
+// and inside their role's access windows, along with how long they may be
+// cached: maxTTL, shortened to the earliest upcoming expiry or window change
+func ActiveRoles(assignments []models.UserRole, now time.Time, maxTTL time.Duration) ([]string, time.Duration) {
+	ttl := maxTTL
+	roles := make([]string, 0, len(assignments))
+	for _, assignment := range assignments {
+		if assignment.ExpiresAt != nil && assignment.ExpiresAt.Before(now) {
+			continue
+		}
+		if next, ok := assignment.Role.AccessWindows.NextChange(now); ok {
+			if remaining := next.Sub(now); remaining < ttl {
+				ttl = remaining
+			}
+		}
+		if !assignment.IsActiveAt(now) {
+			continue
+		}
+		roles = append(roles, assignment.Role.Name)
+		if assignment.ExpiresAt != nil {
+			if remaining := assignment.ExpiresAt.Sub(now); remaining < ttl {
+				ttl = remaining
+			}
+		}
+	}
+	return roles, ttl
+}
+
+// activeRoleNames returns the names of the user's roles that are active now,
+// read from the primary like the permission checks that use them
+func (s *RBACService) activeRoleNames(ctx context.Context, userID string) ([]string, error) {
+	var assignments []models.UserRole
+	err := s.db.WithContext(ctx).Preload("Role").
+		Where("user_id = ?", userID).
+		Order("granted_at ASC").
+		Find(&assignments).Error
+	if err != nil {
+		return nil, err
+	}
+
+	roles, _ := ActiveRoles(assignments, time.Now(), 0)
+	return roles, nil
+}
+
+// AssignRoleToUser assigns a role to a user, optionally expiring at expiresAt
+func (s *RBACService) AssignRoleToUser(ctx context.Context, userID, roleName string, grantedBy *string, expiresAt *time.Time) error {
 	// Check if role exists
 	var role models.Role
-	if err := s.db.Where("name = ?", roleName).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("name = ?", roleName).First(&role).Error; err != nil {
 		return errors.New("role not found")
 	}
 
 	// Check if user exists
 	var user models.User
-	if err := s.db.Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
 		return errors.New("user not found")
 	}
 
 	// Check if assignment already exists
 	var existingAssignment models.UserRole
-	err := s.db.Where("user_id = ? AND role_id = ?", userID, role.ID).First(&existingAssignment).Error
+	err := s.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", userID, role.ID).First(&existingAssignment).Error
 	if err == nil {
 		return errors.New("user already has this role")
 	}
@@ -75,21 +201,27 @@ func (s *RBACService) AssignRoleToUser(userID, roleName string, grantedBy *strin
 		UserID:    userID,
 		RoleID:    role.ID,
 		GrantedBy: grantedBy,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := s.db.WithContext(ctx).Create(&userRole).Error; err != nil {
+		return err
 	}
 
-	return s.db.Create(&userRole).Error
+	cache.Permissions().Delete(userID)
+	return nil
 }
 
 // RemoveRoleFromUser removes a role from a user
-func (s *RBACService) RemoveRoleFromUser(userID, roleName string) error {
+func (s *RBACService) RemoveRoleFromUser(ctx context.Context, userID, roleName string) error {
 	// Get role ID
 	var role models.Role
-	if err := s.db.Where("name = ?", roleName).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("name = ?", roleName).First(&role).Error; err != nil {
 		return errors.New("role not found")
 	}
 
 	// Delete the assignment
-	result := s.db.Where("user_id = ? AND role_id = ?", userID, role.ID).Delete(&models.UserRole{})
+	result := s.db.WithContext(ctx).Where("user_id = ? AND role_id = ?", userID, role.ID).Delete(&models.UserRole{})
 	if result.Error != nil {
 		return result.Error
 	}
@@ -98,12 +230,28 @@ func (s *RBACService) RemoveRoleFromUser(userID, roleName string) error {
 		return errors.New("user does not have this role")
 	}
 
+	cache.Permissions().Delete(userID)
 	return nil
 }
 
-// SetUserRoles replaces all user roles with the provided roles
-func (s *RBACService) SetUserRoles(userID string, roleNames []string, grantedBy *string) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+// CountOtherUsersWithRole returns how many active, non-deleted users other
+// than exceptUserID hold an unexpired assignment of the named role
+func (s *RBACService) CountOtherUsersWithRole(ctx context.Context, roleName, exceptUserID string) (int64, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Table("user_roles").
+		Joins("JOIN roles ON roles.id = user_roles.role_id").
+		Joins("JOIN users ON users.id = user_roles.user_id").
+		Where("roles.name = ? AND user_roles.user_id <> ?", roleName, exceptUserID).
+		Where("user_roles.expires_at IS NULL OR user_roles.expires_at > ?", time.Now()).
+		Where("users.is_active AND users.deleted_at IS NULL").
+		Count(&count).Error
+	return count, err
+}
+
+// SetUserRoles replaces all user roles with the provided roles. Roles present in
+// expiresAt are granted until the given time; all others never expire.
+func (s *RBACService) SetUserRoles(ctx context.Context, userID string, roleNames []string, grantedBy *string, expiresAt map[string]time.Time) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Remove existing roles
 		if err := tx.Where("user_id = ?", userID).Delete(&models.UserRole{}).Error; err != nil {
 			return err
@@ -121,6 +269,9 @@ func (s *RBACService) SetUserRoles(userID string, roleNames []string, grantedBy
 				RoleID:    role.ID,
 				GrantedBy: grantedBy,
 			}
+			if expiry, ok := expiresAt[roleName]; ok {
+				userRole.ExpiresAt = &expiry
+			}
 
 			if err := tx.Create(&userRole).Error; err != nil {
 				return err
@@ -129,45 +280,325 @@ func (s *RBACService) SetUserRoles(userID string, roleNames []string, grantedBy
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	cache.Permissions().Delete(userID)
+	return nil
 }
 
-// HasPermission checks if a user has a specific permission
-func (s *RBACService) HasPermission(userID, permissionName string) (bool, error) {
+// MaxBulkRoleUpdates limits the number of users in one bulk role update
+const MaxBulkRoleUpdates = 100
+
+var (
+	// ErrUnknownRole is returned when a bulk role update names a role that
+	// does not exist
+	ErrUnknownRole = errors.New("unknown role")
+	// ErrGranterNotFound is returned when the user recorded as granting the
+	// roles does not exist
+	ErrGranterNotFound = errors.New("granting user not found")
+)
+
+// BulkRoleUpdate replaces the roles of one user in a bulk role update
+type BulkRoleUpdate struct {
+	UserID string
+	Roles  []string
+}
+
+// BulkRoleResult is the outcome of one BulkRoleUpdate. Err is nil when the
+// user's roles were replaced, in which case PreviousRoles holds the role names
+// the user had before.
+type BulkRoleResult struct {
+	UserID        string
+	PreviousRoles []string
+	Err           error
+}
+
+// BulkSetUserRoles replaces the roles of several users. Each update runs in
+// its own savepoint, so a user that cannot be updated is reported in its
+// result without rolling back the others. Unknown role names or granting user
+// fail the whole call before anything is changed.
+func (s *RBACService) BulkSetUserRoles(ctx context.Context, updates []BulkRoleUpdate, grantedBy string) ([]BulkRoleResult, error) {
+	roleIDs, err := s.roleIDsByName(ctx, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	var granter *string
+	if grantedBy != "" {
+		var count int64
+		if err := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", grantedBy).Count(&count).Error; err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, ErrGranterNotFound
+		}
+		granter = &grantedBy
+	}
+
+	results := make([]BulkRoleResult, len(updates))
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, update := range updates {
+			results[i] = BulkRoleResult{UserID: update.UserID}
+			results[i].Err = tx.Transaction(func(sp *gorm.DB) error {
+				var user models.User
+				if err := sp.Select("id").Where("id = ?", update.UserID).First(&user).Error; err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return errors.New("user not found")
+					}
+					return err
+				}
+
+				var previous []string
+				if err := sp.Table("roles").
+					Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+					Where("user_roles.user_id = ?", update.UserID).
+					Order("roles.name").
+					Pluck("roles.name", &previous).Error; err != nil {
+					return err
+				}
+
+				if err := sp.Where("user_id = ?", update.UserID).Delete(&models.UserRole{}).Error; err != nil {
+					return err
+				}
+				for _, roleName := range update.Roles {
+					userRole := models.UserRole{
+						UserID:    update.UserID,
+						RoleID:    roleIDs[roleName],
+						GrantedBy: granter,
+					}
+					if err := sp.Create(&userRole).Error; err != nil {
+						return err
+					}
+				}
+
+				results[i].PreviousRoles = previous
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			cache.Permissions().Delete(result.UserID)
+		}
+	}
+	return results, nil
+}
+
+// roleIDsByName returns the IDs of the roles named in updates, keyed by name.
+// It returns ErrUnknownRole listing the names that do not exist.
+func (s *RBACService) roleIDsByName(ctx context.Context, updates []BulkRoleUpdate) (map[string]string, error) {
+	var names []string
+	for _, update := range updates {
+		for _, name := range update.Roles {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	var roles []models.Role
+	if err := s.db.WithContext(ctx).Where("name IN ?", names).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	roleIDs := make(map[string]string, len(roles))
+	for _, role := range roles {
+		roleIDs[role.Name] = role.ID
+	}
+
+	var unknown []string
+	for _, name := range names {
+		if _, ok := roleIDs[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRole, strings.Join(unknown, ", "))
+	}
+	return roleIDs, nil
+}
+
+// PurgeExpiredRoleAssignments deletes every expired role assignment and returns
+// the number of assignments removed
+func (s *RBACService) PurgeExpiredRoleAssignments(ctx context.Context) (int64, error) {
+	var userIDs []string
+	if err := s.db.WithContext(ctx).Model(&models.UserRole{}).
+		Distinct("user_id").
+		Where("expires_at IS NOT NULL AND expires_at <= ?", time.Now()).
+		Pluck("user_id", &userIDs).Error; err != nil {
+		return 0, err
+	}
+
+	if len(userIDs) == 0 {
+		return 0, nil
+	}
+
+	result := s.db.WithContext(ctx).Where("expires_at IS NOT NULL AND expires_at <= ?", time.Now()).Delete(&models.UserRole{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	for _, userID := range userIDs {
+		cache.Permissions().Delete(userID)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// HasPermission checks if a user has a specific permission. Without an exact
+// match, the permissions of the user's roles that use wildcard permissions are
+// matched as patterns. Only roles active now count, as in ActiveRoles.
+func (s *RBACService) HasPermission(ctx context.Context, userID, permissionName string) (bool, error) {
+	roles, err := s.activeRoleNames(ctx, userID)
+	if err != nil || len(roles) == 0 {
+		return false, err
+	}
+
 	var count int64
-	err := s.db.Table("permissions").
+	err = s.db.WithContext(ctx).Table("permissions").
 		Select("COUNT(*)").
 		Joins("JOIN role_permissions ON permissions.id = role_permissions.permission_id").
-		Joins("JOIN user_roles ON role_permissions.role_id = user_roles.role_id").
-		Where("user_roles.user_id = ? AND permissions.name = ?", userID, permissionName).
+		Joins("JOIN roles ON role_permissions.role_id = roles.id").
+		Where("roles.name IN ? AND permissions.name = ?", roles, permissionName).
 		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
 
-	return count > 0, err
+	var patterns []string
+	err = s.db.WithContext(ctx).Table("permissions").
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON permissions.id = role_permissions.permission_id").
+		Joins("JOIN roles ON role_permissions.role_id = roles.id").
+		Where("roles.name IN ? AND roles.use_wildcard_permissions = ?", roles, true).
+		Pluck("permissions.name", &patterns).Error
+	if err != nil {
+		return false, err
+	}
+
+	for _, pattern := range patterns {
+		if helpers.MatchesPermission(pattern, permissionName) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// HasPermissions reports, for each of names, whether a user has the
+// permission, including through wildcard roles. Only roles active now count,
+// as in ActiveRoles. The number of queries does not grow with the number of
+// names checked; names the user does not have map to false.
+func (s *RBACService) HasPermissions(ctx context.Context, userID string, names []string) (map[string]bool, error) {
+	results := make(map[string]bool, len(names))
+	if len(names) == 0 {
+		return results, nil
+	}
+
+	roles, err := s.activeRoleNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []struct {
+		Name     string
+		Wildcard bool
+	}
+	if len(roles) > 0 {
+		err = s.db.WithContext(ctx).Table("permissions").
+			Distinct("permissions.name", "roles.use_wildcard_permissions AS wildcard").
+			Joins("JOIN role_permissions ON permissions.id = role_permissions.permission_id").
+			Joins("JOIN roles ON role_permissions.role_id = roles.id").
+			Where("roles.name IN ? AND (permissions.name IN (?) OR roles.use_wildcard_permissions = ?)", roles, names, true).
+			Find(&grants).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, name := range names {
+		results[name] = false
+		for _, grant := range grants {
+			if grant.Name == name || (grant.Wildcard && helpers.MatchesPermission(grant.Name, name)) {
+				results[name] = true
+				break
+			}
+		}
+	}
+	return results, nil
 }
 
-// GetUserPermissions returns all permissions for a user
-func (s *RBACService) GetUserPermissions(userID string) ([]models.Permission, error) {
+// GetUserPermissions returns the permissions of the user's roles that are
+// active now, as in ActiveRoles, with their categories loaded
+func (s *RBACService) GetUserPermissions(ctx context.Context, userID string) ([]models.Permission, error) {
+	roles, err := s.activeRoleNames(ctx, userID)
+	if err != nil || len(roles) == 0 {
+		return []models.Permission{}, err
+	}
+
 	var permissions []models.Permission
-	err := s.db.Table("permissions").
-		Select("DISTINCT permissions.id, permissions.name, permissions.resource, permissions.action, permissions.description, permissions.created_at, permissions.updated_at").
-		Joins("JOIN role_permissions ON permissions.id = role_permissions.permission_id").
-		Joins("JOIN user_roles ON role_permissions.role_id = user_roles.role_id").
-		Where("user_roles.user_id = ?", userID).
+	err = s.db.WithContext(ctx).Joins("Category").Joins("Creator").
+		Where(`permissions.id IN (SELECT role_permissions.permission_id FROM role_permissions
+			JOIN roles ON role_permissions.role_id = roles.id
+			WHERE roles.name IN ?)`, roles).
 		Find(&permissions).Error
 
 	return permissions, err
 }
 
 // GetAllRoles returns all available roles
-func (s *RBACService) GetAllRoles() ([]models.Role, error) {
+func (s *RBACService) GetAllRoles(ctx context.Context) ([]models.Role, error) {
 	var roles []models.Role
-	err := s.db.Select("id, name, description, created_at, updated_at").Find(&roles).Error
+	err := s.readDB.WithContext(ctx).Select("id, name, description, use_wildcard_permissions, data_scope, access_windows, created_by, updated_by, created_at, updated_at").
+		Preload("Creator").
+		Find(&roles).Error
 	return roles, err
 }
 
+// GetRolesPaginated returns a page of roles ordered by name. A non-empty
+// search matches the name or description case-insensitively, and a non-empty
+// permissionFilter keeps only the roles granted that permission ID.
+func (s *RBACService) GetRolesPaginated(ctx context.Context, page, limit int, search, permissionFilter string) ([]models.Role, int64, error) {
+	var roles []models.Role
+	var total int64
+
+	query := s.readDB.WithContext(ctx).Model(&models.Role{})
+	if search != "" {
+		pattern := "%" + likeEscaper.Replace(search) + "%"
+		query = query.Where("name ILIKE ? OR description ILIKE ?", pattern, pattern)
+	}
+	if permissionFilter != "" {
+		query = query.Where("id IN (SELECT role_id FROM role_permissions WHERE permission_id = ?)", permissionFilter)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Select("id, name, description, use_wildcard_permissions, data_scope, access_windows, created_by, updated_by, created_at, updated_at").
+		Preload("Creator").
+		Order("name ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&roles).Error
+
+	return roles, total, err
+}
+
+// likeEscaper escapes the LIKE wildcards in a search term so that it is
+// matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // GetRoleByName returns a role by name
-func (s *RBACService) GetRoleByName(name string) (*models.Role, error) {
+func (s *RBACService) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
 	var role models.Role
-	err := s.db.Where("name = ?", name).First(&role).Error
+	err := s.readDB.WithContext(ctx).Where("name = ?", name).First(&role).Error
 	if err != nil {
 		return nil, err
 	}
@@ -175,32 +606,56 @@ func (s *RBACService) GetRoleByName(name string) (*models.Role, error) {
 }
 
 // GetAllUsersWithRoles returns all users with their roles loaded
-func (s *RBACService) GetAllUsersWithRoles() ([]models.User, error) {
+func (s *RBACService) GetAllUsersWithRoles(ctx context.Context) ([]models.User, error) {
 	var users []models.User
-	err := s.db.Select("id, email, name, phone, company, created_at, updated_at").Preload("Roles").Find(&users).Error
+	err := s.readDB.WithContext(ctx).Select("id, email, name, phone, company_id, avatar_url, created_at, updated_at").Preload("Roles").Preload("Company").Find(&users).Error
 	return users, err
 }
 
-// GetUsersWithRolesPaginated returns paginated users with their roles loaded
-func (s *RBACService) GetUsersWithRolesPaginated(page, limit int, search, sortBy string, sortDesc bool) ([]models.User, int64, error) {
-	var users []models.User
-	var total int64
-	
-	query := s.db.Model(&models.User{})
-	
-	// Apply search filter if provided
-	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Where("email ILIKE ? OR name ILIKE ? OR company ILIKE ?", searchPattern, searchPattern, searchPattern)
+// UserListFilter narrows the users returned by the user list queries
+type UserListFilter struct {
+	// Search is a full-text match against email, name and company name
+	Search string
+	// CompanyID limits the list to the members of a company
+	CompanyID string
+	// IncludeDeleted also returns soft-deleted users
+	IncludeDeleted bool
+	// Tag limits the list to the users with the tag of this name
+	Tag string
+	// DataScopes are the data scopes of the caller's roles; the list only
+	// holds users that every scope allows
+	DataScopes []map[string]interface{}
+}
+
+// apply adds the filter's conditions to a users query
+func (f UserListFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.IncludeDeleted {
+		query = query.Unscoped()
 	}
-	
-	// Count total records
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	if f.Search != "" {
+		query = query.Where("search_vector @@ plainto_tsquery('simple', ?)", f.Search)
+	}
+	if f.CompanyID != "" {
+		query = query.Where("company_id = ?", f.CompanyID)
+	}
+	if f.Tag != "" {
+		query = query.Where(`EXISTS (SELECT 1 FROM user_tags JOIN tags ON tags.id = user_tags.tag_id
+			WHERE user_tags.user_id = users.id AND tags.name = ?)`, f.Tag)
+	}
+	return helpers.ApplyDataScope(query, f.DataScopes)
+}
+
+// userListOrder returns the ORDER BY clause for a user list sorted by sortBy.
+// Unknown sort fields are ignored, and searches rank the best matches first
+// by default.
+func userListOrder(filter UserListFilter, sortBy string, sortDesc bool) interface{} {
+	var orderClause interface{} = "created_at DESC" // default sorting
+	if filter.Search != "" {
+		orderClause = clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(search_vector, plainto_tsquery('simple', ?)) DESC, created_at DESC",
+			Vars: []interface{}{filter.Search},
+		}}
 	}
-	
-	// Apply sorting
-	orderClause := "created_at DESC" // default sorting
 	if sortBy != "" {
 		validSortFields := map[string]bool{
 			"email":      true,
@@ -215,13 +670,35 @@ func (s *RBACService) GetUsersWithRolesPaginated(page, limit int, search, sortBy
 				direction = "DESC"
 			}
 			orderClause = sortBy + " " + direction
+			if sortBy == "company" {
+				orderClause = "(SELECT name FROM companies WHERE companies.id = users.company_id) " + direction
+			}
 		}
 	}
+	return orderClause
+}
+
+// GetUsersWithRolesPaginated returns paginated users matching filter with
+// their roles and company loaded
+func (s *RBACService) GetUsersWithRolesPaginated(ctx context.Context, page, limit int, filter UserListFilter, sortBy string, sortDesc bool) ([]models.User, int64, error) {
+	var users []models.User
+	var total int64
+	
+	query := filter.apply(s.readDB.WithContext(ctx).Model(&models.User{}))
+	
+	// Count total records
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	
+	orderClause := userListOrder(filter, sortBy, sortDesc)
 	
 	// Apply pagination and get results
 	offset := (page - 1) * limit
-	err := query.Select("id, email, name, phone, company, created_at, updated_at").
+	err := query.Select(userListColumns).
 		Preload("Roles").
+		Preload("Tags").
+		Preload("Company").
 		Order(orderClause).
 		Offset(offset).
 		Limit(limit).
@@ -230,9 +707,148 @@ func (s *RBACService) GetUsersWithRolesPaginated(page, limit int, search, sortBy
 	return users, total, err
 }
 
+// GetUsersWithRolesCursor returns the page of users following after (the
+// first page when nil) and the cursor for the next page, which is nil on the
+// last page. Users are ordered newest first unless sortBy is created_at.
+func (s *RBACService) GetUsersWithRolesCursor(ctx context.Context, after *PaginationCursor, limit int, filter UserListFilter, sortBy string, sortDesc bool) ([]models.User, *PaginationCursor, error) {
+	direction := "DESC"
+	switch sortBy {
+	case "":
+	case "created_at":
+		if !sortDesc {
+			direction = "ASC"
+		}
+	default:
+		return nil, nil, ErrUnsupportedCursorSort
+	}
+
+	query := filter.apply(s.readDB.WithContext(ctx).Model(&models.User{}))
+	if after != nil {
+		comparison := "<"
+		if direction == "ASC" {
+			comparison = ">"
+		}
+		query = query.Where("(created_at, id) "+comparison+" (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	// Fetch one extra row to find out whether another page follows
+	var users []models.User
+	err := query.Select(userListColumns).
+		Preload("Roles").
+		Preload("Tags").
+		Preload("Company").
+		Order("created_at " + direction + ", id " + direction).
+		Limit(limit + 1).
+		Find(&users).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(users) <= limit {
+		return users, nil, nil
+	}
+	users = users[:limit]
+	last := users[limit-1]
+	return users, &PaginationCursor{CreatedAt: last.CreatedAt, ID: last.ID}, nil
+}
+
+// GetUsersByRole returns a page of the users holding a role, with their roles
+// and company loaded. search matches like the user list's search, which also
+// decides the order; users are otherwise listed newest first. Only users
+// every one of dataScopes allows are returned. It returns
+// gorm.ErrRecordNotFound for unknown roles.
+func (s *RBACService) GetUsersByRole(ctx context.Context, roleID string, page, limit int, search string, dataScopes []map[string]interface{}) ([]models.User, int64, error) {
+	if err := s.readDB.WithContext(ctx).Select("id").Where("id = ?", roleID).First(&models.Role{}).Error; err != nil {
+		return nil, 0, err
+	}
+
+	filter := UserListFilter{Search: search, DataScopes: dataScopes}
+	query := filter.apply(s.readDB.WithContext(ctx).Model(&models.User{})).
+		Joins("JOIN user_roles ON user_roles.user_id = users.id AND user_roles.role_id = ?", roleID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	err := query.Select(userListColumns).
+		Preload("Roles").
+		Preload("Tags").
+		Preload("Company").
+		Order(userListOrder(filter, "", false)).
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&users).Error
+
+	return users, total, err
+}
+
+// GetUsersByTag returns a page of the users with a tag, newest first, with
+// their roles, tags and company loaded. It returns gorm.ErrRecordNotFound for
+// unknown tags.
+func (s *RBACService) GetUsersByTag(ctx context.Context, tagName string, page, limit int) ([]models.User, int64, error) {
+	if err := s.readDB.WithContext(ctx).Select("id").Where("name = ?", tagName).First(&models.Tag{}).Error; err != nil {
+		return nil, 0, err
+	}
+	return s.GetUsersWithRolesPaginated(ctx, page, limit, UserListFilter{Tag: tagName}, "", false)
+}
+
+// CountUsersByRole returns the number of users holding a role that every one
+// of dataScopes allows, counted without loading them. It returns
+// gorm.ErrRecordNotFound for unknown roles.
+func (s *RBACService) CountUsersByRole(ctx context.Context, roleID string, dataScopes []map[string]interface{}) (int64, error) {
+	if err := s.readDB.WithContext(ctx).Select("id").Where("id = ?", roleID).First(&models.Role{}).Error; err != nil {
+		return 0, err
+	}
+
+	var count int64
+	query := s.readDB.WithContext(ctx).Model(&models.UserRole{}).
+		Joins("JOIN users ON users.id = user_roles.user_id AND users.deleted_at IS NULL").
+		Where("user_roles.role_id = ?", roleID)
+	err := helpers.ApplyDataScope(query, dataScopes).Count(&count).Error
+	return count, err
+}
+
+// GetDataScopes returns the data scopes of the named roles, skipping roles
+// without one
+func (s *RBACService) GetDataScopes(ctx context.Context, roleNames []string) ([]map[string]interface{}, error) {
+	if len(roleNames) == 0 {
+		return nil, nil
+	}
+
+	var roles []models.Role
+	err := s.db.WithContext(ctx).Select("id, name, data_scope").
+		Where("name IN ? AND data_scope IS NOT NULL", roleNames).
+		Order("name").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]map[string]interface{}, 0, len(roles))
+	for _, role := range roles {
+		scopes = append(scopes, role.DataScope)
+	}
+	return scopes, nil
+}
+
+// UserInDataScope reports whether every one of scopes allows the user.
+// Soft-deleted users are checked too, so restores and purges stay scoped.
+func (s *RBACService) UserInDataScope(ctx context.Context, userID string, scopes []map[string]interface{}) (bool, error) {
+	if len(scopes) == 0 {
+		return true, nil
+	}
+
+	var count int64
+	err := helpers.ApplyDataScope(s.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("id = ?", userID), scopes).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // UpdateUser updates user information
-func (s *RBACService) UpdateUser(userID string, updates map[string]interface{}) error {
-	result := s.db.Model(&models.User{}).Where("id = ?", userID).Updates(updates)
+func (s *RBACService) UpdateUser(ctx context.Context, userID string, updates map[string]interface{}) error {
+	result := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Updates(updates)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -242,119 +858,409 @@ func (s *RBACService) UpdateUser(userID string, updates map[string]interface{})
 	return nil
 }
 
+// SetUserActive suspends or reactivates a user. Cached roles are dropped so
+// RequireAuth re-checks the account status on the user's next request.
+func (s *RBACService) SetUserActive(ctx context.Context, userID string, active bool) error {
+	result := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("is_active", active)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	cache.Permissions().Delete(userID)
+	return nil
+}
+
+// SetTokenExpiryOverride sets how long the user's tokens are valid; nil
+// restores the JWT_EXPIRATION default
+func (s *RBACService) SetTokenExpiryOverride(ctx context.Context, userID string, expiry *time.Duration) error {
+	result := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("token_expiry_override", expiry)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// IsUserActive reports whether the user is not suspended
+func (s *RBACService) IsUserActive(ctx context.Context, userID string) (bool, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).Select("id", "is_active").Where("id = ?", userID).First(&user).Error; err != nil {
+		return false, err
+	}
+	return user.IsActive, nil
+}
+
 // DeleteUser soft deletes a user
-func (s *RBACService) DeleteUser(userID string) error {
+func (s *RBACService) DeleteUser(ctx context.Context, userID string) error {
 	var user models.User
-	if err := s.db.Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
 		return err
 	}
-	return s.db.Delete(&user).Error
+	if err := s.db.WithContext(ctx).Delete(&user).Error; err != nil {
+		return err
+	}
+
+	cache.Permissions().Delete(userID)
+	return nil
+}
+
+// RestoreUser undoes a soft delete. Role assignments are kept while a user is
+// deleted; a user left without any is given the default user role so they can
+// sign in again, unless the default is empty.
+func (s *RBACService) RestoreUser(ctx context.Context, userID string) error {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.Unscoped().Where("id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+		if !user.DeletedAt.Valid {
+			return ErrUserNotDeleted
+		}
+
+		var taken int64
+		if err := tx.Model(&models.User{}).Where("email = ? AND id <> ?", user.Email, userID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return ErrEmailTaken
+		}
+
+		if err := tx.Unscoped().Model(&user).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+
+		var assignments int64
+		if err := tx.Model(&models.UserRole{}).Where("user_id = ?", userID).Count(&assignments).Error; err != nil {
+			return err
+		}
+		if assignments > 0 {
+			return nil
+		}
+
+		defaultRole, err := (&SystemSettingService{db: tx}).GetDefaultUserRole(ctx)
+		if err != nil || defaultRole == "" {
+			return err
+		}
+		return NewRBACServiceWithDB(tx, tx).AssignRoleToUser(ctx, userID, defaultRole, nil, nil)
+	})
+	if err != nil {
+		return err
+	}
+
+	cache.Permissions().Delete(userID)
+	return nil
+}
+
+var (
+	// ErrSelfMerge is returned when merging a user into themselves
+	ErrSelfMerge = errors.New("cannot merge a user into themselves")
+	// ErrMergeSourceNotFound is returned when the user being merged away does
+	// not exist or is already deleted
+	ErrMergeSourceNotFound = errors.New("source user not found")
+)
+
+// MergeOptions selects what MergeUsers copies from the source user. Actor is
+// recorded as having performed the merge.
+type MergeOptions struct {
+	TransferRoles       bool
+	TransferPreferences bool
+	Actor               AuditActor
 }
 
-// GetAllPermissions returns all available permissions
-func (s *RBACService) GetAllPermissions() ([]models.Permission, error) {
+// MergeResult counts what MergeUsers copied to the target user
+type MergeResult struct {
+	RolesTransferred       int64
+	PreferencesTransferred int64
+}
+
+// MergeUsers folds a duplicate account into another. In one transaction it
+// copies the source user's unexpired role assignments and preferences that
+// the target does not already have, records the merge in the audit log and
+// soft deletes the source user. The target's own roles and preferences win
+// over the source's.
+func (s *RBACService) MergeUsers(ctx context.Context, targetID, sourceID string, opts MergeOptions) (*MergeResult, error) {
+	if targetID == sourceID {
+		return nil, ErrSelfMerge
+	}
+
+	result := &MergeResult{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var target models.User
+		if err := tx.Select("id").Where("id = ?", targetID).First(&target).Error; err != nil {
+			return err
+		}
+		var source models.User
+		if err := tx.Select("id", "email").Where("id = ?", sourceID).First(&source).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrMergeSourceNotFound
+			}
+			return err
+		}
+
+		if opts.TransferRoles {
+			copied := tx.Exec(`INSERT INTO user_roles (user_id, role_id, granted_at, granted_by, expires_at)
+				SELECT ?, role_id, granted_at, granted_by, expires_at FROM user_roles
+				WHERE user_id = ? AND (expires_at IS NULL OR expires_at > ?)
+				AND role_id NOT IN (SELECT role_id FROM user_roles WHERE user_id = ?)`,
+				targetID, sourceID, time.Now(), targetID)
+			if copied.Error != nil {
+				return copied.Error
+			}
+			result.RolesTransferred = copied.RowsAffected
+		}
+
+		if opts.TransferPreferences {
+			now := time.Now()
+			copied := tx.Exec(`INSERT INTO user_preferences (user_id, key, value, created_at, updated_at)
+				SELECT ?, key, value, ?, ? FROM user_preferences
+				WHERE user_id = ?
+				AND key NOT IN (SELECT key FROM user_preferences WHERE user_id = ?)`,
+				targetID, now, now, sourceID, targetID)
+			if copied.Error != nil {
+				return copied.Error
+			}
+			result.PreferencesTransferred = copied.RowsAffected
+		}
+
+		err := NewAuditServiceWithDB(tx).LogActor(opts.Actor, AuditActionUserMerge, AuditResourceUser, targetID, map[string]interface{}{
+			"source_user_id":          sourceID,
+			"source_email":            source.Email,
+			"roles_transferred":       result.RolesTransferred,
+			"preferences_transferred": result.PreferencesTransferred,
+		})
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&source).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Permissions().Delete(targetID)
+	cache.Permissions().Delete(sourceID)
+	return result, nil
+}
+
+// GetAllPermissions returns all available permissions with their categories
+// loaded, only those in categoryID when it is not empty
+func (s *RBACService) GetAllPermissions(ctx context.Context, categoryID string) ([]models.Permission, error) {
 	var permissions []models.Permission
-	err := s.db.Select("id, name, resource, action, description, created_at, updated_at").Find(&permissions).Error
+	query := s.readDB.WithContext(ctx).Joins("Category").Joins("Creator")
+	if categoryID != "" {
+		query = query.Where("permissions.category_id = ?", categoryID)
+	}
+	err := query.Order("permissions.name ASC").Find(&permissions).Error
 	return permissions, err
 }
 
-// GetPermissionByID returns a permission by its ID
-func (s *RBACService) GetPermissionByID(id string) (*models.Permission, error) {
+// GetPermissionByID returns a permission by its ID with its category loaded
+func (s *RBACService) GetPermissionByID(ctx context.Context, id string) (*models.Permission, error) {
 	var permission models.Permission
-	err := s.db.Where("id = ?", id).First(&permission).Error
+	err := s.readDB.WithContext(ctx).Joins("Category").Joins("Creator").Where("permissions.id = ?", id).First(&permission).Error
 	if err != nil {
 		return nil, err
 	}
 	return &permission, nil
 }
 
-// CreatePermission creates a new permission
-func (s *RBACService) CreatePermission(name, resource, action string, description *string) (*models.Permission, error) {
+// GetDangerousPermissions returns the permissions whose metadata marks them
+// as dangerous, ordered by name
+func (s *RBACService) GetDangerousPermissions(ctx context.Context) ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := s.readDB.WithContext(ctx).Joins("Category").Joins("Creator").
+		Where("permissions.metadata->>'dangerous' = ?", "true").
+		Order("permissions.name ASC").
+		Find(&permissions).Error
+	return permissions, err
+}
+
+// CreatePermission creates a new permission, in categoryID when it is not
+// nil, recording createdBy as its creator
+func (s *RBACService) CreatePermission(ctx context.Context, name, resource, action string, description, categoryID *string, metadata models.PermissionMetadata, createdBy *string) (*models.Permission, error) {
 	permission := models.Permission{
 		Name:        name,
 		Resource:    resource,
 		Action:      action,
 		Description: description,
+		CategoryID:  categoryID,
+		Metadata:    metadata,
+		CreatedBy:   createdBy,
+		UpdatedBy:   createdBy,
 	}
 
-	err := s.db.Create(&permission).Error
-	if err != nil {
+	if err := s.db.WithContext(ctx).Create(&permission).Error; err != nil {
+		return nil, err
+	}
+
+	// Reload with the category
+	if err := s.db.WithContext(ctx).Joins("Category").Joins("Creator").Where("permissions.id = ?", permission.ID).First(&permission).Error; err != nil {
 		return nil, err
 	}
 	return &permission, nil
 }
 
-// UpdatePermission updates a permission
-func (s *RBACService) UpdatePermission(id string, updates map[string]interface{}) (*models.Permission, error) {
+// UpdatePermission updates a permission, recording updatedBy as its last
+// editor
+func (s *RBACService) UpdatePermission(ctx context.Context, id string, updates map[string]interface{}, updatedBy *string) (*models.Permission, error) {
 	var permission models.Permission
 
 	// First check if permission exists
-	if err := s.db.Where("id = ?", id).First(&permission).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&permission).Error; err != nil {
 		return nil, err
 	}
 
 	// Update the permission
-	if err := s.db.Model(&permission).Updates(updates).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&permission).Updates(withUpdatedBy(updates, updatedBy)).Error; err != nil {
 		return nil, err
 	}
 
 	// Reload the updated permission
-	if err := s.db.Where("id = ?", id).First(&permission).Error; err != nil {
+	if err := s.db.WithContext(ctx).Joins("Category").Joins("Creator").Where("permissions.id = ?", id).First(&permission).Error; err != nil {
 		return nil, err
 	}
 
 	return &permission, nil
 }
 
+// withUpdatedBy returns a copy of updates that also sets updated_by, so the
+// caller's map is left untouched
+func withUpdatedBy(updates map[string]interface{}, updatedBy *string) map[string]interface{} {
+	result := make(map[string]interface{}, len(updates)+1)
+	for key, value := range updates {
+		result[key] = value
+	}
+	result["updated_by"] = updatedBy
+	return result
+}
+
 // DeletePermission deletes a permission (cascade to role_permissions)
-func (s *RBACService) DeletePermission(id string) error {
+func (s *RBACService) DeletePermission(ctx context.Context, id string) error {
 	var permission models.Permission
-	if err := s.db.Where("id = ?", id).First(&permission).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&permission).Error; err != nil {
 		return err
 	}
-	return s.db.Delete(&permission).Error
+	return s.db.WithContext(ctx).Delete(&permission).Error
 }
 
-// GetRoleByIDWithPermissions returns a role with its permissions loaded
-func (s *RBACService) GetRoleByIDWithPermissions(id string) (*models.Role, error) {
+// GetRoleByIDWithPermissions returns a role with its creator, its
+// permissions and their categories and creators loaded. The permissions are
+// fetched in one query joining their categories and creators.
+func (s *RBACService) GetRoleByIDWithPermissions(ctx context.Context, id string) (*models.Role, error) {
 	var role models.Role
-	err := s.db.Preload("Permissions").Where("id = ?", id).First(&role).Error
+	err := s.readDB.WithContext(ctx).Preload("Creator").Where("id = ?", id).First(&role).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.readDB.WithContext(ctx).Joins("Category").Joins("Creator").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id = ?", id).
+		Order("permissions.name ASC").
+		Find(&role.Permissions).Error
 	if err != nil {
 		return nil, err
 	}
 	return &role, nil
 }
 
-// CreateRole creates a new role
-func (s *RBACService) CreateRole(name string, description *string) (*models.Role, error) {
+// CreateRole creates a new role, recording createdBy as its creator
+func (s *RBACService) CreateRole(ctx context.Context, name string, description *string, useWildcardPermissions bool, dataScope models.DataScope, createdBy *string) (*models.Role, error) {
 	role := models.Role{
-		Name:        name,
-		Description: description,
+		Name:                   name,
+		Description:            description,
+		UseWildcardPermissions: useWildcardPermissions,
+		DataScope:              dataScope,
+		CreatedBy:              createdBy,
+		UpdatedBy:              createdBy,
 	}
 
-	err := s.db.Create(&role).Error
-	if err != nil {
+	if err := s.db.WithContext(ctx).Create(&role).Error; err != nil {
+		return nil, err
+	}
+
+	// Reload with the creator
+	if err := s.db.WithContext(ctx).Preload("Creator").Where("id = ?", role.ID).First(&role).Error; err != nil {
 		return nil, err
 	}
 	return &role, nil
 }
 
-// UpdateRole updates a role
-func (s *RBACService) UpdateRole(id string, updates map[string]interface{}) (*models.Role, error) {
+// CloneRole creates a role named newName with the same permissions and data
+// scope as the source role, created by createdBy. When
+// PREVENT_SYSTEM_ROLE_CLONE is true the admin and user roles cannot be
+// cloned.
+func (s *RBACService) CloneRole(ctx context.Context, sourceID, newName string, description *string, createdBy *string) (*models.Role, error) {
+	var clone *models.Role
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		txService := NewRBACServiceWithDB(tx, tx)
+
+		source, err := txService.GetRoleByIDWithPermissions(ctx, sourceID)
+		if err != nil {
+			return err
+		}
+		if isSystemRole(source.Name) && helpers.GetEnvBool("PREVENT_SYSTEM_ROLE_CLONE", false) {
+			return ErrSystemRoleClone
+		}
+
+		role, err := txService.CreateRole(ctx, newName, description, source.UseWildcardPermissions, source.DataScope, createdBy)
+		if err != nil {
+			return err
+		}
+
+		permissionIDs := make([]string, len(source.Permissions))
+		for i, permission := range source.Permissions {
+			permissionIDs[i] = permission.ID
+		}
+		if err := txService.SetRolePermissions(ctx, role.ID, permissionIDs); err != nil {
+			return err
+		}
+
+		clone, err = txService.GetRoleByIDWithPermissions(ctx, role.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// isSystemRole reports whether name is one of the built-in roles
+func isSystemRole(name string) bool {
+	return name == "admin" || name == "user"
+}
+
+// UpdateRole updates a role, recording updatedBy as its last editor
+func (s *RBACService) UpdateRole(ctx context.Context, id string, updates map[string]interface{}, updatedBy *string) (*models.Role, error) {
 	var role models.Role
 
 	// First check if role exists
-	if err := s.db.Where("id = ?", id).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&role).Error; err != nil {
 		return nil, err
 	}
 
 	// Update the role
-	if err := s.db.Model(&role).Updates(updates).Error; err != nil {
+	if err := s.db.WithContext(ctx).Model(&role).Updates(withUpdatedBy(updates, updatedBy)).Error; err != nil {
 		return nil, err
 	}
 
+	// Cached role lists were filtered by the old access windows
+	if _, ok := updates["access_windows"]; ok {
+		userIDs, err := s.roleHolderIDs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		invalidateRoleCache(userIDs)
+	}
+
 	// Reload the updated role
-	if err := s.db.Where("id = ?", id).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Preload("Creator").Where("id = ?", id).First(&role).Error; err != nil {
 		return nil, err
 	}
 
@@ -362,9 +1268,9 @@ func (s *RBACService) UpdateRole(id string, updates map[string]interface{}) (*mo
 }
 
 // DeleteRole deletes a role (cascade to user_roles and role_permissions)
-func (s *RBACService) DeleteRole(id string) error {
+func (s *RBACService) DeleteRole(ctx context.Context, id string) error {
 	var role models.Role
-	if err := s.db.Where("id = ?", id).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&role).Error; err != nil {
 		return err
 	}
 
@@ -373,12 +1279,35 @@ func (s *RBACService) DeleteRole(id string) error {
 		return errors.New("cannot delete system role: " + role.Name)
 	}
 
-	return s.db.Delete(&role).Error
+	// The holders' cached role lists still name the role
+	userIDs, err := s.roleHolderIDs(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.db.WithContext(ctx).Delete(&role).Error; err != nil {
+		return err
+	}
+	invalidateRoleCache(userIDs)
+	return nil
+}
+
+// roleHolderIDs returns the IDs of the users the role is assigned to
+func (s *RBACService) roleHolderIDs(ctx context.Context, roleID string) ([]string, error) {
+	var userIDs []string
+	err := s.db.WithContext(ctx).Model(&models.UserRole{}).Where("role_id = ?", roleID).Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
+
+// invalidateRoleCache drops the cached role lists of the given users
+func invalidateRoleCache(userIDs []string) {
+	for _, userID := range userIDs {
+		cache.Permissions().Delete(userID)
+	}
 }
 
 // SetRolePermissions replaces all permissions for a role
-func (s *RBACService) SetRolePermissions(roleID string, permissionIDs []string) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+func (s *RBACService) SetRolePermissions(ctx context.Context, roleID string, permissionIDs []string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Remove existing permissions
 		if err := tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", roleID).Error; err != nil {
 			return err
@@ -403,48 +1332,135 @@ func (s *RBACService) SetRolePermissions(roleID string, permissionIDs []string)
 }
 
 // AssignPermissionToRole assigns a single permission to a role
-func (s *RBACService) AssignPermissionToRole(roleID, permissionID string) error {
+func (s *RBACService) AssignPermissionToRole(ctx context.Context, roleID, permissionID string) error {
 	// Check if role exists
 	var role models.Role
-	if err := s.db.Where("id = ?", roleID).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", roleID).First(&role).Error; err != nil {
 		return errors.New("role not found")
 	}
 
 	// Check if permission exists
 	var permission models.Permission
-	if err := s.db.Where("id = ?", permissionID).First(&permission).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", permissionID).First(&permission).Error; err != nil {
 		return errors.New("permission not found")
 	}
 
 	// Check if assignment already exists
 	var count int64
-	s.db.Table("role_permissions").Where("role_id = ? AND permission_id = ?", roleID, permissionID).Count(&count)
+	s.db.WithContext(ctx).Table("role_permissions").Where("role_id = ? AND permission_id = ?", roleID, permissionID).Count(&count)
 	if count > 0 {
 		return errors.New("permission already assigned to role")
 	}
 
 	// Create assignment
-	return s.db.Exec("INSERT INTO role_permissions (role_id, permission_id) VALUES (?, ?)", roleID, permissionID).Error
+	return s.db.WithContext(ctx).Exec("INSERT INTO role_permissions (role_id, permission_id) VALUES (?, ?)", roleID, permissionID).Error
+}
+
+// RolePermissionError reports a role that a bulk permission change could not
+// be applied to
+type RolePermissionError struct {
+	RoleID string
+	Reason string
+}
+
+func (e *RolePermissionError) Error() string {
+	return "role " + e.RoleID + " " + e.Reason
+}
+
+// AssignPermissionToRoles grants a permission to each of the given roles in
+// a single transaction. Roles that already have the permission are skipped and
+// roles that do not exist are reported in errs as *RolePermissionError without
+// affecting the others. Any other error in errs means nothing was assigned.
+func (s *RBACService) AssignPermissionToRoles(ctx context.Context, permissionID string, roleIDs []string) (assigned int, errs []error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var permission models.Permission
+		if err := tx.Where("id = ?", permissionID).First(&permission).Error; err != nil {
+			return err
+		}
+
+		for _, roleID := range roleIDs {
+			var count int64
+			if err := tx.Model(&models.Role{}).Where("id = ?", roleID).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				errs = append(errs, &RolePermissionError{RoleID: roleID, Reason: "not found"})
+				continue
+			}
+
+			result := tx.Exec("INSERT INTO role_permissions (role_id, permission_id) VALUES (?, ?) ON CONFLICT DO NOTHING", roleID, permissionID)
+			if result.Error != nil {
+				return result.Error
+			}
+			assigned += int(result.RowsAffected)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, []error{err}
+	}
+	return assigned, errs
+}
+
+// RemovePermissionFromRoles revokes a permission from each of the given roles
+// in a single transaction. Roles without the permission are skipped and roles
+// that do not exist, or the admin role losing admin.access, are reported in errs
+// as *RolePermissionError. Any other error in errs means nothing was removed.
+func (s *RBACService) RemovePermissionFromRoles(ctx context.Context, permissionID string, roleIDs []string) (removed int, errs []error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var permission models.Permission
+		if err := tx.Where("id = ?", permissionID).First(&permission).Error; err != nil {
+			return err
+		}
+
+		for _, roleID := range roleIDs {
+			var role models.Role
+			if err := tx.Where("id = ?", roleID).Limit(1).Find(&role).Error; err != nil {
+				return err
+			}
+			if role.ID == "" {
+				errs = append(errs, &RolePermissionError{RoleID: roleID, Reason: "not found"})
+				continue
+			}
+			if role.Name == "admin" && permission.Name == "admin.access" {
+				errs = append(errs, &RolePermissionError{RoleID: roleID, Reason: "is the admin role and must keep admin.access"})
+				continue
+			}
+
+			result := tx.Exec("DELETE FROM role_permissions WHERE role_id = ? AND permission_id = ?", roleID, permissionID)
+			if result.Error != nil {
+				return result.Error
+			}
+			removed += int(result.RowsAffected)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, []error{err}
+	}
+	return removed, errs
 }
 
 // RemovePermissionFromRole removes a permission from a role
-func (s *RBACService) RemovePermissionFromRole(roleID, permissionID string) error {
+func (s *RBACService) RemovePermissionFromRole(ctx context.Context, roleID, permissionID string) error {
 	// Prevent removing critical permissions from admin role
 	var role models.Role
-	if err := s.db.Where("id = ?", roleID).First(&role).Error; err != nil {
+	if err := s.db.WithContext(ctx).Where("id = ?", roleID).First(&role).Error; err != nil {
 		return errors.New("role not found")
 	}
 
 	if role.Name == "admin" {
 		var permission models.Permission
-		if err := s.db.Where("id = ?", permissionID).First(&permission).Error; err == nil {
+		if err := s.db.WithContext(ctx).Where("id = ?", permissionID).First(&permission).Error; err == nil {
 			if permission.Name == "admin.access" {
 				return errors.New("cannot remove admin.access permission from admin role")
 			}
 		}
 	}
 
-	result := s.db.Exec("DELETE FROM role_permissions WHERE role_id = ? AND permission_id = ?", roleID, permissionID)
+	result := s.db.WithContext(ctx).Exec("DELETE FROM role_permissions WHERE role_id = ? AND permission_id = ?", roleID, permissionID)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -0,0 +1,348 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrInvalidRBACImport is returned when an RBAC import is missing required
+// fields
+var ErrInvalidRBACImport = errors.New("invalid RBAC import")
+
+// RBACExportPermission is a permission in an RBAC export. Permissions are
+// matched by name on import; the category is referenced by name too.
+type RBACExportPermission struct {
+	Name        string
+	Resource    string
+	Action      string
+	Description *string
+	Category    *string
+	Metadata    models.PermissionMetadata
+}
+
+// RBACExportRole is a role in an RBAC export with its permissions embedded
+type RBACExportRole struct {
+	Name                   string
+	Description            *string
+	UseWildcardPermissions bool
+	Permissions            []RBACExportPermission
+}
+
+// RBACExport is the complete role and permission configuration. Permissions
+// lists every permission, including those no role has.
+type RBACExport struct {
+	Roles       []RBACExportRole
+	Permissions []RBACExportPermission
+}
+
+// RBACImportSummary counts the roles and permissions an import created or
+// changed. Records that already matched the import are not counted.
+type RBACImportSummary struct {
+	RolesCreated       int
+	RolesUpdated       int
+	PermissionsCreated int
+	PermissionsUpdated int
+}
+
+// Validate checks that every role and permission has the fields the database
+// requires
+func (e *RBACExport) Validate() error {
+	validatePermission := func(p RBACExportPermission, position string) error {
+		if p.Name == "" || p.Resource == "" || p.Action == "" {
+			return fmt.Errorf("%w: %s: name, resource and action are required", ErrInvalidRBACImport, position)
+		}
+		return nil
+	}
+
+	for i, permission := range e.Permissions {
+		if err := validatePermission(permission, fmt.Sprintf("permission %d", i+1)); err != nil {
+			return err
+		}
+	}
+	for i, role := range e.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("%w: role %d: name is required", ErrInvalidRBACImport, i+1)
+		}
+		for j, permission := range role.Permissions {
+			if err := validatePermission(permission, fmt.Sprintf("role %s permission %d", role.Name, j+1)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type RBACExportService struct {
+	db *gorm.DB
+}
+
+func NewRBACExportService() *RBACExportService {
+	return &RBACExportService{db: database.DB}
+}
+
+// Export returns every role with its permissions and every permission,
+// ordered by name
+func (s *RBACExportService) Export() (*RBACExport, error) {
+	var permissions []models.Permission
+	if err := s.db.Preload("Category").Order("name").Find(&permissions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch permissions: %w", err)
+	}
+
+	var roles []models.Role
+	err := s.db.Preload("Permissions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("permissions.name")
+	}).Preload("Permissions.Category").Order("name").Find(&roles).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch roles: %w", err)
+	}
+
+	export := &RBACExport{
+		Roles:       make([]RBACExportRole, 0, len(roles)),
+		Permissions: make([]RBACExportPermission, 0, len(permissions)),
+	}
+	for _, permission := range permissions {
+		export.Permissions = append(export.Permissions, toRBACExportPermission(permission))
+	}
+	for _, role := range roles {
+		exported := RBACExportRole{
+			Name:                   role.Name,
+			Description:            role.Description,
+			UseWildcardPermissions: role.UseWildcardPermissions,
+			Permissions:            make([]RBACExportPermission, 0, len(role.Permissions)),
+		}
+		for _, permission := range role.Permissions {
+			exported.Permissions = append(exported.Permissions, toRBACExportPermission(permission))
+		}
+		export.Roles = append(export.Roles, exported)
+	}
+	return export, nil
+}
+
+func toRBACExportPermission(permission models.Permission) RBACExportPermission {
+	exported := RBACExportPermission{
+		Name:        permission.Name,
+		Resource:    permission.Resource,
+		Action:      permission.Action,
+		Description: permission.Description,
+		Metadata:    permission.Metadata,
+	}
+	if permission.Category != nil {
+		exported.Category = &permission.Category.Name
+	}
+	return exported
+}
+
+// Import creates or updates the roles and permissions in export in one
+// transaction, matching them by name. Each imported role ends up with
+// exactly the permissions embedded in it. Categories that do not exist yet
+// are created. Roles and permissions missing from export are left alone.
+func (s *RBACExportService) Import(export *RBACExport) (*RBACImportSummary, error) {
+	if err := export.Validate(); err != nil {
+		return nil, err
+	}
+
+	summary := &RBACImportSummary{}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		importer := rbacImporter{
+			tx:          tx,
+			summary:     summary,
+			categoryIDs: make(map[string]string),
+			permissions: make(map[string]string),
+		}
+
+		for _, permission := range export.Permissions {
+			if _, err := importer.permission(permission); err != nil {
+				return err
+			}
+		}
+		for _, role := range export.Roles {
+			if err := importer.role(role); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// rbacImporter upserts the records of one import inside its transaction
+type rbacImporter struct {
+	tx      *gorm.DB
+	summary *RBACImportSummary
+	// categoryIDs caches category IDs by name
+	categoryIDs map[string]string
+	// permissions maps the names of permissions already imported to their
+	// IDs, so a permission listed more than once is only applied the first
+	// time
+	permissions map[string]string
+}
+
+// categoryID returns the ID of the named category, creating it if needed
+func (i *rbacImporter) categoryID(name *string) (*string, error) {
+	if name == nil || *name == "" {
+		return nil, nil
+	}
+	if id, ok := i.categoryIDs[*name]; ok {
+		return &id, nil
+	}
+
+	var category models.PermissionCategory
+	if err := i.tx.Where("name = ?", *name).Limit(1).Find(&category).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch category %s: %w", *name, err)
+	}
+	if category.ID == "" {
+		category.Name = *name
+		if err := i.tx.Create(&category).Error; err != nil {
+			return nil, fmt.Errorf("failed to create category %s: %w", *name, err)
+		}
+	}
+	i.categoryIDs[*name] = category.ID
+	return &category.ID, nil
+}
+
+// permission creates or updates p and returns its ID
+func (i *rbacImporter) permission(p RBACExportPermission) (string, error) {
+	if id, ok := i.permissions[p.Name]; ok {
+		return id, nil
+	}
+
+	categoryID, err := i.categoryID(p.Category)
+	if err != nil {
+		return "", err
+	}
+	metadata := p.Metadata
+	if metadata == nil {
+		metadata = models.PermissionMetadata{}
+	}
+
+	var existing models.Permission
+	if err := i.tx.Where("name = ?", p.Name).Limit(1).Find(&existing).Error; err != nil {
+		return "", fmt.Errorf("failed to fetch permission %s: %w", p.Name, err)
+	}
+
+	if existing.ID == "" {
+		permission := models.Permission{
+			Name:        p.Name,
+			Resource:    p.Resource,
+			Action:      p.Action,
+			Description: p.Description,
+			CategoryID:  categoryID,
+			Metadata:    metadata,
+		}
+		if err := i.tx.Create(&permission).Error; err != nil {
+			return "", fmt.Errorf("failed to create permission %s: %w", p.Name, err)
+		}
+		i.summary.PermissionsCreated++
+		i.permissions[p.Name] = permission.ID
+		return permission.ID, nil
+	}
+
+	changed := existing.Resource != p.Resource || existing.Action != p.Action ||
+		!equalStringPtr(existing.Description, p.Description) ||
+		!equalStringPtr(existing.CategoryID, categoryID) ||
+		!equalMetadata(existing.Metadata, metadata)
+	if changed {
+		err := i.tx.Model(&existing).Updates(map[string]interface{}{
+			"resource":    p.Resource,
+			"action":      p.Action,
+			"description": p.Description,
+			"category_id": categoryID,
+			"metadata":    metadata,
+		}).Error
+		if err != nil {
+			return "", fmt.Errorf("failed to update permission %s: %w", p.Name, err)
+		}
+		i.summary.PermissionsUpdated++
+	}
+	i.permissions[p.Name] = existing.ID
+	return existing.ID, nil
+}
+
+// role creates or updates r and replaces its permissions with the embedded
+// ones
+func (i *rbacImporter) role(r RBACExportRole) error {
+	permissionIDs := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		id, err := i.permission(permission)
+		if err != nil {
+			return err
+		}
+		permissionIDs = append(permissionIDs, id)
+	}
+	sort.Strings(permissionIDs)
+
+	var existing models.Role
+	if err := i.tx.Preload("Permissions").Where("name = ?", r.Name).Limit(1).Find(&existing).Error; err != nil {
+		return fmt.Errorf("failed to fetch role %s: %w", r.Name, err)
+	}
+
+	roleID := existing.ID
+	if roleID == "" {
+		role := models.Role{
+			Name:                   r.Name,
+			Description:            r.Description,
+			UseWildcardPermissions: r.UseWildcardPermissions,
+		}
+		if err := i.tx.Create(&role).Error; err != nil {
+			return fmt.Errorf("failed to create role %s: %w", r.Name, err)
+		}
+		i.summary.RolesCreated++
+		roleID = role.ID
+	} else {
+		currentIDs := make([]string, 0, len(existing.Permissions))
+		for _, permission := range existing.Permissions {
+			currentIDs = append(currentIDs, permission.ID)
+		}
+		sort.Strings(currentIDs)
+
+		if equalStringPtr(existing.Description, r.Description) &&
+			existing.UseWildcardPermissions == r.UseWildcardPermissions &&
+			reflect.DeepEqual(currentIDs, permissionIDs) {
+			return nil
+		}
+
+		err := i.tx.Model(&existing).Updates(map[string]interface{}{
+			"description":              r.Description,
+			"use_wildcard_permissions": r.UseWildcardPermissions,
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update role %s: %w", r.Name, err)
+		}
+		if err := i.tx.Exec("DELETE FROM role_permissions WHERE role_id = ?", roleID).Error; err != nil {
+			return fmt.Errorf("failed to clear permissions of role %s: %w", r.Name, err)
+		}
+		i.summary.RolesUpdated++
+	}
+
+	for _, permissionID := range permissionIDs {
+		err := i.tx.Exec("INSERT INTO role_permissions (role_id, permission_id) VALUES (?, ?) ON CONFLICT DO NOTHING", roleID, permissionID).Error
+		if err != nil {
+			return fmt.Errorf("failed to assign permissions to role %s: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// equalMetadata compares metadata by content, treating nil and empty as equal
+func equalMetadata(a, b models.PermissionMetadata) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
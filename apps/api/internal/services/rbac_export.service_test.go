@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"api/internal/models"
+)
+
+func TestRBACExportValidate(t *testing.T) {
+	permission := RBACExportPermission{Name: "users.read", Resource: "users", Action: "read"}
+
+	tests := map[string]struct {
+		export  RBACExport
+		wantErr string
+	}{
+		"valid": {export: RBACExport{
+			Permissions: []RBACExportPermission{permission},
+			Roles:       []RBACExportRole{{Name: "viewer", Permissions: []RBACExportPermission{permission}}},
+		}},
+		"permission missing resource": {
+			export:  RBACExport{Permissions: []RBACExportPermission{{Name: "users.read", Action: "read"}}},
+			wantErr: "permission 1",
+		},
+		"role missing name": {
+			export:  RBACExport{Roles: []RBACExportRole{{}}},
+			wantErr: "role 1",
+		},
+		"embedded permission missing action": {
+			export:  RBACExport{Roles: []RBACExportRole{{Name: "viewer", Permissions: []RBACExportPermission{{Name: "users.read", Resource: "users"}}}}},
+			wantErr: "role viewer permission 1",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.export.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidRBACImport) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEqualMetadata(t *testing.T) {
+	if !equalMetadata(nil, models.PermissionMetadata{}) {
+		t.Error("nil and empty metadata should be equal")
+	}
+	if !equalMetadata(models.PermissionMetadata{"dangerous": true}, models.PermissionMetadata{"dangerous": true}) {
+		t.Error("identical metadata should be equal")
+	}
+	if equalMetadata(models.PermissionMetadata{"dangerous": true}, models.PermissionMetadata{"dangerous": false}) {
+		t.Error("different metadata should not be equal")
+	}
+	if equalMetadata(nil, models.PermissionMetadata{"icon": "trash"}) {
+		t.Error("empty and non-empty metadata should not be equal")
+	}
+}
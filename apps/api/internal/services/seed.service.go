@@ -0,0 +1,186 @@
+package services
+
+import (
+	"fmt"
+	"os"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// DefaultSeedFile is loaded by the seed command when no file is given
+const DefaultSeedFile = "seeds/default.yaml"
+
+// SeedCategory describes a permission category in a seed file
+type SeedCategory struct {
+	Name        string  `yaml:"name"`
+	Description *string `yaml:"description"`
+}
+
+// SeedPermission describes a permission in a seed file and the name of its
+// category, if any
+type SeedPermission struct {
+	Name        string  `yaml:"name"`
+	Resource    string  `yaml:"resource"`
+	Action      string  `yaml:"action"`
+	Description *string `yaml:"description"`
+	Category    string  `yaml:"category"`
+}
+
+// SeedRole describes a role in a seed file and the names of its permissions
+type SeedRole struct {
+	Name        string   `yaml:"name"`
+	Description *string  `yaml:"description"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// SeedData is the contents of a seed file
+type SeedData struct {
+	Categories  []SeedCategory   `yaml:"categories"`
+	Roles       []SeedRole       `yaml:"roles"`
+	Permissions []SeedPermission `yaml:"permissions"`
+}
+
+// SeedSummary counts the records a seed run created
+type SeedSummary struct {
+	Categories  int
+	Roles       int
+	Permissions int
+	Assignments int
+}
+
+func (s SeedSummary) String() string {
+	return fmt.Sprintf("created %d categories, %d roles, %d permissions, %d assignments", s.Categories, s.Roles, s.Permissions, s.Assignments)
+}
+
+// LoadSeedFile reads and validates a YAML seed file
+func LoadSeedFile(path string) (*SeedData, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed file: %w", err)
+	}
+
+	var data SeedData
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file: %w", err)
+	}
+
+	if err := data.Validate(); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// Validate checks that every category, role and permission has the fields the
+// database requires
+func (d *SeedData) Validate() error {
+	for i, category := range d.Categories {
+		if category.Name == "" {
+			return fmt.Errorf("category %d: name is required", i+1)
+		}
+	}
+	for i, permission := range d.Permissions {
+		if permission.Name == "" || permission.Resource == "" || permission.Action == "" {
+			return fmt.Errorf("permission %d: name, resource and action are required", i+1)
+		}
+	}
+	for i, role := range d.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("role %d: name is required", i+1)
+		}
+	}
+	return nil
+}
+
+type SeedService struct {
+	db *gorm.DB
+}
+
+func NewSeedService() *SeedService {
+	return &SeedService{db: database.DB}
+}
+
+// Seed creates the permission categories, then the permissions, then the
+// roles, then the role permission assignments in data in one transaction.
+// Records that already exist are left unchanged, so seeding the same data
+// again creates nothing.
+func (s *SeedService) Seed(data *SeedData) (*SeedSummary, error) {
+	summary := &SeedSummary{}
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range data.Categories {
+			category := models.PermissionCategory{
+				Name:        c.Name,
+				Description: c.Description,
+			}
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&category)
+			if result.Error != nil {
+				return fmt.Errorf("failed to seed category %s: %w", c.Name, result.Error)
+			}
+			summary.Categories += int(result.RowsAffected)
+		}
+
+		for _, p := range data.Permissions {
+			permission := models.Permission{
+				Name:        p.Name,
+				Resource:    p.Resource,
+				Action:      p.Action,
+				Description: p.Description,
+			}
+			if p.Category != "" {
+				var category models.PermissionCategory
+				if err := tx.Where("name = ?", p.Category).Limit(1).Find(&category).Error; err != nil {
+					return err
+				}
+				if category.ID == "" {
+					return fmt.Errorf("permission %s references unknown category %s", p.Name, p.Category)
+				}
+				permission.CategoryID = &category.ID
+			}
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&permission)
+			if result.Error != nil {
+				return fmt.Errorf("failed to seed permission %s: %w", p.Name, result.Error)
+			}
+			summary.Permissions += int(result.RowsAffected)
+		}
+
+		for _, r := range data.Roles {
+			role := models.Role{
+				Name:        r.Name,
+				Description: r.Description,
+			}
+			result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&role)
+			if result.Error != nil {
+				return fmt.Errorf("failed to seed role %s: %w", r.Name, result.Error)
+			}
+			summary.Roles += int(result.RowsAffected)
+
+			for _, permissionName := range r.Permissions {
+				var count int64
+				if err := tx.Model(&models.Permission{}).Where("name = ?", permissionName).Count(&count).Error; err != nil {
+					return err
+				}
+				if count == 0 {
+					return fmt.Errorf("role %s references unknown permission %s", r.Name, permissionName)
+				}
+
+				result := tx.Exec(`INSERT INTO role_permissions (role_id, permission_id)
+					SELECT roles.id, permissions.id FROM roles, permissions
+					WHERE roles.name = ? AND permissions.name = ?
+					ON CONFLICT DO NOTHING`, r.Name, permissionName)
+				if result.Error != nil {
+					return fmt.Errorf("failed to assign permission %s to role %s: %w", permissionName, r.Name, result.Error)
+				}
+				summary.Assignments += int(result.RowsAffected)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
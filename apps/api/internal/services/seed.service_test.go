@@ -0,0 +1,78 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSeedFileDefault(t *testing.T) {
+	data, err := LoadSeedFile(filepath.Join("..", "..", DefaultSeedFile))
+	if err != nil {
+		t.Fatalf("LoadSeedFile() error = %v", err)
+	}
+
+	categories := make(map[string]bool)
+	for _, category := range data.Categories {
+		categories[category.Name] = true
+	}
+
+	declared := make(map[string]bool)
+	for _, permission := range data.Permissions {
+		declared[permission.Name] = true
+		if permission.Category != "" && !categories[permission.Category] {
+			t.Errorf("permission %s references undeclared category %s", permission.Name, permission.Category)
+		}
+	}
+	for _, role := range data.Roles {
+		for _, name := range role.Permissions {
+			if !declared[name] {
+				t.Errorf("role %s references undeclared permission %s", role.Name, name)
+			}
+		}
+	}
+	if len(data.Roles) == 0 || len(data.Permissions) == 0 {
+		t.Errorf("default seed has %d roles and %d permissions, want some of each", len(data.Roles), len(data.Permissions))
+	}
+}
+
+func TestLoadSeedFileValidation(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		wantErr string
+	}{
+		"invalid yaml":       {content: "roles: [", wantErr: "failed to parse"},
+		"permission missing": {content: "permissions:\n  - name: a.read\n    resource: a\n", wantErr: "permission 1"},
+		"role missing name":  {content: "roles:\n  - description: nameless\n", wantErr: "role 1"},
+		"category missing":   {content: "categories:\n  - description: nameless\n", wantErr: "category 1"},
+		"valid":              {content: "permissions:\n  - {name: a.read, resource: a, action: read}\nroles:\n  - {name: a, permissions: [a.read]}\n"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "seed.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadSeedFile(path)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LoadSeedFile() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadSeedFile() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeedSummaryString(t *testing.T) {
+	summary := SeedSummary{Categories: 4, Roles: 1, Permissions: 2, Assignments: 3}
+	if got, want := summary.String(), "created 4 categories, 1 roles, 2 permissions, 3 assignments"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
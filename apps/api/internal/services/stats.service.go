@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+const (
+	// StatsCacheTTL is how long computed admin statistics are reused
+	StatsCacheTTL = 60 * time.Second
+	// ActiveUserWindow is how recently a user must have logged in to count as active
+	ActiveUserWindow = 30 * 24 * time.Hour
+)
+
+// AdminStats is a point-in-time summary of the system
+type AdminStats struct {
+	TotalUsers          int64
+	ActiveUsers         int64
+	UsersToday          int64
+	TotalRoles          int64
+	TotalPermissions    int64
+	TotalEmailTemplates int64
+	// ActiveAnnouncements counts the announcements users currently see
+	ActiveAnnouncements int64
+	// EmailQueueDepth is nil until the email queue has been started
+	EmailQueueDepth   *int
+	DBConnectionsOpen int
+	DBConnectionsIdle int
+	GeneratedAt       time.Time
+}
+
+var statsCache struct {
+	sync.Mutex
+	stats     *AdminStats
+	expiresAt time.Time
+}
+
+type StatsService struct {
+	db *gorm.DB
+}
+
+func NewStatsService() *StatsService {
+	return &StatsService{
+		db: database.DB,
+	}
+}
+
+// GetStats returns the admin statistics, computing them at most once per
+// StatsCacheTTL
+func (s *StatsService) GetStats(ctx context.Context) (*AdminStats, error) {
+	statsCache.Lock()
+	defer statsCache.Unlock()
+
+	now := time.Now()
+	if statsCache.stats != nil && now.Before(statsCache.expiresAt) {
+		stats := *statsCache.stats
+		return &stats, nil
+	}
+
+	stats, err := s.computeStats(ctx, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	statsCache.stats = stats
+	statsCache.expiresAt = now.Add(StatsCacheTTL)
+	result := *stats
+	return &result, nil
+}
+
+// InvalidateStatsCache makes the next GetStats call recompute the statistics
+func InvalidateStatsCache() {
+	statsCache.Lock()
+	statsCache.stats = nil
+	statsCache.Unlock()
+}
+
+func (s *StatsService) computeStats(ctx context.Context, now time.Time) (*AdminStats, error) {
+	db := s.db.WithContext(ctx)
+	stats := &AdminStats{GeneratedAt: now}
+	startOfDay := now.Truncate(24 * time.Hour)
+
+	counts := []struct {
+		target *int64
+		query  *gorm.DB
+	}{
+		{&stats.TotalUsers, db.Model(&models.User{})},
+		{&stats.ActiveUsers, db.Model(&models.User{}).Where("last_login_at >= ?", now.Add(-ActiveUserWindow))},
+		{&stats.UsersToday, db.Model(&models.User{}).Where("created_at >= ?", startOfDay)},
+		{&stats.TotalRoles, db.Model(&models.Role{})},
+		{&stats.TotalPermissions, db.Model(&models.Permission{})},
+		{&stats.TotalEmailTemplates, db.Model(&models.EmailTemplate{})},
+		{&stats.ActiveAnnouncements, activeAnnouncements(db.Model(&models.Announcement{}), now)},
+	}
+	for _, count := range counts {
+		if err := count.query.Count(count.target).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	if emailQueue != nil {
+		depth := emailQueue.Stats().Depth
+		stats.EmailQueueDepth = &depth
+	}
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	dbStats := sqlDB.Stats()
+	stats.DBConnectionsOpen = dbStats.OpenConnections
+	stats.DBConnectionsIdle = dbStats.Idle
+
+	return stats, nil
+}
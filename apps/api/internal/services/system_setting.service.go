@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettingDefaultUserRole stores the role assigned to newly registered users.
+// An empty value assigns no role.
+const SettingDefaultUserRole = "default_user_role"
+
+// DefaultUserRole is assigned to new users when neither the stored setting
+// nor DEFAULT_USER_ROLE is set
+const DefaultUserRole = "user"
+
+// ErrDefaultRoleNotFound is returned when the default user role names a role
+// that does not exist
+var ErrDefaultRoleNotFound = errors.New("default user role not found")
+
+type SystemSettingService struct {
+	db *gorm.DB
+}
+
+func NewSystemSettingService() *SystemSettingService {
+	return &SystemSettingService{
+		db: database.DB,
+	}
+}
+
+// GetSetting returns the value stored under key and whether it is set
+func (s *SystemSettingService) GetSetting(ctx context.Context, key string) (string, bool, error) {
+	var setting models.SystemSetting
+	if err := s.db.WithContext(ctx).Where("key = ?", key).First(&setting).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return setting.Value, true, nil
+}
+
+// SetSetting stores value under key, replacing any previous value
+func (s *SystemSettingService) SetSetting(ctx context.Context, key, value string) error {
+	setting := models.SystemSetting{Key: key, Value: value}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).Create(&setting).Error
+}
+
+// GetDefaultUserRole returns the role assigned to newly registered users: the
+// stored setting once an admin has set it, otherwise DEFAULT_USER_ROLE. An
+// empty role means new users start without one.
+func (s *SystemSettingService) GetDefaultUserRole(ctx context.Context) (string, error) {
+	role, ok, err := s.GetSetting(ctx, SettingDefaultUserRole)
+	if err != nil || ok {
+		return role, err
+	}
+	return helpers.GetEnv("DEFAULT_USER_ROLE", DefaultUserRole), nil
+}
+
+// SetDefaultUserRole changes the role assigned to newly registered users. It
+// returns ErrDefaultRoleNotFound when the role does not exist.
+func (s *SystemSettingService) SetDefaultUserRole(ctx context.Context, role string) error {
+	if err := s.roleExists(ctx, role); err != nil {
+		return err
+	}
+	return s.SetSetting(ctx, SettingDefaultUserRole, role)
+}
+
+// ValidateDefaultUserRole checks that the current default user role exists
+// and returns it. The server calls it at startup.
+func (s *SystemSettingService) ValidateDefaultUserRole(ctx context.Context) (string, error) {
+	role, err := s.GetDefaultUserRole(ctx)
+	if err != nil {
+		return "", err
+	}
+	return role, s.roleExists(ctx, role)
+}
+
+// roleExists returns ErrDefaultRoleNotFound unless role is empty or names an
+// existing role
+func (s *SystemSettingService) roleExists(ctx context.Context, role string) error {
+	if role == "" {
+		return nil
+	}
+
+	var count int64
+	if err := s.db.WithContext(ctx).Model(&models.Role{}).Where("name = ?", role).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return fmt.Errorf("%w: %s", ErrDefaultRoleNotFound, role)
+	}
+	return nil
+}
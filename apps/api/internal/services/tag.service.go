@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrTagNotFound is returned when a tag does not exist
+var ErrTagNotFound = errors.New("tag not found")
+
+type TagService struct {
+	db *gorm.DB
+}
+
+func NewTagService() *TagService {
+	return &TagService{
+		db: database.DB,
+	}
+}
+
+// ListTags returns all tags ordered by name
+func (s *TagService) ListTags() ([]models.Tag, error) {
+	var tags []models.Tag
+	err := s.db.Order("name ASC").Find(&tags).Error
+	return tags, err
+}
+
+// GetTag returns a tag by ID
+func (s *TagService) GetTag(id string) (*models.Tag, error) {
+	var tag models.Tag
+	if err := s.db.Where("id = ?", id).First(&tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// CreateTag stores a tag
+func (s *TagService) CreateTag(tag *models.Tag) error {
+	return s.db.Create(tag).Error
+}
+
+// DeleteTag removes a tag, untagging every user that had it
+func (s *TagService) DeleteTag(id string) error {
+	result := s.db.Where("id = ?", id).Delete(&models.Tag{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// SetUserTags replaces the user's tags with the tags named tagNames. It
+// returns an error wrapping ErrTagNotFound, and leaves the tags unchanged,
+// when any of the names is not a tag.
+func (s *TagService) SetUserTags(userID string, tagNames []string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var tags []models.Tag
+		if len(tagNames) > 0 {
+			if err := tx.Where("name IN ?", tagNames).Find(&tags).Error; err != nil {
+				return err
+			}
+		}
+
+		found := make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			found[tag.Name] = true
+		}
+		for _, name := range tagNames {
+			if !found[name] {
+				return fmt.Errorf("%w: %s", ErrTagNotFound, name)
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM user_tags WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if err := tx.Exec("INSERT INTO user_tags (user_id, tag_id) VALUES (?, ?)", userID, tag.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNoToSVersion is returned when no terms of service version is in effect
+	ErrNoToSVersion = errors.New("no terms of service version is in effect")
+	// ErrToSVersionMismatch is returned when a user accepts a version other
+	// than the current one
+	ErrToSVersionMismatch = errors.New("terms of service version is not current")
+	// ErrToSVersionExists is returned when publishing a version that already exists
+	ErrToSVersionExists = errors.New("terms of service version already exists")
+)
+
+type ToSService struct {
+	db *gorm.DB
+}
+
+func NewToSService() *ToSService {
+	return &ToSService{
+		db: database.DB,
+	}
+}
+
+// CurrentVersion returns the latest version whose effective date has passed,
+// or ErrNoToSVersion when none has been published yet
+func (s *ToSService) CurrentVersion(ctx context.Context) (*models.ToSVersion, error) {
+	var version models.ToSVersion
+	err := s.db.WithContext(ctx).Where("effective_date <= ?", time.Now()).
+		Order("effective_date DESC, created_at DESC").
+		First(&version).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoToSVersion
+		}
+		return nil, err
+	}
+	return &version, nil
+}
+
+// ListVersions returns every published version, newest first
+func (s *ToSService) ListVersions(ctx context.Context) ([]models.ToSVersion, error) {
+	var versions []models.ToSVersion
+	err := s.db.WithContext(ctx).Order("effective_date DESC, created_at DESC").Find(&versions).Error
+	return versions, err
+}
+
+// PublishVersion stores a new version taking effect at effectiveDate, or
+// immediately when effectiveDate is nil
+func (s *ToSService) PublishVersion(ctx context.Context, version, content string, effectiveDate *time.Time) (*models.ToSVersion, error) {
+	tos := models.ToSVersion{
+		Version:       version,
+		Content:       content,
+		EffectiveDate: time.Now(),
+	}
+	if effectiveDate != nil {
+		tos.EffectiveDate = *effectiveDate
+	}
+
+	var existing int64
+	if err := s.db.WithContext(ctx).Model(&models.ToSVersion{}).Where("version = ?", version).Count(&existing).Error; err != nil {
+		return nil, err
+	}
+	if existing > 0 {
+		return nil, ErrToSVersionExists
+	}
+
+	if err := s.db.WithContext(ctx).Create(&tos).Error; err != nil {
+		return nil, err
+	}
+	return &tos, nil
+}
+
+// AcceptCurrentVersion records userID accepting the current version, which
+// must be the one named by version. Accepting the same version twice keeps
+// the first acceptance.
+func (s *ToSService) AcceptCurrentVersion(ctx context.Context, userID, version, ipAddress string) (*models.ToSVersion, error) {
+	current, err := s.CurrentVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version != version {
+		return current, ErrToSVersionMismatch
+	}
+
+	if err := s.RecordAcceptance(ctx, userID, current.ID, ipAddress); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// RecordAcceptance stores userID accepting the version with tosVersionID
+func (s *ToSService) RecordAcceptance(ctx context.Context, userID, tosVersionID, ipAddress string) error {
+	acceptance := models.UserToSAcceptance{
+		UserID:       userID,
+		ToSVersionID: tosVersionID,
+		AcceptedAt:   time.Now(),
+		IPAddress:    ipAddress,
+	}
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&acceptance).Error
+}
+
+// PendingVersion returns the current version when userID has not accepted
+// it yet, or nil when there is nothing to accept
+func (s *ToSService) PendingVersion(ctx context.Context, userID string) (*models.ToSVersion, error) {
+	current, err := s.CurrentVersion(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNoToSVersion) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var accepted int64
+	err = s.db.WithContext(ctx).Model(&models.UserToSAcceptance{}).
+		Where("user_id = ? AND tos_version_id = ?", userID, current.ID).
+		Count(&accepted).Error
+	if err != nil {
+		return nil, err
+	}
+	if accepted > 0 {
+		return nil, nil
+	}
+	return current, nil
+}
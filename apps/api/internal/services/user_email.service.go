@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"api/internal/auth"
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrUserEmailNotFound is returned for addresses the user does not have
+	ErrUserEmailNotFound = errors.New("email address not found")
+	// ErrUserEmailTaken is returned when the address already belongs to the
+	// user or to another account
+	ErrUserEmailTaken = errors.New("email address already in use")
+	// ErrUserEmailNotVerified is returned when an unverified address is made
+	// primary
+	ErrUserEmailNotVerified = errors.New("email address has not been verified")
+	// ErrPrimaryEmailRemoval is returned when the primary address is removed
+	ErrPrimaryEmailRemoval = errors.New("the primary email address cannot be removed")
+	// ErrInvalidEmailVerification is returned for unknown, expired or already
+	// used verification tokens
+	ErrInvalidEmailVerification = errors.New("invalid email verification token")
+	// ErrEmailVerificationNotSent is returned when the verification email
+	// could not be sent; the address is not added
+	ErrEmailVerificationNotSent = errors.New("verification email could not be sent")
+)
+
+// EmailVerificationExpiration is how long an email verification link can be
+// used after it is sent
+const EmailVerificationExpiration = 24 * time.Hour
+
+type UserEmailService struct {
+	db *gorm.DB
+}
+
+func NewUserEmailService() *UserEmailService {
+	return &UserEmailService{
+		db: database.DB,
+	}
+}
+
+// ListEmails returns a user's email addresses, the primary one first
+func (s *UserEmailService) ListEmails(ctx context.Context, userID string) ([]models.UserEmail, error) {
+	var emails []models.UserEmail
+	err := s.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("is_primary DESC, created_at ASC").
+		Find(&emails).Error
+	return emails, err
+}
+
+// AddEmail adds an unverified address to a user and calls send with it and
+// the plain verification token. Addresses another account logs in with or has
+// verified cannot be added. When send fails nothing is stored and
+// ErrEmailVerificationNotSent is returned.
+func (s *UserEmailService) AddEmail(ctx context.Context, userID, email string, send func(to, token string) error) (*models.UserEmail, error) {
+	email = helpers.NormalizeEmail(email)
+
+	token, hashedToken, err := auth.GenerateResetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(EmailVerificationExpiration)
+	userEmail := models.UserEmail{
+		UserID:                userID,
+		Email:                 email,
+		VerificationTokenHash: &hashedToken,
+		VerificationExpiresAt: &expiresAt,
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var taken int64
+		if err := tx.Model(&models.User{}).Where("email = ? AND id <> ?", email, userID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken == 0 {
+			err := tx.Model(&models.UserEmail{}).
+				Joins("JOIN users ON users.id = user_emails.user_id AND users.deleted_at IS NULL").
+				Where("user_emails.email = ? AND (user_emails.user_id = ? OR user_emails.verified_at IS NOT NULL)", email, userID).
+				Count(&taken).Error
+			if err != nil {
+				return err
+			}
+		}
+		if taken > 0 {
+			return ErrUserEmailTaken
+		}
+
+		if err := tx.Create(&userEmail).Error; err != nil {
+			if helpers.IsDuplicateError(err) {
+				return ErrUserEmailTaken
+			}
+			return err
+		}
+		if err := touchUser(tx, userID); err != nil {
+			return err
+		}
+
+		// Sent inside the transaction so that a failed email leaves no
+		// address behind to block adding it again
+		if err := send(userEmail.Email, token); err != nil {
+			return fmt.Errorf("%w: %v", ErrEmailVerificationNotSent, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userEmail, nil
+}
+
+// VerifyEmail marks the address a verification token was sent to as
+// verified. Each token can be used once, within EmailVerificationExpiration;
+// an expired token is discarded, and the address has to be removed and added
+// again for a new link.
+func (s *UserEmailService) VerifyEmail(ctx context.Context, token string) (*models.UserEmail, error) {
+	var userEmail models.UserEmail
+	expired := false
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("verification_token_hash = ?", auth.HashToken(token)).
+			First(&userEmail).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidEmailVerification
+			}
+			return err
+		}
+
+		if userEmail.IsVerificationExpired() {
+			expired = true
+			return tx.Model(&userEmail).Updates(map[string]interface{}{
+				"verification_token_hash": nil,
+				"verification_expires_at": nil,
+			}).Error
+		}
+
+		now := time.Now()
+		err = tx.Model(&userEmail).Updates(map[string]interface{}{
+			"verified_at":             &now,
+			"verification_token_hash": nil,
+			"verification_expires_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		userEmail.VerifiedAt = &now
+		userEmail.VerificationTokenHash = nil
+		userEmail.VerificationExpiresAt = nil
+		return touchUser(tx, userEmail.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, ErrInvalidEmailVerification
+	}
+
+	return &userEmail, nil
+}
+
+// RemoveEmail deletes one of a user's addresses other than the primary one
+func (s *UserEmailService) RemoveEmail(ctx context.Context, userID, emailID string) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userEmail, err := findUserEmail(tx, userID, emailID)
+		if err != nil {
+			return err
+		}
+		if userEmail.IsPrimary {
+			return ErrPrimaryEmailRemoval
+		}
+
+		if err := tx.Delete(userEmail).Error; err != nil {
+			return err
+		}
+		return touchUser(tx, userID)
+	})
+}
+
+// SetPrimaryEmail makes a verified address the user's primary address, which
+// they log in with from then on. The previous primary address is kept as a
+// verified secondary address.
+func (s *UserEmailService) SetPrimaryEmail(ctx context.Context, userID, emailID string) (*models.UserEmail, error) {
+	var userEmail *models.UserEmail
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		userEmail, err = findUserEmail(tx, userID, emailID)
+		if err != nil {
+			return err
+		}
+		if userEmail.IsPrimary {
+			return nil
+		}
+		if !userEmail.IsVerified() {
+			return ErrUserEmailNotVerified
+		}
+
+		// The database trigger on users.email moves the primary flag
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Update("email", userEmail.Email).Error; err != nil {
+			if helpers.IsDuplicateError(err) {
+				return ErrUserEmailTaken
+			}
+			return err
+		}
+		userEmail.IsPrimary = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return userEmail, nil
+}
+
+func findUserEmail(tx *gorm.DB, userID, emailID string) (*models.UserEmail, error) {
+	var userEmail models.UserEmail
+	if err := tx.Where("id = ? AND user_id = ?", emailID, userID).First(&userEmail).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserEmailNotFound
+		}
+		return nil, err
+	}
+	return &userEmail, nil
+}
+
+// touchUser bumps updated_at on a user whose addresses changed, so cached
+// copies of their profile are revalidated
+func touchUser(tx *gorm.DB, userID string) error {
+	return tx.Model(&models.User{}).Where("id = ?", userID).Update("updated_at", time.Now()).Error
+}
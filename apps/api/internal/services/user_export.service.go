@@ -0,0 +1,232 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"time"
+
+	"api/internal/database"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// userExportBatchSize is the number of users loaded per query while exporting
+const userExportBatchSize = 500
+
+// UserExportColumns are the columns of a CSV user export, in order
+var UserExportColumns = []string{"id", "email", "name", "phone", "company", "roles", "created_at"}
+
+// ExportFilter selects and orders the users in an export. It matches the
+// admin user list, without pagination. Mask, when set, applies the viewer's
+// field masking rules to each UserExportRecord.
+type ExportFilter struct {
+	UserListFilter
+	SortBy   string
+	SortDesc bool
+	Mask     func(record interface{}) interface{}
+}
+
+// UserExportRecord is a user in a JSON export
+type UserExportRecord struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Phone     *string   `json:"phone"`
+	Company   *string   `json:"company"`
+	Roles     []string  `json:"roles"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MaskingModel names the model field masking rules apply to
+func (UserExportRecord) MaskingModel() string {
+	return "user"
+}
+
+type UserExportService struct {
+	db *gorm.DB
+}
+
+// NewUserExportService returns a service reading from database.ReadDB, as
+// exports can be large and tolerate replica lag
+func NewUserExportService() *UserExportService {
+	return &UserExportService{
+		db: database.ReadDB,
+	}
+}
+
+// ExportCSV writes the users matching filter to w as CSV with a header row.
+// Users are loaded and written in batches, so the export is never held in
+// memory as a whole.
+func (s *UserExportService) ExportCSV(w io.Writer, filter ExportFilter) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(UserExportColumns); err != nil {
+		return err
+	}
+
+	err := s.eachBatch(filter, func(users []models.User) error {
+		for _, user := range users {
+			record, err := maskExportRecord(toUserExportRecord(user), filter.Mask)
+			if err != nil {
+				return err
+			}
+			if err := writer.Write(userExportRow(record)); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportJSON writes the users matching filter to w as a JSON array, in
+// batches like ExportCSV
+func (s *UserExportService) ExportJSON(w io.Writer, filter ExportFilter) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	first := true
+	err := s.eachBatch(filter, func(users []models.User) error {
+		for _, user := range users {
+			if !first {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return err
+				}
+			}
+			first = false
+
+			var record interface{} = toUserExportRecord(user)
+			if filter.Mask != nil {
+				record = filter.Mask(record)
+			}
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "]\n")
+	return err
+}
+
+// eachBatch calls fn with successive batches of the users matching filter,
+// with their roles and company loaded. The user ID breaks ties in the sort
+// order so batches neither skip nor repeat users.
+func (s *UserExportService) eachBatch(filter ExportFilter, fn func([]models.User) error) error {
+	orderClause := userListOrder(filter.UserListFilter, filter.SortBy, filter.SortDesc)
+
+	for offset := 0; ; offset += userExportBatchSize {
+		var users []models.User
+		err := filter.apply(s.db.Model(&models.User{})).
+			Select(userListColumns).
+			Preload("Roles").
+			Preload("Company").
+			Order(orderClause).
+			Order("id").
+			Offset(offset).
+			Limit(userExportBatchSize).
+			Find(&users).Error
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+
+		if err := fn(users); err != nil {
+			return err
+		}
+		if len(users) < userExportBatchSize {
+			return nil
+		}
+	}
+}
+
+// maskExportRecord applies mask to record for a CSV row. Hidden fields, and
+// fields that cannot be partly shown, are left empty.
+func maskExportRecord(record UserExportRecord, mask func(interface{}) interface{}) (UserExportRecord, error) {
+	if mask == nil {
+		return record, nil
+	}
+	data, err := json.Marshal(mask(record))
+	if err != nil {
+		return record, err
+	}
+	var masked UserExportRecord
+	err = json.Unmarshal(data, &masked)
+	return masked, err
+}
+
+// csvFormulaPrefixes are the leading characters that make spreadsheets
+// evaluate a cell as a formula
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// csvSafe prefixes a cell that spreadsheets would evaluate as a formula with
+// an apostrophe, so that it is shown as text
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// userExportRow returns the CSV columns of a user. Role names are separated
+// by semicolons, and cells that would be evaluated as formulas are escaped
+// with csvSafe.
+func userExportRow(record UserExportRecord) []string {
+	var phone, company, createdAt string
+	if record.Phone != nil {
+		phone = *record.Phone
+	}
+	if record.Company != nil {
+		company = *record.Company
+	}
+	if !record.CreatedAt.IsZero() {
+		createdAt = record.CreatedAt.Format(time.RFC3339)
+	}
+
+	row := []string{
+		record.ID,
+		record.Email,
+		record.Name,
+		phone,
+		company,
+		strings.Join(record.Roles, ";"),
+		createdAt,
+	}
+	for i, cell := range row {
+		row[i] = csvSafe(cell)
+	}
+	return row
+}
+
+func toUserExportRecord(user models.User) UserExportRecord {
+	record := UserExportRecord{
+		ID:        user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Phone:     user.Phone,
+		Roles:     user.GetRoleNames(),
+		CreatedAt: user.CreatedAt.UTC(),
+	}
+	if user.Company != nil {
+		record.Company = &user.Company.Name
+	}
+	return record
+}
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"api/internal/helpers"
+	"api/internal/models"
+)
+
+func TestUserExportRow(t *testing.T) {
+	phone := "+6281234567890"
+	createdAt := time.Date(2024, 1, 2, 10, 30, 0, 0, time.FixedZone("WIB", 7*60*60))
+
+	tests := []struct {
+		name string
+		user models.User
+		want []string
+	}{
+		{
+			name: "all columns",
+			user: models.User{
+				ID: "user-1", Email: "jane@example.com", Name: "Jane, Doe", Phone: &phone,
+				Company:   &models.Company{Name: "Acme"},
+				Roles:     []models.Role{{Name: "admin"}, {Name: "user"}},
+				CreatedAt: createdAt,
+			},
+			want: []string{"user-1", "jane@example.com", "Jane, Doe", "'" + phone, "Acme", "admin;user", "2024-01-02T03:30:00Z"},
+		},
+		{
+			name: "formulas",
+			user: models.User{
+				ID: "user-3", Email: "@mallory@example.com", Name: `=HYPERLINK("http://example.com","x")`,
+				Company:   &models.Company{Name: "-1+1"},
+				CreatedAt: createdAt,
+			},
+			want: []string{"user-3", "'@mallory@example.com", `'=HYPERLINK("http://example.com","x")`, "", "'-1+1", "", "2024-01-02T03:30:00Z"},
+		},
+		{
+			name: "without optional columns",
+			user: models.User{ID: "user-2", Email: "john@example.com", Name: "John", CreatedAt: createdAt},
+			want: []string{"user-2", "john@example.com", "John", "", "", "", "2024-01-02T03:30:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userExportRow(toUserExportRecord(tt.user))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("userExportRow() = %q, want %q", got, tt.want)
+			}
+			if len(got) != len(UserExportColumns) {
+				t.Errorf("row has %d columns, header has %d", len(got), len(UserExportColumns))
+			}
+		})
+	}
+}
+
+func TestMaskExportRecord(t *testing.T) {
+	phone := "+6281234561234"
+	record := UserExportRecord{
+		ID: "user-1", Email: "jane@example.com", Name: "Jane", Phone: &phone,
+		Roles: []string{"user"}, CreatedAt: time.Date(2024, 1, 2, 3, 30, 0, 0, time.UTC),
+	}
+	rules := []models.FieldMaskingRule{
+		{RoleName: "support", Model: "user", Field: "phone", MaskType: models.MaskTypePartial},
+		{RoleName: "support", Model: "user", Field: "name", MaskType: models.MaskTypeHidden},
+		{RoleName: "support", Model: "user", Field: "created_at", MaskType: models.MaskTypeFull},
+	}
+	mask := func(record interface{}) interface{} {
+		return helpers.MaskResponse(record, []string{"support"}, rules)
+	}
+
+	masked, err := maskExportRecord(record, mask)
+	if err != nil {
+		t.Fatalf("maskExportRecord() error = %v", err)
+	}
+	want := []string{"user-1", "jane@example.com", "", "****1234", "", "user", ""}
+	if got := userExportRow(masked); !reflect.DeepEqual(got, want) {
+		t.Errorf("userExportRow() = %q, want %q", got, want)
+	}
+
+	unmasked, err := maskExportRecord(record, nil)
+	if err != nil {
+		t.Fatalf("maskExportRecord() error = %v", err)
+	}
+	if !reflect.DeepEqual(unmasked, record) {
+		t.Errorf("maskExportRecord() without a mask = %+v, want %+v", unmasked, record)
+	}
+}
@@ -0,0 +1,314 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"api/internal/auth"
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/models"
+	"api/internal/pkg/phonenumbers"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// MaxUserImportRows limits the number of data rows accepted in one import
+const MaxUserImportRows = 1000
+
+// userImportRoleSeparator splits multiple roles within the roles column
+const userImportRoleSeparator = ";"
+
+var importValidator = validator.New()
+
+// ErrInvalidUserImport is returned when the CSV as a whole cannot be processed
+var ErrInvalidUserImport = errors.New("invalid user import file")
+
+// UserImportFailure describes a row that could not be imported. Row numbers
+// count the header as row 1, matching spreadsheet line numbers.
+type UserImportFailure struct {
+	Row   int
+	Email string
+	Error string
+}
+
+// UserImportResult summarizes a CSV import. UserIDs lists the imported users
+// in file order.
+type UserImportResult struct {
+	Total    int
+	Imported int
+	Skipped  int
+	Failures []UserImportFailure
+	UserIDs  []string
+}
+
+// userImportRow is a validated CSV row ready to be inserted
+type userImportRow struct {
+	Row      int
+	Email    string
+	Name     string
+	Phone    *string
+	Company  *string
+	Roles    []string
+	Password string
+}
+
+type UserImportService struct {
+	db *gorm.DB
+}
+
+func NewUserImportService() *UserImportService {
+	return &UserImportService{
+		db: database.DB,
+	}
+}
+
+// Import creates users from a CSV with the columns email, name, phone, company,
+// roles and an optional password. Companies are matched by name and created
+// when missing. Rows without roles get the default user role. Users whose
+// email already exists are skipped, so the same file can be imported again
+// safely. Invalid rows are reported without stopping the import.
+func (s *UserImportService) Import(r io.Reader, grantedBy *string) (*UserImportResult, error) {
+	rows, failures, err := parseUserImportCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultRoles []string
+	defaultRole, err := (&SystemSettingService{db: s.db}).GetDefaultUserRole(s.db.Statement.Context)
+	if err != nil {
+		return nil, err
+	}
+	if defaultRole != "" {
+		defaultRoles = []string{defaultRole}
+	}
+
+	result := &UserImportResult{
+		Total:    len(rows) + len(failures),
+		Failures: failures,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			var existing int64
+			if err := tx.Model(&models.User{}).Unscoped().Where("email = ?", row.Email).Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing > 0 {
+				result.Skipped++
+				continue
+			}
+
+			if len(row.Roles) == 0 {
+				row.Roles = defaultRoles
+			}
+
+			// Each row runs in a savepoint so a failed row does not undo the others
+			var userID string
+			err := tx.Transaction(func(rowTx *gorm.DB) error {
+				var err error
+				userID, err = createImportedUser(rowTx, row, grantedBy)
+				return err
+			})
+			if err != nil {
+				result.Failures = append(result.Failures, UserImportFailure{
+					Row:   row.Row,
+					Email: row.Email,
+					Error: err.Error(),
+				})
+				continue
+			}
+			result.Imported++
+			result.UserIDs = append(result.UserIDs, userID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createImportedUser creates the user of a row and returns its ID
+func createImportedUser(tx *gorm.DB, row userImportRow, grantedBy *string) (string, error) {
+	password := row.Password
+	if password == "" {
+		generated, err := generateImportPassword()
+		if err != nil {
+			return "", err
+		}
+		password = generated
+	}
+
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	user := models.User{
+		Email:    row.Email,
+		Password: hashedPassword,
+		Name:     row.Name,
+		Phone:    row.Phone,
+	}
+	if row.Company != nil {
+		company, err := NewCompanyServiceWithDB(tx).FindOrCreateCompany(tx.Statement.Context, *row.Company)
+		if err != nil {
+			return "", err
+		}
+		user.CompanyID = &company.ID
+	}
+	if err := tx.Create(&user).Error; err != nil {
+		return "", err
+	}
+
+	rbacService := &RBACService{db: tx}
+	return user.ID, rbacService.SetUserRoles(tx.Statement.Context, user.ID, row.Roles, grantedBy, nil)
+}
+
+// generateImportPassword creates a random password for users imported without
+// one; they are expected to set their own through the password reset flow
+func generateImportPassword() (string, error) {
+	bytes := make([]byte, 18)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// parseUserImportCSV reads and validates the CSV. Rows that fail validation
+// are returned as failures; duplicate emails within the file after the first
+// occurrence are dropped.
+func parseUserImportCSV(r io.Reader) ([]userImportRow, []UserImportFailure, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("%w: CSV file is empty", ErrInvalidUserImport)
+		}
+		return nil, nil, fmt.Errorf("%w: unreadable CSV header", ErrInvalidUserImport)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"email", "name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: missing required column %s", ErrInvalidUserImport, required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return unescapeCSVFormula(strings.TrimSpace(record[i]))
+	}
+
+	var rows []userImportRow
+	var failures []UserImportFailure
+	seen := make(map[string]bool)
+	rowNumber := 1
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		rowNumber++
+		if len(rows)+len(failures) >= MaxUserImportRows {
+			return nil, nil, fmt.Errorf("%w: more than %d rows", ErrInvalidUserImport, MaxUserImportRows)
+		}
+		if err != nil {
+			failures = append(failures, UserImportFailure{Row: rowNumber, Error: "malformed CSV row"})
+			continue
+		}
+
+		email := helpers.NormalizeEmail(field(record, "email"))
+		row, rowErr := buildUserImportRow(rowNumber, email, record, field)
+		if rowErr != nil {
+			failures = append(failures, UserImportFailure{Row: rowNumber, Email: email, Error: rowErr.Error()})
+			continue
+		}
+
+		if seen[row.Email] {
+			continue
+		}
+		seen[row.Email] = true
+		rows = append(rows, row)
+	}
+
+	return rows, failures, nil
+}
+
+// unescapeCSVFormula removes the apostrophe csvSafe puts before cells that
+// look like formulas, so that exported files can be imported again
+func unescapeCSVFormula(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune(csvFormulaPrefixes, rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+func buildUserImportRow(rowNumber int, email string, record []string, field func([]string, string) string) (userImportRow, error) {
+	if email == "" {
+		return userImportRow{}, errors.New("email is required")
+	}
+	if err := importValidator.Var(email, "email"); err != nil {
+		return userImportRow{}, errors.New("email must be a valid email")
+	}
+
+	name := field(record, "name")
+	if len(name) < 2 {
+		return userImportRow{}, errors.New("name must be at least 2 characters long")
+	}
+
+	row := userImportRow{
+		Row:      rowNumber,
+		Email:    email,
+		Name:     name,
+		Password: field(record, "password"),
+	}
+
+	if row.Password != "" {
+		if err := auth.ValidatePassword(row.Password); err != nil {
+			return userImportRow{}, err
+		}
+	}
+
+	if phone := field(record, "phone"); phone != "" {
+		if !phonenumbers.IsValidNumber(phone, phonenumbers.DefaultPhoneRegion) {
+			return userImportRow{}, errors.New("invalid phone number format")
+		}
+		normalizedPhone, err := phonenumbers.NormalizeNumber(phone, phonenumbers.DefaultPhoneRegion)
+		if err != nil {
+			return userImportRow{}, errors.New("invalid phone number format")
+		}
+		row.Phone = &normalizedPhone
+	}
+
+	if company := field(record, "company"); company != "" {
+		row.Company = &company
+	}
+
+	if roles := field(record, "roles"); roles != "" {
+		for _, role := range strings.Split(roles, userImportRoleSeparator) {
+			if role = strings.TrimSpace(role); role != "" {
+				row.Roles = append(row.Roles, role)
+			}
+		}
+	}
+
+	return row, nil
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseUserImportCSV(t *testing.T) {
+	csv := strings.Join([]string{
+		"Email,Name,Phone,Company,Roles,Password",
+		"Jane@Example.com,Jane Doe,081234567890,Studio45,user;admin,secret123",
+		"john@example.com,John Smith,,,,",
+		"not-an-email,Bad Email,,,,",
+		"short@example.com,X,,,,",
+		"phone@example.com,Bad Phone,12,,,",
+		"weak@example.com,Weak Password,,,,123",
+		"jane@example.com,Jane Again,,,,",
+	}, "\n")
+
+	rows, failures, err := parseUserImportCSV(strings.NewReader(csv))
+	if err != nil {
+		t.Fatalf("parseUserImportCSV() error = %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("got %d valid rows, want 2: %+v", len(rows), rows)
+	}
+
+	jane := rows[0]
+	if jane.Row != 2 || jane.Email != "jane@example.com" || jane.Password != "secret123" {
+		t.Errorf("unexpected first row: %+v", jane)
+	}
+	if jane.Phone == nil || !strings.HasPrefix(*jane.Phone, "+62") {
+		t.Errorf("phone was not normalized: %v", jane.Phone)
+	}
+	if len(jane.Roles) != 2 || jane.Roles[0] != "user" || jane.Roles[1] != "admin" {
+		t.Errorf("roles = %v, want [user admin]", jane.Roles)
+	}
+
+	john := rows[1]
+	if john.Phone != nil || john.Company != nil || john.Password != "" {
+		t.Errorf("empty optional columns should be unset: %+v", john)
+	}
+	if len(john.Roles) != 0 {
+		t.Errorf("roles = %v, want none so the default user role applies", john.Roles)
+	}
+
+	wantFailedRows := []int{4, 5, 6, 7}
+	if len(failures) != len(wantFailedRows) {
+		t.Fatalf("got %d failures, want %d: %+v", len(failures), len(wantFailedRows), failures)
+	}
+	for i, row := range wantFailedRows {
+		if failures[i].Row != row {
+			t.Errorf("failure %d row = %d, want %d", i, failures[i].Row, row)
+		}
+		if failures[i].Error == "" {
+			t.Errorf("failure %d has no error message", i)
+		}
+	}
+}
+
+func TestParseUserImportCSVInvalidFile(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"missing column": "email,phone\njane@example.com,\n",
+	}
+
+	for name, csv := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := parseUserImportCSV(strings.NewReader(csv))
+			if !errors.Is(err, ErrInvalidUserImport) {
+				t.Errorf("parseUserImportCSV() error = %v, want ErrInvalidUserImport", err)
+			}
+		})
+	}
+}
+
+func TestParseUserImportCSVReadsExportedFormulas(t *testing.T) {
+	csv := strings.Join([]string{
+		"email,name,phone,company",
+		"jane@example.com,'-Jane,'+6281234567890,'=Acme",
+	}, "\n")
+
+	rows, failures, err := parseUserImportCSV(strings.NewReader(csv))
+	if err != nil || len(failures) != 0 || len(rows) != 1 {
+		t.Fatalf("parseUserImportCSV() = %+v, %+v, %v", rows, failures, err)
+	}
+	row := rows[0]
+	if row.Name != "-Jane" || row.Phone == nil || *row.Phone != "+6281234567890" || row.Company == nil || *row.Company != "=Acme" {
+		t.Errorf("escaped cells were not restored: %+v", row)
+	}
+}
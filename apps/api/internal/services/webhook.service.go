@@ -0,0 +1,375 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/logger"
+	"api/internal/models"
+	"gorm.io/gorm"
+)
+
+// Webhook events
+const (
+	WebhookEventUserCreated      = "user.created"
+	WebhookEventUserUpdated      = "user.updated"
+	WebhookEventUserDeleted      = "user.deleted"
+	WebhookEventUserRolesUpdated = "user.roles_updated"
+	WebhookEventRoleCreated      = "role.created"
+	WebhookEventRoleUpdated      = "role.updated"
+	WebhookEventRoleDeleted      = "role.deleted"
+)
+
+// WebhookEvents lists the events a webhook can subscribe to
+var WebhookEvents = []string{
+	WebhookEventUserCreated,
+	WebhookEventUserUpdated,
+	WebhookEventUserDeleted,
+	WebhookEventUserRolesUpdated,
+	WebhookEventRoleCreated,
+	WebhookEventRoleUpdated,
+	WebhookEventRoleDeleted,
+}
+
+// WebhookSignatureHeader carries the hex-encoded HMAC-SHA256 of the request
+// body, keyed with the webhook secret and prefixed with "sha256="
+const WebhookSignatureHeader = "X-Studio45-Signature"
+
+// DefaultWebhookDeliveryRetention is how long delivery attempts are kept when
+// WEBHOOK_DELIVERY_RETENTION is not set
+const DefaultWebhookDeliveryRetention = 30 * 24 * time.Hour
+
+const (
+	// webhookMaxAttempts is the number of delivery attempts per event
+	webhookMaxAttempts = 3
+	webhookTimeout     = 10 * time.Second
+	// webhookMaxReplayDepth is how many replays deep a chain of replays of
+	// replays may go
+	webhookMaxReplayDepth = 3
+	// webhookResponseBodyLimit is the most of a response body that is kept
+	// with a delivery attempt
+	webhookResponseBodyLimit = 4096
+)
+
+// Number of delivery attempts listed by default and at most
+const (
+	DefaultWebhookDeliveryLimit = 20
+	MaxWebhookDeliveryLimit     = 100
+)
+
+// webhookRetryDelay is the wait after the first failed attempt; it doubles
+// after each further failure
+var webhookRetryDelay = time.Second
+
+var (
+	// ErrWebhookNotFound is returned when a webhook does not exist
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookDeliveryNotFound is returned when a delivery attempt does not
+	// exist
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	// ErrWebhookDeliverySucceeded is returned when replaying a delivery that
+	// did not fail
+	ErrWebhookDeliverySucceeded = errors.New("webhook delivery succeeded")
+	// ErrWebhookReplayLimit is returned when replaying a delivery that is
+	// already webhookMaxReplayDepth replays deep
+	ErrWebhookReplayLimit = errors.New("webhook replay limit reached")
+	// ErrWebhookReplayFailed is returned when the webhook rejects a replay or
+	// cannot be reached
+	ErrWebhookReplayFailed = errors.New("webhook replay failed")
+)
+
+var (
+	webhookClient     = &http.Client{Timeout: webhookTimeout}
+	webhookDeliveries sync.WaitGroup
+)
+
+// WebhookPayload is the JSON body POSTed to webhooks
+type WebhookPayload struct {
+	Event     string      `json:"event"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+type WebhookService struct {
+	db *gorm.DB
+}
+
+func NewWebhookService() *WebhookService {
+	return &WebhookService{
+		db: database.DB,
+	}
+}
+
+// ListWebhooks returns all webhooks, newest first
+func (s *WebhookService) ListWebhooks() ([]models.Webhook, error) {
+	var webhooks []models.Webhook
+	err := s.db.Order("created_at DESC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+// GetWebhook returns a webhook by ID
+func (s *WebhookService) GetWebhook(id string) (*models.Webhook, error) {
+	var webhook models.Webhook
+	if err := s.db.Where("id = ?", id).First(&webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// CreateWebhook stores a webhook, generating a secret when none is given
+func (s *WebhookService) CreateWebhook(webhook *models.Webhook) error {
+	if webhook.Secret == "" {
+		secret, err := GenerateWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+
+	// Select every column so an inactive webhook is not stored with the default
+	return s.db.Select("*").Create(webhook).Error
+}
+
+// UpdateWebhook applies updates to a webhook
+func (s *WebhookService) UpdateWebhook(id string, updates map[string]interface{}) error {
+	result := s.db.Model(&models.Webhook{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// DeleteWebhook removes a webhook
+func (s *WebhookService) DeleteWebhook(id string) error {
+	result := s.db.Where("id = ?", id).Delete(&models.Webhook{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Dispatch sends event to every active webhook subscribed to it. Deliveries
+// run in the background and are retried with exponential backoff; the
+// returned error only covers finding the webhooks and encoding the payload.
+func (s *WebhookService) Dispatch(event string, payload interface{}) error {
+	var webhooks []models.Webhook
+	err := s.db.Where("is_active = ? AND ? = ANY(events)", true, event).Find(&webhooks).Error
+	if err != nil {
+		return fmt.Errorf("failed to find webhooks for %s: %w", event, err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(WebhookPayload{
+		Event:     event,
+		Data:      payload,
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		webhookDeliveries.Add(1)
+		go func(webhook models.Webhook) {
+			defer webhookDeliveries.Done()
+			err := deliverWebhook(webhook.URL, webhook.Secret, body, func(attempt webhookAttempt) {
+				s.recordAttempt(webhook.ID, event, body, attempt, nil)
+			})
+			if err != nil {
+				logger.Error("Failed to deliver webhook", "webhook_id", webhook.ID, "event", event, "error", err)
+			}
+		}(webhook)
+	}
+	return nil
+}
+
+// ListDeliveries returns the webhook's most recent delivery attempts, newest
+// first, only the failed ones when failedOnly is set
+func (s *WebhookService) ListDeliveries(webhookID string, failedOnly bool, limit int) ([]models.WebhookDeliveryAttempt, error) {
+	if _, err := s.GetWebhook(webhookID); err != nil {
+		return nil, err
+	}
+
+	query := s.db.Where("webhook_id = ?", webhookID)
+	if failedOnly {
+		query = query.Where("succeeded = ?", false)
+	}
+
+	var deliveries []models.WebhookDeliveryAttempt
+	err := query.Order("attempted_at DESC").Limit(limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// GetDelivery returns a delivery attempt by ID
+func (s *WebhookService) GetDelivery(id string) (*models.WebhookDeliveryAttempt, error) {
+	var delivery models.WebhookDeliveryAttempt
+	if err := s.db.Where("id = ?", id).First(&delivery).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// Replay re-sends the payload of a failed delivery to the webhook's current
+// URL, signed with its current secret. The replay is a single attempt,
+// recorded as a delivery of its own; replays of replays stop at
+// webhookMaxReplayDepth so they cannot loop forever.
+func (s *WebhookService) Replay(deliveryID string) error {
+	delivery, err := s.GetDelivery(deliveryID)
+	if err != nil {
+		return err
+	}
+	if delivery.Succeeded {
+		return ErrWebhookDeliverySucceeded
+	}
+	if delivery.ReplayDepth >= webhookMaxReplayDepth {
+		return ErrWebhookReplayLimit
+	}
+
+	webhook, err := s.GetWebhook(delivery.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	body := []byte(delivery.Payload)
+	attempt := postWebhook(webhook.URL, SignWebhookPayload(webhook.Secret, body), body)
+	s.recordAttempt(webhook.ID, delivery.Event, body, attempt, delivery)
+	if attempt.err != nil {
+		return fmt.Errorf("%w: %v", ErrWebhookReplayFailed, attempt.err)
+	}
+	return nil
+}
+
+// recordAttempt stores the outcome of a POST of payload to a webhook. Failing
+// to store it is only logged, so it never affects the delivery.
+func (s *WebhookService) recordAttempt(webhookID, event string, payload []byte, attempt webhookAttempt, replayOf *models.WebhookDeliveryAttempt) {
+	record := models.WebhookDeliveryAttempt{
+		WebhookID:   webhookID,
+		Event:       event,
+		Payload:     models.JSONB(payload),
+		Succeeded:   attempt.err == nil,
+		AttemptedAt: attempt.at,
+	}
+	if attempt.status != 0 {
+		record.ResponseStatus = &attempt.status
+		record.ResponseBody = &attempt.body
+	}
+	if attempt.err != nil {
+		message := attempt.err.Error()
+		record.Error = &message
+	}
+	if replayOf != nil {
+		record.ReplayOf = &replayOf.ID
+		record.ReplayDepth = replayOf.ReplayDepth + 1
+	}
+
+	if err := s.db.Create(&record).Error; err != nil {
+		logger.Error("Failed to record webhook delivery", "webhook_id", webhookID, "event", event, "error", err)
+	}
+}
+
+// WaitForWebhookDeliveries blocks until dispatched webhooks have been
+// delivered or have exhausted their retries
+func WaitForWebhookDeliveries() {
+	webhookDeliveries.Wait()
+}
+
+// webhookAttempt is the outcome of one POST to a webhook. status is 0 when
+// no response was received; err is nil when the webhook accepted the event.
+type webhookAttempt struct {
+	at     time.Time
+	status int
+	body   string
+	err    error
+}
+
+// deliverWebhook POSTs body to url, retrying failed attempts. onAttempt, when
+// not nil, is called with the outcome of every attempt.
+func deliverWebhook(url, secret string, body []byte, onAttempt func(webhookAttempt)) error {
+	signature := SignWebhookPayload(secret, body)
+	delay := webhookRetryDelay
+
+	var err error
+	for attempt := 1; attempt <= webhookMaxAttempts; attempt++ {
+		result := postWebhook(url, signature, body)
+		if onAttempt != nil {
+			onAttempt(result)
+		}
+		if err = result.err; err == nil {
+			return nil
+		}
+		if attempt < webhookMaxAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", webhookMaxAttempts, err)
+}
+
+func postWebhook(url, signature string, body []byte) webhookAttempt {
+	attempt := webhookAttempt{at: time.Now()}
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		attempt.err = err
+		return attempt
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, signature)
+
+	resp, err := webhookClient.Do(req)
+	if err != nil {
+		attempt.err = err
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	attempt.status = resp.StatusCode
+	responseBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseBodyLimit))
+	attempt.body = string(responseBody)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return attempt
+}
+
+// SignWebhookPayload returns the X-Studio45-Signature value for body
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateWebhookSecret returns a random signing secret
+func GenerateWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(bytes), nil
+}
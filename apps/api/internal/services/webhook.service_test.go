@@ -0,0 +1,153 @@
+package services
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func withFastWebhookRetries(t *testing.T) {
+	t.Helper()
+	original := webhookRetryDelay
+	webhookRetryDelay = time.Millisecond
+	t.Cleanup(func() { webhookRetryDelay = original })
+}
+
+func TestDeliverWebhookSignsPayload(t *testing.T) {
+	const secret = "test-webhook-secret"
+	body, err := json.Marshal(WebhookPayload{
+		Event:     WebhookEventUserCreated,
+		Data:      map[string]string{"id": "user-1", "email": "jane@example.com"},
+		Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var gotBody []byte
+	var gotSignature, gotContentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get(WebhookSignatureHeader)
+		gotContentType = r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := deliverWebhook(server.URL, secret, body, nil); err != nil {
+		t.Fatalf("deliverWebhook() error = %v", err)
+	}
+
+	if gotContentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", gotContentType)
+	}
+	if want := SignWebhookPayload(secret, gotBody); gotSignature != want {
+		t.Errorf("signature = %q, want %q", gotSignature, want)
+	}
+
+	var payload struct {
+		Event     string            `json:"event"`
+		Data      map[string]string `json:"data"`
+		Timestamp time.Time         `json:"timestamp"`
+	}
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("received invalid JSON: %v", err)
+	}
+	if payload.Event != WebhookEventUserCreated || payload.Data["email"] != "jane@example.com" || payload.Timestamp.IsZero() {
+		t.Errorf("received payload = %+v", payload)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Reference value computed with: printf 'hello' | openssl dgst -sha256 -hmac secret
+	const want = "sha256=88aab3ede8d3adf94d26ab90d3bafd4a2083070c3bcce9c014ee04a443847c0b"
+	if got := SignWebhookPayload("secret", []byte("hello")); got != want {
+		t.Errorf("SignWebhookPayload() = %q, want %q", got, want)
+	}
+}
+
+func TestDeliverWebhookRetriesFailures(t *testing.T) {
+	withFastWebhookRetries(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < webhookMaxAttempts {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	if err := deliverWebhook(server.URL, "secret", []byte(`{}`), nil); err != nil {
+		t.Fatalf("deliverWebhook() error = %v", err)
+	}
+	if got := attempts.Load(); got != webhookMaxAttempts {
+		t.Errorf("attempts = %d, want %d", got, webhookMaxAttempts)
+	}
+}
+
+func TestDeliverWebhookGivesUp(t *testing.T) {
+	withFastWebhookRetries(t)
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	if err := deliverWebhook(server.URL, "secret", []byte(`{}`), nil); err == nil {
+		t.Fatal("deliverWebhook() succeeded against a failing endpoint")
+	}
+	if got := attempts.Load(); got != webhookMaxAttempts {
+		t.Errorf("attempts = %d, want %d", got, webhookMaxAttempts)
+	}
+}
+
+func TestDeliverWebhookReportsEveryAttempt(t *testing.T) {
+	withFastWebhookRetries(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte("down for maintenance"))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	var attempts []webhookAttempt
+	err := deliverWebhook(server.URL, "secret", []byte(`{}`), func(attempt webhookAttempt) {
+		attempts = append(attempts, attempt)
+	})
+	if err != nil {
+		t.Fatalf("deliverWebhook() error = %v", err)
+	}
+
+	if len(attempts) != 2 {
+		t.Fatalf("reported %d attempts, want 2", len(attempts))
+	}
+	if failed := attempts[0]; failed.err == nil || failed.status != http.StatusServiceUnavailable || failed.body != "down for maintenance" {
+		t.Errorf("first attempt = %+v, want a failed 503 with its body", failed)
+	}
+	if succeeded := attempts[1]; succeeded.err != nil || succeeded.status != http.StatusAccepted || succeeded.at.IsZero() {
+		t.Errorf("second attempt = %+v, want a successful 202", succeeded)
+	}
+}
+
+func TestPostWebhookUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	attempt := postWebhook(server.URL, "sha256=", []byte(`{}`))
+	if attempt.err == nil || attempt.status != 0 {
+		t.Errorf("postWebhook() to a closed server = %+v, want an error without a status", attempt)
+	}
+}
@@ -0,0 +1,80 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store for single instance deployments.
+// Sessions are lost when the process restarts.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]Session
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]map[string]Session),
+	}
+}
+
+func (m *MemoryStore) Add(s Session, limit int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing := make([]Session, 0, len(m.sessions[s.UserID])+1)
+	existing = append(existing, s)
+	for _, other := range m.sessions[s.UserID] {
+		existing = append(existing, other)
+	}
+	keep, _ := prune(existing, time.Now(), limit)
+
+	userSessions := make(map[string]Session, len(keep))
+	for _, kept := range keep {
+		userSessions[kept.ID] = kept
+	}
+	m.sessions[s.UserID] = userSessions
+	return nil
+}
+
+func (m *MemoryStore) Exists(userID, sessionID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[userID][sessionID]
+	return ok && s.ExpiresAt.After(time.Now()), nil
+}
+
+func (m *MemoryStore) List(userID string) ([]Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing := make([]Session, 0, len(m.sessions[userID]))
+	for _, s := range m.sessions[userID] {
+		existing = append(existing, s)
+	}
+	keep, _ := prune(existing, time.Now(), 0)
+	if keep == nil {
+		keep = []Session{}
+	}
+	return keep, nil
+}
+
+func (m *MemoryStore) Revoke(userID, sessionID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sessions[userID][sessionID]; !ok {
+		return false, nil
+	}
+	delete(m.sessions[userID], sessionID)
+	return true, nil
+}
+
+func (m *MemoryStore) RevokeAll(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, userID)
+	return nil
+}
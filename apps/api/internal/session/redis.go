@@ -0,0 +1,135 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"api/internal/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix names the hash holding a user's sessions, keyed by session ID
+const redisKeyPrefix = "session:"
+
+// maxAddAttempts bounds the retries of Add when a concurrent login changes the
+// user's sessions mid-transaction
+const maxAddAttempts = 5
+
+// RedisStore is a Store shared between API instances through Redis. Each user
+// has a hash of session IDs to session metadata that expires with the user's
+// last session.
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{
+		client: client,
+	}
+}
+
+func (r *RedisStore) Add(s Session, limit int) error {
+	ctx := context.Background()
+	key := redisKeyPrefix + s.UserID
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	add := func(tx *redis.Tx) error {
+		existing, err := r.load(ctx, tx, s.UserID)
+		if err != nil {
+			return err
+		}
+		keep, drop := prune(append([]Session{s}, existing...), time.Now(), limit)
+
+		// The hash lives as long as its longest-lived session
+		expiresAt := s.ExpiresAt
+		for _, kept := range keep {
+			if kept.ExpiresAt.After(expiresAt) {
+				expiresAt = kept.ExpiresAt
+			}
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.HSet(ctx, key, s.ID, data)
+			if len(drop) > 0 {
+				pipe.HDel(ctx, key, drop...)
+			}
+			pipe.ExpireAt(ctx, key, expiresAt)
+			return nil
+		})
+		return err
+	}
+
+	for attempt := 0; attempt < maxAddAttempts; attempt++ {
+		err = r.client.Watch(ctx, add, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return err
+}
+
+func (r *RedisStore) Exists(userID, sessionID string) (bool, error) {
+	data, err := r.client.HGet(context.Background(), redisKeyPrefix+userID, sessionID).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return false, fmt.Errorf("failed to decode session: %w", err)
+	}
+	return s.ExpiresAt.After(time.Now()), nil
+}
+
+func (r *RedisStore) List(userID string) ([]Session, error) {
+	existing, err := r.load(context.Background(), r.client, userID)
+	if err != nil {
+		return nil, err
+	}
+	keep, _ := prune(existing, time.Now(), 0)
+	if keep == nil {
+		keep = []Session{}
+	}
+	return keep, nil
+}
+
+func (r *RedisStore) Revoke(userID, sessionID string) (bool, error) {
+	removed, err := r.client.HDel(context.Background(), redisKeyPrefix+userID, sessionID).Result()
+	if err != nil {
+		return false, err
+	}
+	return removed > 0, nil
+}
+
+func (r *RedisStore) RevokeAll(userID string) error {
+	return r.client.Del(context.Background(), redisKeyPrefix+userID).Err()
+}
+
+// load returns every session recorded for the user, skipping entries that
+// cannot be decoded
+func (r *RedisStore) load(ctx context.Context, client redis.Cmdable, userID string) ([]Session, error) {
+	entries, err := client.HGetAll(ctx, redisKeyPrefix+userID).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]Session, 0, len(entries))
+	for id, data := range entries {
+		var s Session
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			logger.Warn("Failed to decode session", "user_id", userID, "session_id", id, "error", err)
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, nil
+}
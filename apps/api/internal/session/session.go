@@ -0,0 +1,169 @@
+// Package session tracks the tokens issued at login so that they can be listed
+// and revoked before they expire
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"api/internal/database"
+	"api/internal/helpers"
+	"api/internal/logger"
+)
+
+const defaultMaxConcurrent = 5
+
+// Session is a token issued to a user. ID is the token's jti claim.
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	UserAgent string    `json:"user_agent"`
+	IPAddress string    `json:"ip_address"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Device describes the client a session was issued to
+type Device struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Store keeps the active sessions of each user
+type Store interface {
+	// Add records a session, revoking the user's oldest sessions when they
+	// would hold more than limit. A limit of 0 or less means no limit.
+	Add(s Session, limit int) error
+	// Exists reports whether the session is recorded and unexpired
+	Exists(userID, sessionID string) (bool, error)
+	// List returns the user's unexpired sessions, newest first
+	List(userID string) ([]Session, error)
+	// Revoke removes a session, reporting whether it was recorded
+	Revoke(userID, sessionID string) (bool, error)
+	// RevokeAll removes every session of the user
+	RevokeAll(userID string) error
+}
+
+var (
+	sessions Store
+	mu       sync.RWMutex
+)
+
+// Sessions returns the process-wide session store, creating it from the
+// SESSION_BACKEND environment variable on first use. The process exits when
+// the store cannot be created; servers call Init at startup instead.
+func Sessions() Store {
+	mu.RLock()
+	s := sessions
+	mu.RUnlock()
+	if s != nil {
+		return s
+	}
+
+	if err := Init(); err != nil {
+		logger.Fatal("Failed to set up session store", "error", err)
+	}
+	mu.RLock()
+	defer mu.RUnlock()
+	return sessions
+}
+
+// Init creates the process-wide session store from the SESSION_BACKEND
+// environment variable unless one is already set
+func Init() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if sessions != nil {
+		return nil
+	}
+
+	s, err := New(helpers.GetEnv("SESSION_BACKEND", "none"))
+	if err != nil {
+		return err
+	}
+	sessions = s
+	return nil
+}
+
+// SetSessions replaces the process-wide session store
+func SetSessions(s Store) {
+	mu.Lock()
+	sessions = s
+	mu.Unlock()
+}
+
+// New creates a session store for the given backend ("redis", "memory" or
+// "none"). Without a store sessions are not tracked and every unexpired token
+// is accepted. The redis backend fails when Redis is unreachable rather than
+// falling back to memory, where sessions revoked on one instance would stay
+// valid on the others.
+func New(backend string) (Store, error) {
+	switch strings.ToLower(backend) {
+	case "redis":
+		if database.Redis == nil {
+			if err := database.ConnectRedis(); err != nil {
+				return nil, fmt.Errorf("redis session store: %w", err)
+			}
+		}
+		logger.Info("Redis session store initialized")
+		return NewRedisStore(database.Redis), nil
+	case "memory":
+		return NewMemoryStore(), nil
+	default:
+		return NewNoopStore(), nil
+	}
+}
+
+// MaxConcurrent returns how many sessions a user may hold at once
+// (MAX_CONCURRENT_SESSIONS); 0 or less means no limit
+func MaxConcurrent() int {
+	return helpers.GetEnvInt("MAX_CONCURRENT_SESSIONS", defaultMaxConcurrent)
+}
+
+// prune splits sessions into those to keep at now, newest first, and the IDs
+// of those that have expired or are the oldest beyond limit. Sessions created
+// at the same time keep their order, so a session being added goes first.
+func prune(sessions []Session, now time.Time, limit int) (keep []Session, drop []string) {
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.After(sessions[j].CreatedAt)
+	})
+
+	for _, s := range sessions {
+		if !s.ExpiresAt.After(now) || (limit > 0 && len(keep) >= limit) {
+			drop = append(drop, s.ID)
+			continue
+		}
+		keep = append(keep, s)
+	}
+	return keep, drop
+}
+
+// NoopStore does not track sessions, so every session exists
+type NoopStore struct{}
+
+func NewNoopStore() *NoopStore {
+	return &NoopStore{}
+}
+
+func (NoopStore) Add(s Session, limit int) error {
+	return nil
+}
+
+func (NoopStore) Exists(userID, sessionID string) (bool, error) {
+	return true, nil
+}
+
+func (NoopStore) List(userID string) ([]Session, error) {
+	return []Session{}, nil
+}
+
+func (NoopStore) Revoke(userID, sessionID string) (bool, error) {
+	return false, nil
+}
+
+func (NoopStore) RevokeAll(userID string) error {
+	return nil
+}
@@ -0,0 +1,197 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// newRedisStore returns a RedisStore backed by an in-process miniredis
+func newRedisStore(t *testing.T) (*RedisStore, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewRedisStore(client), server
+}
+
+// stores runs fn against every Store that tracks sessions
+func stores(t *testing.T, fn func(t *testing.T, store Store)) {
+	t.Run("memory", func(t *testing.T) {
+		fn(t, NewMemoryStore())
+	})
+	t.Run("redis", func(t *testing.T) {
+		store, _ := newRedisStore(t)
+		fn(t, store)
+	})
+}
+
+// newSession returns a session for user-1 created age ago and valid for a day
+func newSession(id string, age time.Duration) Session {
+	created := time.Now().Add(-age)
+	return Session{
+		ID:        id,
+		UserID:    "user-1",
+		UserAgent: "test-agent",
+		IPAddress: "192.0.2.1",
+		CreatedAt: created,
+		ExpiresAt: created.Add(24 * time.Hour),
+	}
+}
+
+func TestStoreConcurrentLimit(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		for i := 0; i < 5; i++ {
+			// Older sessions are added first
+			s := newSession(fmt.Sprintf("session-%d", i), time.Duration(5-i)*time.Minute)
+			if err := store.Add(s, 3); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+		}
+
+		sessions, err := store.List("user-1")
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		expected := []string{"session-4", "session-3", "session-2"}
+		if len(sessions) != len(expected) {
+			t.Fatalf("List() = %d sessions, want %d", len(sessions), len(expected))
+		}
+		for i, id := range expected {
+			if sessions[i].ID != id {
+				t.Errorf("List()[%d] = %s, want %s", i, sessions[i].ID, id)
+			}
+		}
+
+		for _, id := range []string{"session-0", "session-1"} {
+			if ok, err := store.Exists("user-1", id); err != nil || ok {
+				t.Errorf("Exists(%s) = %v, %v, want the oldest sessions revoked", id, ok, err)
+			}
+		}
+		if ok, err := store.Exists("user-1", "session-4"); err != nil || !ok {
+			t.Errorf("Exists(session-4) = %v, %v, want true", ok, err)
+		}
+	})
+}
+
+func TestStoreRevoke(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		if err := store.Add(newSession("session-1", 0), 5); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+
+		revoked, err := store.Revoke("user-1", "session-1")
+		if err != nil || !revoked {
+			t.Fatalf("Revoke() = %v, %v, want true", revoked, err)
+		}
+		if ok, _ := store.Exists("user-1", "session-1"); ok {
+			t.Error("Exists() after Revoke() = true")
+		}
+
+		if revoked, _ := store.Revoke("user-1", "session-1"); revoked {
+			t.Error("Revoke() of a revoked session = true")
+		}
+		if revoked, _ := store.Revoke("user-2", "session-1"); revoked {
+			t.Error("Revoke() of another user's session = true")
+		}
+	})
+}
+
+func TestStoreRevokeAll(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		for _, id := range []string{"session-1", "session-2"} {
+			if err := store.Add(newSession(id, 0), 5); err != nil {
+				t.Fatalf("Add() error = %v", err)
+			}
+		}
+		other := newSession("session-3", 0)
+		other.UserID = "user-2"
+		if err := store.Add(other, 5); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+
+		if err := store.RevokeAll("user-1"); err != nil {
+			t.Fatalf("RevokeAll() error = %v", err)
+		}
+		if sessions, _ := store.List("user-1"); len(sessions) != 0 {
+			t.Errorf("List() after RevokeAll() = %v, want none", sessions)
+		}
+		if ok, _ := store.Exists("user-2", "session-3"); !ok {
+			t.Error("RevokeAll() removed another user's session")
+		}
+	})
+}
+
+func TestStoreExpiredSessions(t *testing.T) {
+	stores(t, func(t *testing.T, store Store) {
+		expired := newSession("expired", 2*time.Hour)
+		expired.ExpiresAt = time.Now().Add(-time.Hour)
+		if err := store.Add(expired, 0); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		if err := store.Add(newSession("active", 0), 0); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+
+		if ok, _ := store.Exists("user-1", "expired"); ok {
+			t.Error("Exists() of an expired session = true")
+		}
+		sessions, err := store.List("user-1")
+		if err != nil {
+			t.Fatalf("List() error = %v", err)
+		}
+		if len(sessions) != 1 || sessions[0].ID != "active" {
+			t.Errorf("List() = %+v, want only the active session", sessions)
+		}
+	})
+}
+
+func TestRedisStoreKeyExpiry(t *testing.T) {
+	store, server := newRedisStore(t)
+
+	if err := store.Add(newSession("session-1", 0), 5); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if !server.Exists("session:user-1") {
+		t.Fatal("session hash was not written to session:user-1")
+	}
+	if ttl := server.TTL("session:user-1"); ttl <= 23*time.Hour || ttl > 24*time.Hour {
+		t.Errorf("session hash TTL = %v, want the session's expiry", ttl)
+	}
+
+	server.FastForward(25 * time.Hour)
+	if ok, _ := store.Exists("user-1", "session-1"); ok {
+		t.Error("Exists() after the hash expired = true")
+	}
+}
+
+func TestNoopStore(t *testing.T) {
+	store := NewNoopStore()
+	if err := store.Add(newSession("session-1", 0), 1); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if ok, _ := store.Exists("user-1", "unknown"); !ok {
+		t.Error("NoopStore.Exists() = false, want every session accepted")
+	}
+	if sessions, _ := store.List("user-1"); len(sessions) != 0 {
+		t.Errorf("NoopStore.List() = %v, want none", sessions)
+	}
+}
+
+func TestNewRedisUnreachable(t *testing.T) {
+	server := miniredis.RunT(t)
+	host, port, _ := strings.Cut(server.Addr(), ":")
+	server.Close()
+	t.Setenv("REDIS_HOST", host)
+	t.Setenv("REDIS_PORT", port)
+
+	store, err := New("redis")
+	if err == nil {
+		t.Fatalf("New(redis) = %T, want an error when redis is unreachable", store)
+	}
+}
@@ -0,0 +1,92 @@
+// Package tracing configures OpenTelemetry tracing. Spans are exported to the
+// backend named by OTEL_EXPORTER; when it is not set, spans are not recorded.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"api/internal/helpers"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/exporters/zipkin"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName identifies the spans started by this service
+const TracerName = "api"
+
+// DefaultZipkinEndpoint is used for OTEL_EXPORTER=zipkin when
+// OTEL_EXPORTER_ZIPKIN_ENDPOINT is not set
+const DefaultZipkinEndpoint = "http://localhost:9411/api/v2/spans"
+
+// provider is the tracer provider installed by Setup, nil when tracing is
+// disabled
+var provider *sdktrace.TracerProvider
+
+// Setup installs the W3C trace context propagator and, when OTEL_EXPORTER is
+// set, a global tracer provider that exports spans for service at version
+func Setup(service, version string) error {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	name := strings.ToLower(helpers.GetEnv("OTEL_EXPORTER", ""))
+	if name == "" {
+		return nil
+	}
+
+	exporter, err := newExporter(name)
+	if err != nil {
+		return err
+	}
+
+	provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(service),
+			semconv.ServiceVersion(version),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	return nil
+}
+
+func newExporter(name string) (sdktrace.SpanExporter, error) {
+	switch name {
+	case "jaeger":
+		// Jaeger receives OTLP over HTTP, by default on localhost:4318
+		var opts []otlptracehttp.Option
+		if endpoint := helpers.GetEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""); endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		}
+		return otlptracehttp.New(context.Background(), opts...)
+	case "zipkin":
+		return zipkin.New(helpers.GetEnv("OTEL_EXPORTER_ZIPKIN_ENDPOINT", DefaultZipkinEndpoint))
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown OTEL_EXPORTER %q: must be jaeger, zipkin or stdout", name)
+	}
+}
+
+// Tracer returns the service's tracer from the global tracer provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Shutdown exports any buffered spans and stops the tracer provider. It does
+// nothing when tracing is disabled.
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
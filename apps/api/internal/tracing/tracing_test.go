@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func reset(t *testing.T) {
+	t.Helper()
+
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() {
+		provider = nil
+		otel.SetTracerProvider(previous)
+	})
+}
+
+func TestSetupDisabled(t *testing.T) {
+	reset(t)
+	t.Setenv("OTEL_EXPORTER", "")
+
+	if err := Setup("test", "dev"); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if provider != nil {
+		t.Error("Setup() installed a tracer provider without OTEL_EXPORTER")
+	}
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+func TestSetupStdout(t *testing.T) {
+	reset(t)
+	t.Setenv("OTEL_EXPORTER", "stdout")
+
+	if err := Setup("test", "dev"); err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if provider == nil || otel.GetTracerProvider() != provider {
+		t.Fatal("Setup() did not install the tracer provider")
+	}
+
+	_, span := Tracer().Start(context.Background(), "test")
+	if !span.SpanContext().IsSampled() {
+		t.Error("span is not recorded")
+	}
+	span.End()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
+
+func TestSetupUnknownExporter(t *testing.T) {
+	reset(t)
+	t.Setenv("OTEL_EXPORTER", "datadog")
+
+	err := Setup("test", "dev")
+	if err == nil || !strings.Contains(err.Error(), "datadog") {
+		t.Errorf("Setup() error = %v, want unknown exporter error", err)
+	}
+}
@@ -0,0 +1,3 @@
+-- Rollback audit_logs table
+
+DROP TABLE IF EXISTS audit_logs CASCADE;
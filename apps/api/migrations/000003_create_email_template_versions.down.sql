@@ -0,0 +1,4 @@
+-- Rollback email template versioning
+
+DROP TABLE IF EXISTS email_template_versions CASCADE;
+ALTER TABLE email_templates DROP COLUMN IF EXISTS version;
@@ -0,0 +1,19 @@
+-- Track the current version of each email template
+ALTER TABLE email_templates ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- Create email_template_versions table holding snapshots of previous template content
+CREATE TABLE email_template_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    template_id UUID NOT NULL REFERENCES email_templates(id) ON DELETE CASCADE,
+    version_number INTEGER NOT NULL,
+    subject VARCHAR(500) NOT NULL,
+    html_template TEXT NOT NULL,
+    text_template TEXT NOT NULL,
+    variables JSONB DEFAULT '[]'::jsonb,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(template_id, version_number)
+);
+
+-- Create index for listing a template's versions
+CREATE INDEX idx_email_template_versions_template_id ON email_template_versions(template_id);
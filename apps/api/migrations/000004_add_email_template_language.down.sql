@@ -0,0 +1,6 @@
+-- Rollback email template languages
+
+DELETE FROM email_templates WHERE language <> 'en';
+ALTER TABLE email_templates DROP CONSTRAINT IF EXISTS email_templates_name_language_key;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_name_key UNIQUE (name);
+ALTER TABLE email_templates DROP COLUMN IF EXISTS language;
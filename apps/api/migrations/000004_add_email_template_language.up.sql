@@ -0,0 +1,142 @@
+-- Add a language (BCP-47 code) to email templates; existing templates are English
+ALTER TABLE email_templates ADD COLUMN language VARCHAR(35) NOT NULL DEFAULT 'en';
+
+-- Template names are now unique per language
+ALTER TABLE email_templates DROP CONSTRAINT email_templates_name_key;
+ALTER TABLE email_templates ADD CONSTRAINT email_templates_name_language_key UNIQUE (name, language);
+
+-- Insert Indonesian password reset email template
+INSERT INTO email_templates (name, language, subject, html_template, text_template, variables) VALUES 
+('password_reset', 'id', 'Atur Ulang Kata Sandi Anda', 
+'<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Atur Ulang Kata Sandi</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, ''Segoe UI'', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.CompanyName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Atur Ulang Kata Sandi Anda</h2>
+            <p>Anda meminta pengaturan ulang kata sandi untuk akun Anda. Klik tombol di bawah ini untuk membuat kata sandi baru:</p>
+            
+            <a href="{{.ResetURL}}" class="button">Atur Ulang Kata Sandi</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Pemberitahuan Keamanan:</strong> Demi keamanan Anda, tautan ini akan kedaluwarsa dalam 15 menit. Jika Anda tidak meminta pengaturan ulang kata sandi, abaikan email ini.
+            </div>
+            
+            <p>Jika tombol tidak berfungsi, salin dan tempel tautan ini ke browser Anda:</p>
+            <p style="word-break: break-all; color: #667eea;">{{.ResetURL}}</p>
+        </div>
+        <div class="footer">
+            <p>Email ini dikirim dari {{.CompanyName}}. Jika Anda memiliki pertanyaan, silakan hubungi tim dukungan kami.</p>
+        </div>
+    </div>
+</body>
+</html>',
+'{{.CompanyName}} - Atur Ulang Kata Sandi
+
+Anda meminta pengaturan ulang kata sandi untuk akun Anda.
+
+Klik atau salin tautan berikut untuk mengatur ulang kata sandi Anda:
+{{.ResetURL}}
+
+PEMBERITAHUAN KEAMANAN: Demi keamanan Anda, tautan ini akan kedaluwarsa dalam 15 menit.
+Jika Anda tidak meminta pengaturan ulang kata sandi, abaikan email ini.
+
+Jika Anda memiliki pertanyaan, silakan hubungi tim dukungan kami.
+
+---
+{{.CompanyName}}',
+'[{"name": "CompanyName", "description": "Nama perusahaan yang mengirim email"}, {"name": "ResetURL", "description": "URL untuk mengatur ulang kata sandi"}]'::jsonb
+);
@@ -0,0 +1,3 @@
+-- Rollback user account suspension
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_active;
@@ -0,0 +1,2 @@
+-- Allow admins to suspend user accounts without deleting them
+ALTER TABLE users ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT true;
@@ -0,0 +1,4 @@
+-- Rollback user invitations
+
+DELETE FROM email_templates WHERE name = 'user_invitation';
+DROP TABLE IF EXISTS user_invitations;
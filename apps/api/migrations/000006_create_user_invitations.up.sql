@@ -0,0 +1,150 @@
+-- Create user_invitations table for admin-issued account invitations
+CREATE TABLE user_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    email VARCHAR(255) NOT NULL,
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    roles JSONB NOT NULL DEFAULT '[]'::jsonb,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for finding pending invitations by email
+CREATE INDEX idx_user_invitations_email ON user_invitations(email);
+
+-- Insert default user invitation email template
+INSERT INTO email_templates (name, language, subject, html_template, text_template, variables) VALUES 
+('user_invitation', 'en', 'You''re invited to join {{.CompanyName}}', 
+'<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Invitation</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, ''Segoe UI'', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.CompanyName}}</h1>
+        </div>
+        <div class="content">
+            <h2>You''re Invited</h2>
+            <p>An administrator has created an account for you. Click the button below to choose your name and password and finish setting up your account:</p>
+            
+            <a href="{{.InvitationURL}}" class="button">Accept Invitation</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Security Notice:</strong> This invitation will expire in 72 hours. If you weren''t expecting it, please ignore this email.
+            </div>
+            
+            <p>If the button doesn''t work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">{{.InvitationURL}}</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from {{.CompanyName}}. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>',
+'{{.CompanyName}} - Invitation
+
+An administrator has created an account for you.
+
+Please click or copy the following link to accept the invitation:
+{{.InvitationURL}}
+
+This invitation will expire in 72 hours.
+If you weren''t expecting it, please ignore this email.
+
+If you have any questions, please contact our support team.
+
+---
+{{.CompanyName}}',
+'[{"name": "CompanyName", "description": "The name of the company sending the email"}, {"name": "InvitationURL", "description": "The URL for accepting the invitation"}]'::jsonb
+);
@@ -0,0 +1,4 @@
+-- Rollback login history
+
+DROP TABLE IF EXISTS login_events;
+ALTER TABLE users DROP COLUMN IF EXISTS last_login_at;
@@ -0,0 +1,15 @@
+-- Track when each user last logged in successfully
+ALTER TABLE users ADD COLUMN last_login_at TIMESTAMP WITH TIME ZONE;
+
+-- Create login_events table recording every login attempt for existing users
+CREATE TABLE login_events (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    success BOOLEAN NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for listing a user's login history and counting recent failures
+CREATE INDEX idx_login_events_user_id_created_at ON login_events(user_id, created_at);
@@ -0,0 +1,3 @@
+-- Rollback password history
+
+DROP TABLE IF EXISTS password_history;
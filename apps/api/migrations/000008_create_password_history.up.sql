@@ -0,0 +1,10 @@
+-- Create password_history table holding previous password hashes to prevent reuse
+CREATE TABLE password_history (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for finding a user's most recent passwords
+CREATE INDEX idx_password_history_user_id_created_at ON password_history(user_id, created_at);
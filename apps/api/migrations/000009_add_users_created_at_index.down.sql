@@ -0,0 +1,3 @@
+-- Rollback user list pagination index
+
+DROP INDEX IF EXISTS idx_users_created_at_id;
@@ -0,0 +1,2 @@
+-- Support keyset pagination of the admin user list ordered by created_at, id
+CREATE INDEX idx_users_created_at_id ON users(created_at, id);
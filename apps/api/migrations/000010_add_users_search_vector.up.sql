@@ -0,0 +1,27 @@
+-- Full-text search over user email, name and company
+ALTER TABLE users ADD COLUMN search_vector tsvector;
+
+-- Keep search_vector in sync with the searchable columns. The simple
+-- configuration avoids stemming so names and emails match as typed.
+CREATE OR REPLACE FUNCTION users_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('simple',
+        coalesce(NEW.email, '') || ' ' ||
+        coalesce(NEW.name, '') || ' ' ||
+        coalesce(NEW.company, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE 'plpgsql';
+
+CREATE TRIGGER users_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF email, name, company ON users
+    FOR EACH ROW EXECUTE FUNCTION users_search_vector_update();
+
+-- Populate existing users
+UPDATE users SET search_vector = to_tsvector('simple',
+    coalesce(email, '') || ' ' ||
+    coalesce(name, '') || ' ' ||
+    coalesce(company, ''));
+
+CREATE INDEX idx_users_search_vector ON users USING GIN(search_vector);
@@ -0,0 +1,3 @@
+-- Rollback API keys
+
+DROP TABLE IF EXISTS api_keys;
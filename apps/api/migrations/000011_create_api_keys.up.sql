@@ -0,0 +1,16 @@
+-- Create api_keys table for services that authenticate without a JWT. Only a
+-- SHA-256 hash of each key is stored.
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    scopes JSONB NOT NULL DEFAULT '[]',
+    last_used_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Create index for listing a user's keys
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
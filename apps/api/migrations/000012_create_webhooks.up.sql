@@ -0,0 +1,12 @@
+-- Create webhooks table for notifying external systems of user and role events
+CREATE TABLE webhooks (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    events TEXT[] NOT NULL DEFAULT '{}',
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for finding the webhooks subscribed to an event
+CREATE INDEX idx_webhooks_events ON webhooks USING GIN (events);
@@ -0,0 +1,3 @@
+-- Rollback user preferences
+
+DROP TABLE IF EXISTS user_preferences;
@@ -0,0 +1,18 @@
+-- Create idempotency_keys table so retried POST requests replay the original
+-- response instead of creating duplicates. Anonymous requests such as
+-- registration have no user_id.
+CREATE TABLE idempotency_keys (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key VARCHAR(255) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    request_hash VARCHAR(64) NOT NULL,
+    response_body BYTEA,
+    response_status INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One entry per key and user, treating anonymous requests as one user
+CREATE UNIQUE INDEX idx_idempotency_keys_key_user ON idempotency_keys(key, COALESCE(user_id, '00000000-0000-0000-0000-000000000000'));
+
+-- Create index for purging expired keys
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
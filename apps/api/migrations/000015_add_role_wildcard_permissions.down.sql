@@ -0,0 +1,3 @@
+-- Rollback wildcard permissions
+
+ALTER TABLE roles DROP COLUMN IF EXISTS use_wildcard_permissions;
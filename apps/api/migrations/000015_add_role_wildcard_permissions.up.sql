@@ -0,0 +1,2 @@
+-- Let roles opt in to matching permission names such as user.* as patterns
+ALTER TABLE roles ADD COLUMN use_wildcard_permissions BOOLEAN NOT NULL DEFAULT false;
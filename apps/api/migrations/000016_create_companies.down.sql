@@ -0,0 +1,37 @@
+-- Rollback companies, restoring the free-text users.company column
+
+ALTER TABLE users ADD COLUMN company VARCHAR(255);
+
+UPDATE users SET company = companies.name
+FROM companies
+WHERE users.company_id = companies.id;
+
+DROP TRIGGER IF EXISTS companies_search_vector_trigger ON companies;
+DROP FUNCTION IF EXISTS companies_search_vector_update();
+DROP TRIGGER IF EXISTS users_search_vector_trigger ON users;
+
+CREATE OR REPLACE FUNCTION users_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := to_tsvector('simple',
+        coalesce(NEW.email, '') || ' ' ||
+        coalesce(NEW.name, '') || ' ' ||
+        coalesce(NEW.company, ''));
+    RETURN NEW;
+END;
+$$ LANGUAGE 'plpgsql';
+
+CREATE TRIGGER users_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF email, name, company ON users
+    FOR EACH ROW EXECUTE FUNCTION users_search_vector_update();
+
+DROP FUNCTION IF EXISTS users_search_document(TEXT, TEXT, UUID);
+
+UPDATE users SET search_vector = to_tsvector('simple',
+    coalesce(email, '') || ' ' ||
+    coalesce(name, '') || ' ' ||
+    coalesce(company, ''));
+
+DROP INDEX IF EXISTS idx_users_company_id;
+ALTER TABLE users DROP COLUMN IF EXISTS company_id;
+DROP TABLE IF EXISTS companies;
@@ -0,0 +1,67 @@
+-- Create companies table and link users to it, replacing the free-text
+-- users.company column
+CREATE TABLE companies (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) UNIQUE NOT NULL,
+    domain VARCHAR(255) UNIQUE,
+    website VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_companies_updated_at
+    BEFORE UPDATE ON companies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+ALTER TABLE users ADD COLUMN company_id UUID REFERENCES companies(id) ON DELETE SET NULL;
+CREATE INDEX idx_users_company_id ON users(company_id);
+
+-- Move existing company names into companies
+INSERT INTO companies (name)
+SELECT DISTINCT trim(company) FROM users
+WHERE company IS NOT NULL AND trim(company) <> '';
+
+UPDATE users SET company_id = companies.id
+FROM companies
+WHERE trim(users.company) = companies.name;
+
+-- Search users by their company's name instead of the dropped column
+DROP TRIGGER IF EXISTS users_search_vector_trigger ON users;
+
+CREATE OR REPLACE FUNCTION users_search_document(TEXT, TEXT, UUID)
+RETURNS tsvector AS $$
+    SELECT to_tsvector('simple',
+        coalesce($1, '') || ' ' ||
+        coalesce($2, '') || ' ' ||
+        coalesce((SELECT companies.name FROM companies WHERE companies.id = $3), ''));
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION users_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := users_search_document(NEW.email, NEW.name, NEW.company_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE 'plpgsql';
+
+CREATE TRIGGER users_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF email, name, company_id ON users
+    FOR EACH ROW EXECUTE FUNCTION users_search_vector_update();
+
+-- Renaming a company changes what its users match
+CREATE OR REPLACE FUNCTION companies_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE users SET search_vector = users_search_document(email, name, company_id)
+    WHERE company_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE 'plpgsql';
+
+CREATE TRIGGER companies_search_vector_trigger
+    AFTER UPDATE OF name ON companies
+    FOR EACH ROW EXECUTE FUNCTION companies_search_vector_update();
+
+UPDATE users SET search_vector = users_search_document(email, name, company_id);
+
+ALTER TABLE users DROP COLUMN company;
@@ -0,0 +1,3 @@
+-- Rollback user avatars
+
+ALTER TABLE users DROP COLUMN IF EXISTS avatar_url;
@@ -0,0 +1,2 @@
+-- Profile pictures uploaded through the avatar endpoint
+ALTER TABLE users ADD COLUMN avatar_url VARCHAR(255);
@@ -0,0 +1,6 @@
+-- Rollback active-only email uniqueness. Fails if a soft-deleted user shares
+-- an email with another user; purge or rename one of them first.
+
+DROP INDEX IF EXISTS idx_users_email;
+CREATE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
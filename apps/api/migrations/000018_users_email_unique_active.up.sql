@@ -0,0 +1,5 @@
+-- Only active users need unique emails, so a soft-deleted user's address can
+-- be registered again. Restoring a user checks for a clash first.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+DROP INDEX IF EXISTS idx_users_email;
+CREATE UNIQUE INDEX idx_users_email ON users(email) WHERE deleted_at IS NULL;
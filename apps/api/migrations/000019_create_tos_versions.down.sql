@@ -0,0 +1,4 @@
+-- Rollback terms of service versions and acceptances
+
+DROP TABLE IF EXISTS user_tos_acceptances;
+DROP TABLE IF EXISTS tos_versions;
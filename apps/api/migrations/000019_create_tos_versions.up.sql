@@ -0,0 +1,20 @@
+-- Create tos_versions table holding every published terms of service. The
+-- current version is the latest one whose effective date has passed.
+CREATE TABLE tos_versions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    version VARCHAR(50) NOT NULL UNIQUE,
+    content TEXT NOT NULL,
+    effective_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tos_versions_effective_date ON tos_versions(effective_date);
+
+-- Create user_tos_acceptances table recording which versions each user accepted
+CREATE TABLE user_tos_acceptances (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tos_version_id UUID NOT NULL REFERENCES tos_versions(id) ON DELETE CASCADE,
+    accepted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ip_address VARCHAR(45),
+    PRIMARY KEY (user_id, tos_version_id)
+);
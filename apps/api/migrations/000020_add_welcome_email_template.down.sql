@@ -0,0 +1,3 @@
+-- Remove the default welcome email template
+
+DELETE FROM email_templates WHERE name = 'welcome';
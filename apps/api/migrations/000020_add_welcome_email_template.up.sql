@@ -0,0 +1,123 @@
+-- Insert default welcome email template, sent after registration. Deleting
+-- it turns the welcome email off.
+INSERT INTO email_templates (name, language, subject, html_template, text_template, variables) VALUES 
+('welcome', 'en', 'Welcome to {{.CompanyName}}', 
+'<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Welcome</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, ''Segoe UI'', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.CompanyName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Welcome, {{.Name}}!</h2>
+            <p>Thanks for signing up. Your account is ready, and you can sign in at any time with the email address and password you registered with:</p>
+            
+            <a href="{{.LoginURL}}" class="button">Sign In</a>
+            
+            <p>If the button doesn''t work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">{{.LoginURL}}</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from {{.CompanyName}}. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>',
+'{{.CompanyName}} - Welcome
+
+Hi {{.Name}},
+
+Thanks for signing up. Your account is ready, and you can sign in at any time
+with the email address and password you registered with:
+{{.LoginURL}}
+
+If you have any questions, please contact our support team.
+
+---
+{{.CompanyName}}',
+'[{"name": "CompanyName", "description": "The name of the company sending the email"}, {"name": "Name", "description": "The name of the new user"}, {"name": "LoginURL", "description": "The URL of the sign in page"}]'::jsonb
+);
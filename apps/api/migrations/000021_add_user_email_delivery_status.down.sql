@@ -0,0 +1,4 @@
+-- Rollback email delivery status
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_delivery_status;
+DROP TYPE IF EXISTS email_delivery_status;
@@ -0,0 +1,5 @@
+-- Track the last delivery outcome reported by the email provider. NULL means
+-- no report has been received for the user's address yet.
+CREATE TYPE email_delivery_status AS ENUM ('delivered', 'bounced', 'complained');
+
+ALTER TABLE users ADD COLUMN email_delivery_status email_delivery_status;
@@ -0,0 +1,3 @@
+-- Remove the super_admin role
+
+DELETE FROM roles WHERE name = 'super_admin';
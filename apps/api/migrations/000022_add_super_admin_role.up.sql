@@ -0,0 +1,4 @@
+-- Add the super_admin role, which allows admins to impersonate other admins
+INSERT INTO roles (name, description) VALUES
+    ('super_admin', 'Can impersonate other admins - grant alongside the admin role')
+ON CONFLICT (name) DO NOTHING;
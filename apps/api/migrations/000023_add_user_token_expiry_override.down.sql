@@ -0,0 +1,3 @@
+-- Remove the per-user JWT lifetime
+
+ALTER TABLE users DROP COLUMN IF EXISTS token_expiry_override;
@@ -0,0 +1,4 @@
+-- Per-user JWT lifetime, overriding JWT_EXPIRATION when set. Stored in
+-- nanoseconds as a Go time.Duration.
+ALTER TABLE users ADD COLUMN token_expiry_override BIGINT
+    CHECK (token_expiry_override > 0);
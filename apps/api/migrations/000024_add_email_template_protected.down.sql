@@ -0,0 +1,5 @@
+-- Remove template protection
+
+DELETE FROM permissions WHERE name = 'template.manage.protected';
+
+ALTER TABLE email_templates DROP COLUMN IF EXISTS protected;
@@ -0,0 +1,12 @@
+-- Protected email templates can only be edited or deleted by holders of the
+-- template.manage.protected permission, which is granted to super_admin
+ALTER TABLE email_templates ADD COLUMN protected BOOLEAN NOT NULL DEFAULT false;
+
+INSERT INTO permissions (name, resource, action, description) VALUES
+    ('template.manage.protected', 'template', 'manage_protected', 'Edit and delete protected email templates')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'super_admin' AND p.name = 'template.manage.protected'
+ON CONFLICT DO NOTHING;
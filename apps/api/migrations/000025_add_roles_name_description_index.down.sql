@@ -0,0 +1,3 @@
+-- Remove the role list search index
+
+DROP INDEX IF EXISTS idx_roles_name_description;
@@ -0,0 +1,3 @@
+-- Supports the role list search over name and description, which returns
+-- roles ordered by name
+CREATE INDEX IF NOT EXISTS idx_roles_name_description ON roles (name, description);
@@ -0,0 +1,6 @@
+-- Rollback permission categories
+
+DROP INDEX IF EXISTS idx_permissions_category_id;
+ALTER TABLE permissions DROP COLUMN IF EXISTS category_id;
+DROP TRIGGER IF EXISTS update_permission_categories_updated_at ON permission_categories;
+DROP TABLE IF EXISTS permission_categories;
@@ -0,0 +1,33 @@
+-- Group permissions into categories so that long permission lists can be
+-- browsed and filtered
+CREATE TABLE permission_categories (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) UNIQUE NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER update_permission_categories_updated_at
+    BEFORE UPDATE ON permission_categories
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Deleting a category leaves its permissions uncategorized
+ALTER TABLE permissions ADD COLUMN category_id UUID REFERENCES permission_categories(id) ON DELETE SET NULL;
+CREATE INDEX idx_permissions_category_id ON permissions(category_id);
+
+INSERT INTO permission_categories (name, description) VALUES
+    ('user_management', 'Profiles, user accounts and their roles'),
+    ('content', 'Moderation of user content'),
+    ('administration', 'Admin panel access and system settings'),
+    ('email_templates', 'Management of email templates')
+ON CONFLICT (name) DO NOTHING;
+
+UPDATE permissions SET category_id = permission_categories.id
+FROM permission_categories
+WHERE permissions.category_id IS NULL AND (
+    (permission_categories.name = 'user_management' AND permissions.resource IN ('profile', 'users')) OR
+    (permission_categories.name = 'content' AND permissions.resource = 'content') OR
+    (permission_categories.name = 'administration' AND permissions.resource = 'admin') OR
+    (permission_categories.name = 'email_templates' AND permissions.resource = 'template')
+);
@@ -0,0 +1,3 @@
+-- Rollback permission metadata
+
+ALTER TABLE permissions DROP COLUMN IF EXISTS metadata;
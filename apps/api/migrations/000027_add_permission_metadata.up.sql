@@ -0,0 +1,2 @@
+-- UI hints for a permission, such as {"dangerous":true,"icon":"trash"}
+ALTER TABLE permissions ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
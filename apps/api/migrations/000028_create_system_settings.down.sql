@@ -0,0 +1,4 @@
+-- Rollback system settings
+
+DROP TRIGGER IF EXISTS update_system_settings_updated_at ON system_settings;
+DROP TABLE IF EXISTS system_settings;
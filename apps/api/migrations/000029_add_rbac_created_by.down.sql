@@ -0,0 +1,6 @@
+-- Rollback role and permission authorship
+
+ALTER TABLE permissions DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE permissions DROP COLUMN IF EXISTS created_by;
+ALTER TABLE roles DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE roles DROP COLUMN IF EXISTS created_by;
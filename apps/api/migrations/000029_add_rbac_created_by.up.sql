@@ -0,0 +1,5 @@
+-- Track who created and last updated each role and permission
+ALTER TABLE roles ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE roles ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE permissions ADD COLUMN created_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE permissions ADD COLUMN updated_by UUID REFERENCES users(id) ON DELETE SET NULL;
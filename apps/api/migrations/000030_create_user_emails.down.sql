@@ -0,0 +1,6 @@
+-- Rollback user email addresses
+
+DELETE FROM email_templates WHERE name = 'email_verification';
+DROP TRIGGER IF EXISTS user_emails_sync_primary_trigger ON users;
+DROP FUNCTION IF EXISTS user_emails_sync_primary();
+DROP TABLE IF EXISTS user_emails;
@@ -0,0 +1,182 @@
+-- Email addresses of each user. The primary address mirrors users.email,
+-- which is still the one used to log in; other addresses must be verified
+-- before they can become primary.
+CREATE TABLE IF NOT EXISTS user_emails (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    is_primary BOOLEAN NOT NULL DEFAULT false,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    verification_token_hash VARCHAR(64) UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_user_emails_user_email ON user_emails(user_id, email);
+CREATE UNIQUE INDEX idx_user_emails_primary ON user_emails(user_id) WHERE is_primary;
+CREATE INDEX idx_user_emails_email ON user_emails(email);
+
+-- Existing addresses become verified primary entries
+INSERT INTO user_emails (user_id, email, is_primary, verified_at, created_at)
+SELECT id, email, true, created_at, created_at FROM users;
+
+-- Keep the primary entry in sync with users.email, whichever code path
+-- creates the user or changes their address. When the new address is one
+-- of the user's other addresses, that entry becomes primary instead.
+CREATE OR REPLACE FUNCTION user_emails_sync_primary()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        INSERT INTO user_emails (user_id, email, is_primary, verified_at)
+        VALUES (NEW.id, NEW.email, true, NOW());
+    ELSIF EXISTS (SELECT 1 FROM user_emails WHERE user_id = NEW.id AND email = NEW.email) THEN
+        UPDATE user_emails SET is_primary = false
+        WHERE user_id = NEW.id AND is_primary AND email <> NEW.email;
+        UPDATE user_emails SET is_primary = true, verified_at = COALESCE(verified_at, NOW())
+        WHERE user_id = NEW.id AND email = NEW.email;
+    ELSE
+        UPDATE user_emails SET email = NEW.email, verified_at = NOW()
+        WHERE user_id = NEW.id AND is_primary;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE 'plpgsql';
+
+CREATE TRIGGER user_emails_sync_primary_trigger
+    AFTER INSERT OR UPDATE OF email ON users
+    FOR EACH ROW EXECUTE FUNCTION user_emails_sync_primary();
+
+-- Insert default email verification template
+INSERT INTO email_templates (name, language, subject, html_template, text_template, variables) VALUES 
+('email_verification', 'en', 'Verify your email address', 
+'<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Verify Your Email</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, ''Segoe UI'', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.CompanyName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Verify Your Email Address</h2>
+            <p>This address was added to your account. Click the button below to confirm that it belongs to you:</p>
+            
+            <a href="{{.VerificationURL}}" class="button">Verify Email</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Security Notice:</strong> If you didn''t add this address to an account, please ignore this email. It will not be verified.
+            </div>
+            
+            <p>If the button doesn''t work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">{{.VerificationURL}}</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from {{.CompanyName}}. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>',
+'{{.CompanyName}} - Verify Your Email
+
+This address was added to your account.
+
+Please click or copy the following link to confirm that it belongs to you:
+{{.VerificationURL}}
+
+If you didn''t add this address to an account, please ignore this email.
+It will not be verified.
+
+If you have any questions, please contact our support team.
+
+---
+{{.CompanyName}}',
+'[{"name": "CompanyName", "description": "The name of the company sending the email"}, {"name": "VerificationURL", "description": "The URL for verifying the email address"}]'::jsonb
+);
@@ -0,0 +1,6 @@
+-- Restore the single-column audit log indexes
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_id ON audit_logs (actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs (resource_type, resource_id);
+DROP INDEX IF EXISTS idx_audit_logs_resource_created_at;
+DROP INDEX IF EXISTS idx_audit_logs_actor_created_at;
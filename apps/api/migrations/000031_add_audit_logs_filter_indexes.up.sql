@@ -0,0 +1,6 @@
+-- Support the audit log filters, which return entries newest first. The
+-- composite indexes replace the single-column actor and resource indexes.
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor_created_at ON audit_logs (actor_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource_created_at ON audit_logs (resource_type, resource_id, created_at DESC);
+DROP INDEX IF EXISTS idx_audit_logs_actor_id;
+DROP INDEX IF EXISTS idx_audit_logs_resource;
@@ -0,0 +1,3 @@
+-- Rollback admin password reset email template
+
+DELETE FROM email_templates WHERE name = 'admin_password_reset';
@@ -0,0 +1,134 @@
+-- Email telling a user that an administrator has reset their password
+INSERT INTO email_templates (name, language, subject, html_template, text_template, variables) VALUES 
+('admin_password_reset', 'en', 'Your password was reset', 
+'<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Password Reset by Administrator</title>
+    <style>
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, ''Segoe UI'', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            line-height: 1.6;
+            color: #333333;
+            background-color: #f5f5f5;
+            margin: 0;
+            padding: 0;
+        }
+        .container {
+            max-width: 600px;
+            margin: 20px auto;
+            background: #ffffff;
+            border-radius: 8px;
+            box-shadow: 0 2px 10px rgba(0, 0, 0, 0.1);
+            overflow: hidden;
+        }
+        .header {
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+        .header h1 {
+            margin: 0;
+            font-size: 28px;
+            font-weight: 600;
+        }
+        .content {
+            padding: 40px 30px;
+        }
+        .content h2 {
+            color: #333333;
+            margin: 0 0 20px 0;
+            font-size: 24px;
+            font-weight: 600;
+        }
+        .content p {
+            margin: 0 0 20px 0;
+            font-size: 16px;
+            line-height: 1.6;
+        }
+        .button {
+            display: inline-block;
+            background: linear-gradient(135deg, #667eea 0%, #764ba2 100%);
+            color: white !important;
+            text-decoration: none;
+            padding: 15px 30px;
+            border-radius: 6px;
+            font-weight: 600;
+            font-size: 16px;
+            margin: 20px 0;
+            transition: transform 0.2s ease;
+        }
+        .button:hover {
+            transform: translateY(-2px);
+        }
+        .security-notice {
+            background: #f8f9fa;
+            border-left: 4px solid #ffc107;
+            padding: 15px;
+            margin: 30px 0;
+            border-radius: 4px;
+        }
+        .footer {
+            background: #f8f9fa;
+            padding: 20px 30px;
+            text-align: center;
+            color: #666666;
+            font-size: 14px;
+        }
+        .footer a {
+            color: #667eea;
+            text-decoration: none;
+        }
+        @media (max-width: 600px) {
+            .container {
+                margin: 10px;
+                border-radius: 0;
+            }
+            .header, .content, .footer {
+                padding: 20px;
+            }
+        }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>{{.CompanyName}}</h1>
+        </div>
+        <div class="content">
+            <h2>Hi {{.Name}},</h2>
+            <p>An administrator has reset the password for your account. Ask them for your new password, then sign in:</p>
+            
+            <a href="{{.LoginURL}}" class="button">Sign In</a>
+            
+            <div class="security-notice">
+                <strong>⚠️ Security Notice:</strong> If you didn''t ask for your password to be reset, please contact our support team right away.
+            </div>
+            
+            <p>If the button doesn''t work, you can copy and paste this link into your browser:</p>
+            <p style="word-break: break-all; color: #667eea;">{{.LoginURL}}</p>
+        </div>
+        <div class="footer">
+            <p>This email was sent from {{.CompanyName}}. If you have any questions, please contact our support team.</p>
+        </div>
+    </div>
+</body>
+</html>',
+'{{.CompanyName}} - Password Reset by Administrator
+
+Hi {{.Name}},
+
+An administrator has reset the password for your account. Ask them for your
+new password, then sign in:
+{{.LoginURL}}
+
+If you didn''t ask for your password to be reset, please contact our support
+team right away.
+
+---
+{{.CompanyName}}',
+'[{"name": "Name", "description": "The name of the user whose password was reset"}, {"name": "LoginURL", "description": "The URL of the login page"}, {"name": "CompanyName", "description": "The name of the company sending the email"}]'::jsonb
+);
@@ -0,0 +1,3 @@
+-- Rollback role access windows
+
+ALTER TABLE roles DROP COLUMN IF EXISTS access_windows;
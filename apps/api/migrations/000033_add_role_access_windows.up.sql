@@ -0,0 +1,4 @@
+-- Limit when a role is active, e.g.
+-- [{"days":["Mon","Fri"],"start":"09:00","end":"17:00","tz":"UTC"}]. Roles
+-- without access windows are always active.
+ALTER TABLE roles ADD COLUMN access_windows JSONB NOT NULL DEFAULT '[]';
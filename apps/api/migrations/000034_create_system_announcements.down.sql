@@ -0,0 +1,4 @@
+-- Rollback system announcements and their reads
+
+DROP TABLE IF EXISTS user_announcement_reads;
+DROP TABLE IF EXISTS system_announcements;
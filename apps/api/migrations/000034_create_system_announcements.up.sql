@@ -0,0 +1,29 @@
+-- Create system_announcements table holding messages admins post to every
+-- user. An announcement is active from visible_from until visible_until, or
+-- indefinitely when visible_until is NULL.
+CREATE TABLE system_announcements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    title VARCHAR(200) NOT NULL,
+    body TEXT NOT NULL,
+    type VARCHAR(20) NOT NULL DEFAULT 'info' CHECK (type IN ('info', 'warning', 'critical')),
+    visible_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    visible_until TIMESTAMP WITH TIME ZONE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_system_announcements_visibility ON system_announcements(visible_from, visible_until);
+
+CREATE TRIGGER update_system_announcements_updated_at
+    BEFORE UPDATE ON system_announcements
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create user_announcement_reads table recording which announcements each
+-- user has marked read
+CREATE TABLE user_announcement_reads (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    announcement_id UUID NOT NULL REFERENCES system_announcements(id) ON DELETE CASCADE,
+    read_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, announcement_id)
+);
@@ -0,0 +1,3 @@
+-- Rollback field masking rules
+
+DROP TABLE IF EXISTS field_masking_rules;
@@ -0,0 +1,18 @@
+-- Create field_masking_rules table. A rule masks a field of a model (such as
+-- the user's phone) in API responses for viewers holding the role, unless
+-- another of their roles has no rule for it or they have the
+-- <model>.read.<field> permission.
+CREATE TABLE field_masking_rules (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON UPDATE CASCADE ON DELETE CASCADE,
+    model VARCHAR(50) NOT NULL,
+    field VARCHAR(100) NOT NULL,
+    mask_type VARCHAR(10) NOT NULL CHECK (mask_type IN ('hidden', 'partial', 'full')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (role_name, model, field)
+);
+
+CREATE TRIGGER update_field_masking_rules_updated_at
+    BEFORE UPDATE ON field_masking_rules
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
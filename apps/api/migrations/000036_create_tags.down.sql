@@ -0,0 +1,4 @@
+-- Rollback user tags
+
+DROP TABLE IF EXISTS user_tags;
+DROP TABLE IF EXISTS tags;
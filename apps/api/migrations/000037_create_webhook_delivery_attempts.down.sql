@@ -0,0 +1,3 @@
+-- Rollback webhook delivery attempts
+
+DROP TABLE IF EXISTS webhook_delivery_attempts;
@@ -0,0 +1,19 @@
+-- Create webhook_delivery_attempts table. Every POST to a webhook is recorded,
+-- including retries and replays, so failed deliveries can be inspected and
+-- replayed. A replay points at the attempt it re-sent through replay_of.
+CREATE TABLE webhook_delivery_attempts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    webhook_id UUID NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    response_status INTEGER,
+    response_body TEXT,
+    error TEXT,
+    succeeded BOOLEAN NOT NULL DEFAULT false,
+    replay_of UUID REFERENCES webhook_delivery_attempts(id) ON DELETE SET NULL,
+    replay_depth INTEGER NOT NULL DEFAULT 0,
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create index for listing a webhook's deliveries, newest first
+CREATE INDEX idx_webhook_delivery_attempts_webhook_id ON webhook_delivery_attempts(webhook_id, attempted_at DESC);
@@ -0,0 +1,3 @@
+-- Rollback role data scopes
+
+ALTER TABLE roles DROP COLUMN IF EXISTS data_scope;
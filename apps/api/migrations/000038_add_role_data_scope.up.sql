@@ -0,0 +1,3 @@
+-- Limit the users a role's holders can list and update, e.g.
+-- {"company_id":"..."}. Roles without a data scope see every user.
+ALTER TABLE roles ADD COLUMN data_scope JSONB;
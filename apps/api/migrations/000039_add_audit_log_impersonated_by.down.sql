@@ -0,0 +1,3 @@
+-- Rollback audit log impersonators
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS impersonated_by;
@@ -0,0 +1,2 @@
+-- Record the admin who performed an action while impersonating the actor
+ALTER TABLE audit_logs ADD COLUMN impersonated_by UUID REFERENCES users(id) ON DELETE SET NULL;
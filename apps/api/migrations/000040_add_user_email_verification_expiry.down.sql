@@ -0,0 +1,3 @@
+-- Rollback email verification expiry
+
+ALTER TABLE user_emails DROP COLUMN IF EXISTS verification_expires_at;
@@ -0,0 +1,7 @@
+-- Email verification links expire like password reset links. Pending links
+-- sent before this migration expire a day after the address was added.
+ALTER TABLE user_emails ADD COLUMN verification_expires_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE user_emails
+SET verification_expires_at = created_at + INTERVAL '24 hours'
+WHERE verification_token_hash IS NOT NULL;
@@ -0,0 +1,3 @@
+-- Rollback webhook delivery attempt age index
+
+DROP INDEX IF EXISTS idx_webhook_delivery_attempts_attempted_at;
@@ -0,0 +1,3 @@
+-- Delivery attempts are deleted once they are older than the retention
+-- period, so index them by age for the cleanup job
+CREATE INDEX idx_webhook_delivery_attempts_attempted_at ON webhook_delivery_attempts(attempted_at);
@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getAdminPasswordResetTestCase tests admins setting a user's password
+func getAdminPasswordResetTestCase() TestCase {
+	user := GenerateTestUser()
+	newPassword := "AdminReset123!"
+
+	return TestCase{
+		Name: "Admin Password Reset",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and a user with a pending reset token",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.UserToken = CreateTestUser(t, config.App, user)
+					user.ID = userIDByEmail(t, config, user.Email)
+
+					resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/forgot-password", dto.ForgotPasswordRequest{Email: user.Email}, nil)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/reset-password should enforce the password policy",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AdminResetPasswordRequest{NewPassword: "weak"}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+user.ID+"/reset-password", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/reset-password should return 404 for an unknown user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AdminResetPasswordRequest{NewPassword: newPassword}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+uuid.New().String()+"/reset-password", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrUserNotFound)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/reset-password should be forbidden for regular users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AdminResetPasswordRequest{NewPassword: newPassword}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+user.ID+"/reset-password", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, apperrors.ErrPermissionDenied)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/reset-password should set the password and notify the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AdminResetPasswordRequest{NewPassword: newPassword, NotifyUser: true}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+user.ID+"/reset-password", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Verify: the new password works, the old one and reset tokens do not",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/login", user.ToLoginRequest(), nil)
+					require.NoError(t, err)
+					RequireErrorCode(t, resp, 401, apperrors.ErrAuthInvalidCredentials)
+
+					var tokens int64
+					require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = ?", user.ID).Scan(&tokens).Error)
+					require.Zero(t, tokens)
+
+					requireAuditEntry(t, config, ctx, services.AuditActionUserPasswordReset, user.ID)
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", dto.LoginRequest{Email: user.Email, Password: newPassword}, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/reset-password should also succeed without notifying the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AdminResetPasswordRequest{NewPassword: "AdminReset456!", NotifyUser: false}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+user.ID+"/reset-password", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
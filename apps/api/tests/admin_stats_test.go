@@ -0,0 +1,95 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getAdminStatsTestCase tests the admin statistics endpoint against seeded data
+func getAdminStatsTestCase() TestCase {
+	var baseline dto.AdminStatsResponse
+
+	return TestCase{
+		Name: "Admin Statistics",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and record baseline statistics",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					services.InvalidateStatsCache()
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/stats", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					ReadJsonResult(t, resp, &baseline)
+					require.Positive(t, baseline.TotalUsers)
+					require.Positive(t, baseline.DBConnectionsOpen)
+				},
+			},
+			{
+				Name: "Statistics should be cached after seeding data",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					suffix := uuid.New().String()[:8]
+					now := time.Now().UTC()
+
+					// Two recent logins, one outside the active window, one never logged in
+					for _, lastLogin := range []interface{}{now.Add(-time.Hour), now.Add(-29 * 24 * time.Hour), now.Add(-31 * 24 * time.Hour), nil} {
+						err := config.DB.Exec(
+							"INSERT INTO users (email, password, name, last_login_at) VALUES (?, 'x', ?, ?)",
+							GenerateUniqueEmail(), GenerateUniqueName(), lastLogin,
+						).Error
+						require.NoError(t, err)
+					}
+					require.NoError(t, config.DB.Exec("INSERT INTO roles (name) VALUES (?)", "stats-"+suffix).Error)
+					require.NoError(t, config.DB.Exec(
+						"INSERT INTO permissions (name, resource, action) VALUES (?, 'stats', 'read')", "stats."+suffix,
+					).Error)
+					require.NoError(t, config.DB.Exec(
+						"INSERT INTO email_templates (name, subject, html_template, text_template) VALUES (?, 'Subject', '<p>Body</p>', 'Body')", "stats_"+suffix,
+					).Error)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/stats", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.AdminStatsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, baseline.TotalUsers, result.TotalUsers)
+					require.True(t, baseline.GeneratedAt.Equal(result.GeneratedAt))
+				},
+			},
+			{
+				Name: "Recomputed statistics should include the seeded data",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					services.InvalidateStatsCache()
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/stats", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.AdminStatsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, baseline.TotalUsers+4, result.TotalUsers)
+					require.Equal(t, baseline.ActiveUsers+2, result.ActiveUsers)
+					require.Equal(t, baseline.UsersToday+4, result.UsersToday)
+					require.Equal(t, baseline.TotalRoles+1, result.TotalRoles)
+					require.Equal(t, baseline.TotalPermissions+1, result.TotalPermissions)
+					require.Equal(t, baseline.TotalEmailTemplates+1, result.TotalEmailTemplates)
+					require.Positive(t, result.DBConnectionsOpen)
+					require.GreaterOrEqual(t, result.DBConnectionsIdle, 0)
+					require.LessOrEqual(t, result.DBConnectionsIdle, result.DBConnectionsOpen)
+					require.True(t, result.GeneratedAt.After(baseline.GeneratedAt))
+				},
+			},
+		},
+	}
+}
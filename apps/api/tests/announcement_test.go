@@ -0,0 +1,248 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// findAnnouncement returns the announcement with id from a list response, or
+// nil when it is not listed
+func findAnnouncement(result map[string]interface{}, id string) map[string]interface{} {
+	for _, item := range result["announcements"].([]interface{}) {
+		announcement := item.(map[string]interface{})
+		if announcement["id"] == id {
+			return announcement
+		}
+	}
+	return nil
+}
+
+// getAnnouncementTestCase tests announcement visibility windows and read
+// tracking
+func getAnnouncementTestCase() TestCase {
+	var activeID, scheduledID, expiredID string
+	suffix := uuid.New().String()[:8]
+
+	createAnnouncement := func(t *testing.T, config *TestConfig, ctx *TestContext, req dto.CreateAnnouncementRequest) string {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/announcements", req, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 201, resp.StatusCode)
+		return RequireJSONResponse(t, resp)["id"].(string)
+	}
+
+	return TestCase{
+		Name: "System Announcements",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/announcements should create an announcement",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.CreateAnnouncementRequest{Title: "Maintenance " + suffix, Body: "Scheduled maintenance tonight", Type: "warning"}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/announcements", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					RequireIsUUID(t, result["id"].(string))
+					require.Equal(t, "warning", result["type"])
+					require.Nil(t, result["visible_until"])
+					require.NotNil(t, result["created_by"])
+					activeID = result["id"].(string)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/announcements should reject an empty visibility window",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					from := time.Now().Add(time.Hour)
+					until := time.Now()
+					req := dto.CreateAnnouncementRequest{Title: "Backwards " + suffix, Body: "Never shown", VisibleFrom: &from, VisibleUntil: &until}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/announcements", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/announcements should only list active announcements",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					from := time.Now().Add(time.Hour)
+					scheduledID = createAnnouncement(t, config, ctx, dto.CreateAnnouncementRequest{Title: "Scheduled " + suffix, Body: "Not yet", VisibleFrom: &from})
+
+					from = time.Now().Add(-2 * time.Hour)
+					until := time.Now().Add(-time.Hour)
+					expiredID = createAnnouncement(t, config, ctx, dto.CreateAnnouncementRequest{Title: "Expired " + suffix, Body: "No longer", VisibleFrom: &from, VisibleUntil: &until})
+
+					return MakeRequest(t, config.App, "GET", "/api/v1/announcements", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					active := findAnnouncement(result, activeID)
+					require.NotNil(t, active)
+					require.Equal(t, "Maintenance "+suffix, active["title"])
+					require.NotContains(t, active, "read")
+					require.Nil(t, findAnnouncement(result, scheduledID))
+					require.Nil(t, findAnnouncement(result, expiredID))
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/announcements should list scheduled and expired announcements",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/announcements", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.NotNil(t, findAnnouncement(result, activeID))
+					require.NotNil(t, findAnnouncement(result, scheduledID))
+					require.NotNil(t, findAnnouncement(result, expiredID))
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/announcements should show the announcement unread",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/announcements", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					active := findAnnouncement(result, activeID)
+					require.NotNil(t, active)
+					require.Equal(t, false, active["read"])
+					require.GreaterOrEqual(t, result["unread"].(float64), float64(1))
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/announcements/:id/read should mark the announcement read",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/announcements/"+activeID+"/read", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/announcements/:id/read twice should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/announcements/"+activeID+"/read", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/announcements should show the announcement read",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/announcements", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					active := findAnnouncement(result, activeID)
+					require.NotNil(t, active)
+					require.Equal(t, true, active["read"])
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/announcements/:id/read on a scheduled announcement should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/announcements/"+scheduledID+"/read", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrAnnouncementNotFound)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/stats should count active announcements",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/stats", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.GreaterOrEqual(t, result["active_announcements"].(float64), float64(1))
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/announcements/:id should expire the announcement",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					until := time.Now().Add(-time.Second)
+					req := dto.UpdateAnnouncementRequest{VisibleUntil: dto.NewNullableTime(&until)}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/announcements/"+activeID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.NotNil(t, result["visible_until"])
+				},
+			},
+			{
+				Name: "GET /api/v1/announcements should no longer list the expired announcement",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/announcements", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Nil(t, findAnnouncement(RequireJSONResponse(t, resp), activeID))
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/announcements/:id with a null visible_until should make it visible indefinitely",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"visible_until": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/announcements/"+activeID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Contains(t, result, "visible_until")
+					require.Nil(t, result["visible_until"])
+				},
+			},
+			{
+				Name: "GET /api/v1/announcements should list the announcement again",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/announcements", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.NotNil(t, findAnnouncement(RequireJSONResponse(t, resp), activeID))
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/announcements/:id should delete the announcement",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/announcements/"+activeID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/announcements/:id should not find the deleted announcement",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/announcements/"+activeID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrAnnouncementNotFound)
+				},
+			},
+		},
+	}
+}
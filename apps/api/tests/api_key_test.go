@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// makeAPIKeyRequest makes a request authenticated with an API key
+func makeAPIKeyRequest(t *testing.T, config *TestConfig, method, path string, body interface{}, key string) (*http.Response, error) {
+	return MakeRequest(t, config.App, method, path, body, map[string]string{"X-API-Key": key})
+}
+
+// getAPIKeyTestCase tests the API key creation, usage and revocation cycle
+func getAPIKeyTestCase() TestCase {
+	var readKey, readKeyID, writeKey string
+
+	return TestCase{
+		Name: "API Keys",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/protected/api-keys should create a read-only key",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/api-keys", dto.CreateAPIKeyRequest{
+						Name:   "Reporting",
+						Scopes: []string{"user:read"},
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var result dto.CreateAPIKeyResponse
+					ReadJsonResult(t, resp, &result)
+					require.NotEmpty(t, result.Key)
+					RequireIsUUID(t, result.APIKey.ID)
+					require.Equal(t, "Reporting", result.APIKey.Name)
+					require.Equal(t, []string{"user:read"}, result.APIKey.Scopes)
+					require.Nil(t, result.APIKey.RevokedAt)
+
+					readKey = result.Key
+					readKeyID = result.APIKey.ID
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/api-keys with an unknown scope should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/api-keys", dto.CreateAPIKeyRequest{
+						Name:   "Too broad",
+						Scopes: []string{"admin"},
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile with the key should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return makeAPIKeyRequest(t, config, "GET", "/api/v1/protected/profile", nil, readKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var profile dto.ProfileResponse
+					ReadJsonResult(t, resp, &profile)
+					require.Equal(t, ctx.RegularUser.Email, profile.Email)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/profile with a read-only key should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return makeAPIKeyRequest(t, config, "PATCH", "/api/v1/protected/profile", map[string]string{"name": "Renamed"}, readKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "Creating an API key with an API key should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return makeAPIKeyRequest(t, config, "POST", "/api/v1/protected/api-keys", dto.CreateAPIKeyRequest{
+						Name:   "Escalation",
+						Scopes: []string{"user:write"},
+					}, readKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "Accepting the terms of service with an API key should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return makeAPIKeyRequest(t, config, "POST", "/api/v1/protected/tos/accept", dto.AcceptToSRequest{ToSVersion: "1.0"}, readKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, apperrors.ErrAPIKeyNotAllowed)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/profile with a write key should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					expiresAt := time.Now().Add(time.Hour)
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/api-keys", dto.CreateAPIKeyRequest{
+						Name:      "Sync",
+						Scopes:    []string{"user:read", "user:write"},
+						ExpiresAt: &expiresAt,
+					}, ctx.UserToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					var result dto.CreateAPIKeyResponse
+					ReadJsonResult(t, resp, &result)
+					writeKey = result.Key
+
+					return makeAPIKeyRequest(t, config, "PATCH", "/api/v1/protected/profile", map[string]string{"name": "Renamed By Key"}, writeKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var profile dto.ProfileResponse
+					ReadJsonResult(t, resp, &profile)
+					require.Equal(t, "Renamed By Key", profile.Name)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/api-keys should list keys with last use",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/api-keys", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result struct {
+						APIKeys []dto.APIKeyResponse `json:"api_keys"`
+						Total   int                  `json:"total"`
+					}
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, 2, result.Total)
+					require.Equal(t, "Sync", result.APIKeys[0].Name)
+					require.NotNil(t, result.APIKeys[0].ExpiresAt)
+					require.Equal(t, readKeyID, result.APIKeys[1].ID)
+					require.NotNil(t, result.APIKeys[1].LastUsedAt)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/api-keys/:id should revoke the key",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/api-keys/"+readKeyID, nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireSuccessResponse(t, resp, 200)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile with a revoked key should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return makeAPIKeyRequest(t, config, "GET", "/api/v1/protected/profile", nil, readKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/api-keys/:id of another user should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					otherToken := CreateTestUser(t, config.App, GenerateTestUser())
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/api-keys/"+readKeyID, nil, otherToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -2,10 +2,13 @@ package tests
 
 import (
 	"api/internal/dto"
+	"api/internal/handlers"
+	"fmt"
 	"log"
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,12 +27,13 @@ type TestCase struct {
 
 // TestContext holds shared data between test steps
 type TestContext struct {
-	AdminToken    string
-	UserToken     string
-	AdminUser     TestUser
-	RegularUser   TestUser
-	CreatedUserID string
-	CreatedRoleID string
+	AdminToken        string
+	UserToken         string
+	AdminUser         TestUser
+	RegularUser       TestUser
+	CreatedUserID     string
+	CreatedRoleID     string
+	CreatedTemplateID string
 }
 
 // TestApi is the main test function that runs all test cases
@@ -75,6 +79,63 @@ func getTestCases() []TestCase {
 		getAuthenticationTestCase(),
 		getProtectedRoutesTestCase(),
 		getAdminUserManagementTestCase(),
+		getRoleExpiryTestCase(),
+		getAuditLogTestCase(),
+		getEmailTemplateVersionTestCase(),
+		getEmailTemplateLanguageTestCase(),
+		getUserDeactivationTestCase(),
+		getUserImportTestCase(),
+		getDataExportTestCase(),
+		getErasureTestCase(),
+		getInvitationTestCase(),
+		getLoginHistoryTestCase(),
+		getPasswordHistoryTestCase(),
+		getUserPaginationTestCase(),
+		getAdminStatsTestCase(),
+		getAPIKeyTestCase(),
+		getWebhookTestCase(),
+		getPreferenceTestCase(),
+		getIdempotencyTestCase(),
+		getRoleCloneTestCase(),
+		getPermissionBulkAssignmentTestCase(),
+		getWildcardPermissionTestCase(),
+		getCompanyTestCase(),
+		getAvatarTestCase(),
+		getUserRestoreTestCase(),
+		getToSTestCase(),
+		getEmailDeliveryTestCase(),
+		getImpersonationTestCase(),
+		getTokenSettingsTestCase(),
+		getProtectedTemplateTestCase(),
+		getCleanupTestCase(),
+		getMyPermissionsTestCase(),
+		getBulkRolesTestCase(),
+		getRoleListTestCase(),
+		getPermissionCategoryTestCase(),
+		getEmailTemplateCloneTestCase(),
+		getContentTypeTestCase(),
+		getPermissionMetadataTestCase(),
+		getDefaultRoleTestCase(),
+		getProfileETagTestCase(),
+		getRBACExportTestCase(),
+		getCreatedByTestCase(),
+		getUserEmailTestCase(),
+		getAuditLogFilterTestCase(),
+		getChangePasswordTestCase(),
+		getUserExportTestCase(),
+		getRoleUsersTestCase(),
+		getEmailTemplateToggleTestCase(),
+		getUserMergeTestCase(),
+		getErrorCodesTestCase(),
+		getUserDetailTestCase(),
+		getAdminPasswordResetTestCase(),
+		getRoleAccessWindowTestCase(),
+		getAnnouncementTestCase(),
+		getProfilePatchTestCase(),
+		getFieldMaskingTestCase(),
+		getTagTestCase(),
+		getWebhookDeliveryTestCase(),
+		getDataScopeTestCase(),
 	}
 }
 
@@ -98,6 +159,7 @@ func getHealthCheckTestCase() TestCase {
 					if len(body) > 0 {
 						result := RequireJSONResponseFromBody(t, body)
 						require.Contains(t, result, "status")
+						require.Equal(t, "healthy", result["database"])
 					} else {
 						t.Log("Health endpoint returned empty body")
 					}
@@ -141,6 +203,21 @@ func getAuthenticationTestCase() TestCase {
 					
 					require.Equal(t, ctx.RegularUser.Email, userObj["email"])
 					require.Equal(t, ctx.RegularUser.Name, userObj["name"])
+					require.NotContains(t, result, "warnings", "A mobile number should not produce warnings")
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/register with a landline should warn but succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					landlineUser := GenerateTestUser()
+					landline := fmt.Sprintf("+6221%08d", uuid.New().ClockSequence())
+					landlineUser.Phone = &landline
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", landlineUser.ToRegisterRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, []interface{}{handlers.LandlinePhoneWarning}, result["warnings"])
 				},
 			},
 			{
@@ -163,6 +240,57 @@ func getAuthenticationTestCase() TestCase {
 					RequireErrorResponse(t, resp, 400)
 				},
 			},
+			{
+				Name: "POST /api/v1/auth/register with a disposable email should fail when REJECT_DISPOSABLE_EMAILS is set",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					t.Setenv("REJECT_DISPOSABLE_EMAILS", "true")
+
+					disposableUser := GenerateTestUser().ToRegisterRequest()
+					disposableUser.Email = "user-" + uuid.New().String()[:8] + "@mailinator.com"
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", disposableUser, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 400, "DISPOSABLE_EMAIL_NOT_ALLOWED")
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login should find an account stored with a plus tag after NORMALIZE_EMAIL_PLUS_ADDRESSING is set",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					taggedUser := GenerateTestUser()
+					taggedUser.Email = "user-" + uuid.New().String()[:8] + "+news@example.com"
+					CreateTestUser(t, config.App, taggedUser)
+
+					t.Setenv("NORMALIZE_EMAIL_PLUS_ADDRESSING", "true")
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", taggedUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/register with weak password should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					weakUser := GenerateTestUser().ToRegisterRequest()
+					weakUser.Password = InvalidTestData.WeakPassword
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", weakUser, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+					ResponseContains(t, resp, "at least 8 characters")
+				},
+			},
+			{
+				Name: "GET /api/v1/auth/password-policy should return the policy",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/auth/password-policy", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					policy := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(8), policy["min_length"])
+					require.Contains(t, policy, "require_special")
+				},
+			},
 			{
 				Name: "POST /api/v1/auth/login with valid credentials should return token",
 				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
@@ -273,13 +401,12 @@ func getProtectedRoutesTestCase() TestCase {
 				},
 			},
 			{
-				Name: "PUT /api/v1/protected/profile should update user profile",
+				Name: "PATCH /api/v1/protected/profile should update user profile",
 				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
 					updateReq := map[string]interface{}{
-						"name":    "Updated Name",
-						"company": "Updated Company",
+						"name": "Updated Name",
 					}
-					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/profile", updateReq, ctx.UserToken)
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", updateReq, ctx.UserToken)
 				},
 				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
 					require.Equal(t, 200, resp.StatusCode)
@@ -295,7 +422,6 @@ func getProtectedRoutesTestCase() TestCase {
 					
 					result := RequireJSONResponseFromBody(t, body)
 					require.Equal(t, "Updated Name", result["name"])
-					require.Equal(t, "Updated Company", result["company"])
 				},
 			},
 		},
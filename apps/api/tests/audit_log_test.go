@@ -0,0 +1,234 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// requireAuditEntry asserts the audit log contains an entry for action on resourceID
+func requireAuditEntry(t *testing.T, config *TestConfig, ctx *TestContext, action, resourceID string) {
+	resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/audit-logs?action="+action, nil, ctx.AdminToken)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	result := RequireJSONResponse(t, resp)
+	logs, ok := result["logs"].([]interface{})
+	require.True(t, ok, "Response should contain logs array")
+
+	for _, entry := range logs {
+		log := entry.(map[string]interface{})
+		if log["resource_id"] == resourceID {
+			require.Equal(t, ctx.AdminUser.ID, log["actor_id"])
+			return
+		}
+	}
+	t.Fatalf("no %s audit entry found for resource %s", action, resourceID)
+}
+
+// getAuditLogTestCase tests that admin mutations are recorded in the audit log
+func getAuditLogTestCase() TestCase {
+	return TestCase{
+		Name: "Audit Log",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "User creation should produce an audit entry",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					newUser := GenerateTestUser().ToAdminRegisterRequest([]string{"user"})
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", newUser, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					ctx.CreatedUserID = result["user"].(map[string]interface{})["id"].(string)
+
+					requireAuditEntry(t, config, ctx, "user.create", ctx.CreatedUserID)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Role update should produce an audit entry",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateRolesRequest{Roles: []string{"user", "admin"}}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID+"/roles", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					requireAuditEntry(t, config, ctx, "user.roles.update", ctx.CreatedUserID)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Permission deletion should produce an audit entry",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					permission := GenerateTestPermission()
+					req := dto.CreatePermissionRequest{
+						Name:        permission.Name,
+						Resource:    permission.Resource,
+						Action:      permission.Action,
+						Description: &permission.Description,
+					}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					permissionID := RequireJSONResponse(t, resp)["id"].(string)
+
+					resp, err = MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/permissions/"+permissionID, nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					requireAuditEntry(t, config, ctx, "permission.delete", permissionID)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/audit-logs should reject invalid dates",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/audit-logs?from=yesterday", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
+
+// getAuditLogFilterTestCase tests each audit log filter and the pagination
+// totals against seeded entries
+func getAuditLogFilterTestCase() TestCase {
+	resourceType := "seed-" + uuid.New().String()[:8]
+	var otherActorID string
+
+	listLogs := func(t *testing.T, config *TestConfig, ctx *TestContext, query url.Values) dto.PaginatedAuditLogsResponse {
+		query.Set("resource_type", resourceType)
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/audit-logs?"+query.Encode(), nil, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var page dto.PaginatedAuditLogsResponse
+		ReadJsonResult(t, resp, &page)
+		return page
+	}
+
+	return TestCase{
+		Name: "Audit Log Filters",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Seed audit entries from two actors over three days",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					other := GenerateTestUser()
+					CreateTestUser(t, config.App, other)
+					otherActorID = userIDByEmail(t, config, other.Email)
+
+					day := func(d, hour int) time.Time { return time.Date(2020, 1, d, hour, 0, 0, 0, time.UTC) }
+					entries := []models.AuditLog{
+						{ActorID: &adminUser.ID, Action: "seed.create", ResourceID: "first", CreatedAt: day(1, 10)},
+						{ActorID: &adminUser.ID, Action: "seed.update", ResourceID: "first", CreatedAt: day(2, 10)},
+						{ActorID: &adminUser.ID, Action: "seed.update", ResourceID: "second", CreatedAt: day(2, 23)},
+						{ActorID: &otherActorID, Action: "seed.delete", ResourceID: "second", CreatedAt: day(3, 10)},
+					}
+					for i := range entries {
+						entries[i].ResourceType = resourceType
+						require.NoError(t, config.DB.Create(&entries[i]).Error)
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Each filter should narrow the entries and the total",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					all := listLogs(t, config, ctx, url.Values{})
+					require.Equal(t, int64(4), all.Total)
+					require.Equal(t, "seed.delete", all.Logs[0].Action)
+
+					tests := []struct {
+						query   url.Values
+						actions []string
+					}{
+						{url.Values{"actor_id": {otherActorID}}, []string{"seed.delete"}},
+						{url.Values{"resource_id": {"first"}}, []string{"seed.update", "seed.create"}},
+						{url.Values{"action": {"seed.update"}}, []string{"seed.update", "seed.update"}},
+						{url.Values{"from": {"2020-01-02"}, "to": {"2020-01-02"}}, []string{"seed.update", "seed.update"}},
+						{url.Values{"from": {"2020-01-02T12:00:00Z"}}, []string{"seed.delete", "seed.update"}},
+						{url.Values{"to": {"2020-01-01T23:59:59Z"}}, []string{"seed.create"}},
+					}
+					for _, tt := range tests {
+						page := listLogs(t, config, ctx, tt.query)
+						require.Equal(t, int64(len(tt.actions)), page.Total, "query %s", tt.query.Encode())
+
+						var actions []string
+						for _, log := range page.Logs {
+							actions = append(actions, log.Action)
+						}
+						require.Equal(t, tt.actions, actions, "query %s", tt.query.Encode())
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "The last page should hold the remainder with the actor's email",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					page := listLogs(t, config, ctx, url.Values{"limit": {"3"}, "page": {"2"}})
+					require.Equal(t, int64(4), page.Total)
+					require.Equal(t, 2, page.TotalPages)
+					require.Len(t, page.Logs, 1)
+					require.Equal(t, "seed.create", page.Logs[0].Action)
+					require.NotNil(t, page.Logs[0].ActorEmail)
+					require.Equal(t, ctx.AdminUser.Email, *page.Logs[0].ActorEmail)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/audit-logs should reject an invalid actor ID",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/audit-logs?actor_id=someone", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
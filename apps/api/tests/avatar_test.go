@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// uploadAvatar posts content as the avatar file named filename
+func uploadAvatar(t *testing.T, app *fiber.App, token, filename string, content []byte) (*http.Response, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", "/api/v1/protected/profile/avatar", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return app.Test(req, -1)
+}
+
+// getAvatarTestCase tests uploading and serving profile pictures
+func getAvatarTestCase() TestCase {
+	var avatarURL string
+
+	return TestCase{
+		Name: "Avatar Upload",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/protected/profile/avatar should reject a text file named like an image",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					return uploadAvatar(t, config.App, ctx.UserToken, "avatar.png", []byte("this is plain text, not a PNG"))
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/profile/avatar should reject a GIF",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					gif := []byte("GIF89a\x01\x00\x01\x00\x00\x00\x00;")
+					return uploadAvatar(t, config.App, ctx.UserToken, "avatar.jpg", gif)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/profile/avatar should store a PNG",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					var img bytes.Buffer
+					require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 400, 300))))
+					return uploadAvatar(t, config.App, ctx.UserToken, "avatar.png", img.Bytes())
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.NotNil(t, result["avatar_url"])
+					avatarURL = result["avatar_url"].(string)
+					require.Regexp(t, `^/uploads/avatars/[0-9a-f-]{36}\.webp$`, avatarURL)
+				},
+			},
+			{
+				Name: "GET avatar_url should serve the WebP image",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", avatarURL, nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, "image/webp", resp.Header.Get("Content-Type"))
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// userIDByEmail looks up the ID of a registered test user
+func userIDByEmail(t *testing.T, config *TestConfig, email string) string {
+	var user struct {
+		ID string
+	}
+	require.NoError(t, config.DB.Raw("SELECT id FROM users WHERE email = ?", email).Scan(&user).Error)
+	require.NotEmpty(t, user.ID)
+	return user.ID
+}
+
+// userRoleNames returns the names of the roles assigned to a user
+func userRoleNames(t *testing.T, config *TestConfig, userID string) []string {
+	var names []string
+	require.NoError(t, config.DB.Raw(`
+		SELECT r.name FROM roles r JOIN user_roles ur ON ur.role_id = r.id
+		WHERE ur.user_id = ? ORDER BY r.name
+	`, userID).Scan(&names).Error)
+	return names
+}
+
+// getBulkRolesTestCase tests replacing the roles of several users at once
+func getBulkRolesTestCase() TestCase {
+	var firstID, secondID, missingID string
+
+	return TestCase{
+		Name: "Bulk Role Updates",
+		Steps: []TestStep{
+			{
+				Name: "PATCH /api/v1/admin/users/bulk-roles should report each user's outcome",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					first := GenerateTestUser()
+					CreateTestUser(t, config.App, first)
+					firstID = userIDByEmail(t, config, first.Email)
+
+					second := GenerateTestUser()
+					CreateTestUser(t, config.App, second)
+					secondID = userIDByEmail(t, config, second.Email)
+
+					missingID = uuid.New().String()
+
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/bulk-roles", map[string]interface{}{
+						"updates": []map[string]interface{}{
+							{"user_id": firstID, "roles": []string{"admin", "user"}},
+							{"user_id": missingID, "roles": []string{"user"}},
+							{"user_id": secondID, "roles": []string{"admin"}},
+						},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.BulkUpdateRolesResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, 2, result.Succeeded)
+					require.Equal(t, 1, result.Failed)
+					require.Len(t, result.Results, 3)
+
+					require.Equal(t, dto.BulkRoleUpdateResult{UserID: firstID, Status: "success"}, result.Results[0])
+					require.Equal(t, missingID, result.Results[1].UserID)
+					require.Equal(t, "error", result.Results[1].Status)
+					require.Equal(t, "user not found", result.Results[1].Error)
+					require.Equal(t, dto.BulkRoleUpdateResult{UserID: secondID, Status: "success"}, result.Results[2])
+				},
+			},
+			{
+				Name: "Successful updates should not be rolled back by a failed one",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					require.Equal(t, []string{"admin", "user"}, userRoleNames(t, config, firstID))
+					require.Equal(t, []string{"admin"}, userRoleNames(t, config, secondID))
+
+					var grantedBy string
+					require.NoError(t, config.DB.Raw("SELECT granted_by FROM user_roles WHERE user_id = ? LIMIT 1", firstID).Scan(&grantedBy).Error)
+					require.Equal(t, ctx.AdminUser.ID, grantedBy)
+					requireAuditEntry(t, config, ctx, services.AuditActionUserRolesUpdate, secondID)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+firstID+"/role-assignments", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/bulk-roles should reject unknown roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/bulk-roles", map[string]interface{}{
+						"updates": []map[string]interface{}{
+							{"user_id": firstID, "roles": []string{"user"}},
+							{"user_id": secondID, "roles": []string{"no-such-role"}},
+						},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "A rejected request should not change any roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					require.Equal(t, []string{"admin", "user"}, userRoleNames(t, config, firstID))
+
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/bulk-roles", map[string]interface{}{
+						"updates": []map[string]interface{}{
+							{"user_id": "not-a-uuid", "roles": []string{"user"}},
+						},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/bulk-roles should reject more than 100 updates",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					updates := make([]map[string]interface{}, 101)
+					for i := range updates {
+						updates[i] = map[string]interface{}{"user_id": uuid.New().String(), "roles": []string{"user"}}
+					}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/bulk-roles", map[string]interface{}{
+						"updates": updates,
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/bulk-roles should not let an admin remove their own admin role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/bulk-roles", map[string]interface{}{
+						"updates": []map[string]interface{}{
+							{"user_id": ctx.AdminUser.ID, "roles": []string{"user"}},
+						},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/bulk-roles should require admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/bulk-roles", map[string]interface{}{
+						"updates": []map[string]interface{}{
+							{"user_id": firstID, "roles": []string{"user"}},
+						},
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getChangePasswordTestCase tests that users can change their password after
+// confirming the current one, and that outstanding reset tokens stop working
+func getChangePasswordTestCase() TestCase {
+	regularUser := GenerateTestUser()
+	newPassword := "changed-password-1"
+
+	changePassword := func(current, password, confirm string) func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+		return func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+			req := dto.ChangePasswordRequest{CurrentPassword: current, NewPassword: password, ConfirmPassword: confirm}
+			return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/change-password", req, ctx.UserToken)
+		}
+	}
+
+	return TestCase{
+		Name: "Change Password",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.UserToken = CreateTestUser(t, config.App, regularUser)
+					ctx.RegularUser = regularUser
+					ctx.CreatedUserID = userIDByEmail(t, config, regularUser.Email)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name:        "PATCH /api/v1/protected/change-password should reject a wrong current password",
+				RequestFunc: changePassword("wrong-password", newPassword, newPassword),
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+					ResponseContains(t, resp, "Current password is incorrect")
+				},
+			},
+			{
+				Name:        "PATCH /api/v1/protected/change-password should reject a mismatched confirmation",
+				RequestFunc: changePassword(regularUser.Password, newPassword, "something-else-1"),
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+					ResponseContains(t, resp, "ConfirmPassword must match NewPassword")
+				},
+			},
+			{
+				Name:        "PATCH /api/v1/protected/change-password should apply the password policy",
+				RequestFunc: changePassword(regularUser.Password, "short", "short"),
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+					ResponseContains(t, resp, "at least 8 characters")
+				},
+			},
+			{
+				Name:        "PATCH /api/v1/protected/change-password should reject the current password",
+				RequestFunc: changePassword(regularUser.Password, regularUser.Password, regularUser.Password),
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+					ResponseContains(t, resp, "used recently")
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/change-password should change the password",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					err := config.DB.Exec("INSERT INTO password_reset_tokens (id, user_id, token, expires_at) VALUES (gen_random_uuid(), ?, ?, NOW() + INTERVAL '15 minutes')",
+						ctx.CreatedUserID, "pending-reset-"+ctx.CreatedUserID).Error
+					require.NoError(t, err)
+
+					return changePassword(regularUser.Password, newPassword, newPassword)(t, config, ctx)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Outstanding reset tokens should be revoked",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					var count int64
+					require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = ?", ctx.CreatedUserID).Scan(&count).Error)
+					require.Zero(t, count)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Login with the old password should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", regularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "Login with the new password should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.LoginRequest{Email: regularUser.Email, Password: newPassword}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package tests
+
+import (
+	"api/internal/models"
+	"api/internal/services"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getCleanupTestCase tests that the cleanup job deletes expired records only
+func getCleanupTestCase() TestCase {
+	var expiredResetToken, validResetToken models.PasswordResetToken
+	var expiredKey models.IdempotencyKey
+	var oldDelivery, recentDelivery models.WebhookDeliveryAttempt
+
+	return TestCase{
+		Name: "Expired Record Cleanup",
+		Steps: []TestStep{
+			{
+				Name: "A cleanup run should delete expired tokens, idempotency keys and old webhook deliveries",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					expiredResetToken = models.PasswordResetToken{
+						UserID:    adminUser.ID,
+						Token:     uuid.New().String(),
+						ExpiresAt: time.Now().Add(-time.Hour),
+					}
+					validResetToken = models.PasswordResetToken{
+						UserID:    adminUser.ID,
+						Token:     uuid.New().String(),
+						ExpiresAt: time.Now().Add(time.Hour),
+					}
+					require.NoError(t, config.DB.Create(&expiredResetToken).Error)
+					require.NoError(t, config.DB.Create(&validResetToken).Error)
+
+					expiredKey = models.IdempotencyKey{
+						Key:         uuid.New().String(),
+						RequestHash: "hash",
+						CreatedAt:   time.Now().Add(-services.IdempotencyKeyTTL - time.Hour),
+					}
+					require.NoError(t, config.DB.Create(&expiredKey).Error)
+
+					webhook := models.Webhook{URL: "http://localhost/cleanup", Secret: "secret", Events: []string{"user.created"}}
+					require.NoError(t, config.DB.Create(&webhook).Error)
+					oldDelivery = models.WebhookDeliveryAttempt{
+						WebhookID:   webhook.ID,
+						Event:       "user.created",
+						Payload:     models.JSONB(`{}`),
+						AttemptedAt: time.Now().Add(-services.DefaultWebhookDeliveryRetention - time.Hour),
+					}
+					recentDelivery = models.WebhookDeliveryAttempt{
+						WebhookID:   webhook.ID,
+						Event:       "user.created",
+						Payload:     models.JSONB(`{}`),
+						AttemptedAt: time.Now(),
+					}
+					require.NoError(t, config.DB.Create(&oldDelivery).Error)
+					require.NoError(t, config.DB.Create(&recentDelivery).Error)
+
+					deleted, err := services.NewCleanupService().Run()
+					require.NoError(t, err)
+					require.GreaterOrEqual(t, deleted[services.CleanupTablePasswordResetTokens], int64(1))
+					require.GreaterOrEqual(t, deleted[services.CleanupTableIdempotencyKeys], int64(1))
+					require.GreaterOrEqual(t, deleted[services.CleanupTableWebhookDeliveries], int64(1))
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/cleanup/stats", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.NotNil(t, result["last_run_at"])
+					deleted := result["deleted"].(map[string]interface{})
+					require.GreaterOrEqual(t, deleted[services.CleanupTablePasswordResetTokens].(float64), float64(1))
+					require.GreaterOrEqual(t, deleted[services.CleanupTableIdempotencyKeys].(float64), float64(1))
+				},
+			},
+			{
+				Name: "Only the expired records should be gone",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					var count int64
+					require.NoError(t, config.DB.Model(&models.PasswordResetToken{}).Where("id = ?", expiredResetToken.ID).Count(&count).Error)
+					require.Zero(t, count)
+					require.NoError(t, config.DB.Model(&models.PasswordResetToken{}).Where("id = ?", validResetToken.ID).Count(&count).Error)
+					require.Equal(t, int64(1), count)
+					require.NoError(t, config.DB.Model(&models.IdempotencyKey{}).Where("id = ?", expiredKey.ID).Count(&count).Error)
+					require.Zero(t, count)
+					require.NoError(t, config.DB.Model(&models.WebhookDeliveryAttempt{}).Where("id = ?", oldDelivery.ID).Count(&count).Error)
+					require.Zero(t, count)
+					require.NoError(t, config.DB.Model(&models.WebhookDeliveryAttempt{}).Where("id = ?", recentDelivery.ID).Count(&count).Error)
+					require.Equal(t, int64(1), count)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
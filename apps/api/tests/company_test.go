@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getCompanyTestCase tests company management and user membership
+func getCompanyTestCase() TestCase {
+	var companyID string
+	suffix := uuid.New().String()[:8]
+	companyName := "Acme " + suffix
+	renamedCompany := "Globex " + suffix
+
+	return TestCase{
+		Name: "Company Management",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/companies should create a company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					domain := "acme-" + suffix + ".example.com"
+					website := "https://acme-" + suffix + ".example.com"
+					req := dto.CreateCompanyRequest{Name: companyName, Domain: &domain, Website: &website}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/companies", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					RequireIsUUID(t, result["id"].(string))
+					require.Equal(t, companyName, result["name"])
+					require.Equal(t, "acme-"+suffix+".example.com", result["domain"])
+					companyID = result["id"].(string)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/companies with a taken name should conflict",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreateCompanyRequest{Name: companyName}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/companies", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/profile should join a company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					updateReq := map[string]interface{}{"company_id": companyID}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", updateReq, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.Equal(t, companyID, result["company_id"])
+					company := result["company"].(map[string]interface{})
+					require.Equal(t, companyName, company["name"])
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/profile with an unknown company should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					updateReq := map[string]interface{}{"company_id": uuid.New().String()}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", updateReq, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users should filter by company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users?company_id="+companyID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, int64(1), result.Total)
+					require.Equal(t, ctx.RegularUser.Email, result.Users[0].Email)
+					require.NotNil(t, result.Users[0].Company)
+					require.Equal(t, companyName, result.Users[0].Company.Name)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users with an invalid company_id should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users?company_id=not-a-uuid", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/companies/:id should rename the company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateCompanyRequest{Name: &renamedCompany}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/companies/"+companyID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, renamedCompany, result["name"])
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users should find members by the new company name",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users?search="+url.QueryEscape(renamedCompany), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Len(t, result.Users, 1)
+					require.Equal(t, ctx.RegularUser.Email, result.Users[0].Email)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/companies/:id should remove the company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/companies/"+companyID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile should no longer have a company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Nil(t, result["company_id"])
+					require.Nil(t, result["company"])
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/companies/:id should return 404 after deletion",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/companies/"+companyID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package tests
 
 import (
 	"api/internal/database"
+	"api/internal/dto"
 	"api/internal/server"
 	"bytes"
 	"encoding/json"
@@ -9,6 +10,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -77,6 +79,12 @@ func setTestEnvVars() {
 		"BCRYPT_COST":         getEnvWithDefault("TEST_BCRYPT_COST", "4"), // Lower cost for faster tests
 		"CORS_ALLOWED_ORIGINS": "*",
 		"LOG_LEVEL":           "error", // Reduce log noise during tests
+		"RATE_LIMIT_API_REQUESTS":  "10000", // Every test request shares one client IP
+		"RATE_LIMIT_AUTH_REQUESTS": "1000",
+		"PASSWORD_HISTORY_DEPTH":   "3",
+		"UPLOAD_DIR":               filepath.Join(os.TempDir(), "studio45-test-uploads"),
+		"EMAIL_DELIVERY_WEBHOOK_FORMAT": "SENDGRID_WEBHOOK",
+		"EMAIL_DELIVERY_WEBHOOK_SECRET": testEmailWebhookSecret,
 	}
 	
 	for key, value := range envVars {
@@ -96,11 +104,31 @@ func getEnvWithDefault(key, defaultValue string) string {
 func cleanupTestData(t *testing.T, db *gorm.DB) {
 	// Order matters due to foreign key constraints
 	tables := []string{
+		"audit_logs",
 		"user_roles",
 		"role_permissions", 
 		"password_reset_tokens",
+		"user_emails",
+		"login_events",
+		"password_history",
+		"api_keys",
+		"user_preferences",
+		"idempotency_keys",
+		"webhook_delivery_attempts",
+		"webhooks",
+		"email_template_versions",
 		"email_templates",
+		"user_invitations",
+		"user_tos_acceptances",
+		"tos_versions",
+		"system_settings",
+		"user_announcement_reads",
+		"system_announcements",
+		"field_masking_rules",
+		"user_tags",
+		"tags",
 		"users",
+		"companies",
 		"roles",
 		"permissions",
 	}
@@ -149,17 +177,32 @@ func MakeAuthenticatedRequest(t *testing.T, app *fiber.App, method, path string,
 	return MakeRequest(t, app, method, path, body, headers)
 }
 
-// CreateTestUser creates a test user in the database and returns auth token
-func CreateTestUser(t *testing.T, app *fiber.App, user TestUser, roles ...string) string {
-	// Register user
+// registerTestUser registers user, accepting the current terms of service
+// when one has been published
+func registerTestUser(t *testing.T, app *fiber.App, user TestUser) {
 	registerReq := user.ToRegisterRequest()
-	resp, err := MakeRequest(t, app, "POST", "/api/v1/auth/register", registerReq, nil)
+
+	resp, err := MakeRequest(t, app, "GET", "/api/v1/auth/tos/current", nil, nil)
+	require.NoError(t, err)
+	if resp.StatusCode == 200 {
+		var current dto.ToSVersionResponse
+		ReadJsonResult(t, resp, &current)
+		registerReq.ToSVersion = current.Version
+	}
+
+	resp, err = MakeRequest(t, app, "POST", "/api/v1/auth/register", registerReq, nil)
 	require.NoError(t, err)
 	require.Equal(t, 201, resp.StatusCode)
+}
+
+// CreateTestUser creates a test user in the database and returns auth token
+func CreateTestUser(t *testing.T, app *fiber.App, user TestUser, roles ...string) string {
+	// Register user
+	registerTestUser(t, app, user)
 	
 	// Login to get token
 	loginReq := user.ToLoginRequest()
-	resp, err = MakeRequest(t, app, "POST", "/api/v1/auth/login", loginReq, nil)
+	resp, err := MakeRequest(t, app, "POST", "/api/v1/auth/login", loginReq, nil)
 	require.NoError(t, err)
 	
 	token := RequireAuthToken(t, resp)
@@ -178,17 +221,14 @@ func CreateAdminUser(t *testing.T, config *TestConfig) (TestUser, string) {
 	adminUser := GenerateTestUser()
 	
 	// Register user first
-	registerReq := adminUser.ToRegisterRequest()
-	resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/register", registerReq, nil)
-	require.NoError(t, err)
-	require.Equal(t, 201, resp.StatusCode)
+	registerTestUser(t, config.App, adminUser)
 	
 	// Manually assign admin role in database
 	// Find the user
 	var user struct {
 		ID string `json:"id"`
 	}
-	err = config.DB.Raw("SELECT id FROM users WHERE email = ?", adminUser.Email).Scan(&user).Error
+	err := config.DB.Raw("SELECT id FROM users WHERE email = ?", adminUser.Email).Scan(&user).Error
 	require.NoError(t, err)
 	
 	// Create admin role if it doesn't exist
@@ -207,7 +247,7 @@ func CreateAdminUser(t *testing.T, config *TestConfig) (TestUser, string) {
 	
 	// Login to get token
 	loginReq := adminUser.ToLoginRequest()
-	resp, err = MakeRequest(t, config.App, "POST", "/api/v1/auth/login", loginReq, nil)
+	resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/login", loginReq, nil)
 	require.NoError(t, err)
 	
 	token := RequireAuthToken(t, resp)
@@ -224,7 +264,8 @@ func setupDefaultRolesAndPermissions(db *gorm.DB) {
 		INSERT INTO roles (id, name, description, created_at, updated_at) 
 		VALUES 
 			(gen_random_uuid(), 'admin', 'Administrator role with full access', NOW(), NOW()),
-			(gen_random_uuid(), 'user', 'Regular user role', NOW(), NOW())
+			(gen_random_uuid(), 'user', 'Regular user role', NOW(), NOW()),
+			(gen_random_uuid(), 'super_admin', 'Can impersonate other admins', NOW(), NOW())
 		ON CONFLICT (name) DO NOTHING
 	`)
 	
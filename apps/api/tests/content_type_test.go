@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getContentTypeTestCase tests that JSON endpoints reject other body formats
+func getContentTypeTestCase() TestCase {
+	return TestCase{
+		Name: "JSON Content-Type Enforcement",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/auth/login with an XML body should return 415",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					registerTestUser(t, config.App, ctx.RegularUser)
+
+					body := "<login><email>" + ctx.RegularUser.Email + "</email><password>" + ctx.RegularUser.Password + "</password></login>"
+					req, err := http.NewRequest("POST", "/api/v1/auth/login", strings.NewReader(body))
+					require.NoError(t, err)
+					req.Header.Set("Content-Type", "text/xml")
+
+					return config.App.Test(req, -1)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 415)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login with a JSON body should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", ctx.RegularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireAuthToken(t, resp)
+				},
+			},
+		},
+	}
+}
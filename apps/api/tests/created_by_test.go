@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// rbacActorColumns returns the created_by and updated_by columns of a row in
+// roles or permissions
+func rbacActorColumns(t *testing.T, config *TestConfig, table, id string) (createdBy, updatedBy *string) {
+	var row struct {
+		CreatedBy *string
+		UpdatedBy *string
+	}
+	require.NoError(t, config.DB.Raw("SELECT created_by, updated_by FROM "+table+" WHERE id = ?", id).Scan(&row).Error)
+	return row.CreatedBy, row.UpdatedBy
+}
+
+// getCreatedByTestCase tests that roles and permissions record the admins
+// who created and last updated them
+func getCreatedByTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	var roleID, permissionID, adminID string
+
+	return TestCase{
+		Name: "RBAC Created By",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/roles should record the creating admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+					adminID = userIDByEmail(t, config, adminUser.Email)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{Name: "audited-" + suffix}, token)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, ctx.AdminUser.Email, result["created_by_email"])
+					roleID = result["id"].(string)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id should include the creator's email",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					createdBy, updatedBy := rbacActorColumns(t, config, "roles", roleID)
+					require.Equal(t, &adminID, createdBy)
+					require.Equal(t, &adminID, updatedBy)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+roleID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, ctx.AdminUser.Email, RequireJSONResponse(t, resp)["created_by_email"])
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/roles/:id by another admin should only change updated_by",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					otherAdmin, token := CreateAdminUser(t, config)
+					otherID := userIDByEmail(t, config, otherAdmin.Email)
+
+					description := "Updated by another admin"
+					resp, err := MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+roleID, dto.UpdateRoleRequest{Description: &description}, token)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, ctx.AdminUser.Email, RequireJSONResponse(t, resp)["created_by_email"])
+
+					createdBy, updatedBy := rbacActorColumns(t, config, "roles", roleID)
+					require.Equal(t, &adminID, createdBy)
+					require.Equal(t, &otherID, updatedBy)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/permissions should record the creating admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreatePermissionRequest{Name: "audited." + suffix, Resource: "audited", Action: suffix}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, ctx.AdminUser.Email, result["created_by_email"])
+					permissionID = result["id"].(string)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/permissions/:id should include the creator's email",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					createdBy, _ := rbacActorColumns(t, config, "permissions", permissionID)
+					require.Equal(t, &adminID, createdBy)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/permissions/"+permissionID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, ctx.AdminUser.Email, RequireJSONResponse(t, resp)["created_by_email"])
+				},
+			},
+		},
+	}
+}
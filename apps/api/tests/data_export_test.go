@@ -0,0 +1,103 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireDataExport decodes a data export download and checks its headers
+func requireDataExport(t *testing.T, resp *http.Response, filename string) map[string]interface{} {
+	require.Equal(t, 200, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+	require.Equal(t, `attachment; filename="`+filename+`"`, resp.Header.Get("Content-Disposition"))
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	var export map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &export))
+	return export
+}
+
+// getDataExportTestCase tests the personal data export endpoints
+func getDataExportTestCase() TestCase {
+	regularUser := GenerateTestUser()
+
+	return TestCase{
+		Name: "Data Export",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create users with roles, audit entries and reset tokens",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.UserToken = CreateTestUser(t, config.App, regularUser)
+					ctx.RegularUser = regularUser
+					err := config.DB.Raw("SELECT id FROM users WHERE email = ?", regularUser.Email).Scan(&ctx.CreatedUserID).Error
+					require.NoError(t, err)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID+"/roles", dto.UpdateRolesRequest{Roles: []string{"user"}}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/forgot-password", map[string]string{"email": regularUser.Email}, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/data-export should include all personal data",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/data-export", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					export := requireDataExport(t, resp, "my_data.json")
+
+					profile := export["profile"].(map[string]interface{})
+					require.Equal(t, ctx.RegularUser.Email, profile["email"])
+
+					assignments := export["role_assignments"].([]interface{})
+					require.NotEmpty(t, assignments)
+					assignment := assignments[0].(map[string]interface{})
+					require.Contains(t, assignment, "granted_at")
+					require.Equal(t, ctx.AdminUser.ID, assignment["granted_by"])
+
+					require.NotEmpty(t, export["audit_logs"])
+
+					tokens := export["password_reset_tokens"].([]interface{})
+					require.NotEmpty(t, tokens)
+					require.NotContains(t, tokens[0], "token")
+
+					require.NotEmpty(t, export["login_history"])
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id/data-export should export any user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.CreatedUserID+"/data-export", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					export := requireDataExport(t, resp, "user_"+ctx.CreatedUserID+"_data.json")
+					require.Equal(t, ctx.CreatedUserID, export["profile"].(map[string]interface{})["id"])
+				},
+			},
+			{
+				Name: "Non-admin should not export other users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.AdminUser.ID+"/data-export", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+		},
+	}
+}
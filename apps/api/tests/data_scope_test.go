@@ -0,0 +1,210 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getDataScopeTestCase tests that an admin holding a role with a data scope
+// only lists and updates the users inside it
+func getDataScopeTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	roleName := "region-admin-" + suffix
+	var scopedCompanyID, otherCompanyID, scopedToken string
+	users := GenerateTestUsers(2)
+
+	createCompany := func(t *testing.T, config *TestConfig, ctx *TestContext, name string) string {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/companies", dto.CreateCompanyRequest{Name: name}, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 201, resp.StatusCode)
+		return RequireJSONResponse(t, resp)["id"].(string)
+	}
+
+	listUsers := func(t *testing.T, config *TestConfig, token, query string) []dto.UserManagementResponse {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users?limit=100"+query, nil, token)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var page dto.PaginatedUsersResponse
+		ReadJsonResult(t, resp, &page)
+		return page.Users
+	}
+
+	return TestCase{
+		Name: "Role Data Scopes",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin, two companies and a user in each",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					scopedCompanyID = createCompany(t, config, ctx, "Scoped "+suffix)
+					otherCompanyID = createCompany(t, config, ctx, "Other "+suffix)
+
+					for i, companyID := range []string{scopedCompanyID, otherCompanyID} {
+						CreateTestUser(t, config.App, users[i])
+						users[i].ID = userIDByEmail(t, config, users[i].Email)
+
+						resp, err := MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+users[i].ID, map[string]interface{}{"company_id": companyID}, token)
+						require.NoError(t, err)
+						require.Equal(t, 200, resp.StatusCode)
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/roles with a data scope on an unsupported column should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{
+						Name:      "bad-scope-" + suffix,
+						DataScope: map[string]interface{}{"password": "secret"},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+					ResponseContains(t, resp, "data_scope")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/roles should create a role with a data scope",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{
+						Name:      roleName,
+						DataScope: map[string]interface{}{"company_id": scopedCompanyID},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var role dto.RoleResponse
+					ReadJsonResult(t, resp, &role)
+					require.Equal(t, map[string]interface{}{"company_id": scopedCompanyID}, role.DataScope)
+				},
+			},
+			{
+				Name: "Setup: Create an admin holding the scoped role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					scopedAdmin, token := CreateAdminUser(t, config)
+					scopedToken = token
+
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+scopedAdmin.ID+"/roles", dto.UpdateRolesRequest{
+						Roles: []string{"admin", roleName},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users should only list users inside the data scope",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					var emails []string
+					for _, user := range listUsers(t, config, scopedToken, "") {
+						require.NotNil(t, user.CompanyID)
+						require.Equal(t, scopedCompanyID, *user.CompanyID)
+						emails = append(emails, user.Email)
+					}
+					require.Contains(t, emails, users[0].Email)
+					require.NotContains(t, emails, users[1].Email)
+
+					require.Empty(t, listUsers(t, config, scopedToken, "&company_id="+otherCompanyID))
+
+					cursorPage := listUsers(t, config, scopedToken, "&pagination=cursor")
+					for _, user := range cursorPage {
+						require.Equal(t, scopedCompanyID, *user.CompanyID)
+					}
+
+					var unscopedEmails []string
+					for _, user := range listUsers(t, config, ctx.AdminToken, "&company_id="+otherCompanyID) {
+						unscopedEmails = append(unscopedEmails, user.Email)
+					}
+					require.Contains(t, unscopedEmails, users[1].Email, "admins without a data scope should see every user")
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id outside the data scope should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+users[1].ID, map[string]interface{}{"name": "Out Of Scope"}, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, "USER_NOT_FOUND")
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id outside the data scope should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+users[1].ID, nil, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, "USER_NOT_FOUND")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate outside the data scope should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+users[1].ID+"/impersonate", nil, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, "USER_NOT_FOUND")
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/reset-password outside the data scope should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+users[1].ID+"/reset-password", dto.AdminResetPasswordRequest{
+						NewPassword: "Taken0ver!Pass",
+					}, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, "USER_NOT_FOUND")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users in another company should return 403",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", dto.AdminRegisterUserRequest{
+						Email:     "scoped-create-" + suffix + "@example.com",
+						Password:  "Scoped!Pass123",
+						Name:      "Scoped Create",
+						CompanyID: &otherCompanyID,
+					}, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, "DATA_SCOPE_VIOLATION")
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id inside the data scope should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+users[0].ID, map[string]interface{}{"name": "In Scope"}, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, "In Scope", result["name"])
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id moving a user out of the data scope should return 403",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+users[0].ID, map[string]interface{}{"company_id": otherCompanyID}, scopedToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, "DATA_SCOPE_VIOLATION")
+				},
+			},
+		},
+	}
+}
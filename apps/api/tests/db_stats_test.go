@@ -0,0 +1,44 @@
+package tests
+
+import (
+	"api/internal/database"
+	"api/internal/handlers"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDBPoolStats limits the pool to one connection so concurrent queries
+// have to wait, and checks the stats endpoint reports the waits
+func TestDBPoolStats(t *testing.T) {
+	SkipIfNoDatabase(t)
+	setTestEnvVars()
+
+	t.Setenv("DB_MAX_OPEN_CONNS", "1")
+	require.NoError(t, database.Connect())
+	t.Cleanup(func() { database.Close() })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.NoError(t, database.DB.Exec("SELECT pg_sleep(0.05)").Error)
+		}()
+	}
+	wg.Wait()
+
+	app := fiber.New()
+	app.Get("/api/v1/admin/db/stats", handlers.GetDBStats)
+	resp, err := app.Test(httptest.NewRequest("GET", "/api/v1/admin/db/stats", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	result := RequireJSONResponse(t, resp)
+	require.LessOrEqual(t, result["open"].(float64), float64(1))
+	require.Equal(t, float64(0), result["in_use"])
+	require.Positive(t, result["wait_count"].(float64), "queries beyond the single connection should have waited")
+}
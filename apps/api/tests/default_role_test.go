@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// setDefaultRole changes the default user role through the admin API
+func setDefaultRole(t *testing.T, config *TestConfig, ctx *TestContext, role string) (*http.Response, error) {
+	return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/settings/default-role", dto.DefaultRoleRequest{Role: &role}, ctx.AdminToken)
+}
+
+// getDefaultRoleTestCase tests that changing the default role applies to the
+// next registration without a restart
+func getDefaultRoleTestCase() TestCase {
+	newcomerRole := "newcomer-" + uuid.New().String()[:8]
+
+	return TestCase{
+		Name: "Default Role on Registration",
+		Steps: []TestStep{
+			{
+				Name: "GET /api/v1/admin/settings/default-role should return the user role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.CreateRoleRequest{Name: newcomerRole}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/settings/default-role", nil, token)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.DefaultRoleResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, services.DefaultUserRole, result.Role)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/settings/default-role should reject an unknown role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return setDefaultRole(t, config, ctx, "missing-"+uuid.New().String()[:8])
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "Registrations after changing the default role should get the new role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := setDefaultRole(t, config, ctx, newcomerRole)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					requireAuditEntry(t, config, ctx, services.AuditActionSettingUpdate, services.SettingDefaultUserRole)
+
+					ctx.RegularUser = GenerateTestUser()
+					registerTestUser(t, config.App, ctx.RegularUser)
+
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "The new user should have only the new default role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					userID := userIDByEmail(t, config, ctx.RegularUser.Email)
+					require.Equal(t, []string{newcomerRole}, userRoleNames(t, config, userID))
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "An empty default role should register users without a role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := setDefaultRole(t, config, ctx, "")
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					user := GenerateTestUser()
+					registerTestUser(t, config.App, user)
+					require.Empty(t, userRoleNames(t, config, userIDByEmail(t, config, user.Email)))
+
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Cleanup: Restore the user role as the default",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return setDefaultRole(t, config, ctx, services.DefaultUserRole)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.DefaultRoleResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, services.DefaultUserRole, result.Role)
+				},
+			},
+		},
+	}
+}
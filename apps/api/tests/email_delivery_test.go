@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// testEmailWebhookKey signs SendGrid events; testEmailWebhookSecret is its
+// verification key, configured as EMAIL_DELIVERY_WEBHOOK_SECRET
+var testEmailWebhookKey, testEmailWebhookSecret = newSendGridWebhookKey()
+
+func newSendGridWebhookKey() (*ecdsa.PrivateKey, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		panic(err)
+	}
+	return key, base64.StdEncoding.EncodeToString(der)
+}
+
+// postEmailDeliveryEvents sends SendGrid events to the delivery webhook,
+// signed with key
+func postEmailDeliveryEvents(t *testing.T, app *fiber.App, events []map[string]interface{}, key *ecdsa.PrivateKey) (*http.Response, error) {
+	body, err := json.Marshal(events)
+	require.NoError(t, err)
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	digest := sha256.Sum256(append([]byte(timestamp), body...))
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("POST", "/api/v1/webhooks/email/delivery", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(services.SendGridSignatureHeader, base64.StdEncoding.EncodeToString(signature))
+	req.Header.Set(services.SendGridTimestampHeader, timestamp)
+	return app.Test(req, -1)
+}
+
+// getEmailDeliveryTestCase tests that provider bounce reports deactivate users
+func getEmailDeliveryTestCase() TestCase {
+	var userID string
+
+	return TestCase{
+		Name: "Email Delivery Webhook",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/webhooks/email/delivery with a bad signature should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+					require.NoError(t, err)
+					userID = RequireJSONResponse(t, resp)["id"].(string)
+
+					events := []map[string]interface{}{{"email": ctx.RegularUser.Email, "event": "bounce"}}
+					otherKey, _ := newSendGridWebhookKey()
+					return postEmailDeliveryEvents(t, config.App, events, otherKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "POST /api/v1/webhooks/email/delivery should record deliveries",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					events := []map[string]interface{}{
+						{"email": ctx.RegularUser.Email, "event": "delivered"},
+						{"email": "nobody-" + ctx.RegularUser.Email, "event": "bounce"},
+					}
+					return postEmailDeliveryEvents(t, config.App, events, testEmailWebhookKey)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(2), result["processed"])
+				},
+			},
+			{
+				Name: "POST /api/v1/webhooks/email/delivery with a bounce should deactivate the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					events := []map[string]interface{}{{"email": ctx.RegularUser.Email, "event": "bounce"}}
+					resp, err := postEmailDeliveryEvents(t, config.App, events, testEmailWebhookKey)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					path := "/api/v1/admin/users?search=" + url.QueryEscape(ctx.RegularUser.Email)
+					return MakeAuthenticatedRequest(t, config.App, "GET", path, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Len(t, result.Users, 1)
+					require.False(t, result.Users[0].IsActive)
+					require.NotNil(t, result.Users[0].EmailDeliveryStatus)
+					require.Equal(t, services.EmailDeliveryBounced, *result.Users[0].EmailDeliveryStatus)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/audit-logs should record the deactivation",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/audit-logs?action="+services.AuditActionUserDeactivate, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					logs := result["logs"].([]interface{})
+
+					for _, entry := range logs {
+						log := entry.(map[string]interface{})
+						if log["resource_id"] == userID {
+							require.Nil(t, log["actor_id"])
+							return
+						}
+					}
+					t.Fatalf("no deactivation audit entry found for user %s", userID)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login should reject the bounced user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", ctx.RegularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getEmailTemplateCloneTestCase tests copying a template under a new name
+func getEmailTemplateCloneTestCase() TestCase {
+	var sourceName, cloneName, cloneID string
+
+	return TestCase{
+		Name: "Email Template Clone",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user and source template",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					sourceName = GenerateTestEmailTemplate().Name
+					cloneName = GenerateTestEmailTemplate().Name
+					req := dto.CreateEmailTemplateRequest{
+						Name:         sourceName,
+						Subject:      "Welcome to {{.CompanyName}}",
+						HTMLTemplate: "<p>Hello from {{.CompanyName}}</p>",
+						TextTemplate: "Hello from {{.CompanyName}}",
+						Variables:    models.TemplateVariables{{Name: "CompanyName"}},
+					}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", req, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					ctx.CreatedTemplateID = result["id"].(string)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Cloning should create an inactive copy",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CloneEmailTemplateRequest{Name: cloneName}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					cloneID = result["id"].(string)
+					require.NotEqual(t, ctx.CreatedTemplateID, cloneID)
+					require.Equal(t, cloneName, result["name"])
+					require.Equal(t, "Welcome to {{.CompanyName}}", result["subject"])
+					require.Equal(t, "<p>Hello from {{.CompanyName}}</p>", result["html_template"])
+					require.Equal(t, "Hello from {{.CompanyName}}", result["text_template"])
+					require.Equal(t, false, result["is_active"])
+					require.Equal(t, float64(1), result["version"])
+
+					variables := result["variables"].([]interface{})
+					require.Len(t, variables, 1)
+					require.Equal(t, "CompanyName", variables[0].(map[string]interface{})["name"])
+				},
+			},
+			{
+				Name: "Clone should be stored inactive and audited",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					requireAuditEntry(t, config, ctx, services.AuditActionEmailTemplateClone, cloneID)
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/email-templates/"+cloneID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, false, result["is_active"])
+				},
+			},
+			{
+				Name: "Cloning to an existing name should conflict",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CloneEmailTemplateRequest{Name: sourceName}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "Cloning a deleted template should return not found",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					req := dto.CloneEmailTemplateRequest{Name: GenerateTestEmailTemplate().Name}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
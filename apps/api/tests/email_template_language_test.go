@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getEmailTemplateLanguageTestCase tests per-language template variants
+func getEmailTemplateLanguageTestCase() TestCase {
+	templateName := GenerateTestEmailTemplate().Name
+	newTemplate := func(language, subject string) dto.CreateEmailTemplateRequest {
+		return dto.CreateEmailTemplateRequest{
+			Name:         templateName,
+			Language:     language,
+			Subject:      subject,
+			HTMLTemplate: "<p>{{.CompanyName}}</p>",
+			TextTemplate: "{{.CompanyName}}",
+			Variables:    models.TemplateVariables{{Name: "CompanyName"}},
+		}
+	}
+
+	return TestCase{
+		Name: "Email Template Languages",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user and English template",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", newTemplate("", "Welcome"), token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, "en", result["language"])
+					ctx.CreatedTemplateID = result["id"].(string)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Same name in another language should be allowed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", newTemplate("id", "Selamat datang"), ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					require.Equal(t, "id", RequireJSONResponse(t, resp)["language"])
+				},
+			},
+			{
+				Name: "Same name and language should conflict",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", newTemplate("id", "Duplikat"), ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "Invalid language should be rejected",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", newTemplate("not a language", "Invalid"), ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "Test email should use the Accept-Language variant",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.TestEmailTemplateRequest{
+						Email:     GenerateUniqueEmail(),
+						Variables: map[string]string{"CompanyName": "Studio45"},
+					}
+					headers := map[string]string{
+						"Authorization":   "Bearer " + ctx.AdminToken,
+						"Accept-Language": "id-ID,id;q=0.9,en;q=0.8",
+					}
+					return MakeRequest(t, config.App, "POST", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/test", req, headers)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, "id", result["language"])
+					require.Equal(t, "Selamat datang", result["subject"])
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,93 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getEmailTemplateToggleTestCase tests flipping whether a template is active
+func getEmailTemplateToggleTestCase() TestCase {
+	toggle := func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+		return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/toggle", nil, ctx.AdminToken)
+	}
+
+	return TestCase{
+		Name: "Email Template Toggle",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user and active template",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.CreateEmailTemplateRequest{
+						Name:         GenerateTestEmailTemplate().Name,
+						Subject:      "Welcome to {{.CompanyName}}",
+						HTMLTemplate: "<p>Hello from {{.CompanyName}}</p>",
+						TextTemplate: "Hello from {{.CompanyName}}",
+						Variables:    models.TemplateVariables{{Name: "CompanyName"}},
+					}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", req, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, true, result["is_active"])
+					ctx.CreatedTemplateID = result["id"].(string)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name:        "Toggling an active template should deactivate it",
+				RequestFunc: toggle,
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, ctx.CreatedTemplateID, result["id"])
+					require.Equal(t, false, result["is_active"])
+					require.Equal(t, "Welcome to {{.CompanyName}}", result["subject"])
+					require.Equal(t, float64(1), result["version"])
+				},
+			},
+			{
+				Name: "The deactivation should be stored and audited",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					requireAuditEntry(t, config, ctx, services.AuditActionEmailTemplateUpdate, ctx.CreatedTemplateID)
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, false, RequireJSONResponse(t, resp)["is_active"])
+				},
+			},
+			{
+				Name:        "Toggling an inactive template should activate it",
+				RequestFunc: toggle,
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, true, RequireJSONResponse(t, resp)["is_active"])
+				},
+			},
+			{
+				Name: "Toggling an unknown template should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/email-templates/"+uuid.New().String()+"/toggle", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const originalTemplateSubject = "Welcome to {{.CompanyName}}"
+
+// getEmailTemplateVersionTestCase tests that template updates are versioned and can be restored
+func getEmailTemplateVersionTestCase() TestCase {
+	return TestCase{
+		Name: "Email Template Versions",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user and template",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.CreateEmailTemplateRequest{
+						Name:         GenerateTestEmailTemplate().Name,
+						Subject:      originalTemplateSubject,
+						HTMLTemplate: "<p>Hello from {{.CompanyName}}</p>",
+						TextTemplate: "Hello from {{.CompanyName}}",
+						Variables:    models.TemplateVariables{{Name: "CompanyName"}},
+					}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", req, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(1), result["version"])
+					ctx.CreatedTemplateID = result["id"].(string)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Three updates should produce three versions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					for i := 1; i <= 3; i++ {
+						subject := fmt.Sprintf("Update %d from {{.CompanyName}}", i)
+						req := dto.UpdateEmailTemplateRequest{Subject: &subject}
+						resp, err := MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, req, ctx.AdminToken)
+						require.NoError(t, err)
+						require.Equal(t, 200, resp.StatusCode)
+					}
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/versions", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(3), result["total"])
+					require.Equal(t, float64(4), result["current_version"])
+
+					versions := result["versions"].([]interface{})
+					newest := versions[0].(map[string]interface{})
+					oldest := versions[2].(map[string]interface{})
+					require.Equal(t, float64(3), newest["version_number"])
+					require.Equal(t, "Update 2 from {{.CompanyName}}", newest["subject"])
+					require.Equal(t, float64(1), oldest["version_number"])
+					require.Equal(t, originalTemplateSubject, oldest["subject"])
+					require.Equal(t, ctx.AdminUser.ID, oldest["created_by"])
+				},
+			},
+			{
+				Name: "Restoring version 1 should revert the content",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/versions/1/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, originalTemplateSubject, result["subject"])
+					require.Equal(t, float64(5), result["version"])
+				},
+			},
+			{
+				Name: "GET template should report the current version",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, originalTemplateSubject, result["subject"])
+					require.Equal(t, float64(5), result["version"])
+				},
+			},
+			{
+				Name: "Restoring an unknown version should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID+"/versions/99/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var eraseConfirmation = map[string]string{"confirm": "DELETE MY ACCOUNT"}
+
+// requireUserErased asserts no rows reference the user after erasure
+func requireUserErased(t *testing.T, config *TestConfig, userID, email string) {
+	var count int64
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM users WHERE id = ?", userID).Scan(&count).Error)
+	require.Zero(t, count, "user row should be hard deleted")
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM user_roles WHERE user_id = ? OR granted_by = ?", userID, userID).Scan(&count).Error)
+	require.Zero(t, count, "user_roles should not reference the erased user")
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = ?", userID).Scan(&count).Error)
+	require.Zero(t, count, "password reset tokens should be deleted")
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM login_events WHERE user_id = ?", userID).Scan(&count).Error)
+	require.Zero(t, count, "login events should be deleted")
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM password_history WHERE user_id = ?", userID).Scan(&count).Error)
+	require.Zero(t, count, "password history should be deleted")
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM audit_logs WHERE actor_id = ?", userID).Scan(&count).Error)
+	require.Zero(t, count, "audit logs should not reference the erased user as actor")
+
+	require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM audit_logs WHERE changes::text ILIKE ?", "%"+email+"%").Scan(&count).Error)
+	require.Zero(t, count, "audit logs should not contain the erased user's email")
+}
+
+// getErasureTestCase tests permanent account erasure
+func getErasureTestCase() TestCase {
+	regularUser := GenerateTestUser()
+	var granter TestUser
+	var grantee TestUser
+
+	return TestCase{
+		Name: "Account Erasure",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create users with audit history and reset tokens",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.UserToken = CreateTestUser(t, config.App, regularUser)
+					ctx.RegularUser = regularUser
+					err := config.DB.Raw("SELECT id FROM users WHERE email = ?", regularUser.Email).Scan(&ctx.CreatedUserID).Error
+					require.NoError(t, err)
+
+					// Audit entry containing the user's name
+					name := "Renamed " + regularUser.Name
+					resp, err := MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID, dto.UpdateUserRequest{Name: &name}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/forgot-password", map[string]string{"email": regularUser.Email}, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Erasure without confirmation should return 400",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/account", map[string]string{"confirm": "yes"}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/account should erase the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/account", eraseConfirmation, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 204, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Erased user should leave no rows behind",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					requireUserErased(t, config, ctx.CreatedUserID, ctx.RegularUser.Email)
+
+					// The erased user's token no longer works
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "Setup: Second admin grants roles to another user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					var granterToken string
+					granter, granterToken = CreateAdminUser(t, config)
+
+					grantee = GenerateTestUser()
+					CreateTestUser(t, config.App, grantee)
+					err := config.DB.Raw("SELECT id FROM users WHERE email = ?", grantee.Email).Scan(&grantee.ID).Error
+					require.NoError(t, err)
+
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+grantee.ID+"/roles", dto.UpdateRolesRequest{Roles: []string{"user"}}, granterToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/users/:id/purge should erase the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/users/"+granter.ID+"/purge", eraseConfirmation, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 204, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Roles granted by the purged user should remain without a reference",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					requireUserErased(t, config, granter.ID, granter.Email)
+
+					var count int64
+					require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM user_roles WHERE user_id = ? AND granted_by IS NULL", grantee.ID).Scan(&count).Error)
+					require.NotZero(t, count)
+
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/users/"+ctx.AdminUser.ID+"/purge", eraseConfirmation, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					// Admins cannot purge themselves
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getErrorCodesTestCase tests that error responses carry the documented codes
+func getErrorCodesTestCase() TestCase {
+	user := GenerateTestUser()
+	var userToken string
+
+	return TestCase{
+		Name: "Error Codes",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and regular user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+					userToken = CreateTestUser(t, config.App, user)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login with a wrong password should return AUTH_INVALID_CREDENTIALS",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.LoginRequest{Email: user.Email, Password: "wrong-password"}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					body := RequireErrorCode(t, resp, 401, apperrors.ErrAuthInvalidCredentials)
+					require.Equal(t, "Invalid email or password", body.Error)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login with a malformed body should return INVALID_REQUEST_BODY",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", "not an object", nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 400, apperrors.ErrInvalidRequestBody)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login without a password should return VALIDATION_FAILED",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", dto.LoginRequest{Email: user.Email}, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 400, apperrors.ErrValidation)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/register with a taken email should return EMAIL_TAKEN",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					duplicate := GenerateTestUser()
+					duplicate.Email = user.Email
+					req := duplicate.ToRegisterRequest()
+
+					resp, err := MakeRequest(t, config.App, "GET", "/api/v1/auth/tos/current", nil, nil)
+					require.NoError(t, err)
+					if resp.StatusCode == 200 {
+						var current dto.ToSVersionResponse
+						ReadJsonResult(t, resp, &current)
+						req.ToSVersion = current.Version
+					}
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 409, apperrors.ErrEmailTaken)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile without a token should return AUTH_REQUIRED",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 401, apperrors.ErrAuthRequired)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile with a bad token should return AUTH_INVALID_TOKEN",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, "not-a-token")
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 401, apperrors.ErrAuthInvalidToken)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users as a regular user should return PERMISSION_DENIED",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users", nil, userToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, apperrors.ErrPermissionDenied)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/users/:id for an unknown user should return USER_NOT_FOUND",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/users/"+uuid.New().String(), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrUserNotFound)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id for an unknown role should return ROLE_NOT_FOUND",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+uuid.New().String(), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrRoleNotFound)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/email-templates/:id for an unknown template should return EMAIL_TEMPLATE_NOT_FOUND",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/email-templates/"+uuid.New().String(), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrEmailTemplateNotFound)
+				},
+			},
+			{
+				Name: "GET on an unknown route should return NOT_FOUND",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/does-not-exist", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrNotFound)
+				},
+			},
+		},
+	}
+}
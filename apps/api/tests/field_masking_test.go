@@ -0,0 +1,97 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getFieldMaskingTestCase tests masking user fields by the viewer's roles
+func getFieldMaskingTestCase() TestCase {
+	roleName := "masked-" + uuid.New().String()[:8]
+	var roleID string
+	user := GenerateTestUser()
+	phone := *user.Phone
+
+	return TestCase{
+		Name: "Field Masking",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Give a user only a role that masks phone numbers",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{Name: roleName}, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					roleID = RequireJSONResponse(t, resp)["id"].(string)
+
+					ctx.UserToken = CreateTestUser(t, config.App, user)
+					ctx.CreatedUserID = userIDByEmail(t, config, user.Email)
+
+					req := dto.UpdateRolesRequest{Roles: []string{roleName}}
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID+"/roles", req, token)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					rule := models.FieldMaskingRule{RoleName: roleName, Model: "user", Field: "phone", MaskType: models.MaskTypePartial}
+					require.NoError(t, config.DB.Create(&rule).Error)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile should show only the last four digits of the phone",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, "****"+phone[len(phone)-4:], result["phone"])
+					require.Equal(t, user.Email, result["email"])
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id should show the full phone to an admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.CreatedUserID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, phone, result["phone"])
+				},
+			},
+			{
+				Name: "Granting user.read.phone should lift the mask",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreatePermissionRequest{Name: "user.read.phone", Resource: "user", Action: "read.phone"}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					permissionID := RequireJSONResponse(t, resp)["id"].(string)
+
+					assign := dto.AssignPermissionsToRoleRequest{PermissionIDs: []string{permissionID}}
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+roleID+"/permissions", assign, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, phone, result["phone"])
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getIdempotencyTestCase tests that retried POSTs with the same
+// Idempotency-Key replay the first response instead of creating duplicates
+func getIdempotencyTestCase() TestCase {
+	var registerKey, userKey string
+	var userBody []byte
+	var registerReq dto.RegisterRequest
+	var userReq dto.AdminRegisterUserRequest
+
+	return TestCase{
+		Name: "Idempotency Keys",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/auth/register with an Idempotency-Key should create the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					registerKey = uuid.New().String()
+					registerReq = GenerateTestUser().ToRegisterRequest()
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", registerReq, map[string]string{
+						"Idempotency-Key": registerKey,
+					})
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					require.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/register retried with the same key should not replay the token",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", registerReq, map[string]string{
+						"Idempotency-Key": registerKey,
+					})
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Empty(t, resp.Header.Get("Idempotent-Replayed"))
+					// The email from the first request is already taken
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users with an Idempotency-Key should create the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					userKey = uuid.New().String()
+					userReq = dto.AdminRegisterUserRequest{
+						Email:    GenerateUniqueEmail(),
+						Password: "Password123!",
+						Name:     GenerateUniqueName(),
+					}
+
+					return makeIdempotentAdminRequest(t, config, ctx, userKey, userReq)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var err error
+					userBody, err = io.ReadAll(resp.Body)
+					require.NoError(t, err)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users retried with the same key should return the same 201 body",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return makeIdempotentAdminRequest(t, config, ctx, userKey, userReq)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					require.Equal(t, "true", resp.Header.Get("Idempotent-Replayed"))
+
+					body, err := io.ReadAll(resp.Body)
+					require.NoError(t, err)
+					require.Equal(t, string(userBody), string(body))
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users reusing the key for another user should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					otherReq := userReq
+					otherReq.Email = GenerateUniqueEmail()
+					return makeIdempotentAdminRequest(t, config, ctx, userKey, otherReq)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 422)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users without a key should not be replayed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", userReq, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					// The email from the first request is already taken
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+		},
+	}
+}
+
+func makeIdempotentAdminRequest(t *testing.T, config *TestConfig, ctx *TestContext, key string, body interface{}) (*http.Response, error) {
+	return MakeRequest(t, config.App, "POST", "/api/v1/admin/users", body, map[string]string{
+		"Authorization":   "Bearer " + ctx.AdminToken,
+		"Idempotency-Key": key,
+	})
+}
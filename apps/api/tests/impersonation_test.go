@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"api/internal/cache"
+	"api/internal/dto"
+	"api/internal/middleware"
+	"api/internal/services"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// grantSuperAdmin gives user the super_admin role directly in the database
+func grantSuperAdmin(t *testing.T, config *TestConfig, user TestUser) {
+	err := config.DB.Exec(`
+		INSERT INTO user_roles (user_id, role_id)
+		SELECT ?, r.id FROM roles r WHERE r.name = 'super_admin'
+		ON CONFLICT DO NOTHING
+	`, user.ID).Error
+	require.NoError(t, err)
+	cache.Permissions().Delete(user.ID)
+}
+
+// getImpersonationTestCase tests admins acting as other users
+func getImpersonationTestCase() TestCase {
+	var userID, impersonationToken string
+	var otherAdmin TestUser
+
+	return TestCase{
+		Name: "Admin Impersonation",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate should issue a short-lived token",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+					otherAdmin, _ = CreateAdminUser(t, config)
+
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+					require.NoError(t, err)
+					userID = RequireJSONResponse(t, resp)["id"].(string)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+userID+"/impersonate", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.ImpersonationResponse
+					ReadJsonResult(t, resp, &result)
+					require.NotEmpty(t, result.Token)
+					require.Equal(t, userID, result.User.ID)
+					require.WithinDuration(t, time.Now().Add(15*time.Minute), result.ExpiresAt, time.Minute)
+					impersonationToken = result.Token
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile with an impersonation token should act as the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, impersonationToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, ctx.AdminUser.ID, resp.Header.Get(middleware.ImpersonatedByHeader))
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, userID, result["id"])
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile with the user's own token should not be marked",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Empty(t, resp.Header.Get(middleware.ImpersonatedByHeader))
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/change-password with an impersonation token should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/change-password", dto.ChangePasswordRequest{
+						CurrentPassword: ctx.RegularUser.Password,
+						NewPassword:     "Impers0nated!Pass",
+					}, impersonationToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, "IMPERSONATION_NOT_ALLOWED")
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/api-keys with an impersonation token should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/api-keys", dto.CreateAPIKeyRequest{
+						Name:   "impersonated",
+						Scopes: []string{services.APIKeyScopeUserRead},
+					}, impersonationToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, "IMPERSONATION_NOT_ALLOWED")
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/account with an impersonation token should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/account", nil, impersonationToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, "IMPERSONATION_NOT_ALLOWED")
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/audit-logs should record the impersonation",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					requireAuditEntry(t, config, ctx, services.AuditActionUserImpersonate, userID)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate on another admin should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+otherAdmin.ID+"/impersonate", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate on a super admin should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					superAdmin := GenerateTestUser()
+					token := CreateTestUser(t, config.App, superAdmin)
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, token)
+					require.NoError(t, err)
+					superAdmin.ID = RequireJSONResponse(t, resp)["id"].(string)
+					grantSuperAdmin(t, config, superAdmin)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+superAdmin.ID+"/impersonate", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate on another admin should succeed for a super admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					grantSuperAdmin(t, config, ctx.AdminUser)
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+otherAdmin.ID+"/impersonate", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.ImpersonationResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, otherAdmin.ID, result.User.ID)
+					impersonationToken = result.Token
+				},
+			},
+			{
+				Name: "Actions taken while impersonating should record the impersonating admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/companies", dto.CreateCompanyRequest{
+						Name: "Impersonated " + uuid.New().String()[:8],
+					}, impersonationToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					companyID := RequireJSONResponse(t, resp)["id"].(string)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/audit-logs?resource_id="+companyID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PaginatedAuditLogsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Len(t, result.Logs, 1)
+					require.Equal(t, otherAdmin.ID, *result.Logs[0].ActorID)
+					require.NotNil(t, result.Logs[0].ImpersonatedBy)
+					require.Equal(t, ctx.AdminUser.ID, *result.Logs[0].ImpersonatedBy)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate with an impersonation token should be forbidden",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+userID+"/impersonate", nil, impersonationToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/impersonate on yourself should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+ctx.AdminUser.ID+"/impersonate", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,188 @@
+package tests
+
+import (
+	"api/internal/auth"
+	"api/internal/dto"
+	"api/internal/services"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// invitationToken replaces the emailed token so the test can accept the invitation
+const invitationToken = "test-invitation-token"
+
+// getInvitationTestCase tests inviting a user and accepting the invitation
+func getInvitationTestCase() TestCase {
+	invitee := GenerateTestUser()
+
+	return TestCase{
+		Name: "User Invitations",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, token)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/invitations should reject unknown roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreateInvitationRequest{Email: invitee.Email, Roles: []string{"no-such-role"}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/invitations", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/invitations should reject existing users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreateInvitationRequest{Email: ctx.AdminUser.Email}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/invitations", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/invitations should create an invitation",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreateInvitationRequest{Email: invitee.Email, Roles: []string{"admin"}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/invitations", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var result struct {
+						Invitation dto.InvitationResponse `json:"invitation"`
+					}
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, invitee.Email, result.Invitation.Email)
+					require.Equal(t, []string{"admin"}, result.Invitation.Roles)
+					require.WithinDuration(t, time.Now().Add(72*time.Hour), result.Invitation.ExpiresAt, time.Minute)
+				},
+			},
+			{
+				Name: "Invitation should not store the plain token",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					var tokenHash string
+					err := config.DB.Raw("SELECT token_hash FROM user_invitations WHERE email = ?", invitee.Email).Scan(&tokenHash).Error
+					require.NoError(t, err)
+					require.Len(t, tokenHash, 64)
+
+					// Swap in a known token; the real one only exists in the email
+					err = config.DB.Exec("UPDATE user_invitations SET token_hash = ? WHERE email = ?", auth.HashToken(invitationToken), invitee.Email).Error
+					require.NoError(t, err)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/invitations", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					ResponseContains(t, resp, invitee.Email)
+				},
+			},
+			{
+				Name: "An invitation whose email fails to send should not replace the pending one",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					failed := func(to, token string) error { return errors.New("smtp unavailable") }
+					_, err := services.NewInvitationService().CreateInvitation(invitee.Email, nil, nil, failed)
+					require.ErrorIs(t, err, services.ErrInvitationNotSent)
+
+					var tokenHashes []string
+					require.NoError(t, config.DB.Raw("SELECT token_hash FROM user_invitations WHERE email = ?", invitee.Email).Scan(&tokenHashes).Error)
+					require.Equal(t, []string{auth.HashToken(invitationToken)}, tokenHashes)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/accept-invitation should reject an invalid token",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AcceptInvitationRequest{Token: "invalid-token", Name: invitee.Name, Password: invitee.Password}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/accept-invitation", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/accept-invitation should create the user with the invited roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AcceptInvitationRequest{Token: invitationToken, Name: invitee.Name, Password: invitee.Password}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/accept-invitation", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var result dto.AuthResponse
+					ReadJsonResult(t, resp, &result)
+					require.NotEmpty(t, result.Token)
+					require.Equal(t, invitee.Email, result.User.Email)
+					require.Equal(t, invitee.Name, result.User.Name)
+					require.Contains(t, result.User.Roles, "admin")
+				},
+			},
+			{
+				Name: "Invited user should be able to log in",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", invitee.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Accepted invitation should not be usable again",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AcceptInvitationRequest{Token: invitationToken, Name: invitee.Name, Password: invitee.Password}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/accept-invitation", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "Accepted invitation should no longer be listed as pending",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/invitations", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.NotContains(t, GetResponseBody(t, resp), invitee.Email)
+				},
+			},
+			{
+				Name: "Expired invitation should be rejected",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					expired := GenerateTestUser()
+					req := dto.CreateInvitationRequest{Email: expired.Email}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/invitations", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					err = config.DB.Exec("UPDATE user_invitations SET token_hash = ?, expires_at = ? WHERE email = ?",
+						auth.HashToken("expired-invitation-token"), time.Now().Add(-time.Hour), expired.Email).Error
+					require.NoError(t, err)
+
+					accept := dto.AcceptInvitationRequest{Token: "expired-invitation-token", Name: expired.Name, Password: expired.Password}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/accept-invitation", accept, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// loginHistoryResponse is the body of the login history endpoints
+type loginHistoryResponse struct {
+	Events []dto.LoginEventResponse `json:"events"`
+	Total  int                      `json:"total"`
+}
+
+// getLoginHistoryTestCase tests login event recording, last-login tracking and lockout
+func getLoginHistoryTestCase() TestCase {
+	regularUser := GenerateTestUser()
+
+	return TestCase{
+		Name: "Login History",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and regular users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.UserToken = CreateTestUser(t, config.App, regularUser)
+					ctx.RegularUser = regularUser
+
+					err := config.DB.Raw("SELECT id FROM users WHERE email = ?", regularUser.Email).Scan(&ctx.CreatedUserID).Error
+					require.NoError(t, err)
+
+					wrongPassword := dto.LoginRequest{Email: regularUser.Email, Password: "wrong-password"}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", wrongPassword, map[string]string{"User-Agent": "login-history-test"})
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/login-history should list successes and failures",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/login-history", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result loginHistoryResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, 2, result.Total)
+
+					// Newest first: the failed attempt follows the login from setup
+					require.False(t, result.Events[0].Success)
+					require.Equal(t, "login-history-test", result.Events[0].UserAgent)
+					require.NotEmpty(t, result.Events[0].IPAddress)
+					require.True(t, result.Events[1].Success)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/login-history should honour the limit",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/login-history?limit=1", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result loginHistoryResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, 1, result.Total)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id/login-history should return the user's history",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.CreatedUserID+"/login-history", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result loginHistoryResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, 2, result.Total)
+				},
+			},
+			{
+				Name: "Non-admin should not read other users' login history",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.AdminUser.ID+"/login-history", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "Successful login should set last_login_at",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users?search="+url.QueryEscape(regularUser.Email), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Len(t, result.Users, 1)
+					require.NotNil(t, result.Users[0].LastLoginAt)
+				},
+			},
+			{
+				Name: "Account should lock after too many failed attempts",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					// One failure was recorded during setup
+					wrongPassword := dto.LoginRequest{Email: regularUser.Email, Password: "wrong-password"}
+					for i := 0; i < 9; i++ {
+						resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/login", wrongPassword, nil)
+						require.NoError(t, err)
+						require.Equal(t, 401, resp.StatusCode)
+					}
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", regularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 429)
+				},
+			},
+			{
+				Name: "Logins refused by the lockout should not be recorded",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.CreatedUserID+"/login-history", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					// The login from setup and ten failures; the refused login adds nothing
+					var result loginHistoryResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, 11, result.Total)
+					require.False(t, result.Events[0].Success)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type myPermissionsResponse struct {
+	Permissions []dto.PermissionResponse `json:"permissions"`
+	Total       int                      `json:"total"`
+}
+
+func permissionNames(permissions []dto.PermissionResponse) []string {
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		names = append(names, permission.Name)
+	}
+	return names
+}
+
+// getMyPermissionsTestCase tests that users can check their own permissions
+// and only their own
+func getMyPermissionsTestCase() TestCase {
+	return TestCase{
+		Name: "Own Permissions",
+		Steps: []TestStep{
+			{
+				Name: "GET /api/v1/protected/permissions should list the user's permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/permissions", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result myPermissionsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, len(result.Permissions), result.Total)
+					names := permissionNames(result.Permissions)
+					require.Contains(t, names, "user.read")
+					require.NotContains(t, names, "admin.access")
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/permissions/:name should report a granted permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/permissions/user.read", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, true, RequireJSONResponse(t, resp)["has_permission"])
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/permissions/:name should report a missing permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/permissions/admin.access", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, false, RequireJSONResponse(t, resp)["has_permission"])
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/permissions/check should report granted and missing permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CheckPermissionsRequest{Permissions: []string{"user.read", "admin.access", "billing.manage"}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/permissions/check", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.CheckPermissionsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, map[string]bool{"user.read": true, "admin.access": false, "billing.manage": false}, result.Results)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/permissions/check with no permissions should return empty results",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CheckPermissionsRequest{Permissions: []string{}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/permissions/check", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.CheckPermissionsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Empty(t, result.Results)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/permissions/check should reject more than 50 permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					names := make([]string, 51)
+					for i := range names {
+						names[i] = fmt.Sprintf("permission.%d", i)
+					}
+					req := dto.CheckPermissionsRequest{Permissions: names}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/permissions/check", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 400, apperrors.ErrValidation)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id/permissions should not let a user check someone else",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.AdminUser.ID+"/permissions", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/permissions should list the admin's own permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/permissions", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result myPermissionsResponse
+					ReadJsonResult(t, resp, &result)
+					require.Contains(t, permissionNames(result.Permissions), "admin.access")
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,85 @@
+package tests
+
+import (
+	"api/internal/auth"
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// resetPasswordWithNewToken issues a reset token for the user directly, since
+// the real token is only sent by email, and resets the password with it
+func resetPasswordWithNewToken(t *testing.T, config *TestConfig, userID, password string) (*http.Response, error) {
+	token := uuid.New().String()
+	err := config.DB.Exec("INSERT INTO password_reset_tokens (id, user_id, token, expires_at) VALUES (gen_random_uuid(), ?, ?, NOW() + INTERVAL '15 minutes')",
+		userID, auth.HashToken(token)).Error
+	require.NoError(t, err)
+
+	req := dto.ResetPasswordRequest{Token: token, Password: password}
+	return MakeRequest(t, config.App, "POST", "/api/v1/auth/reset-password", req, nil)
+}
+
+// getPasswordHistoryTestCase tests that recent passwords cannot be reused.
+// The test environment sets PASSWORD_HISTORY_DEPTH to 3.
+func getPasswordHistoryTestCase() TestCase {
+	regularUser := GenerateTestUser()
+	passwords := []string{"first-password-1", "second-password-2", "third-password-3"}
+
+	resetStep := func(name, password string, expectedStatus int) TestStep {
+		return TestStep{
+			Name: name,
+			RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+				return resetPasswordWithNewToken(t, config, ctx.CreatedUserID, password)
+			},
+			ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+				if expectedStatus == 200 {
+					require.Equal(t, 200, resp.StatusCode)
+					return
+				}
+				RequireErrorResponse(t, resp, expectedStatus)
+				ResponseContains(t, resp, "used recently")
+			},
+		}
+	}
+
+	return TestCase{
+		Name: "Password History",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.UserToken = CreateTestUser(t, config.App, regularUser)
+					ctx.RegularUser = regularUser
+
+					err := config.DB.Raw("SELECT id FROM users WHERE email = ?", regularUser.Email).Scan(&ctx.CreatedUserID).Error
+					require.NoError(t, err)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			resetStep("Reset to first distinct password should succeed", passwords[0], 200),
+			resetStep("Reset to second distinct password should succeed", passwords[1], 200),
+			resetStep("Reset to third distinct password should succeed", passwords[2], 200),
+			resetStep("Reusing the current password should fail", passwords[2], 400),
+			resetStep("Reusing a recent password should fail", passwords[0], 400),
+			resetStep("Reusing the registration password should fail", regularUser.Password, 400),
+			resetStep("Reset to a fourth distinct password should succeed", "fourth-password-4", 200),
+			resetStep("Password older than the history depth can be reused", regularUser.Password, 200),
+			{
+				Name: "User should log in with the latest password",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", regularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
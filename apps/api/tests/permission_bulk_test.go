@@ -0,0 +1,143 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getPermissionBulkAssignmentTestCase tests granting and revoking a permission
+// across several roles in one request
+func getPermissionBulkAssignmentTestCase() TestCase {
+	var permissionID string
+	var roleIDs []string
+	missingRoleID := uuid.New().String()
+
+	createRole := func(t *testing.T, config *TestConfig, ctx *TestContext) {
+		req := dto.CreateRoleRequest{Name: "bulk-" + uuid.New().String()[:8]}
+		resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 201, resp.StatusCode)
+		roleIDs = append(roleIDs, RequireJSONResponse(t, resp)["id"].(string))
+	}
+
+	return TestCase{
+		Name: "Permission Bulk Assignment",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin, two roles and a permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					createRole(t, config, ctx)
+					createRole(t, config, ctx)
+
+					suffix := uuid.New().String()[:8]
+					req := dto.CreatePermissionRequest{
+						Name:     "bulk.read." + suffix,
+						Resource: "bulk-" + suffix,
+						Action:   "read",
+					}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					permissionID = RequireJSONResponse(t, resp)["id"].(string)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/permissions/:id/assign-to-roles should assign and report missing roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PermissionRolesRequest{RoleIDs: append([]string{missingRoleID}, roleIDs...)}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/"+permissionID+"/assign-to-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(2), result["assigned"])
+					require.Equal(t, float64(0), result["skipped"])
+					require.Equal(t, []interface{}{"role " + missingRoleID + " not found"}, result["errors"])
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/permissions/:id/assign-to-roles should skip existing assignments",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PermissionRolesRequest{RoleIDs: roleIDs}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/"+permissionID+"/assign-to-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(0), result["assigned"])
+					require.Equal(t, float64(2), result["skipped"])
+					require.Empty(t, result["errors"])
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id/permissions should include the assigned permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+roleIDs[0]+"/permissions", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					permissions := result["permissions"].([]interface{})
+					require.Len(t, permissions, 1)
+					require.Equal(t, permissionID, permissions[0].(map[string]interface{})["id"])
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/permissions/:id/remove-from-roles should remove the permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PermissionRolesRequest{RoleIDs: roleIDs}
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/permissions/"+permissionID+"/remove-from-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(2), result["removed"])
+					require.Equal(t, float64(0), result["skipped"])
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/permissions/:id/remove-from-roles should skip roles without the permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PermissionRolesRequest{RoleIDs: append([]string{missingRoleID}, roleIDs...)}
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/permissions/"+permissionID+"/remove-from-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(0), result["removed"])
+					require.Equal(t, float64(2), result["skipped"])
+					require.Equal(t, []interface{}{"role " + missingRoleID + " not found"}, result["errors"])
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/permissions/:id/assign-to-roles should return 404 for unknown permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PermissionRolesRequest{RoleIDs: roleIDs}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/"+uuid.New().String()+"/assign-to-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/permissions/:id/assign-to-roles should reject invalid role IDs",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PermissionRolesRequest{RoleIDs: []string{"not-a-uuid"}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/"+permissionID+"/assign-to-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,202 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getPermissionCategoryTestCase tests grouping permissions into categories
+// and filtering the permission list by category
+func getPermissionCategoryTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	var firstCategoryID, secondCategoryID string
+	var firstPermissionID, secondPermissionID, roleID string
+
+	createCategory := func(t *testing.T, config *TestConfig, ctx *TestContext, name string) string {
+		req := dto.CreatePermissionCategoryRequest{Name: name}
+		resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/categories", req, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 201, resp.StatusCode)
+		var category dto.PermissionCategoryResponse
+		ReadJsonResult(t, resp, &category)
+		require.Equal(t, name, category.Name)
+		return category.ID
+	}
+
+	createPermission := func(t *testing.T, config *TestConfig, ctx *TestContext, action string, categoryID *string) dto.PermissionResponse {
+		req := dto.CreatePermissionRequest{
+			Name:       "category." + action + "." + suffix,
+			Resource:   "category-" + suffix,
+			Action:     action,
+			CategoryID: categoryID,
+		}
+		resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 201, resp.StatusCode)
+		var permission dto.PermissionResponse
+		ReadJsonResult(t, resp, &permission)
+		return permission
+	}
+
+	listPermissions := func(t *testing.T, resp *http.Response) []dto.PermissionResponse {
+		require.Equal(t, 200, resp.StatusCode)
+		var result struct {
+			Permissions []dto.PermissionResponse `json:"permissions"`
+			Total       int                      `json:"total"`
+		}
+		ReadJsonResult(t, resp, &result)
+		require.Equal(t, len(result.Permissions), result.Total)
+		return result.Permissions
+	}
+
+	return TestCase{
+		Name: "Permission Categories",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create two categories and a permission in each",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					firstName := "reports-" + suffix
+					firstCategoryID = createCategory(t, config, ctx, firstName)
+					secondCategoryID = createCategory(t, config, ctx, "billing-"+suffix)
+
+					first := createPermission(t, config, ctx, "read", &firstCategoryID)
+					require.Equal(t, &firstCategoryID, first.CategoryID)
+					require.NotNil(t, first.CategoryName)
+					require.Equal(t, firstName, *first.CategoryName)
+					firstPermissionID = first.ID
+
+					secondPermissionID = createPermission(t, config, ctx, "write", &secondCategoryID).ID
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/permissions/categories", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result struct {
+						Categories []dto.PermissionCategoryResponse `json:"categories"`
+					}
+					ReadJsonResult(t, resp, &result)
+					ids := make([]string, 0, len(result.Categories))
+					for _, category := range result.Categories {
+						ids = append(ids, category.ID)
+					}
+					require.Contains(t, ids, firstCategoryID)
+					require.Contains(t, ids, secondCategoryID)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/permissions should reject an unknown category",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					missing := uuid.New().String()
+					req := dto.CreatePermissionRequest{
+						Name:       "category.delete." + suffix,
+						Resource:   "category-" + suffix,
+						Action:     "delete",
+						CategoryID: &missing,
+					}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/permissions?category_id should list only that category",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/permissions?category_id="+firstCategoryID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					permissions := listPermissions(t, resp)
+					require.Len(t, permissions, 1)
+					require.Equal(t, firstPermissionID, permissions[0].ID)
+					require.Equal(t, "reports-"+suffix, *permissions[0].CategoryName)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/permissions should reject an invalid category_id",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/permissions?category_id=not-a-uuid", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id should name the categories of the role's permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{Name: "category-" + suffix}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					roleID = RequireJSONResponse(t, resp)["id"].(string)
+
+					req := dto.AssignPermissionsToRoleRequest{PermissionIDs: []string{firstPermissionID, secondPermissionID}}
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+roleID+"/permissions", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+roleID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var role dto.RoleResponse
+					ReadJsonResult(t, resp, &role)
+					require.Len(t, role.Permissions, 2)
+					categories := map[string]string{}
+					for _, permission := range role.Permissions {
+						require.NotNil(t, permission.CategoryName)
+						categories[permission.ID] = *permission.CategoryName
+					}
+					require.Equal(t, "reports-"+suffix, categories[firstPermissionID])
+					require.Equal(t, "billing-"+suffix, categories[secondPermissionID])
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/permissions/:id should remove the category when empty",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					empty := ""
+					req := dto.UpdatePermissionRequest{CategoryID: &empty}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/permissions/"+firstPermissionID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var permission dto.PermissionResponse
+					ReadJsonResult(t, resp, &permission)
+					require.Nil(t, permission.CategoryID)
+					require.Nil(t, permission.CategoryName)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/permissions/categories/:id should keep its permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/permissions/categories/"+secondCategoryID, nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/permissions/"+secondPermissionID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var permission dto.PermissionResponse
+					ReadJsonResult(t, resp, &permission)
+					require.Nil(t, permission.CategoryID)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/permissions/categories/:id should return 404 for a deleted category",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/permissions/categories/"+secondCategoryID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getPermissionMetadataTestCase tests storing UI hints on permissions and
+// querying the ones marked dangerous
+func getPermissionMetadataTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	var dangerousID, safeID string
+
+	return TestCase{
+		Name: "Permission Metadata",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/permissions should store metadata",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.CreatePermissionRequest{
+						Name:     "metadata.purge." + suffix,
+						Resource: "metadata-" + suffix,
+						Action:   "purge",
+						Metadata: map[string]interface{}{
+							"dangerous":       true,
+							"icon":            "trash",
+							"confirm_message": "This will delete all data",
+						},
+					}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var permission dto.PermissionResponse
+					ReadJsonResult(t, resp, &permission)
+					require.Equal(t, true, permission.Metadata["dangerous"])
+					require.Equal(t, "trash", permission.Metadata["icon"])
+					require.Equal(t, "This will delete all data", permission.Metadata["confirm_message"])
+					dangerousID = permission.ID
+				},
+			},
+			{
+				Name: "Permissions created without metadata should return an empty object",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreatePermissionRequest{
+						Name:     "metadata.read." + suffix,
+						Resource: "metadata-" + suffix,
+						Action:   "read",
+					}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var permission dto.PermissionResponse
+					ReadJsonResult(t, resp, &permission)
+					require.NotNil(t, permission.Metadata)
+					require.Empty(t, permission.Metadata)
+					safeID = permission.ID
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/permissions/:id should replace metadata",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdatePermissionRequest{
+						Metadata: map[string]interface{}{"icon": "eye"},
+					}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/permissions/"+safeID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var permission dto.PermissionResponse
+					ReadJsonResult(t, resp, &permission)
+					require.Equal(t, map[string]interface{}{"icon": "eye"}, permission.Metadata)
+				},
+			},
+			{
+				Name: "Updating other fields should keep metadata",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					description := "Purge all metadata test data"
+					req := dto.UpdatePermissionRequest{Description: &description}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/permissions/"+dangerousID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var permission dto.PermissionResponse
+					ReadJsonResult(t, resp, &permission)
+					require.Equal(t, true, permission.Metadata["dangerous"])
+				},
+			},
+			{
+				Name: "GetDangerousPermissions should return only permissions marked dangerous",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					permissions, err := services.NewRBACService().GetDangerousPermissions(context.Background())
+					require.NoError(t, err)
+
+					ids := make([]string, 0, len(permissions))
+					for _, permission := range permissions {
+						require.Equal(t, true, permission.Metadata["dangerous"])
+						ids = append(ids, permission.ID)
+					}
+					require.Contains(t, ids, dangerousID)
+					require.NotContains(t, ids, safeID)
+
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
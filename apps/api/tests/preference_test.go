@@ -0,0 +1,130 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getPreferenceTestCase tests storing, reading and deleting user preferences
+func getPreferenceTestCase() TestCase {
+	return TestCase{
+		Name: "User Preferences",
+		Steps: []TestStep{
+			{
+				Name: "GET /api/v1/protected/preferences should return defaults after registration",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/preferences", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PreferencesResponse
+					ReadJsonResult(t, resp, &result)
+					require.JSONEq(t, `"system"`, string(result.Preferences["theme"]))
+					require.JSONEq(t, `"en"`, string(result.Preferences["locale"]))
+					require.JSONEq(t, `true`, string(result.Preferences["email_notifications"]))
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/preferences/theme should replace the value",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/theme", map[string]interface{}{
+						"value": "dark",
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PreferenceResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, "theme", result.Key)
+					require.JSONEq(t, `"dark"`, string(result.Value))
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/preferences/timezone should store a new value",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/timezone", map[string]interface{}{
+						"value": map[string]interface{}{"name": "Asia/Jakarta", "offset": 7},
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/preferences should include the updated values",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/preferences", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PreferencesResponse
+					ReadJsonResult(t, resp, &result)
+					require.JSONEq(t, `"dark"`, string(result.Preferences["theme"]))
+
+					var timezone map[string]interface{}
+					require.NoError(t, json.Unmarshal(result.Preferences["timezone"], &timezone))
+					require.Equal(t, "Asia/Jakarta", timezone["name"])
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/preferences/:key with a disallowed key should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/is_admin", map[string]interface{}{
+						"value": true,
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/preferences/theme with a null value should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/theme", map[string]interface{}{
+						"value": nil,
+					}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/preferences/theme should remove the value",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/preferences/theme", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireSuccessResponse(t, resp, 200)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/preferences/theme again should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/preferences/theme", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/preferences without auth should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/protected/preferences", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getProfileETagTestCase tests conditional GET requests for the profile
+func getProfileETagTestCase() TestCase {
+	var etag string
+
+	getProfile := func(t *testing.T, config *TestConfig, ctx *TestContext, ifNoneMatch string) (*http.Response, error) {
+		headers := map[string]string{"Authorization": "Bearer " + ctx.UserToken}
+		if ifNoneMatch != "" {
+			headers["If-None-Match"] = ifNoneMatch
+		}
+		return MakeRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, headers)
+	}
+
+	return TestCase{
+		Name: "Profile ETag",
+		Steps: []TestStep{
+			{
+				Name: "GET /api/v1/protected/profile should return an ETag and Last-Modified",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					return getProfile(t, config, ctx, "")
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					etag = resp.Header.Get("ETag")
+					require.NotEmpty(t, etag)
+					require.NotEmpty(t, resp.Header.Get("Last-Modified"))
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, ctx.RegularUser.Email, result["email"])
+				},
+			},
+			{
+				Name: "GET with a matching If-None-Match should return 304 without a body",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return getProfile(t, config, ctx, etag)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 304, resp.StatusCode)
+					require.Equal(t, etag, resp.Header.Get("ETag"))
+
+					body, err := io.ReadAll(resp.Body)
+					require.NoError(t, err)
+					require.Empty(t, body)
+				},
+			},
+			{
+				Name: "GET with the old ETag after a profile update should return the new profile",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", map[string]string{"name": "ETag Renamed"}, ctx.UserToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return getProfile(t, config, ctx, etag)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.NotEmpty(t, resp.Header.Get("ETag"))
+					require.NotEqual(t, etag, resp.Header.Get("ETag"))
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, "ETag Renamed", result["name"])
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getProfilePatchTestCase tests JSON Merge Patch updates of the profile and
+// admin user endpoints, and full replacement with PUT
+func getProfilePatchTestCase() TestCase {
+	var companyID, userID string
+
+	return TestCase{
+		Name: "Profile Merge Patch",
+		Steps: []TestStep{
+			{
+				Name: "PATCH /api/v1/protected/profile should leave absent fields unchanged",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/companies", dto.CreateCompanyRequest{Name: "Initech " + uuid.New().String()[:8]}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					companyID = RequireJSONResponse(t, resp)["id"].(string)
+
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					req := map[string]interface{}{"company_id": companyID}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.Equal(t, companyID, result["company_id"])
+					require.Equal(t, ctx.RegularUser.Name, result["name"])
+					require.NotNil(t, result["phone"])
+					userID = result["id"].(string)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/profile with null should clear the field",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"phone": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.Nil(t, result["phone"])
+					require.Equal(t, companyID, result["company_id"])
+					require.Equal(t, ctx.RegularUser.Name, result["name"])
+				},
+			},
+			{
+				Name: "PATCH /api/v1/protected/profile with a null name should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"name": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/profile", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/profile without every field should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"name": "Full Replace", "phone": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/profile", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/profile without a name should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"phone": nil, "company_id": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/profile", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/protected/profile should replace every field",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"name": "Full Replace", "phone": nil, "company_id": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/profile", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.Equal(t, "Full Replace", result["name"])
+					require.Nil(t, result["phone"])
+					require.Nil(t, result["company_id"])
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id should leave absent fields unchanged",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"company_id": companyID}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+userID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.Equal(t, companyID, result["company_id"])
+					require.Equal(t, "Full Replace", result["name"])
+					require.Equal(t, ctx.RegularUser.Email, result["email"])
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id with null should clear the field",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"company_id": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+userID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					require.Nil(t, result["company_id"])
+					require.Equal(t, "Full Replace", result["name"])
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id with a null email should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := map[string]interface{}{"email": nil}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+userID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
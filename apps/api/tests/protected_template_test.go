@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// grantProtectedTemplatePermission makes user a super_admin, making sure the
+// role carries the permission for managing protected templates
+func grantProtectedTemplatePermission(t *testing.T, config *TestConfig, user TestUser) {
+	err := config.DB.Exec(`
+		INSERT INTO permissions (name, resource, action, description)
+		VALUES (?, 'template', 'manage_protected', 'Edit and delete protected email templates')
+		ON CONFLICT (name) DO NOTHING
+	`, services.PermissionManageProtectedTemplates).Error
+	require.NoError(t, err)
+
+	err = config.DB.Exec(`
+		INSERT INTO role_permissions (role_id, permission_id)
+		SELECT r.id, p.id FROM roles r, permissions p
+		WHERE r.name = 'super_admin' AND p.name = ?
+		ON CONFLICT DO NOTHING
+	`, services.PermissionManageProtectedTemplates).Error
+	require.NoError(t, err)
+
+	grantSuperAdmin(t, config, user)
+}
+
+// getProtectedTemplateTestCase tests that protected templates need a dedicated
+// permission on top of the admin role
+func getProtectedTemplateTestCase() TestCase {
+	var managerToken string
+	protected := true
+
+	newTemplate := func() dto.CreateEmailTemplateRequest {
+		return dto.CreateEmailTemplateRequest{
+			Name:         GenerateTestEmailTemplate().Name,
+			Subject:      "Protected",
+			HTMLTemplate: "<p>Protected</p>",
+			TextTemplate: "Protected",
+			IsProtected:  &protected,
+		}
+	}
+
+	return TestCase{
+		Name: "Protected Email Templates",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/email-templates should not let a plain admin create a protected template",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					manager, token := CreateAdminUser(t, config)
+					grantProtectedTemplatePermission(t, config, manager)
+					managerToken = token
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", newTemplate(), ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/email-templates should let the permission holder create a protected template",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/email-templates", newTemplate(), managerToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					var result dto.EmailTemplateResponse
+					ReadJsonResult(t, resp, &result)
+					require.True(t, result.IsProtected)
+					ctx.CreatedTemplateID = result.ID
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/email-templates/:id should reject a plain admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					subject := "Changed by admin"
+					req := dto.UpdateEmailTemplateRequest{Subject: &subject}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/email-templates/:id should reject a plain admin",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 403)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/email-templates/:id should let the permission holder edit",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					subject := "Changed by manager"
+					req := dto.UpdateEmailTemplateRequest{Subject: &subject}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, req, managerToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.EmailTemplateResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, "Changed by manager", result.Subject)
+					require.True(t, result.IsProtected)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/email-templates/:id should let the permission holder delete",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/email-templates/"+ctx.CreatedTemplateID, nil, managerToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"api/internal/database"
+	apperrors "api/internal/errors"
+	"api/internal/server"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueryTimeout locks the user_preferences table from another transaction so
+// GET /preferences blocks on it, and checks the handler's query is cancelled by
+// the request deadline and answered with 503
+func TestQueryTimeout(t *testing.T) {
+	SkipIfNoDatabase(t)
+	setTestEnvVars()
+	t.Setenv("QUERY_TIMEOUT_MS", "300")
+
+	require.NoError(t, database.Connect())
+	t.Cleanup(func() { database.Close() })
+
+	app := server.NewRouter()
+	token := CreateTestUser(t, app, GenerateTestUser())
+
+	lock := database.DB.Begin()
+	require.NoError(t, lock.Error)
+	t.Cleanup(func() { lock.Rollback() })
+	require.NoError(t, lock.Exec("LOCK TABLE user_preferences IN ACCESS EXCLUSIVE MODE").Error)
+
+	start := time.Now()
+	resp, err := MakeAuthenticatedRequest(t, app, "GET", "/api/v1/protected/preferences", nil, token)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 2*time.Second, "the blocked query should have been cancelled")
+	RequireErrorCode(t, resp, 503, apperrors.ErrRequestTimeout)
+
+	// The pool is still usable once the timed out query is gone
+	require.NoError(t, lock.Rollback().Error)
+	resp, err = MakeAuthenticatedRequest(t, app, "GET", "/api/v1/protected/preferences", nil, token)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+}
@@ -0,0 +1,36 @@
+package tests
+
+import (
+	"api/internal/server"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestAuthRateLimit fires invalid login attempts at the real router until the
+// strict auth limit kicks in. Invalid bodies are rejected before touching the
+// database, so no database is required.
+func TestAuthRateLimit(t *testing.T) {
+	t.Setenv("RATE_LIMIT_AUTH_REQUESTS", "3")
+	t.Setenv("RATE_LIMIT_AUTH_WINDOW", "1m")
+	t.Setenv("RATE_LIMIT_API_REQUESTS", "100")
+
+	app := server.NewRouter()
+
+	for i := 0; i < 3; i++ {
+		resp, err := MakeRequest(t, app, "POST", "/api/v1/auth/login", map[string]string{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 400, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("X-RateLimit-Remaining"))
+	}
+
+	resp, err := MakeRequest(t, app, "POST", "/api/v1/auth/login", map[string]string{}, nil)
+	require.NoError(t, err)
+	RequireErrorResponse(t, resp, 429)
+	require.NotEmpty(t, resp.Header.Get("Retry-After"))
+
+	// Other routes are governed by the looser API limit
+	resp, err = MakeRequest(t, app, "POST", "/api/v1/auth/register", map[string]string{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 400, resp.StatusCode)
+}
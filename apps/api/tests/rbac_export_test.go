@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getRBACExportTestCase tests that an RBAC export imported into a database
+// missing those roles and permissions recreates them
+func getRBACExportTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	roleName := "exporter-" + suffix
+	readName := "export.read." + suffix
+	purgeName := "export.purge." + suffix
+	var exported dto.RBACExport
+
+	exportRBAC := func(t *testing.T, config *TestConfig, ctx *TestContext) dto.RBACExport {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/rbac/export", nil, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var export dto.RBACExport
+		ReadJsonResult(t, resp, &export)
+		return export
+	}
+
+	return TestCase{
+		Name: "RBAC Export and Import",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create a role with two permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					var permissionIDs []string
+					for _, req := range []dto.CreatePermissionRequest{
+						{Name: readName, Resource: "export", Action: "read"},
+						{Name: purgeName, Resource: "export", Action: "purge", Metadata: map[string]interface{}{"dangerous": true}},
+					} {
+						resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, token)
+						require.NoError(t, err)
+						require.Equal(t, 201, resp.StatusCode)
+						permissionIDs = append(permissionIDs, RequireJSONResponse(t, resp)["id"].(string))
+					}
+
+					description := "Exports things"
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{Name: roleName, Description: &description}, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					roleID := RequireJSONResponse(t, resp)["id"].(string)
+
+					req := dto.AssignPermissionsToRoleRequest{PermissionIDs: permissionIDs}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+roleID+"/permissions", req, token)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/rbac/export should embed the role's permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					exported = exportRBAC(t, config, ctx)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					var role *dto.RBACExportRole
+					for i := range exported.Roles {
+						if exported.Roles[i].Name == roleName {
+							role = &exported.Roles[i]
+						}
+					}
+					require.NotNil(t, role)
+					require.Equal(t, "Exports things", *role.Description)
+					require.Len(t, role.Permissions, 2)
+					require.Equal(t, purgeName, role.Permissions[0].Name)
+					require.Equal(t, true, role.Permissions[0].Metadata["dangerous"])
+					require.Equal(t, readName, role.Permissions[1].Name)
+
+					var names []string
+					for _, permission := range exported.Permissions {
+						names = append(names, permission.Name)
+					}
+					require.Contains(t, names, readName)
+					require.Contains(t, names, purgeName)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/rbac/import should recreate deleted roles and permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					require.NoError(t, config.DB.Exec("DELETE FROM roles WHERE name = ?", roleName).Error)
+					require.NoError(t, config.DB.Exec("DELETE FROM permissions WHERE name IN (?, ?)", readName, purgeName).Error)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/rbac/import", exported, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var summary dto.RBACImportResponse
+					ReadJsonResult(t, resp, &summary)
+					require.Equal(t, dto.RBACImportResponse{RolesCreated: 1, PermissionsCreated: 2}, summary)
+				},
+			},
+			{
+				Name: "The imported configuration should match the export",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					require.Equal(t, exported, exportRBAC(t, config, ctx))
+					requireAuditEntry(t, config, ctx, services.AuditActionRBACImport, "rbac")
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Importing the same configuration again should change nothing",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/rbac/import", exported, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var summary dto.RBACImportResponse
+					ReadJsonResult(t, resp, &summary)
+					require.Equal(t, dto.RBACImportResponse{}, summary)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/rbac/import should reject permissions without a resource",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.RBACExport{Permissions: []dto.RBACExportPermission{{Name: "export.broken." + suffix, Action: "read"}}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/rbac/import", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getRoleAccessWindowTestCase tests limiting when a role is active
+func getRoleAccessWindowTestCase() TestCase {
+	return TestCase{
+		Name: "Role Access Windows",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and a role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.CreateRoleRequest{Name: "support-" + uuid.New().String()[:8]}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					ctx.CreatedRoleID = result["id"].(string)
+					require.Empty(t, result["access_windows"])
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/roles/:id should reject an invalid window",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					windows := []dto.AccessWindow{{Days: []string{"Funday"}, Start: "09:00", End: "17:00"}}
+					req := dto.UpdateRoleRequest{AccessWindows: &windows}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+ctx.CreatedRoleID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/roles/:id should set business hours",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					windows := []dto.AccessWindow{{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00", TZ: "UTC"}}
+					req := dto.UpdateRoleRequest{AccessWindows: &windows}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+ctx.CreatedRoleID, req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id should show the windows",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+ctx.CreatedRoleID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					windows := result["access_windows"].([]interface{})
+					require.Len(t, windows, 1)
+					window := windows[0].(map[string]interface{})
+					require.Equal(t, "09:00", window["start"])
+					require.Equal(t, "17:00", window["end"])
+					require.Equal(t, "UTC", window["tz"])
+					require.Len(t, window["days"], 5)
+				},
+			},
+		},
+	}
+}
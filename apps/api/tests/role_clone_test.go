@@ -0,0 +1,123 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getRoleCloneTestCase tests copying a role together with its permissions
+func getRoleCloneTestCase() TestCase {
+	var permissionID string
+	cloneName := "clone-" + uuid.New().String()[:8]
+
+	return TestCase{
+		Name: "Role Cloning",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and a permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					suffix := uuid.New().String()[:8]
+					req := dto.CreatePermissionRequest{
+						Name:     "reports.read." + suffix,
+						Resource: "reports-" + suffix,
+						Action:   "read",
+					}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					permissionID = result["id"].(string)
+				},
+			},
+			{
+				Name: "Setup: Create source role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreateRoleRequest{Name: "source-" + uuid.New().String()[:8]}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					ctx.CreatedRoleID = result["id"].(string)
+				},
+			},
+			{
+				Name: "Setup: Assign permission to source role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AssignPermissionsToRoleRequest{PermissionIDs: []string{permissionID}}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/roles/"+ctx.CreatedRoleID+"/permissions", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/roles/:id/clone should copy permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					description := "Cloned role"
+					req := dto.CloneRoleRequest{Name: cloneName, Description: &description}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles/"+ctx.CreatedRoleID+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					RequireIsUUID(t, result["id"].(string))
+					require.NotEqual(t, ctx.CreatedRoleID, result["id"])
+					require.Equal(t, cloneName, result["name"])
+					require.Equal(t, "Cloned role", result["description"])
+
+					permissions := result["permissions"].([]interface{})
+					require.Len(t, permissions, 1)
+					require.Equal(t, permissionID, permissions[0].(map[string]interface{})["id"])
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/roles/:id/clone should reject a duplicate name",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CloneRoleRequest{Name: cloneName}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles/"+ctx.CreatedRoleID+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/roles/:id/clone should return 404 for unknown role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CloneRoleRequest{Name: "clone-" + uuid.New().String()[:8]}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles/"+uuid.New().String()+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/roles/:id/clone should refuse system roles when PREVENT_SYSTEM_ROLE_CLONE is set",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					t.Setenv("PREVENT_SYSTEM_ROLE_CLONE", "true")
+
+					adminRole, err := services.NewRBACService().GetRoleByName(context.Background(), "admin")
+					require.NoError(t, err)
+
+					req := dto.CloneRoleRequest{Name: "clone-" + uuid.New().String()[:8]}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles/"+adminRole.ID+"/clone", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getRoleExpiryTestCase tests time-limited role assignments
+func getRoleExpiryTestCase() TestCase {
+	return TestCase{
+		Name: "Role Assignment Expiry",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and regular user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					newUser := GenerateTestUser().ToAdminRegisterRequest([]string{"user"})
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", newUser, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					user := result["user"].(map[string]interface{})
+					ctx.CreatedUserID = user["id"].(string)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/users/:id/roles should accept a future expiry",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateRolesRequest{
+						Roles:     []string{"user"},
+						ExpiresAt: map[string]time.Time{"user": time.Now().Add(time.Hour)},
+					}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID+"/roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/users/:id/roles should reject a past expiry",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateRolesRequest{
+						Roles:     []string{"user"},
+						ExpiresAt: map[string]time.Time{"user": time.Now().Add(-time.Hour)},
+					}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID+"/roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id/role-assignments should include expiry",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+ctx.CreatedUserID+"/role-assignments", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+
+					assignments := result["assignments"].([]interface{})
+					require.Len(t, assignments, 1)
+
+					assignment := assignments[0].(map[string]interface{})
+					require.Equal(t, "user", assignment["role_name"])
+					require.NotNil(t, assignment["expires_at"])
+					require.Equal(t, false, assignment["is_expired"])
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func roleNames(roles []dto.RoleResponse) []string {
+	names := make([]string, 0, len(roles))
+	for _, role := range roles {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+// getRoleListTestCase tests searching, filtering and paginating the role list
+func getRoleListTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	search := "list-" + suffix
+	roleA := search + "-a"
+	roleB := search + "-b"
+	roleC := "other-" + suffix
+	roleD := "unrelated-" + suffix
+	var permissionID string
+
+	createRole := func(t *testing.T, config *TestConfig, ctx *TestContext, name string, description *string) string {
+		req := dto.CreateRoleRequest{Name: name, Description: description}
+		resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 201, resp.StatusCode)
+		return RequireJSONResponse(t, resp)["id"].(string)
+	}
+
+	listRoles := func(t *testing.T, resp *http.Response) dto.PaginatedRolesResponse {
+		require.Equal(t, 200, resp.StatusCode)
+		var result dto.PaginatedRolesResponse
+		ReadJsonResult(t, resp, &result)
+		return result
+	}
+
+	return TestCase{
+		Name: "Role List Filters",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create roles and grant a permission to two of them",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					description := "Matches " + search + " by description"
+					roleAID := createRole(t, config, ctx, roleA, nil)
+					createRole(t, config, ctx, roleB, nil)
+					createRole(t, config, ctx, roleC, &description)
+					roleDID := createRole(t, config, ctx, roleD, nil)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", dto.CreatePermissionRequest{
+						Name:     "list.read." + suffix,
+						Resource: "list-" + suffix,
+						Action:   "read",
+					}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					permissionID = RequireJSONResponse(t, resp)["id"].(string)
+
+					req := dto.PermissionRolesRequest{RoleIDs: []string{roleAID, roleDID}}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/"+permissionID+"/assign-to-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles?search should match names and descriptions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles?search="+search, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					result := listRoles(t, resp)
+					require.Equal(t, int64(3), result.Total)
+					require.Equal(t, []string{roleA, roleB, roleC}, roleNames(result.Roles))
+					require.Equal(t, "offset", result.PaginationType)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles?search should be case-insensitive",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles?search=LIST-"+suffix, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, int64(3), listRoles(t, resp).Total)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles?has_permission should list roles granted the permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles?has_permission="+permissionID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					result := listRoles(t, resp)
+					require.Equal(t, int64(2), result.Total)
+					require.Equal(t, []string{roleA, roleD}, roleNames(result.Roles))
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles should combine search and has_permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles?search="+search+"&has_permission="+permissionID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					result := listRoles(t, resp)
+					require.Equal(t, int64(1), result.Total)
+					require.Equal(t, []string{roleA}, roleNames(result.Roles))
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles should paginate filtered roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles?search="+search+"&page=2&limit=2", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					result := listRoles(t, resp)
+					require.Equal(t, int64(3), result.Total)
+					require.Equal(t, 2, result.Page)
+					require.Equal(t, 2, result.Limit)
+					require.Equal(t, 2, result.TotalPages)
+					require.Equal(t, []string{roleC}, roleNames(result.Roles))
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles should reject an invalid has_permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles?has_permission=not-a-uuid", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getRoleUsersTestCase tests listing and counting the users holding a role
+func getRoleUsersTestCase() TestCase {
+	roleName := "members-" + uuid.New().String()[:8]
+	var roleID string
+	users := GenerateTestUsers(3)
+	holders, outsider := users[:2], users[2]
+
+	listRoleUsers := func(t *testing.T, config *TestConfig, ctx *TestContext, query string) dto.PaginatedUsersResponse {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+roleID+"/users"+query, nil, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var page dto.PaginatedUsersResponse
+		ReadJsonResult(t, resp, &page)
+		return page
+	}
+
+	return TestCase{
+		Name: "Role Users",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Grant a new role to two of three users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{Name: roleName}, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					roleID = RequireJSONResponse(t, resp)["id"].(string)
+
+					for i := range users {
+						CreateTestUser(t, config.App, users[i])
+						users[i].ID = userIDByEmail(t, config, users[i].Email)
+					}
+					for _, holder := range holders {
+						req := dto.UpdateRolesRequest{Roles: []string{"user", roleName}}
+						resp, err := MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+holder.ID+"/roles", req, token)
+						require.NoError(t, err)
+						require.Equal(t, 200, resp.StatusCode)
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id/users should list only the role's holders",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					page := listRoleUsers(t, config, ctx, "")
+					require.Equal(t, int64(2), page.Total)
+					require.Equal(t, 1, page.TotalPages)
+
+					var emails []string
+					for _, user := range page.Users {
+						emails = append(emails, user.Email)
+						require.Contains(t, user.Roles, roleName)
+					}
+					require.ElementsMatch(t, []string{holders[0].Email, holders[1].Email}, emails)
+					require.NotContains(t, emails, outsider.Email)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id/users should search within the holders",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					term := strings.TrimPrefix(holders[1].Name, "Test User ")
+					page := listRoleUsers(t, config, ctx, "?search="+url.QueryEscape(term))
+					require.Equal(t, int64(1), page.Total)
+					require.Equal(t, holders[1].Email, page.Users[0].Email)
+
+					term = strings.TrimPrefix(outsider.Name, "Test User ")
+					page = listRoleUsers(t, config, ctx, "?search="+url.QueryEscape(term))
+					require.Zero(t, page.Total)
+					require.Empty(t, page.Users)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id/users should paginate",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					page := listRoleUsers(t, config, ctx, "?limit=1&page=2")
+					require.Equal(t, int64(2), page.Total)
+					require.Equal(t, 2, page.TotalPages)
+					require.Len(t, page.Users, 1)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id/users/count should count the holders",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+roleID+"/users/count", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, float64(2), RequireJSONResponse(t, resp)["count"])
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/roles/:id/users should return 404 for unknown roles",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/roles/"+uuid.New().String()+"/users", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/session"
+	"fmt"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConcurrentSessionLimit logs in past MAX_CONCURRENT_SESSIONS with a
+// Redis session store and checks the oldest session is revoked
+func TestConcurrentSessionLimit(t *testing.T) {
+	SkipIfNoDatabase(t)
+	config := SetupTestEnvironment(t)
+	defer CleanupTestEnvironment(t, config)
+
+	t.Setenv("MAX_CONCURRENT_SESSIONS", "2")
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	previous := session.Sessions()
+	session.SetSessions(session.NewRedisStore(client))
+	t.Cleanup(func() { session.SetSessions(previous) })
+
+	user := GenerateTestUser()
+	registerTestUser(t, config.App, user)
+
+	tokens := make([]string, 3)
+	for i := range tokens {
+		resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/login", user.ToLoginRequest(), map[string]string{"User-Agent": fmt.Sprintf("device-%d", i+1)})
+		require.NoError(t, err)
+		tokens[i] = RequireAuthToken(t, resp)
+	}
+
+	// The first login is the oldest of three sessions and was revoked
+	resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, tokens[0])
+	require.NoError(t, err)
+	RequireErrorCode(t, resp, 401, apperrors.ErrAuthSessionRevoked)
+
+	resp, err = MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/sessions", nil, tokens[2])
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	result := RequireJSONResponse(t, resp)
+	require.Equal(t, float64(2), result["total"])
+
+	sessions := result["sessions"].([]interface{})
+	newest := sessions[0].(map[string]interface{})
+	require.Equal(t, "device-3", newest["user_agent"])
+	require.Equal(t, true, newest["current"])
+	older := sessions[1].(map[string]interface{})
+	require.Equal(t, "device-2", older["user_agent"])
+	require.Equal(t, false, older["current"])
+
+	// Revoking the second session signs its token out
+	resp, err = MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/sessions/"+older["id"].(string), nil, tokens[2])
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	resp, err = MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, tokens[1])
+	require.NoError(t, err)
+	RequireErrorCode(t, resp, 401, apperrors.ErrAuthSessionRevoked)
+
+	resp, err = MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/sessions/"+older["id"].(string), nil, tokens[2])
+	require.NoError(t, err)
+	RequireErrorCode(t, resp, 404, apperrors.ErrSessionNotFound)
+}
+
+// TestPasswordChangeRevokesSessions checks that changing a password signs out
+// every session of the user
+func TestPasswordChangeRevokesSessions(t *testing.T) {
+	SkipIfNoDatabase(t)
+	config := SetupTestEnvironment(t)
+	defer CleanupTestEnvironment(t, config)
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	previous := session.Sessions()
+	session.SetSessions(session.NewRedisStore(client))
+	t.Cleanup(func() { session.SetSessions(previous) })
+
+	user := GenerateTestUser()
+	registerTestUser(t, config.App, user)
+
+	tokens := make([]string, 2)
+	for i := range tokens {
+		resp, err := MakeRequest(t, config.App, "POST", "/api/v1/auth/login", user.ToLoginRequest(), nil)
+		require.NoError(t, err)
+		tokens[i] = RequireAuthToken(t, resp)
+	}
+
+	req := dto.ChangePasswordRequest{CurrentPassword: user.Password, NewPassword: "changed-password-1", ConfirmPassword: "changed-password-1"}
+	resp, err := MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/protected/change-password", req, tokens[0])
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	for _, token := range tokens {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, token)
+		require.NoError(t, err)
+		RequireErrorCode(t, resp, 401, apperrors.ErrAuthSessionRevoked)
+	}
+}
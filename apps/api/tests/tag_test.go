@@ -0,0 +1,166 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getTagTestCase tests tagging users and filtering the user list by tag
+func getTagTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	vip, beta := "vip-"+suffix, "beta-"+suffix
+	var vipID string
+	users := GenerateTestUsers(2)
+	tagged, untagged := users[0], users[1]
+
+	listUserEmails := func(t *testing.T, config *TestConfig, ctx *TestContext, tag string) []string {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users?tag="+tag, nil, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var page dto.PaginatedUsersResponse
+		ReadJsonResult(t, resp, &page)
+		var emails []string
+		for _, user := range page.Users {
+			emails = append(emails, user.Email)
+		}
+		return emails
+	}
+
+	return TestCase{
+		Name: "User Tags",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin, users and two tags",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					for i := range users {
+						CreateTestUser(t, config.App, users[i])
+						users[i].ID = userIDByEmail(t, config, users[i].Email)
+					}
+					tagged, untagged = users[0], users[1]
+
+					for _, name := range []string{vip, beta} {
+						resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/tags", dto.CreateTagRequest{Name: name}, token)
+						require.NoError(t, err)
+						require.Equal(t, 201, resp.StatusCode)
+						result := RequireJSONResponse(t, resp)
+						require.Equal(t, name, result["name"])
+						if name == vip {
+							vipID = result["id"].(string)
+						}
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/tags should reject a duplicate name",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/tags", dto.CreateTagRequest{Name: vip}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 409, "TAG_EXISTS")
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/tags should list the tags",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/tags", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var names []string
+					for _, tag := range RequireJSONResponse(t, resp)["tags"].([]interface{}) {
+						names = append(names, tag.(map[string]interface{})["name"].(string))
+					}
+					require.Contains(t, names, vip)
+					require.Contains(t, names, beta)
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/tags should reject unknown tags",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateUserTagsRequest{Tags: []string{vip, "missing-" + suffix}}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+tagged.ID+"/tags", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 400, "TAG_NOT_FOUND")
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/tags should replace the user's tags",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateUserTagsRequest{Tags: []string{vip, beta}}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+tagged.ID+"/tags", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var user dto.UserManagementResponse
+					ReadJsonResult(t, resp, &user)
+					require.ElementsMatch(t, []string{vip, beta}, user.Tags)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users?tag= should list only tagged users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					emails := listUserEmails(t, config, ctx, vip)
+					require.Equal(t, []string{tagged.Email}, emails)
+					require.NotContains(t, emails, untagged.Email)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id should include the tags",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+tagged.ID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var user dto.UserDetailResponse
+					ReadJsonResult(t, resp, &user)
+					require.ElementsMatch(t, []string{vip, beta}, user.Tags)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/tags/:id should untag its users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/tags/"+vipID, nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					require.Empty(t, listUserEmails(t, config, ctx, vip))
+					require.Equal(t, []string{tagged.Email}, listUserEmails(t, config, ctx, beta))
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/tags/"+vipID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, "TAG_NOT_FOUND")
+				},
+			},
+			{
+				Name: "PATCH /api/v1/admin/users/:id/tags with an empty list should remove all tags",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateUserTagsRequest{Tags: []string{}}
+					return MakeAuthenticatedRequest(t, config.App, "PATCH", "/api/v1/admin/users/"+tagged.ID+"/tags", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var user dto.UserManagementResponse
+					ReadJsonResult(t, resp, &user)
+					require.Empty(t, user.Tags)
+				},
+			},
+		},
+	}
+}
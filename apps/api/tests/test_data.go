@@ -11,7 +11,6 @@ type TestUser struct {
 	Password string
 	Name     string
 	Phone    *string
-	Company  *string
 	ID       string
 	Token    string
 }
@@ -19,14 +18,12 @@ type TestUser struct {
 // GenerateTestUser creates a new test user with random data
 func GenerateTestUser() TestUser {
 	phone := GenerateUniquePhone()
-	company := "Test Company Inc."
 	
 	return TestUser{
 		Email:    GenerateUniqueEmail(),
 		Password: "password123",
 		Name:     GenerateUniqueName(),
 		Phone:    &phone,
-		Company:  &company,
 		ID:       uuid.New().String(),
 	}
 }
@@ -65,7 +62,6 @@ func (u TestUser) ToAdminRegisterRequest(roles []string) dto.AdminRegisterUserRe
 		Password: u.Password,
 		Name:     u.Name,
 		Phone:    u.Phone,
-		Company:  u.Company,
 		Roles:    roles,
 	}
 }
@@ -97,7 +93,7 @@ type TestPermission struct {
 // GenerateTestPermission creates a test permission
 func GenerateTestPermission() TestPermission {
 	return TestPermission{
-		Name:        "test-permission-" + uuid.New().String()[:8],
+		Name:        "test-resource.read-" + uuid.New().String()[:8],
 		Description: "Test permission for API testing",
 		Resource:    "test-resource",
 		Action:      "read",
@@ -139,14 +135,12 @@ var DefaultTestData = struct {
 		Password: "admin123",
 		Name:     "Test Admin",
 		Phone:    nil,
-		Company:  nil,
 	},
 	RegularUser: TestUser{
 		Email:    "user@test.com",
 		Password: "user123",
 		Name:     "Test User",
 		Phone:    nil,
-		Company:  nil,
 	},
 	AdminRole: TestRole{
 		Name:        "admin",
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"api/internal/helpers"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -73,14 +74,25 @@ func RequireJSONResponseFromBody(t require.TestingT, body string) map[string]int
 // RequireErrorResponse validates error response format
 func RequireErrorResponse(t require.TestingT, resp *http.Response, expectedStatusCode int) {
 	require.Equal(t, expectedStatusCode, resp.StatusCode)
-	
-	result := RequireJSONResponse(t, resp)
-	
-	// Check if error field exists
-	_, hasError := result["error"]
-	_, hasMessage := result["message"]
-	
-	require.True(t, hasError || hasMessage, "Error response should contain 'error' or 'message' field")
+
+	var envelope helpers.ErrorEnvelope
+	ReadJsonResult(t, resp, &envelope)
+
+	require.NotEmpty(t, envelope.Error, "Error response should contain 'error'")
+	require.NotEmpty(t, envelope.Code, "Error response should contain 'code'")
+}
+
+// RequireErrorCode validates the status and error code of an error response
+// and returns its body
+func RequireErrorCode(t require.TestingT, resp *http.Response, expectedStatusCode int, expectedCode string) helpers.ErrorEnvelope {
+	require.Equal(t, expectedStatusCode, resp.StatusCode)
+
+	var envelope helpers.ErrorEnvelope
+	ReadJsonResult(t, resp, &envelope)
+
+	require.Equal(t, expectedCode, envelope.Code)
+	require.NotEmpty(t, envelope.Error, "Error response should contain 'error'")
+	return envelope
 }
 
 // RequireSuccessResponse validates success response format
@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"api/internal/auth"
+	"api/internal/dto"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireTokenLifetime asserts that a login response carries a token valid
+// for want
+func requireTokenLifetime(t *testing.T, resp *http.Response, want time.Duration) {
+	token := RequireAuthToken(t, resp)
+	claims, err := auth.ValidateToken(token)
+	require.NoError(t, err)
+	require.Equal(t, want, claims.ExpiresAt.Sub(claims.IssuedAt.Time))
+}
+
+// getTokenSettingsTestCase tests per-user token lifetimes
+func getTokenSettingsTestCase() TestCase {
+	var userID string
+
+	return TestCase{
+		Name: "Token Settings",
+		Steps: []TestStep{
+			{
+				Name: "PUT /api/v1/admin/users/:id/token-settings should set the token lifetime",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+					require.NoError(t, err)
+					userID = RequireJSONResponse(t, resp)["id"].(string)
+
+					seconds := int64(7 * 24 * 60 * 60)
+					req := dto.UpdateTokenSettingsRequest{TokenExpirySeconds: &seconds}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+userID+"/token-settings", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.UserManagementResponse
+					ReadJsonResult(t, resp, &result)
+					require.NotNil(t, result.TokenExpiryOverride)
+					require.Equal(t, int64(7*24*60*60), *result.TokenExpiryOverride)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login should apply the override",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", ctx.RegularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					requireTokenLifetime(t, resp, 7*24*time.Hour)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/users/:id/token-settings above the maximum should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					seconds := int64(31 * 24 * 60 * 60)
+					req := dto.UpdateTokenSettingsRequest{TokenExpirySeconds: &seconds}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+userID+"/token-settings", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/users/:id/token-settings with 0 should restore the default",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					seconds := int64(0)
+					req := dto.UpdateTokenSettingsRequest{TokenExpirySeconds: &seconds}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+userID+"/token-settings", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.UserManagementResponse
+					ReadJsonResult(t, resp, &result)
+					require.Nil(t, result.TokenExpiryOverride)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login should use the default lifetime again",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", ctx.RegularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					requireTokenLifetime(t, resp, auth.DefaultTokenExpiration())
+				},
+			},
+		},
+	}
+}
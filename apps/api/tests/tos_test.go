@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getToSTestCase tests terms of service publishing and acceptance
+func getToSTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	firstVersion := "1.0-" + suffix
+	secondVersion := "1.1-" + suffix
+
+	return TestCase{
+		Name: "Terms of Service",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/tos should publish a version",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					req := dto.PublishToSVersionRequest{Version: firstVersion, Content: "Be nice."}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/tos", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					RequireIsUUID(t, result["id"].(string))
+					require.Equal(t, firstVersion, result["version"])
+				},
+			},
+			{
+				Name: "GET /api/v1/auth/tos/current should return the published version",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "GET", "/api/v1/auth/tos/current", nil, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, firstVersion, result["version"])
+					require.Equal(t, "Be nice.", result["content"])
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/register without accepting the terms should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", ctx.RegularUser.ToRegisterRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/register accepting the current terms should succeed",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := ctx.RegularUser.ToRegisterRequest()
+					req.ToSVersion = firstVersion
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/register", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					ctx.UserToken = result["token"].(string)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile should succeed after registering",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile should require accepting a new version",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PublishToSVersionRequest{Version: secondVersion, Content: "Be nicer."}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/tos", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 403, resp.StatusCode)
+					result := RequireErrorCode(t, resp, 403, apperrors.ErrToSAcceptanceRequired)
+					require.Equal(t, secondVersion, result.Details.(map[string]interface{})["current_version"])
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/tos/accept with an old version should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AcceptToSRequest{ToSVersion: firstVersion}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/tos/accept", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/tos/accept should accept the current version",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.AcceptToSRequest{ToSVersion: secondVersion}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/tos/accept", req, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, secondVersion, result["version"])
+				},
+			},
+			{
+				Name: "GET /api/v1/protected/profile should succeed after accepting",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/tos with an existing version should conflict",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.PublishToSVersionRequest{Version: secondVersion, Content: "Duplicate."}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/tos", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package tests
+
+import (
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getUserDetailTestCase tests fetching a single user as an admin
+func getUserDetailTestCase() TestCase {
+	user := GenerateTestUser()
+
+	return TestCase{
+		Name: "User Detail",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and regular user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					CreateTestUser(t, config.App, user)
+					user.ID = userIDByEmail(t, config, user.Email)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id should return the user with roles and effective permissions",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+user.ID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.UserDetailResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, user.ID, result.ID)
+					require.Equal(t, user.Email, result.Email)
+					require.Contains(t, result.Roles, "user")
+					names := permissionNames(result.EffectivePermissions)
+					require.Contains(t, names, "user.read")
+					require.NotContains(t, names, "admin.access")
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id should return 404 for an unknown user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+uuid.New().String(), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrUserNotFound)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id should return 404 for an ID that is not a UUID",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/not-a-uuid", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, apperrors.ErrUserNotFound)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/:id should be forbidden for regular users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					token := CreateTestUser(t, config.App, GenerateTestUser())
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+user.ID, nil, token)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 403, apperrors.ErrPermissionDenied)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"api/internal/auth"
+	"api/internal/dto"
+	apperrors "api/internal/errors"
+	"api/internal/services"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// getUserEmailTestCase tests adding, verifying and switching to a secondary
+// email address
+func getUserEmailTestCase() TestCase {
+	var secondaryID, secondaryAddress string
+
+	return TestCase{
+		Name: "User Emails",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/protected/emails should add an unverified address",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+					secondaryAddress = GenerateUniqueEmail()
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/emails", dto.AddEmailRequest{Email: secondaryAddress}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var email dto.UserEmailResponse
+					ReadJsonResult(t, resp, &email)
+					require.Equal(t, secondaryAddress, email.Address)
+					require.False(t, email.Primary)
+					require.False(t, email.Verified)
+					secondaryID = email.ID
+				},
+			},
+			{
+				Name: "Adding the same address again should conflict",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/emails", dto.AddEmailRequest{Email: secondaryAddress}, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "An address whose verification email fails to send should not be stored",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					// A separate user keeps the address list of the main one intact
+					token := CreateTestUser(t, config.App, GenerateTestUser())
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, token)
+					require.NoError(t, err)
+					userID := RequireJSONResponse(t, resp)["id"].(string)
+					address := GenerateUniqueEmail()
+
+					failed := func(to, token string) error { return errors.New("smtp unavailable") }
+					_, err = services.NewUserEmailService().AddEmail(context.Background(), userID, address, failed)
+					require.ErrorIs(t, err, services.ErrEmailVerificationNotSent)
+
+					var stored int64
+					require.NoError(t, config.DB.Raw("SELECT COUNT(*) FROM user_emails WHERE email = ?", address).Scan(&stored).Error)
+					require.Zero(t, stored)
+
+					// Retrying once the mailer works again is not a conflict
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/emails", dto.AddEmailRequest{Email: address}, token)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+				},
+			},
+			{
+				Name: "An unverified address should not become primary",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/emails/"+secondaryID+"/set-primary", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/verify-email with an expired token should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					token, hashedToken, err := auth.GenerateResetToken()
+					require.NoError(t, err)
+					require.NoError(t, config.DB.Exec("UPDATE user_emails SET verification_token_hash = ?, verification_expires_at = NOW() - INTERVAL '1 minute' WHERE id = ?", hashedToken, secondaryID).Error)
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/verify-email", dto.VerifyEmailRequest{Token: token}, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 401, apperrors.ErrAuthInvalidVerificationToken)
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/verify-email should verify the address",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					// The emailed token is not readable here, so replace its hash
+					token, hashedToken, err := auth.GenerateResetToken()
+					require.NoError(t, err)
+					require.NoError(t, config.DB.Exec("UPDATE user_emails SET verification_token_hash = ?, verification_expires_at = NOW() + INTERVAL '1 hour' WHERE id = ?", hashedToken, secondaryID).Error)
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/verify-email", dto.VerifyEmailRequest{Token: token}, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var email dto.UserEmailResponse
+					ReadJsonResult(t, resp, &email)
+					require.Equal(t, secondaryID, email.ID)
+					require.True(t, email.Verified)
+				},
+			},
+			{
+				Name: "POST /api/v1/protected/emails/:id/set-primary should swap the primary address",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/protected/emails/"+secondaryID+"/set-primary", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var email dto.UserEmailResponse
+					ReadJsonResult(t, resp, &email)
+					require.True(t, email.Primary)
+				},
+			},
+			{
+				Name: "The profile should list both addresses with the new one primary",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var profile dto.ProfileResponse
+					ReadJsonResult(t, resp, &profile)
+					require.Equal(t, secondaryAddress, profile.Email)
+					require.Len(t, profile.Emails, 2)
+					require.Equal(t, dto.UserEmailResponse{ID: secondaryID, Address: secondaryAddress, Primary: true, Verified: true}, profile.Emails[0])
+					require.Equal(t, ctx.RegularUser.Email, profile.Emails[1].Address)
+					require.False(t, profile.Emails[1].Primary)
+					require.True(t, profile.Emails[1].Verified)
+				},
+			},
+			{
+				Name: "Login should use the new primary address",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.LoginRequest{Email: secondaryAddress, Password: ctx.RegularUser.Password}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", req, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/protected/emails/:id should not remove the primary address",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/protected/emails/"+secondaryID, nil, ctx.UserToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"encoding/csv"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getUserExportTestCase tests that the user export returns every user
+// matching the list filters, in order, as CSV or JSON
+func getUserExportTestCase() TestCase {
+	companyName := "Exporters " + uuid.New().String()[:8]
+	var companyID string
+	users := GenerateTestUsers(3)
+	sort.Slice(users, func(i, j int) bool { return users[i].Email < users[j].Email })
+
+	exportPath := func(format string) string {
+		return "/api/v1/admin/users/export?format=" + format + "&company_id=" + companyID + "&sort_by=email"
+	}
+
+	return TestCase{
+		Name: "User Export",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create three users in a company",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/companies", dto.CreateCompanyRequest{Name: companyName}, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					companyID = RequireJSONResponse(t, resp)["id"].(string)
+
+					for i := range users {
+						CreateTestUser(t, config.App, users[i])
+						users[i].ID = userIDByEmail(t, config, users[i].Email)
+						require.NoError(t, config.DB.Exec("UPDATE users SET company_id = ? WHERE id = ?", companyID, users[i].ID).Error)
+					}
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/export should download the matching users as CSV",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", exportPath("csv"), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.True(t, strings.HasPrefix(resp.Header.Get("Content-Type"), "text/csv"))
+					filename := "users-" + time.Now().UTC().Format("2006-01-02") + ".csv"
+					require.Contains(t, resp.Header.Get("Content-Disposition"), filename)
+
+					rows, err := csv.NewReader(resp.Body).ReadAll()
+					require.NoError(t, err)
+					require.Len(t, rows, len(users)+1)
+					require.Equal(t, services.UserExportColumns, rows[0])
+
+					for i, user := range users {
+						row := rows[i+1]
+						require.Equal(t, user.ID, row[0])
+						require.Equal(t, user.Email, row[1])
+						require.Equal(t, user.Name, row[2])
+						require.Equal(t, *user.Phone, row[3])
+						require.Equal(t, companyName, row[4])
+						require.Equal(t, "user", row[5])
+						_, err := time.Parse(time.RFC3339, row[6])
+						require.NoError(t, err)
+					}
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/export?format=json should download the same users as JSON",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", exportPath("json"), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Contains(t, resp.Header.Get("Content-Disposition"), ".json")
+
+					var records []services.UserExportRecord
+					ReadJsonResult(t, resp, &records)
+					require.Len(t, records, len(users))
+					for i, user := range users {
+						require.Equal(t, user.Email, records[i].Email)
+						require.Equal(t, companyName, *records[i].Company)
+						require.Equal(t, []string{"user"}, records[i].Roles)
+					}
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users/export should reject unknown formats",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/export?format=xlsx", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
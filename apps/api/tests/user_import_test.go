@@ -0,0 +1,105 @@
+package tests
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// importUsersCSV uploads a CSV fixture to the user import endpoint
+func importUsersCSV(t *testing.T, app *fiber.App, token, fixture string) (*http.Response, error) {
+	content, err := os.ReadFile(fixture)
+	require.NoError(t, err)
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "users.csv")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, err := http.NewRequest("POST", "/api/v1/admin/users/import", &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return app.Test(req, -1)
+}
+
+// getUserImportTestCase tests bulk user import from CSV
+func getUserImportTestCase() TestCase {
+	return TestCase{
+		Name: "User Import",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/import should import valid rows",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return importUsersCSV(t, config.App, ctx.AdminToken, "testdata/users_import.csv")
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(5), result["total"])
+					require.Equal(t, float64(3), result["imported"])
+					require.Equal(t, float64(0), result["skipped"])
+
+					failures := result["failures"].([]interface{})
+					require.Len(t, failures, 2)
+					require.Equal(t, float64(5), failures[0].(map[string]interface{})["row"])
+					require.Equal(t, float64(6), failures[1].(map[string]interface{})["row"])
+				},
+			},
+			{
+				Name: "Imported user should log in with the provided password",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					login := map[string]string{"email": "import-alice@example.com", "password": "alice-secret"}
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", login, nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Re-importing the same file should skip existing users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return importUsersCSV(t, config.App, ctx.AdminToken, "testdata/users_import.csv")
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, float64(0), result["imported"])
+					require.Equal(t, float64(3), result["skipped"])
+				},
+			},
+			{
+				Name: "Import without a file should return 400",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/import", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getUserMergeTestCase tests folding a duplicate account into another user
+func getUserMergeTestCase() TestCase {
+	roleName := "merged-" + uuid.New().String()[:8]
+	target, source := GenerateTestUser(), GenerateTestUser()
+	var targetToken string
+
+	return TestCase{
+		Name: "User Merge",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create a target user and a duplicate with an extra role and preference",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					targetToken = CreateTestUser(t, config.App, target)
+					target.ID = userIDByEmail(t, config, target.Email)
+					sourceToken := CreateTestUser(t, config.App, source)
+					source.ID = userIDByEmail(t, config, source.Email)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{Name: roleName}, token)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					req := dto.UpdateRolesRequest{Roles: []string{"user", roleName}}
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+source.ID+"/roles", req, token)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					// Both users share the default preferences; only the source
+					// has a timezone, and the target's theme must survive
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/timezone", map[string]interface{}{"value": "Asia/Jakarta"}, sourceToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/theme", map[string]interface{}{"value": "light"}, sourceToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					resp, err = MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/protected/preferences/theme", map[string]interface{}{"value": "dark"}, targetToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/merge should reject merging a user into themselves",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.MergeUsersRequest{SourceUserID: target.ID, TransferRoles: true, TransferPreferences: true}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+target.ID+"/merge", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+					ResponseContains(t, resp, "into themselves")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/merge should return 404 for an unknown source user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.MergeUsersRequest{SourceUserID: uuid.New().String()}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+target.ID+"/merge", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+					ResponseContains(t, resp, "Source user not found")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/merge should copy missing roles and preferences",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.MergeUsersRequest{SourceUserID: source.ID, TransferRoles: true, TransferPreferences: true}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+target.ID+"/merge", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.MergeUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, target.ID, result.TargetUserID)
+					require.Equal(t, source.ID, result.SourceUserID)
+					require.Equal(t, int64(1), result.RolesTransferred)
+					require.Equal(t, int64(1), result.PreferencesTransferred)
+				},
+			},
+			{
+				Name: "The target should hold the merged role and preference",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+target.ID+"/role-assignments", nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					ResponseContains(t, resp, roleName)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/preferences", nil, targetToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.PreferencesResponse
+					ReadJsonResult(t, resp, &result)
+					require.JSONEq(t, `"Asia/Jakarta"`, string(result.Preferences["timezone"]))
+					require.JSONEq(t, `"dark"`, string(result.Preferences["theme"]))
+				},
+			},
+			{
+				Name: "The source should be deleted and the merge audited",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					requireAuditEntry(t, config, ctx, services.AuditActionUserMerge, target.ID)
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/users/"+source.ID+"/role-assignments", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,136 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/models"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// insertPaginationUsers inserts members of the given company directly, count at a time concurrently
+func insertPaginationUsers(t *testing.T, config *TestConfig, companyID string, count int) {
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- config.DB.Exec(
+				"INSERT INTO users (email, password, name, company_id) VALUES (?, 'x', ?, ?)",
+				GenerateUniqueEmail(), GenerateUniqueName(), companyID,
+			).Error
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+}
+
+// getUserPaginationTestCase tests cursor pagination of the admin user list
+func getUserPaginationTestCase() TestCase {
+	company := models.Company{Name: "pagination-" + uuid.New().String()}
+	listPath := "/api/v1/admin/users?limit=2&search=" + url.QueryEscape(company.Name)
+
+	var expectedIDs []string
+	var seenIDs []string
+	var nextCursor string
+	inserted := 0
+
+	return TestCase{
+		Name: "User Cursor Pagination",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and users to page through",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					require.NoError(t, config.DB.Create(&company).Error)
+					insertPaginationUsers(t, config, company.ID, 5)
+					err := config.DB.Raw("SELECT id FROM users WHERE company_id = ? ORDER BY created_at DESC, id DESC", company.ID).Scan(&expectedIDs).Error
+					require.NoError(t, err)
+					require.Len(t, expectedIDs, 5)
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", listPath+"&pagination=cursor", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.CursorPaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, "cursor", result.PaginationType)
+					require.True(t, result.HasMore)
+					require.NotNil(t, result.NextCursor)
+					require.Len(t, result.Users, 2)
+
+					for _, user := range result.Users {
+						seenIDs = append(seenIDs, user.ID)
+					}
+					nextCursor = *result.NextCursor
+				},
+			},
+			{
+				Name: "Following pages should be stable while users are inserted",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					for {
+						// New users sort ahead of the cursor and must not shift later pages
+						insertPaginationUsers(t, config, company.ID, 3)
+						inserted += 3
+
+						resp, err := MakeAuthenticatedRequest(t, config.App, "GET", listPath+"&after="+url.QueryEscape(nextCursor), nil, ctx.AdminToken)
+						require.NoError(t, err)
+						require.Equal(t, 200, resp.StatusCode)
+
+						var result dto.CursorPaginatedUsersResponse
+						ReadJsonResult(t, resp, &result)
+						for _, user := range result.Users {
+							seenIDs = append(seenIDs, user.ID)
+						}
+						if !result.HasMore {
+							require.Nil(t, result.NextCursor)
+							break
+						}
+						nextCursor = *result.NextCursor
+					}
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", listPath, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, expectedIDs, seenIDs, "cursor pages skipped or repeated users")
+
+					// Offset pagination remains available but is flagged as such
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Equal(t, "offset", result.PaginationType)
+					require.Equal(t, int64(len(expectedIDs)+inserted), result.Total)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users with an invalid cursor should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", listPath+"&after=not-a-cursor", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users with a cursor and unsupported sort should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", listPath+"&pagination=cursor&sort_by=name", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+		},
+	}
+}
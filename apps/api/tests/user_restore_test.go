@@ -0,0 +1,190 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getUserRestoreTestCase tests restoring soft-deleted users
+func getUserRestoreTestCase() TestCase {
+	var userID, otherUserID string
+	other := GenerateTestUser()
+	restoredRole := "restored-" + uuid.New().String()[:8]
+
+	// deleteWithoutRoles deletes a new user and strips their role assignments
+	deleteWithoutRoles := func(t *testing.T, config *TestConfig, ctx *TestContext) string {
+		token := CreateTestUser(t, config.App, GenerateTestUser())
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, token)
+		require.NoError(t, err)
+		id := RequireJSONResponse(t, resp)["id"].(string)
+
+		resp, err = MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/users/"+id, nil, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+		require.NoError(t, config.DB.Exec("DELETE FROM user_roles WHERE user_id = ?", id).Error)
+		return id
+	}
+
+	return TestCase{
+		Name: "User Restore",
+		Steps: []TestStep{
+			{
+				Name: "DELETE /api/v1/admin/users/:id should block login",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					ctx.RegularUser = GenerateTestUser()
+					ctx.UserToken = CreateTestUser(t, config.App, ctx.RegularUser)
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, ctx.UserToken)
+					require.NoError(t, err)
+					userID = RequireJSONResponse(t, resp)["id"].(string)
+
+					resp, err = MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/users/"+userID, nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", ctx.RegularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 401)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users should hide deleted users by default",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					path := "/api/v1/admin/users?search=" + url.QueryEscape(ctx.RegularUser.Email)
+					return MakeAuthenticatedRequest(t, config.App, "GET", path, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Empty(t, result.Users)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/users with include_deleted should list deleted users",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					path := "/api/v1/admin/users?include_deleted=true&search=" + url.QueryEscape(ctx.RegularUser.Email)
+					return MakeAuthenticatedRequest(t, config.App, "GET", path, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					var result dto.PaginatedUsersResponse
+					ReadJsonResult(t, resp, &result)
+					require.Len(t, result.Users, 1)
+					require.Equal(t, userID, result.Users[0].ID)
+					require.NotNil(t, result.Users[0].DeletedAt)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/restore should restore the user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+userID+"/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, userID, result["id"])
+					require.Nil(t, result["deleted_at"])
+					require.Contains(t, result["roles"], "user")
+				},
+			},
+			{
+				Name: "POST /api/v1/auth/login should succeed after restore",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeRequest(t, config.App, "POST", "/api/v1/auth/login", ctx.RegularUser.ToLoginRequest(), nil)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					RequireAuthToken(t, resp)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/restore on an active user should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+userID+"/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/restore should conflict when the email was reused",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					token := CreateTestUser(t, config.App, other)
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/protected/profile", nil, token)
+					require.NoError(t, err)
+					otherUserID = RequireJSONResponse(t, resp)["id"].(string)
+
+					resp, err = MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/users/"+otherUserID, nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					// The email of a deleted user is free to register again
+					CreateTestUser(t, config.App, other)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+otherUserID+"/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 409)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/restore should give a user without roles the default role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.CreateRoleRequest{Name: restoredRole}
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					resp, err = setDefaultRole(t, config, ctx, restoredRole)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					id := deleteWithoutRoles(t, config, ctx)
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+id+"/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Equal(t, []interface{}{restoredRole}, result["roles"])
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/:id/restore should leave a user without roles when the default is empty",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := setDefaultRole(t, config, ctx, "")
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+
+					id := deleteWithoutRoles(t, config, ctx)
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users/"+id+"/restore", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					require.Empty(t, result["roles"])
+				},
+			},
+			{
+				Name: "Cleanup: Restore the user role as the default",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return setDefaultRole(t, config, ctx, services.DefaultUserRole)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,174 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getWebhookDeliveryTestCase tests recording failed webhook deliveries and
+// replaying them once the receiver is back up
+func getWebhookDeliveryTestCase() TestCase {
+	var receiver *httptest.Server
+	var healthy atomic.Bool
+	var received atomic.Int32
+	var webhookID, failedID string
+
+	listDeliveries := func(t *testing.T, config *TestConfig, ctx *TestContext, query string) []dto.WebhookDeliveryResponse {
+		resp, err := MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/webhooks/"+webhookID+"/deliveries"+query, nil, ctx.AdminToken)
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var result struct {
+			Deliveries []dto.WebhookDeliveryResponse `json:"deliveries"`
+			Total      int                           `json:"total"`
+		}
+		ReadJsonResult(t, resp, &result)
+		require.Len(t, result.Deliveries, result.Total)
+		return result.Deliveries
+	}
+
+	replay := func(t *testing.T, config *TestConfig, ctx *TestContext, deliveryID string) (*http.Response, error) {
+		return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/webhooks/deliveries/"+deliveryID+"/replay", nil, ctx.AdminToken)
+	}
+
+	return TestCase{
+		Name: "Webhook Deliveries",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create admin and a webhook whose receiver is down",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						received.Add(1)
+						if !healthy.Load() {
+							w.WriteHeader(http.StatusServiceUnavailable)
+							w.Write([]byte("maintenance"))
+							return
+						}
+						w.WriteHeader(http.StatusOK)
+					}))
+					t.Cleanup(receiver.Close)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/webhooks", dto.CreateWebhookRequest{
+						URL:    receiver.URL,
+						Events: []string{services.WebhookEventRoleCreated},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var result dto.CreateWebhookResponse
+					ReadJsonResult(t, resp, &result)
+					webhookID = result.Webhook.ID
+				},
+			},
+			{
+				Name: "Failed deliveries should be recorded for every attempt",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", dto.CreateRoleRequest{
+						Name: "webhook-" + uuid.New().String()[:8],
+					}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+
+					services.WaitForWebhookDeliveries()
+
+					deliveries := listDeliveries(t, config, ctx, "?failed=true&limit=20")
+					require.Len(t, deliveries, int(received.Load()))
+					require.Greater(t, len(deliveries), 1, "failed deliveries should have been retried")
+					for _, delivery := range deliveries {
+						require.False(t, delivery.Succeeded)
+						require.Equal(t, services.WebhookEventRoleCreated, delivery.Event)
+						require.NotNil(t, delivery.ResponseStatus)
+						require.Equal(t, http.StatusServiceUnavailable, *delivery.ResponseStatus)
+						require.Equal(t, "maintenance", *delivery.ResponseBody)
+						require.Contains(t, string(delivery.Payload), services.WebhookEventRoleCreated)
+					}
+					failedID = deliveries[0].ID
+
+					require.Len(t, listDeliveries(t, config, ctx, "?failed=true&limit=1"), 1)
+					return &http.Response{StatusCode: 200}, nil
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/webhooks/deliveries/:id/replay should fail while the receiver is down",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return replay(t, config, ctx, failedID)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 502, "WEBHOOK_REPLAY_FAILED")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/webhooks/deliveries/:id/replay should succeed once the receiver is back up",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					healthy.Store(true)
+					return replay(t, config, ctx, failedID)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireSuccessResponse(t, resp, 200)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/webhooks/:id/deliveries should list the successful replay",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					deliveries := listDeliveries(t, config, ctx, "")
+					latest := deliveries[0]
+					require.True(t, latest.Succeeded)
+					require.NotNil(t, latest.ReplayOf)
+					require.Equal(t, failedID, *latest.ReplayOf)
+
+					for _, delivery := range listDeliveries(t, config, ctx, "?failed=true") {
+						require.NotEqual(t, latest.ID, delivery.ID)
+					}
+
+					return replay(t, config, ctx, latest.ID)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 409, "WEBHOOK_DELIVERY_SUCCEEDED")
+				},
+			},
+			{
+				Name: "Replays of replays should stop at the replay limit",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					healthy.Store(false)
+
+					deliveryID := failedID
+					for {
+						resp, err := replay(t, config, ctx, deliveryID)
+						require.NoError(t, err)
+						if resp.StatusCode != 502 {
+							return resp, nil
+						}
+						deliveryID = listDeliveries(t, config, ctx, "?failed=true&limit=1")[0].ID
+						require.Less(t, int(received.Load()), 20, "replays were never limited")
+					}
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 409, "WEBHOOK_REPLAY_LIMIT")
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/webhooks/deliveries/:id/replay for an unknown delivery should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return replay(t, config, ctx, uuid.New().String())
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorCode(t, resp, 404, "WEBHOOK_DELIVERY_NOT_FOUND")
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,204 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"api/internal/services"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// capturedWebhook is a delivery received by the test receiver
+type capturedWebhook struct {
+	Body      []byte
+	Signature string
+}
+
+// getWebhookTestCase tests webhook management and delivery of user events
+func getWebhookTestCase() TestCase {
+	var receiver *httptest.Server
+	var deliveries chan capturedWebhook
+	var webhookID, secret, createdEmail string
+
+	return TestCase{
+		Name: "Webhooks",
+		Steps: []TestStep{
+			{
+				Name: "POST /api/v1/admin/webhooks should create a webhook",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					_, token := CreateAdminUser(t, config)
+					ctx.AdminToken = token
+
+					deliveries = make(chan capturedWebhook, 10)
+					receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						body, _ := io.ReadAll(r.Body)
+						deliveries <- capturedWebhook{Body: body, Signature: r.Header.Get(services.WebhookSignatureHeader)}
+						w.WriteHeader(http.StatusOK)
+					}))
+					t.Cleanup(receiver.Close)
+
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/webhooks", dto.CreateWebhookRequest{
+						URL:    receiver.URL,
+						Events: []string{services.WebhookEventUserCreated},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var result dto.CreateWebhookResponse
+					ReadJsonResult(t, resp, &result)
+					RequireIsUUID(t, result.Webhook.ID)
+					require.NotEmpty(t, result.Secret)
+					require.Equal(t, []string{services.WebhookEventUserCreated}, result.Webhook.Events)
+					require.True(t, result.Webhook.IsActive)
+
+					webhookID = result.Webhook.ID
+					secret = result.Secret
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/webhooks with an unknown event should fail",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/webhooks", dto.CreateWebhookRequest{
+						URL:    receiver.URL,
+						Events: []string{"user.exploded"},
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 400)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/webhooks should list the webhook without its secret",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/webhooks", nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					body, err := io.ReadAll(resp.Body)
+					require.NoError(t, err)
+					require.Contains(t, string(body), webhookID)
+					require.NotContains(t, string(body), secret)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users should dispatch a signed user.created webhook",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					createdEmail = GenerateUniqueEmail()
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", dto.AdminRegisterUserRequest{
+						Email:    createdEmail,
+						Password: "Password123!",
+						Name:     GenerateUniqueName(),
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					var delivery capturedWebhook
+					select {
+					case delivery = <-deliveries:
+					case <-time.After(5 * time.Second):
+						t.Fatal("webhook was not delivered")
+					}
+
+					require.Equal(t, services.SignWebhookPayload(secret, delivery.Body), delivery.Signature)
+
+					var payload struct {
+						Event     string                     `json:"event"`
+						Data      dto.UserManagementResponse `json:"data"`
+						Timestamp time.Time                  `json:"timestamp"`
+					}
+					require.NoError(t, json.Unmarshal(delivery.Body, &payload))
+					require.Equal(t, services.WebhookEventUserCreated, payload.Event)
+					require.Equal(t, createdEmail, payload.Data.Email)
+					require.False(t, payload.Timestamp.IsZero())
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users/import should dispatch user.created for each imported user",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					createdEmail = GenerateUniqueEmail()
+					fixture := filepath.Join(t.TempDir(), "users.csv")
+					require.NoError(t, os.WriteFile(fixture, []byte("email,name\n"+createdEmail+",Imported User\n"), 0o600))
+					return importUsersCSV(t, config.App, ctx.AdminToken, fixture)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var delivery capturedWebhook
+					select {
+					case delivery = <-deliveries:
+					case <-time.After(5 * time.Second):
+						t.Fatal("webhook was not delivered")
+					}
+
+					var payload struct {
+						Event string                     `json:"event"`
+						Data  dto.UserManagementResponse `json:"data"`
+					}
+					require.NoError(t, json.Unmarshal(delivery.Body, &payload))
+					require.Equal(t, services.WebhookEventUserCreated, payload.Event)
+					require.Equal(t, createdEmail, payload.Data.Email)
+				},
+			},
+			{
+				Name: "PUT /api/v1/admin/webhooks/:id should deactivate the webhook",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					inactive := false
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/webhooks/"+webhookID, dto.UpdateWebhookRequest{
+						IsActive: &inactive,
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+
+					var result dto.WebhookResponse
+					ReadJsonResult(t, resp, &result)
+					require.False(t, result.IsActive)
+				},
+			},
+			{
+				Name: "POST /api/v1/admin/users should not notify an inactive webhook",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", dto.AdminRegisterUserRequest{
+						Email:    GenerateUniqueEmail(),
+						Password: "Password123!",
+						Name:     GenerateUniqueName(),
+					}, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+
+					services.WaitForWebhookDeliveries()
+					require.Empty(t, deliveries)
+				},
+			},
+			{
+				Name: "DELETE /api/v1/admin/webhooks/:id should delete the webhook",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "DELETE", "/api/v1/admin/webhooks/"+webhookID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireSuccessResponse(t, resp, 200)
+				},
+			},
+			{
+				Name: "GET /api/v1/admin/webhooks/:id after delete should return 404",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					return MakeAuthenticatedRequest(t, config.App, "GET", "/api/v1/admin/webhooks/"+webhookID, nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					RequireErrorResponse(t, resp, 404)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"api/internal/dto"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// getWildcardPermissionTestCase tests that roles opted in to wildcard
+// permissions grant every permission their patterns match
+func getWildcardPermissionTestCase() TestCase {
+	suffix := uuid.New().String()[:8]
+	resource := "wild" + suffix
+	wildcardRole := "wildcard-" + suffix
+	exactRole := "exact-" + suffix
+
+	checkPath := func(ctx *TestContext, permission string) string {
+		return "/api/v1/admin/users/" + ctx.CreatedUserID + "/permissions/" + permission
+	}
+
+	return TestCase{
+		Name: "Wildcard Permissions",
+		Steps: []TestStep{
+			{
+				Name: "Setup: Create roles sharing a wildcard permission",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					adminUser, token := CreateAdminUser(t, config)
+					ctx.AdminUser = adminUser
+					ctx.AdminToken = token
+
+					var roleIDs []string
+					for _, req := range []dto.CreateRoleRequest{
+						{Name: wildcardRole, UseWildcardPermissions: true},
+						{Name: exactRole},
+					} {
+						resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/roles", req, ctx.AdminToken)
+						require.NoError(t, err)
+						require.Equal(t, 201, resp.StatusCode)
+						result := RequireJSONResponse(t, resp)
+						require.Equal(t, req.UseWildcardPermissions, result["use_wildcard_permissions"])
+						roleIDs = append(roleIDs, result["id"].(string))
+					}
+
+					resp, err := MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions", dto.CreatePermissionRequest{
+						Name:     resource + ".*",
+						Resource: resource,
+						Action:   "*",
+					}, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 201, resp.StatusCode)
+					permissionID := RequireJSONResponse(t, resp)["id"].(string)
+
+					req := dto.PermissionRolesRequest{RoleIDs: roleIDs}
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/permissions/"+permissionID+"/assign-to-roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, float64(2), RequireJSONResponse(t, resp)["assigned"])
+				},
+			},
+			{
+				Name: "Setup: Create user with the exact-match role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					newUser := GenerateTestUser().ToAdminRegisterRequest([]string{exactRole})
+					return MakeAuthenticatedRequest(t, config.App, "POST", "/api/v1/admin/users", newUser, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 201, resp.StatusCode)
+					result := RequireJSONResponse(t, resp)
+					ctx.CreatedUserID = result["user"].(map[string]interface{})["id"].(string)
+				},
+			},
+			{
+				Name: "Roles without wildcard permissions should only match exactly",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", checkPath(ctx, resource+".*"), nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, true, RequireJSONResponse(t, resp)["has_permission"])
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", checkPath(ctx, resource+".read"), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, false, RequireJSONResponse(t, resp)["has_permission"])
+				},
+			},
+			{
+				Name: "Setup: Give user the wildcard role",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					req := dto.UpdateRolesRequest{Roles: []string{wildcardRole}}
+					return MakeAuthenticatedRequest(t, config.App, "PUT", "/api/v1/admin/users/"+ctx.CreatedUserID+"/roles", req, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+				},
+			},
+			{
+				Name: "Roles with wildcard permissions should match the pattern",
+				RequestFunc: func(t *testing.T, config *TestConfig, ctx *TestContext) (*http.Response, error) {
+					resp, err := MakeAuthenticatedRequest(t, config.App, "GET", checkPath(ctx, "other"+suffix+".read"), nil, ctx.AdminToken)
+					require.NoError(t, err)
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, false, RequireJSONResponse(t, resp)["has_permission"])
+
+					return MakeAuthenticatedRequest(t, config.App, "GET", checkPath(ctx, resource+".read"), nil, ctx.AdminToken)
+				},
+				ExpectFunc: func(t *testing.T, resp *http.Response, ctx *TestContext) {
+					require.Equal(t, 200, resp.StatusCode)
+					require.Equal(t, true, RequireJSONResponse(t, resp)["has_permission"])
+				},
+			},
+		},
+	}
+}
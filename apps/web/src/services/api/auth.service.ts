@@ -31,7 +31,7 @@ export class AuthService {
   }
 
   async updateProfile(data: Partial<User>): Promise<ApiResponse<User>> {
-    return apiClient.put<User>('/api/v1/protected/profile', data)
+    return apiClient.patch<User>('/api/v1/protected/profile', data)
   }
 
   setToken(token: string): void {
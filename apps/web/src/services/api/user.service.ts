@@ -7,7 +7,7 @@ export class UserService {
   }
 
   async updateProfile(data: UpdateUserRequest): Promise<ApiResponse<User>> {
-    return apiClient.put<User>('/api/v1/protected/profile', data)
+    return apiClient.patch<User>('/api/v1/protected/profile', data)
   }
 }
 